@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// interruptedFileName marks an output directory whose run was stopped by SIGINT or SIGTERM; it is
+// removed when a later run completes
+const interruptedFileName = "interrupted.yaml"
+
+// interruptedRun is the content of the interrupted-run marker
+type interruptedRun struct {
+	InterruptedAt string `yaml:"interruptedAt"`
+	// Stage is the pipeline stage that was running when the signal arrived
+	Stage           string `yaml:"stage"`
+	CompletedModels int    `yaml:"completedModels"`
+	TotalModels     int    `yaml:"totalModels"`
+}
+
+// exitInterruptedRun writes the interrupted-run marker and exits with exitInterrupted. The output
+// of completed models and the checkpoint are already on disk, so the run can be continued with
+// --resume.
+func exitInterruptedRun(outputDir, stage string, completed, total int) {
+	marker := interruptedRun{
+		InterruptedAt:   time.Now().UTC().Format(time.RFC3339),
+		Stage:           stage,
+		CompletedModels: completed,
+		TotalModels:     total,
+	}
+	if err := writeInterruptedMarker(outputDir, marker); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	log.Printf("Run interrupted during %s with %d of %d models extracted; rerun with --resume to continue", stage, completed, total)
+	os.Exit(exitInterrupted)
+}
+
+func writeInterruptedMarker(outputDir string, marker interruptedRun) error {
+	data, err := yaml.Marshal(&marker)
+	if err != nil {
+		return fmt.Errorf("failed to marshal interrupted-run marker: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, interruptedFileName), data, 0644); err != nil {
+		return fmt.Errorf("failed to write interrupted-run marker: %v", err)
+	}
+	return nil
+}
+
+// clearInterruptedMarker removes the marker of an earlier interrupted run once a run completes
+func clearInterruptedMarker(outputDir string) {
+	if err := os.Remove(filepath.Join(outputDir, interruptedFileName)); err != nil && !os.IsNotExist(err) {
+		log.Printf("Warning: Failed to remove interrupted-run marker: %v", err)
+	}
+}
@@ -1,35 +1,34 @@
 package main
 
 import (
-	"archive/tar"
-	"bytes"
-	"compress/gzip"
 	"context"
-	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
-	"io"
 	"log"
 	"os"
+	"os/signal"
 	"path/filepath"
-	"slices"
 	"strings"
-	"sync"
-	"time"
-
-	"github.com/containers/image/v5/docker"
-	blobinfocachememory "github.com/containers/image/v5/pkg/blobinfocache/memory"
-	containertypes "github.com/containers/image/v5/types"
-	"gopkg.in/yaml.v3"
+	"syscall"
 
 	"github.com/opendatahub-io/model-metadata-collection/internal/catalog"
 	"github.com/opendatahub-io/model-metadata-collection/internal/config"
 	"github.com/opendatahub-io/model-metadata-collection/internal/enrichment"
+	"github.com/opendatahub-io/model-metadata-collection/internal/extraction"
+	"github.com/opendatahub-io/model-metadata-collection/internal/gitcommit"
+	"github.com/opendatahub-io/model-metadata-collection/internal/httpclient"
 	"github.com/opendatahub-io/model-metadata-collection/internal/huggingface"
 	"github.com/opendatahub-io/model-metadata-collection/internal/metadata"
+	"github.com/opendatahub-io/model-metadata-collection/internal/metrics"
+	"github.com/opendatahub-io/model-metadata-collection/internal/notify"
+	"github.com/opendatahub-io/model-metadata-collection/internal/objectstore"
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+	"github.com/opendatahub-io/model-metadata-collection/internal/pyxis"
 	"github.com/opendatahub-io/model-metadata-collection/internal/registry"
-	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/internal/store"
+	"github.com/opendatahub-io/model-metadata-collection/internal/summary"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/pipeline"
 	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
 )
 
@@ -39,12 +38,77 @@ var (
 	inputDir                 = flag.String("input-dir", "input", "Base directory for supplemental input files (supplemental-catalog.yaml, models/vllm-config/)")
 	outputDir                = flag.String("output-dir", "output", "Output directory for extracted metadata")
 	catalogOutputPath        = flag.String("catalog-output", "data/models-catalog.yaml", "Path for the generated models catalog")
-	maxConcurrent            = flag.Int("max-concurrent", 5, "Maximum number of concurrent model processing jobs")
+	catalogFormat            = flag.String("catalog-format", catalog.CatalogFormatYAML, "Output format for the models catalog: yaml, json or ndjson")
+	catalogValidation        = flag.String("catalog-validation", catalog.CatalogValidationError, "Models catalog schema validation: error (fail the run), warn, or off")
+	includeLabels            = flag.String("include-labels", "", "Comma-separated labels; only models with at least one of them are written to the catalog")
+	excludeLabels            = flag.String("exclude-labels", "", "Comma-separated labels; models with any of them are left out of the catalog")
+	modelFilterPath          = flag.String("model-filter", "", "YAML allowlist/denylist of model names or artifact URI patterns applied as the final catalog filter (defaults to model-filter.yaml in the input directory when present)")
+	requireFields            = flag.String("require-fields", "", "Comma-separated catalog fields (e.g. name,provider,license,description) every model must have; catalog generation fails listing models that lack them")
+	skipURIDedup             = flag.Bool("skip-uri-dedup", false, "Keep catalog models with different names that share an artifact URI instead of merging them")
+	skipTagGrouping          = flag.Bool("skip-tag-grouping", false, "Keep catalog models whose images are different tags of the same repository instead of grouping them into one model")
+	internalRegistries       = flag.String("internal-registries", "", "Comma-separated registry hosts or domains (e.g. registry.example.corp) whose artifacts get the \"internal\" registrySource, in addition to cluster-local and private hosts")
+	excludeLowConfidence     = flag.Bool("exclude-low-confidence", false, "Leave values that provenance.yaml marks as guesses (inferred tasks, generated descriptions) out of the catalog")
+	logoMappingPath          = flag.String("logo-mapping", "", "Path to a provider logo mapping YAML file that overrides the built-in provider logos")
+	modelcardTemplatePath    = flag.String("modelcard-template", "", "Go text/template rendering the catalog readme of models without one, replacing the built-in template")
+	logoDirPath              = flag.String("logo-dir", "", "Directory of SVG, PNG or JPEG logos replacing built-in logos with the same name (e.g. ibm.png, catalog-model.png)")
+	publishS3                = flag.String("publish-s3", "", "After a run without degraded models, upload the catalog and per-model metadata to object storage: s3://bucket/prefix, gs://bucket/prefix or azblob://account/container/prefix")
+	metadataStore            = flag.String("store", "", "Also record extracted and enriched metadata in a relational store with per-run history: sqlite://path/to/metadata.db")
+	slackWebhook             = flag.String("slack-webhook", "", "Slack incoming webhook URL the run summary is posted to when the run ends (defaults to $SLACK_WEBHOOK_URL)")
+	gitCommit                = flag.Bool("git-commit", false, "After a successful run, commit changes under --git-paths to --git-branch and push it to --git-remote")
+	gitPaths                 = flag.String("git-paths", "data", "Comma-separated files and directories committed by --git-commit")
+	gitBranch                = flag.String("git-branch", "catalog-update", "Branch --git-commit creates or resets at the current HEAD without checking it out; must differ from the checked-out branch and --git-pr-base")
+	gitRemote                = flag.String("git-remote", "origin", "Remote --git-commit pushes the branch to with --force-with-lease (empty commits locally only)")
+	gitPRBase                = flag.String("git-pr-base", "", "Open a GitHub pull request from --git-branch into this branch (requires GITHUB_TOKEN)")
+	gitPRRepo                = flag.String("git-pr-repo", "", "GitHub repository (owner/name) of the pull request (defaults to the repository of --git-remote)")
+	catalogChunkSize         = flag.Int("catalog-chunk-size", 0, "Also split catalogs larger than this many bytes into numbered chunk files with an index (0 disables)")
+	catalogMarkdownPath      = flag.String("catalog-markdown", "data/CATALOG.md", "Markdown table of the catalog for review in pull requests; each catalog file gets its own section (empty disables)")
+	changelogOutputPath      = flag.String("changelog-output", "data/catalog-changelog.md", "Markdown changelog that receives an entry when the catalog changed since the previous run (empty disables)")
+	changelogSnapshotPath    = flag.String("changelog-snapshot", "", "Snapshot of the previous run's catalog used for the changelog (defaults to .<catalog name>-snapshot.yaml next to the catalog)")
+	catalogConfigMapPath     = flag.String("catalog-configmap", "", "Also write the catalog as Kubernetes ConfigMap manifests to this path (chunked to stay under the 1MB limit; apply with kubectl apply --server-side)")
+	configMapName            = flag.String("configmap-name", "model-catalog", "Name of the catalog ConfigMap; chunks are suffixed -1, -2, ...")
+	configMapNamespace       = flag.String("configmap-namespace", "", "Namespace set on the catalog ConfigMaps (omitted when empty)")
+	catalogSourcePath        = flag.String("catalog-source-output", "", "Also write the catalog ConfigMaps and an OpenDataHub ModelCatalogSource referencing them to this path, for a single oc apply --server-side (uses --configmap-name and --configmap-namespace)")
+	catalogSourceName        = flag.String("catalog-source-name", catalog.DefaultCatalogSourceName, "Name of the ModelCatalogSource written to --catalog-source-output")
+	catalogSourceLabels      = flag.String("catalog-source-labels", "", "Comma-separated source labels of the ModelCatalogSource")
+	kserveOutputDir          = flag.String("kserve-output-dir", "", "Also write a ServingRuntime and InferenceService manifest per model to this directory, deploying its OCI modelcar with KServe")
+	kserveRuntimeImage       = flag.String("kserve-runtime-image", catalog.DefaultKServeRuntimeImage, "vLLM image of the ServingRuntimes written to --kserve-output-dir")
+	kserveNamespace          = flag.String("kserve-namespace", "", "Namespace set on the KServe manifests (omitted when empty)")
+	catalogPush              = flag.String("catalog-push", "", "Also push the catalog and per-model readmes to this registry reference as an OCI artifact, e.g. quay.io/org/model-catalog:latest (credentials from the standard container auth files)")
+	embeddingsOutputPath     = flag.String("embeddings-output", "", "Also write embeddings of each model's name, description and readme to this JSON file for semantic search (requires --embeddings-url and --embeddings-model)")
+	embeddingsURL            = flag.String("embeddings-url", "", "OpenAI-compatible embeddings endpoint, e.g. http://localhost:11434/v1 or https://api.openai.com/v1 (API key from $EMBEDDINGS_API_KEY)")
+	embeddingsModel          = flag.String("embeddings-model", "", "Embedding model requested from --embeddings-url, e.g. nomic-embed-text or text-embedding-3-small")
+	searchIndexOutputPath    = flag.String("search-index-output", "", "Also write a full-text search index over each model's name, description, tasks and readme to this JSON file")
+	maxFailures              = flag.Int("max-failures", -1, "Fail the run before catalog generation when more than this many models end up with skeleton or no metadata (negative disables)")
+	maxFailureRate           = flag.Float64("max-failure-rate", 1, "Fail the run before catalog generation when a larger share (0-1) of the models end up with skeleton or no metadata (1 disables)")
+	resume                   = flag.Bool("resume", false, "Resume an interrupted run: skip models that checkpoint.yaml in the output directory records as already extracted or enriched")
+	retryFailed              = flag.Bool("retry-failed", false, "Reprocess only the models listed in errors.yaml of the previous run, keeping the output of the others and regenerating the catalog")
+	modelcardAnnotations     = flag.String("modelcard-annotations", extraction.DefaultModelcardAnnotation.String(), "Comma-separated layer annotations marking modelcard layers, as key=value or a bare key accepting any value; a layer carrying any of them is read as the modelcard")
+	verifyArtifacts          = flag.Bool("verify-artifacts", false, "Look up the cosign signature, attestation and SBOM of each image and record a verification block on its artifact")
+	verificationKeys         = flag.String("verification-keys", "", "Comma-separated PEM public keys (e.g. cosign.pub) image signatures are verified against; without keys signatureVerified stays false")
+	vulnScanner              = flag.String("vuln-scanner", "", "Run this vulnerability scanner (trivy) on each image and record CVE counts by severity on its artifact")
+	vulnReports              = flag.String("vuln-reports", "", "Directory of Trivy or Clair JSON reports to read CVE counts from instead of running a scanner, matched to images by reference or digest")
+	vulnSeverityThreshold    = flag.String("vuln-severity-threshold", "", "Exclude models from the catalog when an artifact has vulnerabilities of this severity or higher: critical, high, medium, low or unknown")
+	redHatRegistryCreds      = flag.String("redhat-registry-credentials", "", "YAML file with the username and token of a registry.redhat.io service account, used instead of the container auth files for that registry (defaults to $REDHAT_REGISTRY_USERNAME and $REDHAT_REGISTRY_TOKEN)")
+	keepIntermediate         = flag.Bool("keep-intermediate", false, "Keep each model's raw manifest, config blob and modelcard layer in <output-dir>/<model>/debug to reproduce parsing failures offline")
+	maxConcurrent            = flag.Int("max-concurrent", 5, "Maximum number of models extracted or enriched concurrently")
 	skipHuggingFace          = flag.Bool("skip-huggingface", false, "Skip HuggingFace collection processing and enrichment")
+	redHatCatalog            = flag.Bool("redhat-catalog", false, "Enrich Red Hat registry images with their certified-image record from the Red Hat container catalog (Pyxis): freshness grade, advisories and repository description")
+	pyxisURL                 = flag.String("pyxis-url", pyxis.DefaultURL, "Pyxis API queried by --redhat-catalog; an API key is read from $PYXIS_API_KEY when set")
 	skipEnrichment           = flag.Bool("skip-enrichment", false, "Skip metadata enrichment from HuggingFace")
+	hfTimeout                = flag.Duration("hf-timeout", huggingface.DefaultTimeout, "Timeout of each HuggingFace request, including reading the response")
+	hfRetries                = flag.Int("hf-retries", huggingface.DefaultRetries, "Retries of HuggingFace requests that fail with a network error, 429 or a 5xx status")
+	httpProxy                = flag.String("http-proxy", "", "Proxy URL for HuggingFace, registry, GitHub and object storage requests (defaults to HTTPS_PROXY/HTTP_PROXY)")
+	httpCACert               = flag.String("http-ca-cert", "", "PEM file of CA certificates trusted for HTTPS requests in addition to the system ones")
+	httpInsecure             = flag.Bool("http-insecure", false, "Skip TLS certificate verification of HTTPS requests (testing only)")
+	userAgent                = flag.String("user-agent", httpclient.DefaultUserAgent, "User-Agent header of outgoing HTTP requests")
+	hfSnapshotDir            = flag.String("hf-snapshot-dir", "", "Directory of pre-downloaded HuggingFace model files (README.md, config.json) used instead of the network")
+	matchThreshold           = flag.Float64("match-threshold", enrichment.DefaultMatchThreshold, "Minimum similarity score (0-1) for matching registry models to HuggingFace models")
+	skipReadme               = flag.Bool("skip-readme", false, "Leave the readme field of models empty instead of filling it from modelcards")
+	readmeMaxSize            = flag.Int("readme-max-size", metadata.DefaultMaxReadmeSize, "Maximum readme size in bytes; longer modelcards are truncated at a line break (0 disables the cap)")
 	skipCatalog              = flag.Bool("skip-catalog", false, "Skip catalog generation")
 	staticCatalogFiles       = flag.String("static-catalog-files", "", "Comma-separated list of static catalog files to include")
 	skipDefaultStaticCatalog = flag.Bool("skip-default-static-catalog", false, "Skip processing the default supplemental-catalog.yaml from the input directory")
+	descriptionOverrides     = flag.String("description-overrides", "", "YAML file of descriptions and localized descriptions (description_i18n) by model name (defaults to description-overrides.yaml in the input directory when present)")
 	mcpIndexPath             = flag.String("mcp-index", "", "Path to MCP servers index YAML file (if set, generates MCP catalog)")
 	mcpCatalogOutputPath     = flag.String("mcp-catalog-output", "data/redhat-mcp-servers-catalog.yaml", "Path for the generated MCP servers catalog")
 	skipMCPEnrichment        = flag.Bool("skip-mcp-enrichment", false, "Skip MCP server OCI image enrichment (architectures, timestamps)")
@@ -52,15 +116,21 @@ var (
 	agentCatalogOutputPath   = flag.String("agent-catalog-output", "data/redhat-agents-catalog.yaml", "Path for the generated agents catalog")
 	agentBranch              = flag.String("agent-branch", "", "Override the GitHub branch for agent metadata fetching (defaults to branch in index file)")
 	skipAgentEnrichment      = flag.Bool("skip-agent-enrichment", false, "Skip fetching agent metadata and READMEs from GitHub")
+	configPath               = flag.String("config", "", "Pipeline YAML file setting any of these options by flag name, plus credential references; command line flags override its values")
 	help                     = flag.Bool("help", false, "Show help message")
 )
 
-// ModelResult represents the result of processing a single model
-type ModelResult struct {
-	Ref            string
-	ModelCardFound bool
-	Metadata       types.ModelMetadata
-}
+// Exit codes of a model extraction run, so CI can tell soft failures from hard ones. Other fatal
+// errors exit with 1 (log.Fatalf).
+const (
+	// exitConfigError reports invalid options or configuration files, like the flag package does
+	// for unknown flags
+	exitConfigError = 2
+	// exitDegraded reports a run that completed, but with skeleton models or failed enrichment
+	exitDegraded = 3
+	// exitInterrupted reports a run stopped by SIGINT or SIGTERM, as shells do for SIGINT
+	exitInterrupted = 130
+)
 
 // loadDotEnv reads a .env file and sets any unset environment variables from it.
 // Variables already present in the environment take precedence over .env values.
@@ -102,6 +172,47 @@ func loadDotEnv(path string) {
 func main() {
 	loadDotEnv(".env")
 
+	// Subcommands take their own flags; everything else runs the extraction pipeline
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "publish":
+			if err := runPublish(os.Args[2:]); err != nil {
+				log.Fatalf("Publish failed: %v", err)
+			}
+			return
+		case "preview":
+			if err := runPreview(os.Args[2:]); err != nil {
+				log.Fatalf("Preview failed: %v", err)
+			}
+			return
+		case "serve":
+			if err := runServe(os.Args[2:]); err != nil {
+				log.Fatalf("Serve failed: %v", err)
+			}
+			return
+		case "validate":
+			if err := runValidate(os.Args[2:], os.Stdout); err != nil {
+				log.Fatalf("Validation failed: %v", err)
+			}
+			return
+		case "query":
+			if err := runQuery(os.Args[2:], os.Stdout); err != nil {
+				log.Fatalf("Query failed: %v", err)
+			}
+			return
+		case "merge":
+			if err := runMerge(os.Args[2:]); err != nil {
+				log.Fatalf("Merge failed: %v", err)
+			}
+			return
+		case "repack":
+			if err := runRepack(os.Args[2:]); err != nil {
+				log.Fatalf("Repack failed: %v", err)
+			}
+			return
+		}
+	}
+
 	flag.Parse()
 
 	if *help {
@@ -109,20 +220,169 @@ func main() {
 		return
 	}
 
+	if *configPath != "" {
+		if err := applyPipelineConfig(*configPath); err != nil {
+			configFatalf("Invalid --config: %v", err)
+		}
+	}
+
+	if *matchThreshold <= 0 || *matchThreshold > 1 {
+		configFatalf("Invalid --match-threshold %.2f: must be greater than 0 and at most 1", *matchThreshold)
+	}
+
+	if *hfTimeout <= 0 {
+		configFatalf("Invalid --hf-timeout %s: must be positive", *hfTimeout)
+	}
+	if *hfRetries < 0 {
+		configFatalf("Invalid --hf-retries %d: must not be negative", *hfRetries)
+	}
+
+	if err := httpclient.Configure(httpclient.Options{
+		Proxy:              *httpProxy,
+		CACertFile:         *httpCACert,
+		InsecureSkipVerify: *httpInsecure,
+		UserAgent:          *userAgent,
+	}); err != nil {
+		configFatalf("Invalid HTTP client options: %v", err)
+	}
+
+	// Service-account credentials are passed to every step that reads a registry, so the pipeline
+	// needs no docker login
+	registryAuth, err := loadRegistryAuth(*redHatRegistryCreds)
+	if err != nil {
+		configFatalf("Invalid registry.redhat.io credentials: %v", err)
+	}
+
+	if *maxFailureRate < 0 || *maxFailureRate > 1 {
+		configFatalf("Invalid --max-failure-rate %.2f: must be between 0 and 1", *maxFailureRate)
+	}
+
+	if *readmeMaxSize < 0 {
+		configFatalf("Invalid --readme-max-size %d: must not be negative", *readmeMaxSize)
+	}
+
+	if *catalogChunkSize < 0 {
+		configFatalf("Invalid --catalog-chunk-size %d: must not be negative", *catalogChunkSize)
+	}
+
+	modelcardAnnotationList, err := pipeline.ParseLayerAnnotations(*modelcardAnnotations)
+	if err != nil {
+		configFatalf("Invalid --modelcard-annotations: %v", err)
+	}
+
+	if *verificationKeys != "" && !*verifyArtifacts {
+		configFatalf("--verification-keys requires --verify-artifacts")
+	}
+
+	// Resolve the object storage destination up front so missing credentials fail before extraction
+	var publishDestination *objectstore.Destination
+	if *publishS3 != "" {
+		dest, err := objectstore.ParseDestination(*publishS3)
+		if err != nil {
+			configFatalf("Invalid --publish-s3: %v", err)
+		}
+		publishDestination = dest
+	}
+
+	if *gitPRBase != "" && *gitRemote == "" {
+		configFatalf("--git-pr-base requires --git-remote")
+	}
+	if *gitCommit && *gitBranch == *gitPRBase {
+		configFatalf("--git-branch must differ from --git-pr-base")
+	}
+
+	if *metadataStore != "" {
+		if err := store.ValidateURL(*metadataStore); err != nil {
+			configFatalf("Invalid --store: %v", err)
+		}
+	}
+
+	// Use the extension matching the format unless an explicit catalog path was given
+	if !isFlagSet("catalog-output") {
+		*catalogOutputPath = catalog.CatalogPathForFormat(*catalogOutputPath, *catalogFormat)
+	}
+
+	// The recorder receives the catalog changes, so it is created with the pipeline. Keys, reports,
+	// logos, the model filter and description overrides are loaded here so invalid configuration
+	// fails before extraction.
+	recorder := summary.NewRecorder()
+	models, err := pipeline.New(pipelineOptions(modelcardAnnotationList, registryAuth, recorder))
+	if err != nil {
+		configFatalf("Invalid configuration: %v", err)
+	}
+
 	if os.Getenv("HF_TOKEN") != "" {
 		log.Println("HuggingFace token detected: authenticated requests enabled")
 	}
 
 	log.Printf("Starting model metadata collection with configuration:")
+	log.Printf("  Config: %s", *configPath)
 	log.Printf("  Models Index: %s", *modelsIndexPath)
 	log.Printf("  Output Directory: %s", *outputDir)
 	log.Printf("  Catalog Output: %s", *catalogOutputPath)
+	log.Printf("  Catalog Format: %s", *catalogFormat)
+	log.Printf("  Catalog Validation: %s", *catalogValidation)
+	log.Printf("  Include Labels: %s", *includeLabels)
+	log.Printf("  Exclude Labels: %s", *excludeLabels)
+	log.Printf("  Model Filter: %s", *modelFilterPath)
+	log.Printf("  Require Fields: %s", *requireFields)
+	log.Printf("  Skip URI Dedup: %v", *skipURIDedup)
+	log.Printf("  Skip Tag Grouping: %v", *skipTagGrouping)
+	log.Printf("  Internal Registries: %s", *internalRegistries)
+	log.Printf("  Exclude Low-Confidence Values: %v", *excludeLowConfidence)
+	log.Printf("  Modelcard Template: %s", *modelcardTemplatePath)
+	log.Printf("  Logo Mapping: %s", *logoMappingPath)
+	log.Printf("  Logo Directory: %s", *logoDirPath)
+	log.Printf("  Publish To Object Storage: %s", *publishS3)
+	log.Printf("  Metadata Store: %s", *metadataStore)
+	log.Printf("  Slack Notifications: %v", slackWebhookURL() != "")
+	log.Printf("  Git Commit: %v (branch %s, remote %s, PR base %s)", *gitCommit, *gitBranch, *gitRemote, *gitPRBase)
+	log.Printf("  Catalog Chunk Size: %d", *catalogChunkSize)
+	log.Printf("  Catalog Markdown: %s", *catalogMarkdownPath)
+	log.Printf("  Changelog Output: %s", *changelogOutputPath)
+	log.Printf("  Changelog Snapshot: %s", *changelogSnapshotPath)
+	log.Printf("  Catalog ConfigMap: %s", *catalogConfigMapPath)
+	log.Printf("  ConfigMap Name: %s", *configMapName)
+	log.Printf("  ConfigMap Namespace: %s", *configMapNamespace)
+	log.Printf("  Catalog Source Output: %s", *catalogSourcePath)
+	log.Printf("  Catalog Source Name: %s", *catalogSourceName)
+	log.Printf("  Catalog Source Labels: %s", *catalogSourceLabels)
+	log.Printf("  KServe Output Dir: %s", *kserveOutputDir)
+	log.Printf("  KServe Runtime Image: %s", *kserveRuntimeImage)
+	log.Printf("  KServe Namespace: %s", *kserveNamespace)
+	log.Printf("  Catalog Push: %s", *catalogPush)
+	log.Printf("  Embeddings Output: %s (model %s at %s)", *embeddingsOutputPath, *embeddingsModel, *embeddingsURL)
+	log.Printf("  Search Index Output: %s", *searchIndexOutputPath)
+	log.Printf("  Max Failures: %d", *maxFailures)
+	log.Printf("  Max Failure Rate: %.2f", *maxFailureRate)
+	log.Printf("  Resume: %v", *resume)
+	log.Printf("  Retry Failed: %v", *retryFailed)
+	log.Printf("  Modelcard Annotations: %s", *modelcardAnnotations)
+	log.Printf("  Keep Intermediate: %v", *keepIntermediate)
+	log.Printf("  Verify Artifacts: %v", *verifyArtifacts)
+	log.Printf("  Verification Keys: %s", *verificationKeys)
+	log.Printf("  Red Hat Registry Credentials: %s", *redHatRegistryCreds)
+	log.Printf("  Vulnerability Scanner: %s", *vulnScanner)
+	log.Printf("  Vulnerability Reports: %s", *vulnReports)
+	log.Printf("  Vulnerability Severity Threshold: %s", *vulnSeverityThreshold)
 	log.Printf("  Max Concurrent: %d", *maxConcurrent)
 	log.Printf("  Skip HuggingFace: %v", *skipHuggingFace)
 	log.Printf("  Skip Enrichment: %v", *skipEnrichment)
+	log.Printf("  Red Hat Catalog: %v (%s)", *redHatCatalog, *pyxisURL)
+	log.Printf("  Match Threshold: %.2f", *matchThreshold)
+	log.Printf("  HuggingFace Timeout: %s", *hfTimeout)
+	log.Printf("  HuggingFace Retries: %d", *hfRetries)
+	log.Printf("  HuggingFace Snapshot Directory: %s", *hfSnapshotDir)
+	log.Printf("  HTTP Proxy: %s", *httpProxy)
+	log.Printf("  HTTP CA Certificates: %s", *httpCACert)
+	log.Printf("  HTTP Insecure: %v", *httpInsecure)
+	log.Printf("  User Agent: %s", *userAgent)
+	log.Printf("  Skip Readme: %v", *skipReadme)
+	log.Printf("  Readme Max Size: %d", *readmeMaxSize)
 	log.Printf("  Skip Catalog: %v", *skipCatalog)
 	log.Printf("  Static Catalog Files: %s", *staticCatalogFiles)
 	log.Printf("  Skip Default Static Catalog: %v", *skipDefaultStaticCatalog)
+	log.Printf("  Description Overrides: %s", *descriptionOverrides)
 	log.Printf("  MCP Index: %s", *mcpIndexPath)
 	log.Printf("  MCP Catalog Output: %s", *mcpCatalogOutputPath)
 	log.Printf("  Skip MCP Enrichment: %v", *skipMCPEnrichment)
@@ -131,26 +391,23 @@ func main() {
 	log.Printf("  Agent Branch Override: %s", *agentBranch)
 	log.Printf("  Skip Agent Enrichment: %v", *skipAgentEnrichment)
 
+	// SIGINT and SIGTERM cancel every registry, HuggingFace and GitHub request in flight. Model
+	// processing stops after the models in flight, keeping the output of completed models so the
+	// run can be resumed.
+	ctx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+
 	// Determine if model processing should run.
 	// Skip when all model pipeline steps are disabled, regardless of MCP processing.
 	skipModels := *skipHuggingFace && *skipEnrichment && *skipCatalog
 
 	if !skipModels {
-		// Ensure output directory exists
-		if err := os.MkdirAll(*outputDir, 0755); err != nil {
-			log.Fatalf("Failed to create output directory: %v", err)
-		}
-
-		// Ensure catalog output directory exists
-		catalogDir := filepath.Dir(*catalogOutputPath)
-		if err := os.MkdirAll(catalogDir, 0755); err != nil {
-			log.Fatalf("Failed to create catalog output directory: %v", err)
-		}
-
 		// Process HuggingFace collections (unless skipped)
 		if !*skipHuggingFace {
 			log.Println("Processing HuggingFace collections...")
-			err := huggingface.ProcessCollections()
+			endStage := recorder.StartStage("huggingface-collections")
+			err := models.ProcessCollections(ctx)
+			endStage()
 			if err != nil {
 				log.Printf("Warning: Failed to process HuggingFace collections: %v", err)
 				log.Println("Falling back to existing models-index.yaml")
@@ -158,89 +415,91 @@ func main() {
 		}
 
 		// Load models from configuration file
-		modelEntries, err := loadModelsWithMetadata(*modelsIndexPath)
+		modelEntries, err := pipeline.LoadModels(*modelsIndexPath)
 		if err != nil {
-			log.Fatalf("Failed to load models: %v", err)
+			runFatalf(recorder, "Failed to load models: %v", err)
 		}
 
-		log.Printf("Processing %d models...", len(modelEntries))
-
-		// Process models in parallel
-		modelResults := processModelsInParallelWithMetadata(modelEntries, *maxConcurrent)
-
-		// Generate manifests.yaml
-		err = generateManifestsYAML(modelResults, *outputDir)
+		endStage := recorder.StartStage("model-extraction")
+		modelResults, err := models.Extract(ctx, modelEntries)
+		endStage()
+		if ctx.Err() != nil {
+			exitInterruptedRun(*outputDir, "model-extraction", len(modelResults), len(modelEntries))
+		}
 		if err != nil {
-			log.Fatalf("Failed to generate manifests.yaml: %v", err)
+			runFatalf(recorder, "Model extraction failed: %v", err)
 		}
 
 		log.Printf("All manifest processing completed")
 
+		var processedModelRefs []string
+		for _, entry := range modelEntries {
+			processedModelRefs = append(processedModelRefs, entry.URI)
+		}
+
 		// Enrich registry model metadata with HuggingFace data (unless skipped)
 		// This happens AFTER model processing to enrich the extracted metadata
 		if !*skipEnrichment {
 			log.Println("Enriching extracted metadata with HuggingFace data...")
-
-			// Determine HuggingFace index file to use
-			// Prefer merged index file to ensure all models from all collections are available for matching
-			hfIndexFile := huggingface.MergedFilePath()
-			if _, err := os.Stat(hfIndexFile); err != nil {
-				if !errors.Is(err, os.ErrNotExist) {
-					log.Fatalf("Failed to access merged index file %s: %v", hfIndexFile, err)
-				}
-				// Fallback to latest version-specific file if merged doesn't exist
-				log.Printf("Warning: Merged index file not found, falling back to latest version file")
-				hfIndexFile, err = huggingface.GetLatestVersionIndexFile()
-				if err != nil {
-					log.Fatalf("Could not find any HuggingFace index file: %v", err)
-				}
+			endStage := recorder.StartStage("enrichment")
+			err := models.Enrich(ctx)
+			endStage()
+			if ctx.Err() != nil {
+				exitInterruptedRun(*outputDir, "enrichment", len(modelResults), len(modelEntries))
 			}
+			recordDegraded(recorder, err, "Enrichment failed")
+		} else {
+			// Record which source supplied each metadata field for auditing
+			models.WriteProvenanceReports(processedModelRefs)
+		}
 
-			log.Printf("Using HuggingFace index file: %s", hfIndexFile)
-			err := enrichment.EnrichMetadataFromHuggingFace(hfIndexFile, *modelsIndexPath, *outputDir, filepath.Join(*inputDir, "models", "vllm-config"))
-			if err != nil {
-				log.Printf("Warning: Failed to enrich metadata: %v", err)
+		if *metadataStore != "" {
+			if err := recordMetadataStore(*metadataStore, outputfs.Dir(*outputDir), processedModelRefs); err != nil {
+				log.Printf("Warning: Failed to record metadata in %s: %v", *metadataStore, err)
+				recorder.RecordDegraded(fmt.Sprintf("metadata store not updated: %v", err))
 			}
+		}
 
-			// Update all existing models with OCI artifact metadata
-			err = enrichment.UpdateAllModelsWithOCIArtifacts(*modelsIndexPath, *outputDir)
-			if err != nil {
-				log.Printf("Warning: Failed to update OCI artifacts: %v", err)
+		var resultRefs []string
+		var modelcardsFound []bool
+		for _, result := range modelResults {
+			resultRefs = append(resultRefs, result.Ref)
+			modelcardsFound = append(modelcardsFound, result.ModelCardFound)
+		}
+		recorder.RecordModels(resultRefs, modelcardsFound, *outputDir)
+		recorder.RecordEnrichment(processedModelRefs, *outputDir)
+
+		// A broken registry or HuggingFace outage must not silently produce a mostly-empty catalog
+		if err := recorder.Models().CheckFailureThreshold(*maxFailures, *maxFailureRate); err != nil {
+			if writeErr := recorder.Write(*outputDir); writeErr != nil {
+				log.Printf("Warning: %v", writeErr)
 			}
+			runFatalf(recorder, "Too many failed models: %v", err)
 		}
 
 		// Create the models catalog (unless skipped)
 		if !*skipCatalog {
-			// Load static catalogs
-			staticCatalogPaths := getStaticCatalogPaths(*staticCatalogFiles, *skipDefaultStaticCatalog)
-
-			var staticModels []types.CatalogMetadata
-			if len(staticCatalogPaths) > 0 {
-				log.Printf("Loading static catalogs...")
-				loadedStaticModels, err := catalog.LoadStaticCatalogs(staticCatalogPaths)
+			endStage := recorder.StartStage("catalog")
+			err := models.BuildCatalog(ctx, processedModelRefs)
+			endStage()
+			recordDegraded(recorder, err, "Failed to create models catalog")
+
+			// Like --git-commit, a degraded catalog is not published over the last good one
+			if publishDestination != nil && len(recorder.Degraded()) > 0 {
+				log.Printf("Skipping --publish-s3: the run completed with degraded models")
+			} else if publishDestination != nil {
+				log.Printf("Publishing catalog to %s...", publishDestination.URI)
+				endStage := recorder.StartStage("publish")
+				var modelDirs []string
+				for _, ref := range processedModelRefs {
+					modelDirs = append(modelDirs, utils.SanitizeManifestRef(ref))
+				}
+				uploaded, err := objectstore.PublishCatalog(ctx, publishDestination, *catalogOutputPath, *outputDir, modelDirs)
 				if err != nil {
-					log.Printf("Warning: Failed to load static catalogs: %v", err)
-					staticModels = []types.CatalogMetadata{} // Continue with empty static models
-				} else {
-					staticModels = loadedStaticModels
+					runFatalf(recorder, "Failed to publish catalog to %s: %v", publishDestination.URI, err)
 				}
-			} else {
-				log.Printf("No static catalog files to process")
-				staticModels = []types.CatalogMetadata{}
-			}
-
-			// Create the models catalog with both dynamic and static models
-			log.Printf("Creating models catalog...")
-
-			// Extract model references from the entries that were processed in this run
-			var processedModelRefs []string
-			for _, entry := range modelEntries {
-				processedModelRefs = append(processedModelRefs, entry.URI)
-			}
-
-			err = catalog.CreateModelsCatalogWithStaticFromResults(*outputDir, *catalogOutputPath, processedModelRefs, staticModels)
-			if err != nil {
-				log.Fatalf("Failed to create models catalog: %v", err)
+				endStage()
+				log.Printf("Uploaded %d objects to %s", uploaded, publishDestination.URI)
 			}
 		}
 	} else {
@@ -249,11 +508,12 @@ func main() {
 
 	// Process MCP servers catalog (if index path is provided).
 	if *mcpIndexPath != "" {
+		endStage := recorder.StartStage("mcp-catalog")
 		// Step 1: Enrich MCP servers from OCI registry (unless skipped)
 		if !*skipMCPEnrichment {
 			log.Printf("Enriching MCP servers from OCI registry...")
-			if err := catalog.EnrichMCPServersFromRegistry(*mcpIndexPath); err != nil {
-				log.Fatalf("MCP server enrichment failed: %v", err)
+			if err := catalog.EnrichMCPServersFromRegistry(ctx, *mcpIndexPath, registryAuth); err != nil {
+				runFatalf(recorder, "MCP server enrichment failed: %v", err)
 			}
 		}
 
@@ -261,16 +521,55 @@ func main() {
 		log.Printf("Processing MCP servers catalog from: %s", *mcpIndexPath)
 		err := catalog.CreateMCPServersCatalog(*mcpIndexPath, *mcpCatalogOutputPath)
 		if err != nil {
-			log.Fatalf("Failed to create MCP servers catalog: %v", err)
+			runFatalf(recorder, "Failed to create MCP servers catalog: %v", err)
 		}
+		endStage()
 	}
 
 	// Process agents catalog (if index path is provided).
 	if *agentIndexPath != "" {
 		log.Printf("Processing agents catalog from: %s", *agentIndexPath)
-		if err := catalog.CreateAgentsCatalog(*agentIndexPath, *agentCatalogOutputPath, *agentBranch, *skipAgentEnrichment); err != nil {
-			log.Fatalf("Failed to create agents catalog: %v", err)
+		endStage := recorder.StartStage("agents-catalog")
+		if err := catalog.CreateAgentsCatalog(ctx, *agentIndexPath, *agentCatalogOutputPath, *agentBranch, *skipAgentEnrichment); err != nil {
+			runFatalf(recorder, "Failed to create agents catalog: %v", err)
+		}
+		endStage()
+	}
+
+	// The summary tracks metadata coverage, so it is only written when models were processed
+	if !skipModels {
+		if err := recorder.Write(*outputDir); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+		clearInterruptedMarker(*outputDir)
+		recordRunMetrics(recorder.Summary(), *catalogOutputPath, *skipCatalog)
+		if err := metrics.Default.WriteSnapshot(filepath.Join(*outputDir, metrics.SnapshotFileName)); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+
+	status := notify.StatusSucceeded
+	if len(recorder.Degraded()) > 0 {
+		status = notify.StatusDegraded
+	}
+
+	if *gitCommit {
+		if status == notify.StatusSucceeded {
+			if err := commitDataChanges(ctx, recorder.Summary()); err != nil {
+				runFatalf(recorder, "Failed to commit data changes: %v", err)
+			}
+		} else {
+			log.Printf("Skipping --git-commit: the run completed with degraded models")
+		}
+	}
+	notifySlack(recorder.Summary(), status, "")
+
+	if degraded := recorder.Degraded(); len(degraded) > 0 {
+		log.Println("Model metadata collection completed with degraded models:")
+		for _, reason := range degraded {
+			log.Printf("  - %s", reason)
 		}
+		os.Exit(exitDegraded)
 	}
 
 	log.Println("Model metadata collection completed successfully!")
@@ -283,6 +582,18 @@ func printHelp() {
 	fmt.Println("")
 	fmt.Println("Usage:")
 	fmt.Printf("  %s [options]\n", os.Args[0])
+	fmt.Printf("  %s publish --to <reference> [options]\n", os.Args[0])
+	fmt.Printf("  %s publish --model-registry-url <url> [options]\n", os.Args[0])
+	fmt.Printf("  %s publish --mlflow-url <url> [options]\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("Subcommands:")
+	fmt.Println("  publish    Push the generated catalog and model readmes to a registry as an OCI artifact, or its models to a Kubeflow Model Registry or MLflow")
+	fmt.Println("  preview    Render the generated catalog into a static HTML page for review")
+	fmt.Println("  validate   Check static catalog files and report every problem with its line number")
+	fmt.Println("  query      Print the catalog models matching a filter as a table or JSON")
+	fmt.Println("  merge      Combine generated catalogs into one, deduplicating and validating the models")
+	fmt.Println("  serve      Keep running and refresh the catalog periodically, re-extracting changed models")
+	fmt.Println("  repack     Add a modelcard layer built from the catalog to a modelcar image that shipped without one")
 	fmt.Println("")
 	fmt.Println("Options:")
 	flag.PrintDefaults()
@@ -294,9 +605,118 @@ func printHelp() {
 	fmt.Println("  # Custom input and output paths")
 	fmt.Printf("  %s --input custom-models.yaml --output-dir /tmp/output --catalog-output /tmp/catalog.yaml\n", os.Args[0])
 	fmt.Println("")
+	fmt.Println("  # Write the models catalog as JSON (data/models-catalog.json)")
+	fmt.Printf("  %s --catalog-format json\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Report catalog schema violations without failing the run")
+	fmt.Printf("  %s --catalog-validation warn\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Publish only validated models, keeping all extracted metadata in the output directory")
+	fmt.Printf("  %s --include-labels validated --exclude-labels experimental\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Override or extend the built-in provider logos")
+	fmt.Printf("  %s --logo-mapping input/logo-mapping.yaml\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Replace built-in logos with PNG/JPEG/SVG files of the same name")
+	fmt.Printf("  %s --logo-dir input/logos\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Upload the catalog and per-model metadata to S3 (credentials from AWS_* environment variables)")
+	fmt.Printf("  %s --publish-s3 s3://model-catalogs/prod\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Post the run summary to the team's Slack channel")
+	fmt.Printf("  %s --slack-webhook https://hooks.slack.com/services/T000/B000/XXXX\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Commit updated data files to a branch and open a pull request against main")
+	fmt.Printf("  %s --git-commit --git-branch catalog-update --git-pr-base main\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Record metadata and its history across runs in SQLite")
+	fmt.Printf("  %s --store sqlite://data/metadata.db\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Write the catalog changelog elsewhere, or pass an empty value to disable it")
+	fmt.Printf("  %s --changelog-output release/CHANGELOG.md\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Also split catalogs over 5MB into models-catalog-001.yaml, ... with models-catalog-index.yaml")
+	fmt.Printf("  %s --catalog-chunk-size 5000000\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Also write the catalog as ConfigMaps for kubectl apply --server-side")
+	fmt.Printf("  %s --catalog-configmap data/models-catalog-configmap.yaml --configmap-namespace rhoai-model-registries\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Also write a ModelCatalogSource with its catalog ConfigMaps for a single oc apply --server-side")
+	fmt.Printf("  %s --catalog-source-output data/model-catalog-source.yaml --configmap-namespace rhoai-model-registries\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Also write KServe manifests deploying each model with vLLM")
+	fmt.Printf("  %s --kserve-output-dir data/kserve --kserve-namespace models\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Also push the catalog and readmes to a registry as an OCI artifact")
+	fmt.Printf("  %s --catalog-push quay.io/opendatahub/model-catalog:latest\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Also write embeddings of the models for semantic search, computed by a local Ollama")
+	fmt.Printf("  %s --embeddings-output data/models-catalog-embeddings.json --embeddings-url http://localhost:11434/v1 --embeddings-model nomic-embed-text\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Also write a full-text search index of the models for lightweight catalog UIs")
+	fmt.Printf("  %s --search-index-output data/models-search-index.json\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Fail catalog generation when any model lacks a provider, license or description")
+	fmt.Printf("  %s --require-fields name,provider,license,description\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Keep internal test models out of the published catalog")
+	fmt.Printf("  %s --model-filter input/model-filter.yaml\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Cap readmes at 64 KB, or leave them out entirely")
+	fmt.Printf("  %s --readme-max-size 65536\n", os.Args[0])
+	fmt.Printf("  %s --skip-readme\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Publish only values stated by a modelcard or HuggingFace, not guesses")
+	fmt.Printf("  %s --exclude-low-confidence\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Mark artifacts on a corporate registry as internal in their registrySource")
+	fmt.Printf("  %s --internal-registries registry.example.corp\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Run with options from a pipeline config file, overriding one of them")
+	fmt.Printf("  %s --config pipeline.yaml --max-concurrent 10\n", os.Args[0])
+	fmt.Println()
+	fmt.Println("  # Continue an interrupted run with the models it had not finished")
+	fmt.Printf("  %s --resume\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Reprocess only the models that failed in the previous run")
+	fmt.Printf("  %s --retry-failed\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Keep raw manifests, config blobs and modelcard layers to debug parsing offline")
+	fmt.Printf("  %s --keep-intermediate\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Also read modelcards from layers annotated by other modelcar tooling")
+	fmt.Printf("  %s --modelcard-annotations io.opendatahub.modelcar.layer.type=modelcard,com.example.layer.kind=docs\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Record signature, attestation and SBOM checks on each artifact")
+	fmt.Printf("  %s --verify-artifacts --verification-keys cosign.pub\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Pull registry.redhat.io images with a registry service account instead of a docker login")
+	fmt.Printf("  %s --redhat-registry-credentials /run/secrets/redhat-registry.yaml\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Add freshness grades, advisories and descriptions of registry.redhat.io images")
+	fmt.Printf("  %s --redhat-catalog\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Record CVE counts from Clair reports and drop models with critical vulnerabilities")
+	fmt.Printf("  %s --vuln-reports clair-reports/ --vuln-severity-threshold critical\n", os.Args[0])
+	fmt.Println()
+	fmt.Println("  # Fail instead of building a catalog when more than 10% of the models have no modelcard")
+	fmt.Printf("  %s --max-failure-rate 0.1\n", os.Args[0])
+	fmt.Println()
 	fmt.Println("  # Skip HuggingFace processing and enrichment")
 	fmt.Printf("  %s --skip-huggingface --skip-enrichment\n", os.Args[0])
 	fmt.Println("")
+	fmt.Println("  # Require closer HuggingFace name matches (writes output/match-report.yaml)")
+	fmt.Printf("  %s --match-threshold 0.8\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Air-gapped enrichment from pre-downloaded HuggingFace files")
+	fmt.Printf("  %s --hf-snapshot-dir /mnt/hf-snapshots\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Allow slow HuggingFace responses and retry failed requests more often")
+	fmt.Printf("  %s --hf-timeout 2m --hf-retries 5\n", os.Args[0])
+	fmt.Println("")
+	fmt.Println("  # Send requests through a corporate proxy that re-signs TLS traffic")
+	fmt.Printf("  %s --http-proxy http://proxy.example.com:3128 --http-ca-cert /etc/pki/proxy-ca.pem\n", os.Args[0])
+	fmt.Println("")
 	fmt.Println("  # Process only metadata extraction")
 	fmt.Printf("  %s --skip-huggingface --skip-enrichment --skip-catalog\n", os.Args[0])
 	fmt.Println("")
@@ -306,6 +726,9 @@ func printHelp() {
 	fmt.Println("  # Skip default static catalog but include custom ones")
 	fmt.Printf("  %s --skip-default-static-catalog --static-catalog-files custom.yaml\n", os.Args[0])
 	fmt.Println("")
+	fmt.Println("  # Add localized descriptions (description_i18n) to catalog models")
+	fmt.Printf("  %s --description-overrides input/description-overrides.yaml\n", os.Args[0])
+	fmt.Println("")
 	fmt.Println("  # Generate MCP servers catalog with OCI enrichment (no model processing)")
 	fmt.Printf("  %s --mcp-index data/redhat-mcp-servers-index.yaml --skip-huggingface --skip-enrichment --skip-catalog\n", os.Args[0])
 	fmt.Println("")
@@ -319,615 +742,306 @@ func printHelp() {
 	fmt.Printf("  %s --agent-index data/redhat-agents-index.yaml --skip-huggingface --skip-enrichment --skip-catalog --skip-agent-enrichment\n", os.Args[0])
 }
 
-// getStaticCatalogPaths returns the list of static catalog files to process
-func getStaticCatalogPaths(staticCatalogFiles string, skipDefaultStaticCatalog bool) []string {
-	var paths []string
-
-	// Add custom static catalog files if specified
-	if staticCatalogFiles != "" {
-		customPaths := strings.Split(staticCatalogFiles, ",")
-		for _, path := range customPaths {
-			path = strings.TrimSpace(path)
-			if path != "" {
-				paths = append(paths, path)
-			}
-		}
-	}
-
-	// Add default static catalog file if not skipped and exists
-	if !skipDefaultStaticCatalog {
-		defaultPath := filepath.Join(*inputDir, "supplemental-catalog.yaml")
-		if _, err := os.Stat(defaultPath); err == nil {
-			paths = append(paths, defaultPath)
-		}
-	}
-
-	return paths
-}
-
-// loadModelsWithMetadata loads models with their metadata from various sources with fallback logic
-func loadModelsWithMetadata(modelsIndexPath string) ([]types.ModelEntry, error) {
-	// First try to load from specified models index file
-	if _, err := os.Stat(modelsIndexPath); err == nil {
-		log.Printf("Loading models from: %s", modelsIndexPath)
-		return config.LoadModelsConfigFromYAML(modelsIndexPath)
+// applyPipelineConfig sets the flags not given on the command line from a pipeline config file
+// and resolves its credential references into environment variables
+func applyPipelineConfig(path string) error {
+	cfg, err := config.LoadPipelineConfig(path)
+	if err != nil {
+		return err
 	}
-
-	// Try to load from latest version index file as fallback
-	latestIndexFile, err := huggingface.GetLatestVersionIndexFile()
-	if err == nil {
-		log.Printf("Using latest version index file: %s", latestIndexFile)
-		// Convert version index to model entries (all validated=true, featured=false by default)
-		modelURIs, err := config.LoadModelsFromVersionIndex(latestIndexFile)
-		if err != nil {
-			return nil, err
-		}
-
-		var modelEntries []types.ModelEntry
-		for _, uri := range modelURIs {
-			modelEntries = append(modelEntries, types.ModelEntry{
-				Type:   "oci",
-				URI:    uri,
-				Labels: []string{"validated"},
-			})
-		}
-		return modelEntries, nil
+	explicit := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
+	if err := cfg.Apply(flag.CommandLine, explicit); err != nil {
+		return fmt.Errorf("%s: %v", path, err)
 	}
-
-	return nil, fmt.Errorf("no valid models index file found at %s and no version index files available", modelsIndexPath)
+	return cfg.ResolveCredentials()
 }
 
-// processModelsInParallelWithMetadata processes multiple models concurrently with metadata support
-func processModelsInParallelWithMetadata(modelEntries []types.ModelEntry, maxConcurrent int) []ModelResult {
-	// Extract URIs for processing
-	var manifestRefs []string
-	uriToEntry := make(map[string]types.ModelEntry)
-
-	for _, entry := range modelEntries {
-		manifestRefs = append(manifestRefs, entry.URI)
-		uriToEntry[entry.URI] = entry
+// slackWebhookURL returns --slack-webhook or, if unset, $SLACK_WEBHOOK_URL. The variable is read
+// on use so values from .env and --config credentials apply.
+func slackWebhookURL() string {
+	if *slackWebhook != "" {
+		return *slackWebhook
 	}
-
-	return processModelsInParallelWithEntryMap(manifestRefs, uriToEntry, maxConcurrent)
+	return os.Getenv("SLACK_WEBHOOK_URL")
 }
 
-// processModelsInParallelWithEntryMap processes multiple models concurrently with entry metadata
-func processModelsInParallelWithEntryMap(manifestRefs []string, uriToEntry map[string]types.ModelEntry, maxConcurrent int) []ModelResult {
-	sys := &containertypes.SystemContext{
-		ArchitectureChoice: "amd64",
-		OSChoice:           "linux",
-	}
-
-	// Create a WaitGroup to wait for all goroutines to complete
-	var wg sync.WaitGroup
-
-	// Create a semaphore to limit concurrent goroutines
-	semaphore := make(chan struct{}, maxConcurrent)
-
-	// Channel to collect results from goroutines
-	results := make(chan ModelResult, len(manifestRefs))
-
-	// Process each manifest reference in parallel with concurrency limit
-	for _, manifestRef := range manifestRefs {
-		// Acquire semaphore (blocks if max goroutines are already running)
-		semaphore <- struct{}{}
-
-		wg.Add(1)
-		go func(ref string, entry types.ModelEntry) {
-			defer wg.Done()
-			defer func() { <-semaphore }() // Release semaphore when done
-
-			log.Printf("Starting processing for: %s", ref)
-			src, layers, configBlob := fetchManifestSrcAndLayers(ref, sys)
-			defer func() { _ = src.Close() }()
-			modelCardFound, metadata := scanLayersForModelCardWithTags(layers, src, ref, configBlob, entry)
-			log.Printf("Completed processing for: %s", ref)
-
-			// Send result to channel
-			results <- ModelResult{
-				Ref:            ref,
-				ModelCardFound: modelCardFound,
-				Metadata:       metadata,
-			}
-		}(manifestRef, uriToEntry[manifestRef])
-	}
-
-	// Wait for all goroutines to complete
-	wg.Wait()
-	close(results)
-
-	// Collect all results
-	var modelResults []ModelResult
-	for result := range results {
-		modelResults = append(modelResults, result)
-	}
-
-	return modelResults
-}
-
-// scanLayersForModelCardWithTags scans container layers for model card content and adds model labels as tags
-func scanLayersForModelCardWithTags(layers []containertypes.BlobInfo, src containertypes.ImageSource, manifestRef string, configBlob []byte, entry types.ModelEntry) (bool, types.ModelMetadata) {
-	modelCardFound, metadata := scanLayersForModelCard(layers, src, manifestRef, configBlob)
-
-	// Add labels from the model entry as tags to the extracted metadata
-	// This works for both successful extractions and skeleton metadata
-	addModelLabelTags(manifestRef, entry)
-
-	return modelCardFound, metadata
-}
-
-// addModelLabelTags adds model labels as tags to the extracted metadata
-func addModelLabelTags(manifestRef string, entry types.ModelEntry) {
-	// Create sanitized directory name for the model
-	sanitizedName := utils.SanitizeManifestRef(manifestRef)
-	metadataPath := fmt.Sprintf("%s/%s/models/metadata.yaml", *outputDir, sanitizedName)
-
-	// Read existing metadata
-	data, err := os.ReadFile(metadataPath)
-	if err != nil {
-		log.Printf("Warning: Could not read metadata file %s: %v", metadataPath, err)
+// notifySlack posts the run summary to the --slack-webhook, if set; a failed post only logs a warning
+func notifySlack(runSummary summary.RunSummary, status, reason string) {
+	webhookURL := slackWebhookURL()
+	if webhookURL == "" {
 		return
 	}
-
-	// Parse existing metadata
-	var metadata types.ExtractedMetadata
-	err = yaml.Unmarshal(data, &metadata)
+	err := notify.PostSlack(context.Background(), runSummary, notify.SlackOptions{
+		WebhookURL: webhookURL,
+		Catalog:    filepath.Base(*catalogOutputPath),
+		Status:     status,
+		Reason:     reason,
+	})
 	if err != nil {
-		log.Printf("Warning: Could not parse metadata file %s: %v", metadataPath, err)
+		log.Printf("Warning: Failed to post Slack notification: %v", err)
 		return
 	}
-
-	// Initialize tags slice if nil
-	if metadata.Tags == nil {
-		metadata.Tags = []string{}
-	}
-
-	// Track if we made changes
-	changed := false
-
-	// Add each label from the model entry as a tag if not already present
-	for _, label := range entry.Labels {
-		if label != "" && !slices.Contains(metadata.Tags, label) {
-			metadata.Tags = append(metadata.Tags, label)
-			changed = true
-			log.Printf("Added '%s' tag to %s", label, manifestRef)
-		}
-	}
-
-	// Write back the metadata if changes were made
-	if changed {
-		updatedData, err := yaml.Marshal(&metadata)
-		if err != nil {
-			log.Printf("Warning: Could not marshal updated metadata for %s: %v", manifestRef, err)
-			return
-		}
-
-		err = os.WriteFile(metadataPath, updatedData, 0644)
-		if err != nil {
-			log.Printf("Warning: Could not write updated metadata file %s: %v", metadataPath, err)
-			return
-		}
-	}
+	log.Printf("Posted run summary to Slack")
 }
 
-// scanLayersForModelCard scans container layers for model card content
-func scanLayersForModelCard(layers []containertypes.BlobInfo, src containertypes.ImageSource, manifestRef string, configBlob []byte) (bool, types.ModelMetadata) {
-	for i, layer := range layers {
-		log.Printf("Layer %d:", i+1)
-		log.Printf("  Digest: %s", layer.Digest)
-		log.Printf("  MediaType: %s", layer.MediaType)
-		log.Printf("  Size: %d bytes", layer.Size)
-		if layer.Annotations != nil {
-			log.Printf("  Annotations: %v", layer.Annotations)
-
-			// Check if this layer has the modelcard annotation
-			if layerType, exists := layer.Annotations["io.opendatahub.modelcar.layer.type"]; exists && layerType == "modelcard" {
-				log.Printf("  Found modelcard layer! Attempting to access modelcard layer blob with digest: %s", layer.Digest)
-
-				var layerBlob io.ReadCloser
-				var err error
-
-				layerBlob, _, err = src.GetBlob(context.Background(), containertypes.BlobInfo{
-					Digest: layer.Digest,
-				}, blobinfocachememory.New())
-				if err != nil {
-					log.Fatalf("Failed to get modelcard layer blob: %v", err)
-				}
-
-				if layerBlob == nil {
-					log.Printf("layerBlob is nil for modelcard layer")
-				} else {
-					var reader io.Reader = layerBlob
-					defer func() { _ = layerBlob.Close() }()
-					log.Printf("  Successfully fetched modelcard layer blob. Attempting to read as tar...")
-
-					// Check if it's a gzipped tar file
-					if strings.Contains(layer.MediaType, "+gzip") {
-						log.Printf("  Detected gzipped tar file, decompressing...")
-						gzReader, err := gzip.NewReader(layerBlob)
-						if err != nil {
-							log.Printf("Error creating gzip reader: %v", err)
-							continue
-						}
-						defer func() { _ = gzReader.Close() }()
-						reader = gzReader
-					}
-
-					tr := tar.NewReader(reader)
-					var mdFileCount int
-					var singleMdFileName string
-					var singleMdContent []byte
-
-					for {
-						header, err := tr.Next()
-						if err == io.EOF {
-							break
-						}
-						if err != nil {
-							log.Printf("Error reading tar: %v", err)
-							break
-						}
-						log.Printf("  Found file in tar: %s (size: %d bytes)", header.Name, header.Size)
-						if strings.HasSuffix(header.Name, ".md") {
-							mdFileCount++
-							if mdFileCount > 1 {
-								log.Printf("  Found multiple .md files, skipping content display")
-								break
-							}
-							singleMdFileName = header.Name
-							// Only read content if this is the first (and potentially only) .md file
-							var content bytes.Buffer
-							_, err := io.Copy(&content, tr)
-							if err != nil {
-								log.Printf("Error reading %s: %v", header.Name, err)
-								continue
-							}
-							singleMdContent = content.Bytes()
-						} else {
-							// Skip non-.md files
-							_, err := io.Copy(io.Discard, tr)
-							if err != nil {
-								log.Printf("Error skipping %s: %v", header.Name, err)
-								continue
-							}
-						}
-					}
-
-					if mdFileCount == 1 {
-						log.Printf("  Found single .md file: %s (size: %d bytes)", singleMdFileName, len(singleMdContent))
-
-						// Create output directory
-						sanitizedDir := utils.SanitizeManifestRef(manifestRef)
-						outputDir := filepath.Join(*outputDir, sanitizedDir)
-
-						// Create the full directory path for the file (including subdirectories)
-						outputFilePath := filepath.Join(outputDir, singleMdFileName)
-						outputFileDir := filepath.Dir(outputFilePath)
-						err := os.MkdirAll(outputFileDir, 0755)
-						if err != nil {
-							log.Fatalf("Failed to create output directory: %v", err)
-						}
-
-						// Write modelcard content to file
-						err = os.WriteFile(outputFilePath, singleMdContent, 0644)
-						if err != nil {
-							log.Fatalf("Failed to write modelcard content to file: %v", err)
-						}
-
-						log.Printf("  Successfully wrote modelcard content to: %s", outputFilePath)
-
-						// Parse metadata from the modelcard content
-						metadataFlags := metadata.ParseModelCardMetadata(singleMdContent)
-
-						// Extract actual metadata values
-						extractedMetadata := metadata.ExtractMetadataValues(singleMdContent)
-
-						// Populate artifacts with OCI registry metadata and real timestamps
-						extractedMetadata.Artifacts = registry.ExtractOCIArtifactsFromRegistry(manifestRef)
-
-						// Extract real timestamps from config blob and update artifacts
-						createTime, updateTime := extractTimestampsFromConfig(configBlob)
-						for i := range extractedMetadata.Artifacts {
-							if extractedMetadata.Artifacts[i].CreateTimeSinceEpoch == nil {
-								extractedMetadata.Artifacts[i].CreateTimeSinceEpoch = createTime
-							}
-							if extractedMetadata.Artifacts[i].LastUpdateTimeSinceEpoch == nil {
-								extractedMetadata.Artifacts[i].LastUpdateTimeSinceEpoch = updateTime
-							}
-						}
-
-						// Generate metadata.yaml file in the same directory
-						metadataFilePath := filepath.Join(outputFileDir, "metadata.yaml")
-						metadataYaml, err := yaml.Marshal(&extractedMetadata)
-						if err != nil {
-							log.Printf("Failed to marshal metadata to YAML: %v", err)
-						} else {
-							err = os.WriteFile(metadataFilePath, metadataYaml, 0644)
-							if err != nil {
-								log.Printf("Failed to write metadata.yaml: %v", err)
-							} else {
-								log.Printf("  Successfully wrote metadata.yaml to: %s", metadataFilePath)
-							}
-						}
-
-						return true, metadataFlags
-					} else {
-						log.Printf("  No .md files found in the blob")
-					}
-				}
-			}
-		}
-	}
-
-	// If no modelcard was found, create a skeleton metadata.yaml for enrichment processing
-	log.Printf("  No modelcard layer found, creating skeleton metadata for enrichment")
-	createSkeletonMetadata(manifestRef, configBlob)
-
-	return false, types.ModelMetadata{}
+// runFatalf reports the run as failed to the --slack-webhook before exiting, so errors that stop
+// the run reach the team like exceeded failure thresholds do
+func runFatalf(recorder *summary.Recorder, format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	notifySlack(recorder.Summary(), notify.StatusFailed, message)
+	log.Fatal(message)
 }
 
-// createSkeletonMetadata creates a basic metadata.yaml file when modelcard extraction fails
-// and attempts to fetch HuggingFace README as a fallback modelcard
-func createSkeletonMetadata(manifestRef string, configBlob []byte) {
-	// Create output directory
-	sanitizedDir := utils.SanitizeManifestRef(manifestRef)
-	outputDir := filepath.Join(*outputDir, sanitizedDir, "models")
-
-	err := os.MkdirAll(outputDir, 0755)
-	if err != nil {
-		log.Printf("  Warning: Failed to create skeleton output directory: %v", err)
-		return
+// commitDataChanges commits the files under --git-paths, pushes them and opens a pull request as
+// configured by the --git-* flags
+func commitDataChanges(ctx context.Context, runSummary summary.RunSummary) error {
+	message := "Update model catalog data\n\n" + fmt.Sprintf("- %d models processed\n", runSummary.Models.Processed)
+	if len(runSummary.NewModels) > 0 {
+		message += "- New models: " + strings.Join(runSummary.NewModels, ", ") + "\n"
 	}
-
-	// Try to find matching HuggingFace model and fetch README as fallback
-	tryHuggingFaceFallback(manifestRef, outputDir)
-
-	// Create basic metadata with minimal information
-	metadata := types.ExtractedMetadata{
-		Tags:      []string{}, // Empty tags slice for enrichment to populate
-		Language:  []string{},
-		Tasks:     []string{},
-		Artifacts: registry.ExtractOCIArtifactsFromRegistry(manifestRef),
+	if len(runSummary.RemovedModels) > 0 {
+		message += "- Removed models: " + strings.Join(runSummary.RemovedModels, ", ") + "\n"
 	}
 
-	// Extract timestamps from config blob if available
-	createTime, updateTime := extractTimestampsFromConfig(configBlob)
-	for i := range metadata.Artifacts {
-		if metadata.Artifacts[i].CreateTimeSinceEpoch == nil {
-			metadata.Artifacts[i].CreateTimeSinceEpoch = createTime
-		}
-		if metadata.Artifacts[i].LastUpdateTimeSinceEpoch == nil {
-			metadata.Artifacts[i].LastUpdateTimeSinceEpoch = updateTime
-		}
-	}
+	_, err := gitcommit.Commit(ctx, gitcommit.Options{
+		Paths:           parseCommaList(*gitPaths),
+		Branch:          *gitBranch,
+		Message:         message,
+		Remote:          *gitRemote,
+		PullRequestBase: *gitPRBase,
+		Repo:            *gitPRRepo,
+	})
+	return err
+}
 
-	// Write skeleton metadata.yaml
-	metadataFilePath := filepath.Join(outputDir, "metadata.yaml")
-	metadataYaml, err := yaml.Marshal(&metadata)
+// recordMetadataStore records the metadata of the processed models as a new run of the --store
+func recordMetadataStore(storeURL string, output outputfs.FS, refs []string) error {
+	metadataStore, err := store.Open(storeURL)
 	if err != nil {
-		log.Printf("  Warning: Failed to marshal skeleton metadata to YAML: %v", err)
-		return
+		return err
 	}
+	defer func() { _ = metadataStore.Close() }()
 
-	err = os.WriteFile(metadataFilePath, metadataYaml, 0644)
+	runSummary, err := metadataStore.RecordRun(output, refs)
 	if err != nil {
-		log.Printf("  Warning: Failed to write skeleton metadata.yaml: %v", err)
-		return
+		return err
 	}
-
-	log.Printf("  Successfully created skeleton metadata.yaml: %s", metadataFilePath)
+	log.Printf("Recorded %d models in metadata store run %d (%d new or changed)", runSummary.Models, runSummary.RunID, runSummary.Changed)
+	return nil
 }
 
-// tryHuggingFaceFallback attempts to find a matching HuggingFace model and fetch its README as a fallback modelcard
-func tryHuggingFaceFallback(manifestRef string, outputDir string) {
-	log.Printf("  Attempting HuggingFace README fallback for: %s", manifestRef)
-
-	// Try to get the latest HuggingFace index file
-	latestIndexFile, err := huggingface.GetLatestVersionIndexFile()
-	if err != nil {
-		log.Printf("  Warning: Failed to find HuggingFace index file for fallback: %v", err)
-		return
+// recordRunMetrics records the model outcomes, enrichment coverage and catalog size of the run
+func recordRunMetrics(runSummary summary.RunSummary, catalogPath string, skipCatalog bool) {
+	metrics.Default.Add(metrics.ModelsProcessed, float64(runSummary.Models.ModelcardsFound), "modelcard")
+	metrics.Default.Add(metrics.ModelsProcessed, float64(runSummary.Models.SkeletonsCreated), "skeleton")
+	metrics.Default.Add(metrics.ModelsProcessed, float64(runSummary.Models.Failed), "failed")
+	for field, counts := range runSummary.Enrichment {
+		metrics.Default.Set(metrics.EnrichmentCoverage, float64(counts.Enriched), field, "enriched")
+		metrics.Default.Set(metrics.EnrichmentCoverage, float64(counts.Populated), field, "populated")
+		metrics.Default.Set(metrics.EnrichmentCoverage, float64(counts.Missing), field, "missing")
 	}
 
-	// Load HuggingFace index to find matching models
-	hfData, err := os.ReadFile(latestIndexFile)
-	if err != nil {
-		log.Printf("  Warning: Failed to read HuggingFace index file for fallback: %v", err)
+	if skipCatalog {
 		return
 	}
-
-	var hfIndex types.VersionIndex
-	err = yaml.Unmarshal(hfData, &hfIndex)
-	if err != nil {
-		log.Printf("  Warning: Failed to parse HuggingFace index for fallback: %v", err)
-		return
+	if info, err := os.Stat(catalogPath); err == nil {
+		metrics.Default.Set(metrics.CatalogSize, float64(info.Size()))
 	}
-
-	// Find best matching HuggingFace model using similar logic to enrichment
-	bestMatch := types.ModelIndex{}
-	bestScore := 0.0
-
-	for _, hfModel := range hfIndex.Models {
-		score := utils.CalculateSimilarity(manifestRef, hfModel.Name)
-		if score > bestScore {
-			bestScore = score
-			bestMatch = hfModel
-		}
+	if generated, err := catalog.ReadCatalog(catalogPath); err == nil {
+		metrics.Default.Set(metrics.CatalogModels, float64(len(generated.Models)))
 	}
+}
 
-	// Only proceed if we have a reasonable match
-	threshold := 0.5
-	if bestScore < threshold {
-		log.Printf("  No suitable HuggingFace model found for fallback (best score: %.2f)", bestScore)
-		return
-	}
+// configFatalf logs an invalid option or configuration file and exits with exitConfigError
+func configFatalf(format string, args ...any) {
+	log.Printf(format, args...)
+	os.Exit(exitConfigError)
+}
 
-	log.Printf("  Found HuggingFace match for fallback: %s (score: %.2f)", bestMatch.Name, bestScore)
+// isFlagSet reports whether a command line flag was explicitly provided
+func isFlagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
 
-	// Fetch README content from HuggingFace
-	hfReadme, err := huggingface.FetchReadme(bestMatch.Name)
-	if err != nil {
-		log.Printf("  Warning: Failed to fetch HuggingFace README for fallback: %v", err)
-		return
+// loadRegistryAuth returns the registry.redhat.io service account of a secrets file or of the
+// REDHAT_REGISTRY_* environment variables; nil when neither is configured
+func loadRegistryAuth(secretsPath string) (registry.Auth, error) {
+	creds, err := registry.LoadRedHatCredentials(secretsPath)
+	if err != nil || creds == nil {
+		return nil, err
 	}
+	log.Printf("Authenticating to %s as service account %s", registry.RedHatRegistry, creds.Username)
+	return registry.Auth{registry.RedHatRegistry: *creds}, nil
+}
 
-	// Strip YAML frontmatter to match container modelcard format
-	processedContent := utils.StripYAMLFrontmatter(hfReadme)
-
-	// Write the README content as modelcard.md
-	modelcardPath := filepath.Join(outputDir, "modelcard.md")
-	err = os.WriteFile(modelcardPath, []byte(processedContent), 0644)
-	if err != nil {
-		log.Printf("  Warning: Failed to write HuggingFace README as modelcard.md: %v", err)
-		return
+// parseCommaList splits a comma-separated flag value, dropping empty entries
+func parseCommaList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
 	}
-
-	log.Printf("  Successfully created fallback modelcard.md from HuggingFace README: %s", modelcardPath)
+	return items
 }
 
-// fetchManifestSrcAndLayers fetches manifest, layers, and config blob from container registry
-func fetchManifestSrcAndLayers(manifestRef string, sys *containertypes.SystemContext) (containertypes.ImageSource, []containertypes.BlobInfo, []byte) {
-	log.Printf("Parsing reference...")
-	ref, err := docker.ParseReference("//" + manifestRef)
-	if err != nil {
-		log.Fatalf("Failed to parse reference: %v", err)
+// pipelineOptions returns the options of the models pipeline from the command-line flags
+func pipelineOptions(modelcardAnnotations []pipeline.LayerAnnotation, registryAuth registry.Auth, recorder *summary.Recorder) pipeline.Options {
+	// The flags use 0 to disable retries and the readme cap, the pipeline a negative value
+	retries := *hfRetries
+	if retries == 0 {
+		retries = -1
 	}
-
-	// Create a new image source (later will use to get "the" blob)
-	log.Printf("Creating image source...")
-	src, err := ref.NewImageSource(context.Background(), sys)
-	if err != nil {
-		log.Fatalf("Failed to create image source: %v", err)
+	readmeMaxSize := *readmeMaxSize
+	if readmeMaxSize == 0 {
+		readmeMaxSize = -1
 	}
-	// not closing `src` given it is returned to the caller
-
-	// Get the manifest
-	manifest, manifestType, err := src.GetManifest(context.Background(), nil)
-	if err != nil {
-		log.Fatalf("Failed to get manifest: %v", err)
+	var registryCredentials map[string]pipeline.RegistryCredentials
+	for host, creds := range registryAuth {
+		if registryCredentials == nil {
+			registryCredentials = make(map[string]pipeline.RegistryCredentials)
+		}
+		registryCredentials[host] = pipeline.RegistryCredentials{Username: creds.Username, Token: creds.Token}
 	}
-
-	log.Printf("Manifest type: %s", manifestType)
-	log.Printf("Manifest size: %d bytes", len(manifest))
-
-	// Get the image
-	img, err := ref.NewImage(context.Background(), sys)
-	if err != nil {
-		log.Fatalf("Failed to create image: %v", err)
+	return pipeline.Options{
+		OutputDir:       *outputDir,
+		ModelsIndexPath: *modelsIndexPath,
+		MaxConcurrent:   *maxConcurrent,
+		MatchThreshold:  *matchThreshold,
+		Resume:          *resume,
+		RetryFailed:     *retryFailed,
+		HuggingFace: pipeline.HuggingFaceOptions{
+			Timeout:     *hfTimeout,
+			Retries:     retries,
+			SnapshotDir: *hfSnapshotDir,
+		},
+		RegistryCredentials: registryCredentials,
+		Readme: pipeline.ReadmeOptions{
+			Skip:    *skipReadme,
+			MaxSize: readmeMaxSize,
+		},
+		Extract: pipeline.ExtractOptions{
+			KeepIntermediate:     *keepIntermediate,
+			ModelcardAnnotations: modelcardAnnotations,
+			VerifyArtifacts:      *verifyArtifacts,
+			VerificationKeyPaths: parseCommaList(*verificationKeys),
+			VulnScanner:          *vulnScanner,
+			VulnReportsDir:       *vulnReports,
+		},
+		Enrich: pipeline.EnrichOptions{
+			VLLMConfigDir: filepath.Join(*inputDir, "models", "vllm-config"),
+			RedHatCatalog: *redHatCatalog,
+			PyxisURL:      *pyxisURL,
+			PyxisAPIKey:   os.Getenv("PYXIS_API_KEY"),
+		},
+		Catalog: pipeline.CatalogOptions{
+			CatalogPath:              *catalogOutputPath,
+			StaticCatalogPaths:       getStaticCatalogPaths(*staticCatalogFiles, *skipDefaultStaticCatalog),
+			Format:                   *catalogFormat,
+			Validation:               *catalogValidation,
+			IncludeLabels:            parseCommaList(*includeLabels),
+			ExcludeLabels:            parseCommaList(*excludeLabels),
+			ModelFilterPath:          getModelFilterPath(*modelFilterPath),
+			RequiredFields:           parseCommaList(*requireFields),
+			SkipURIDedup:             *skipURIDedup,
+			SkipTagGrouping:          *skipTagGrouping,
+			InternalRegistries:       parseCommaList(*internalRegistries),
+			ExcludeLowConfidence:     *excludeLowConfidence,
+			VulnerabilityThreshold:   *vulnSeverityThreshold,
+			DescriptionOverridesPath: getDescriptionOverridesPath(*descriptionOverrides),
+			LogoDir:                  *logoDirPath,
+			LogoMappingPath:          *logoMappingPath,
+			ModelcardTemplatePath:    *modelcardTemplatePath,
+			ChunkSize:                *catalogChunkSize,
+			MarkdownPath:             *catalogMarkdownPath,
+			ChangelogPath:            *changelogOutputPath,
+			ChangelogSnapshotPath:    *changelogSnapshotPath,
+			OnChanges:                recorder.RecordCatalogChanges,
+			ConfigMapPath:            *catalogConfigMapPath,
+			ConfigMapName:            *configMapName,
+			ConfigMapNamespace:       *configMapNamespace,
+			CatalogSourcePath:        *catalogSourcePath,
+			CatalogSourceName:        *catalogSourceName,
+			CatalogSourceLabels:      parseCommaList(*catalogSourceLabels),
+			KServeOutputDir:          *kserveOutputDir,
+			KServeRuntimeImage:       *kserveRuntimeImage,
+			KServeNamespace:          *kserveNamespace,
+			PushReference:            *catalogPush,
+			EmbeddingsPath:           *embeddingsOutputPath,
+			EmbeddingsURL:            *embeddingsURL,
+			EmbeddingsModel:          *embeddingsModel,
+			EmbeddingsAPIKey:         os.Getenv("EMBEDDINGS_API_KEY"),
+			SearchIndexPath:          *searchIndexOutputPath,
+		},
 	}
-	defer func() { _ = img.Close() }()
+}
 
-	// Get the image configuration
-	log.Printf("Getting config blob...")
-	configBlob, err := img.ConfigBlob(context.Background())
-	if err != nil {
-		log.Fatalf("Failed to get config blob: %v", err)
+// recordDegraded records the failed parts of a pipeline step that completed degraded; any other
+// error stops the run
+func recordDegraded(recorder *summary.Recorder, err error, message string) {
+	if err == nil {
+		return
 	}
-
-	log.Printf("Config blob size: %d bytes", len(configBlob))
-
-	// Get layer information
-	log.Printf("Getting layer infos...")
-	layers := img.LayerInfos()
-	log.Printf("Number of layers: %d", len(layers))
-
-	// Get layer digests from layer infos
-	log.Printf("Layer digests:")
-	for i, layer := range layers {
-		log.Printf("  Layer %d: %s", i+1, layer.Digest)
+	var degraded *pipeline.DegradedError
+	if !errors.As(err, &degraded) {
+		runFatalf(recorder, "%s: %v", message, err)
 	}
-	return src, layers, configBlob
-}
-
-// OCI Image Config structure for timestamp extraction
-type OCIImageConfig struct {
-	Created string `json:"created"`
-	History []struct {
-		Created string `json:"created"`
-	} `json:"history"`
-}
-
-// extractTimestampsFromConfig extracts creation and update timestamps from OCI config blob
-func extractTimestampsFromConfig(configBlob []byte) (*int64, *int64) {
-	if len(configBlob) == 0 {
-		return nil, nil
+	for _, reason := range degraded.Errors {
+		log.Printf("Warning: %v", reason)
+		recorder.RecordDegraded(reason.Error())
 	}
+}
 
-	var config OCIImageConfig
-	if err := json.Unmarshal(configBlob, &config); err != nil {
-		log.Printf("Warning: Failed to parse config blob for timestamps: %v", err)
-		return nil, nil
-	}
+// getStaticCatalogPaths returns the list of static catalog files to process
+func getStaticCatalogPaths(staticCatalogFiles string, skipDefaultStaticCatalog bool) []string {
+	var paths []string
 
-	// Parse creation timestamp
-	var createTime *int64
-	if config.Created != "" {
-		if parsedTime, err := time.Parse(time.RFC3339, config.Created); err == nil {
-			epochMs := parsedTime.Unix() * 1000
-			createTime = &epochMs
-		} else {
-			log.Printf("Warning: Failed to parse creation time '%s': %v", config.Created, err)
-		}
-	}
+	// Add custom static catalog files if specified
+	paths = append(paths, parseCommaList(staticCatalogFiles)...)
 
-	// Use the most recent history entry for update time, fallback to creation time
-	updateTime := createTime
-	if len(config.History) > 0 {
-		lastHistoryEntry := config.History[len(config.History)-1]
-		if lastHistoryEntry.Created != "" {
-			if parsedTime, err := time.Parse(time.RFC3339, lastHistoryEntry.Created); err == nil {
-				epochMs := parsedTime.Unix() * 1000
-				updateTime = &epochMs
-			}
+	// Add default static catalog file if not skipped and exists
+	if !skipDefaultStaticCatalog {
+		defaultPath := filepath.Join(*inputDir, "supplemental-catalog.yaml")
+		if _, err := os.Stat(defaultPath); err == nil {
+			paths = append(paths, defaultPath)
 		}
 	}
 
-	log.Printf("Extracted timestamps - Create: %v, Update: %v", formatTimestamp(createTime), formatTimestamp(updateTime))
-	return createTime, updateTime
+	return paths
 }
 
-// formatTimestamp formats a timestamp pointer for logging
-func formatTimestamp(ts *int64) string {
-	if ts == nil {
-		return "nil"
+// getModelFilterPath returns the model filter file to apply: the given path, or model-filter.yaml
+// in the input directory when it exists
+func getModelFilterPath(path string) string {
+	if path != "" {
+		return path
 	}
-	return time.Unix(*ts/1000, 0).Format(time.RFC3339)
-}
-
-// generateManifestsYAML creates a manifests.yaml file tracking all processed models
-func generateManifestsYAML(modelResults []ModelResult, outputDir string) error {
-	var manifests types.ManifestsData
-
-	for _, result := range modelResults {
-		manifest := types.ModelManifest{
-			Ref: result.Ref,
-			ModelCard: types.ModelCard{
-				Present:  result.ModelCardFound,
-				Metadata: result.Metadata,
-			},
-		}
-		manifests.Models = append(manifests.Models, manifest)
-	}
-
-	// Marshal to YAML
-	yamlData, err := yaml.Marshal(&manifests)
-	if err != nil {
-		return err
+	defaultPath := filepath.Join(*inputDir, "model-filter.yaml")
+	if _, err := os.Stat(defaultPath); err == nil {
+		return defaultPath
 	}
+	return ""
+}
 
-	// Ensure output directory exists
-	err = os.MkdirAll(outputDir, 0755)
-	if err != nil {
-		return err
+// getDescriptionOverridesPath returns the description overrides file to apply: the given path, or
+// description-overrides.yaml in the input directory when it exists
+func getDescriptionOverridesPath(path string) string {
+	if path != "" {
+		return path
 	}
-
-	// Write to file in output directory
-	manifestsPath := filepath.Join(outputDir, "manifests.yaml")
-	err = os.WriteFile(manifestsPath, yamlData, 0644)
-	if err != nil {
-		return err
+	defaultPath := filepath.Join(*inputDir, "description-overrides.yaml")
+	if _, err := os.Stat(defaultPath); err == nil {
+		return defaultPath
 	}
-
-	log.Printf("Generated manifests.yaml with %d models", len(manifests.Models))
-	return nil
+	return ""
 }
@@ -1,9 +1,19 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/extraction"
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+	"github.com/opendatahub-io/model-metadata-collection/internal/registry"
+	"github.com/opendatahub-io/model-metadata-collection/internal/summary"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
 )
 
 func TestLoadDotEnv(t *testing.T) {
@@ -157,3 +167,197 @@ func TestLoadDotEnv_MissingFile(t *testing.T) {
 	// Should not panic or error on missing file
 	loadDotEnv("/nonexistent/path/.env")
 }
+
+func TestParseCommaList(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"", nil},
+		{"validated", []string{"validated"}},
+		{" validated , featured ,, ", []string{"validated", "featured"}},
+	}
+
+	for _, tt := range tests {
+		result := parseCommaList(tt.input)
+		if len(result) != len(tt.expected) {
+			t.Errorf("parseCommaList(%q) = %v, expected %v", tt.input, result, tt.expected)
+			continue
+		}
+		for i := range result {
+			if result[i] != tt.expected[i] {
+				t.Errorf("parseCommaList(%q) = %v, expected %v", tt.input, result, tt.expected)
+				break
+			}
+		}
+	}
+}
+
+func TestPipelineOptions(t *testing.T) {
+	defer func(previous string) { *requireFields = previous }(*requireFields)
+	defer func(previous int) { *hfRetries = previous }(*hfRetries)
+	*requireFields = "license, tasks"
+	*hfRetries = 0
+
+	auth := registry.Auth{registry.RedHatRegistry: {Username: "12345678|ci", Token: "secret"}}
+	opts := pipelineOptions(nil, auth, summary.NewRecorder())
+	if !reflect.DeepEqual(opts.Catalog.RequiredFields, []string{"license", "tasks"}) {
+		t.Errorf("Expected --require-fields to reach the catalog options, got %v", opts.Catalog.RequiredFields)
+	}
+	if opts.HuggingFace.Retries >= 0 {
+		t.Errorf("Expected --hf-retries 0 to disable retries, got %d", opts.HuggingFace.Retries)
+	}
+	if creds := opts.RegistryCredentials[registry.RedHatRegistry]; creds.Username != "12345678|ci" || creds.Token != "secret" {
+		t.Errorf("Expected the registry.redhat.io service account in the pipeline options, got %+v", opts.RegistryCredentials)
+	}
+}
+
+func TestRunPublish(t *testing.T) {
+	tmpDir := t.TempDir()
+	catalogPath := filepath.Join(tmpDir, "models-catalog.yaml")
+	if err := os.WriteFile(catalogPath, []byte("source: Red Hat\nmodels: []\n"), 0644); err != nil {
+		t.Fatalf("Failed to write catalog: %v", err)
+	}
+	layoutDir := filepath.Join(tmpDir, "layout")
+
+	err := runPublish([]string{"--to", "oci:" + layoutDir + ":latest", "--catalog", catalogPath, "--output-dir", filepath.Join(tmpDir, "output")})
+	if err != nil {
+		t.Fatalf("runPublish failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(layoutDir, "index.json")); err != nil {
+		t.Errorf("Expected OCI layout index to be written: %v", err)
+	}
+
+	if err := runPublish([]string{"--catalog", catalogPath}); err == nil {
+		t.Error("Expected error when --to is missing")
+	}
+}
+
+func TestRunValidate(t *testing.T) {
+	tmpDir := t.TempDir()
+	validPath := filepath.Join(tmpDir, "valid.yaml")
+	invalidPath := filepath.Join(tmpDir, "invalid.yaml")
+	if err := os.WriteFile(validPath, []byte("source: Red Hat\nmodels:\n  - name: a\n    artifacts:\n      - uri: oci://example.com/a:1\n"), 0644); err != nil {
+		t.Fatalf("Failed to write catalog: %v", err)
+	}
+	if err := os.WriteFile(invalidPath, []byte("source: Red Hat\nmodels:\n  - name: b\n"), 0644); err != nil {
+		t.Fatalf("Failed to write catalog: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := runValidate([]string{validPath}, &out); err != nil {
+		t.Errorf("Expected valid catalog to pass, got %v", err)
+	}
+	if out.String() != validPath+": ok\n" {
+		t.Errorf("Unexpected output %q", out.String())
+	}
+
+	out.Reset()
+	if err := runValidate([]string{validPath, invalidPath}, &out); err == nil {
+		t.Error("Expected invalid catalog to fail")
+	}
+	if !strings.Contains(out.String(), invalidPath+":3: model 'b' has no artifacts") {
+		t.Errorf("Expected line-oriented problem output, got %q", out.String())
+	}
+
+	if err := runValidate(nil, &out); err == nil {
+		t.Error("Expected error when no files are given")
+	}
+}
+
+func TestRunPreview(t *testing.T) {
+	tmpDir := t.TempDir()
+	catalogPath := filepath.Join(tmpDir, "models-catalog.yaml")
+	if err := os.WriteFile(catalogPath, []byte("source: Red Hat\nmodels:\n  - name: a\n"), 0644); err != nil {
+		t.Fatalf("Failed to write catalog: %v", err)
+	}
+
+	if err := runPreview([]string{"--catalog", catalogPath}); err != nil {
+		t.Fatalf("runPreview failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "models-catalog.html")); err != nil {
+		t.Errorf("Expected preview next to the catalog: %v", err)
+	}
+
+	if err := runPreview([]string{"--catalog", filepath.Join(tmpDir, "missing.yaml")}); err == nil {
+		t.Error("Expected error for a missing catalog")
+	}
+}
+
+func TestRunQuery(t *testing.T) {
+	catalogPath := filepath.Join(t.TempDir(), "models-catalog.yaml")
+	content := "source: Red Hat\nmodels:\n  - name: granite\n    license: apache-2.0\n    tasks: [text-generation]\n  - name: llama\n    license: llama3.1\n    tasks: [text-generation]\n"
+	if err := os.WriteFile(catalogPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write catalog: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := runQuery([]string{"--catalog", catalogPath, "--filter", "license=Apache 2.0 && task=text-generation"}, &out); err != nil {
+		t.Fatalf("runQuery failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "granite") || strings.Contains(out.String(), "llama") || !strings.Contains(out.String(), "1 of 2 models") {
+		t.Errorf("Unexpected table output %q", out.String())
+	}
+
+	out.Reset()
+	if err := runQuery([]string{"--catalog", catalogPath, "--filter", "name=llama", "--output", "json"}, &out); err != nil {
+		t.Fatalf("runQuery failed: %v", err)
+	}
+	if !strings.Contains(out.String(), `"name": "llama"`) || strings.Contains(out.String(), "granite") {
+		t.Errorf("Unexpected JSON output %q", out.String())
+	}
+
+	if err := runQuery([]string{"--catalog", catalogPath, "--filter", "size=8b"}, &out); err == nil {
+		t.Error("Expected error for an unknown filter field")
+	}
+}
+
+func TestRunMerge(t *testing.T) {
+	tmpDir := t.TempDir()
+	validatedPath := filepath.Join(tmpDir, "models-catalog.yaml")
+	partnerPath := filepath.Join(tmpDir, "partner-models-catalog.json")
+	if err := os.WriteFile(validatedPath, []byte("source: Red Hat\nmodels:\n  - name: granite\n"), 0644); err != nil {
+		t.Fatalf("Failed to write catalog: %v", err)
+	}
+	if err := os.WriteFile(partnerPath, []byte(`{"source": "Partner", "models": [{"name": "Granite"}, {"name": "mistral"}]}`), 0644); err != nil {
+		t.Fatalf("Failed to write catalog: %v", err)
+	}
+
+	outputPath := filepath.Join(tmpDir, "merged.yaml")
+	if err := runMerge([]string{"--output", outputPath, "--catalog-validation", "off", validatedPath, partnerPath}); err != nil {
+		t.Fatalf("runMerge failed: %v", err)
+	}
+	data, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to read merged catalog: %v", err)
+	}
+	if strings.Count(string(data), "- name:") != 2 || !strings.Contains(string(data), "source: Partner") {
+		t.Errorf("Expected granite deduplicated and mistral from the partner catalog, got:\n%s", data)
+	}
+
+	if err := runMerge([]string{validatedPath}); err == nil {
+		t.Error("Expected error when --output is missing")
+	}
+}
+
+func TestInterruptedRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	entries := []types.ModelEntry{{URI: "registry.example.com/org/a:1.0"}, {URI: "registry.example.com/org/b:1.0"}}
+	if results := extraction.ProcessModels(ctx, entries, extraction.Options{Output: outputfs.Dir(t.TempDir()), MaxConcurrent: 2}); len(results) != 0 {
+		t.Errorf("Expected no models to be started after cancellation, got %d results", len(results))
+	}
+
+	tmpDir := t.TempDir()
+	if err := writeInterruptedMarker(tmpDir, interruptedRun{Stage: "model-extraction", CompletedModels: 1, TotalModels: 2}); err != nil {
+		t.Fatalf("Failed to write marker: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(tmpDir, interruptedFileName))
+	if err != nil || !strings.Contains(string(data), "stage: model-extraction") {
+		t.Errorf("Unexpected marker %q: %v", data, err)
+	}
+	clearInterruptedMarker(tmpDir)
+	if _, err := os.Stat(filepath.Join(tmpDir, interruptedFileName)); !os.IsNotExist(err) {
+		t.Errorf("Expected the marker to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/catalog"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// runMerge implements the merge subcommand, which combines generated catalogs, such as the
+// validated, partner and community ones, into a single catalog with the deduplication and
+// validation of catalog generation
+func runMerge(args []string) error {
+	fs := flag.NewFlagSet("merge", flag.ExitOnError)
+	outputPath := fs.String("output", "", "Path of the merged catalog; its extension selects yaml, json or ndjson")
+	source := fs.String("source", "", "Source of the merged catalog (defaults to the source of the first catalog)")
+	validation := fs.String("catalog-validation", catalog.CatalogValidationError, "Merged catalog schema validation: error (fail the merge), warn, or off")
+	requiredFields := fs.String("require-fields", "", "Comma-separated catalog fields every merged model must have")
+	skipDedup := fs.Bool("skip-uri-dedup", false, "Keep models with different names that share an artifact URI instead of merging them")
+	skipTagGrouping := fs.Bool("skip-tag-grouping", false, "Keep models whose images are different tags of the same repository instead of grouping them")
+	internalRegistries := fs.String("internal-registries", "", "Comma-separated registry hosts or domains whose artifacts get the \"internal\" registrySource")
+	fs.Usage = func() {
+		fmt.Println("Merge generated catalogs into one; earlier catalogs win conflicting fields of the same model")
+		fmt.Println("")
+		fmt.Println("Usage:")
+		fmt.Printf("  %s merge --output <catalog> [options] <catalog>...\n", os.Args[0])
+		fmt.Println("")
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println("")
+		fmt.Println("Examples:")
+		fmt.Printf("  %s merge --output data/all-models-catalog.yaml data/models-catalog.yaml data/other-models-catalog.yaml\n", os.Args[0])
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *outputPath == "" {
+		fs.Usage()
+		return fmt.Errorf("--output is required")
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return fmt.Errorf("no catalogs given")
+	}
+
+	var catalogs []*types.ModelsCatalog
+	for _, path := range fs.Args() {
+		input, err := catalog.ReadCatalog(path)
+		if err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+		log.Printf("Read %d models from %s", len(input.Models), path)
+		catalogs = append(catalogs, input)
+	}
+
+	merged, err := catalog.MergeCatalogs(catalogs, catalog.MergeOptions{
+		Source:             *source,
+		Validation:         *validation,
+		SkipURIDedup:       *skipDedup,
+		SkipTagGrouping:    *skipTagGrouping,
+		InternalRegistries: parseCommaList(*internalRegistries),
+		RequiredFields:     parseCommaList(*requiredFields),
+	})
+	if err != nil {
+		return err
+	}
+	if err := catalog.WriteCatalog(merged, *outputPath, catalog.CatalogFormatForPath(*outputPath)); err != nil {
+		return err
+	}
+
+	log.Printf("Wrote merged catalog of %d models to %s", len(merged.Models), *outputPath)
+	return nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/preview"
+)
+
+// runPreview implements the preview subcommand, which renders a generated catalog into a static
+// HTML page for reviewing what will ship without deploying the UI
+func runPreview(args []string) error {
+	fs := flag.NewFlagSet("preview", flag.ExitOnError)
+	catalogPath := fs.String("catalog", "data/models-catalog.yaml", "Path of the generated models catalog (yaml, json or ndjson)")
+	outputPath := fs.String("output", "", "Path of the HTML page to write (defaults to the catalog path with an .html extension)")
+	fs.Usage = func() {
+		fmt.Println("Render the models catalog into a static HTML page for review")
+		fmt.Println("")
+		fmt.Println("Usage:")
+		fmt.Printf("  %s preview [options]\n", os.Args[0])
+		fmt.Println("")
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println("")
+		fmt.Println("Examples:")
+		fmt.Printf("  %s preview\n", os.Args[0])
+		fmt.Printf("  %s preview --catalog data/validated-models-catalog.yaml --output /tmp/validated.html\n", os.Args[0])
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	output := *outputPath
+	if output == "" {
+		output = strings.TrimSuffix(*catalogPath, filepath.Ext(*catalogPath)) + ".html"
+	}
+
+	models, err := preview.WriteFile(*catalogPath, output)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Wrote preview of %d models to %s", models, output)
+	return nil
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	containertypes "github.com/containers/image/v5/types"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/catalog"
+	"github.com/opendatahub-io/model-metadata-collection/internal/mlflow"
+	"github.com/opendatahub-io/model-metadata-collection/internal/modelregistry"
+	"github.com/opendatahub-io/model-metadata-collection/internal/publish"
+)
+
+// publishTimeout bounds the whole artifact upload
+const publishTimeout = 10 * time.Minute
+
+// runPublish implements the publish subcommand, which pushes a generated catalog and the
+// per-model readmes to a registry as an OCI artifact, or the catalog models to a Kubeflow Model
+// Registry or an MLflow tracking server
+func runPublish(args []string) error {
+	fs := flag.NewFlagSet("publish", flag.ExitOnError)
+	to := fs.String("to", "", "Destination reference, e.g. quay.io/org/model-catalog:latest or oci:/path/to/layout:tag (required)")
+	catalogPath := fs.String("catalog", "data/models-catalog.yaml", "Path of the generated models catalog to publish")
+	publishOutputDir := fs.String("output-dir", "output", "Output directory containing per-model modelcard.md readmes")
+	skipReadmes := fs.Bool("skip-readmes", false, "Publish only the catalog, without per-model readmes")
+	authFile := fs.String("authfile", "", "Path to a registry auth file (defaults to the standard container auth locations)")
+	insecure := fs.Bool("insecure", false, "Skip TLS verification when pushing to the registry")
+	modelRegistryURL := fs.String("model-registry-url", "", "Create or update the catalog models in the Kubeflow Model Registry at this URL, e.g. https://model-registry.example.com")
+	modelRegistryToken := fs.String("model-registry-token", os.Getenv("MODEL_REGISTRY_TOKEN"), "Bearer token for the model registry (defaults to $MODEL_REGISTRY_TOKEN)")
+	mlflowURL := fs.String("mlflow-url", "", "Register the catalog models in the model registry of the MLflow tracking server at this URL, e.g. https://mlflow.example.com")
+	mlflowToken := fs.String("mlflow-token", os.Getenv("MLFLOW_TRACKING_TOKEN"), "Bearer token for the MLflow tracking server (defaults to $MLFLOW_TRACKING_TOKEN)")
+	fs.Usage = func() {
+		fmt.Println("Push the models catalog to a registry as an OCI artifact, or its models to a Kubeflow Model Registry or MLflow")
+		fmt.Println("")
+		fmt.Println("Usage:")
+		fmt.Printf("  %s publish --to <reference> [options]\n", os.Args[0])
+		fmt.Printf("  %s publish --model-registry-url <url> [options]\n", os.Args[0])
+		fmt.Printf("  %s publish --mlflow-url <url> [options]\n", os.Args[0])
+		fmt.Println("")
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println("")
+		fmt.Println("Examples:")
+		fmt.Printf("  %s publish --to quay.io/opendatahub/model-catalog:latest\n", os.Args[0])
+		fmt.Printf("  %s publish --to oci:/tmp/catalog-layout:latest --catalog data/models-catalog.json --skip-readmes\n", os.Args[0])
+		fmt.Printf("  %s publish --model-registry-url https://model-registry.example.com\n", os.Args[0])
+		fmt.Printf("  %s publish --mlflow-url https://mlflow.example.com\n", os.Args[0])
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *to == "" && *modelRegistryURL == "" && *mlflowURL == "" {
+		fs.Usage()
+		return fmt.Errorf("--to, --model-registry-url or --mlflow-url is required")
+	}
+
+	if *modelRegistryURL != "" {
+		if err := publishToModelRegistry(*catalogPath, *modelRegistryURL, *modelRegistryToken); err != nil {
+			return err
+		}
+	}
+	if *mlflowURL != "" {
+		if err := publishToMLflow(*catalogPath, *mlflowURL, *mlflowToken); err != nil {
+			return err
+		}
+	}
+	if *to == "" {
+		return nil
+	}
+
+	files, err := publish.CollectFiles(*catalogPath, *publishOutputDir, !*skipReadmes)
+	if err != nil {
+		return err
+	}
+
+	sys := &containertypes.SystemContext{AuthFilePath: *authFile}
+	if *insecure {
+		sys.DockerInsecureSkipTLSVerify = containertypes.OptionalBoolTrue
+	}
+
+	log.Printf("Publishing %s with %d readmes to %s", *catalogPath, len(files)-1, *to)
+
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+
+	manifestDigest, err := publish.Push(ctx, files, publish.Options{
+		Destination: *to,
+		Annotations: map[string]string{
+			imgspecv1.AnnotationCreated: time.Now().UTC().Format(time.RFC3339),
+		},
+		SystemContext: sys,
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Published catalog artifact %s@%s", *to, manifestDigest)
+	return nil
+}
+
+// publishToModelRegistry creates or updates a registered model with its versions and artifacts
+// for every model of the catalog
+func publishToModelRegistry(catalogPath, registryURL, token string) error {
+	models, err := catalog.ReadCatalog(catalogPath)
+	if err != nil {
+		return err
+	}
+	client, err := modelregistry.NewClient(registryURL, token)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Pushing %d models from %s to the model registry at %s", len(models.Models), catalogPath, registryURL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+
+	result, err := client.Push(ctx, models)
+	log.Printf("Model registry: %d objects created, %d updated", result.Created, result.Updated)
+	return err
+}
+
+// publishToMLflow registers every model of the catalog, with a model version per artifact, in the
+// model registry of an MLflow tracking server
+func publishToMLflow(catalogPath, trackingURL, token string) error {
+	models, err := catalog.ReadCatalog(catalogPath)
+	if err != nil {
+		return err
+	}
+	client, err := mlflow.NewClient(trackingURL, token)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Exporting %d models from %s to MLflow at %s", len(models.Models), catalogPath, trackingURL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+
+	result, err := client.Export(ctx, models)
+	log.Printf("MLflow: %d objects created, %d updated", result.Created, result.Updated)
+	return err
+}
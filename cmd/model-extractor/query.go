@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/catalog"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// runQuery implements the query subcommand, which prints the models of a generated catalog
+// matching a filter as a table or as a JSON catalog, so the catalog can be inspected without jq
+func runQuery(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("query", flag.ExitOnError)
+	catalogPath := fs.String("catalog", "data/models-catalog.yaml", "Path of the generated models catalog (yaml, json or ndjson)")
+	filter := fs.String("filter", "", "Conditions joined by &&, each comparing a field with = (ignoring case, spaces and hyphens), != or ~ (substring); fields: "+strings.Join(catalog.QueryFields(), ", "))
+	outputFormat := fs.String("output", "table", "Output format: table or json")
+	fs.Usage = func() {
+		fmt.Println("Print the models of the catalog matching a filter")
+		fmt.Println("")
+		fmt.Println("Usage:")
+		fmt.Printf("  %s query [options]\n", os.Args[0])
+		fmt.Println("")
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println("")
+		fmt.Println("Examples:")
+		fmt.Printf("  %s query --filter 'license=Apache 2.0 && task=text-generation'\n", os.Args[0])
+		fmt.Printf("  %s query --catalog data/models-catalog.json --filter 'name~granite' --output json\n", os.Args[0])
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *outputFormat != "table" && *outputFormat != "json" {
+		return fmt.Errorf("invalid --output %q (must be table or json)", *outputFormat)
+	}
+
+	query, err := catalog.ParseQuery(*filter)
+	if err != nil {
+		return fmt.Errorf("invalid --filter: %v", err)
+	}
+	models, err := catalog.ReadCatalog(*catalogPath)
+	if err != nil {
+		return err
+	}
+	matches := query.Filter(models.Models)
+
+	if *outputFormat == "json" {
+		data, err := catalog.EncodeCatalog(&types.ModelsCatalog{Source: models.Source, Models: matches}, catalog.CatalogFormatJSON)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NAME\tPROVIDER\tLICENSE\tTASKS")
+	for _, model := range matches {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", queryCell(model.Name), queryCell(model.Provider), queryCell(model.License), strings.Join(model.Tasks, ","))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(out, "\n%d of %d models\n", len(matches), len(models.Models))
+	return err
+}
+
+// queryCell returns the table cell of an optional field, "-" when unset
+func queryCell(value *string) string {
+	if value == nil || *value == "" {
+		return "-"
+	}
+	return *value
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	containertypes "github.com/containers/image/v5/types"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/catalog"
+	"github.com/opendatahub-io/model-metadata-collection/internal/extraction"
+	"github.com/opendatahub-io/model-metadata-collection/internal/repack"
+)
+
+// runRepack implements the repack subcommand, which writes the readme and metadata the catalog has
+// for a model back into its modelcar image as a modelcard layer
+func runRepack(args []string) error {
+	fs := flag.NewFlagSet("repack", flag.ExitOnError)
+	from := fs.String("from", "", "Modelcar image to repack, e.g. registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5 (required)")
+	to := fs.String("to", "", "Destination reference of the repacked image, e.g. quay.io/org/modelcar-granite:1.5 or oci:/path/to/layout:tag (required)")
+	catalogPath := fs.String("catalog", "data/models-catalog.yaml", "Generated models catalog holding the model's readme and metadata")
+	readmePath := fs.String("readme", "", "Write this file as the modelcard instead of the catalog readme")
+	replace := fs.Bool("replace", false, "Replace an existing modelcard layer instead of refusing to repack the image")
+	modelcardAnnotations := fs.String("modelcard-annotations", extraction.DefaultModelcardAnnotation.String(), "Comma-separated layer annotations marking modelcard layers, as taken by extraction; a layer carrying any of them is an existing modelcard, and the new layer is annotated with the first")
+	authFile := fs.String("authfile", "", "Path to a registry auth file (defaults to the standard container auth locations)")
+	insecure := fs.Bool("insecure", false, "Skip TLS verification when pulling from and pushing to registries")
+	redHatCreds := fs.String("redhat-registry-credentials", "", "YAML file with the username and token of a registry.redhat.io service account to pull the source image with (defaults to $REDHAT_REGISTRY_USERNAME and $REDHAT_REGISTRY_TOKEN)")
+	fs.Usage = func() {
+		fmt.Println("Add a modelcard layer to a modelcar image that shipped without one")
+		fmt.Println("")
+		fmt.Println("Usage:")
+		fmt.Printf("  %s repack --from <reference> --to <reference> [options]\n", os.Args[0])
+		fmt.Println("")
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println("")
+		fmt.Println("Examples:")
+		fmt.Printf("  %s repack --from registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5 --to quay.io/org/modelcar-granite-3-1-8b-instruct:1.5\n", os.Args[0])
+		fmt.Printf("  %s repack --from quay.io/org/modelcar-granite:1.5 --to quay.io/org/modelcar-granite:1.5 --readme granite.md --replace\n", os.Args[0])
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *from == "" || *to == "" {
+		fs.Usage()
+		return fmt.Errorf("--from and --to are required")
+	}
+
+	annotations, err := extraction.ParseLayerAnnotations(*modelcardAnnotations)
+	if err != nil {
+		return fmt.Errorf("invalid --modelcard-annotations: %v", err)
+	}
+
+	var modelcard []byte
+	if *readmePath != "" {
+		content, err := os.ReadFile(*readmePath)
+		if err != nil {
+			return fmt.Errorf("failed to read readme: %v", err)
+		}
+		modelcard = content
+	} else {
+		models, err := catalog.ReadCatalog(*catalogPath)
+		if err != nil {
+			return err
+		}
+		model, ok := repack.FindModel(models, *from)
+		if !ok {
+			return fmt.Errorf("%s has no model with artifact %s; use --readme to provide the modelcard", *catalogPath, *from)
+		}
+		if modelcard, err = repack.Modelcard(model); err != nil {
+			return err
+		}
+	}
+
+	registryAuth, err := loadRegistryAuth(*redHatCreds)
+	if err != nil {
+		return fmt.Errorf("invalid registry.redhat.io credentials: %v", err)
+	}
+
+	sys := &containertypes.SystemContext{AuthFilePath: *authFile}
+	if *insecure {
+		sys.DockerInsecureSkipTLSVerify = containertypes.OptionalBoolTrue
+	}
+
+	log.Printf("Repacking %s with a %d byte modelcard to %s", *from, len(modelcard), *to)
+
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+
+	manifestDigest, err := repack.Repack(ctx, repack.Options{
+		Source:               *from,
+		Destination:          *to,
+		Modelcard:            modelcard,
+		Replace:              *replace,
+		ModelcardAnnotations: annotations,
+		SystemContext:        sys,
+		RegistryAuth:         registryAuth,
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Pushed repacked modelcar %s@%s", *to, manifestDigest)
+	return nil
+}
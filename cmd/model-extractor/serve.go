@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/metrics"
+	"github.com/opendatahub-io/model-metadata-collection/internal/serve"
+)
+
+// runServe implements the serve subcommand, which keeps the process running and refreshes the
+// catalog periodically for an in-cluster, continuously updated catalog
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	interval := fs.Duration("interval", 6*time.Hour, "Time between catalog refreshes, e.g. 30m or 6h")
+	serveOutputDir := fs.String("output-dir", "output", "Output directory, kept as a symlink that is swapped to the new output after each refresh")
+	catalogName := fs.String("catalog-name", "models-catalog.yaml", "File name of the models catalog written inside the output directory")
+	listenAddr := fs.String("listen", ":8080", "Address of the HTTP server exposing the catalog REST API and /metrics (empty disables)")
+	modelsIndex := fs.String("input", "data/models-index.yaml", "Path to the models index; models whose manifest digest changed are extracted again")
+	redHatCreds := fs.String("redhat-registry-credentials", "", "YAML file with the username and token of a registry.redhat.io service account the manifest digests are looked up with (defaults to $REDHAT_REGISTRY_USERNAME and $REDHAT_REGISTRY_TOKEN); pass it to the pipeline options too")
+	fs.Usage = func() {
+		fmt.Println("Refresh the models catalog periodically, re-extracting only changed models")
+		fmt.Println("")
+		fmt.Println("Usage:")
+		fmt.Printf("  %s serve [options] [-- pipeline options]\n", os.Args[0])
+		fmt.Println("")
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println("")
+		fmt.Println("Examples:")
+		fmt.Printf("  %s serve --interval 6h\n", os.Args[0])
+		fmt.Printf("  %s serve --interval 1h --output-dir /data/catalog -- --config pipeline.yaml\n", os.Args[0])
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *interval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+	registryAuth, err := loadRegistryAuth(*redHatCreds)
+	if err != nil {
+		return fmt.Errorf("invalid registry.redhat.io credentials: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	registry := metrics.NewRegistry()
+	catalog := serve.NewCatalog()
+	// Serve the previous output until the first refresh completes
+	catalogPath := filepath.Join(*serveOutputDir, *catalogName)
+	if _, err := os.Stat(catalogPath); err == nil {
+		if err := catalog.Load(catalogPath); err != nil {
+			log.Printf("Warning: Failed to load the existing catalog: %v", err)
+		}
+	}
+	if *listenAddr != "" {
+		server := &http.Server{
+			Addr:              *listenAddr,
+			Handler:           serve.NewHandler(registry, catalog),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() {
+			log.Printf("Serving the catalog API and metrics on %s", *listenAddr)
+			if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Printf("Warning: HTTP server stopped: %v", err)
+			}
+		}()
+		defer func() {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			_ = server.Shutdown(shutdownCtx)
+		}()
+	}
+
+	return serve.Run(ctx, serve.Options{
+		OutputDir:    *serveOutputDir,
+		CatalogName:  *catalogName,
+		ModelsIndex:  *modelsIndex,
+		PipelineArgs: fs.Args(),
+		Metrics:      registry,
+		Catalog:      catalog,
+		RegistryAuth: registryAuth,
+	}, *interval)
+}
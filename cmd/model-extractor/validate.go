@@ -0,0 +1,62 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/catalog"
+)
+
+// runValidate implements the validate subcommand, which checks static catalog files without
+// running an extraction. Each problem is written to out as a "file:line: message" line, and an
+// error is returned when any file has problems.
+func runValidate(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Println("Check static catalog files such as supplemental-catalog.yaml")
+		fmt.Println("")
+		fmt.Println("Usage:")
+		fmt.Printf("  %s validate <file>...\n", os.Args[0])
+		fmt.Println("")
+		fmt.Println("Examples:")
+		fmt.Printf("  %s validate input/supplemental-catalog.yaml\n", os.Args[0])
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return fmt.Errorf("no static catalog files given")
+	}
+
+	problems := 0
+	failedFiles := 0
+	for _, path := range fs.Args() {
+		issues, err := catalog.ValidateStaticCatalogFile(path)
+		if err != nil {
+			issues = []catalog.StaticCatalogIssue{{Message: err.Error()}}
+		}
+		if len(issues) == 0 {
+			_, _ = fmt.Fprintf(out, "%s: ok\n", path)
+			continue
+		}
+
+		failedFiles++
+		problems += len(issues)
+		for _, issue := range issues {
+			if issue.Line > 0 {
+				_, _ = fmt.Fprintf(out, "%s:%d: %s\n", path, issue.Line, issue.Message)
+			} else {
+				_, _ = fmt.Fprintf(out, "%s: %s\n", path, issue.Message)
+			}
+		}
+	}
+
+	if problems > 0 {
+		return fmt.Errorf("found %d problems in %d of %d files", problems, failedFiles, fs.NArg())
+	}
+	return nil
+}
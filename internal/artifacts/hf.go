@@ -0,0 +1,63 @@
+package artifacts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/huggingface"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// ArtifactTypeHF is the "type" custom property of artifacts that are HuggingFace repositories
+const ArtifactTypeHF = "hf"
+
+// fetchModelRevision fetches the metadata of a HuggingFace repository revision; replaced in tests
+var fetchModelRevision = (*huggingface.Client).FetchModelRevision
+
+// DescribeHF describes the HuggingFace repository at an hf:// URI: the commit its revision points
+// at and the time it was last modified. The model metadata itself is left to enrichment.
+func DescribeHF(ctx context.Context, hf *huggingface.Client, uri string) (*types.OCIArtifact, error) {
+	parsed, err := utils.ParseArtifactURI(uri)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Scheme != utils.URISchemeHF {
+		return nil, fmt.Errorf("artifact URI %q is not an hf:// URI", uri)
+	}
+
+	details, err := fetchModelRevision(hf, ctx, parsed.Repository, parsed.Revision)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe %s: %v", parsed.Repository, err)
+	}
+
+	props := map[string]interface{}{
+		"type":   map[string]interface{}{"string_value": ArtifactTypeHF},
+		"source": map[string]interface{}{"string_value": "huggingface.co"},
+	}
+	if details.Sha != "" {
+		props["revision"] = map[string]interface{}{"string_value": details.Sha}
+	}
+
+	artifact := &types.OCIArtifact{URI: parsed.String(), CustomProperties: props}
+	if !details.CreatedAt.IsZero() {
+		created := details.CreatedAt.UnixMilli()
+		artifact.CreateTimeSinceEpoch = &created
+	}
+	if modified, err := time.Parse(time.RFC3339, details.LastModified); err == nil {
+		updated := modified.UnixMilli()
+		artifact.LastUpdateTimeSinceEpoch = &updated
+	}
+	return artifact, nil
+}
+
+// HFRevision identifies the current content of the HuggingFace repository at an hf:// URI by the
+// commit its revision points at, so callers can tell when it changed
+func HFRevision(ctx context.Context, hf *huggingface.Client, uri string) (string, error) {
+	artifact, err := DescribeHF(ctx, hf, uri)
+	if err != nil {
+		return "", err
+	}
+	return Revision(artifact)
+}
@@ -0,0 +1,53 @@
+package artifacts
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/huggingface"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestDescribeHF(t *testing.T) {
+	created := time.Date(2024, 12, 18, 9, 0, 0, 0, time.UTC)
+	var gotRepository, gotRevision string
+	previous := fetchModelRevision
+	fetchModelRevision = func(_ *huggingface.Client, _ context.Context, modelName, revision string) (*types.HFModelDetails, error) {
+		gotRepository, gotRevision = modelName, revision
+		if modelName != "ibm-granite/granite-3.1-8b-instruct" {
+			return nil, fmt.Errorf("API returned status 404")
+		}
+		return &types.HFModelDetails{ID: modelName, Sha: "3f05a8d5", CreatedAt: created, LastModified: "2025-01-07T12:00:00.000Z"}, nil
+	}
+	t.Cleanup(func() { fetchModelRevision = previous })
+
+	artifact, err := DescribeHF(context.Background(), nil, "HF://ibm-granite/granite-3.1-8b-instruct:v1.0")
+	if err != nil {
+		t.Fatalf("DescribeHF() error = %v", err)
+	}
+	if gotRepository != "ibm-granite/granite-3.1-8b-instruct" || gotRevision != "v1.0" {
+		t.Errorf("fetched %s at %q", gotRepository, gotRevision)
+	}
+	if artifact.URI != "hf://ibm-granite/granite-3.1-8b-instruct:v1.0" {
+		t.Errorf("URI = %q", artifact.URI)
+	}
+	props := artifact.CustomProperties
+	if stringProp(props, "type") != ArtifactTypeHF || stringProp(props, "source") != "huggingface.co" || stringProp(props, "revision") != "3f05a8d5" {
+		t.Errorf("Unexpected properties: %+v", props)
+	}
+	if artifact.CreateTimeSinceEpoch == nil || *artifact.CreateTimeSinceEpoch != created.UnixMilli() {
+		t.Errorf("CreateTimeSinceEpoch = %v", artifact.CreateTimeSinceEpoch)
+	}
+	if artifact.LastUpdateTimeSinceEpoch == nil || *artifact.LastUpdateTimeSinceEpoch != time.Date(2025, 1, 7, 12, 0, 0, 0, time.UTC).UnixMilli() {
+		t.Errorf("LastUpdateTimeSinceEpoch = %v", artifact.LastUpdateTimeSinceEpoch)
+	}
+
+	if revision, err := HFRevision(context.Background(), nil, "hf://ibm-granite/granite-3.1-8b-instruct"); err != nil || revision != "3f05a8d5" {
+		t.Errorf("HFRevision() = %q, %v", revision, err)
+	}
+	if _, err := DescribeHF(context.Background(), nil, "hf://ibm-granite/missing"); err == nil {
+		t.Error("DescribeHF() described a missing repository")
+	}
+}
@@ -0,0 +1,195 @@
+// Package artifacts describes model artifacts hosted outside container registries, such as
+// weights downloadable over HTTPS or stored in S3 buckets and HuggingFace repositories, as
+// catalog artifacts.
+package artifacts
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/httpclient"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// ArtifactTypeHTTPS is the "type" custom property of artifacts downloadable over HTTPS
+const ArtifactTypeHTTPS = "https"
+
+// maxRedirects bounds the redirects followed by DescribeHTTPS
+const maxRedirects = 10
+
+// httpsClient does not follow redirects itself, so headers of intermediate responses such as
+// HuggingFace's X-Linked-Etag are not lost
+var httpsClient = func() *http.Client {
+	client := httpclient.New("artifacts", 30*time.Second)
+	client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	return client
+}()
+
+// sha256HexPattern matches a hex-encoded SHA-256 digest
+var sha256HexPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// weightFormats maps file extensions to the weight formats recorded on artifacts
+var weightFormats = map[string]string{
+	".gguf":        "gguf",
+	".safetensors": "safetensors",
+	".bin":         "pytorch",
+	".pt":          "pytorch",
+	".pth":         "pytorch",
+	".onnx":        "onnx",
+	".tar":         "tar",
+	".gz":          "tar.gz",
+	".zip":         "zip",
+}
+
+// DescribeHTTPS describes the file at an https:// URL from a HEAD request: its size, its SHA-256
+// checksum when the server reports one, and its last modification time
+func DescribeHTTPS(ctx context.Context, rawURL string) (*types.OCIArtifact, error) {
+	parsed, err := utils.ParseArtifactURI(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Scheme != utils.URISchemeHTTPS {
+		return nil, fmt.Errorf("artifact URI %q is not an https:// URL", rawURL)
+	}
+
+	props := map[string]interface{}{
+		"type": map[string]interface{}{"string_value": ArtifactTypeHTTPS},
+	}
+	target := parsed.URL
+	for redirects := 0; ; redirects++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodHead, target, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := httpsClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("HEAD %s failed: %v", target, err)
+		}
+		_ = resp.Body.Close()
+
+		// Headers of redirects describe the file as well, e.g. those of a HuggingFace resolve URL
+		// redirecting to its CDN
+		recordHeaders(props, resp.Header)
+		if resp.StatusCode >= 300 && resp.StatusCode < 400 && resp.Header.Get("Location") != "" {
+			if redirects == maxRedirects {
+				return nil, fmt.Errorf("HEAD %s: too many redirects", rawURL)
+			}
+			next, err := resp.Request.URL.Parse(resp.Header.Get("Location"))
+			if err != nil {
+				return nil, fmt.Errorf("HEAD %s: invalid redirect: %v", target, err)
+			}
+			target = next.String()
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("HEAD %s returned status %d", target, resp.StatusCode)
+		}
+		if _, ok := props["size"]; !ok && resp.ContentLength >= 0 {
+			props["size"] = resp.ContentLength
+		}
+		break
+	}
+
+	u, _ := url.Parse(parsed.URL)
+	props["source"] = map[string]interface{}{"string_value": u.Hostname()}
+	if format, ok := weightFormats[strings.ToLower(path.Ext(u.Path))]; ok {
+		props["format"] = map[string]interface{}{"string_value": format}
+	}
+
+	artifact := &types.OCIArtifact{URI: parsed.URL, CustomProperties: props}
+	if modified, ok := props["lastModified"].(int64); ok {
+		artifact.CreateTimeSinceEpoch = &modified
+		artifact.LastUpdateTimeSinceEpoch = &modified
+		delete(props, "lastModified")
+	}
+	return artifact, nil
+}
+
+// recordHeaders adds the size, checksum, ETag and modification time reported by response headers
+// to props, keeping values recorded from earlier responses
+func recordHeaders(props map[string]interface{}, header http.Header) {
+	setOnce := func(key string, value interface{}) {
+		if _, ok := props[key]; !ok {
+			props[key] = value
+		}
+	}
+
+	if size, err := strconv.ParseInt(header.Get("X-Linked-Size"), 10, 64); err == nil {
+		setOnce("size", size)
+	}
+	if checksum := checksumFromHeaders(header); checksum != "" {
+		setOnce("checksum", map[string]interface{}{"string_value": checksum})
+	}
+	if etag := strings.Trim(strings.TrimPrefix(header.Get("ETag"), "W/"), `"`); etag != "" {
+		setOnce("etag", map[string]interface{}{"string_value": etag})
+	}
+	if modified, err := http.ParseTime(header.Get("Last-Modified")); err == nil {
+		setOnce("lastModified", modified.UnixMilli())
+	}
+}
+
+// checksumFromHeaders returns the SHA-256 digest ("sha256:<hex>") a response reports for its
+// content, from RFC 9530 Repr-Digest, RFC 3230 Digest, S3 checksum or HuggingFace LFS headers
+func checksumFromHeaders(header http.Header) string {
+	for _, name := range []string{"Repr-Digest", "Digest"} {
+		for _, field := range strings.Split(header.Get(name), ",") {
+			algorithm, value, found := strings.Cut(strings.TrimSpace(field), "=")
+			if !found || !strings.EqualFold(algorithm, "sha-256") {
+				continue
+			}
+			if checksum := base64SHA256(strings.Trim(value, ":")); checksum != "" {
+				return checksum
+			}
+		}
+	}
+	if checksum := base64SHA256(header.Get("X-Amz-Checksum-Sha256")); checksum != "" {
+		return checksum
+	}
+	// HuggingFace reports the SHA-256 of LFS files as their linked ETag
+	if etag := strings.Trim(header.Get("X-Linked-Etag"), `"`); sha256HexPattern.MatchString(etag) {
+		return "sha256:" + etag
+	}
+	return ""
+}
+
+// base64SHA256 converts a base64-encoded SHA-256 digest to "sha256:<hex>", or returns ""
+func base64SHA256(value string) string {
+	digest, err := base64.StdEncoding.DecodeString(value)
+	if err != nil || len(digest) != 32 {
+		return ""
+	}
+	return "sha256:" + hex.EncodeToString(digest)
+}
+
+// HTTPSRevision identifies the current content of the file at an https:// URL by its checksum,
+// or its ETag when the server reports no checksum, so callers can tell when it changed
+func HTTPSRevision(ctx context.Context, rawURL string) (string, error) {
+	artifact, err := DescribeHTTPS(ctx, rawURL)
+	if err != nil {
+		return "", err
+	}
+	return Revision(artifact)
+}
+
+// Revision identifies the content of a described artifact by the commit of HuggingFace
+// repositories, or the checksum of files, or their ETag when they have no checksum
+func Revision(artifact *types.OCIArtifact) (string, error) {
+	for _, key := range []string{"revision", "checksum", "etag"} {
+		if value, ok := artifact.CustomProperties[key].(map[string]interface{}); ok {
+			if revision, _ := value["string_value"].(string); revision != "" {
+				return revision, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("%s reports neither a commit, a checksum nor an ETag", artifact.URI)
+}
@@ -0,0 +1,103 @@
+package artifacts
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// useTestServer sends the requests of DescribeHTTPS to server for the duration of the test
+func useTestServer(t *testing.T, server *httptest.Server) {
+	t.Helper()
+	client := server.Client()
+	client.CheckRedirect = httpsClient.CheckRedirect
+	previous := httpsClient
+	httpsClient = client
+	t.Cleanup(func() { httpsClient = previous })
+}
+
+func stringProp(props map[string]interface{}, key string) string {
+	value, _ := props[key].(map[string]interface{})
+	s, _ := value["string_value"].(string)
+	return s
+}
+
+func TestDescribeHTTPS_HuggingFaceRedirect(t *testing.T) {
+	const sha = "6a1f3e5b0c9d2e4f8a7b6c5d4e3f2a1b0c9d8e7f6a5b4c3d2e1f0a9b8c7d6e5f"
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("method = %s, want HEAD", r.Method)
+		}
+		switch r.URL.Path {
+		case "/ibm-granite/granite-3.1-8b-instruct-GGUF/resolve/main/granite-3.1-8b-instruct-Q4_K_M.gguf":
+			w.Header().Set("X-Linked-Etag", `"`+sha+`"`)
+			w.Header().Set("X-Linked-Size", "4942856128")
+			w.Header().Set("Location", "/cdn/granite.gguf")
+			w.WriteHeader(http.StatusFound)
+		case "/cdn/granite.gguf":
+			w.Header().Set("Content-Length", "4942856128")
+			w.Header().Set("Last-Modified", "Tue, 17 Dec 2024 10:00:00 GMT")
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	useTestServer(t, server)
+
+	artifact, err := DescribeHTTPS(context.Background(), server.URL+"/ibm-granite/granite-3.1-8b-instruct-GGUF/resolve/main/granite-3.1-8b-instruct-Q4_K_M.gguf")
+	if err != nil {
+		t.Fatalf("DescribeHTTPS() error = %v", err)
+	}
+	props := artifact.CustomProperties
+	if size, _ := props["size"].(int64); size != 4942856128 {
+		t.Errorf("size = %v", props["size"])
+	}
+	if got := stringProp(props, "checksum"); got != "sha256:"+sha {
+		t.Errorf("checksum = %q", got)
+	}
+	if stringProp(props, "type") != ArtifactTypeHTTPS || stringProp(props, "format") != "gguf" || stringProp(props, "source") != "127.0.0.1" {
+		t.Errorf("custom properties = %v", props)
+	}
+	if artifact.LastUpdateTimeSinceEpoch == nil || *artifact.LastUpdateTimeSinceEpoch != 1734429600000 {
+		t.Errorf("last update = %v, want the Last-Modified time", artifact.LastUpdateTimeSinceEpoch)
+	}
+	if _, ok := props["lastModified"]; ok {
+		t.Error("lastModified is left in the custom properties")
+	}
+}
+
+func TestDescribeHTTPS_DigestHeaders(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repr.safetensors":
+			// sha-256 of "hello world"
+			w.Header().Set("Repr-Digest", "sha-512=:AAAA:, sha-256=:uU0nuZNNPgilLlLX2n2r+sSE7+N6U4DukIj3rOLvzek=:")
+			w.Header().Set("Content-Length", "11")
+		case "/etag.bin":
+			w.Header().Set("ETag", `"9b2cf535f27731c974343645a3985328"`)
+			w.Header().Set("Content-Length", "11")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	useTestServer(t, server)
+	ctx := context.Background()
+
+	revision, err := HTTPSRevision(ctx, server.URL+"/repr.safetensors")
+	if err != nil || revision != "sha256:b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9" {
+		t.Errorf("HTTPSRevision() = %q, %v, want the Repr-Digest checksum", revision, err)
+	}
+	revision, err = HTTPSRevision(ctx, server.URL+"/etag.bin")
+	if err != nil || revision != "9b2cf535f27731c974343645a3985328" {
+		t.Errorf("HTTPSRevision() = %q, %v, want the ETag", revision, err)
+	}
+	if _, err := DescribeHTTPS(ctx, server.URL+"/missing.gguf"); err == nil {
+		t.Error("DescribeHTTPS() succeeded for a missing file")
+	}
+	if _, err := DescribeHTTPS(ctx, "registry.redhat.io/rhelai1/modelcar-granite:1.5"); err == nil {
+		t.Error("DescribeHTTPS() accepted an image reference")
+	}
+}
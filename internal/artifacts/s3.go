@@ -0,0 +1,68 @@
+package artifacts
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/objectstore"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// ArtifactTypeS3 is the "type" custom property of artifacts stored in S3 buckets
+const ArtifactTypeS3 = "s3"
+
+// statS3Object describes an S3 object; replaced in tests
+var statS3Object = objectstore.StatS3Object
+
+// DescribeS3 describes the object at an s3:// URI from the S3 API: its size, ETag, SHA-256
+// checksum when it was uploaded with one, and its last modification time
+func DescribeS3(ctx context.Context, uri string) (*types.OCIArtifact, error) {
+	parsed, err := utils.ParseArtifactURI(uri)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Scheme != utils.URISchemeS3 {
+		return nil, fmt.Errorf("artifact URI %q is not an s3:// URI", uri)
+	}
+
+	info, err := statS3Object(ctx, parsed.Bucket, parsed.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	props := map[string]interface{}{
+		"type":   map[string]interface{}{"string_value": ArtifactTypeS3},
+		"source": map[string]interface{}{"string_value": parsed.Bucket},
+		"size":   info.Size,
+	}
+	if info.ETag != "" {
+		props["etag"] = map[string]interface{}{"string_value": info.ETag}
+	}
+	if checksum := base64SHA256(info.ChecksumSHA256); checksum != "" {
+		props["checksum"] = map[string]interface{}{"string_value": checksum}
+	}
+	if format, ok := weightFormats[strings.ToLower(path.Ext(parsed.Key))]; ok {
+		props["format"] = map[string]interface{}{"string_value": format}
+	}
+
+	artifact := &types.OCIArtifact{URI: parsed.String(), CustomProperties: props}
+	if !info.LastModified.IsZero() {
+		modified := info.LastModified.UnixMilli()
+		artifact.CreateTimeSinceEpoch = &modified
+		artifact.LastUpdateTimeSinceEpoch = &modified
+	}
+	return artifact, nil
+}
+
+// S3Revision identifies the current content of the object at an s3:// URI by its checksum, or its
+// ETag when it was uploaded without one, so callers can tell when it changed
+func S3Revision(ctx context.Context, uri string) (string, error) {
+	artifact, err := DescribeS3(ctx, uri)
+	if err != nil {
+		return "", err
+	}
+	return Revision(artifact)
+}
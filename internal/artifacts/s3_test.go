@@ -0,0 +1,70 @@
+package artifacts
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/objectstore"
+)
+
+// useObjects answers the S3 requests of DescribeS3 from objects, keyed by bucket/key, for the
+// duration of the test
+func useObjects(t *testing.T, objects map[string]*objectstore.ObjectInfo) {
+	t.Helper()
+	previous := statS3Object
+	statS3Object = func(_ context.Context, bucket, key string) (*objectstore.ObjectInfo, error) {
+		if info, ok := objects[bucket+"/"+key]; ok {
+			return info, nil
+		}
+		return nil, fmt.Errorf("s3://%s/%s does not exist", bucket, key)
+	}
+	t.Cleanup(func() { statS3Object = previous })
+}
+
+func TestDescribeS3(t *testing.T) {
+	modified := time.Date(2025, 10, 21, 7, 28, 0, 0, time.UTC)
+	useObjects(t, map[string]*objectstore.ObjectInfo{
+		"models-bucket/granite/granite-3.1-8b-instruct.safetensors": {
+			Size:           16340000000,
+			ETag:           "9b2cf535f27731c974343645a3985328-1950",
+			LastModified:   modified,
+			ChecksumSHA256: "n4bQgYhMfWWaL+qgxVrQFaO/TxsrC4Is0V1sFbDwCgg=",
+		},
+		"models-bucket/granite/model.bin": {Size: 42, ETag: "d41d8cd98f00b204e9800998ecf8427e"},
+	})
+
+	artifact, err := DescribeS3(context.Background(), "S3://models-bucket/granite/granite-3.1-8b-instruct.safetensors")
+	if err != nil {
+		t.Fatalf("DescribeS3() error = %v", err)
+	}
+	if artifact.URI != "s3://models-bucket/granite/granite-3.1-8b-instruct.safetensors" {
+		t.Errorf("URI = %q", artifact.URI)
+	}
+	props := artifact.CustomProperties
+	if stringProp(props, "type") != ArtifactTypeS3 || stringProp(props, "source") != "models-bucket" || stringProp(props, "format") != "safetensors" {
+		t.Errorf("Unexpected properties: %+v", props)
+	}
+	if props["size"] != int64(16340000000) || stringProp(props, "etag") != "9b2cf535f27731c974343645a3985328-1950" {
+		t.Errorf("size = %v, etag = %q", props["size"], stringProp(props, "etag"))
+	}
+	if checksum := stringProp(props, "checksum"); checksum != "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08" {
+		t.Errorf("checksum = %q", checksum)
+	}
+	if artifact.CreateTimeSinceEpoch == nil || *artifact.CreateTimeSinceEpoch != modified.UnixMilli() {
+		t.Errorf("CreateTimeSinceEpoch = %v, want %d", artifact.CreateTimeSinceEpoch, modified.UnixMilli())
+	}
+
+	// Objects uploaded without a checksum are identified by their ETag
+	if revision, err := S3Revision(context.Background(), "s3://models-bucket/granite/model.bin"); err != nil || revision != "d41d8cd98f00b204e9800998ecf8427e" {
+		t.Errorf("S3Revision() = %q, %v", revision, err)
+	}
+
+	if _, err := DescribeS3(context.Background(), "s3://models-bucket/missing.gguf"); err == nil {
+		t.Error("DescribeS3() described a missing object")
+	}
+	if _, err := DescribeS3(context.Background(), "https://example.com/model.gguf"); err == nil {
+		t.Error("DescribeS3() accepted an https:// URL")
+	}
+}
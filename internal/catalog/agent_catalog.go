@@ -1,6 +1,7 @@
 package catalog
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -19,8 +20,9 @@ import (
 )
 
 // CreateAgentsCatalog reads an agents index file, fetches metadata from GitHub
-// for each agent, and writes an aggregated catalog YAML.
-func CreateAgentsCatalog(indexPath, catalogPath, branchOverride string, skipEnrichment bool) error {
+// for each agent, and writes an aggregated catalog YAML. Canceling ctx aborts the
+// GitHub requests and stops without writing the catalog.
+func CreateAgentsCatalog(ctx context.Context, indexPath, catalogPath, branchOverride string, skipEnrichment bool) error {
 	indexData, err := os.ReadFile(indexPath)
 	if err != nil {
 		return fmt.Errorf("error reading agents index file %s: %v", indexPath, err)
@@ -48,7 +50,7 @@ func CreateAgentsCatalog(indexPath, catalogPath, branchOverride string, skipEnri
 	// (e.g. releases/rhoai-2.18).
 	var rawRef string
 	if !skipEnrichment {
-		sha, err := github.ValidateBranch(index.Repository, index.Branch)
+		sha, err := github.ValidateBranch(ctx, index.Repository, index.Branch)
 		if err != nil {
 			return fmt.Errorf("branch validation failed: %v", err)
 		}
@@ -57,7 +59,10 @@ func CreateAgentsCatalog(indexPath, catalogPath, branchOverride string, skipEnri
 
 	var agents []types.AgentMetadata
 	for _, entry := range index.Agents {
-		agent, err := buildAgentMetadata(index.Repository, index.Branch, rawRef, entry, skipEnrichment)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		agent, err := buildAgentMetadata(ctx, index.Repository, index.Branch, rawRef, entry, skipEnrichment)
 		if err != nil {
 			log.Printf("Warning: skipping agent at path %q: %v", entry.Path, err)
 			continue
@@ -93,11 +98,11 @@ func CreateAgentsCatalog(indexPath, catalogPath, branchOverride string, skipEnri
 // falling back to inline overrides from the index entry.
 // rawRef is the commit SHA used for raw.githubusercontent.com URLs (safe for
 // slash-containing branch names); branch is used for the human-readable tree URL.
-func buildAgentMetadata(repo, branch, rawRef string, entry types.AgentIndexEntry, skipEnrichment bool) (*types.AgentMetadata, error) {
+func buildAgentMetadata(ctx context.Context, repo, branch, rawRef string, entry types.AgentIndexEntry, skipEnrichment bool) (*types.AgentMetadata, error) {
 	agent := &types.AgentMetadata{}
 
 	if !skipEnrichment {
-		upstream, err := github.FetchAgentYAML(repo, rawRef, entry.Path)
+		upstream, err := github.FetchAgentYAML(ctx, repo, rawRef, entry.Path)
 		if errors.Is(err, github.ErrNotFound) {
 			log.Printf("  No agent.yaml at %s, using index overrides", entry.Path)
 		} else if err != nil {
@@ -118,7 +123,7 @@ func buildAgentMetadata(repo, branch, rawRef string, entry types.AgentIndexEntry
 		if entry.ReadmePath != "" {
 			readmePath = entry.ReadmePath
 		}
-		readme, err := github.FetchReadme(repo, rawRef, readmePath)
+		readme, err := github.FetchReadme(ctx, repo, rawRef, readmePath)
 		if err != nil {
 			log.Printf("  Warning: failed to fetch README for %s: %v", readmePath, err)
 		} else if readme != "" {
@@ -197,10 +202,7 @@ func forwardExtraAsCustomProperties(agent *types.AgentMetadata, extra map[string
 			}
 			strVal = string(jsonBytes)
 		}
-		agent.CustomProperties[key] = types.MetadataValue{
-			MetadataType: "MetadataStringValue",
-			StringValue:  strVal,
-		}
+		agent.CustomProperties[key] = types.NewStringValue(strVal)
 	}
 }
 
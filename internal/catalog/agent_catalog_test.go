@@ -1,6 +1,7 @@
 package catalog
 
 import (
+	"context"
 	"encoding/json"
 	"os"
 	"path/filepath"
@@ -112,7 +113,7 @@ func TestLabelsLogoMapping(t *testing.T) {
 	writeYAML(t, indexPath, index)
 
 	catalogPath := filepath.Join(tmpDir, "catalog.yaml")
-	err := CreateAgentsCatalog(indexPath, catalogPath, "", true)
+	err := CreateAgentsCatalog(context.Background(), indexPath, catalogPath, "", true)
 	if err != nil {
 		t.Fatalf("CreateAgentsCatalog failed: %v", err)
 	}
@@ -277,7 +278,7 @@ func TestCreateAgentsCatalogSkipEnrichment(t *testing.T) {
 	writeYAML(t, indexPath, index)
 
 	catalogPath := filepath.Join(tmpDir, "catalog.yaml")
-	err := CreateAgentsCatalog(indexPath, catalogPath, "", true)
+	err := CreateAgentsCatalog(context.Background(), indexPath, catalogPath, "", true)
 	if err != nil {
 		t.Fatalf("CreateAgentsCatalog failed: %v", err)
 	}
@@ -310,7 +311,7 @@ func TestCreateAgentsCatalogSkipEnrichment(t *testing.T) {
 
 func TestCreateAgentsCatalogMissingIndex(t *testing.T) {
 	tmpDir := t.TempDir()
-	err := CreateAgentsCatalog(filepath.Join(tmpDir, "nonexistent.yaml"), filepath.Join(tmpDir, "catalog.yaml"), "", true)
+	err := CreateAgentsCatalog(context.Background(), filepath.Join(tmpDir, "nonexistent.yaml"), filepath.Join(tmpDir, "catalog.yaml"), "", true)
 	if err == nil {
 		t.Fatal("expected error for missing index file")
 	}
@@ -326,7 +327,7 @@ func TestCreateAgentsCatalogMissingRepository(t *testing.T) {
 	indexPath := filepath.Join(tmpDir, "index.yaml")
 	writeYAML(t, indexPath, index)
 
-	err := CreateAgentsCatalog(indexPath, filepath.Join(tmpDir, "catalog.yaml"), "", true)
+	err := CreateAgentsCatalog(context.Background(), indexPath, filepath.Join(tmpDir, "catalog.yaml"), "", true)
 	if err == nil {
 		t.Fatal("expected error for missing repository field")
 	}
@@ -345,7 +346,7 @@ func TestCreateAgentsCatalogEmptyIndex(t *testing.T) {
 	writeYAML(t, indexPath, index)
 
 	catalogPath := filepath.Join(tmpDir, "catalog.yaml")
-	err := CreateAgentsCatalog(indexPath, catalogPath, "", true)
+	err := CreateAgentsCatalog(context.Background(), indexPath, catalogPath, "", true)
 	if err != nil {
 		t.Fatalf("CreateAgentsCatalog failed: %v", err)
 	}
@@ -387,7 +388,7 @@ func TestCreateAgentsCatalogDefaultBranch(t *testing.T) {
 	writeYAML(t, indexPath, index)
 
 	catalogPath := filepath.Join(tmpDir, "catalog.yaml")
-	err := CreateAgentsCatalog(indexPath, catalogPath, "", true)
+	err := CreateAgentsCatalog(context.Background(), indexPath, catalogPath, "", true)
 	if err != nil {
 		t.Fatalf("CreateAgentsCatalog failed: %v", err)
 	}
@@ -430,7 +431,7 @@ func TestCreateAgentsCatalogBranchOverride(t *testing.T) {
 	writeYAML(t, indexPath, index)
 
 	catalogPath := filepath.Join(tmpDir, "catalog.yaml")
-	err := CreateAgentsCatalog(indexPath, catalogPath, "release-3.5", true)
+	err := CreateAgentsCatalog(context.Background(), indexPath, catalogPath, "release-3.5", true)
 	if err != nil {
 		t.Fatalf("CreateAgentsCatalog failed: %v", err)
 	}
@@ -468,7 +469,7 @@ func TestCreateAgentsCatalogNonExistentBranch(t *testing.T) {
 	writeYAML(t, indexPath, index)
 
 	catalogPath := filepath.Join(tmpDir, "catalog.yaml")
-	err := CreateAgentsCatalog(indexPath, catalogPath, "this-branch-does-not-exist-xyz-12345", false)
+	err := CreateAgentsCatalog(context.Background(), indexPath, catalogPath, "this-branch-does-not-exist-xyz-12345", false)
 	if err == nil {
 		t.Fatal("expected error for non-existent branch")
 	}
@@ -1,25 +1,31 @@
 package catalog
 
 import (
-	"encoding/base64"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"log"
 	"os"
 	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/opendatahub-io/model-metadata-collection/internal/metadata"
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
 	"github.com/opendatahub-io/model-metadata-collection/internal/registry"
 	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
 	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
 )
 
-// LoadStaticCatalogs loads static catalog files and returns their models
-func LoadStaticCatalogs(filePaths []string) ([]types.CatalogMetadata, error) {
+// LoadStaticCatalogs loads static catalog files and returns their models, looking up the
+// architectures of their artifacts in the registry with auth
+func LoadStaticCatalogs(ctx context.Context, auth registry.Auth, filePaths []string) ([]types.CatalogMetadata, error) {
 	var allStaticModels []types.CatalogMetadata
 
 	for _, filePath := range filePaths {
@@ -59,7 +65,14 @@ func LoadStaticCatalogs(filePaths []string) ([]types.CatalogMetadata, error) {
 
 		// Enrich artifacts with architecture information
 		for i := range staticCatalog.Models {
-			enrichStaticArtifactsWithArchitecture(&staticCatalog.Models[i])
+			enrichStaticArtifactsWithArchitecture(ctx, auth, &staticCatalog.Models[i])
+		}
+
+		// Remember which catalog each model came from so the source survives the merge
+		for i := range staticCatalog.Models {
+			if staticCatalog.Models[i].Source == "" {
+				staticCatalog.Models[i].Source = staticCatalog.Source
+			}
 		}
 
 		// Add models from this catalog
@@ -71,54 +84,108 @@ func LoadStaticCatalogs(filePaths []string) ([]types.CatalogMetadata, error) {
 	return allStaticModels, nil
 }
 
-// validateStaticCatalog validates the structure of a static catalog
+// validateStaticCatalog validates the structure of a static catalog, returning its first problem
 func validateStaticCatalog(catalog *types.ModelsCatalog) error {
-	if catalog.Source == "" {
-		return fmt.Errorf("static catalog missing required 'source' field")
+	if issues := staticCatalogIssues(catalog, staticCatalogLines{}); len(issues) > 0 {
+		return errors.New(issues[0].Message)
 	}
+	return nil
+}
 
-	for i, model := range catalog.Models {
-		if model.Name == nil || *model.Name == "" {
-			return fmt.Errorf("model at index %d missing required 'name' field", i)
-		}
+// CreateModelsCatalogWithStaticFromResults creates a YAML models catalog from specific model results and static models
+func CreateModelsCatalogWithStaticFromResults(outputDir, catalogPath string, modelRefs []string, staticModels []types.CatalogMetadata) error {
+	return CreateModelsCatalogWithOptions(context.Background(), outputfs.Dir(outputDir), catalogPath, modelRefs, staticModels, CatalogOptions{Format: CatalogFormatYAML})
+}
 
-		if len(model.Artifacts) == 0 {
-			return fmt.Errorf("model '%s' has no artifacts", *model.Name)
-		}
+// DefaultCatalogSource is the source name of the generated models catalog
+const DefaultCatalogSource = "Red Hat"
+
+// CatalogOptions controls how the models catalog is written
+type CatalogOptions struct {
+	// Format is the output format of the catalog file: yaml, json or ndjson
+	Format string
+	// Writers emit the catalog; defaults to writing catalogPath in Format. Chunks, the markdown
+	// summary and the changelog are still derived from catalogPath.
+	Writers []CatalogWriter
+	// Validation is the schema validation mode: error, warn or off (empty disables validation)
+	Validation string
+	// IncludeLabels, when non-empty, limits the catalog to models carrying at least one of these labels
+	IncludeLabels []string
+	// ExcludeLabels drops models carrying any of these labels from the catalog
+	ExcludeLabels []string
+	// SkipURIDedup disables merging of models with different names that share an artifact URI
+	SkipURIDedup bool
+	// SkipTagGrouping keeps models whose images are different tags of the same repository apart
+	SkipTagGrouping bool
+	// InternalRegistries are registry hosts or domains whose artifacts get the "internal"
+	// registrySource, in addition to cluster-local and private hosts
+	InternalRegistries []string
+	// Logos are the provider and generic logos of models; nil uses the logos shipped with the tool
+	Logos *Logos
+	// ModelFilter is the allowlist/denylist applied as the final filter of the catalog, after
+	// static models are merged; nil keeps every model
+	ModelFilter *ModelFilter
+	// Readme configures the readmes generated for models without one
+	Readme metadata.ReadmeOptions
+	// ModelcardTemplate renders the readmes generated for models without one; nil uses the
+	// embedded template
+	ModelcardTemplate *ModelcardTemplate
+	// RequiredFields fails catalog generation when any model lacks one of these fields
+	RequiredFields []string
+	// ChunkSize, when positive, also splits catalogs larger than this many bytes into numbered
+	// chunk files with an index next to the catalog
+	ChunkSize int
+	// Changelog, when its Path is set, records the changes since the previous run's catalog snapshot
+	Changelog ChangelogOptions
+	// MarkdownPath, when set, receives a markdown table of the catalog for review in pull requests
+	MarkdownPath string
+	// DescriptionOverrides replace or add descriptions and localized descriptions of models by name
+	DescriptionOverrides []DescriptionOverride
+	// ExcludeLowConfidence leaves out values the provenance reports mark as guesses, such as tasks
+	// inferred from the modelcard or generated descriptions
+	ExcludeLowConfidence bool
+	// VulnerabilityThreshold, when set, excludes models with an artifact whose scan found
+	// vulnerabilities of this severity or higher: critical, high, medium, low or unknown
+	VulnerabilityThreshold string
+}
 
-		// Validate each artifact has a URI
-		for j, artifact := range model.Artifacts {
-			if artifact.URI == "" {
-				return fmt.Errorf("model '%s' artifact at index %d missing required 'uri' field", *model.Name, j)
-			}
+// CreateModelsCatalogWithOptions creates a models catalog from the metadata of specific models in
+// output and static models, validating it against the embedded catalog schema before handing it
+// to the configured writers
+func CreateModelsCatalogWithOptions(ctx context.Context, output outputfs.FS, catalogPath string, modelRefs []string, staticModels []types.CatalogMetadata, opts CatalogOptions) error {
+	if err := ValidateCatalogFormat(opts.Format); err != nil {
+		return err
+	}
+	if opts.Validation != "" {
+		if err := ValidateCatalogValidationMode(opts.Validation); err != nil {
+			return err
 		}
 	}
 
-	return nil
-}
+	if err := ValidateRequiredFields(opts.RequiredFields); err != nil {
+		return err
+	}
 
-// CreateModelsCatalogWithStaticFromResults creates a models catalog from specific model results and static models
-func CreateModelsCatalogWithStaticFromResults(outputDir, catalogPath string, modelRefs []string, staticModels []types.CatalogMetadata) error {
 	var allModels []types.ExtractedMetadata
+	// refsByName finds the provenance reports of extracted models by lower-case model name
+	refsByName := make(map[string][]string)
 
 	// Process only metadata files for models that were processed in the current run
 	for _, ref := range modelRefs {
-		// Create sanitized directory name for the model (using same logic as main.go)
-		sanitizedName := utils.SanitizeManifestRef(ref)
-		metadataPath := filepath.Join(outputDir, sanitizedName, "models", "metadata.yaml")
+		metadataPath := outputfs.ModelPath(ref, "metadata.yaml")
 
 		// Check if the metadata file exists
-		if _, err := os.Stat(metadataPath); os.IsNotExist(err) {
-			log.Printf("  Warning: metadata file not found for %s: %s", ref, metadataPath)
+		if _, err := output.Stat(metadataPath); errors.Is(err, fs.ErrNotExist) {
+			log.Printf("  Warning: metadata file not found for %s: %s", ref, output.Path(metadataPath))
 			continue
 		}
 
-		log.Printf("  Processing: %s", metadataPath)
+		log.Printf("  Processing: %s", output.Path(metadataPath))
 
 		// Read the metadata file
-		data, err := os.ReadFile(metadataPath)
+		data, err := output.ReadFile(metadataPath)
 		if err != nil {
-			log.Printf("  Error reading %s: %v", metadataPath, err)
+			log.Printf("  Error reading %s: %v", output.Path(metadataPath), err)
 			continue
 		}
 
@@ -126,10 +193,41 @@ func CreateModelsCatalogWithStaticFromResults(outputDir, catalogPath string, mod
 		var metadata types.ExtractedMetadata
 		err = yaml.Unmarshal(data, &metadata)
 		if err != nil {
-			log.Printf("  Error parsing %s: %v", metadataPath, err)
+			log.Printf("  Error parsing %s: %v", output.Path(metadataPath), err)
 			continue
 		}
 
+		if opts.ExcludeLowConfidence {
+			dropped, err := dropLowConfidenceValues(&metadata, output, ref)
+			if err != nil {
+				log.Printf("  Warning: Keeping all values of %s: %v", ref, err)
+			} else if len(dropped) > 0 {
+				log.Printf("  Excluded low-confidence values of %s: %s", ref, strings.Join(dropped, ", "))
+			}
+		}
+
+		if metadata.Readme == nil {
+			readme, err := generateReadme(output, ref, metadata, opts.Readme, opts.ModelcardTemplate)
+			if err != nil {
+				log.Printf("  Warning: %v", err)
+			} else if readme != nil {
+				metadata.Readme = readme
+				log.Printf("  Generated readme for %s from its metadata", ref)
+			}
+		}
+
+		profilePath, err := writeVLLMProfile(output, ref, metadata)
+		if err != nil {
+			log.Printf("  Warning: %v", err)
+		}
+		metadata.VLLMProfilePath = profilePath
+		metadata.PreviousVersions = previousVersions(output, ref)
+
+		if metadata.Name != nil {
+			key := strings.ToLower(strings.TrimSpace(*metadata.Name))
+			refsByName[key] = append(refsByName[key], ref)
+		}
+
 		// Add to collection
 		allModels = append(allModels, metadata)
 	}
@@ -150,53 +248,148 @@ func CreateModelsCatalogWithStaticFromResults(outputDir, catalogPath string, mod
 	// Convert dynamic models to catalog metadata (excluding tags)
 	var catalogModels []types.CatalogMetadata
 	for _, model := range allModels {
-		catalogModel := convertExtractedToCatalogMetadata(model)
+		catalogModel := convertExtractedToCatalogMetadata(model, opts.Logos)
 		catalogModels = append(catalogModels, catalogModel)
 	}
 
+	// Publish artifact URIs in normalized form so the same image written differently is deduplicated
+	normalizeArtifactURIs(catalogModels)
+	normalizeArtifactURIs(staticModels)
+
+	// Record the registry family of every artifact so consumers can filter by distribution channel
+	addRegistrySources(catalogModels, opts.InternalRegistries)
+	addRegistrySources(staticModels, opts.InternalRegistries)
+
+	// Drop models filtered out by label; their extracted metadata stays in the output directory
+	catalogModels = FilterModelsByLabels(catalogModels, opts.IncludeLabels, opts.ExcludeLabels)
+	staticModels = FilterModelsByLabels(staticModels, opts.IncludeLabels, opts.ExcludeLabels)
+
 	// Deduplicate models by consolidating artifacts and merging metadata
 	catalogModels = deduplicateAndMergeModels(catalogModels)
+	if !opts.SkipURIDedup {
+		catalogModels = deduplicateModelsByURI(catalogModels)
+	}
+	if !opts.SkipTagGrouping {
+		catalogModels = groupModelsByRepository(catalogModels)
+	}
+
+	// Merge static models with dynamic models; static duplicates fill fields the extracted model lacks
+	// and the remaining static models are appended at the end
+	provenance := newCatalogProvenance()
+	populatedBefore := populatedByName(catalogModels)
+	catalogModels = mergeStaticModels(catalogModels, staticModels)
+	provenance.recordStaticMerge(populatedBefore, catalogModels)
+
+	// Description overrides are applied last so curated text wins over extracted and static descriptions
+	applyDescriptionOverrides(catalogModels, opts.DescriptionOverrides)
+	provenance.recordOverrides(catalogModels, opts.DescriptionOverrides)
+
+	// Provenance reports list the static catalog and override sources of every model, including
+	// those the filters below leave out of the catalog
+	if err := provenance.write(output, refsByName); err != nil {
+		log.Printf("  Warning: %v", err)
+	}
+
+	// Models over the vulnerability threshold are dropped whether extracted or static
+	catalogModels = excludeVulnerableModels(catalogModels, opts.VulnerabilityThreshold)
 
-	// Merge static models with dynamic models (static models are appended at the end)
-	catalogModels = append(catalogModels, staticModels...)
+	// The allowlist/denylist is the final filter so nothing it excludes can reach the published catalog
+	catalogModels = opts.ModelFilter.apply(catalogModels)
 
 	// Create the catalog structure
 	catalog := types.ModelsCatalog{
-		Source: "Red Hat",
+		Source: DefaultCatalogSource,
 		Models: catalogModels,
 	}
+	clearInheritedSources(&catalog)
 
-	// Marshal to YAML
-	output, err := yaml.Marshal(&catalog)
-	if err != nil {
-		return fmt.Errorf("error marshaling catalog: %v", err)
+	// Validate before writing so malformed catalogs are never published
+	if err := applyCatalogValidation(&catalog, opts.Validation); err != nil {
+		return err
 	}
 
-	// Write to the specified catalog path
-	err = os.WriteFile(catalogPath, output, 0644)
-	if err != nil {
-		return fmt.Errorf("error writing catalog file: %v", err)
+	// Required fields are checked before writing so incomplete catalogs are caught before publishing
+	if err := CheckRequiredFields(&catalog, opts.RequiredFields); err != nil {
+		return err
+	}
+
+	writers := opts.Writers
+	if len(writers) == 0 {
+		writers = []CatalogWriter{NewFileWriter(catalogPath, opts.Format)}
+	}
+	for _, writer := range writers {
+		if err := writer.Write(ctx, &catalog); err != nil {
+			return err
+		}
+		if writer.Destination() != catalogPath {
+			log.Printf("Wrote catalog to %s", writer.Destination())
+		}
+	}
+
+	if opts.ChunkSize > 0 {
+		index, err := WriteCatalogChunks(&catalog, catalogPath, opts.Format, opts.ChunkSize)
+		if err != nil {
+			return err
+		}
+		if index != nil {
+			log.Printf("Split catalog into %d chunks listed in %s", len(index.Chunks), IndexPath(catalogPath))
+		}
+	}
+
+	if opts.MarkdownPath != "" {
+		if err := UpdateCatalogMarkdown(&catalog, catalogPath, opts.MarkdownPath); err != nil {
+			return err
+		}
+		log.Printf("Updated catalog summary in %s", opts.MarkdownPath)
+	}
+
+	if opts.Changelog.Path != "" {
+		changes, err := UpdateChangelog(&catalog, catalogPath, opts.Changelog, time.Now())
+		if err != nil {
+			return err
+		}
+		if changes != nil && opts.Changelog.OnChanges != nil {
+			opts.Changelog.OnChanges(changes)
+		}
+		switch {
+		case changes == nil:
+			log.Printf("Recorded catalog snapshot; changelog entries start with the next run")
+		case changes.Empty():
+			log.Printf("No catalog changes since the previous run")
+		default:
+			log.Printf("Catalog changes since the previous run: %d added, %d removed, %d re-licensed, %d artifacts updated (see %s)",
+				len(changes.Added), len(changes.Removed), len(changes.Relicensed), len(changes.ArtifactsUpdated), opts.Changelog.Path)
+		}
 	}
 
 	log.Printf("Successfully created %s with %d dynamic models and %d static models", catalogPath, len(allModels), len(staticModels))
 	return nil
 }
 
+// clearInheritedSources drops per-model sources that match the catalog source, so only models
+// merged in from differently-sourced static catalogs carry an explicit source
+func clearInheritedSources(catalog *types.ModelsCatalog) {
+	for i := range catalog.Models {
+		if catalog.Models[i].Source == catalog.Source {
+			catalog.Models[i].Source = ""
+		}
+	}
+}
+
 // CreateModelsCatalogWithStatic collects all metadata.yaml files, merges with static models, and creates a models-catalog.yaml (backward compatibility)
 func CreateModelsCatalogWithStatic(outputDir, catalogPath string, staticModels []types.CatalogMetadata) error {
 	var modelRefs []string
 
 	// Find all metadata.yaml files in the specified output directory to maintain backward compatibility
-	err := filepath.Walk(outputDir, func(path string, info os.FileInfo, err error) error {
+	err := fs.WalkDir(outputfs.Dir(outputDir), ".", func(path string, entry fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
 
-		if info.Name() == "metadata.yaml" {
+		if entry.Name() == "metadata.yaml" {
 			// Extract model reference from path for backward compatibility
-			// Path format: outputDir/sanitized-ref/models/metadata.yaml
-			relPath, _ := filepath.Rel(outputDir, path)
-			pathParts := strings.Split(relPath, string(filepath.Separator))
+			// Path format: sanitized-ref/models/metadata.yaml
+			pathParts := strings.Split(path, "/")
 			if len(pathParts) >= 2 {
 				sanitizedRef := pathParts[0]
 				// Convert back to original reference format (this is a best effort)
@@ -222,7 +415,7 @@ func CreateModelsCatalog(outputDir, catalogPath string) error {
 }
 
 // convertExtractedToCatalogMetadata converts ExtractedMetadata to CatalogMetadata
-func convertExtractedToCatalogMetadata(model types.ExtractedMetadata) types.CatalogMetadata {
+func convertExtractedToCatalogMetadata(model types.ExtractedMetadata, logos *Logos) types.CatalogMetadata {
 	// Convert timestamps to strings and use artifact values when model values are null
 	createTimeStr := convertTimestampToString(model.CreateTimeSinceEpoch)
 	lastUpdateTimeStr := convertTimestampToString(model.LastUpdateTimeSinceEpoch)
@@ -247,6 +440,9 @@ func convertExtractedToCatalogMetadata(model types.ExtractedMetadata) types.Cata
 			CreateTimeSinceEpoch:     convertTimestampToString(artifact.CreateTimeSinceEpoch),
 			LastUpdateTimeSinceEpoch: convertTimestampToString(artifact.LastUpdateTimeSinceEpoch),
 			CustomProperties:         convertCustomPropertiesToMetadataValue(artifact.CustomProperties),
+			Verification:             artifact.Verification,
+			Vulnerabilities:          artifact.Vulnerabilities,
+			RedHatCatalog:            artifact.RedHatCatalog,
 		}
 		catalogArtifacts = append(catalogArtifacts, catalogArtifact)
 	}
@@ -269,10 +465,23 @@ func convertExtractedToCatalogMetadata(model types.ExtractedMetadata) types.Cata
 		customProps["hardware_tag"] = createMetadataValue(strings.Join(model.HardwareTag, ","))
 	}
 
+	// Add parameter size and quantization scheme derived from names, image references and the card
+	addModelTraits(customProps, model)
+
+	// Add the serving performance declared by the image's performance annotations
+	addPerformance(customProps, model.Performance)
+
 	// Add model_type as customProperty (defaults to "generative")
 	// Note: In future, this could be extracted from modelcard metadata
 	customProps["model_type"] = createMetadataValue(types.GetDefaultModelType())
 
+	if model.VLLMProfilePath != "" {
+		customProps[VLLMProfileProperty] = createMetadataValue(model.VLLMProfilePath)
+	}
+	if model.PreviousVersions != "" {
+		customProps[PreviousVersionsProperty] = createMetadataValue(model.PreviousVersions)
+	}
+
 	// Build ServingConfig from ToolCallingConfig if present
 	var servingConfig *types.ServingConfig
 	if model.ToolCallingConfig != nil && model.ToolCallingConfig.HasToolCalling() {
@@ -288,17 +497,17 @@ func convertExtractedToCatalogMetadata(model types.ExtractedMetadata) types.Cata
 	}
 
 	// Ensure "tool-calling" is in tasks when servingConfig exists
-	catalogTasks := model.Tasks
+	catalogTasks := types.NormalizeTasks(model.Tasks)
 	if servingConfig != nil {
 		hasToolCalling := false
 		for _, t := range catalogTasks {
-			if t == "tool-calling" {
+			if t == types.TaskToolCalling {
 				hasToolCalling = true
 				break
 			}
 		}
 		if !hasToolCalling {
-			catalogTasks = append(catalogTasks, "tool-calling")
+			catalogTasks = append(catalogTasks, types.TaskToolCalling)
 		}
 	}
 
@@ -306,19 +515,101 @@ func convertExtractedToCatalogMetadata(model types.ExtractedMetadata) types.Cata
 		Name:                     model.Name,
 		Provider:                 model.Provider,
 		Description:              model.Description,
-		Readme:                   model.Readme,
+		Readme:                   sanitizedReadme(model.Readme),
 		Language:                 model.Language,
 		License:                  model.License,
 		LicenseLink:              model.LicenseLink,
 		Tasks:                    catalogTasks,
 		ValidatedTasks:           model.ValidatedTasks,
+		TrainingDatasets:         model.TrainingDatasets,
+		BaseModel:                model.BaseModel,
+		TrainingData:             sanitizedReadme(model.TrainingData),
 		ServingConfig:            servingConfig,
+		Quantization:             model.Quantization,
+		HardwareRequirements:     model.HardwareRequirements,
+		ResponsibleUse:           sanitizedResponsibleUse(model.ResponsibleUse),
+		MaxContextLength:         maxContextLength(model),
+		ModelConfig:              model.ModelConfig,
+		Evaluations:              model.Evaluations,
+		Deprecated:               model.Deprecated,
+		EndOfLife:                model.EndOfLife,
+		ReplacedBy:               model.ReplacedBy,
 		CreateTimeSinceEpoch:     createTimeStr,
 		LastUpdateTimeSinceEpoch: lastUpdateTimeStr,
 		CustomProperties:         customProps,
 		Artifacts:                catalogArtifacts,
-		Logo:                     determineLogo(model.Tags),
+		Logo:                     determineLogo(logos, model.Provider, model.Tags),
+	}
+}
+
+// sanitizedReadme returns the model's readme made safe for rendering, or nil when it has none
+func sanitizedReadme(readme *string) *string {
+	if readme == nil {
+		return nil
+	}
+	sanitized := metadata.SanitizeReadme(*readme)
+	return &sanitized
+}
+
+// sanitizedResponsibleUse sanitizes the markdown of each responsible-AI section like the readme
+func sanitizedResponsibleUse(responsible *types.ResponsibleUse) *types.ResponsibleUse {
+	if responsible.IsEmpty() {
+		return nil
+	}
+	return &types.ResponsibleUse{
+		IntendedUse:           metadata.SanitizeReadme(responsible.IntendedUse),
+		Limitations:           metadata.SanitizeReadme(responsible.Limitations),
+		Risks:                 metadata.SanitizeReadme(responsible.Risks),
+		EthicalConsiderations: metadata.SanitizeReadme(responsible.EthicalConsiderations),
+	}
+}
+
+// addModelTraits adds the "parameters", "parameter_count" and "quantization" customProperties.
+// Values read from a GGUF header are exact and take precedence over the name heuristics.
+func addModelTraits(customProps map[string]types.MetadataValue, model types.ExtractedMetadata) {
+	var references []string
+	for _, artifact := range model.Artifacts {
+		references = append(references, artifact.URI)
+	}
+	traits := metadata.DetectModelTraits(utils.StringValue(model.Name), references, utils.StringValue(model.Readme))
+
+	parameterCount := traits.ParameterCount
+	quantization := traits.Quantization
+	if model.Quantization != nil {
+		if model.Quantization.ParameterCount > 0 {
+			parameterCount = model.Quantization.ParameterCount
+		}
+		if model.Quantization.Type != "" {
+			quantization = model.Quantization.Type
+		}
+	}
+
+	if traits.Parameters != "" {
+		customProps["parameters"] = types.NewStringValue(traits.Parameters)
+	}
+	if parameterCount > 0 {
+		customProps["parameter_count"] = types.NewIntValue(parameterCount)
+	}
+	if quantization != "" {
+		customProps["quantization"] = types.NewStringValue(quantization)
+	}
+}
+
+// maxContextLength returns the model's context window, falling back to the context length
+// declared in the image's config.json or read from a GGUF header
+func maxContextLength(model types.ExtractedMetadata) *int64 {
+	if model.MaxContextLength != nil {
+		return model.MaxContextLength
+	}
+	if model.ModelConfig != nil && model.ModelConfig.MaxPositionEmbeddings > 0 {
+		contextLength := model.ModelConfig.MaxPositionEmbeddings
+		return &contextLength
 	}
+	if model.Quantization != nil && model.Quantization.ContextLength > 0 {
+		contextLength := model.Quantization.ContextLength
+		return &contextLength
+	}
+	return nil
 }
 
 // convertTimestampToString converts an int64 timestamp to a string, returning nil if input is nil
@@ -345,10 +636,7 @@ func convertTagsToCustomProperties(tags []string) map[string]types.MetadataValue
 
 // createMetadataValue creates a MetadataValue with the standard format
 func createMetadataValue(value string) types.MetadataValue {
-	return types.MetadataValue{
-		MetadataType: "MetadataStringValue",
-		StringValue:  value,
-	}
+	return types.NewStringValue(value)
 }
 
 // convertCustomPropertiesToMetadataValue converts CustomProperties from interface{} to MetadataValue format
@@ -381,61 +669,94 @@ func ensureMetadataValueFormat(value interface{}) map[string]interface{} {
 				}
 			}
 			return map[string]interface{}{
-				"metadataType": "MetadataStringValue",
+				"metadataType": types.MetadataTypeString,
 				"string_value": stringValue,
 			}
 		}
-	} else {
-		// Convert simple values to MetadataValue format
-		stringValue := ""
-		if str, ok := value.(string); ok {
-			stringValue = str
-		}
-		return map[string]interface{}{
-			"metadataType": "MetadataStringValue",
-			"string_value": stringValue,
-		}
+	}
+
+	// Convert simple values to MetadataValue format, keeping numbers and booleans typed
+	switch v := value.(type) {
+	case int:
+		return typedMetadataValue(types.NewIntValue(int64(v)))
+	case int64:
+		return typedMetadataValue(types.NewIntValue(v))
+	case float64:
+		return typedMetadataValue(types.NewDoubleValue(v))
+	case bool:
+		return typedMetadataValue(types.NewBoolValue(v))
+	}
+	stringValue := ""
+	if str, ok := value.(string); ok {
+		stringValue = str
+	}
+	return map[string]interface{}{
+		"metadataType": types.MetadataTypeString,
+		"string_value": stringValue,
 	}
 }
 
-// determineLogo determines which logo to use based on model tags and returns base64-encoded data URI
-func determineLogo(tags []string) *string {
-	var svgPath string
+// typedMetadataValue converts a non-string MetadataValue to the map form used for artifact properties
+func typedMetadataValue(value types.MetadataValue) map[string]interface{} {
+	switch value.MetadataType {
+	case types.MetadataTypeInt:
+		return map[string]interface{}{"metadataType": value.MetadataType, "int_value": value.IntValue}
+	case types.MetadataTypeDouble:
+		return map[string]interface{}{"metadataType": value.MetadataType, "double_value": value.DoubleValue}
+	default:
+		return map[string]interface{}{"metadataType": value.MetadataType, "bool_value": value.BoolValue}
+	}
+}
+
+// determineLogo determines which logo to use based on the model provider and tags and returns a
+// base64-encoded data URI. Providers with a mapped logo get it; others get a generic catalog logo.
+func determineLogo(logos *Logos, provider *string, tags []string) *string {
+	logos = logos.orBuiltin()
+	if logo := logos.lookupProvider(provider); logo != nil {
+		return logo
+	}
+
+	logoPath := "assets/catalog-model.svg"
 
 	// Check if the model has the "validated" label
 	for _, tag := range tags {
 		if tag == "validated" {
-			svgPath = "assets/catalog-validated_model.svg"
+			logoPath = "assets/catalog-validated_model.svg"
 			break
 		}
 	}
 
-	// Default logo for non-validated models
-	if svgPath == "" {
-		svgPath = "assets/catalog-model.svg"
+	// A logo of the same name in the logo directory replaces the bundled asset
+	if content, file, err := logos.readDir(filepath.Base(logoPath)); err == nil {
+		dataURI, err := encodeLogoDataURI(file, content)
+		if err == nil {
+			return &dataURI
+		}
+		log.Printf("Warning: Ignoring logo %s from logo directory: %v", file, err)
 	}
 
-	// Read and encode the SVG file
-	dataUri := encodeSVGToDataURI(svgPath)
+	// Read and encode the logo file
+	dataUri := encodeLogoToDataURI(logoPath)
 	return dataUri
 }
 
-// encodeSVGToDataURI reads an SVG file and returns a base64-encoded data URI
-func encodeSVGToDataURI(svgPath string) *string {
-	// Read the SVG file
-	svgContent, err := os.ReadFile(svgPath)
+// encodeLogoToDataURI reads an SVG, PNG or JPEG file and returns a base64-encoded data URI
+func encodeLogoToDataURI(logoPath string) *string {
+	// Read the logo file
+	content, err := os.ReadFile(logoPath)
 	if err != nil {
-		log.Printf("Warning: Failed to read SVG file %s: %v", svgPath, err)
+		log.Printf("Warning: Failed to read logo file %s: %v", logoPath, err)
 		// Return the file path as fallback
-		fallback := svgPath
+		fallback := logoPath
 		return &fallback
 	}
 
-	// Encode to base64
-	base64Content := base64.StdEncoding.EncodeToString(svgContent)
-
-	// Create data URI
-	dataUri := "data:image/svg+xml;base64," + base64Content
+	dataUri, err := encodeLogoDataURI(logoPath, content)
+	if err != nil {
+		log.Printf("Warning: Failed to encode logo file %s: %v", logoPath, err)
+		fallback := logoPath
+		return &fallback
+	}
 	return &dataUri
 }
 
@@ -495,6 +816,95 @@ func deduplicateAndMergeModels(models []types.CatalogMetadata) []types.CatalogMe
 	return append(result, unnamed...)
 }
 
+// normalizeArtifactURIs rewrites the artifact URIs of models into their normalized form; URIs that
+// do not parse are kept as they are
+func normalizeArtifactURIs(models []types.CatalogMetadata) {
+	for i := range models {
+		for j := range models[i].Artifacts {
+			if models[i].Artifacts[j].URI != "" {
+				models[i].Artifacts[j].URI = utils.NormalizeArtifactURI(models[i].Artifacts[j].URI)
+			}
+		}
+	}
+}
+
+// deduplicateModelsByURI merges models that publish the same artifact URI under different
+// names. Each collision is logged; the first model of a group (in catalog order) keeps its name.
+// Unnamed models are left untouched.
+func deduplicateModelsByURI(models []types.CatalogMetadata) []types.CatalogMetadata {
+	if len(models) <= 1 {
+		return models
+	}
+
+	// Union models that share any artifact URI
+	parent := make([]int, len(models))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	uriOwner := make(map[string]int)
+	for i, model := range models {
+		if model.Name == nil || strings.TrimSpace(*model.Name) == "" {
+			continue
+		}
+		for _, artifact := range model.Artifacts {
+			if artifact.URI == "" {
+				continue
+			}
+			key := utils.NormalizeArtifactURI(artifact.URI)
+			owner, exists := uriOwner[key]
+			if !exists {
+				uriOwner[key] = i
+				continue
+			}
+			rootOwner, rootModel := find(owner), find(i)
+			if rootOwner == rootModel {
+				continue
+			}
+			log.Printf("URI collision: '%s' and '%s' both reference %s, merging", *models[owner].Name, *model.Name, artifact.URI)
+			// Keep the earlier model as the root so it provides the merged name
+			if rootModel < rootOwner {
+				rootOwner, rootModel = rootModel, rootOwner
+			}
+			parent[rootModel] = rootOwner
+		}
+	}
+
+	groups := make(map[int][]types.CatalogMetadata)
+	for i, model := range models {
+		root := find(i)
+		groups[root] = append(groups[root], model)
+	}
+
+	var result []types.CatalogMetadata
+	collisions := 0
+	for i := range models {
+		group, isRoot := groups[i]
+		if !isRoot {
+			continue
+		}
+		if len(group) == 1 {
+			result = append(result, group[0])
+			continue
+		}
+		collisions += len(group) - 1
+		result = append(result, mergeModelGroup(group))
+	}
+
+	if collisions > 0 {
+		log.Printf("Merged %d catalog entries that shared artifact URIs with another entry", collisions)
+	}
+
+	return result
+}
+
 // mergeModelGroup merges a group of duplicate models into a single consolidated model
 func mergeModelGroup(group []types.CatalogMetadata) types.CatalogMetadata {
 	if len(group) == 0 {
@@ -511,9 +921,9 @@ func mergeModelGroup(group []types.CatalogMetadata) types.CatalogMetadata {
 	for _, model := range group {
 		for _, artifact := range model.Artifacts {
 			// Only add if URI is unique
-			if !artifactURIs[artifact.URI] {
+			if key := utils.NormalizeArtifactURI(artifact.URI); !artifactURIs[key] {
 				allArtifacts = append(allArtifacts, artifact)
-				artifactURIs[artifact.URI] = true
+				artifactURIs[key] = true
 			}
 		}
 	}
@@ -554,6 +964,11 @@ func mergeModelGroup(group []types.CatalogMetadata) types.CatalogMetadata {
 	merged.CreateTimeSinceEpoch = earliestCreate
 	merged.LastUpdateTimeSinceEpoch = latestUpdate
 
+	// A consolidated model is only deprecated when every entry in the group is
+	for _, model := range group {
+		merged.Deprecated = merged.Deprecated && model.Deprecated
+	}
+
 	// Merge metadata fields - prefer non-empty values, with priority to first model
 	for i := 1; i < len(group); i++ {
 		model := group[i]
@@ -585,9 +1000,42 @@ func mergeModelGroup(group []types.CatalogMetadata) types.CatalogMetadata {
 		if len(model.ValidatedTasks) > 0 {
 			merged.ValidatedTasks = mergeUniqueStrings(merged.ValidatedTasks, model.ValidatedTasks)
 		}
+		if len(model.TrainingDatasets) > 0 {
+			merged.TrainingDatasets = mergeUniqueStrings(merged.TrainingDatasets, model.TrainingDatasets)
+		}
+		if len(model.BaseModel) > 0 {
+			merged.BaseModel = mergeUniqueStrings(merged.BaseModel, model.BaseModel)
+		}
+		if merged.TrainingData == nil && model.TrainingData != nil {
+			merged.TrainingData = model.TrainingData
+		}
 		if merged.ServingConfig == nil && model.ServingConfig != nil {
 			merged.ServingConfig = model.ServingConfig
 		}
+		if merged.Quantization == nil && model.Quantization != nil {
+			merged.Quantization = model.Quantization
+		}
+		if merged.HardwareRequirements == nil && model.HardwareRequirements != nil {
+			merged.HardwareRequirements = model.HardwareRequirements
+		}
+		if merged.ResponsibleUse == nil && model.ResponsibleUse != nil {
+			merged.ResponsibleUse = model.ResponsibleUse
+		}
+		if merged.MaxContextLength == nil && model.MaxContextLength != nil {
+			merged.MaxContextLength = model.MaxContextLength
+		}
+		if merged.ModelConfig == nil && model.ModelConfig != nil {
+			merged.ModelConfig = model.ModelConfig
+		}
+		if len(merged.Evaluations) == 0 && len(model.Evaluations) > 0 {
+			merged.Evaluations = model.Evaluations
+		}
+		if merged.EndOfLife == nil && model.EndOfLife != nil {
+			merged.EndOfLife = model.EndOfLife
+		}
+		if merged.ReplacedBy == nil && model.ReplacedBy != nil {
+			merged.ReplacedBy = model.ReplacedBy
+		}
 
 		// Merge custom properties
 		if model.CustomProperties != nil {
@@ -685,13 +1133,13 @@ func getModelName(model *types.CatalogMetadata) string {
 }
 
 // enrichStaticArtifactsWithArchitecture adds architecture information to artifacts in static catalog models
-func enrichStaticArtifactsWithArchitecture(model *types.CatalogMetadata) {
+func enrichStaticArtifactsWithArchitecture(ctx context.Context, auth registry.Auth, model *types.CatalogMetadata) {
 	for i := range model.Artifacts {
 		artifact := &model.Artifacts[i]
 
 		// Extract the image reference from the OCI URI
 		// Format: oci://registry.redhat.io/rhelai1/modelcar-name:tag
-		imageRef := strings.TrimPrefix(artifact.URI, "oci://")
+		imageRef := utils.ImageReferenceOf(artifact.URI)
 
 		// Add architecture information to the artifact's custom properties
 		if artifact.CustomProperties == nil {
@@ -699,6 +1147,6 @@ func enrichStaticArtifactsWithArchitecture(model *types.CatalogMetadata) {
 		}
 
 		// Use the registry package function to add architecture
-		registry.AddArchitectureToArtifactProps(imageRef, artifact.CustomProperties)
+		registry.AddArchitectureToArtifactProps(ctx, auth, imageRef, artifact.CustomProperties)
 	}
 }
@@ -1,9 +1,11 @@
 package catalog
 
 import (
+	"context"
 	"encoding/base64"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -90,32 +92,15 @@ func TestCreateModelsCatalog(t *testing.T) {
 		}
 	}
 
-	// Change to the temp directory so CreateModelsCatalog can find the output directory
-	originalDir, err := os.Getwd()
-	if err != nil {
-		t.Fatalf("Failed to get working directory: %v", err)
-	}
-	defer func() {
-		err := os.Chdir(originalDir)
-		if err != nil {
-			t.Errorf("Failed to restore working directory: %v", err)
-		}
-	}()
-
-	err = os.Chdir(tmpDir)
-	if err != nil {
-		t.Fatalf("Failed to change to temp directory: %v", err)
-	}
-
 	// Create data directory for catalog output
-	err = os.MkdirAll("data", 0755)
+	err := os.MkdirAll(filepath.Join(tmpDir, "data"), 0755)
 	if err != nil {
 		t.Fatalf("Failed to create data directory: %v", err)
 	}
 
 	// Test CreateModelsCatalog
-	testCatalogPath := filepath.Join("data", "test-models-catalog.yaml")
-	err = CreateModelsCatalog("output", testCatalogPath)
+	testCatalogPath := filepath.Join(tmpDir, "data", "test-models-catalog.yaml")
+	err = CreateModelsCatalog(filepath.Join(tmpDir, "output"), testCatalogPath)
 	if err != nil {
 		t.Fatalf("CreateModelsCatalog failed: %v", err)
 	}
@@ -218,32 +203,15 @@ func TestCreateModelsCatalog_EmptyOutput(t *testing.T) {
 		t.Fatalf("Failed to create empty output directory: %v", err)
 	}
 
-	// Change to temp directory
-	originalDir, err := os.Getwd()
-	if err != nil {
-		t.Fatalf("Failed to get working directory: %v", err)
-	}
-	defer func() {
-		err := os.Chdir(originalDir)
-		if err != nil {
-			t.Errorf("Failed to restore working directory: %v", err)
-		}
-	}()
-
-	err = os.Chdir(tmpDir)
-	if err != nil {
-		t.Fatalf("Failed to change to temp directory: %v", err)
-	}
-
 	// Create data directory for catalog output
-	err = os.MkdirAll("data", 0755)
+	err = os.MkdirAll(filepath.Join(tmpDir, "data"), 0755)
 	if err != nil {
 		t.Fatalf("Failed to create data directory: %v", err)
 	}
 
 	// Test CreateModelsCatalog with empty directory
-	testCatalogPath := filepath.Join("data", "test-models-catalog.yaml")
-	err = CreateModelsCatalog("output", testCatalogPath)
+	testCatalogPath := filepath.Join(tmpDir, "data", "test-models-catalog.yaml")
+	err = CreateModelsCatalog(filepath.Join(tmpDir, "output"), testCatalogPath)
 	if err != nil {
 		t.Fatalf("CreateModelsCatalog failed with empty directory: %v", err)
 	}
@@ -270,32 +238,15 @@ func TestCreateModelsCatalog_NoOutputDirectory(t *testing.T) {
 	// Test with no output directory - should create empty catalog
 	tmpDir := t.TempDir()
 
-	// Change to temp directory
-	originalDir, err := os.Getwd()
-	if err != nil {
-		t.Fatalf("Failed to get working directory: %v", err)
-	}
-	defer func() {
-		err := os.Chdir(originalDir)
-		if err != nil {
-			t.Errorf("Failed to restore working directory: %v", err)
-		}
-	}()
-
-	err = os.Chdir(tmpDir)
-	if err != nil {
-		t.Fatalf("Failed to change to temp directory: %v", err)
-	}
-
 	// Create data directory for catalog output
-	err = os.MkdirAll("data", 0755)
+	err := os.MkdirAll(filepath.Join(tmpDir, "data"), 0755)
 	if err != nil {
 		t.Fatalf("Failed to create data directory: %v", err)
 	}
 
 	// Test CreateModelsCatalog with no output directory - should not fail
-	testCatalogPath := filepath.Join("data", "test-models-catalog.yaml")
-	err = CreateModelsCatalog("output", testCatalogPath)
+	testCatalogPath := filepath.Join(tmpDir, "data", "test-models-catalog.yaml")
+	err = CreateModelsCatalog(filepath.Join(tmpDir, "output"), testCatalogPath)
 	if err != nil {
 		// The function should handle missing output directory gracefully
 		t.Logf("CreateModelsCatalog returned error (expected for missing output dir): %v", err)
@@ -327,32 +278,15 @@ func TestCreateModelsCatalog_InvalidMetadata(t *testing.T) {
 		t.Fatalf("Failed to create invalid metadata file: %v", err)
 	}
 
-	// Change to temp directory
-	originalDir, err := os.Getwd()
-	if err != nil {
-		t.Fatalf("Failed to get working directory: %v", err)
-	}
-	defer func() {
-		err := os.Chdir(originalDir)
-		if err != nil {
-			t.Errorf("Failed to restore working directory: %v", err)
-		}
-	}()
-
-	err = os.Chdir(tmpDir)
-	if err != nil {
-		t.Fatalf("Failed to change to temp directory: %v", err)
-	}
-
 	// Create data directory for catalog output
-	err = os.MkdirAll("data", 0755)
+	err = os.MkdirAll(filepath.Join(tmpDir, "data"), 0755)
 	if err != nil {
 		t.Fatalf("Failed to create data directory: %v", err)
 	}
 
 	// Test CreateModelsCatalog - should continue processing despite invalid file
-	testCatalogPath := filepath.Join("data", "test-models-catalog.yaml")
-	err = CreateModelsCatalog("output", testCatalogPath)
+	testCatalogPath := filepath.Join(tmpDir, "data", "test-models-catalog.yaml")
+	err = CreateModelsCatalog(filepath.Join(tmpDir, "output"), testCatalogPath)
 	if err != nil {
 		t.Fatalf("CreateModelsCatalog failed: %v", err)
 	}
@@ -493,26 +427,12 @@ func TestLogoAssignment(t *testing.T) {
 		}
 	}
 
-	// Change to the temp directory so CreateModelsCatalog can find the output directory
-	originalDir, err := os.Getwd()
-	if err != nil {
-		t.Fatalf("Failed to get working directory: %v", err)
-	}
-	defer func() {
-		err := os.Chdir(originalDir)
-		if err != nil {
-			t.Errorf("Failed to restore working directory: %v", err)
-		}
-	}()
-
-	err = os.Chdir(tmpDir)
-	if err != nil {
-		t.Fatalf("Failed to change to temp directory: %v", err)
-	}
+	// The logos are read from assets/ in the working directory
+	t.Chdir(tmpDir)
 
 	// Test CreateModelsCatalog
-	testCatalogPath := filepath.Join("data", "test-models-catalog.yaml")
-	err = CreateModelsCatalog("output", testCatalogPath)
+	testCatalogPath := filepath.Join(tmpDir, "data", "test-models-catalog.yaml")
+	err = CreateModelsCatalog(filepath.Join(tmpDir, "output"), testCatalogPath)
 	if err != nil {
 		t.Fatalf("CreateModelsCatalog failed: %v", err)
 	}
@@ -598,21 +518,7 @@ func TestDetermineLogo(t *testing.T) {
 	modelDataURI := "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString([]byte(modelSVG))
 
 	// Change to the temp directory so the function can find the assets
-	originalDir, err := os.Getwd()
-	if err != nil {
-		t.Fatalf("Failed to get working directory: %v", err)
-	}
-	defer func() {
-		err := os.Chdir(originalDir)
-		if err != nil {
-			t.Errorf("Failed to restore working directory: %v", err)
-		}
-	}()
-
-	err = os.Chdir(tmpDir)
-	if err != nil {
-		t.Fatalf("Failed to change to temp directory: %v", err)
-	}
+	t.Chdir(tmpDir)
 
 	testCases := []struct {
 		name         string
@@ -648,7 +554,7 @@ func TestDetermineLogo(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			logo := determineLogo(tc.tags)
+			logo := determineLogo(nil, nil, tc.tags)
 			if logo == nil {
 				t.Fatal("determineLogo returned nil")
 			}
@@ -738,7 +644,7 @@ func TestLoadStaticCatalogs(t *testing.T) {
 
 	// Test successful loading of valid catalog
 	t.Run("ValidCatalog", func(t *testing.T) {
-		models, err := LoadStaticCatalogs([]string{validCatalogPath})
+		models, err := LoadStaticCatalogs(context.Background(), nil, []string{validCatalogPath})
 		if err != nil {
 			t.Fatalf("LoadStaticCatalogs failed: %v", err)
 		}
@@ -753,12 +659,18 @@ func TestLoadStaticCatalogs(t *testing.T) {
 		if models[1].Name == nil || *models[1].Name != "Static Model 2" {
 			t.Error("Second model should be 'Static Model 2'")
 		}
+
+		for _, model := range models {
+			if model.Source != "Test Source" {
+				t.Errorf("Expected model source 'Test Source', got %q", model.Source)
+			}
+		}
 	})
 
 	// Test handling of missing files
 	t.Run("MissingFile", func(t *testing.T) {
 		missingFilePath := filepath.Join(tmpDir, "nonexistent.yaml")
-		models, err := LoadStaticCatalogs([]string{missingFilePath})
+		models, err := LoadStaticCatalogs(context.Background(), nil, []string{missingFilePath})
 		if err != nil {
 			t.Fatalf("LoadStaticCatalogs failed: %v", err)
 		}
@@ -770,7 +682,7 @@ func TestLoadStaticCatalogs(t *testing.T) {
 
 	// Test handling of invalid YAML
 	t.Run("InvalidYAML", func(t *testing.T) {
-		models, err := LoadStaticCatalogs([]string{invalidCatalogPath})
+		models, err := LoadStaticCatalogs(context.Background(), nil, []string{invalidCatalogPath})
 		if err != nil {
 			t.Fatalf("LoadStaticCatalogs failed: %v", err)
 		}
@@ -782,7 +694,7 @@ func TestLoadStaticCatalogs(t *testing.T) {
 
 	// Test handling of invalid structure
 	t.Run("InvalidStructure", func(t *testing.T) {
-		models, err := LoadStaticCatalogs([]string{invalidStructurePath})
+		models, err := LoadStaticCatalogs(context.Background(), nil, []string{invalidStructurePath})
 		if err != nil {
 			t.Fatalf("LoadStaticCatalogs failed: %v", err)
 		}
@@ -821,7 +733,7 @@ func TestLoadStaticCatalogs(t *testing.T) {
 			t.Fatalf("Failed to write second valid catalog file: %v", err)
 		}
 
-		models, err := LoadStaticCatalogs([]string{validCatalogPath, validCatalog2Path})
+		models, err := LoadStaticCatalogs(context.Background(), nil, []string{validCatalogPath, validCatalog2Path})
 		if err != nil {
 			t.Fatalf("LoadStaticCatalogs failed: %v", err)
 		}
@@ -833,7 +745,7 @@ func TestLoadStaticCatalogs(t *testing.T) {
 
 	// Test empty file list
 	t.Run("EmptyFileList", func(t *testing.T) {
-		models, err := LoadStaticCatalogs([]string{})
+		models, err := LoadStaticCatalogs(context.Background(), nil, []string{})
 		if err != nil {
 			t.Fatalf("LoadStaticCatalogs failed: %v", err)
 		}
@@ -1053,6 +965,28 @@ func TestConvertCustomPropertiesToMetadataValue(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "numeric and boolean values",
+			input: map[string]interface{}{
+				"downloads": 1200,
+				"score":     0.75,
+				"gated":     true,
+			},
+			expected: map[string]interface{}{
+				"downloads": map[string]interface{}{
+					"metadataType": "MetadataIntValue",
+					"int_value":    "1200",
+				},
+				"score": map[string]interface{}{
+					"metadataType": "MetadataDoubleValue",
+					"double_value": 0.75,
+				},
+				"gated": map[string]interface{}{
+					"metadataType": "MetadataBoolValue",
+					"bool_value":   true,
+				},
+			},
+		},
 		{
 			name: "mixed format properties",
 			input: map[string]interface{}{
@@ -1146,6 +1080,73 @@ func TestConvertCustomPropertiesToMetadataValue(t *testing.T) {
 	}
 }
 
+func TestCreateModelsCatalogWithStatic_PreservesStaticSources(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writeStatic := func(name string, catalog types.ModelsCatalog) string {
+		path := filepath.Join(tmpDir, name)
+		data, err := yaml.Marshal(catalog)
+		if err != nil {
+			t.Fatalf("Failed to marshal static catalog: %v", err)
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatalf("Failed to write static catalog: %v", err)
+		}
+		return path
+	}
+
+	redHat := writeStatic("redhat.yaml", types.ModelsCatalog{
+		Source: DefaultCatalogSource,
+		Models: []types.CatalogMetadata{
+			{Name: stringPtr("Red Hat Model"), Artifacts: []types.CatalogOCIArtifact{{URI: "oci://example.com/rh:1.0"}}},
+		},
+	})
+	partner := writeStatic("partner.yaml", types.ModelsCatalog{
+		Source: "Partner Models",
+		Models: []types.CatalogMetadata{
+			{Name: stringPtr("Partner Model"), Artifacts: []types.CatalogOCIArtifact{{URI: "oci://example.com/partner:1.0"}}},
+			{Name: stringPtr("Community Model"), Source: "Community", Artifacts: []types.CatalogOCIArtifact{{URI: "oci://example.com/community:1.0"}}},
+		},
+	})
+
+	staticModels, err := LoadStaticCatalogs(context.Background(), nil, []string{redHat, partner})
+	if err != nil {
+		t.Fatalf("LoadStaticCatalogs failed: %v", err)
+	}
+
+	catalogPath := filepath.Join(tmpDir, "models-catalog.yaml")
+	if err := CreateModelsCatalogWithStaticFromResults(filepath.Join(tmpDir, "output"), catalogPath, nil, staticModels); err != nil {
+		t.Fatalf("CreateModelsCatalogWithStaticFromResults failed: %v", err)
+	}
+
+	data, err := os.ReadFile(catalogPath)
+	if err != nil {
+		t.Fatalf("Failed to read catalog: %v", err)
+	}
+	var catalog types.ModelsCatalog
+	if err := yaml.Unmarshal(data, &catalog); err != nil {
+		t.Fatalf("Failed to parse catalog: %v", err)
+	}
+
+	if catalog.Source != DefaultCatalogSource {
+		t.Errorf("Expected catalog source %q, got %q", DefaultCatalogSource, catalog.Source)
+	}
+
+	expected := map[string]string{
+		"Red Hat Model":   "",
+		"Partner Model":   "Partner Models",
+		"Community Model": "Community",
+	}
+	if len(catalog.Models) != len(expected) {
+		t.Fatalf("Expected %d models, got %d", len(expected), len(catalog.Models))
+	}
+	for _, model := range catalog.Models {
+		if want := expected[*model.Name]; model.Source != want {
+			t.Errorf("Model %s: expected source %q, got %q", *model.Name, want, model.Source)
+		}
+	}
+}
+
 func TestCreateModelsCatalogWithStatic(t *testing.T) {
 	// Create temporary directory structure for testing
 	tmpDir := t.TempDir()
@@ -1198,23 +1199,6 @@ func TestCreateModelsCatalogWithStatic(t *testing.T) {
 		t.Fatalf("Failed to create data directory: %v", err)
 	}
 
-	// Change to temp directory
-	originalDir, err := os.Getwd()
-	if err != nil {
-		t.Fatalf("Failed to get working directory: %v", err)
-	}
-	defer func() {
-		err := os.Chdir(originalDir)
-		if err != nil {
-			t.Errorf("Failed to restore working directory: %v", err)
-		}
-	}()
-
-	err = os.Chdir(tmpDir)
-	if err != nil {
-		t.Fatalf("Failed to change to temp directory: %v", err)
-	}
-
 	// Test with static models
 	t.Run("WithStaticModels", func(t *testing.T) {
 		staticModels := []types.CatalogMetadata{
@@ -1243,8 +1227,8 @@ func TestCreateModelsCatalogWithStatic(t *testing.T) {
 			},
 		}
 
-		testCatalogPath := filepath.Join("data", "test-catalog-with-static.yaml")
-		err := CreateModelsCatalogWithStatic("output", testCatalogPath, staticModels)
+		testCatalogPath := filepath.Join(dataDir, "test-catalog-with-static.yaml")
+		err := CreateModelsCatalogWithStatic(outputDir, testCatalogPath, staticModels)
 		if err != nil {
 			t.Fatalf("CreateModelsCatalogWithStatic failed: %v", err)
 		}
@@ -1298,8 +1282,8 @@ func TestCreateModelsCatalogWithStatic(t *testing.T) {
 
 	// Test with no static models (should work like CreateModelsCatalog)
 	t.Run("WithoutStaticModels", func(t *testing.T) {
-		testCatalogPath := filepath.Join("data", "test-catalog-no-static.yaml")
-		err := CreateModelsCatalogWithStatic("output", testCatalogPath, []types.CatalogMetadata{})
+		testCatalogPath := filepath.Join(dataDir, "test-catalog-no-static.yaml")
+		err := CreateModelsCatalogWithStatic(outputDir, testCatalogPath, []types.CatalogMetadata{})
 		if err != nil {
 			t.Fatalf("CreateModelsCatalogWithStatic failed: %v", err)
 		}
@@ -1339,7 +1323,7 @@ func TestConvertExtractedToCatalogMetadata_ValidatedOn(t *testing.T) {
 		Artifacts:   []types.OCIArtifact{},
 	}
 
-	result := convertExtractedToCatalogMetadata(metadata)
+	result := convertExtractedToCatalogMetadata(metadata, nil)
 
 	// Check that validated_on is in customProperties
 	if result.CustomProperties == nil {
@@ -1416,7 +1400,7 @@ func TestConvertExtractedToCatalogMetadata_HardwareTag(t *testing.T) {
 				Artifacts:   []types.OCIArtifact{},
 			}
 
-			result := convertExtractedToCatalogMetadata(metadata)
+			result := convertExtractedToCatalogMetadata(metadata, nil)
 
 			if result.CustomProperties == nil {
 				if tc.expectPresent {
@@ -1458,7 +1442,7 @@ func TestConvertExtractedToCatalogMetadata_NoValidatedOn(t *testing.T) {
 		Artifacts:   []types.OCIArtifact{},
 	}
 
-	result := convertExtractedToCatalogMetadata(metadata)
+	result := convertExtractedToCatalogMetadata(metadata, nil)
 
 	// Check that validated_on is NOT in customProperties
 	if result.CustomProperties != nil {
@@ -1484,7 +1468,7 @@ func TestConvertExtractedToCatalogMetadata_WithToolCalling(t *testing.T) {
 		Artifacts: []types.OCIArtifact{},
 	}
 
-	result := convertExtractedToCatalogMetadata(metadata)
+	result := convertExtractedToCatalogMetadata(metadata, nil)
 
 	// Verify servingConfig
 	if result.ServingConfig == nil {
@@ -1532,7 +1516,7 @@ func TestConvertExtractedToCatalogMetadata_WithoutToolCalling(t *testing.T) {
 		Artifacts: []types.OCIArtifact{},
 	}
 
-	result := convertExtractedToCatalogMetadata(metadata)
+	result := convertExtractedToCatalogMetadata(metadata, nil)
 
 	if result.ServingConfig != nil {
 		t.Error("Expected ServingConfig to be nil for model without tool-calling")
@@ -1579,7 +1563,7 @@ func TestConvertExtractedToCatalogMetadata_ToolCallingPathConversion(t *testing.
 				Artifacts: []types.OCIArtifact{},
 			}
 
-			result := convertExtractedToCatalogMetadata(metadata)
+			result := convertExtractedToCatalogMetadata(metadata, nil)
 
 			if result.ServingConfig == nil || result.ServingConfig.ToolCalling == nil {
 				t.Fatal("Expected ServingConfig.ToolCalling to be set")
@@ -1605,7 +1589,7 @@ func TestConvertExtractedToCatalogMetadata_ToolCallingInjectsTask(t *testing.T)
 		Artifacts: []types.OCIArtifact{},
 	}
 
-	result := convertExtractedToCatalogMetadata(metadata)
+	result := convertExtractedToCatalogMetadata(metadata, nil)
 
 	// Verify tool-calling was injected into tasks
 	hasToolCalling := false
@@ -1618,3 +1602,195 @@ func TestConvertExtractedToCatalogMetadata_ToolCallingInjectsTask(t *testing.T)
 		t.Errorf("Expected 'tool-calling' to be injected into tasks, got %v", result.Tasks)
 	}
 }
+
+func TestConvertExtractedToCatalogMetadata_TrainingDatasets(t *testing.T) {
+	metadata := types.ExtractedMetadata{
+		Name:             stringPtr("Test Model"),
+		TrainingDatasets: []string{"allenai/c4", "HuggingFaceH4/ultrachat_200k"},
+		Artifacts:        []types.OCIArtifact{},
+	}
+
+	result := convertExtractedToCatalogMetadata(metadata, nil)
+
+	if !reflect.DeepEqual(result.TrainingDatasets, metadata.TrainingDatasets) {
+		t.Errorf("Expected TrainingDatasets %v, got %v", metadata.TrainingDatasets, result.TrainingDatasets)
+	}
+
+	output, err := yaml.Marshal(&result)
+	if err != nil {
+		t.Fatalf("Failed to marshal catalog metadata: %v", err)
+	}
+	if !strings.Contains(string(output), "trainingDatasets:") {
+		t.Errorf("Expected trainingDatasets in catalog YAML, got:\n%s", output)
+	}
+
+	withoutDatasets := convertExtractedToCatalogMetadata(types.ExtractedMetadata{Name: stringPtr("Other Model")}, nil)
+	output, err = yaml.Marshal(&withoutDatasets)
+	if err != nil {
+		t.Fatalf("Failed to marshal catalog metadata: %v", err)
+	}
+	if strings.Contains(string(output), "trainingDatasets") {
+		t.Errorf("Expected trainingDatasets to be omitted when empty, got:\n%s", output)
+	}
+}
+
+func TestMergeModelGroup_TrainingDatasets(t *testing.T) {
+	group := []types.CatalogMetadata{
+		{Name: stringPtr("Model"), TrainingDatasets: []string{"allenai/c4"}},
+		{Name: stringPtr("Model"), TrainingDatasets: []string{"allenai/c4", "openbmb/UltraFeedback"}},
+	}
+
+	merged := mergeModelGroup(group)
+
+	expected := []string{"allenai/c4", "openbmb/UltraFeedback"}
+	if !reflect.DeepEqual(merged.TrainingDatasets, expected) {
+		t.Errorf("Expected merged TrainingDatasets %v, got %v", expected, merged.TrainingDatasets)
+	}
+}
+
+func TestConvertExtractedToCatalogMetadata_Quantization(t *testing.T) {
+	name := "Test GGUF Model"
+	model := types.ExtractedMetadata{
+		Name: &name,
+		Quantization: &types.QuantizationInfo{
+			Format:         types.QuantizationFormatGGUF,
+			Type:           "Q4_K_M",
+			ParameterCount: 8030261248,
+			ContextLength:  131072,
+		},
+	}
+
+	result := convertExtractedToCatalogMetadata(model, nil)
+	if result.Quantization == nil || result.Quantization.Type != "Q4_K_M" || result.Quantization.ContextLength != 131072 {
+		t.Errorf("Expected quantization to be carried into catalog, got %+v", result.Quantization)
+	}
+}
+
+func TestConvertExtractedToCatalogMetadata_ModelTraits(t *testing.T) {
+	model := types.ExtractedMetadata{
+		Name:      stringPtr("Llama-3.1-8B-Instruct-quantized.w4a16"),
+		Artifacts: []types.OCIArtifact{{URI: "oci://registry.example.com/llama:1.5"}},
+	}
+
+	result := convertExtractedToCatalogMetadata(model, nil)
+	expected := map[string]types.MetadataValue{
+		"parameters":      types.NewStringValue("8B"),
+		"parameter_count": types.NewIntValue(8000000000),
+		"quantization":    types.NewStringValue("W4A16"),
+	}
+	for key, value := range expected {
+		if result.CustomProperties[key] != value {
+			t.Errorf("Expected customProperty %s to be %+v, got %+v", key, value, result.CustomProperties[key])
+		}
+	}
+
+	// GGUF header values are exact and replace the name heuristics
+	model.Quantization = &types.QuantizationInfo{Format: types.QuantizationFormatGGUF, Type: "Q4_K_M", ParameterCount: 8030261248}
+	result = convertExtractedToCatalogMetadata(model, nil)
+	if result.CustomProperties["parameter_count"] != types.NewIntValue(8030261248) {
+		t.Errorf("Expected GGUF parameter count, got %+v", result.CustomProperties["parameter_count"])
+	}
+	if result.CustomProperties["quantization"] != types.NewStringValue("Q4_K_M") {
+		t.Errorf("Expected GGUF quantization type, got %+v", result.CustomProperties["quantization"])
+	}
+}
+
+func TestMergeModelGroup_Evaluations(t *testing.T) {
+	evaluations := []types.Evaluation{{Benchmark: "MMLU", Metric: "acc", Score: 0.61}}
+	group := []types.CatalogMetadata{
+		{Name: stringPtr("Model")},
+		{Name: stringPtr("Model"), Evaluations: evaluations},
+	}
+
+	merged := mergeModelGroup(group)
+	if !reflect.DeepEqual(merged.Evaluations, evaluations) {
+		t.Errorf("Expected evaluations %+v, got %+v", evaluations, merged.Evaluations)
+	}
+}
+
+func TestDeduplicateModelsByURI(t *testing.T) {
+	artifact := func(uri string) []types.CatalogOCIArtifact {
+		return []types.CatalogOCIArtifact{{URI: uri}}
+	}
+	models := []types.CatalogMetadata{
+		{Name: stringPtr("granite-3.1-8b"), Artifacts: artifact("oci://registry.example.com/granite:1.5")},
+		{Name: stringPtr("llama-3.3-70b"), Artifacts: artifact("oci://registry.example.com/llama:1.5")},
+		{Name: stringPtr("Granite 3.1 8B"), Description: stringPtr("Better description"), Artifacts: []types.CatalogOCIArtifact{
+			{URI: "oci://registry.example.com/granite:1.5"},
+			{URI: "oci://registry.example.com/granite:1.4"},
+		}},
+		{Name: stringPtr("granite-3.1-8b-old"), Artifacts: artifact("oci://registry.example.com/granite:1.4")},
+		{Name: nil, Artifacts: artifact("oci://registry.example.com/llama:1.5")},
+	}
+
+	result := deduplicateModelsByURI(models)
+
+	if len(result) != 3 {
+		t.Fatalf("Expected 3 models after URI dedup, got %d", len(result))
+	}
+	if *result[0].Name != "granite-3.1-8b" {
+		t.Errorf("Expected first model to keep name 'granite-3.1-8b', got %q", *result[0].Name)
+	}
+	if len(result[0].Artifacts) != 2 {
+		t.Errorf("Expected merged model to have 2 artifacts, got %d", len(result[0].Artifacts))
+	}
+	if result[0].Description == nil || *result[0].Description != "Better description" {
+		t.Errorf("Expected merged model to take the missing description, got %v", result[0].Description)
+	}
+	if *result[1].Name != "llama-3.3-70b" {
+		t.Errorf("Expected second model 'llama-3.3-70b', got %q", *result[1].Name)
+	}
+	if result[2].Name != nil {
+		t.Errorf("Expected unnamed model to be left untouched")
+	}
+}
+
+func TestConvertExtractedToCatalogMetadata_Lifecycle(t *testing.T) {
+	model := types.ExtractedMetadata{
+		Name:       stringPtr("granite-3.1-8b-instruct"),
+		Deprecated: true,
+		EndOfLife:  stringPtr("2026-06-30"),
+		ReplacedBy: stringPtr("granite-3.3-8b-instruct"),
+	}
+
+	result := convertExtractedToCatalogMetadata(model, nil)
+	if !result.Deprecated || result.EndOfLife == nil || *result.EndOfLife != "2026-06-30" ||
+		result.ReplacedBy == nil || *result.ReplacedBy != "granite-3.3-8b-instruct" {
+		t.Errorf("Expected lifecycle fields to be carried into catalog, got %+v", result)
+	}
+}
+
+func TestMergeModelGroup_Deprecated(t *testing.T) {
+	deprecated := types.CatalogMetadata{Name: stringPtr("Model"), Deprecated: true, ReplacedBy: stringPtr("Model v2")}
+	current := types.CatalogMetadata{Name: stringPtr("Model")}
+
+	if merged := mergeModelGroup([]types.CatalogMetadata{deprecated, current}); merged.Deprecated {
+		t.Error("Expected model with a non-deprecated entry not to be deprecated")
+	}
+	merged := mergeModelGroup([]types.CatalogMetadata{current, deprecated, deprecated})
+	if merged.ReplacedBy == nil || *merged.ReplacedBy != "Model v2" {
+		t.Errorf("Expected replacedBy to be filled from group, got %v", merged.ReplacedBy)
+	}
+	if merged := mergeModelGroup([]types.CatalogMetadata{deprecated, deprecated}); !merged.Deprecated {
+		t.Error("Expected fully deprecated group to stay deprecated")
+	}
+}
+
+func TestValidateStaticCatalog_Lifecycle(t *testing.T) {
+	catalog := &types.ModelsCatalog{
+		Source: "Test",
+		Models: []types.CatalogMetadata{{
+			Name:      stringPtr("Old Model"),
+			EndOfLife: stringPtr("not-a-date"),
+			Artifacts: []types.CatalogOCIArtifact{{URI: "oci://example.com/old:1"}},
+		}},
+	}
+	if err := validateStaticCatalog(catalog); err == nil {
+		t.Error("Expected invalid endOfLife to fail static catalog validation")
+	}
+
+	catalog.Models[0].EndOfLife = stringPtr("2026-12-31")
+	if err := validateStaticCatalog(catalog); err != nil {
+		t.Errorf("Expected valid lifecycle fields to pass, got %v", err)
+	}
+}
@@ -0,0 +1,131 @@
+package catalog
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// DefaultCatalogSourceName is the name of the generated ModelCatalogSource resource
+const DefaultCatalogSourceName = "redhat-ai-models"
+
+// CatalogSourceOptions controls generation of the ModelCatalogSource custom resource consumed by
+// the OpenDataHub model catalog controller
+type CatalogSourceOptions struct {
+	// Path is the file receiving the catalog ConfigMaps followed by the ModelCatalogSource
+	Path string
+	// Name is the ModelCatalogSource name (default DefaultCatalogSourceName)
+	Name string
+	// Namespace is set on the resource and its ConfigMaps when non-empty
+	Namespace string
+	// Labels are the source labels the catalog UI groups and filters the source's models by
+	Labels []string
+	// ConfigMapName is the name of the ConfigMaps holding the catalog payload; chunks are suffixed
+	// -1, -2, ... like the --catalog-configmap output
+	ConfigMapName string
+	// Image is the OCI artifact reference the catalog is also pushed to, recorded when non-empty
+	Image string
+}
+
+// modelCatalogSource is the ModelCatalogSource custom resource written by the tool
+type modelCatalogSource struct {
+	APIVersion string                 `yaml:"apiVersion"`
+	Kind       string                 `yaml:"kind"`
+	Metadata   configMapMetadata      `yaml:"metadata"`
+	Spec       modelCatalogSourceSpec `yaml:"spec"`
+}
+
+type modelCatalogSourceSpec struct {
+	DisplayName string                    `yaml:"displayName"`
+	Type        string                    `yaml:"type"`
+	Enabled     bool                      `yaml:"enabled"`
+	Labels      []string                  `yaml:"labels,omitempty"`
+	Catalog     modelCatalogSourcePayload `yaml:"catalog"`
+}
+
+// modelCatalogSourcePayload references the catalog data of a source
+type modelCatalogSourcePayload struct {
+	ConfigMaps []configMapKeyRef `yaml:"configMaps"`
+	Image      string            `yaml:"image,omitempty"`
+}
+
+type configMapKeyRef struct {
+	Name string `yaml:"name"`
+	Key  string `yaml:"key"`
+}
+
+// EncodeCatalogSource returns the catalog ConfigMaps followed by a ModelCatalogSource referencing
+// them, separated by "---", so a new catalog version is deployed with a single
+// `oc apply --server-side`
+func EncodeCatalogSource(catalog *types.ModelsCatalog, opts CatalogSourceOptions) ([]byte, error) {
+	name := opts.Name
+	if name == "" {
+		name = DefaultCatalogSourceName
+	}
+	configMapName := opts.ConfigMapName
+	if configMapName == "" {
+		configMapName = name
+	}
+
+	configMaps, err := EncodeConfigMaps(catalog, ConfigMapOptions{Name: configMapName, Namespace: opts.Namespace})
+	if err != nil {
+		return nil, err
+	}
+	chunks, err := ChunkCatalog(catalog, CatalogFormatYAML, MaxConfigMapDataSize)
+	if err != nil {
+		return nil, err
+	}
+
+	source := modelCatalogSource{
+		APIVersion: "modelregistry.opendatahub.io/v1alpha1",
+		Kind:       "ModelCatalogSource",
+		Metadata: configMapMetadata{
+			Name:      name,
+			Namespace: opts.Namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "model-metadata-collection",
+			},
+		},
+		Spec: modelCatalogSourceSpec{
+			DisplayName: catalog.Source,
+			Type:        "yaml",
+			Enabled:     true,
+			Labels:      opts.Labels,
+			Catalog:     modelCatalogSourcePayload{Image: opts.Image},
+		},
+	}
+	for i := range chunks {
+		refName := configMapName
+		if len(chunks) > 1 {
+			refName = fmt.Sprintf("%s-%d", configMapName, i+1)
+		}
+		source.Spec.Catalog.ConfigMaps = append(source.Spec.Catalog.ConfigMaps, configMapKeyRef{Name: refName, Key: ConfigMapDataKey})
+	}
+
+	manifest, err := yaml.Marshal(source)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling ModelCatalogSource %s: %v", name, err)
+	}
+
+	var buf bytes.Buffer
+	buf.Write(configMaps)
+	buf.WriteString("---\n")
+	buf.Write(manifest)
+	return buf.Bytes(), nil
+}
+
+// WriteCatalogSource writes the ConfigMaps and ModelCatalogSource of a catalog to opts.Path
+func WriteCatalogSource(catalog *types.ModelsCatalog, opts CatalogSourceOptions) error {
+	output, err := EncodeCatalogSource(catalog, opts)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(opts.Path, output, 0644); err != nil {
+		return fmt.Errorf("error writing ModelCatalogSource: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package catalog
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestEncodeCatalogSource(t *testing.T) {
+	data, err := EncodeCatalogSource(sampleCatalog(), CatalogSourceOptions{
+		Namespace:     "rhoai",
+		Labels:        []string{"Red Hat AI", "validated"},
+		ConfigMapName: "model-catalog",
+		Image:         "quay.io/opendatahub/model-catalog:latest",
+	})
+	if err != nil {
+		t.Fatalf("EncodeCatalogSource failed: %v", err)
+	}
+	parts := bytes.Split(data, []byte("---\n"))
+	if len(parts) != 2 {
+		t.Fatalf("Expected a ConfigMap and a ModelCatalogSource, got %d documents", len(parts))
+	}
+	if configMaps := decodeConfigMaps(t, parts[0]); len(configMaps) != 1 || configMaps[0].Metadata.Namespace != "rhoai" {
+		t.Errorf("Unexpected ConfigMaps %+v", configMaps)
+	}
+
+	var source modelCatalogSource
+	if err := yaml.Unmarshal(parts[1], &source); err != nil {
+		t.Fatal(err)
+	}
+	if source.Kind != "ModelCatalogSource" || source.Metadata.Name != DefaultCatalogSourceName || source.Metadata.Namespace != "rhoai" {
+		t.Errorf("Unexpected resource %s %+v", source.Kind, source.Metadata)
+	}
+	if source.Spec.DisplayName != sampleCatalog().Source || !reflect.DeepEqual(source.Spec.Labels, []string{"Red Hat AI", "validated"}) {
+		t.Errorf("Unexpected spec %+v", source.Spec)
+	}
+	want := []configMapKeyRef{{Name: "model-catalog", Key: ConfigMapDataKey}}
+	if !reflect.DeepEqual(source.Spec.Catalog.ConfigMaps, want) || source.Spec.Catalog.Image != "quay.io/opendatahub/model-catalog:latest" {
+		t.Errorf("Unexpected catalog payload %+v", source.Spec.Catalog)
+	}
+}
@@ -0,0 +1,258 @@
+package catalog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// changelogHeader starts every generated changelog file
+const changelogHeader = "# Catalog Changelog\n\n"
+
+// ChangelogOptions controls changelog generation between runs
+type ChangelogOptions struct {
+	// Path is the markdown changelog new entries are prepended to
+	Path string
+	// SnapshotPath stores the catalog of the previous run; defaults to SnapshotPath(catalogPath)
+	SnapshotPath string
+	// OnChanges, when set, receives the changes since the previous run; it is not called on the
+	// first run, which has no snapshot to compare with
+	OnChanges func(changes *CatalogChanges)
+}
+
+// CatalogChanges lists the differences between two catalogs
+type CatalogChanges struct {
+	Added            []string
+	Removed          []string
+	Relicensed       []LicenseChange
+	ArtifactsUpdated []ArtifactChange
+}
+
+// LicenseChange records a model whose license changed
+type LicenseChange struct {
+	Model string
+	From  string
+	To    string
+}
+
+// ArtifactChange records an artifact that now points at a different image: a new tag or digest
+// for the same repository, or a rebuilt image under the same reference
+type ArtifactChange struct {
+	Model string
+	From  string
+	To    string
+}
+
+// Empty reports whether there are no changes
+func (c *CatalogChanges) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Relicensed) == 0 && len(c.ArtifactsUpdated) == 0
+}
+
+// SnapshotPath returns the default previous-run snapshot location for catalogPath, e.g.
+// data/.models-catalog-snapshot.yaml for data/models-catalog.yaml
+func SnapshotPath(catalogPath string) string {
+	base := strings.TrimSuffix(filepath.Base(catalogPath), filepath.Ext(catalogPath))
+	return filepath.Join(filepath.Dir(catalogPath), "."+base+"-snapshot.yaml")
+}
+
+// DiffCatalogs compares the models of two catalogs by name
+func DiffCatalogs(previous, current *types.ModelsCatalog) CatalogChanges {
+	var changes CatalogChanges
+
+	previousModels := modelsByName(previous)
+	currentModels := modelsByName(current)
+
+	for _, name := range sortedModelNames(currentModels) {
+		old, existed := previousModels[name]
+		model := currentModels[name]
+		if !existed {
+			changes.Added = append(changes.Added, name)
+			continue
+		}
+
+		oldLicense, newLicense := utils.StringValue(old.License), utils.StringValue(model.License)
+		if oldLicense != newLicense {
+			changes.Relicensed = append(changes.Relicensed, LicenseChange{Model: name, From: oldLicense, To: newLicense})
+		}
+
+		changes.ArtifactsUpdated = append(changes.ArtifactsUpdated, diffArtifacts(name, old.Artifacts, model.Artifacts)...)
+	}
+
+	for _, name := range sortedModelNames(previousModels) {
+		if _, exists := currentModels[name]; !exists {
+			changes.Removed = append(changes.Removed, name)
+		}
+	}
+
+	return changes
+}
+
+// diffArtifacts matches artifacts by repository and reports those whose reference or image changed
+func diffArtifacts(model string, previous, current []types.CatalogOCIArtifact) []ArtifactChange {
+	previousByRepo := make(map[string]types.CatalogOCIArtifact)
+	for _, artifact := range previous {
+		previousByRepo[artifactRepository(artifact.URI)] = artifact
+	}
+
+	var changes []ArtifactChange
+	for _, artifact := range current {
+		old, ok := previousByRepo[artifactRepository(artifact.URI)]
+		if !ok {
+			continue
+		}
+		if old.URI != artifact.URI {
+			changes = append(changes, ArtifactChange{Model: model, From: old.URI, To: artifact.URI})
+			continue
+		}
+		// Same reference but a newer image: the tag was moved to a different digest
+		oldUpdate, newUpdate := utils.StringValue(old.LastUpdateTimeSinceEpoch), utils.StringValue(artifact.LastUpdateTimeSinceEpoch)
+		if oldUpdate != "" && newUpdate != "" && oldUpdate != newUpdate {
+			changes = append(changes, ArtifactChange{
+				Model: model,
+				From:  fmt.Sprintf("%s (updated %s)", old.URI, formatEpochMillis(oldUpdate)),
+				To:    fmt.Sprintf("%s (updated %s)", artifact.URI, formatEpochMillis(newUpdate)),
+			})
+		}
+	}
+	return changes
+}
+
+// artifactRepository strips the tag or digest from an artifact URI
+func artifactRepository(uri string) string {
+	if at := strings.Index(uri, "@"); at >= 0 {
+		return uri[:at]
+	}
+	if colon := strings.LastIndex(uri, ":"); colon > strings.LastIndex(uri, "/") {
+		return uri[:colon]
+	}
+	return uri
+}
+
+// formatEpochMillis renders a millisecond epoch timestamp as a UTC date, or returns it unchanged
+func formatEpochMillis(value string) string {
+	var millis int64
+	if _, err := fmt.Sscanf(value, "%d", &millis); err != nil {
+		return value
+	}
+	return time.UnixMilli(millis).UTC().Format("2006-01-02")
+}
+
+func modelsByName(catalog *types.ModelsCatalog) map[string]types.CatalogMetadata {
+	models := make(map[string]types.CatalogMetadata)
+	if catalog == nil {
+		return models
+	}
+	for _, model := range catalog.Models {
+		if model.Name != nil && *model.Name != "" {
+			models[*model.Name] = model
+		}
+	}
+	return models
+}
+
+func sortedModelNames(models map[string]types.CatalogMetadata) []string {
+	names := make([]string, 0, len(models))
+	for name := range models {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RenderChangelogEntry formats changes as a markdown changelog section
+func RenderChangelogEntry(changes *CatalogChanges, catalogName string, generated time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s - %s\n\n", generated.UTC().Format("2006-01-02 15:04 UTC"), catalogName)
+
+	if len(changes.Added) > 0 {
+		fmt.Fprintf(&b, "### Added (%d)\n\n", len(changes.Added))
+		for _, name := range changes.Added {
+			fmt.Fprintf(&b, "- %s\n", name)
+		}
+		b.WriteString("\n")
+	}
+	if len(changes.Removed) > 0 {
+		fmt.Fprintf(&b, "### Removed (%d)\n\n", len(changes.Removed))
+		for _, name := range changes.Removed {
+			fmt.Fprintf(&b, "- %s\n", name)
+		}
+		b.WriteString("\n")
+	}
+	if len(changes.Relicensed) > 0 {
+		fmt.Fprintf(&b, "### Re-licensed (%d)\n\n", len(changes.Relicensed))
+		for _, change := range changes.Relicensed {
+			fmt.Fprintf(&b, "- %s: %s → %s\n", change.Model, orNone(change.From), orNone(change.To))
+		}
+		b.WriteString("\n")
+	}
+	if len(changes.ArtifactsUpdated) > 0 {
+		fmt.Fprintf(&b, "### Artifacts updated (%d)\n\n", len(changes.ArtifactsUpdated))
+		for _, change := range changes.ArtifactsUpdated {
+			fmt.Fprintf(&b, "- %s: `%s` → `%s`\n", change.Model, change.From, change.To)
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
+
+// UpdateChangelog compares the catalog with the snapshot of the previous run, prepends an entry to
+// the changelog when anything changed, and replaces the snapshot with the current catalog. The
+// first run only records the snapshot. Returns the changes, or nil when there was no snapshot.
+func UpdateChangelog(catalog *types.ModelsCatalog, catalogPath string, opts ChangelogOptions, now time.Time) (*CatalogChanges, error) {
+	snapshotPath := opts.SnapshotPath
+	if snapshotPath == "" {
+		snapshotPath = SnapshotPath(catalogPath)
+	}
+
+	var changes *CatalogChanges
+	data, err := os.ReadFile(snapshotPath)
+	switch {
+	case err == nil:
+		var previous types.ModelsCatalog
+		if err := yaml.Unmarshal(data, &previous); err != nil {
+			return nil, fmt.Errorf("error parsing catalog snapshot %s: %v", snapshotPath, err)
+		}
+		diff := DiffCatalogs(&previous, catalog)
+		changes = &diff
+	case !os.IsNotExist(err):
+		return nil, fmt.Errorf("error reading catalog snapshot %s: %v", snapshotPath, err)
+	}
+
+	if changes != nil && !changes.Empty() {
+		existing, err := os.ReadFile(opts.Path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("error reading changelog: %v", err)
+		}
+		entries := strings.TrimPrefix(string(existing), changelogHeader)
+		entry := RenderChangelogEntry(changes, filepath.Base(catalogPath), now)
+		if err := os.WriteFile(opts.Path, []byte(changelogHeader+entry+entries), 0644); err != nil {
+			return nil, fmt.Errorf("error writing changelog: %v", err)
+		}
+	}
+
+	snapshot, err := yaml.Marshal(catalog)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling catalog snapshot: %v", err)
+	}
+	if err := os.WriteFile(snapshotPath, snapshot, 0644); err != nil {
+		return nil, fmt.Errorf("error writing catalog snapshot: %v", err)
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,126 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestDiffCatalogs(t *testing.T) {
+	previous := &types.ModelsCatalog{Models: []types.CatalogMetadata{
+		{Name: stringPtr("kept"), License: stringPtr("apache-2.0"), Artifacts: []types.CatalogOCIArtifact{
+			{URI: "oci://registry.example.com/kept:1.4"},
+			{URI: "oci://registry.example.com/kept-fp8:1.5", LastUpdateTimeSinceEpoch: stringPtr("1739776988000")},
+		}},
+		{Name: stringPtr("removed")},
+	}}
+	current := &types.ModelsCatalog{Models: []types.CatalogMetadata{
+		{Name: stringPtr("kept"), License: stringPtr("mit"), Artifacts: []types.CatalogOCIArtifact{
+			{URI: "oci://registry.example.com/kept:1.5"},
+			{URI: "oci://registry.example.com/kept-fp8:1.5", LastUpdateTimeSinceEpoch: stringPtr("1744136202000")},
+		}},
+		{Name: stringPtr("added")},
+	}}
+
+	changes := DiffCatalogs(previous, current)
+
+	if len(changes.Added) != 1 || changes.Added[0] != "added" {
+		t.Errorf("Added = %v", changes.Added)
+	}
+	if len(changes.Removed) != 1 || changes.Removed[0] != "removed" {
+		t.Errorf("Removed = %v", changes.Removed)
+	}
+	if len(changes.Relicensed) != 1 || changes.Relicensed[0] != (LicenseChange{Model: "kept", From: "apache-2.0", To: "mit"}) {
+		t.Errorf("Relicensed = %+v", changes.Relicensed)
+	}
+	if len(changes.ArtifactsUpdated) != 2 {
+		t.Fatalf("Expected 2 artifact updates, got %+v", changes.ArtifactsUpdated)
+	}
+	if changes.ArtifactsUpdated[0].From != "oci://registry.example.com/kept:1.4" || changes.ArtifactsUpdated[0].To != "oci://registry.example.com/kept:1.5" {
+		t.Errorf("Unexpected tag change %+v", changes.ArtifactsUpdated[0])
+	}
+	if !strings.Contains(changes.ArtifactsUpdated[1].To, "updated 2025-04-08") {
+		t.Errorf("Unexpected rebuilt image change %+v", changes.ArtifactsUpdated[1])
+	}
+
+	if unchanged := DiffCatalogs(current, current); !unchanged.Empty() {
+		t.Errorf("Expected no changes between identical catalogs, got %+v", unchanged)
+	}
+}
+
+func TestArtifactRepository(t *testing.T) {
+	tests := map[string]string{
+		"oci://registry.example.com/model:1.5":               "oci://registry.example.com/model",
+		"oci://registry.example.com/model@sha256:abc":        "oci://registry.example.com/model",
+		"oci://registry.example.com:5000/model":              "oci://registry.example.com:5000/model",
+		"oci://registry.example.com:5000/model:latest":       "oci://registry.example.com:5000/model",
+		"oci://registry.example.com:5000/model@sha256:abcde": "oci://registry.example.com:5000/model",
+	}
+	for uri, expected := range tests {
+		if got := artifactRepository(uri); got != expected {
+			t.Errorf("artifactRepository(%s) = %s, want %s", uri, got, expected)
+		}
+	}
+}
+
+func TestUpdateChangelog(t *testing.T) {
+	tmpDir := t.TempDir()
+	catalogPath := filepath.Join(tmpDir, "models-catalog.yaml")
+	opts := ChangelogOptions{Path: filepath.Join(tmpDir, "catalog-changelog.md")}
+	now := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+
+	// The first run only records the snapshot
+	changes, err := UpdateChangelog(sampleCatalog(), catalogPath, opts, now)
+	if err != nil {
+		t.Fatalf("UpdateChangelog failed: %v", err)
+	}
+	if changes != nil {
+		t.Errorf("Expected no changes without a snapshot, got %+v", changes)
+	}
+	if _, err := os.Stat(SnapshotPath(catalogPath)); err != nil {
+		t.Fatalf("Expected snapshot to be written: %v", err)
+	}
+	if _, err := os.Stat(opts.Path); !os.IsNotExist(err) {
+		t.Error("Expected no changelog on the first run")
+	}
+
+	// Unchanged catalogs leave the changelog alone
+	if changes, err = UpdateChangelog(sampleCatalog(), catalogPath, opts, now); err != nil || changes == nil || !changes.Empty() {
+		t.Fatalf("Expected empty changes, got %+v (err %v)", changes, err)
+	}
+	if _, err := os.Stat(opts.Path); !os.IsNotExist(err) {
+		t.Error("Expected no changelog when nothing changed")
+	}
+
+	updated := sampleCatalog()
+	updated.Models = append(updated.Models, types.CatalogMetadata{Name: stringPtr("Model C")})
+	if _, err := UpdateChangelog(updated, catalogPath, opts, now); err != nil {
+		t.Fatalf("UpdateChangelog failed: %v", err)
+	}
+
+	later := sampleCatalog()
+	if _, err := UpdateChangelog(later, catalogPath, opts, now.Add(24*time.Hour)); err != nil {
+		t.Fatalf("UpdateChangelog failed: %v", err)
+	}
+
+	data, err := os.ReadFile(opts.Path)
+	if err != nil {
+		t.Fatalf("Failed to read changelog: %v", err)
+	}
+	changelog := string(data)
+	if !strings.HasPrefix(changelog, changelogHeader) || strings.Count(changelog, changelogHeader) != 1 {
+		t.Errorf("Expected a single changelog header, got:\n%s", changelog)
+	}
+	newest := strings.Index(changelog, "## 2026-10-17 09:30 UTC - models-catalog.yaml")
+	oldest := strings.Index(changelog, "## 2026-10-16 09:30 UTC - models-catalog.yaml")
+	if newest < 0 || oldest < 0 || newest > oldest {
+		t.Errorf("Expected newest entry first, got:\n%s", changelog)
+	}
+	if !strings.Contains(changelog, "### Added (1)\n\n- Model C\n") || !strings.Contains(changelog, "### Removed (1)\n\n- Model C\n") {
+		t.Errorf("Expected added and removed entries, got:\n%s", changelog)
+	}
+}
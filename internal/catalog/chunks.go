@@ -0,0 +1,146 @@
+package catalog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// CatalogIndex lists the chunk files a large catalog was split into, in model order
+type CatalogIndex struct {
+	Source      string              `yaml:"source"`
+	Format      string              `yaml:"format"`
+	TotalModels int                 `yaml:"totalModels"`
+	Chunks      []CatalogIndexChunk `yaml:"chunks"`
+}
+
+// CatalogIndexChunk describes one chunk file of a split catalog
+type CatalogIndexChunk struct {
+	// File is the chunk file name, relative to the index file
+	File   string `yaml:"file"`
+	Models int    `yaml:"models"`
+	Size   int    `yaml:"size"`
+}
+
+// ChunkCatalog splits a catalog into catalogs with the same source whose encoding in format stays
+// within maxSize bytes. Models are kept in order and never split; a single model larger than
+// maxSize is an error.
+func ChunkCatalog(catalog *types.ModelsCatalog, format string, maxSize int) ([]types.ModelsCatalog, error) {
+	var chunks []types.ModelsCatalog
+	current := types.ModelsCatalog{Source: catalog.Source}
+	currentSize := 0
+
+	for _, model := range catalog.Models {
+		encoded, err := EncodeCatalog(&types.ModelsCatalog{Source: catalog.Source, Models: []types.CatalogMetadata{model}}, format)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling model %s: %v", getModelName(&model), err)
+		}
+		size := len(encoded)
+		if size > maxSize {
+			return nil, fmt.Errorf("model %s encodes to %d bytes, more than the %d byte chunk limit", getModelName(&model), size, maxSize)
+		}
+
+		// Single-model encodings include the catalog header, so the sum overestimates the chunk size
+		if len(current.Models) > 0 && currentSize+size > maxSize {
+			chunks = append(chunks, current)
+			current = types.ModelsCatalog{Source: catalog.Source}
+			currentSize = 0
+		}
+		current.Models = append(current.Models, model)
+		currentSize += size
+	}
+
+	if len(current.Models) > 0 || len(chunks) == 0 {
+		chunks = append(chunks, current)
+	}
+	return chunks, nil
+}
+
+// ChunkPath returns the path of the n-th (1-based) chunk of catalogPath, e.g.
+// data/models-catalog-001.yaml for data/models-catalog.yaml
+func ChunkPath(catalogPath string, n int) string {
+	ext := filepath.Ext(catalogPath)
+	return fmt.Sprintf("%s-%03d%s", strings.TrimSuffix(catalogPath, ext), n, ext)
+}
+
+// IndexPath returns the path of the chunk index for catalogPath, e.g.
+// data/models-catalog-index.yaml for data/models-catalog.yaml
+func IndexPath(catalogPath string) string {
+	return strings.TrimSuffix(catalogPath, filepath.Ext(catalogPath)) + "-index.yaml"
+}
+
+// WriteCatalogChunks splits the catalog into chunk files next to catalogPath when its encoding
+// exceeds maxSize bytes, and writes an index listing them. Chunk files and the index left by a
+// previous run are removed first, so a catalog that fits again is not shadowed by stale chunks.
+// Returns the index, or nil when the catalog was small enough to stay in one file.
+func WriteCatalogChunks(catalog *types.ModelsCatalog, catalogPath, format string, maxSize int) (*CatalogIndex, error) {
+	if err := removeCatalogChunks(catalogPath); err != nil {
+		return nil, err
+	}
+
+	full, err := EncodeCatalog(catalog, format)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling catalog: %v", err)
+	}
+	if len(full) <= maxSize {
+		return nil, nil
+	}
+
+	chunks, err := ChunkCatalog(catalog, format, maxSize)
+	if err != nil {
+		return nil, err
+	}
+
+	index := &CatalogIndex{
+		Source:      catalog.Source,
+		Format:      format,
+		TotalModels: len(catalog.Models),
+	}
+	for i := range chunks {
+		output, err := EncodeCatalog(&chunks[i], format)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling catalog chunk: %v", err)
+		}
+		chunkPath := ChunkPath(catalogPath, i+1)
+		if err := os.WriteFile(chunkPath, output, 0644); err != nil {
+			return nil, fmt.Errorf("error writing catalog chunk: %v", err)
+		}
+		index.Chunks = append(index.Chunks, CatalogIndexChunk{
+			File:   filepath.Base(chunkPath),
+			Models: len(chunks[i].Models),
+			Size:   len(output),
+		})
+	}
+
+	output, err := yaml.Marshal(index)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling catalog index: %v", err)
+	}
+	if err := os.WriteFile(IndexPath(catalogPath), output, 0644); err != nil {
+		return nil, fmt.Errorf("error writing catalog index: %v", err)
+	}
+
+	return index, nil
+}
+
+// removeCatalogChunks deletes the chunk files and index of a previous split of catalogPath
+func removeCatalogChunks(catalogPath string) error {
+	ext := filepath.Ext(catalogPath)
+	stale, err := filepath.Glob(strings.TrimSuffix(catalogPath, ext) + "-[0-9][0-9][0-9]" + ext)
+	if err != nil {
+		return fmt.Errorf("error listing catalog chunks: %v", err)
+	}
+	stale = append(stale, IndexPath(catalogPath))
+
+	for _, path := range stale {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("error removing stale catalog chunk: %v", err)
+		}
+	}
+	return nil
+}
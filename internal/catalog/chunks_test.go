@@ -0,0 +1,129 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestChunkCatalog(t *testing.T) {
+	catalog := sampleCatalog()
+
+	chunks, err := ChunkCatalog(catalog, CatalogFormatYAML, MaxConfigMapDataSize)
+	if err != nil {
+		t.Fatalf("ChunkCatalog failed: %v", err)
+	}
+	if len(chunks) != 1 || len(chunks[0].Models) != 2 {
+		t.Fatalf("Expected one chunk with 2 models, got %d chunks", len(chunks))
+	}
+
+	// A limit that fits one model at a time forces one chunk per model
+	single, err := EncodeCatalog(&types.ModelsCatalog{Source: catalog.Source, Models: catalog.Models[:1]}, CatalogFormatYAML)
+	if err != nil {
+		t.Fatalf("EncodeCatalog failed: %v", err)
+	}
+	chunks, err = ChunkCatalog(catalog, CatalogFormatYAML, len(single))
+	if err != nil {
+		t.Fatalf("ChunkCatalog failed: %v", err)
+	}
+	if len(chunks) != 2 {
+		t.Fatalf("Expected 2 chunks, got %d", len(chunks))
+	}
+	for i, chunk := range chunks {
+		if chunk.Source != catalog.Source {
+			t.Errorf("Chunk %d source = %q, want %q", i, chunk.Source, catalog.Source)
+		}
+		if *chunk.Models[0].Name != *catalog.Models[i].Name {
+			t.Errorf("Chunk %d model = %s, want %s", i, *chunk.Models[0].Name, *catalog.Models[i].Name)
+		}
+	}
+
+	if _, err := ChunkCatalog(catalog, CatalogFormatYAML, 10); err == nil {
+		t.Error("Expected error for a model larger than the chunk limit")
+	}
+}
+
+func TestChunkAndIndexPaths(t *testing.T) {
+	if got := ChunkPath("data/models-catalog.yaml", 2); got != "data/models-catalog-002.yaml" {
+		t.Errorf("ChunkPath = %s", got)
+	}
+	if got := ChunkPath("data/models-catalog.json", 12); got != "data/models-catalog-012.json" {
+		t.Errorf("ChunkPath = %s", got)
+	}
+	if got := IndexPath("data/models-catalog.json"); got != "data/models-catalog-index.yaml" {
+		t.Errorf("IndexPath = %s", got)
+	}
+}
+
+func TestWriteCatalogChunks(t *testing.T) {
+	readme := strings.Repeat("x", 400)
+	catalog := &types.ModelsCatalog{Source: "Red Hat"}
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		catalog.Models = append(catalog.Models, types.CatalogMetadata{Name: stringPtr(name), Readme: &readme})
+	}
+
+	catalogPath := filepath.Join(t.TempDir(), "models-catalog.yaml")
+	index, err := WriteCatalogChunks(catalog, catalogPath, CatalogFormatYAML, 1000)
+	if err != nil {
+		t.Fatalf("WriteCatalogChunks failed: %v", err)
+	}
+	if index == nil || len(index.Chunks) < 2 {
+		t.Fatalf("Expected the catalog to be split, got %+v", index)
+	}
+	if index.TotalModels != 5 || index.Format != CatalogFormatYAML || index.Source != "Red Hat" {
+		t.Errorf("Unexpected index header %+v", index)
+	}
+
+	data, err := os.ReadFile(IndexPath(catalogPath))
+	if err != nil {
+		t.Fatalf("Failed to read index: %v", err)
+	}
+	var written CatalogIndex
+	if err := yaml.Unmarshal(data, &written); err != nil {
+		t.Fatalf("Failed to parse index: %v", err)
+	}
+
+	var names []string
+	for i, chunk := range written.Chunks {
+		if chunk.File != filepath.Base(ChunkPath(catalogPath, i+1)) {
+			t.Errorf("Chunk %d file = %s", i, chunk.File)
+		}
+		content, err := os.ReadFile(filepath.Join(filepath.Dir(catalogPath), chunk.File))
+		if err != nil {
+			t.Fatalf("Failed to read chunk: %v", err)
+		}
+		if len(content) > 1000 || len(content) != chunk.Size {
+			t.Errorf("Chunk %s is %d bytes, index says %d", chunk.File, len(content), chunk.Size)
+		}
+		var part types.ModelsCatalog
+		if err := yaml.Unmarshal(content, &part); err != nil {
+			t.Fatalf("Failed to parse chunk: %v", err)
+		}
+		for _, model := range part.Models {
+			names = append(names, *model.Name)
+		}
+	}
+	if strings.Join(names, ",") != "a,b,c,d,e" {
+		t.Errorf("Chunks hold models %v, want a-e in order", names)
+	}
+
+	// A catalog that fits removes the chunks of the previous run
+	index, err = WriteCatalogChunks(sampleCatalog(), catalogPath, CatalogFormatYAML, 1000)
+	if err != nil {
+		t.Fatalf("WriteCatalogChunks failed: %v", err)
+	}
+	if index != nil {
+		t.Errorf("Expected no index for a small catalog, got %+v", index)
+	}
+	if _, err := os.Stat(ChunkPath(catalogPath, 1)); !os.IsNotExist(err) {
+		t.Error("Expected stale chunk to be removed")
+	}
+	if _, err := os.Stat(IndexPath(catalogPath)); !os.IsNotExist(err) {
+		t.Error("Expected stale index to be removed")
+	}
+}
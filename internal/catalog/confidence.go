@@ -0,0 +1,73 @@
+package catalog
+
+import (
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// provenanceFileName is the per-model provenance report written next to metadata.yaml
+const provenanceFileName = "provenance.yaml"
+
+// lowConfidence marks values that were inferred or generated rather than stated by any source
+const lowConfidence = "guess"
+
+// provenanceFile mirrors provenance.yaml, whose confidence levels catalog generation reads and
+// whose fields it completes with the static catalog and override sources
+type provenanceFile struct {
+	RegistryModel    string                     `yaml:"registry_model"`
+	HuggingFaceModel string                     `yaml:"huggingface_model,omitempty"`
+	MatchConfidence  string                     `yaml:"match_confidence,omitempty"`
+	Fields           map[string]provenanceField `yaml:"fields"`
+}
+
+// provenanceField mirrors the provenance of a single field
+type provenanceField struct {
+	Category   string `yaml:"category"`
+	Source     string `yaml:"source"`
+	Confidence string `yaml:"confidence,omitempty"`
+}
+
+// lowConfidenceFields clears each metadata field that can be dropped from the catalog; the name,
+// readme and artifacts identify and describe the model and are always kept
+var lowConfidenceFields = map[string]func(*types.ExtractedMetadata){
+	"provider":                 func(m *types.ExtractedMetadata) { m.Provider = nil },
+	"description":              func(m *types.ExtractedMetadata) { m.Description = nil },
+	"language":                 func(m *types.ExtractedMetadata) { m.Language = nil },
+	"license":                  func(m *types.ExtractedMetadata) { m.License = nil },
+	"licenseLink":              func(m *types.ExtractedMetadata) { m.LicenseLink = nil },
+	"tasks":                    func(m *types.ExtractedMetadata) { m.Tasks = nil },
+	"createTimeSinceEpoch":     func(m *types.ExtractedMetadata) { m.CreateTimeSinceEpoch = nil },
+	"lastUpdateTimeSinceEpoch": func(m *types.ExtractedMetadata) { m.LastUpdateTimeSinceEpoch = nil },
+	"trainingDatasets":         func(m *types.ExtractedMetadata) { m.TrainingDatasets = nil },
+	"baseModel":                func(m *types.ExtractedMetadata) { m.BaseModel = nil },
+	"evaluations":              func(m *types.ExtractedMetadata) { m.Evaluations = nil },
+	"maxContextLength":         func(m *types.ExtractedMetadata) { m.MaxContextLength = nil },
+}
+
+// dropLowConfidenceValues clears the fields of a model that its provenance report in output
+// marks as guesses, returning the names of the cleared fields
+func dropLowConfidenceValues(model *types.ExtractedMetadata, output outputfs.FS, ref string) ([]string, error) {
+	data, err := output.ReadFile(outputfs.ModelPath(ref, provenanceFileName))
+	if err != nil {
+		return nil, fmt.Errorf("error reading provenance: %v", err)
+	}
+	var provenance provenanceFile
+	if err := yaml.Unmarshal(data, &provenance); err != nil {
+		return nil, fmt.Errorf("error parsing provenance: %v", err)
+	}
+
+	var dropped []string
+	for field, entry := range provenance.Fields {
+		if clear, ok := lowConfidenceFields[field]; ok && entry.Confidence == lowConfidence {
+			clear(model)
+			dropped = append(dropped, field)
+		}
+	}
+	sort.Strings(dropped)
+	return dropped, nil
+}
@@ -0,0 +1,64 @@
+package catalog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestDropLowConfidenceValues(t *testing.T) {
+	output := outputfs.Dir(t.TempDir())
+	ref := "registry.example.com/org/granite:1.0"
+	provenance := `fields:
+  name:
+    category: modelcard
+    source: modelcard.md
+    confidence: heuristic
+  description:
+    category: generated
+    source: generated
+    confidence: guess
+  tasks:
+    category: modelcard
+    source: modelcard.inferred
+    confidence: guess
+  license:
+    category: huggingface-frontmatter
+    source: huggingface.yaml
+    confidence: exact
+`
+	if err := output.MkdirAll(outputfs.ModelPath(ref), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := output.WriteFile(outputfs.ModelPath(ref, provenanceFileName), []byte(provenance), 0644); err != nil {
+		t.Fatalf("Failed to write provenance: %v", err)
+	}
+
+	name, description, license := "granite", "A granite model", "apache-2.0"
+	model := types.ExtractedMetadata{
+		Name:        &name,
+		Description: &description,
+		License:     &license,
+		Tasks:       []string{"text-generation"},
+	}
+
+	dropped, err := dropLowConfidenceValues(&model, output, ref)
+	if err != nil {
+		t.Fatalf("dropLowConfidenceValues() error = %v", err)
+	}
+	if !reflect.DeepEqual(dropped, []string{"description", "tasks"}) {
+		t.Errorf("dropped = %v, want [description tasks]", dropped)
+	}
+	if model.Description != nil || model.Tasks != nil {
+		t.Errorf("Expected guessed description and tasks to be cleared, got %v and %v", model.Description, model.Tasks)
+	}
+	if model.Name == nil || model.License == nil {
+		t.Error("Expected name and exact license to be kept")
+	}
+
+	if _, err := dropLowConfidenceValues(&model, outputfs.Dir(t.TempDir()), ref); err == nil {
+		t.Error("Expected an error when provenance.yaml is missing")
+	}
+}
@@ -0,0 +1,113 @@
+package catalog
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// MaxConfigMapDataSize is the catalog data budget per ConfigMap. Kubernetes rejects objects
+// over 1 MiB, so this leaves headroom for the manifest envelope and YAML escaping. ConfigMaps
+// this large must be applied server-side (see ConfigMapApplyNote).
+const MaxConfigMapDataSize = 1000 * 1000
+
+// ConfigMapApplyNote heads the generated manifests. Client-side `kubectl apply` copies each object
+// into the kubectl.kubernetes.io/last-applied-configuration annotation, and annotations are
+// limited to 256KiB in total, less than a single model's readme may take.
+const ConfigMapApplyNote = "# Apply with `kubectl apply --server-side` (or `oc apply --server-side`) or `kubectl create`:\n" +
+	"# client-side apply fails for ConfigMaps over 256KiB.\n"
+
+// ConfigMapDataKey is the data key holding the catalog in each generated ConfigMap
+const ConfigMapDataKey = "models-catalog.yaml"
+
+// ConfigMapOptions controls generation of Kubernetes ConfigMap manifests for the catalog
+type ConfigMapOptions struct {
+	// Path is the file the ConfigMap manifests are written to (multiple YAML documents)
+	Path string
+	// Name is the ConfigMap name; chunks are named <name>-1, <name>-2, ...
+	Name string
+	// Namespace is set on every ConfigMap when non-empty
+	Namespace string
+}
+
+// configMap is the subset of a Kubernetes ConfigMap manifest written by the tool
+type configMap struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   configMapMetadata `yaml:"metadata"`
+	Data       map[string]string `yaml:"data"`
+}
+
+type configMapMetadata struct {
+	Name      string            `yaml:"name"`
+	Namespace string            `yaml:"namespace,omitempty"`
+	Labels    map[string]string `yaml:"labels,omitempty"`
+}
+
+// EncodeConfigMaps wraps a catalog into one or more ConfigMap manifests separated by "---".
+// A catalog that fits in a single ConfigMap keeps the configured name; larger catalogs are
+// chunked into <name>-1, <name>-2, ..., each holding a complete catalog with a subset of models.
+func EncodeConfigMaps(catalog *types.ModelsCatalog, opts ConfigMapOptions) ([]byte, error) {
+	if opts.Name == "" {
+		return nil, fmt.Errorf("ConfigMap name must not be empty")
+	}
+
+	chunks, err := ChunkCatalog(catalog, CatalogFormatYAML, MaxConfigMapDataSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(ConfigMapApplyNote)
+	for i := range chunks {
+		data, err := EncodeCatalog(&chunks[i], CatalogFormatYAML)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling catalog chunk: %v", err)
+		}
+
+		name := opts.Name
+		if len(chunks) > 1 {
+			name = fmt.Sprintf("%s-%d", opts.Name, i+1)
+		}
+
+		manifest, err := yaml.Marshal(configMap{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+			Metadata: configMapMetadata{
+				Name:      name,
+				Namespace: opts.Namespace,
+				Labels: map[string]string{
+					"app.kubernetes.io/part-of":    opts.Name,
+					"app.kubernetes.io/managed-by": "model-metadata-collection",
+				},
+			},
+			Data: map[string]string{ConfigMapDataKey: string(data)},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling ConfigMap %s: %v", name, err)
+		}
+
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(manifest)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// WriteConfigMaps writes the ConfigMap manifests for a catalog to opts.Path
+func WriteConfigMaps(catalog *types.ModelsCatalog, opts ConfigMapOptions) error {
+	output, err := EncodeConfigMaps(catalog, opts)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(opts.Path, output, 0644); err != nil {
+		return fmt.Errorf("error writing ConfigMap manifests: %v", err)
+	}
+	return nil
+}
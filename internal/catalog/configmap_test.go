@@ -0,0 +1,107 @@
+package catalog
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// decodeConfigMaps parses a multi-document ConfigMap manifest
+func decodeConfigMaps(t *testing.T, data []byte) []configMap {
+	t.Helper()
+	var configMaps []configMap
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var cm configMap
+		if err := decoder.Decode(&cm); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			t.Fatalf("Failed to decode ConfigMap manifest: %v", err)
+		}
+		configMaps = append(configMaps, cm)
+	}
+	return configMaps
+}
+
+func TestEncodeConfigMaps(t *testing.T) {
+	data, err := EncodeConfigMaps(sampleCatalog(), ConfigMapOptions{Name: "model-catalog", Namespace: "rhoai"})
+	if err != nil {
+		t.Fatalf("EncodeConfigMaps failed: %v", err)
+	}
+	if !bytes.HasPrefix(data, []byte(ConfigMapApplyNote)) {
+		t.Errorf("Expected the manifests to start with the server-side apply note, got %q", data[:min(len(data), 120)])
+	}
+
+	configMaps := decodeConfigMaps(t, data)
+	if len(configMaps) != 1 {
+		t.Fatalf("Expected 1 ConfigMap, got %d", len(configMaps))
+	}
+	cm := configMaps[0]
+	if cm.APIVersion != "v1" || cm.Kind != "ConfigMap" {
+		t.Errorf("Unexpected apiVersion/kind %s/%s", cm.APIVersion, cm.Kind)
+	}
+	if cm.Metadata.Name != "model-catalog" || cm.Metadata.Namespace != "rhoai" {
+		t.Errorf("Unexpected metadata %+v", cm.Metadata)
+	}
+
+	var catalog types.ModelsCatalog
+	if err := yaml.Unmarshal([]byte(cm.Data[ConfigMapDataKey]), &catalog); err != nil {
+		t.Fatalf("ConfigMap data is not a catalog: %v", err)
+	}
+	if len(catalog.Models) != 2 {
+		t.Errorf("Expected 2 models in ConfigMap data, got %d", len(catalog.Models))
+	}
+
+	if _, err := EncodeConfigMaps(sampleCatalog(), ConfigMapOptions{}); err == nil {
+		t.Error("Expected error for empty ConfigMap name")
+	}
+}
+
+func TestWriteConfigMaps_ChunksLargeCatalogs(t *testing.T) {
+	// Each readme is a third of the limit, so three models cannot share one ConfigMap
+	readme := strings.Repeat("x", MaxConfigMapDataSize/3)
+	catalog := &types.ModelsCatalog{Source: "Red Hat"}
+	for _, name := range []string{"a", "b", "c", "d"} {
+		catalog.Models = append(catalog.Models, types.CatalogMetadata{Name: stringPtr(name), Readme: &readme})
+	}
+
+	path := filepath.Join(t.TempDir(), "configmap.yaml")
+	if err := WriteConfigMaps(catalog, ConfigMapOptions{Path: path, Name: "model-catalog"}); err != nil {
+		t.Fatalf("WriteConfigMaps failed: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read ConfigMap manifests: %v", err)
+	}
+
+	configMaps := decodeConfigMaps(t, data)
+	if len(configMaps) != 2 {
+		t.Fatalf("Expected 2 ConfigMaps, got %d", len(configMaps))
+	}
+	total := 0
+	for i, cm := range configMaps {
+		if want := "model-catalog-" + string(rune('1'+i)); cm.Metadata.Name != want {
+			t.Errorf("ConfigMap %d name = %s, want %s", i, cm.Metadata.Name, want)
+		}
+		if size := len(cm.Data[ConfigMapDataKey]); size > MaxConfigMapDataSize {
+			t.Errorf("ConfigMap %s holds %d bytes, over the limit", cm.Metadata.Name, size)
+		}
+		var chunk types.ModelsCatalog
+		if err := yaml.Unmarshal([]byte(cm.Data[ConfigMapDataKey]), &chunk); err != nil {
+			t.Fatalf("ConfigMap data is not a catalog: %v", err)
+		}
+		total += len(chunk.Models)
+	}
+	if total != 4 {
+		t.Errorf("Expected 4 models across ConfigMaps, got %d", total)
+	}
+}
@@ -0,0 +1,112 @@
+package catalog
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// languageTagPattern matches BCP 47 style language tags such as es, ja or pt-BR
+var languageTagPattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+// DescriptionOverrides is the file format of description overrides, which replace or add the
+// descriptions of catalog models by name
+type DescriptionOverrides struct {
+	Models []DescriptionOverride `yaml:"models"`
+}
+
+// DescriptionOverride sets the description and localized descriptions of one model
+type DescriptionOverride struct {
+	Name            string            `yaml:"name"`
+	Description     *string           `yaml:"description,omitempty"`
+	DescriptionI18n map[string]string `yaml:"description_i18n,omitempty"`
+}
+
+// ValidateDescriptionI18n checks that localized descriptions are keyed by language tags and not empty
+func ValidateDescriptionI18n(descriptions map[string]string) error {
+	languages := make([]string, 0, len(descriptions))
+	for language := range descriptions {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+
+	for _, language := range languages {
+		if !languageTagPattern.MatchString(language) {
+			return fmt.Errorf("invalid description_i18n language: %q (expected a language tag such as \"es\" or \"pt-BR\")", language)
+		}
+		if strings.TrimSpace(descriptions[language]) == "" {
+			return fmt.Errorf("invalid description_i18n: %q description must not be empty", language)
+		}
+	}
+	return nil
+}
+
+// LoadDescriptionOverrides reads and validates a description overrides file
+func LoadDescriptionOverrides(path string) ([]DescriptionOverride, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading description overrides: %v", err)
+	}
+
+	var overrides DescriptionOverrides
+	if err := yaml.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("error parsing description overrides: %v", err)
+	}
+
+	for i, override := range overrides.Models {
+		if strings.TrimSpace(override.Name) == "" {
+			return nil, fmt.Errorf("description override at index %d missing required 'name' field", i)
+		}
+		if err := ValidateDescriptionI18n(override.DescriptionI18n); err != nil {
+			return nil, fmt.Errorf("description override '%s': %v", override.Name, err)
+		}
+	}
+
+	return overrides.Models, nil
+}
+
+// applyDescriptionOverrides sets the descriptions of models matching an override by name
+// (case-insensitive). An override's description replaces the model's; its localized descriptions
+// are added to the model's, replacing those for the same language.
+func applyDescriptionOverrides(models []types.CatalogMetadata, overrides []DescriptionOverride) {
+	if len(overrides) == 0 {
+		return
+	}
+
+	byName := make(map[string]int, len(models))
+	for i, model := range models {
+		if model.Name != nil {
+			byName[strings.ToLower(strings.TrimSpace(*model.Name))] = i
+		}
+	}
+
+	for _, override := range overrides {
+		i, ok := byName[strings.ToLower(strings.TrimSpace(override.Name))]
+		if !ok {
+			log.Printf("  Warning: description override for '%s' matches no catalog model", override.Name)
+			continue
+		}
+
+		model := &models[i]
+		if override.Description != nil {
+			model.Description = override.Description
+		}
+		if len(override.DescriptionI18n) > 0 {
+			descriptions := make(map[string]string, len(model.DescriptionI18n)+len(override.DescriptionI18n))
+			for language, description := range model.DescriptionI18n {
+				descriptions[language] = description
+			}
+			for language, description := range override.DescriptionI18n {
+				descriptions[language] = description
+			}
+			model.DescriptionI18n = descriptions
+		}
+	}
+}
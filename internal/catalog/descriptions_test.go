@@ -0,0 +1,122 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+func TestValidateDescriptionI18n(t *testing.T) {
+	testCases := []struct {
+		name         string
+		descriptions map[string]string
+		expectError  string
+	}{
+		{name: "nil", descriptions: nil},
+		{name: "valid tags", descriptions: map[string]string{"es": "Hola", "pt-BR": "Olá", "zh-Hant": "你好"}},
+		{name: "invalid tag", descriptions: map[string]string{"Spanish": "Hola"}, expectError: `invalid description_i18n language: "Spanish"`},
+		{name: "empty description", descriptions: map[string]string{"ja": " "}, expectError: `"ja" description must not be empty`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateDescriptionI18n(tc.descriptions)
+			if tc.expectError == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectError) {
+				t.Errorf("Expected error containing %q, got %v", tc.expectError, err)
+			}
+		})
+	}
+}
+
+func TestLoadDescriptionOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	validPath := filepath.Join(tmpDir, "valid.yaml")
+	if err := os.WriteFile(validPath, []byte(`models:
+  - name: RedHatAI/granite
+    description: Curated description
+    description_i18n:
+      es: Descripción
+`), 0644); err != nil {
+		t.Fatalf("Failed to write overrides: %v", err)
+	}
+
+	overrides, err := LoadDescriptionOverrides(validPath)
+	if err != nil {
+		t.Fatalf("LoadDescriptionOverrides failed: %v", err)
+	}
+	if len(overrides) != 1 || overrides[0].Name != "RedHatAI/granite" || utils.StringValue(overrides[0].Description) != "Curated description" || overrides[0].DescriptionI18n["es"] != "Descripción" {
+		t.Errorf("Unexpected overrides: %+v", overrides)
+	}
+
+	invalidPath := filepath.Join(tmpDir, "invalid.yaml")
+	if err := os.WriteFile(invalidPath, []byte(`models:
+  - name: RedHatAI/granite
+    description_i18n:
+      spanish: Descripción
+`), 0644); err != nil {
+		t.Fatalf("Failed to write overrides: %v", err)
+	}
+	if _, err := LoadDescriptionOverrides(invalidPath); err == nil || !strings.Contains(err.Error(), "description override 'RedHatAI/granite'") {
+		t.Errorf("Expected invalid language error, got %v", err)
+	}
+
+	unnamedPath := filepath.Join(tmpDir, "unnamed.yaml")
+	if err := os.WriteFile(unnamedPath, []byte("models:\n  - description: No name\n"), 0644); err != nil {
+		t.Fatalf("Failed to write overrides: %v", err)
+	}
+	if _, err := LoadDescriptionOverrides(unnamedPath); err == nil || !strings.Contains(err.Error(), "missing required 'name' field") {
+		t.Errorf("Expected missing name error, got %v", err)
+	}
+}
+
+func TestApplyDescriptionOverrides(t *testing.T) {
+	models := []types.CatalogMetadata{
+		{
+			Name:            stringPtr("RedHatAI/granite"),
+			Description:     stringPtr("Extracted description"),
+			DescriptionI18n: map[string]string{"es": "Antigua", "ja": "説明"},
+		},
+		{
+			Name:        stringPtr("other"),
+			Description: stringPtr("Untouched"),
+		},
+	}
+
+	applyDescriptionOverrides(models, []DescriptionOverride{
+		{
+			Name:            "redhatai/granite",
+			DescriptionI18n: map[string]string{"es": "Nueva", "fr": "Nouvelle"},
+		},
+		{Name: "other", Description: stringPtr("Curated")},
+		{Name: "missing", Description: stringPtr("Ignored")},
+	})
+
+	granite := models[0]
+	if utils.StringValue(granite.Description) != "Extracted description" {
+		t.Errorf("Expected description without override to be kept, got %q", utils.StringValue(granite.Description))
+	}
+	expected := map[string]string{"es": "Nueva", "ja": "説明", "fr": "Nouvelle"}
+	if len(granite.DescriptionI18n) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, granite.DescriptionI18n)
+	}
+	for language, description := range expected {
+		if granite.DescriptionI18n[language] != description {
+			t.Errorf("Expected %s description %q, got %q", language, description, granite.DescriptionI18n[language])
+		}
+	}
+
+	if utils.StringValue(models[1].Description) != "Curated" {
+		t.Errorf("Expected overridden description, got %q", utils.StringValue(models[1].Description))
+	}
+}
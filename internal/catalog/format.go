@@ -0,0 +1,166 @@
+package catalog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// Supported catalog output formats
+const (
+	CatalogFormatYAML   = "yaml"
+	CatalogFormatJSON   = "json"
+	CatalogFormatNDJSON = "ndjson"
+)
+
+// ValidateCatalogFormat checks that format is one of the supported catalog output formats
+func ValidateCatalogFormat(format string) error {
+	switch format {
+	case CatalogFormatYAML, CatalogFormatJSON, CatalogFormatNDJSON:
+		return nil
+	default:
+		return fmt.Errorf("invalid catalog format: %q (allowed values: %q, %q, %q)",
+			format, CatalogFormatYAML, CatalogFormatJSON, CatalogFormatNDJSON)
+	}
+}
+
+// CatalogPathForFormat replaces a .yaml/.yml extension on catalogPath with the extension matching format
+func CatalogPathForFormat(catalogPath, format string) string {
+	ext := filepath.Ext(catalogPath)
+	if format == CatalogFormatYAML || (ext != ".yaml" && ext != ".yml") {
+		return catalogPath
+	}
+	return strings.TrimSuffix(catalogPath, ext) + "." + format
+}
+
+// EncodeCatalog serializes a models catalog in the requested format. JSON output is derived from
+// the YAML encoding so that field names, omitted fields and customProperties match exactly.
+// NDJSON writes one model per line without the catalog envelope.
+func EncodeCatalog(catalog *types.ModelsCatalog, format string) ([]byte, error) {
+	switch format {
+	case CatalogFormatYAML:
+		return yaml.Marshal(catalog)
+	case CatalogFormatJSON:
+		value, err := toJSONValue(catalog)
+		if err != nil {
+			return nil, err
+		}
+		output, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(output, '\n'), nil
+	case CatalogFormatNDJSON:
+		var buf bytes.Buffer
+		for i := range catalog.Models {
+			value, err := toJSONValue(&catalog.Models[i])
+			if err != nil {
+				return nil, err
+			}
+			line, err := json.Marshal(value)
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(line)
+			buf.WriteByte('\n')
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, ValidateCatalogFormat(format)
+	}
+}
+
+// EncodeModelJSON serializes a single catalog model as JSON with the catalog's field names
+func EncodeModelJSON(model *types.CatalogMetadata) ([]byte, error) {
+	value, err := toJSONValue(model)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
+// CatalogFormatForPath returns the catalog format implied by a file extension, defaulting to YAML
+func CatalogFormatForPath(catalogPath string) string {
+	switch strings.ToLower(filepath.Ext(catalogPath)) {
+	case ".json":
+		return CatalogFormatJSON
+	case ".ndjson":
+		return CatalogFormatNDJSON
+	default:
+		return CatalogFormatYAML
+	}
+}
+
+// DecodeCatalog parses a models catalog written by EncodeCatalog. NDJSON input has no catalog
+// envelope, so the returned catalog has an empty source.
+func DecodeCatalog(data []byte, format string) (*types.ModelsCatalog, error) {
+	var catalog types.ModelsCatalog
+	switch format {
+	case CatalogFormatYAML, CatalogFormatJSON:
+		// JSON documents are valid YAML, and the YAML decoder honors the catalog's field names
+		if err := yaml.Unmarshal(data, &catalog); err != nil {
+			return nil, err
+		}
+	case CatalogFormatNDJSON:
+		for i, line := range bytes.Split(data, []byte("\n")) {
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue
+			}
+			var model types.CatalogMetadata
+			if err := yaml.Unmarshal(line, &model); err != nil {
+				return nil, fmt.Errorf("line %d: %v", i+1, err)
+			}
+			catalog.Models = append(catalog.Models, model)
+		}
+	default:
+		return nil, ValidateCatalogFormat(format)
+	}
+	return &catalog, nil
+}
+
+// ReadCatalog reads a models catalog file in the format implied by its extension
+func ReadCatalog(catalogPath string) (*types.ModelsCatalog, error) {
+	data, err := os.ReadFile(catalogPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading catalog file: %v", err)
+	}
+	catalog, err := DecodeCatalog(data, CatalogFormatForPath(catalogPath))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing catalog file %s: %v", catalogPath, err)
+	}
+	return catalog, nil
+}
+
+// WriteCatalog encodes a models catalog in the requested format and writes it to catalogPath
+func WriteCatalog(catalog *types.ModelsCatalog, catalogPath, format string) error {
+	output, err := EncodeCatalog(catalog, format)
+	if err != nil {
+		return fmt.Errorf("error marshaling catalog: %v", err)
+	}
+
+	if err := os.WriteFile(catalogPath, output, 0644); err != nil {
+		return fmt.Errorf("error writing catalog file: %v", err)
+	}
+	return nil
+}
+
+// toJSONValue round-trips v through YAML to obtain a generic value that encoding/json can marshal
+// with the catalog's YAML field names
+func toJSONValue(v interface{}) (interface{}, error) {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := yaml.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
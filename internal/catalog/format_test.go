@@ -0,0 +1,162 @@
+package catalog
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func sampleCatalog() *types.ModelsCatalog {
+	createTime := "1755612925000"
+	return &types.ModelsCatalog{
+		Source: "Red Hat",
+		Models: []types.CatalogMetadata{
+			{
+				Name:                 stringPtr("Model A"),
+				Tasks:                []string{"text-generation"},
+				CreateTimeSinceEpoch: &createTime,
+				CustomProperties: map[string]types.MetadataValue{
+					"model_type": createMetadataValue("generative"),
+				},
+				Artifacts: []types.CatalogOCIArtifact{{URI: "oci://registry.example.com/a:1.0"}},
+			},
+			{
+				Name:      stringPtr("Model B"),
+				Artifacts: []types.CatalogOCIArtifact{{URI: "oci://registry.example.com/b:1.0"}},
+			},
+		},
+	}
+}
+
+func TestValidateCatalogFormat(t *testing.T) {
+	for _, format := range []string{CatalogFormatYAML, CatalogFormatJSON, CatalogFormatNDJSON} {
+		if err := ValidateCatalogFormat(format); err != nil {
+			t.Errorf("ValidateCatalogFormat(%q) unexpected error: %v", format, err)
+		}
+	}
+	if err := ValidateCatalogFormat("xml"); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
+
+func TestCatalogPathForFormat(t *testing.T) {
+	tests := []struct {
+		path     string
+		format   string
+		expected string
+	}{
+		{"data/models-catalog.yaml", CatalogFormatYAML, "data/models-catalog.yaml"},
+		{"data/models-catalog.yaml", CatalogFormatJSON, "data/models-catalog.json"},
+		{"data/models-catalog.yml", CatalogFormatNDJSON, "data/models-catalog.ndjson"},
+		{"data/catalog.out", CatalogFormatJSON, "data/catalog.out"},
+	}
+
+	for _, tt := range tests {
+		if got := CatalogPathForFormat(tt.path, tt.format); got != tt.expected {
+			t.Errorf("CatalogPathForFormat(%q, %q) = %q, expected %q", tt.path, tt.format, got, tt.expected)
+		}
+	}
+}
+
+func TestEncodeCatalog_JSON(t *testing.T) {
+	output, err := EncodeCatalog(sampleCatalog(), CatalogFormatJSON)
+	if err != nil {
+		t.Fatalf("EncodeCatalog() error = %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(output, &decoded); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+	if decoded["source"] != "Red Hat" {
+		t.Errorf("Expected source 'Red Hat', got %v", decoded["source"])
+	}
+
+	models := decoded["models"].([]interface{})
+	first := models[0].(map[string]interface{})
+	if first["createTimeSinceEpoch"] != "1755612925000" {
+		t.Errorf("Expected timestamp to stay a string, got %#v", first["createTimeSinceEpoch"])
+	}
+	modelType := first["customProperties"].(map[string]interface{})["model_type"].(map[string]interface{})
+	if modelType["metadataType"] != "MetadataStringValue" || modelType["string_value"] != "generative" {
+		t.Errorf("Unexpected customProperties encoding: %v", modelType)
+	}
+	if _, exists := first["servingConfig"]; exists {
+		t.Error("Expected omitted YAML fields to be omitted from JSON")
+	}
+}
+
+func TestEncodeCatalog_NDJSON(t *testing.T) {
+	output, err := EncodeCatalog(sampleCatalog(), CatalogFormatNDJSON)
+	if err != nil {
+		t.Fatalf("EncodeCatalog() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %d", len(lines))
+	}
+	for i, expectedName := range []string{"Model A", "Model B"} {
+		var model map[string]interface{}
+		if err := json.Unmarshal([]byte(lines[i]), &model); err != nil {
+			t.Fatalf("Line %d is not valid JSON: %v", i, err)
+		}
+		if model["name"] != expectedName {
+			t.Errorf("Line %d: expected name %q, got %v", i, expectedName, model["name"])
+		}
+	}
+}
+
+func TestWriteCatalog_YAMLRoundTrip(t *testing.T) {
+	catalogPath := filepath.Join(t.TempDir(), "catalog.yaml")
+	if err := WriteCatalog(sampleCatalog(), catalogPath, CatalogFormatYAML); err != nil {
+		t.Fatalf("WriteCatalog() error = %v", err)
+	}
+
+	data, err := os.ReadFile(catalogPath)
+	if err != nil {
+		t.Fatalf("Failed to read catalog: %v", err)
+	}
+	var decoded types.ModelsCatalog
+	if err := yaml.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to parse catalog: %v", err)
+	}
+	if len(decoded.Models) != 2 || *decoded.Models[0].Name != "Model A" {
+		t.Errorf("Unexpected catalog contents: %+v", decoded)
+	}
+}
+
+func TestReadCatalog_AllFormats(t *testing.T) {
+	for _, format := range []string{CatalogFormatYAML, CatalogFormatJSON, CatalogFormatNDJSON} {
+		t.Run(format, func(t *testing.T) {
+			catalogPath := filepath.Join(t.TempDir(), "models-catalog."+format)
+			if err := WriteCatalog(sampleCatalog(), catalogPath, format); err != nil {
+				t.Fatalf("WriteCatalog() error = %v", err)
+			}
+
+			decoded, err := ReadCatalog(catalogPath)
+			if err != nil {
+				t.Fatalf("ReadCatalog() error = %v", err)
+			}
+			if len(decoded.Models) != 2 || *decoded.Models[0].Name != "Model A" {
+				t.Fatalf("Unexpected catalog contents: %+v", decoded)
+			}
+			if decoded.Models[0].CustomProperties["model_type"].StringValue != "generative" {
+				t.Errorf("Expected customProperties to round-trip, got %+v", decoded.Models[0].CustomProperties)
+			}
+			if format != CatalogFormatNDJSON && decoded.Source != "Red Hat" {
+				t.Errorf("Expected source to round-trip, got %q", decoded.Source)
+			}
+		})
+	}
+
+	if _, err := ReadCatalog(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Expected error for a missing catalog")
+	}
+}
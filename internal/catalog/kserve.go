@@ -0,0 +1,263 @@
+package catalog
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// DefaultKServeRuntimeImage is the vLLM image of the generated ServingRuntimes
+const DefaultKServeRuntimeImage = "quay.io/modh/vllm:latest"
+
+// kserveModelFormat is the model format the generated runtimes serve and InferenceServices request
+const kserveModelFormat = "vLLM"
+
+// maxKServeNameLength keeps generated names, including the "-vllm" suffix of runtimes, within the
+// 63 characters of a Kubernetes DNS label
+const maxKServeNameLength = 58
+
+// KServeOptions controls generation of KServe deployment manifests for catalog models
+type KServeOptions struct {
+	// Dir receives one <model>.yaml file per model holding a ServingRuntime and an InferenceService
+	Dir string
+	// RuntimeImage is the vLLM container image of the ServingRuntimes (default DefaultKServeRuntimeImage)
+	RuntimeImage string
+	// Namespace is set on every manifest when non-empty
+	Namespace string
+}
+
+// kserveMetadata is the object metadata of the generated manifests
+type kserveMetadata struct {
+	Name        string            `yaml:"name"`
+	Namespace   string            `yaml:"namespace,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+}
+
+// servingRuntime is the subset of a KServe ServingRuntime manifest written by the tool
+type servingRuntime struct {
+	APIVersion string             `yaml:"apiVersion"`
+	Kind       string             `yaml:"kind"`
+	Metadata   kserveMetadata     `yaml:"metadata"`
+	Spec       servingRuntimeSpec `yaml:"spec"`
+}
+
+type servingRuntimeSpec struct {
+	SupportedModelFormats []kserveModelFormatSpec `yaml:"supportedModelFormats"`
+	MultiModel            bool                    `yaml:"multiModel"`
+	Containers            []kserveContainer       `yaml:"containers"`
+}
+
+type kserveModelFormatSpec struct {
+	Name       string `yaml:"name"`
+	AutoSelect bool   `yaml:"autoSelect,omitempty"`
+}
+
+type kserveContainer struct {
+	Name    string       `yaml:"name"`
+	Image   string       `yaml:"image"`
+	Command []string     `yaml:"command"`
+	Args    []string     `yaml:"args"`
+	Ports   []kservePort `yaml:"ports"`
+}
+
+type kservePort struct {
+	ContainerPort int    `yaml:"containerPort"`
+	Protocol      string `yaml:"protocol"`
+}
+
+// inferenceService is the subset of a KServe InferenceService manifest written by the tool
+type inferenceService struct {
+	APIVersion string               `yaml:"apiVersion"`
+	Kind       string               `yaml:"kind"`
+	Metadata   kserveMetadata       `yaml:"metadata"`
+	Spec       inferenceServiceSpec `yaml:"spec"`
+}
+
+type inferenceServiceSpec struct {
+	Predictor struct {
+		Model kservePredictorModel `yaml:"model"`
+	} `yaml:"predictor"`
+}
+
+type kservePredictorModel struct {
+	ModelFormat kserveModelFormatSpec `yaml:"modelFormat"`
+	Runtime     string                `yaml:"runtime"`
+	StorageURI  string                `yaml:"storageUri"`
+	Resources   *kserveResources      `yaml:"resources,omitempty"`
+}
+
+type kserveResources struct {
+	Requests map[string]string `yaml:"requests"`
+	Limits   map[string]string `yaml:"limits"`
+}
+
+// kserveNamePattern matches the runs of characters not allowed in Kubernetes names
+var kserveNamePattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// KServeName returns the Kubernetes name of a model's manifests: the model name lowercased, with
+// other characters than letters and digits replaced by "-"
+func KServeName(modelName string) string {
+	name := strings.Trim(kserveNamePattern.ReplaceAllString(strings.ToLower(modelName), "-"), "-")
+	if len(name) > maxKServeNameLength {
+		name = strings.TrimRight(name[:maxKServeNameLength], "-")
+	}
+	return name
+}
+
+// EncodeKServeManifests returns a ServingRuntime running vLLM with the model's serving arguments
+// and an InferenceService deploying the model's first OCI artifact as a modelcar, separated by
+// "---". Models without a name or an OCI artifact return an error.
+func EncodeKServeManifests(model types.CatalogMetadata, opts KServeOptions) ([]byte, error) {
+	if model.Name == nil || KServeName(*model.Name) == "" {
+		return nil, fmt.Errorf("model has no name")
+	}
+	storageURI := ""
+	for _, artifact := range model.Artifacts {
+		if parsed, err := utils.ParseArtifactURI(artifact.URI); err == nil && parsed.Scheme == utils.URISchemeOCI {
+			storageURI = parsed.String()
+			break
+		}
+	}
+	if storageURI == "" {
+		return nil, fmt.Errorf("model '%s' has no OCI artifact", *model.Name)
+	}
+
+	name := KServeName(*model.Name)
+	runtimeName := name + "-vllm"
+	image := opts.RuntimeImage
+	if image == "" {
+		image = DefaultKServeRuntimeImage
+	}
+	labels := map[string]string{
+		"app.kubernetes.io/name":       name,
+		"app.kubernetes.io/managed-by": "model-metadata-collection",
+	}
+	acceleratorCount := 1
+	if model.HardwareRequirements != nil && model.HardwareRequirements.RecommendedAcceleratorCount > 0 {
+		acceleratorCount = model.HardwareRequirements.RecommendedAcceleratorCount
+	}
+
+	runtime := servingRuntime{
+		APIVersion: "serving.kserve.io/v1alpha1",
+		Kind:       "ServingRuntime",
+		Metadata: kserveMetadata{
+			Name:      runtimeName,
+			Namespace: opts.Namespace,
+			Labels:    labels,
+			Annotations: map[string]string{
+				"openshift.io/display-name":               *model.Name + " vLLM runtime",
+				"opendatahub.io/recommended-accelerators": `["nvidia.com/gpu"]`,
+			},
+		},
+		Spec: servingRuntimeSpec{
+			SupportedModelFormats: []kserveModelFormatSpec{{Name: kserveModelFormat, AutoSelect: true}},
+			Containers: []kserveContainer{{
+				Name:    "kserve-container",
+				Image:   image,
+				Command: []string{"python", "-m", "vllm.entrypoints.openai.api_server"},
+				Args:    vllmServingArgs(model, name, acceleratorCount),
+				Ports:   []kservePort{{ContainerPort: 8080, Protocol: "TCP"}},
+			}},
+		},
+	}
+
+	isvc := inferenceService{
+		APIVersion: "serving.kserve.io/v1beta1",
+		Kind:       "InferenceService",
+		Metadata: kserveMetadata{
+			Name:      name,
+			Namespace: opts.Namespace,
+			Labels:    labels,
+			Annotations: map[string]string{
+				"openshift.io/display-name":        *model.Name,
+				"serving.kserve.io/deploymentMode": "RawDeployment",
+			},
+		},
+	}
+	gpus := strconv.Itoa(acceleratorCount)
+	isvc.Spec.Predictor.Model = kservePredictorModel{
+		ModelFormat: kserveModelFormatSpec{Name: kserveModelFormat},
+		Runtime:     runtimeName,
+		StorageURI:  storageURI,
+		Resources: &kserveResources{
+			Requests: map[string]string{"nvidia.com/gpu": gpus},
+			Limits:   map[string]string{"nvidia.com/gpu": gpus},
+		},
+	}
+
+	var buf bytes.Buffer
+	for i, manifest := range []interface{}{runtime, isvc} {
+		data, err := yaml.Marshal(manifest)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling KServe manifest of %s: %v", *model.Name, err)
+		}
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(data)
+	}
+	return buf.Bytes(), nil
+}
+
+// vllmServingArgs returns the vLLM arguments serving the modelcar mounted at /mnt/models,
+// including the model's tool-calling arguments and tensor parallelism over its accelerators
+func vllmServingArgs(model types.CatalogMetadata, servedName string, acceleratorCount int) []string {
+	args := []string{"--port=8080", "--model=/mnt/models", "--served-model-name=" + servedName}
+	if acceleratorCount > 1 {
+		args = append(args, "--tensor-parallel-size="+strconv.Itoa(acceleratorCount))
+	}
+	if model.ServingConfig == nil || model.ServingConfig.ToolCalling == nil {
+		return args
+	}
+	toolCalling := model.ServingConfig.ToolCalling
+	if len(toolCalling.RequiredArgs) > 0 {
+		return append(args, toolCalling.RequiredArgs...)
+	}
+	if toolCalling.EnableAutoToolChoice {
+		args = append(args, "--enable-auto-tool-choice")
+	}
+	if toolCalling.ToolCallParser != "" {
+		args = append(args, "--tool-call-parser="+toolCalling.ToolCallParser)
+	}
+	if toolCalling.ChatTemplate != "" {
+		args = append(args, "--chat-template=/"+strings.TrimPrefix(toolCalling.ChatTemplate, "/"))
+	}
+	return args
+}
+
+// WriteKServeManifests writes the KServe manifests of every catalog model to opts.Dir. Models
+// that cannot be deployed from an OCI artifact, or whose name maps to the manifest name of an
+// earlier model, are skipped.
+func WriteKServeManifests(catalog *types.ModelsCatalog, opts KServeOptions) error {
+	if err := os.MkdirAll(opts.Dir, 0755); err != nil {
+		return fmt.Errorf("error creating KServe manifest directory: %v", err)
+	}
+	written := make(map[string]string)
+	for _, model := range catalog.Models {
+		manifests, err := EncodeKServeManifests(model, opts)
+		if err != nil {
+			continue
+		}
+		name := KServeName(*model.Name)
+		if other, exists := written[name]; exists {
+			log.Printf("  Warning: Skipping KServe manifests of '%s': '%s' has the same name %s", *model.Name, other, name)
+			continue
+		}
+		written[name] = *model.Name
+		if err := os.WriteFile(filepath.Join(opts.Dir, name+".yaml"), manifests, 0644); err != nil {
+			return fmt.Errorf("error writing KServe manifests: %v", err)
+		}
+	}
+	return nil
+}
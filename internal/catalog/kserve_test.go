@@ -0,0 +1,89 @@
+package catalog
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestEncodeKServeManifests(t *testing.T) {
+	name := "Granite 3.1 8B Instruct"
+	model := types.CatalogMetadata{
+		Name: &name,
+		Artifacts: []types.CatalogOCIArtifact{
+			{URI: "https://huggingface.co/ibm-granite/granite-3.1-8b-instruct"},
+			{URI: "registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5"},
+		},
+		HardwareRequirements: &types.HardwareRequirements{RecommendedAcceleratorCount: 2},
+		ServingConfig: &types.ServingConfig{ToolCalling: &types.CatalogToolCallingConfig{
+			ToolCallParser:       "granite",
+			ChatTemplate:         "opt/app-root/template/tool_chat_template_granite.jinja",
+			EnableAutoToolChoice: true,
+		}},
+	}
+
+	data, err := EncodeKServeManifests(model, KServeOptions{Namespace: "models"})
+	if err != nil {
+		t.Fatalf("EncodeKServeManifests failed: %v", err)
+	}
+	parts := bytes.Split(data, []byte("---\n"))
+	if len(parts) != 2 {
+		t.Fatalf("Expected 2 manifests, got %d", len(parts))
+	}
+
+	var runtime servingRuntime
+	if err := yaml.Unmarshal(parts[0], &runtime); err != nil {
+		t.Fatal(err)
+	}
+	if runtime.Kind != "ServingRuntime" || runtime.Metadata.Name != "granite-3-1-8b-instruct-vllm" || runtime.Metadata.Namespace != "models" {
+		t.Errorf("Unexpected runtime %s %+v", runtime.Kind, runtime.Metadata)
+	}
+	wantArgs := []string{
+		"--port=8080", "--model=/mnt/models", "--served-model-name=granite-3-1-8b-instruct", "--tensor-parallel-size=2",
+		"--enable-auto-tool-choice", "--tool-call-parser=granite", "--chat-template=/opt/app-root/template/tool_chat_template_granite.jinja",
+	}
+	if len(runtime.Spec.Containers) != 1 || !reflect.DeepEqual(runtime.Spec.Containers[0].Args, wantArgs) {
+		t.Errorf("Unexpected runtime containers %+v", runtime.Spec.Containers)
+	}
+	if runtime.Spec.Containers[0].Image != DefaultKServeRuntimeImage {
+		t.Errorf("Expected the default runtime image, got %s", runtime.Spec.Containers[0].Image)
+	}
+
+	var isvc inferenceService
+	if err := yaml.Unmarshal(parts[1], &isvc); err != nil {
+		t.Fatal(err)
+	}
+	predictor := isvc.Spec.Predictor.Model
+	if isvc.Kind != "InferenceService" || isvc.Metadata.Name != "granite-3-1-8b-instruct" {
+		t.Errorf("Unexpected InferenceService %s %+v", isvc.Kind, isvc.Metadata)
+	}
+	if predictor.StorageURI != "oci://registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5" || predictor.Runtime != runtime.Metadata.Name {
+		t.Errorf("Unexpected predictor %+v", predictor)
+	}
+	if predictor.Resources == nil || predictor.Resources.Limits["nvidia.com/gpu"] != "2" {
+		t.Errorf("Expected 2 GPUs, got %+v", predictor.Resources)
+	}
+
+	model.Artifacts = model.Artifacts[:1]
+	if _, err := EncodeKServeManifests(model, KServeOptions{}); err == nil {
+		t.Error("Expected an error for a model without an OCI artifact")
+	}
+}
+
+func TestWriteKServeManifests(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "kserve")
+	if err := WriteKServeManifests(sampleCatalog(), KServeOptions{Dir: dir, RuntimeImage: "quay.io/example/vllm:1"}); err != nil {
+		t.Fatalf("WriteKServeManifests failed: %v", err)
+	}
+	for _, model := range sampleCatalog().Models {
+		if _, err := os.Stat(filepath.Join(dir, KServeName(*model.Name)+".yaml")); err != nil {
+			t.Errorf("Expected manifests for %s: %v", *model.Name, err)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package catalog
+
+import (
+	"log"
+	"strings"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// FilterModelsByLabels keeps the models carrying at least one of the include labels (when any
+// are given) and none of the exclude labels. Model labels are emitted as customProperties keys,
+// so the filter applies equally to extracted and static models.
+func FilterModelsByLabels(models []types.CatalogMetadata, includeLabels, excludeLabels []string) []types.CatalogMetadata {
+	if len(includeLabels) == 0 && len(excludeLabels) == 0 {
+		return models
+	}
+
+	var result []types.CatalogMetadata
+	for _, model := range models {
+		if len(includeLabels) > 0 && !hasAnyLabel(model, includeLabels) {
+			continue
+		}
+		if hasAnyLabel(model, excludeLabels) {
+			continue
+		}
+		result = append(result, model)
+	}
+
+	if excluded := len(models) - len(result); excluded > 0 {
+		log.Printf("Label filter excluded %d of %d models from the catalog", excluded, len(models))
+	}
+	return result
+}
+
+// hasAnyLabel reports whether a model's customProperties contain any of the given labels
+func hasAnyLabel(model types.CatalogMetadata, labels []string) bool {
+	for _, label := range labels {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			continue
+		}
+		if _, ok := model.CustomProperties[label]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// HasLabel reports whether a catalog model carries the given label
+func HasLabel(model types.CatalogMetadata, label string) bool {
+	return hasAnyLabel(model, []string{label})
+}
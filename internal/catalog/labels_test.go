@@ -0,0 +1,51 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func labeledModel(name string, labels ...string) types.CatalogMetadata {
+	return types.CatalogMetadata{
+		Name:             stringPtr(name),
+		CustomProperties: convertTagsToCustomProperties(labels),
+	}
+}
+
+func TestFilterModelsByLabels(t *testing.T) {
+	models := []types.CatalogMetadata{
+		labeledModel("validated", "validated", "featured"),
+		labeledModel("experimental", "experimental"),
+		labeledModel("validated-experimental", "validated", "experimental"),
+		labeledModel("unlabeled"),
+	}
+
+	tests := []struct {
+		name     string
+		include  []string
+		exclude  []string
+		expected []string
+	}{
+		{"no filters", nil, nil, []string{"validated", "experimental", "validated-experimental", "unlabeled"}},
+		{"include only", []string{"validated"}, nil, []string{"validated", "validated-experimental"}},
+		{"include any of several", []string{"featured", "experimental"}, nil, []string{"validated", "experimental", "validated-experimental"}},
+		{"exclude only", nil, []string{"experimental"}, []string{"validated", "unlabeled"}},
+		{"include and exclude", []string{"validated"}, []string{"experimental"}, []string{"validated"}},
+		{"unknown include label", []string{"missing"}, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FilterModelsByLabels(models, tt.include, tt.exclude)
+			if len(result) != len(tt.expected) {
+				t.Fatalf("Expected %d models, got %d", len(tt.expected), len(result))
+			}
+			for i, name := range tt.expected {
+				if *result[i].Name != name {
+					t.Errorf("Expected model %d to be %q, got %q", i, name, *result[i].Name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package catalog
+
+import (
+	"embed"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed logos
+var logoFS embed.FS
+
+// embeddedLogoMapping is the provider logo mapping shipped with the tool
+const embeddedLogoMapping = "logos/providers.yaml"
+
+// LogoMapping maps model providers to logo files
+type LogoMapping struct {
+	Providers []ProviderLogo `yaml:"providers"`
+}
+
+// ProviderLogo selects a logo for providers containing any of the Match strings
+type ProviderLogo struct {
+	Match []string `yaml:"match"`
+	Logo  string   `yaml:"logo"`
+}
+
+// providerLogo is a mapping entry with its logo already encoded as a data URI
+type providerLogo struct {
+	match   []string
+	dataURI string
+}
+
+// logoMediaTypes maps the supported logo file extensions to their data URI media types
+var logoMediaTypes = map[string]string{
+	".svg":  "image/svg+xml",
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+}
+
+// logoExtensions is the order in which a logo directory is searched for a logo of any format
+var logoExtensions = []string{".svg", ".png", ".jpg", ".jpeg"}
+
+// Logos are the provider logos and generic catalog logos models are given. A nil *Logos uses
+// the logos shipped with the tool.
+type Logos struct {
+	defaults  []providerLogo
+	overrides []providerLogo
+	// dir holds logos that replace embedded and generic logos of the same name, in any format
+	dir string
+}
+
+// builtinLogos encodes the embedded logo mapping once, for catalogs without custom logos
+var builtinLogos = sync.OnceValue(func() *Logos {
+	logos := &Logos{}
+	if err := logos.loadDefaults(); err != nil {
+		log.Printf("ERROR: Failed to load embedded logo mapping (provider logos will be disabled): %v", err)
+	}
+	return logos
+})
+
+// LoadLogos loads the logos of catalog models. dir, when set, is a directory of logos that
+// replace the embedded provider logos and the generic catalog logos without rebuilding; a file
+// replaces a logo when its name matches ignoring the extension, so ibm.png replaces the embedded
+// ibm.svg. mappingPath, when set, is a provider logo mapping that takes precedence over the
+// embedded one. Returns nil, the built-in logos, when both are empty.
+func LoadLogos(dir, mappingPath string) (*Logos, error) {
+	if dir == "" && mappingPath == "" {
+		return nil, nil
+	}
+
+	logos := &Logos{dir: dir}
+	if dir != "" {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("logo directory %s is not accessible", dir)
+		}
+		if err := logos.loadDefaults(); err != nil {
+			return nil, fmt.Errorf("invalid logo directory %s: %v", dir, err)
+		}
+	} else {
+		logos.defaults = builtinLogos().defaults
+	}
+	if mappingPath != "" {
+		if err := logos.loadMapping(mappingPath); err != nil {
+			return nil, err
+		}
+	}
+	return logos, nil
+}
+
+// orBuiltin returns l, or the built-in logos when l is nil
+func (l *Logos) orBuiltin() *Logos {
+	if l == nil {
+		return builtinLogos()
+	}
+	return l
+}
+
+// loadDefaults encodes the embedded mapping, preferring logos from the logo directory
+func (l *Logos) loadDefaults() error {
+	data, err := logoFS.ReadFile(embeddedLogoMapping)
+	if err != nil {
+		return err
+	}
+	logos, err := parseLogoMapping(data, func(name string) ([]byte, string, error) {
+		if content, file, err := l.readDir(name); err == nil {
+			return content, file, nil
+		}
+		content, err := logoFS.ReadFile("logos/" + name)
+		return content, name, err
+	})
+	if err != nil {
+		return err
+	}
+	l.defaults = logos
+	return nil
+}
+
+// readDir reads the logo from the logo directory whose name matches name ignoring the
+// extension, returning the content and the name of the file that was found
+func (l *Logos) readDir(name string) ([]byte, string, error) {
+	if l.dir == "" {
+		return nil, "", os.ErrNotExist
+	}
+	return findLogoFile(l.dir, strings.TrimSuffix(name, filepath.Ext(name)))
+}
+
+// findLogoFile reads dir/stem with the first supported logo extension that exists
+func findLogoFile(dir, stem string) ([]byte, string, error) {
+	for _, ext := range logoExtensions {
+		content, err := os.ReadFile(filepath.Join(dir, stem+ext))
+		if err == nil {
+			return content, stem + ext, nil
+		}
+	}
+	return nil, "", os.ErrNotExist
+}
+
+// encodeLogoDataURI returns a base64 data URI for a logo, using the media type of its extension.
+// PNG and JPEG content must match the extension.
+func encodeLogoDataURI(name string, content []byte) (string, error) {
+	ext := strings.ToLower(filepath.Ext(name))
+	mediaType, ok := logoMediaTypes[ext]
+	if !ok {
+		return "", fmt.Errorf("unsupported logo format %q for %s (allowed values: .svg, .png, .jpg, .jpeg)", ext, name)
+	}
+	if ext != ".svg" {
+		if detected := http.DetectContentType(content); detected != mediaType {
+			return "", fmt.Errorf("logo %s has content type %s, expected %s", name, detected, mediaType)
+		}
+	}
+	return "data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(content), nil
+}
+
+// loadMapping loads a provider logo mapping that takes precedence over the embedded one. Logo
+// paths are resolved relative to the mapping file, then against the logo directory and the
+// embedded logos, so an override can point additional providers at a bundled logo.
+func (l *Logos) loadMapping(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read logo mapping %s: %v", path, err)
+	}
+
+	baseDir := filepath.Dir(path)
+	logos, err := parseLogoMapping(data, func(name string) ([]byte, string, error) {
+		content, err := os.ReadFile(filepath.Join(baseDir, name))
+		if err == nil {
+			return content, name, nil
+		}
+		if content, file, dirErr := l.readDir(name); dirErr == nil {
+			return content, file, nil
+		}
+		if embedded, embeddedErr := logoFS.ReadFile("logos/" + name); embeddedErr == nil {
+			return embedded, name, nil
+		}
+		return nil, "", err
+	})
+	if err != nil {
+		return fmt.Errorf("invalid logo mapping %s: %v", path, err)
+	}
+
+	l.overrides = logos
+	return nil
+}
+
+// parseLogoMapping parses a mapping file and encodes each referenced logo using readLogo, which
+// returns the logo content and the name of the file it was read from
+func parseLogoMapping(data []byte, readLogo func(name string) ([]byte, string, error)) ([]providerLogo, error) {
+	var mapping LogoMapping
+	if err := yaml.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %v", err)
+	}
+
+	var logos []providerLogo
+	for i, entry := range mapping.Providers {
+		if entry.Logo == "" {
+			return nil, fmt.Errorf("provider entry at index %d missing required 'logo' field", i)
+		}
+		if _, ok := logoMediaTypes[strings.ToLower(filepath.Ext(entry.Logo))]; !ok {
+			return nil, fmt.Errorf("provider logo %s is not an SVG, PNG or JPEG file", entry.Logo)
+		}
+
+		var match []string
+		for _, m := range entry.Match {
+			if normalized := normalizeProvider(m); normalized != "" {
+				match = append(match, normalized)
+			}
+		}
+		if len(match) == 0 {
+			return nil, fmt.Errorf("provider entry for %s has no match strings", entry.Logo)
+		}
+
+		content, file, err := readLogo(entry.Logo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read logo %s: %v", entry.Logo, err)
+		}
+		dataURI, err := encodeLogoDataURI(file, content)
+		if err != nil {
+			return nil, err
+		}
+
+		logos = append(logos, providerLogo{match: match, dataURI: dataURI})
+	}
+
+	return logos, nil
+}
+
+// lookupProvider returns the logo data URI for a provider, checking the override mapping first
+func (l *Logos) lookupProvider(provider *string) *string {
+	if provider == nil {
+		return nil
+	}
+	normalized := normalizeProvider(*provider)
+	if normalized == "" {
+		return nil
+	}
+
+	for _, logos := range [][]providerLogo{l.overrides, l.defaults} {
+		for _, logo := range logos {
+			for _, m := range logo.match {
+				if strings.Contains(normalized, m) {
+					dataURI := logo.dataURI
+					return &dataURI
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// normalizeProvider lowercases a provider name and drops non-alphanumeric characters,
+// so "Mistral AI" and "MistralAI" compare equal
+func normalizeProvider(provider string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(provider) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
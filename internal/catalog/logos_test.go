@@ -0,0 +1,179 @@
+package catalog
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLookupProviderLogo_Embedded(t *testing.T) {
+	tests := []struct {
+		provider string
+		logo     string
+	}{
+		{"IBM", "ibm.svg"},
+		{"Mistral AI", "mistral.svg"},
+		{"MistralAI", "mistral.svg"},
+		{"Alibaba Cloud", "qwen.svg"},
+		{"Meta", "meta.svg"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			content, err := logoFS.ReadFile("logos/" + tt.logo)
+			if err != nil {
+				t.Fatalf("Failed to read embedded logo: %v", err)
+			}
+			expected := "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString(content)
+
+			logo := builtinLogos().lookupProvider(stringPtr(tt.provider))
+			if logo == nil || *logo != expected {
+				t.Errorf("Expected %s logo for provider %q", tt.logo, tt.provider)
+			}
+		})
+	}
+
+	for _, provider := range []*string{nil, stringPtr(""), stringPtr("Red Hat"), stringPtr("Unknown Lab")} {
+		if logo := builtinLogos().lookupProvider(provider); logo != nil {
+			t.Errorf("Expected no provider logo for %v", provider)
+		}
+	}
+}
+
+func TestLoadLogos_Mapping(t *testing.T) {
+	tmpDir := t.TempDir()
+	customSVG := `<svg xmlns="http://www.w3.org/2000/svg"><rect width="10" height="10"/></svg>`
+	if err := os.WriteFile(filepath.Join(tmpDir, "custom.svg"), []byte(customSVG), 0644); err != nil {
+		t.Fatalf("Failed to write logo: %v", err)
+	}
+	mapping := `providers:
+  - match: ["IBM"]
+    logo: custom.svg
+  - match: ["Red Hat"]
+    logo: ibm.svg
+`
+	mappingPath := filepath.Join(tmpDir, "logo-mapping.yaml")
+	if err := os.WriteFile(mappingPath, []byte(mapping), 0644); err != nil {
+		t.Fatalf("Failed to write mapping: %v", err)
+	}
+
+	logos, err := LoadLogos("", mappingPath)
+	if err != nil {
+		t.Fatalf("LoadLogos failed: %v", err)
+	}
+
+	customURI := "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString([]byte(customSVG))
+	if logo := logos.lookupProvider(stringPtr("IBM")); logo == nil || *logo != customURI {
+		t.Error("Expected override logo to take precedence for IBM")
+	}
+	if logo := logos.lookupProvider(stringPtr("Red Hat")); logo == nil {
+		t.Error("Expected override to resolve an embedded logo for Red Hat")
+	}
+	if logo := logos.lookupProvider(stringPtr("Meta")); logo == nil {
+		t.Error("Expected embedded mapping to still apply for Meta")
+	}
+
+	if logo := determineLogo(logos, stringPtr("IBM"), []string{"validated"}); logo == nil || *logo != customURI {
+		t.Error("Expected determineLogo to prefer the provider logo")
+	}
+	if logo := determineLogo(nil, stringPtr("IBM"), nil); logo == nil || *logo == customURI {
+		t.Error("Expected the built-in logos to be unaffected by a loaded mapping")
+	}
+}
+
+func TestLoadLogos_InvalidMapping(t *testing.T) {
+	tmpDir := t.TempDir()
+	tests := map[string]string{
+		"missing logo":    "providers:\n  - match: [\"ibm\"]\n",
+		"unsupported":     "providers:\n  - match: [\"ibm\"]\n    logo: ibm.gif\n",
+		"no match":        "providers:\n  - logo: ibm.svg\n",
+		"unreadable logo": "providers:\n  - match: [\"ibm\"]\n    logo: missing.svg\n",
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(tmpDir, strings.ReplaceAll(name, " ", "-")+".yaml")
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write mapping: %v", err)
+			}
+			if _, err := LoadLogos("", path); err == nil {
+				t.Error("Expected error for invalid mapping")
+			}
+		})
+	}
+
+	if _, err := LoadLogos("", filepath.Join(tmpDir, "does-not-exist.yaml")); err == nil {
+		t.Error("Expected error for missing mapping file")
+	}
+}
+
+// testPNG is a minimal PNG header, enough for content type detection
+var testPNG = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestEncodeLogoDataURI(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  []byte
+		expected string
+	}{
+		{"logo.svg", []byte("<svg/>"), "data:image/svg+xml;base64,"},
+		{"logo.PNG", testPNG, "data:image/png;base64,"},
+		{"logo.jpeg", []byte("\xff\xd8\xff\xe0"), "data:image/jpeg;base64,"},
+		{"logo.jpg", []byte("\xff\xd8\xff\xe0"), "data:image/jpeg;base64,"},
+	}
+	for _, tt := range tests {
+		dataURI, err := encodeLogoDataURI(tt.name, tt.content)
+		if err != nil {
+			t.Errorf("encodeLogoDataURI(%s) failed: %v", tt.name, err)
+			continue
+		}
+		if dataURI != tt.expected+base64.StdEncoding.EncodeToString(tt.content) {
+			t.Errorf("encodeLogoDataURI(%s) = %s", tt.name, dataURI)
+		}
+	}
+
+	if _, err := encodeLogoDataURI("logo.gif", []byte("GIF89a")); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+	if _, err := encodeLogoDataURI("logo.png", []byte("<svg/>")); err == nil {
+		t.Error("Expected error for PNG extension with non-PNG content")
+	}
+}
+
+func TestLoadLogos_Dir(t *testing.T) {
+	logoDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(logoDir, "ibm.png"), testPNG, 0644); err != nil {
+		t.Fatalf("Failed to write logo: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(logoDir, "catalog-model.png"), testPNG, 0644); err != nil {
+		t.Fatalf("Failed to write logo: %v", err)
+	}
+
+	logos, err := LoadLogos(logoDir, "")
+	if err != nil {
+		t.Fatalf("LoadLogos failed: %v", err)
+	}
+
+	pngURI := "data:image/png;base64," + base64.StdEncoding.EncodeToString(testPNG)
+	if logo := logos.lookupProvider(stringPtr("IBM")); logo == nil || *logo != pngURI {
+		t.Error("Expected ibm.png from the logo directory to replace the embedded IBM logo")
+	}
+	if logo := logos.lookupProvider(stringPtr("Meta")); logo == nil || !strings.HasPrefix(*logo, "data:image/svg+xml;base64,") {
+		t.Error("Expected embedded Meta logo to remain")
+	}
+	if logo := determineLogo(logos, stringPtr("Unknown Lab"), nil); logo == nil || *logo != pngURI {
+		t.Error("Expected catalog-model.png from the logo directory to replace the generic logo")
+	}
+
+	if _, err := LoadLogos(filepath.Join(logoDir, "missing"), ""); err == nil {
+		t.Error("Expected error for a missing logo directory")
+	}
+	if logos, err := LoadLogos("", ""); err != nil || logos != nil {
+		t.Errorf("Expected the built-in logos without a directory or mapping, got %v, %v", logos, err)
+	}
+	if logo := determineLogo(nil, stringPtr("IBM"), nil); logo == nil || !strings.HasPrefix(*logo, "data:image/svg+xml;base64,") {
+		t.Error("Expected the built-in IBM logo without a logo directory")
+	}
+}
@@ -0,0 +1,135 @@
+package catalog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// catalogMarkdownHeader starts every generated catalog markdown summary
+const catalogMarkdownHeader = "# Model Catalog\n\n<!-- Generated by model-extractor; do not edit. -->\n"
+
+// RenderCatalogMarkdown formats a catalog as a markdown section with one table row per model,
+// headed by the catalog's file name
+func RenderCatalogMarkdown(catalog *types.ModelsCatalog, catalogName string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s\n\n", catalogName)
+	fmt.Fprintf(&b, "%d models", len(catalog.Models))
+	if catalog.Source != "" {
+		fmt.Fprintf(&b, " from %s", catalog.Source)
+	}
+	b.WriteString(".\n\n")
+
+	if len(catalog.Models) == 0 {
+		return b.String()
+	}
+
+	b.WriteString("| Model | Provider | License | Artifacts | Validated | Featured |\n")
+	b.WriteString("|-------|----------|---------|-----------|:---------:|:--------:|\n")
+	for _, model := range catalog.Models {
+		var artifacts []string
+		for _, artifact := range model.Artifacts {
+			artifacts = append(artifacts, "`"+markdownCell(artifact.URI)+"`")
+		}
+
+		name := markdownCell(getModelName(&model))
+		if model.Deprecated {
+			name += " (deprecated)"
+		}
+
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s |\n",
+			name,
+			markdownCell(utils.StringValue(model.Provider)),
+			markdownCell(utils.StringValue(model.License)),
+			strings.Join(artifacts, "<br>"),
+			checkMark(HasLabel(model, "validated")),
+			checkMark(HasLabel(model, "featured")),
+		)
+	}
+	return b.String()
+}
+
+// markdownCell escapes a value for use in a markdown table cell
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.Join(strings.Fields(value), " ")
+}
+
+func checkMark(set bool) string {
+	if set {
+		return "✓"
+	}
+	return ""
+}
+
+// UpdateCatalogMarkdown writes the catalog's section of the markdown summary at markdownPath.
+// Each catalog file owns one section, so the catalogs generated into one directory can share a
+// summary; sections of other catalogs are kept and all sections are ordered by catalog name.
+func UpdateCatalogMarkdown(catalog *types.ModelsCatalog, catalogPath, markdownPath string) error {
+	catalogName := filepath.Base(catalogPath)
+
+	sections := make(map[string]string)
+	existing, err := os.ReadFile(markdownPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error reading catalog markdown: %v", err)
+	}
+	for name, section := range parseMarkdownSections(string(existing)) {
+		sections[name] = section
+	}
+	sections[catalogName] = RenderCatalogMarkdown(catalog, catalogName)
+
+	names := make([]string, 0, len(sections))
+	for name := range sections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString(catalogMarkdownHeader)
+	for _, name := range names {
+		fmt.Fprintf(&b, "\n%s\n%s%s\n", sectionStart(name), sections[name], sectionEnd(name))
+	}
+
+	if err := os.WriteFile(markdownPath, []byte(b.String()), 0644); err != nil {
+		return fmt.Errorf("error writing catalog markdown: %v", err)
+	}
+	return nil
+}
+
+func sectionStart(name string) string {
+	return "<!-- catalog: " + name + " -->"
+}
+
+func sectionEnd(name string) string {
+	return "<!-- end catalog: " + name + " -->"
+}
+
+// parseMarkdownSections returns the catalog sections of a generated markdown summary by name
+func parseMarkdownSections(content string) map[string]string {
+	sections := make(map[string]string)
+	const startPrefix = "<!-- catalog: "
+	for {
+		start := strings.Index(content, startPrefix)
+		if start < 0 {
+			return sections
+		}
+		rest := content[start+len(startPrefix):]
+		nameEnd := strings.Index(rest, " -->\n")
+		if nameEnd < 0 {
+			return sections
+		}
+		name := rest[:nameEnd]
+		body := rest[nameEnd+len(" -->\n"):]
+		end := strings.Index(body, sectionEnd(name))
+		if end < 0 {
+			return sections
+		}
+		sections[name] = body[:end]
+		content = body[end:]
+	}
+}
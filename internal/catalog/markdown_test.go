@@ -0,0 +1,85 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestRenderCatalogMarkdown(t *testing.T) {
+	catalog := &types.ModelsCatalog{
+		Source: "Red Hat",
+		Models: []types.CatalogMetadata{
+			{
+				Name:     stringPtr("granite"),
+				Provider: stringPtr("IBM"),
+				License:  stringPtr("apache-2.0"),
+				CustomProperties: map[string]types.MetadataValue{
+					"validated": createMetadataValue(""),
+					"featured":  createMetadataValue(""),
+				},
+				Artifacts: []types.CatalogOCIArtifact{
+					{URI: "oci://registry.example.com/granite:1.5"},
+					{URI: "oci://registry.example.com/granite:1.4"},
+				},
+			},
+			{
+				Name:       stringPtr("pipe|model"),
+				License:    stringPtr("other\nlicense"),
+				Deprecated: true,
+			},
+		},
+	}
+
+	markdown := RenderCatalogMarkdown(catalog, "models-catalog.yaml")
+
+	for _, expected := range []string{
+		"## models-catalog.yaml\n\n2 models from Red Hat.\n",
+		"| granite | IBM | apache-2.0 | `oci://registry.example.com/granite:1.5`<br>`oci://registry.example.com/granite:1.4` | ✓ | ✓ |\n",
+		"| pipe\\|model (deprecated) |  | other license |  |  |  |\n",
+	} {
+		if !strings.Contains(markdown, expected) {
+			t.Errorf("Expected markdown to contain %q, got:\n%s", expected, markdown)
+		}
+	}
+}
+
+func TestUpdateCatalogMarkdown(t *testing.T) {
+	tmpDir := t.TempDir()
+	markdownPath := filepath.Join(tmpDir, "CATALOG.md")
+
+	if err := UpdateCatalogMarkdown(sampleCatalog(), filepath.Join(tmpDir, "validated-models-catalog.yaml"), markdownPath); err != nil {
+		t.Fatalf("UpdateCatalogMarkdown failed: %v", err)
+	}
+	if err := UpdateCatalogMarkdown(sampleCatalog(), filepath.Join(tmpDir, "models-catalog.yaml"), markdownPath); err != nil {
+		t.Fatalf("UpdateCatalogMarkdown failed: %v", err)
+	}
+
+	// Regenerating a catalog replaces only its own section
+	smaller := sampleCatalog()
+	smaller.Models = smaller.Models[:1]
+	if err := UpdateCatalogMarkdown(smaller, filepath.Join(tmpDir, "validated-models-catalog.yaml"), markdownPath); err != nil {
+		t.Fatalf("UpdateCatalogMarkdown failed: %v", err)
+	}
+
+	data, err := os.ReadFile(markdownPath)
+	if err != nil {
+		t.Fatalf("Failed to read markdown: %v", err)
+	}
+	markdown := string(data)
+
+	if !strings.HasPrefix(markdown, catalogMarkdownHeader) || strings.Count(markdown, "# Model Catalog\n") != 1 {
+		t.Errorf("Expected a single header, got:\n%s", markdown)
+	}
+	models := strings.Index(markdown, "## models-catalog.yaml\n\n2 models")
+	validated := strings.Index(markdown, "## validated-models-catalog.yaml\n\n1 models")
+	if models < 0 || validated < 0 || models > validated {
+		t.Errorf("Expected sections ordered by catalog name with the validated section updated, got:\n%s", markdown)
+	}
+	if strings.Count(markdown, sectionStart("validated-models-catalog.yaml")) != 1 {
+		t.Errorf("Expected the validated section to be replaced, got:\n%s", markdown)
+	}
+}
@@ -130,8 +130,5 @@ func injectSupportTier(server *types.MCPServerMetadata, tier string) {
 	if server.CustomProperties == nil {
 		server.CustomProperties = make(map[string]types.MetadataValue)
 	}
-	server.CustomProperties["supportTier"] = types.MetadataValue{
-		MetadataType: "MetadataStringValue",
-		StringValue:  tier,
-	}
+	server.CustomProperties["supportTier"] = types.NewStringValue(tier)
 }
@@ -1,6 +1,7 @@
 package catalog
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -25,8 +26,9 @@ type tsResult struct {
 
 // EnrichMCPServersFromRegistry reads the MCP servers index, inspects each
 // server's container image artifacts via OCI registry, extracts architectures
-// and timestamps, and writes enriched data back to the input YAML files.
-func EnrichMCPServersFromRegistry(indexPath string) error {
+// and timestamps, and writes enriched data back to the input YAML files. Registry operations
+// authenticate with auth.
+func EnrichMCPServersFromRegistry(ctx context.Context, indexPath string, auth registry.Auth) error {
 	indexData, err := os.ReadFile(indexPath)
 	if err != nil {
 		return fmt.Errorf("error reading MCP index file %s: %v", indexPath, err)
@@ -41,6 +43,9 @@ func EnrichMCPServersFromRegistry(indexPath string) error {
 
 	enrichedCount := 0
 	for _, entry := range index.MCPServers {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 		cleaned := filepath.Clean(entry.InputPath)
 		if filepath.IsAbs(cleaned) || strings.HasPrefix(cleaned, "..") {
 			log.Printf("Warning: skipping MCP server %q enrichment: invalid input_path %q", entry.Name, entry.InputPath)
@@ -53,7 +58,7 @@ func EnrichMCPServersFromRegistry(indexPath string) error {
 			continue
 		}
 
-		changed, err := enrichMCPServerArtifacts(server)
+		changed, err := enrichMCPServerArtifacts(ctx, auth, server)
 		if err != nil {
 			log.Printf("Warning: skipping MCP server %q enrichment: %v", entry.Name, err)
 			continue
@@ -77,7 +82,7 @@ func EnrichMCPServersFromRegistry(indexPath string) error {
 
 // enrichMCPServerArtifacts enriches a single MCP server's metadata with OCI
 // registry data (architectures and timestamps). Returns true if changes were made.
-func enrichMCPServerArtifacts(server *types.MCPServerMetadata) (bool, error) {
+func enrichMCPServerArtifacts(ctx context.Context, auth registry.Auth, server *types.MCPServerMetadata) (bool, error) {
 	changed := false
 
 	// Validate all artifacts have URIs (if any exist)
@@ -93,15 +98,16 @@ func enrichMCPServerArtifacts(server *types.MCPServerMetadata) (bool, error) {
 
 	for i := range server.Artifacts {
 		artifact := &server.Artifacts[i]
-		imageRef := strings.TrimPrefix(artifact.URI, "oci://")
+		imageRef := utils.ImageReferenceOf(artifact.URI)
 
 		log.Printf("  DEBUG: inspecting artifact: %s", imageRef)
 
 		// Fetch architectures with retry
 		architectures, err := utils.RetryWithExponentialBackoff(
+			ctx,
 			utils.DefaultRetryConfig,
 			func() ([]string, error) {
-				return registry.FetchImageArchitectures(imageRef)
+				return registry.FetchImageArchitectures(ctx, auth, imageRef)
 			},
 			fmt.Sprintf("fetch architectures for %s", imageRef),
 		)
@@ -114,9 +120,10 @@ func enrichMCPServerArtifacts(server *types.MCPServerMetadata) (bool, error) {
 
 		// Fetch timestamps with retry
 		ts, err := utils.RetryWithExponentialBackoff(
+			ctx,
 			utils.DefaultRetryConfig,
 			func() (tsResult, error) {
-				c, u, e := registry.FetchImageTimestamps(imageRef)
+				c, u, e := registry.FetchImageTimestamps(ctx, auth, imageRef)
 				return tsResult{c, u}, e
 			},
 			fmt.Sprintf("fetch timestamps for %s", imageRef),
@@ -163,10 +170,7 @@ func enrichMCPServerArtifacts(server *types.MCPServerMetadata) (bool, error) {
 		if err != nil {
 			log.Printf("  Warning: failed to marshal architectures: %v", err)
 		} else {
-			archValue := types.MetadataValue{
-				MetadataType: "MetadataStringValue",
-				StringValue:  string(archJSON),
-			}
+			archValue := types.NewStringValue(string(archJSON))
 
 			if server.CustomProperties == nil {
 				server.CustomProperties = make(map[string]types.MetadataValue)
@@ -1,6 +1,7 @@
 package catalog
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"strings"
@@ -20,7 +21,7 @@ func TestEnrichMCPServerArtifacts_EmptyURI(t *testing.T) {
 		},
 	}
 
-	_, err := enrichMCPServerArtifacts(server)
+	_, err := enrichMCPServerArtifacts(context.Background(), nil, server)
 	if err == nil {
 		t.Fatal("expected error for empty URI artifact, got nil")
 	}
@@ -35,7 +36,7 @@ func TestEnrichMCPServerArtifacts_NoArtifacts(t *testing.T) {
 		Provider: "Test",
 	}
 
-	changed, err := enrichMCPServerArtifacts(server)
+	changed, err := enrichMCPServerArtifacts(context.Background(), nil, server)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -51,7 +52,7 @@ func TestEnrichMCPServerArtifacts_PublishedDateDerivation(t *testing.T) {
 		PublishedDate: "2025-07-23T00:00:00Z",
 	}
 
-	changed, err := enrichMCPServerArtifacts(server)
+	changed, err := enrichMCPServerArtifacts(context.Background(), nil, server)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -79,7 +80,7 @@ func TestEnrichMCPServerArtifacts_PublishedDateIdempotent(t *testing.T) {
 		CreateTimeSinceEpoch: "1753228800000",
 	}
 
-	changed, err := enrichMCPServerArtifacts(server)
+	changed, err := enrichMCPServerArtifacts(context.Background(), nil, server)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -162,7 +163,7 @@ func TestWriteMCPServerInput(t *testing.T) {
 }
 
 func TestEnrichMCPServersFromRegistry_MissingIndex(t *testing.T) {
-	err := EnrichMCPServersFromRegistry("/nonexistent/index.yaml")
+	err := EnrichMCPServersFromRegistry(context.Background(), "/nonexistent/index.yaml", nil)
 	if err == nil {
 		t.Fatal("expected error for missing index file, got nil")
 	}
@@ -175,7 +176,7 @@ func TestEnrichMCPServersFromRegistry_InvalidYAML(t *testing.T) {
 		t.Fatalf("failed to write test file: %v", err)
 	}
 
-	err := EnrichMCPServersFromRegistry(indexPath)
+	err := EnrichMCPServersFromRegistry(context.Background(), indexPath, nil)
 	if err == nil {
 		t.Fatal("expected error for invalid YAML, got nil")
 	}
@@ -194,7 +195,7 @@ mcp_servers:
 	}
 
 	// Should not return error — individual server failures are logged as warnings
-	err := EnrichMCPServersFromRegistry(indexPath)
+	err := EnrichMCPServersFromRegistry(context.Background(), indexPath, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -210,7 +211,7 @@ mcp_servers: []
 		t.Fatalf("failed to write index: %v", err)
 	}
 
-	err := EnrichMCPServersFromRegistry(indexPath)
+	err := EnrichMCPServersFromRegistry(context.Background(), indexPath, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -0,0 +1,79 @@
+package catalog
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// MergeOptions controls how generated catalogs are combined by MergeCatalogs
+type MergeOptions struct {
+	// Source is the source of the merged catalog; defaults to the source of the first catalog
+	Source string
+	// Validation is the schema validation mode: error, warn or off (empty disables validation)
+	Validation string
+	// SkipURIDedup keeps models with different names that share an artifact URI apart
+	SkipURIDedup bool
+	// SkipTagGrouping keeps models whose images are different tags of the same repository apart
+	SkipTagGrouping bool
+	// InternalRegistries are registry hosts or domains whose artifacts get the "internal"
+	// registrySource when it is not set yet
+	InternalRegistries []string
+	// RequiredFields fails the merge when any model lacks one of these fields
+	RequiredFields []string
+}
+
+// MergeCatalogs combines generated catalogs, e.g. the validated, partner and community ones, into
+// one catalog. Models keep the source of the catalog they came from, and models of the same name
+// or sharing an artifact URI are consolidated like during catalog generation, with the earlier
+// catalogs winning conflicting fields, and tags of the same image repository are grouped. The merged catalog is validated before it is returned.
+func MergeCatalogs(catalogs []*types.ModelsCatalog, opts MergeOptions) (*types.ModelsCatalog, error) {
+	if len(catalogs) == 0 {
+		return nil, fmt.Errorf("no catalogs to merge")
+	}
+	if opts.Validation != "" {
+		if err := ValidateCatalogValidationMode(opts.Validation); err != nil {
+			return nil, err
+		}
+	}
+	if err := ValidateRequiredFields(opts.RequiredFields); err != nil {
+		return nil, err
+	}
+
+	var models []types.CatalogMetadata
+	for _, input := range catalogs {
+		for _, model := range input.Models {
+			if model.Source == "" {
+				model.Source = input.Source
+			}
+			models = append(models, model)
+		}
+	}
+
+	normalizeArtifactURIs(models)
+	addRegistrySources(models, opts.InternalRegistries)
+	models = deduplicateAndMergeModels(models)
+	if !opts.SkipURIDedup {
+		models = deduplicateModelsByURI(models)
+	}
+	if !opts.SkipTagGrouping {
+		models = groupModelsByRepository(models)
+	}
+
+	merged := &types.ModelsCatalog{Source: opts.Source, Models: models}
+	if merged.Source == "" {
+		merged.Source = catalogs[0].Source
+	}
+	clearInheritedSources(merged)
+
+	if err := applyCatalogValidation(merged, opts.Validation); err != nil {
+		return nil, err
+	}
+	if err := CheckRequiredFields(merged, opts.RequiredFields); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Merged %d catalogs into %d models", len(catalogs), len(merged.Models))
+	return merged, nil
+}
@@ -0,0 +1,63 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestMergeCatalogs(t *testing.T) {
+	artifact := func(uri string) []types.CatalogOCIArtifact {
+		return []types.CatalogOCIArtifact{{URI: uri}}
+	}
+	validated := &types.ModelsCatalog{Source: "Red Hat", Models: []types.CatalogMetadata{
+		{Name: stringPtr("granite-3.1-8b"), Artifacts: artifact("oci://registry.example.com/granite:1.5")},
+	}}
+	partner := &types.ModelsCatalog{Source: "Partner", Models: []types.CatalogMetadata{
+		{Name: stringPtr("Granite-3.1-8B"), Description: stringPtr("From the partner catalog"), Artifacts: artifact("oci://registry.example.com/granite:1.5")},
+		{Name: stringPtr("mistral-7b"), Artifacts: artifact("oci://registry.example.com/mistral:1")},
+	}}
+	community := &types.ModelsCatalog{Source: "Community", Models: []types.CatalogMetadata{
+		{Name: stringPtr("mistral-7b-copy"), Artifacts: artifact("oci://registry.example.com/mistral:1")},
+		{Name: stringPtr("phi-4"), Source: "Microsoft", Artifacts: artifact("oci://registry.example.com/phi:4")},
+	}}
+
+	merged, err := MergeCatalogs([]*types.ModelsCatalog{validated, partner, community}, MergeOptions{})
+	if err != nil {
+		t.Fatalf("MergeCatalogs() error = %v", err)
+	}
+
+	if merged.Source != "Red Hat" {
+		t.Errorf("Source = %q, want the first catalog's source", merged.Source)
+	}
+	if len(merged.Models) != 3 {
+		t.Fatalf("Expected 3 models after merging, got %d", len(merged.Models))
+	}
+	granite, mistral, phi := merged.Models[0], merged.Models[1], merged.Models[2]
+	if *granite.Name != "granite-3.1-8b" || granite.Source != "" {
+		t.Errorf("Expected the validated granite to win with the catalog source, got %q from %q", *granite.Name, granite.Source)
+	}
+	if granite.Description == nil || *granite.Description != "From the partner catalog" {
+		t.Errorf("Expected the partner description to fill the missing one, got %v", granite.Description)
+	}
+	if *mistral.Name != "mistral-7b" || mistral.Source != "Partner" {
+		t.Errorf("Expected the URI duplicate to merge into the partner mistral, got %q from %q", *mistral.Name, mistral.Source)
+	}
+	if phi.Source != "Microsoft" {
+		t.Errorf("Expected explicit model sources to be kept, got %q", phi.Source)
+	}
+}
+
+func TestMergeCatalogs_Errors(t *testing.T) {
+	if _, err := MergeCatalogs(nil, MergeOptions{}); err == nil {
+		t.Error("Expected an error without catalogs")
+	}
+
+	input := &types.ModelsCatalog{Source: "Red Hat", Models: []types.CatalogMetadata{{Name: stringPtr("granite")}}}
+	if _, err := MergeCatalogs([]*types.ModelsCatalog{input}, MergeOptions{RequiredFields: []string{"license"}}); err == nil {
+		t.Error("Expected an error for a model missing a required field")
+	}
+	if _, err := MergeCatalogs([]*types.ModelsCatalog{input}, MergeOptions{Validation: "strict"}); err == nil {
+		t.Error("Expected an error for an invalid validation mode")
+	}
+}
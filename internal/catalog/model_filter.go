@@ -0,0 +1,137 @@
+package catalog
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// ModelFilterFile is the file format of the model filter. Entries are model names, matched
+// case-insensitively, or artifact URI patterns in which * matches any characters.
+type ModelFilterFile struct {
+	// Allow, when non-empty, limits the catalog to models whose name or an artifact URI matches
+	Allow []string `yaml:"allow"`
+	// Deny lists models and artifacts that must never appear in the catalog
+	Deny []string `yaml:"deny"`
+}
+
+// ModelFilter is a compiled allowlist/denylist of catalog models. A nil *ModelFilter keeps every
+// model.
+type ModelFilter struct {
+	allow []*regexp.Regexp
+	deny  []*regexp.Regexp
+}
+
+// LoadModelFilter loads an allowlist/denylist file for CatalogOptions.ModelFilter. An empty path
+// returns a nil filter.
+func LoadModelFilter(path string) (*ModelFilter, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read model filter %s: %v", path, err)
+	}
+
+	var file ModelFilterFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid model filter %s: %v", path, err)
+	}
+
+	filter := &ModelFilter{}
+	if filter.allow, err = compileFilterPatterns(file.Allow); err != nil {
+		return nil, fmt.Errorf("invalid model filter %s: allow: %v", path, err)
+	}
+	if filter.deny, err = compileFilterPatterns(file.Deny); err != nil {
+		return nil, fmt.Errorf("invalid model filter %s: deny: %v", path, err)
+	}
+	return filter, nil
+}
+
+// compileFilterPatterns turns filter entries into case-insensitive whole-string matchers
+func compileFilterPatterns(entries []string) ([]*regexp.Regexp, error) {
+	var patterns []*regexp.Regexp
+	for i, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			return nil, fmt.Errorf("entry at index %d is empty", i)
+		}
+		parts := strings.Split(entry, "*")
+		for j := range parts {
+			parts[j] = regexp.QuoteMeta(parts[j])
+		}
+		patterns = append(patterns, regexp.MustCompile("(?i)^"+strings.Join(parts, ".*")+"$"))
+	}
+	return patterns, nil
+}
+
+func matchesAny(patterns []*regexp.Regexp, value string) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// apply drops denied models and artifacts and, when an allowlist is set, models it does not
+// match. A model whose artifacts are all denied is dropped as well.
+func (f *ModelFilter) apply(models []types.CatalogMetadata) []types.CatalogMetadata {
+	if f == nil {
+		return models
+	}
+	var result []types.CatalogMetadata
+	for _, model := range models {
+		name := getModelName(&model)
+		if matchesAny(f.deny, name) {
+			log.Printf("  Model filter: dropping denied model '%s'", name)
+			continue
+		}
+		if len(f.allow) > 0 && !f.allowed(model) {
+			log.Printf("  Model filter: dropping model '%s' not on the allowlist", name)
+			continue
+		}
+
+		if len(f.deny) > 0 && len(model.Artifacts) > 0 {
+			var artifacts []types.CatalogOCIArtifact
+			for _, artifact := range model.Artifacts {
+				if matchesAny(f.deny, artifact.URI) {
+					log.Printf("  Model filter: dropping denied artifact %s of '%s'", artifact.URI, name)
+					continue
+				}
+				artifacts = append(artifacts, artifact)
+			}
+			if len(artifacts) == 0 {
+				log.Printf("  Model filter: dropping model '%s' with only denied artifacts", name)
+				continue
+			}
+			model.Artifacts = artifacts
+		}
+
+		result = append(result, model)
+	}
+
+	if dropped := len(models) - len(result); dropped > 0 {
+		log.Printf("Model filter excluded %d of %d models from the catalog", dropped, len(models))
+	}
+	return result
+}
+
+func (f *ModelFilter) allowed(model types.CatalogMetadata) bool {
+	if model.Name != nil && matchesAny(f.allow, *model.Name) {
+		return true
+	}
+	for _, artifact := range model.Artifacts {
+		if matchesAny(f.allow, artifact.URI) {
+			return true
+		}
+	}
+	return false
+}
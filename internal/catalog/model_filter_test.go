@@ -0,0 +1,113 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func writeModelFilter(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "model-filter.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write model filter: %v", err)
+	}
+	return path
+}
+
+func filterTestModels() []types.CatalogMetadata {
+	return []types.CatalogMetadata{
+		{
+			Name:      stringPtr("RedHatAI/granite"),
+			Artifacts: []types.CatalogOCIArtifact{{URI: "oci://registry.redhat.io/rhelai1/granite:1.5"}},
+		},
+		{
+			Name:      stringPtr("RedHatAI/Internal-Smoke-Test"),
+			Artifacts: []types.CatalogOCIArtifact{{URI: "oci://registry.redhat.io/rhelai1/smoke:1"}},
+		},
+		{
+			Name: stringPtr("RedHatAI/mixed"),
+			Artifacts: []types.CatalogOCIArtifact{
+				{URI: "oci://registry.redhat.io/rhelai1/mixed:1.5"},
+				{URI: "oci://quay.io/rhoai-internal/mixed:dev"},
+			},
+		},
+		{
+			Name:      stringPtr("RedHatAI/internal-only"),
+			Artifacts: []types.CatalogOCIArtifact{{URI: "oci://quay.io/rhoai-internal/only:dev"}},
+		},
+		{
+			Name:      stringPtr("community/model"),
+			Artifacts: []types.CatalogOCIArtifact{{URI: "oci://quay.io/community/model:1"}},
+		},
+	}
+}
+
+func filteredNames(models []types.CatalogMetadata) []string {
+	var names []string
+	for i := range models {
+		names = append(names, getModelName(&models[i]))
+	}
+	return names
+}
+
+func TestModelFilter_Deny(t *testing.T) {
+	path := writeModelFilter(t, `deny:
+  - redhatai/internal-smoke-test
+  - oci://quay.io/rhoai-internal/*
+`)
+	filter, err := LoadModelFilter(path)
+	if err != nil {
+		t.Fatalf("LoadModelFilter failed: %v", err)
+	}
+
+	result := filter.apply(filterTestModels())
+
+	expected := "RedHatAI/granite,RedHatAI/mixed,community/model"
+	if got := strings.Join(filteredNames(result), ","); got != expected {
+		t.Errorf("Expected models %s, got %s", expected, got)
+	}
+	if len(result[1].Artifacts) != 1 || result[1].Artifacts[0].URI != "oci://registry.redhat.io/rhelai1/mixed:1.5" {
+		t.Errorf("Expected denied artifact to be removed, got %+v", result[1].Artifacts)
+	}
+}
+
+func TestModelFilter_Allow(t *testing.T) {
+	path := writeModelFilter(t, `allow:
+  - oci://registry.redhat.io/*
+  - community/model
+deny:
+  - "*smoke*"
+`)
+	filter, err := LoadModelFilter(path)
+	if err != nil {
+		t.Fatalf("LoadModelFilter failed: %v", err)
+	}
+
+	result := filter.apply(filterTestModels())
+
+	expected := "RedHatAI/granite,RedHatAI/mixed,community/model"
+	if got := strings.Join(filteredNames(result), ","); got != expected {
+		t.Errorf("Expected models %s, got %s", expected, got)
+	}
+}
+
+func TestLoadModelFilter_Errors(t *testing.T) {
+	if _, err := LoadModelFilter(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+	if _, err := LoadModelFilter(writeModelFilter(t, "deny:\n  - \"\"\n")); err == nil || !strings.Contains(err.Error(), "deny: entry at index 0 is empty") {
+		t.Errorf("Expected empty entry error, got %v", err)
+	}
+	filter, err := LoadModelFilter("")
+	if err != nil || filter != nil {
+		t.Errorf("Expected no filter for an empty path, got %v, %v", filter, err)
+	}
+	models := filterTestModels()
+	if result := filter.apply(models); len(result) != len(models) {
+		t.Errorf("Expected no filtering without a filter, got %d models", len(result))
+	}
+}
@@ -0,0 +1,142 @@
+package catalog
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/metadata"
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// defaultModelcardTemplateText renders the readme of models without one
+//
+//go:embed templates/modelcard.md.tmpl
+var defaultModelcardTemplateText string
+
+var modelcardTemplateFuncs = template.FuncMap{"join": strings.Join}
+
+var defaultModelcardTemplate = &ModelcardTemplate{
+	tmpl: template.Must(template.New("modelcard").Funcs(modelcardTemplateFuncs).Parse(defaultModelcardTemplateText)),
+}
+
+// ModelcardTemplate renders the readmes generated for models without one
+type ModelcardTemplate struct {
+	tmpl *template.Template
+}
+
+// ModelcardTemplateData is the data a modelcard template is executed with. Empty fields are
+// unknown for the model.
+type ModelcardTemplateData struct {
+	Name             string
+	Provider         string
+	Description      string
+	License          string
+	LicenseLink      string
+	Tasks            []string
+	Language         []string
+	BaseModel        []string
+	MaxContextLength int64
+	// Artifacts are the URIs of the model's OCI artifacts
+	Artifacts []string
+	// HuggingFaceModel is the HuggingFace repository the model was enriched from, e.g.
+	// ibm-granite/granite-3.1-8b-instruct, and HuggingFaceURL its page
+	HuggingFaceModel string
+	HuggingFaceURL   string
+}
+
+// LoadModelcardTemplate loads a Go text/template for CatalogOptions.ModelcardTemplate that
+// replaces the embedded template for generated readmes. The template is executed with a
+// ModelcardTemplateData and may use the join function. An empty path returns a nil template,
+// which renders with the embedded one.
+func LoadModelcardTemplate(path string) (*ModelcardTemplate, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read modelcard template %s: %v", path, err)
+	}
+	tmpl, err := template.New("modelcard").Funcs(modelcardTemplateFuncs).Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse modelcard template %s: %v", path, err)
+	}
+	// Surface missing fields and functions now rather than once per model
+	if err := tmpl.Execute(&bytes.Buffer{}, ModelcardTemplateData{}); err != nil {
+		return nil, fmt.Errorf("invalid modelcard template %s: %v", path, err)
+	}
+	return &ModelcardTemplate{tmpl: tmpl}, nil
+}
+
+// Render renders the template for a model; a nil template renders with the embedded one
+func (t *ModelcardTemplate) Render(data ModelcardTemplateData) (string, error) {
+	if t == nil {
+		t = defaultModelcardTemplate
+	}
+	var buf bytes.Buffer
+	if err := t.tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("error rendering modelcard of %s: %v", data.Name, err)
+	}
+	return buf.String(), nil
+}
+
+// generateReadme renders a readme from the catalog metadata of a model without one, e.g. a model
+// image without a modelcard layer that HuggingFace enrichment found no readme for. Returns nil
+// when readmes are skipped.
+func generateReadme(output outputfs.FS, ref string, model types.ExtractedMetadata, readme metadata.ReadmeOptions, tmpl *ModelcardTemplate) (*string, error) {
+	if !readme.Enabled() {
+		return nil, nil
+	}
+	data := ModelcardTemplateData{
+		Name:             utils.StringValue(model.Name),
+		Provider:         utils.StringValue(model.Provider),
+		Description:      utils.StringValue(model.Description),
+		License:          utils.StringValue(model.License),
+		LicenseLink:      utils.StringValue(model.LicenseLink),
+		Tasks:            model.Tasks,
+		Language:         model.Language,
+		BaseModel:        model.BaseModel,
+		HuggingFaceModel: enrichedHuggingFaceModel(output, ref),
+	}
+	if data.Name == "" {
+		data.Name = ref
+	}
+	if contextLength := maxContextLength(model); contextLength != nil {
+		data.MaxContextLength = *contextLength
+	}
+	for _, artifact := range model.Artifacts {
+		data.Artifacts = append(data.Artifacts, artifact.URI)
+	}
+	if data.HuggingFaceModel != "" {
+		data.HuggingFaceURL = "https://huggingface.co/" + data.HuggingFaceModel
+	}
+
+	rendered, err := tmpl.Render(data)
+	if err != nil {
+		return nil, err
+	}
+	return &rendered, nil
+}
+
+// enrichedHuggingFaceModel returns the HuggingFace model recorded in a model's enrichment.yaml,
+// or "" when the model was not enriched
+func enrichedHuggingFaceModel(output outputfs.FS, ref string) string {
+	data, err := output.ReadFile(outputfs.ModelPath(ref, "enrichment.yaml"))
+	if err != nil {
+		return ""
+	}
+	var enrichment struct {
+		HuggingFaceModel string `yaml:"huggingface_model"`
+	}
+	if err := yaml.Unmarshal(data, &enrichment); err != nil {
+		return ""
+	}
+	return enrichment.HuggingFaceModel
+}
@@ -0,0 +1,90 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/metadata"
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestGenerateReadme(t *testing.T) {
+	output := outputfs.Dir(t.TempDir())
+	ref := "registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5"
+	if err := output.MkdirAll(outputfs.ModelPath(ref), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := output.WriteFile(outputfs.ModelPath(ref, "enrichment.yaml"), []byte("huggingface_model: ibm-granite/granite-3.1-8b-instruct\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	contextLength := int64(131072)
+	readme, err := generateReadme(output, ref, types.ExtractedMetadata{
+		Name:             stringPtr("RedHatAI/granite-3.1-8b-instruct"),
+		Provider:         stringPtr("IBM"),
+		Description:      stringPtr("Granite instruct model"),
+		License:          stringPtr("apache-2.0"),
+		LicenseLink:      stringPtr("https://www.apache.org/licenses/LICENSE-2.0"),
+		Tasks:            []string{"text-generation", "tool-calling"},
+		MaxContextLength: &contextLength,
+		Artifacts:        []types.OCIArtifact{{URI: "oci://" + ref}},
+	}, metadata.ReadmeOptions{}, nil)
+	if err != nil || readme == nil {
+		t.Fatalf("generateReadme() = %v, %v", readme, err)
+	}
+	for _, want := range []string{
+		"# RedHatAI/granite-3.1-8b-instruct\n\nGranite instruct model\n",
+		"| Provider | IBM |\n",
+		"| License | [apache-2.0](https://www.apache.org/licenses/LICENSE-2.0) |\n",
+		"| Tasks | text-generation, tool-calling |\n",
+		"| Context length | 131072 tokens |\n",
+		"| Hugging Face | [ibm-granite/granite-3.1-8b-instruct](https://huggingface.co/ibm-granite/granite-3.1-8b-instruct) |\n",
+		"## Artifacts\n\n- `oci://" + ref + "`\n",
+	} {
+		if !strings.Contains(*readme, want) {
+			t.Errorf("readme missing %q:\n%s", want, *readme)
+		}
+	}
+	if strings.Contains(*readme, "Languages") {
+		t.Errorf("readme lists unknown languages:\n%s", *readme)
+	}
+}
+
+func TestLoadModelcardTemplate(t *testing.T) {
+	dir := t.TempDir()
+	custom := filepath.Join(dir, "modelcard.md.tmpl")
+	if err := os.WriteFile(custom, []byte("## {{ .Name }} by {{ .Provider }}\n{{ join .Tasks \" / \" }}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tmpl, err := LoadModelcardTemplate(custom)
+	if err != nil {
+		t.Fatalf("LoadModelcardTemplate() error = %v", err)
+	}
+	data := ModelcardTemplateData{Name: "granite", Provider: "IBM", Tasks: []string{"a", "b"}}
+	readme, err := tmpl.Render(data)
+	if err != nil || readme != "## granite by IBM\na / b\n" {
+		t.Errorf("Render() = %q, %v", readme, err)
+	}
+
+	// Without a template file the embedded template is used
+	if tmpl, err := LoadModelcardTemplate(""); tmpl != nil || err != nil {
+		t.Errorf("LoadModelcardTemplate(\"\") = %v, %v, want nil", tmpl, err)
+	}
+	if readme, err := (*ModelcardTemplate)(nil).Render(data); err != nil || !strings.HasPrefix(readme, "# granite") {
+		t.Errorf("Render() with the embedded template = %q, %v", readme, err)
+	}
+
+	invalid := filepath.Join(dir, "invalid.tmpl")
+	if err := os.WriteFile(invalid, []byte("{{ .Unknown }}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadModelcardTemplate(invalid); err == nil {
+		t.Error("LoadModelcardTemplate() accepted a template with an unknown field")
+	}
+	if _, err := LoadModelcardTemplate(filepath.Join(dir, "missing.tmpl")); err == nil {
+		t.Error("LoadModelcardTemplate() accepted a missing file")
+	}
+}
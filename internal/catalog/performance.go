@@ -0,0 +1,39 @@
+package catalog
+
+import (
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// CustomProperties holding the serving performance declared by a model image's annotations
+const (
+	TokensPerSecondProperty        = "performance_tokens_per_second"
+	TimeToFirstTokenMsProperty     = "performance_time_to_first_token_ms"
+	InterTokenLatencyMsProperty    = "performance_inter_token_latency_ms"
+	RequestLatencyMsProperty       = "performance_request_latency_ms"
+	PerformanceHardwareProperty    = "performance_hardware"
+	PerformanceConcurrencyProperty = "performance_concurrency"
+)
+
+// addPerformance adds the model's benchmarked serving performance as typed customProperties:
+// measurements as doubles, the concurrency as an int and the reference hardware as a string
+func addPerformance(customProps map[string]types.MetadataValue, performance *types.PerformanceInfo) {
+	if performance.IsEmpty() {
+		return
+	}
+	for property, value := range map[string]float64{
+		TokensPerSecondProperty:     performance.TokensPerSecond,
+		TimeToFirstTokenMsProperty:  performance.TimeToFirstTokenMs,
+		InterTokenLatencyMsProperty: performance.InterTokenLatencyMs,
+		RequestLatencyMsProperty:    performance.RequestLatencyMs,
+	} {
+		if value > 0 {
+			customProps[property] = types.NewDoubleValue(value)
+		}
+	}
+	if performance.Hardware != "" {
+		customProps[PerformanceHardwareProperty] = types.NewStringValue(performance.Hardware)
+	}
+	if performance.Concurrency > 0 {
+		customProps[PerformanceConcurrencyProperty] = types.NewIntValue(performance.Concurrency)
+	}
+}
@@ -0,0 +1,39 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestConvertExtractedToCatalogMetadata_Performance(t *testing.T) {
+	metadata := types.ExtractedMetadata{
+		Name: stringPtr("granite-3.1-8b-instruct"),
+		Performance: &types.PerformanceInfo{
+			TokensPerSecond:    2450.5,
+			TimeToFirstTokenMs: 38,
+			Hardware:           "1x NVIDIA H100 80GB",
+			Concurrency:        32,
+		},
+		Artifacts: []types.OCIArtifact{},
+	}
+
+	result := convertExtractedToCatalogMetadata(metadata, nil)
+
+	expected := map[string]types.MetadataValue{
+		TokensPerSecondProperty:        types.NewDoubleValue(2450.5),
+		TimeToFirstTokenMsProperty:     types.NewDoubleValue(38),
+		PerformanceHardwareProperty:    types.NewStringValue("1x NVIDIA H100 80GB"),
+		PerformanceConcurrencyProperty: types.NewIntValue(32),
+	}
+	for property, value := range expected {
+		if got, ok := result.CustomProperties[property]; !ok || got != value {
+			t.Errorf("Expected %s to be %+v, got %+v", property, value, got)
+		}
+	}
+	for _, property := range []string{InterTokenLatencyMsProperty, RequestLatencyMsProperty} {
+		if _, ok := result.CustomProperties[property]; ok {
+			t.Errorf("Expected no %s when the image does not declare it", property)
+		}
+	}
+}
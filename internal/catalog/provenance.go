@@ -0,0 +1,206 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// StaticProvenanceFileName is the provenance report of the models that only static catalogs
+// supply, written to the root of the output directory
+const StaticProvenanceFileName = "static-provenance.yaml"
+
+// Provenance of values that catalog generation adds: static catalog entries and description
+// overrides are curated input, so their values are exact
+const (
+	provenanceStaticCatalog = "static-catalog"
+	provenanceOverride      = "override"
+	sourceStaticCatalog     = "static-catalog"
+	sourceOverride          = "description-overrides"
+	exactConfidence         = "exact"
+)
+
+// staticModelProvenance is the provenance of one model that only static catalogs supply
+type staticModelProvenance struct {
+	Name   string                     `yaml:"name"`
+	Fields map[string]provenanceField `yaml:"fields"`
+}
+
+// staticProvenanceFile is the format of StaticProvenanceFileName
+type staticProvenanceFile struct {
+	Models []staticModelProvenance `yaml:"models"`
+}
+
+// catalogProvenance collects the fields that static catalog entries and description overrides
+// supply during catalog generation, by lower-case model name
+type catalogProvenance struct {
+	fields map[string]map[string]provenanceField
+	// static are the models only static catalogs supply, by lower-case name
+	static map[string]string
+}
+
+func newCatalogProvenance() *catalogProvenance {
+	return &catalogProvenance{fields: make(map[string]map[string]provenanceField), static: make(map[string]string)}
+}
+
+// record sets the provenance of a field of the named model
+func (p *catalogProvenance) record(name, field, category, source string) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if p.fields[key] == nil {
+		p.fields[key] = make(map[string]provenanceField)
+	}
+	p.fields[key][field] = provenanceField{Category: category, Source: source, Confidence: exactConfidence}
+}
+
+// populatedByName returns the populated fields of each model by lower-case name; it is taken
+// before static models are merged, which updates the dynamic models in place
+func populatedByName(models []types.CatalogMetadata) map[string]map[string]bool {
+	populated := make(map[string]map[string]bool, len(models))
+	for _, model := range models {
+		if model.Name != nil {
+			populated[strings.ToLower(strings.TrimSpace(*model.Name))] = provenanceFields(model)
+		}
+	}
+	return populated
+}
+
+// recordStaticMerge records the fields static catalog entries supplied when they were merged into
+// the dynamic models, whose populated fields were populatedBefore: every field of a static-only
+// model, and the fields a static entry filled in an extracted model of the same name
+func (p *catalogProvenance) recordStaticMerge(populatedBefore map[string]map[string]bool, merged []types.CatalogMetadata) {
+	for _, model := range merged {
+		if model.Name == nil || strings.TrimSpace(*model.Name) == "" {
+			continue
+		}
+		key := strings.ToLower(strings.TrimSpace(*model.Name))
+		previous, extracted := populatedBefore[key]
+		if !extracted {
+			p.static[key] = *model.Name
+		}
+		for field, populated := range provenanceFields(model) {
+			if populated && !previous[field] {
+				p.record(*model.Name, field, provenanceStaticCatalog, sourceStaticCatalog)
+			}
+		}
+	}
+}
+
+// recordOverrides records the fields description overrides replaced
+func (p *catalogProvenance) recordOverrides(models []types.CatalogMetadata, overrides []DescriptionOverride) {
+	names := make(map[string]bool, len(models))
+	for _, model := range models {
+		if model.Name != nil {
+			names[strings.ToLower(strings.TrimSpace(*model.Name))] = true
+		}
+	}
+	for _, override := range overrides {
+		if !names[strings.ToLower(strings.TrimSpace(override.Name))] {
+			continue
+		}
+		if override.Description != nil {
+			p.record(override.Name, "description", provenanceOverride, sourceOverride)
+		}
+		if len(override.DescriptionI18n) > 0 {
+			p.record(override.Name, "descriptionI18n", provenanceOverride, sourceOverride)
+		}
+	}
+}
+
+// write adds the recorded fields to the provenance.yaml of the extracted models, found by name in
+// refsByName, and writes the provenance of static-only models to StaticProvenanceFileName
+func (p *catalogProvenance) write(output outputfs.FS, refsByName map[string][]string) error {
+	var staticModels []staticModelProvenance
+	for key, fields := range p.fields {
+		if name, ok := p.static[key]; ok {
+			staticModels = append(staticModels, staticModelProvenance{Name: name, Fields: fields})
+			continue
+		}
+		for _, ref := range refsByName[key] {
+			if err := updateModelProvenance(output, ref, fields); err != nil {
+				log.Printf("  Warning: Failed to record static catalog and override sources for %s: %v", ref, err)
+			}
+		}
+	}
+
+	if len(staticModels) == 0 {
+		if err := output.Remove(StaticProvenanceFileName); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to remove stale static catalog provenance: %v", err)
+		}
+		return nil
+	}
+	sort.Slice(staticModels, func(i, j int) bool { return staticModels[i].Name < staticModels[j].Name })
+	data, err := yaml.Marshal(staticProvenanceFile{Models: staticModels})
+	if err != nil {
+		return fmt.Errorf("failed to marshal static catalog provenance: %v", err)
+	}
+	if err := output.WriteFile(StaticProvenanceFileName, data, 0644); err != nil {
+		return fmt.Errorf("failed to write static catalog provenance: %v", err)
+	}
+	return nil
+}
+
+// updateModelProvenance sets fields in the provenance.yaml of an extracted model; models without
+// a provenance report are left alone
+func updateModelProvenance(output outputfs.FS, ref string, fields map[string]provenanceField) error {
+	path := outputfs.ModelPath(ref, provenanceFileName)
+	data, err := output.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var provenance provenanceFile
+	if err := yaml.Unmarshal(data, &provenance); err != nil {
+		return fmt.Errorf("error parsing provenance: %v", err)
+	}
+	if provenance.Fields == nil {
+		provenance.Fields = make(map[string]provenanceField)
+	}
+	for field, entry := range fields {
+		provenance.Fields[field] = entry
+	}
+	updated, err := yaml.Marshal(&provenance)
+	if err != nil {
+		return err
+	}
+	return output.WriteFile(path, updated, 0644)
+}
+
+// provenanceFields reports which fields of the provenance report a catalog model populates
+func provenanceFields(model types.CatalogMetadata) map[string]bool {
+	set := func(value *string) bool { return value != nil && strings.TrimSpace(*value) != "" }
+	return map[string]bool{
+		"name":                     set(model.Name),
+		"provider":                 set(model.Provider),
+		"description":              set(model.Description),
+		"descriptionI18n":          len(model.DescriptionI18n) > 0,
+		"readme":                   set(model.Readme),
+		"language":                 len(model.Language) > 0,
+		"license":                  set(model.License),
+		"licenseLink":              set(model.LicenseLink),
+		"tasks":                    len(model.Tasks) > 0,
+		"createTimeSinceEpoch":     set(model.CreateTimeSinceEpoch),
+		"lastUpdateTimeSinceEpoch": set(model.LastUpdateTimeSinceEpoch),
+		"validatedTasks":           len(model.ValidatedTasks) > 0,
+		"trainingDatasets":         len(model.TrainingDatasets) > 0,
+		"baseModel":                len(model.BaseModel) > 0,
+		"trainingData":             set(model.TrainingData),
+		"evaluations":              len(model.Evaluations) > 0,
+		"servingConfig":            model.ServingConfig != nil,
+		"quantization":             model.Quantization != nil,
+		"hardwareRequirements":     model.HardwareRequirements != nil,
+		"responsibleUse":           model.ResponsibleUse != nil,
+		"maxContextLength":         model.MaxContextLength != nil,
+		"modelConfig":              model.ModelConfig != nil,
+		"endOfLife":                set(model.EndOfLife),
+		"replacedBy":               set(model.ReplacedBy),
+		"logo":                     set(model.Logo),
+		"artifacts":                len(model.Artifacts) > 0,
+	}
+}
@@ -0,0 +1,113 @@
+package catalog
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestCatalogProvenance(t *testing.T) {
+	output := outputfs.Dir(t.TempDir())
+	ref := "registry.example.com/org/granite:1.0"
+	extracted := types.ExtractedMetadata{
+		Name:      stringPtr("granite"),
+		Provider:  stringPtr("IBM"),
+		Artifacts: []types.OCIArtifact{{URI: "oci://" + ref}},
+	}
+	data, err := yaml.Marshal(&extracted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	provenance := `registry_model: registry.example.com/org/granite:1.0
+fields:
+  provider:
+    category: modelcard
+    source: modelcard.yaml
+    confidence: exact
+  license:
+    category: none
+    source: "null"
+`
+	if err := output.MkdirAll(outputfs.ModelPath(ref), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := output.WriteFile(outputfs.ModelPath(ref, "metadata.yaml"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := output.WriteFile(outputfs.ModelPath(ref, provenanceFileName), []byte(provenance), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	staticModels := []types.CatalogMetadata{
+		// Fills the license of the extracted model; its provider is kept
+		{Name: stringPtr("Granite"), Provider: stringPtr("Static"), License: stringPtr("apache-2.0")},
+		{Name: stringPtr("whisper"), Provider: stringPtr("OpenAI"), Description: stringPtr("Speech recognition"),
+			Artifacts: []types.CatalogOCIArtifact{{URI: "oci://quay.io/org/whisper:1.0"}}},
+	}
+	overrides := []DescriptionOverride{{Name: "granite", Description: stringPtr("Curated description")}}
+
+	catalogPath := filepath.Join(t.TempDir(), "models-catalog.yaml")
+	err = CreateModelsCatalogWithOptions(context.Background(), output, catalogPath, []string{ref}, staticModels, CatalogOptions{
+		Format:               CatalogFormatYAML,
+		Validation:           CatalogValidationOff,
+		DescriptionOverrides: overrides,
+	})
+	if err != nil {
+		t.Fatalf("CreateModelsCatalogWithOptions() error = %v", err)
+	}
+
+	var updated provenanceFile
+	data, err = output.ReadFile(outputfs.ModelPath(ref, provenanceFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := yaml.Unmarshal(data, &updated); err != nil {
+		t.Fatal(err)
+	}
+	if updated.RegistryModel != ref {
+		t.Errorf("Expected the report to keep its registry model, got %q", updated.RegistryModel)
+	}
+	if got := updated.Fields["provider"]; got.Category != "modelcard" {
+		t.Errorf("Expected the extracted provider to keep its source, got %+v", got)
+	}
+	if got := updated.Fields["license"]; got.Category != provenanceStaticCatalog || got.Source != sourceStaticCatalog || got.Confidence != "exact" {
+		t.Errorf("Expected the license from the static catalog, got %+v", got)
+	}
+	if got := updated.Fields["description"]; got.Category != provenanceOverride || got.Source != sourceOverride {
+		t.Errorf("Expected the description from the overrides file, got %+v", got)
+	}
+
+	var static staticProvenanceFile
+	data, err = output.ReadFile(StaticProvenanceFileName)
+	if err != nil {
+		t.Fatalf("Expected the provenance of static-only models: %v", err)
+	}
+	if err := yaml.Unmarshal(data, &static); err != nil {
+		t.Fatal(err)
+	}
+	if len(static.Models) != 1 || static.Models[0].Name != "whisper" {
+		t.Fatalf("Expected only the static-only model, got %+v", static.Models)
+	}
+	for _, field := range []string{"name", "provider", "description", "artifacts"} {
+		if got := static.Models[0].Fields[field]; got.Category != provenanceStaticCatalog {
+			t.Errorf("Expected %s of the static-only model from the static catalog, got %+v", field, got)
+		}
+	}
+	if _, ok := static.Models[0].Fields["license"]; ok {
+		t.Error("Expected no provenance for fields the static model leaves empty")
+	}
+
+	// Without static-only models, a stale report is removed
+	err = CreateModelsCatalogWithOptions(context.Background(), output, catalogPath, []string{ref}, nil, CatalogOptions{Format: CatalogFormatYAML, Validation: CatalogValidationOff})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := output.Stat(StaticProvenanceFileName); err == nil {
+		t.Error("Expected the stale static catalog provenance to be removed")
+	}
+}
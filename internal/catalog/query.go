@@ -0,0 +1,155 @@
+package catalog
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// Query operators: equality and inequality ignore case and treat spaces, hyphens and underscores
+// alike, so license=Apache 2.0 matches apache-2.0; ~ matches a case-insensitive substring
+const (
+	QueryOpEqual    = "="
+	QueryOpNotEqual = "!="
+	QueryOpContains = "~"
+)
+
+// queryFields returns the values of each filterable field of a model; list fields match when
+// any of their values does
+var queryFields = map[string]func(model types.CatalogMetadata) []string{
+	"name":        func(m types.CatalogMetadata) []string { return optionalValue(m.Name) },
+	"provider":    func(m types.CatalogMetadata) []string { return optionalValue(m.Provider) },
+	"description": func(m types.CatalogMetadata) []string { return optionalValue(m.Description) },
+	"license":     func(m types.CatalogMetadata) []string { return optionalValue(m.License) },
+	"task":        func(m types.CatalogMetadata) []string { return m.Tasks },
+	"language":    func(m types.CatalogMetadata) []string { return m.Language },
+	"label": func(m types.CatalogMetadata) []string {
+		labels := make([]string, 0, len(m.CustomProperties))
+		for key := range m.CustomProperties {
+			labels = append(labels, key)
+		}
+		return labels
+	},
+	"registry":   artifactRegistrySources,
+	"deprecated": func(m types.CatalogMetadata) []string { return []string{strconv.FormatBool(m.Deprecated)} },
+}
+
+// QueryFields returns the field names a catalog query can filter on
+func QueryFields() []string {
+	fields := make([]string, 0, len(queryFields))
+	for field := range queryFields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// QueryCondition compares one field of a model with a value
+type QueryCondition struct {
+	Field    string
+	Operator string
+	Value    string
+}
+
+// Query is a conjunction of conditions; a model matches when it satisfies all of them
+type Query []QueryCondition
+
+// ParseQuery parses a filter such as "license=Apache 2.0 && task=text-generation". Conditions
+// are joined by && and compare a field with =, != or ~; an empty filter matches every model.
+func ParseQuery(filter string) (Query, error) {
+	var query Query
+	if strings.TrimSpace(filter) == "" {
+		return query, nil
+	}
+	for _, clause := range strings.Split(filter, "&&") {
+		condition, err := parseQueryCondition(strings.TrimSpace(clause))
+		if err != nil {
+			return nil, err
+		}
+		query = append(query, condition)
+	}
+	return query, nil
+}
+
+// parseQueryCondition parses a single field-operator-value condition
+func parseQueryCondition(clause string) (QueryCondition, error) {
+	// != is looked for first, as its "=" would otherwise split the condition
+	for _, operator := range []string{QueryOpNotEqual, QueryOpContains, QueryOpEqual} {
+		field, value, found := strings.Cut(clause, operator)
+		if !found {
+			continue
+		}
+		field = strings.ToLower(strings.TrimSpace(field))
+		if _, ok := queryFields[field]; !ok {
+			return QueryCondition{}, fmt.Errorf("unknown field %q in condition %q (fields: %s)", field, clause, strings.Join(QueryFields(), ", "))
+		}
+		return QueryCondition{Field: field, Operator: operator, Value: strings.TrimSpace(value)}, nil
+	}
+	return QueryCondition{}, fmt.Errorf("condition %q has no =, != or ~ operator", clause)
+}
+
+// Matches reports whether a model satisfies every condition of the query
+func (q Query) Matches(model types.CatalogMetadata) bool {
+	for _, condition := range q {
+		if !condition.matches(model) {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns the models of a catalog matching the query, in catalog order
+func (q Query) Filter(models []types.CatalogMetadata) []types.CatalogMetadata {
+	var result []types.CatalogMetadata
+	for _, model := range models {
+		if q.Matches(model) {
+			result = append(result, model)
+		}
+	}
+	return result
+}
+
+// matches reports whether a model satisfies the condition
+func (c QueryCondition) matches(model types.CatalogMetadata) bool {
+	values := queryFields[c.Field](model)
+	switch c.Operator {
+	case QueryOpContains:
+		for _, value := range values {
+			if strings.Contains(strings.ToLower(value), strings.ToLower(c.Value)) {
+				return true
+			}
+		}
+		return false
+	case QueryOpNotEqual:
+		return !containsQueryValue(values, c.Value)
+	default:
+		return containsQueryValue(values, c.Value)
+	}
+}
+
+// containsQueryValue reports whether any of values equals value after normalization
+func containsQueryValue(values []string, value string) bool {
+	want := normalizeQueryValue(value)
+	for _, candidate := range values {
+		if normalizeQueryValue(candidate) == want {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeQueryValue lowercases a value and unifies spaces, hyphens and underscores
+func normalizeQueryValue(value string) string {
+	return strings.NewReplacer(" ", "-", "_", "-").Replace(strings.ToLower(strings.TrimSpace(value)))
+}
+
+// optionalValue returns the value of an optional string field as a list
+func optionalValue(value *string) []string {
+	if value == nil {
+		return nil
+	}
+	return []string{*value}
+}
@@ -0,0 +1,70 @@
+package catalog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func queryTestModels() []types.CatalogMetadata {
+	return []types.CatalogMetadata{
+		{
+			Name:             stringPtr("RedHatAI/granite-3.1-8b-instruct"),
+			Provider:         stringPtr("IBM"),
+			License:          stringPtr("apache-2.0"),
+			Tasks:            []string{"text-generation"},
+			Language:         []string{"en", "de"},
+			CustomProperties: map[string]types.MetadataValue{"validated": {}},
+		},
+		{
+			Name:     stringPtr("RedHatAI/Llama-3.1-8B-Instruct"),
+			Provider: stringPtr("Meta"),
+			License:  stringPtr("llama3.1"),
+			Tasks:    []string{"text-generation"},
+		},
+		{
+			Name:       stringPtr("RedHatAI/whisper-large-v3"),
+			License:    stringPtr("apache-2.0"),
+			Tasks:      []string{"automatic-speech-recognition"},
+			Deprecated: true,
+		},
+	}
+}
+
+func TestQueryFilter(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		{"", []string{"RedHatAI/granite-3.1-8b-instruct", "RedHatAI/Llama-3.1-8B-Instruct", "RedHatAI/whisper-large-v3"}},
+		{"license=Apache 2.0 && task=text-generation", []string{"RedHatAI/granite-3.1-8b-instruct"}},
+		{"license != apache-2.0", []string{"RedHatAI/Llama-3.1-8B-Instruct"}},
+		{"name~llama", []string{"RedHatAI/Llama-3.1-8B-Instruct"}},
+		{"language=DE", []string{"RedHatAI/granite-3.1-8b-instruct"}},
+		{"label=validated", []string{"RedHatAI/granite-3.1-8b-instruct"}},
+		{"deprecated=true", []string{"RedHatAI/whisper-large-v3"}},
+		{"provider=IBM && provider=Meta", nil},
+	}
+	for _, tt := range tests {
+		query, err := ParseQuery(tt.filter)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error = %v", tt.filter, err)
+		}
+		var got []string
+		for _, model := range query.Filter(queryTestModels()) {
+			got = append(got, *model.Name)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Filter(%q) = %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestParseQuery_Errors(t *testing.T) {
+	for _, filter := range []string{"size=8b", "license", "task=text-generation && "} {
+		if _, err := ParseQuery(filter); err == nil {
+			t.Errorf("ParseQuery(%q) expected an error", filter)
+		}
+	}
+}
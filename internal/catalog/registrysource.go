@@ -0,0 +1,134 @@
+package catalog
+
+import (
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// RegistrySourceProperty is the artifact customProperty naming the registry family an artifact is
+// distributed through, so consumers can filter models by channel and entitlement
+const RegistrySourceProperty = "registrySource"
+
+// Registry families of artifacts that are not hosted on a well-known public registry
+const (
+	// RegistrySourceInternal is the family of cluster-local, private-network and configured
+	// internal registries
+	RegistrySourceInternal = "internal"
+	// RegistrySourceS3 is the family of s3:// artifacts
+	RegistrySourceS3 = "s3"
+	// RegistrySourceOther is the family of any other registry or download host
+	RegistrySourceOther = "other"
+)
+
+// knownRegistrySources maps the hosts of well-known registries to their family. Images on
+// registry.redhat.io require a Red Hat entitlement to pull.
+var knownRegistrySources = map[string]string{
+	"registry.redhat.io":          "registry.redhat.io",
+	"registry.access.redhat.com":  "registry.access.redhat.com",
+	"registry.connect.redhat.com": "registry.connect.redhat.com",
+	"quay.io":                     "quay.io",
+	"docker.io":                   "docker.io",
+	"index.docker.io":             "docker.io",
+	"registry-1.docker.io":        "docker.io",
+	"ghcr.io":                     "ghcr.io",
+	"nvcr.io":                     "nvcr.io",
+	"huggingface.co":              "huggingface.co",
+}
+
+// internalDomainSuffixes are domains that are only resolvable inside a cluster or private network
+var internalDomainSuffixes = []string{".svc", ".cluster.local", ".local", ".internal", ".lan", ".home.arpa"}
+
+// RegistrySource returns the registry family of an artifact URI: the well-known registry it is
+// hosted on, RegistrySourceInternal for cluster-local and private hosts and for hosts on or under
+// one of internalRegistries, RegistrySourceS3 for S3 objects, or RegistrySourceOther
+func RegistrySource(uri string, internalRegistries []string) string {
+	parsed, err := utils.ParseArtifactURI(uri)
+	if err != nil {
+		return RegistrySourceOther
+	}
+
+	var host string
+	switch parsed.Scheme {
+	case utils.URISchemeHF:
+		return knownRegistrySources["huggingface.co"]
+	case utils.URISchemeS3:
+		return RegistrySourceS3
+	case utils.URISchemeHTTPS:
+		if u, err := url.Parse(parsed.URL); err == nil {
+			host = u.Hostname()
+		}
+	default:
+		host = parsed.Registry
+		if hostname, _, err := net.SplitHostPort(host); err == nil {
+			host = hostname
+		}
+	}
+
+	if isInternalHost(host, internalRegistries) {
+		return RegistrySourceInternal
+	}
+	if source, ok := knownRegistrySources[host]; ok {
+		return source
+	}
+	return RegistrySourceOther
+}
+
+// isInternalHost reports whether host is a loopback or private address, a single-label or
+// cluster-local name, or on or under one of internalRegistries
+func isInternalHost(host string, internalRegistries []string) bool {
+	for _, internal := range internalRegistries {
+		internal = strings.ToLower(strings.TrimSpace(internal))
+		if internal != "" && (host == internal || strings.HasSuffix(host, "."+internal)) {
+			return true
+		}
+	}
+	if ip := net.ParseIP(strings.Trim(host, "[]")); ip != nil {
+		return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast()
+	}
+	if host == "localhost" || !strings.Contains(host, ".") {
+		return true
+	}
+	for _, suffix := range internalDomainSuffixes {
+		if strings.HasSuffix(host, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// addRegistrySources sets the registrySource customProperty of every artifact that does not
+// already carry one, as static catalogs may curate it
+func addRegistrySources(models []types.CatalogMetadata, internalRegistries []string) {
+	for i := range models {
+		for j := range models[i].Artifacts {
+			artifact := &models[i].Artifacts[j]
+			if _, exists := artifact.CustomProperties[RegistrySourceProperty]; exists {
+				continue
+			}
+			if artifact.CustomProperties == nil {
+				artifact.CustomProperties = make(map[string]interface{})
+			}
+			artifact.CustomProperties[RegistrySourceProperty] = map[string]interface{}{
+				"metadataType": types.MetadataTypeString,
+				"string_value": RegistrySource(artifact.URI, internalRegistries),
+			}
+		}
+	}
+}
+
+// artifactRegistrySources returns the registrySource of each artifact of a model
+func artifactRegistrySources(model types.CatalogMetadata) []string {
+	var sources []string
+	for _, artifact := range model.Artifacts {
+		if value, ok := artifact.CustomProperties[RegistrySourceProperty].(map[string]interface{}); ok {
+			if source, ok := value["string_value"].(string); ok && source != "" {
+				sources = append(sources, source)
+			}
+		}
+	}
+	return sources
+}
@@ -0,0 +1,58 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestRegistrySource(t *testing.T) {
+	internal := []string{"example.corp"}
+	tests := map[string]string{
+		"oci://registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5": "registry.redhat.io",
+		"quay.io/redhat-ai-services/modelcar-catalog:granite":                   "quay.io",
+		"oci://docker.io/library/model:1.0":                                     "docker.io",
+		"localhost:5000/models/granite:1.0":                                     RegistrySourceInternal,
+		"10.0.12.4:5000/models/granite:1.0":                                     RegistrySourceInternal,
+		"image-registry.openshift-image-registry.svc:5000/models/granite:1.0":   RegistrySourceInternal,
+		"registry.example.corp/models/granite:1.0":                              RegistrySourceInternal,
+		"registry.example.com/models/granite:1.0":                               RegistrySourceOther,
+		"hf://ibm-granite/granite-3.1-8b-instruct":                              "huggingface.co",
+		"https://huggingface.co/org/model/resolve/main/model.gguf":              "huggingface.co",
+		"https://downloads.example.corp/model.gguf":                             RegistrySourceInternal,
+		"s3://models/granite/model.safetensors":                                 RegistrySourceS3,
+		"not a uri":                                                             RegistrySourceOther,
+	}
+	for uri, expected := range tests {
+		if source := RegistrySource(uri, internal); source != expected {
+			t.Errorf("RegistrySource(%q) = %q, expected %q", uri, source, expected)
+		}
+	}
+}
+
+func TestAddRegistrySources(t *testing.T) {
+	models := []types.CatalogMetadata{{
+		Name: stringPtr("granite"),
+		Artifacts: []types.CatalogOCIArtifact{
+			{URI: "oci://registry.redhat.io/rhelai1/modelcar-granite:1.5"},
+			{URI: "oci://quay.io/org/granite:1.5", CustomProperties: map[string]interface{}{
+				RegistrySourceProperty: map[string]interface{}{"metadataType": types.MetadataTypeString, "string_value": "mirror"},
+			}},
+		},
+	}}
+
+	addRegistrySources(models, nil)
+
+	sources := artifactRegistrySources(models[0])
+	if len(sources) != 2 || sources[0] != "registry.redhat.io" || sources[1] != "mirror" {
+		t.Errorf("Expected the derived source and the curated one to be kept, got %v", sources)
+	}
+
+	query, err := ParseQuery("registry=registry.redhat.io")
+	if err != nil {
+		t.Fatalf("ParseQuery failed: %v", err)
+	}
+	if len(query.Filter(models)) != 1 {
+		t.Error("Expected the registry query field to match the artifact's registrySource")
+	}
+}
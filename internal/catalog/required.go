@@ -0,0 +1,87 @@
+package catalog
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// requiredFieldCheckers report whether a catalog model has a value for each field that can be
+// required with --require-fields
+var requiredFieldCheckers = map[string]func(model *types.CatalogMetadata) bool{
+	"name":        func(m *types.CatalogMetadata) bool { return populated(m.Name) },
+	"provider":    func(m *types.CatalogMetadata) bool { return populated(m.Provider) },
+	"description": func(m *types.CatalogMetadata) bool { return populated(m.Description) },
+	"readme":      func(m *types.CatalogMetadata) bool { return populated(m.Readme) },
+	"license":     func(m *types.CatalogMetadata) bool { return populated(m.License) },
+	"licenseLink": func(m *types.CatalogMetadata) bool { return populated(m.LicenseLink) },
+	"logo":        func(m *types.CatalogMetadata) bool { return populated(m.Logo) },
+	"language":    func(m *types.CatalogMetadata) bool { return len(m.Language) > 0 },
+	"tasks":       func(m *types.CatalogMetadata) bool { return len(m.Tasks) > 0 },
+}
+
+// maxReportedIncompleteModels caps the number of incomplete models included in an error message
+const maxReportedIncompleteModels = 20
+
+func populated(value *string) bool {
+	return value != nil && strings.TrimSpace(*value) != ""
+}
+
+// RequirableFields returns the catalog fields that can be required, sorted by name
+func RequirableFields() []string {
+	fields := make([]string, 0, len(requiredFieldCheckers))
+	for field := range requiredFieldCheckers {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// ValidateRequiredFields checks that every field can be required
+func ValidateRequiredFields(fields []string) error {
+	for _, field := range fields {
+		if _, ok := requiredFieldCheckers[field]; !ok {
+			return fmt.Errorf("invalid required field: %q (allowed values: %s)", field, strings.Join(RequirableFields(), ", "))
+		}
+	}
+	return nil
+}
+
+// CheckRequiredFields returns an error listing every catalog model that lacks a value for any of
+// the required fields
+func CheckRequiredFields(catalog *types.ModelsCatalog, fields []string) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	if err := ValidateRequiredFields(fields); err != nil {
+		return err
+	}
+
+	var incomplete []string
+	for i := range catalog.Models {
+		model := &catalog.Models[i]
+		var missing []string
+		for _, field := range fields {
+			if !requiredFieldCheckers[field](model) {
+				missing = append(missing, field)
+			}
+		}
+		if len(missing) > 0 {
+			entry := fmt.Sprintf("%s (%s)", getModelName(model), strings.Join(missing, ", "))
+			log.Printf("  Missing required fields: %s", entry)
+			incomplete = append(incomplete, entry)
+		}
+	}
+
+	if len(incomplete) == 0 {
+		return nil
+	}
+	reported := incomplete
+	if len(reported) > maxReportedIncompleteModels {
+		reported = reported[:maxReportedIncompleteModels]
+	}
+	return fmt.Errorf("%d models are missing required fields: %s", len(incomplete), strings.Join(reported, "; "))
+}
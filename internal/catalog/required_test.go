@@ -0,0 +1,59 @@
+package catalog
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestValidateRequiredFields(t *testing.T) {
+	if err := ValidateRequiredFields([]string{"name", "provider", "license", "description", "tasks"}); err != nil {
+		t.Errorf("Expected valid fields, got %v", err)
+	}
+	err := ValidateRequiredFields([]string{"name", "authors"})
+	if err == nil || !strings.Contains(err.Error(), `invalid required field: "authors"`) {
+		t.Errorf("Expected invalid field error, got %v", err)
+	}
+}
+
+func TestCheckRequiredFields(t *testing.T) {
+	catalog := &types.ModelsCatalog{
+		Source: "Red Hat",
+		Models: []types.CatalogMetadata{
+			{
+				Name:        stringPtr("complete"),
+				Provider:    stringPtr("IBM"),
+				License:     stringPtr("apache-2.0"),
+				Description: stringPtr("A model"),
+			},
+			{
+				Name:        stringPtr("no-license"),
+				Provider:    stringPtr("IBM"),
+				Description: stringPtr("A model"),
+			},
+			{
+				Name:        stringPtr("blank"),
+				Provider:    stringPtr(" "),
+				License:     stringPtr("mit"),
+				Description: nil,
+			},
+		},
+	}
+
+	if err := CheckRequiredFields(catalog, nil); err != nil {
+		t.Errorf("Expected no error without required fields, got %v", err)
+	}
+	if err := CheckRequiredFields(catalog, []string{"name"}); err != nil {
+		t.Errorf("Expected all models to have names, got %v", err)
+	}
+
+	err := CheckRequiredFields(catalog, []string{"name", "provider", "license", "description"})
+	if err == nil {
+		t.Fatal("Expected missing fields error")
+	}
+	expected := "2 models are missing required fields: no-license (license); blank (provider, description)"
+	if err.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, err.Error())
+	}
+}
@@ -0,0 +1,182 @@
+package catalog
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// Catalog validation modes
+const (
+	CatalogValidationError = "error"
+	CatalogValidationWarn  = "warn"
+	CatalogValidationOff   = "off"
+)
+
+// modelsCatalogSchema is the JSON Schema of the catalogs this repository generates for the
+// model-registry catalog API
+//
+//go:embed schema/models-catalog.schema.json
+var modelsCatalogSchema []byte
+
+// modelsCatalogSchemaURL is the $id of the embedded catalog schema
+const modelsCatalogSchemaURL = "https://github.com/opendatahub-io/model-metadata-collection/schema/models-catalog.schema.json"
+
+// compiledCatalogSchema compiles the embedded catalog schema once
+var compiledCatalogSchema = sync.OnceValues(func() (*jsonschema.Schema, error) {
+	document, err := jsonschema.UnmarshalJSON(bytes.NewReader(modelsCatalogSchema))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse embedded catalog schema: %v", err)
+	}
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(modelsCatalogSchemaURL, document); err != nil {
+		return nil, fmt.Errorf("failed to load embedded catalog schema: %v", err)
+	}
+	schema, err := compiler.Compile(modelsCatalogSchemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile embedded catalog schema: %v", err)
+	}
+	return schema, nil
+})
+
+// maxReportedViolations caps the number of schema violations included in an error message
+const maxReportedViolations = 20
+
+// ValidateCatalogValidationMode checks that mode is one of the supported validation modes
+func ValidateCatalogValidationMode(mode string) error {
+	switch mode {
+	case CatalogValidationError, CatalogValidationWarn, CatalogValidationOff:
+		return nil
+	default:
+		return fmt.Errorf("invalid catalog validation mode: %q (allowed values: %q, %q, %q)",
+			mode, CatalogValidationError, CatalogValidationWarn, CatalogValidationOff)
+	}
+}
+
+// ValidateCatalogSchema validates a models catalog against the embedded JSON Schema and returns
+// every violation found, each prefixed with the JSON pointer of the offending value
+func ValidateCatalogSchema(catalog *types.ModelsCatalog) ([]string, error) {
+	schema, err := compiledCatalogSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the serialized JSON form so the check sees exactly what consumers will read
+	data, err := EncodeCatalog(catalog, CatalogFormatJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize catalog for validation: %v", err)
+	}
+	document, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize catalog for validation: %v", err)
+	}
+
+	err = schema.Validate(document)
+	var validationErr *jsonschema.ValidationError
+	if err == nil {
+		return nil, nil
+	} else if !errors.As(err, &validationErr) {
+		return nil, err
+	}
+	return schemaViolations(validationErr), nil
+}
+
+// schemaViolations flattens a validation error into one message per failed keyword, each
+// prefixed with the JSON pointer of the offending value and sorted by it
+func schemaViolations(err *jsonschema.ValidationError) []string {
+	printer := message.NewPrinter(language.English)
+	var violations []string
+	var collect func(*jsonschema.ValidationError)
+	collect = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) > 0 {
+			for _, cause := range e.Causes {
+				collect(cause)
+			}
+			return
+		}
+		path := "/"
+		if len(e.InstanceLocation) > 0 {
+			segments := make([]string, len(e.InstanceLocation))
+			for i, segment := range e.InstanceLocation {
+				segments[i] = strings.NewReplacer("~", "~0", "/", "~1").Replace(segment)
+			}
+			path = "/" + strings.Join(segments, "/")
+		}
+		violations = append(violations, path+": "+e.ErrorKind.LocalizedString(printer))
+	}
+	collect(err)
+	sort.Strings(violations)
+	return violations
+}
+
+// applyCatalogValidation validates a catalog according to mode, returning an error only
+// when mode is CatalogValidationError and violations were found
+func applyCatalogValidation(catalog *types.ModelsCatalog, mode string) error {
+	if mode == CatalogValidationOff || mode == "" {
+		return nil
+	}
+
+	// Tasks outside the canonical vocabulary are still valid catalog values, but usually typos or
+	// task names that need an alias
+	for _, warning := range UnknownTaskWarnings(catalog) {
+		log.Printf("  Warning: %s", warning)
+	}
+
+	violations, err := ValidateCatalogSchema(catalog)
+	if err != nil {
+		return err
+	}
+	if len(violations) == 0 {
+		log.Printf("Catalog passed schema validation")
+		return nil
+	}
+
+	for _, violation := range violations {
+		log.Printf("  Schema violation: %s", violation)
+	}
+
+	if mode == CatalogValidationWarn {
+		log.Printf("Warning: Catalog has %d schema violations", len(violations))
+		return nil
+	}
+
+	reported := violations
+	if len(reported) > maxReportedViolations {
+		reported = reported[:maxReportedViolations]
+	}
+	return fmt.Errorf("catalog failed schema validation with %d violations: %s", len(violations), strings.Join(reported, "; "))
+}
+
+// UnknownTaskWarnings describes every task and validated task of the catalog models that is not
+// part of the canonical task vocabulary (types.SupportedTasks)
+func UnknownTaskWarnings(catalog *types.ModelsCatalog) []string {
+	var warnings []string
+	for i, model := range catalog.Models {
+		name := fmt.Sprintf("models[%d]", i)
+		if model.Name != nil {
+			name = *model.Name
+		}
+		for _, task := range model.Tasks {
+			if !types.IsSupportedTask(task) {
+				warnings = append(warnings, fmt.Sprintf("model %s has unknown task %q", name, task))
+			}
+		}
+		for _, task := range model.ValidatedTasks {
+			if !types.IsSupportedTask(task) {
+				warnings = append(warnings, fmt.Sprintf("model %s has unknown validated task %q", name, task))
+			}
+		}
+	}
+	return warnings
+}
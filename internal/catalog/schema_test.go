@@ -0,0 +1,132 @@
+package catalog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestValidateCatalogSchema_Valid(t *testing.T) {
+	violations, err := ValidateCatalogSchema(sampleCatalog())
+	if err != nil {
+		t.Fatalf("ValidateCatalogSchema() error = %v", err)
+	}
+	if len(violations) != 0 {
+		t.Errorf("Expected no violations, got %v", violations)
+	}
+}
+
+func TestValidateCatalogSchema_Violations(t *testing.T) {
+	badTime := "yesterday"
+	catalog := &types.ModelsCatalog{
+		Source: "Red Hat",
+		Models: []types.CatalogMetadata{
+			{
+				Name:                 stringPtr("Model A"),
+				CreateTimeSinceEpoch: &badTime,
+				CustomProperties: map[string]types.MetadataValue{
+					"bad": {MetadataType: "MetadataUnknownValue"},
+				},
+				Artifacts: []types.CatalogOCIArtifact{
+					{URI: ""},
+					{URI: "oci://registry.example.com/a:1", Vulnerabilities: &types.VulnerabilitySummary{Scanner: "trivy", High: -1}},
+				},
+			},
+			{
+				Artifacts: []types.CatalogOCIArtifact{},
+			},
+		},
+	}
+
+	violations, err := ValidateCatalogSchema(catalog)
+	if err != nil {
+		t.Fatalf("ValidateCatalogSchema() error = %v", err)
+	}
+
+	expected := []string{
+		"/models/0/createTimeSinceEpoch: 'yesterday' does not match pattern",
+		"/models/0/customProperties/bad/metadataType: value must be one of",
+		"/models/0/artifacts/0/uri: minLength: got 0, want 1",
+		"/models/0/artifacts/1/vulnerabilities/high: minimum: got -1, want 0",
+		"/models/1/name: got null, want string",
+		"/models/1/artifacts: minItems: got 0, want 1",
+	}
+	joined := strings.Join(violations, "\n")
+	for _, want := range expected {
+		if !strings.Contains(joined, want) {
+			t.Errorf("Expected violation containing %q, got:\n%s", want, joined)
+		}
+	}
+}
+
+func TestUnknownTaskWarnings(t *testing.T) {
+	name := "org/model"
+	catalog := &types.ModelsCatalog{Models: []types.CatalogMetadata{
+		{Name: &name, Tasks: []string{"text-generation", "text-genration"}, ValidatedTasks: []string{"chat"}},
+		{Tasks: []string{"audio-to-text"}},
+	}}
+
+	expected := []string{
+		`model org/model has unknown task "text-genration"`,
+		`model org/model has unknown validated task "chat"`,
+	}
+	if got := UnknownTaskWarnings(catalog); !reflect.DeepEqual(got, expected) {
+		t.Errorf("UnknownTaskWarnings() = %v, expected %v", got, expected)
+	}
+}
+
+func TestValidateCatalogValidationMode(t *testing.T) {
+	for _, mode := range []string{CatalogValidationError, CatalogValidationWarn, CatalogValidationOff} {
+		if err := ValidateCatalogValidationMode(mode); err != nil {
+			t.Errorf("ValidateCatalogValidationMode(%q) unexpected error: %v", mode, err)
+		}
+	}
+	if err := ValidateCatalogValidationMode("strict"); err == nil {
+		t.Error("Expected error for unsupported mode")
+	}
+}
+
+func TestCreateModelsCatalogWithOptions_Validation(t *testing.T) {
+	invalidStatic := []types.CatalogMetadata{{Name: stringPtr("Static Model")}}
+
+	tests := []struct {
+		mode        string
+		expectError bool
+	}{
+		{CatalogValidationError, true},
+		{CatalogValidationWarn, false},
+		{CatalogValidationOff, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			catalogPath := filepath.Join(t.TempDir(), "catalog.yaml")
+			err := CreateModelsCatalogWithOptions(context.Background(), outputfs.Dir(t.TempDir()), catalogPath, nil, invalidStatic, CatalogOptions{
+				Format:     CatalogFormatYAML,
+				Validation: tt.mode,
+			})
+
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("Expected validation error")
+				}
+				if _, statErr := os.Stat(catalogPath); !os.IsNotExist(statErr) {
+					t.Error("Catalog should not be written when validation fails")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if _, statErr := os.Stat(catalogPath); statErr != nil {
+				t.Errorf("Expected catalog to be written: %v", statErr)
+			}
+		})
+	}
+}
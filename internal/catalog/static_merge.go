@@ -0,0 +1,138 @@
+package catalog
+
+import (
+	"log"
+	"strings"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// mergeStaticModels adds static models to the dynamic catalog models. A static model whose name
+// matches a dynamic model (case-insensitive) is merged into it field by field rather than being
+// emitted as a second entry; all other static models are appended in order.
+func mergeStaticModels(dynamicModels, staticModels []types.CatalogMetadata) []types.CatalogMetadata {
+	byName := make(map[string]int)
+	for i, model := range dynamicModels {
+		if model.Name != nil && strings.TrimSpace(*model.Name) != "" {
+			byName[strings.ToLower(strings.TrimSpace(*model.Name))] = i
+		}
+	}
+
+	result := dynamicModels
+	merged := 0
+	for _, static := range staticModels {
+		if static.Name != nil {
+			if i, ok := byName[strings.ToLower(strings.TrimSpace(*static.Name))]; ok {
+				log.Printf("  Merging static entry '%s' into extracted model '%s'", *static.Name, *result[i].Name)
+				result[i] = mergeStaticIntoDynamic(result[i], static)
+				merged++
+				continue
+			}
+		}
+		result = append(result, static)
+	}
+
+	if merged > 0 {
+		log.Printf("Merged %d static models into extracted models with the same name", merged)
+	}
+	return result
+}
+
+// mergeStaticIntoDynamic merges a static catalog entry into an extracted model. Extracted values
+// win for every field they populate; the static entry fills the remaining fields. Artifacts and
+// customProperties are combined, with extracted entries taking precedence on conflicts.
+func mergeStaticIntoDynamic(dynamic, static types.CatalogMetadata) types.CatalogMetadata {
+	merged := dynamic
+
+	merged.Provider = preferPopulated(dynamic.Provider, static.Provider)
+	merged.Description = preferPopulated(dynamic.Description, static.Description)
+	merged.Readme = preferPopulated(dynamic.Readme, static.Readme)
+	merged.License = preferPopulated(dynamic.License, static.License)
+	merged.LicenseLink = preferPopulated(dynamic.LicenseLink, static.LicenseLink)
+	merged.Logo = preferPopulated(dynamic.Logo, static.Logo)
+	merged.CreateTimeSinceEpoch = preferPopulated(dynamic.CreateTimeSinceEpoch, static.CreateTimeSinceEpoch)
+	merged.LastUpdateTimeSinceEpoch = preferPopulated(dynamic.LastUpdateTimeSinceEpoch, static.LastUpdateTimeSinceEpoch)
+	merged.EndOfLife = preferPopulated(dynamic.EndOfLife, static.EndOfLife)
+	merged.ReplacedBy = preferPopulated(dynamic.ReplacedBy, static.ReplacedBy)
+	merged.Deprecated = dynamic.Deprecated || static.Deprecated
+
+	if len(merged.Language) == 0 {
+		merged.Language = static.Language
+	}
+	if len(merged.Tasks) == 0 {
+		merged.Tasks = static.Tasks
+	}
+	if len(merged.ValidatedTasks) == 0 {
+		merged.ValidatedTasks = static.ValidatedTasks
+	}
+	if len(merged.TrainingDatasets) == 0 {
+		merged.TrainingDatasets = static.TrainingDatasets
+	}
+	if len(merged.BaseModel) == 0 {
+		merged.BaseModel = static.BaseModel
+	}
+	merged.TrainingData = preferPopulated(dynamic.TrainingData, static.TrainingData)
+	if len(merged.Evaluations) == 0 {
+		merged.Evaluations = static.Evaluations
+	}
+	if merged.ServingConfig == nil {
+		merged.ServingConfig = static.ServingConfig
+	}
+	if merged.Quantization == nil {
+		merged.Quantization = static.Quantization
+	}
+	if merged.HardwareRequirements == nil {
+		merged.HardwareRequirements = static.HardwareRequirements
+	}
+	if merged.ResponsibleUse == nil {
+		merged.ResponsibleUse = static.ResponsibleUse
+	}
+	if merged.MaxContextLength == nil {
+		merged.MaxContextLength = static.MaxContextLength
+	}
+	if merged.ModelConfig == nil {
+		merged.ModelConfig = static.ModelConfig
+	}
+
+	if len(static.DescriptionI18n) > 0 {
+		descriptions := make(map[string]string, len(dynamic.DescriptionI18n)+len(static.DescriptionI18n))
+		for language, description := range static.DescriptionI18n {
+			descriptions[language] = description
+		}
+		for language, description := range dynamic.DescriptionI18n {
+			descriptions[language] = description
+		}
+		merged.DescriptionI18n = descriptions
+	}
+
+	if len(static.CustomProperties) > 0 {
+		customProps := make(map[string]types.MetadataValue, len(dynamic.CustomProperties)+len(static.CustomProperties))
+		for key, value := range static.CustomProperties {
+			customProps[key] = value
+		}
+		for key, value := range dynamic.CustomProperties {
+			customProps[key] = value
+		}
+		merged.CustomProperties = customProps
+	}
+
+	artifactURIs := make(map[string]bool)
+	merged.Artifacts = nil
+	for _, artifact := range append(append([]types.CatalogOCIArtifact{}, dynamic.Artifacts...), static.Artifacts...) {
+		if key := utils.NormalizeArtifactURI(artifact.URI); !artifactURIs[key] {
+			merged.Artifacts = append(merged.Artifacts, artifact)
+			artifactURIs[key] = true
+		}
+	}
+
+	return merged
+}
+
+// preferPopulated returns primary when it holds a non-blank value, otherwise fallback
+func preferPopulated(primary, fallback *string) *string {
+	if primary != nil && strings.TrimSpace(*primary) != "" {
+		return primary
+	}
+	return fallback
+}
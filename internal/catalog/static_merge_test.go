@@ -0,0 +1,94 @@
+package catalog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestMergeStaticIntoDynamic(t *testing.T) {
+	dynamic := types.CatalogMetadata{
+		Name:        stringPtr("granite-3.1-8b-instruct"),
+		Provider:    stringPtr("IBM"),
+		Description: stringPtr(""),
+		License:     stringPtr("apache-2.0"),
+		Tasks:       []string{"text-generation"},
+		CustomProperties: map[string]types.MetadataValue{
+			"model_type": createMetadataValue("generative"),
+		},
+		Artifacts: []types.CatalogOCIArtifact{{URI: "oci://registry.example.com/granite:1.5"}},
+	}
+	static := types.CatalogMetadata{
+		Name:        stringPtr("Granite-3.1-8B-Instruct"),
+		Provider:    stringPtr("Static Provider"),
+		Description: stringPtr("Curated description"),
+		DescriptionI18n: map[string]string{
+			"es": "Descripción curada",
+		},
+		License:     stringPtr("mit"),
+		LicenseLink: stringPtr("https://www.apache.org/licenses/LICENSE-2.0"),
+		Language:    []string{"en"},
+		Tasks:       []string{"text-classification"},
+		CustomProperties: map[string]types.MetadataValue{
+			"model_type": createMetadataValue("predictive"),
+			"featured":   createMetadataValue(""),
+		},
+		Artifacts: []types.CatalogOCIArtifact{
+			{URI: "oci://registry.example.com/granite:1.5"},
+			{URI: "oci://registry.example.com/granite:1.4"},
+		},
+	}
+
+	merged := mergeStaticIntoDynamic(dynamic, static)
+
+	if *merged.Name != "granite-3.1-8b-instruct" || *merged.Provider != "IBM" || *merged.License != "apache-2.0" {
+		t.Errorf("Expected populated extracted fields to win, got name=%s provider=%s license=%s", *merged.Name, *merged.Provider, *merged.License)
+	}
+	if merged.Description == nil || *merged.Description != "Curated description" {
+		t.Errorf("Expected blank description to be filled from static entry, got %v", merged.Description)
+	}
+	if merged.DescriptionI18n["es"] != "Descripción curada" {
+		t.Errorf("Expected localized descriptions from static entry, got %v", merged.DescriptionI18n)
+	}
+	if merged.LicenseLink == nil || *merged.LicenseLink != "https://www.apache.org/licenses/LICENSE-2.0" {
+		t.Errorf("Expected licenseLink from static entry, got %v", merged.LicenseLink)
+	}
+	if !reflect.DeepEqual(merged.Language, []string{"en"}) {
+		t.Errorf("Expected language from static entry, got %v", merged.Language)
+	}
+	if !reflect.DeepEqual(merged.Tasks, []string{"text-generation"}) {
+		t.Errorf("Expected extracted tasks to win, got %v", merged.Tasks)
+	}
+	if merged.CustomProperties["model_type"].StringValue != "generative" {
+		t.Errorf("Expected extracted model_type to win, got %q", merged.CustomProperties["model_type"].StringValue)
+	}
+	if _, ok := merged.CustomProperties["featured"]; !ok {
+		t.Error("Expected static customProperties to be added")
+	}
+	if len(merged.Artifacts) != 2 {
+		t.Errorf("Expected 2 unique artifacts, got %d", len(merged.Artifacts))
+	}
+}
+
+func TestMergeStaticModels(t *testing.T) {
+	dynamicModels := []types.CatalogMetadata{
+		{Name: stringPtr("Model A"), Artifacts: []types.CatalogOCIArtifact{{URI: "oci://example.com/a:1"}}},
+	}
+	staticModels := []types.CatalogMetadata{
+		{Name: stringPtr("model a"), Description: stringPtr("From static"), Artifacts: []types.CatalogOCIArtifact{{URI: "oci://example.com/a:1"}}},
+		{Name: stringPtr("Model B"), Artifacts: []types.CatalogOCIArtifact{{URI: "oci://example.com/b:1"}}},
+	}
+
+	result := mergeStaticModels(dynamicModels, staticModels)
+
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 models, got %d", len(result))
+	}
+	if *result[0].Name != "Model A" || result[0].Description == nil || *result[0].Description != "From static" {
+		t.Errorf("Expected static duplicate to be merged into 'Model A', got %+v", result[0])
+	}
+	if *result[1].Name != "Model B" {
+		t.Errorf("Expected non-duplicate static model to be appended, got %q", *result[1].Name)
+	}
+}
@@ -0,0 +1,166 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// StaticCatalogIssue is a single problem found in a static catalog file
+type StaticCatalogIssue struct {
+	// Line is the 1-based line the problem was found on, or 0 when it applies to the whole file
+	Line    int
+	Message string
+}
+
+// staticCatalogLines maps catalog elements to the lines they start on
+type staticCatalogLines struct {
+	models    []int
+	artifacts [][]int
+}
+
+// yamlErrorLine matches the line prefix of yaml.v3 syntax and type errors
+var yamlErrorLine = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// ValidateStaticCatalogFile checks a static catalog file and returns every problem found, with the
+// line it occurs on where known. An error is returned only when the file cannot be read.
+func ValidateStaticCatalogFile(path string) ([]StaticCatalogIssue, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading static catalog file: %v", err)
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return yamlIssues(err), nil
+	}
+
+	var staticCatalog types.ModelsCatalog
+	if err := node.Decode(&staticCatalog); err != nil {
+		return yamlIssues(err), nil
+	}
+
+	return staticCatalogIssues(&staticCatalog, catalogLines(&node)), nil
+}
+
+// yamlIssues converts a YAML syntax or type error into issues, one per reported line
+func yamlIssues(err error) []StaticCatalogIssue {
+	messages := []string{err.Error()}
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		messages = typeErr.Errors
+	}
+
+	issues := make([]StaticCatalogIssue, 0, len(messages))
+	for _, message := range messages {
+		issue := StaticCatalogIssue{Message: message}
+		if match := yamlErrorLine.FindStringSubmatch(message); match != nil {
+			issue.Line, _ = strconv.Atoi(match[1])
+			issue.Message = match[2]
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// catalogLines records the line of every model and artifact in a parsed static catalog document
+func catalogLines(document *yaml.Node) staticCatalogLines {
+	var lines staticCatalogLines
+	if len(document.Content) == 0 {
+		return lines
+	}
+	models := mappingValue(document.Content[0], "models")
+	if models == nil || models.Kind != yaml.SequenceNode {
+		return lines
+	}
+
+	for _, model := range models.Content {
+		lines.models = append(lines.models, model.Line)
+		var artifactLines []int
+		if artifacts := mappingValue(model, "artifacts"); artifacts != nil && artifacts.Kind == yaml.SequenceNode {
+			for _, artifact := range artifacts.Content {
+				artifactLines = append(artifactLines, artifact.Line)
+			}
+		}
+		lines.artifacts = append(lines.artifacts, artifactLines)
+	}
+	return lines
+}
+
+// mappingValue returns the value node of key in a mapping node, or nil
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// staticCatalogIssues returns every structural problem in a static catalog, in document order
+func staticCatalogIssues(catalog *types.ModelsCatalog, lines staticCatalogLines) []StaticCatalogIssue {
+	var issues []StaticCatalogIssue
+
+	if catalog.Source == "" {
+		issues = append(issues, StaticCatalogIssue{Message: "static catalog missing required 'source' field"})
+	}
+
+	for i, model := range catalog.Models {
+		modelLine := lineAt(lines.models, i)
+
+		if model.Name == nil || *model.Name == "" {
+			issues = append(issues, StaticCatalogIssue{Line: modelLine, Message: fmt.Sprintf("model at index %d missing required 'name' field", i)})
+			continue
+		}
+
+		if len(model.Artifacts) == 0 {
+			issues = append(issues, StaticCatalogIssue{Line: modelLine, Message: fmt.Sprintf("model '%s' has no artifacts", *model.Name)})
+		}
+
+		if err := types.ValidateLifecycle(model.EndOfLife, model.ReplacedBy); err != nil {
+			issues = append(issues, StaticCatalogIssue{Line: modelLine, Message: fmt.Sprintf("model '%s': %v", *model.Name, err)})
+		}
+
+		if err := ValidateDescriptionI18n(model.DescriptionI18n); err != nil {
+			issues = append(issues, StaticCatalogIssue{Line: modelLine, Message: fmt.Sprintf("model '%s': %v", *model.Name, err)})
+		}
+
+		var artifactLines []int
+		if i < len(lines.artifacts) {
+			artifactLines = lines.artifacts[i]
+		}
+		for j, artifact := range model.Artifacts {
+			if artifact.URI == "" {
+				issues = append(issues, StaticCatalogIssue{
+					Line:    lineAt(artifactLines, j),
+					Message: fmt.Sprintf("model '%s' artifact at index %d missing required 'uri' field", *model.Name, j),
+				})
+			} else if err := utils.ValidateArtifactURI(artifact.URI); err != nil {
+				issues = append(issues, StaticCatalogIssue{
+					Line:    lineAt(artifactLines, j),
+					Message: fmt.Sprintf("model '%s': %v", *model.Name, err),
+				})
+			}
+		}
+	}
+
+	return issues
+}
+
+// lineAt returns lines[i], or 0 when the line is unknown
+func lineAt(lines []int, i int) int {
+	if i < len(lines) {
+		return lines[i]
+	}
+	return 0
+}
@@ -0,0 +1,104 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeStaticCatalogFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "supplemental-catalog.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write static catalog: %v", err)
+	}
+	return path
+}
+
+func TestValidateStaticCatalogFile(t *testing.T) {
+	path := writeStaticCatalogFile(t, `source: Red Hat
+models:
+  - name: good
+    artifacts:
+      - uri: oci://example.com/good:1
+  - name: no-artifacts
+  - name: bad-uri
+    endOfLife: soon
+    artifacts:
+      - uri: oci://example.com/bad:1
+      - tags: [latest]
+  - provider: IBM
+    artifacts:
+      - uri: oci://example.com/unnamed:1
+  - name: bad-i18n
+    description_i18n:
+      Spanish: Hola
+    artifacts:
+      - uri: oci://example.com/i18n:1
+  - name: malformed-uri
+    artifacts:
+      - uri: oci://example.com/Model:1
+`)
+
+	issues, err := ValidateStaticCatalogFile(path)
+	if err != nil {
+		t.Fatalf("ValidateStaticCatalogFile failed: %v", err)
+	}
+
+	expected := []struct {
+		line    int
+		message string
+	}{
+		{6, "model 'no-artifacts' has no artifacts"},
+		{7, "model 'bad-uri': invalid endOfLife"},
+		{11, "model 'bad-uri' artifact at index 1 missing required 'uri' field"},
+		{12, "model at index 3 missing required 'name' field"},
+		{15, "model 'bad-i18n': invalid description_i18n language"},
+		{22, "model 'malformed-uri': artifact URI \"oci://example.com/Model:1\" has invalid repository"},
+	}
+	if len(issues) != len(expected) {
+		t.Fatalf("Expected %d issues, got %d: %+v", len(expected), len(issues), issues)
+	}
+	for i, want := range expected {
+		if issues[i].Line != want.line || !strings.HasPrefix(issues[i].Message, want.message) {
+			t.Errorf("Issue %d = %d: %s, want %d: %s", i, issues[i].Line, issues[i].Message, want.line, want.message)
+		}
+	}
+}
+
+func TestValidateStaticCatalogFile_Valid(t *testing.T) {
+	path := writeStaticCatalogFile(t, "source: Red Hat\nmodels:\n  - name: good\n    artifacts:\n      - uri: oci://example.com/good:1\n")
+
+	issues, err := ValidateStaticCatalogFile(path)
+	if err != nil {
+		t.Fatalf("ValidateStaticCatalogFile failed: %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Expected no issues, got %+v", issues)
+	}
+}
+
+func TestValidateStaticCatalogFile_YAMLErrors(t *testing.T) {
+	path := writeStaticCatalogFile(t, "source: Red Hat\nmodels:\n  - name: [unclosed\n")
+	issues, err := ValidateStaticCatalogFile(path)
+	if err != nil {
+		t.Fatalf("ValidateStaticCatalogFile failed: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Line == 0 {
+		t.Errorf("Expected one syntax issue with a line number, got %+v", issues)
+	}
+
+	path = writeStaticCatalogFile(t, "source: Red Hat\nmodels:\n  - name: good\n    artifacts: not-a-list\n")
+	issues, err = ValidateStaticCatalogFile(path)
+	if err != nil {
+		t.Fatalf("ValidateStaticCatalogFile failed: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Line != 4 {
+		t.Errorf("Expected one type issue on line 4, got %+v", issues)
+	}
+
+	if _, err := ValidateStaticCatalogFile(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Expected error for a missing file")
+	}
+}
@@ -0,0 +1,131 @@
+package catalog
+
+import (
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// groupModelsByRepository merges models whose images are tags of the same repository, e.g. the
+// 1.4 and 1.5 modelcars of a model, into one model with an artifact per tag. The model of the
+// highest tag provides the name and wins conflicting fields, and takes the catalog position of the
+// first model of its repository. Models without an OCI artifact and unnamed models are left
+// untouched.
+func groupModelsByRepository(models []types.CatalogMetadata) []types.CatalogMetadata {
+	if len(models) <= 1 {
+		return models
+	}
+
+	groups := make(map[string][]int)
+	var repositories []string
+	for i, model := range models {
+		if model.Name == nil || strings.TrimSpace(*model.Name) == "" {
+			continue
+		}
+		repository, _ := imageRepositoryOf(model)
+		if repository == "" {
+			continue
+		}
+		if _, exists := groups[repository]; !exists {
+			repositories = append(repositories, repository)
+		}
+		groups[repository] = append(groups[repository], i)
+	}
+
+	merged := make(map[int]types.CatalogMetadata)
+	skipped := make(map[int]bool)
+	grouped := 0
+	for _, repository := range repositories {
+		members := groups[repository]
+		if len(members) == 1 {
+			continue
+		}
+		group := make([]types.CatalogMetadata, 0, len(members))
+		for _, i := range members {
+			group = append(group, models[i])
+			skipped[i] = true
+		}
+		// Stable, so models of equal tags keep their catalog order
+		sort.SliceStable(group, func(a, b int) bool {
+			_, tagA := imageRepositoryOf(group[a])
+			_, tagB := imageRepositoryOf(group[b])
+			return compareTags(tagA, tagB) > 0
+		})
+		log.Printf("Grouping %d tags of %s into '%s'", len(group), repository, *group[0].Name)
+		merged[members[0]] = mergeModelGroup(group)
+		grouped += len(group) - 1
+	}
+	if grouped == 0 {
+		return models
+	}
+
+	var result []types.CatalogMetadata
+	for i, model := range models {
+		if groupModel, ok := merged[i]; ok {
+			result = append(result, groupModel)
+		} else if !skipped[i] {
+			result = append(result, model)
+		}
+	}
+	log.Printf("Grouped %d catalog entries into models of the same image repository", grouped)
+	return result
+}
+
+// imageRepositoryOf returns the registry/repository and tag of a model's first OCI artifact
+func imageRepositoryOf(model types.CatalogMetadata) (string, string) {
+	for _, artifact := range model.Artifacts {
+		parsed, err := utils.ParseArtifactURI(artifact.URI)
+		if err != nil || parsed.Scheme != utils.URISchemeOCI {
+			continue
+		}
+		return parsed.Registry + "/" + parsed.Repository, parsed.Tag
+	}
+	return "", ""
+}
+
+// compareTags compares image tags in natural order, so 1.10 sorts after 1.9: runs of digits are
+// compared as numbers and other characters as text. It returns -1 if a < b, 1 if a > b, 0 if equal.
+func compareTags(a, b string) int {
+	partsA, partsB := tagParts(a), tagParts(b)
+	for i := 0; i < len(partsA) && i < len(partsB); i++ {
+		numberA, errA := strconv.Atoi(partsA[i])
+		numberB, errB := strconv.Atoi(partsB[i])
+		switch {
+		case errA == nil && errB == nil && numberA != numberB:
+			if numberA < numberB {
+				return -1
+			}
+			return 1
+		case (errA != nil || errB != nil) && partsA[i] != partsB[i]:
+			return strings.Compare(partsA[i], partsB[i])
+		}
+	}
+	switch {
+	case len(partsA) < len(partsB):
+		return -1
+	case len(partsA) > len(partsB):
+		return 1
+	}
+	return 0
+}
+
+// tagParts splits a tag into runs of digits and runs of other characters
+func tagParts(tag string) []string {
+	var parts []string
+	start := 0
+	for i, r := range tag {
+		if i > start && unicode.IsDigit(r) != unicode.IsDigit(rune(tag[start])) {
+			parts = append(parts, tag[start:i])
+			start = i
+		}
+	}
+	if start < len(tag) {
+		parts = append(parts, tag[start:])
+	}
+	return parts
+}
@@ -0,0 +1,71 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestGroupModelsByRepository(t *testing.T) {
+	artifact := func(uri string) []types.CatalogOCIArtifact {
+		return []types.CatalogOCIArtifact{{URI: uri}}
+	}
+	models := []types.CatalogMetadata{
+		{Name: stringPtr("granite-3.1-8b-instruct 1.4"), Description: stringPtr("Older description"), License: stringPtr("apache-2.0"),
+			Artifacts: artifact("oci://registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.4")},
+		{Name: stringPtr("llama-3.1-8b-instruct"), Artifacts: artifact("oci://registry.redhat.io/rhelai1/modelcar-llama-3-1-8b-instruct:1.5")},
+		{Name: stringPtr("granite-3.1-8b-instruct"), Description: stringPtr("Current description"),
+			Artifacts: artifact("oci://registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.10")},
+		{Name: stringPtr("granite-3.1-8b-instruct 1.5"), Artifacts: artifact("oci://registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5")},
+		{Name: stringPtr("granite-hf"), Artifacts: artifact("hf://ibm-granite/granite-3.1-8b-instruct")},
+		{Name: nil, Artifacts: artifact("oci://registry.redhat.io/rhelai1/modelcar-llama-3-1-8b-instruct:1.4")},
+	}
+
+	result := groupModelsByRepository(models)
+
+	if len(result) != 4 {
+		t.Fatalf("Expected 4 models after grouping, got %d", len(result))
+	}
+	granite := result[0]
+	if *granite.Name != "granite-3.1-8b-instruct" || *granite.Description != "Current description" {
+		t.Errorf("Expected the model of the highest tag to win, got %q: %q", *granite.Name, *granite.Description)
+	}
+	if granite.License == nil || *granite.License != "apache-2.0" {
+		t.Errorf("Expected missing fields to be filled from older tags, got %v", granite.License)
+	}
+	wantURIs := []string{
+		"oci://registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.10",
+		"oci://registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5",
+		"oci://registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.4",
+	}
+	if len(granite.Artifacts) != len(wantURIs) {
+		t.Fatalf("Expected an artifact per tag, got %+v", granite.Artifacts)
+	}
+	for i, uri := range wantURIs {
+		if granite.Artifacts[i].URI != uri {
+			t.Errorf("Artifacts[%d] = %s, want %s", i, granite.Artifacts[i].URI, uri)
+		}
+	}
+	if *result[1].Name != "llama-3.1-8b-instruct" || *result[2].Name != "granite-hf" || result[3].Name != nil {
+		t.Errorf("Expected other models to keep their order, got %v, %v, %v", result[1].Name, result[2].Name, result[3].Name)
+	}
+}
+
+func TestCompareTags(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.5", "1.4", 1},
+		{"1.10", "1.9", 1},
+		{"1.5", "1.5", 0},
+		{"1.5", "1.5-w4a16", -1},
+		{"2.0", "10.0", -1},
+		{"latest", "1.5", 1},
+	}
+	for _, tt := range tests {
+		if got := compareTags(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareTags(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package catalog
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/history"
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+)
+
+// PreviousVersionsProperty is the customProperty holding the JSON list of a model's previous
+// versions, newest first, each with its tag, digest and extraction date
+const PreviousVersionsProperty = "previous_versions"
+
+// maxPreviousVersions caps the previous versions listed in the catalog; the history file keeps all
+const maxPreviousVersions = 10
+
+// previousVersions returns the JSON list of the versions in a model's history whose digest differs
+// from the current one, or "" when there are none
+func previousVersions(output outputfs.FS, ref string) string {
+	modelHistory, err := history.Read(output, ref)
+	if err != nil {
+		log.Printf("  Warning: %v", err)
+		return ""
+	}
+	previous := modelHistory.Previous()
+	if len(previous) == 0 {
+		return ""
+	}
+	if len(previous) > maxPreviousVersions {
+		previous = previous[:maxPreviousVersions]
+	}
+	for i := range previous {
+		// The metadata hash and rollback marker only matter within the history file
+		previous[i].MetadataSHA256 = ""
+		previous[i].Rollback = false
+	}
+	data, err := json.Marshal(previous)
+	if err != nil {
+		log.Printf("  Warning: error encoding previous versions of %s: %v", ref, err)
+		return ""
+	}
+	return string(data)
+}
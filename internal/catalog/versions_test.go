@@ -0,0 +1,36 @@
+package catalog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/history"
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestPreviousVersions(t *testing.T) {
+	output := outputfs.Dir(t.TempDir())
+	ref := "registry.redhat.io/rhelai1/modelcar-phi-4:1.5"
+	if got := previousVersions(output, ref); got != "" {
+		t.Errorf("Expected no previous versions without a history, got %s", got)
+	}
+
+	for i, digest := range []string{"sha256:aaa", "sha256:bbb"} {
+		extractedAt := time.Date(2026, 10, i+1, 9, 0, 0, 0, time.UTC)
+		if _, err := history.Record(output, ref, digest, []byte("name: phi-4"), extractedAt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := `[{"tag":"1.5","digest":"sha256:aaa","extractedAt":"2026-10-01T09:00:00Z"}]`
+	got := previousVersions(output, ref)
+	if got != want {
+		t.Errorf("previousVersions() = %s, want %s", got, want)
+	}
+
+	name := "phi-4"
+	catalogModel := convertExtractedToCatalogMetadata(types.ExtractedMetadata{Name: &name, PreviousVersions: got}, nil)
+	if catalogModel.CustomProperties[PreviousVersionsProperty].StringValue != want {
+		t.Errorf("Expected the %s customProperty, got %+v", PreviousVersionsProperty, catalogModel.CustomProperties)
+	}
+}
@@ -0,0 +1,110 @@
+package catalog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/metadata"
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// VLLMProfileFileName is the name of the vLLM launch profile written next to a model's metadata.yaml
+const VLLMProfileFileName = "vllm-profile.yaml"
+
+// VLLMProfileProperty is the customProperty holding the path of a model's vLLM launch profile
+const VLLMProfileProperty = "vllm_profile"
+
+// vllmDtypes are the torch_dtype values passed through to --dtype; others leave vLLM on "auto"
+var vllmDtypes = map[string]bool{"bfloat16": true, "float16": true, "float32": true}
+
+// VLLMProfile is a vLLM launch profile derived from a model's extracted metadata
+type VLLMProfile struct {
+	// Model is the model's first OCI artifact, or its name when it has none
+	Model              string `yaml:"model"`
+	TensorParallelSize int    `yaml:"tensorParallelSize"`
+	// MaxModelLen is the model's context window; omitted when unknown so vLLM reads it from the model
+	MaxModelLen int64  `yaml:"maxModelLen,omitempty"`
+	Dtype       string `yaml:"dtype"`
+	// Quantization is the vLLM quantization method, e.g. gguf, awq or compressed-tensors
+	Quantization string `yaml:"quantization,omitempty"`
+	// Args are the vLLM serve arguments applying the profile
+	Args []string `yaml:"args"`
+}
+
+// BuildVLLMProfile derives a vLLM launch profile from a model's metadata: the tensor parallel
+// size from its recommended accelerator count, --max-model-len from its context length, --dtype
+// from the torch_dtype of its config.json and --quantization from its GGUF header or quantization
+// scheme
+func BuildVLLMProfile(model types.ExtractedMetadata) VLLMProfile {
+	profile := VLLMProfile{
+		Model:              utils.StringValue(model.Name),
+		TensorParallelSize: 1,
+		Dtype:              "auto",
+	}
+	if len(model.Artifacts) > 0 {
+		profile.Model = model.Artifacts[0].URI
+	}
+	if model.HardwareRequirements != nil && model.HardwareRequirements.RecommendedAcceleratorCount > 0 {
+		profile.TensorParallelSize = model.HardwareRequirements.RecommendedAcceleratorCount
+	}
+	if contextLength := maxContextLength(model); contextLength != nil {
+		profile.MaxModelLen = *contextLength
+	}
+	if model.ModelConfig != nil && vllmDtypes[model.ModelConfig.TorchDtype] {
+		profile.Dtype = model.ModelConfig.TorchDtype
+	}
+	profile.Quantization = vllmQuantization(model)
+
+	profile.Args = []string{"--tensor-parallel-size=" + strconv.Itoa(profile.TensorParallelSize)}
+	if profile.MaxModelLen > 0 {
+		profile.Args = append(profile.Args, "--max-model-len="+strconv.FormatInt(profile.MaxModelLen, 10))
+	}
+	profile.Args = append(profile.Args, "--dtype="+profile.Dtype)
+	if profile.Quantization != "" {
+		profile.Args = append(profile.Args, "--quantization="+profile.Quantization)
+	}
+	return profile
+}
+
+// vllmQuantization returns the vLLM quantization method of a model. Schemes such as W4A16 or FP8
+// in Red Hat AI model names are produced by llm-compressor and load as compressed-tensors.
+func vllmQuantization(model types.ExtractedMetadata) string {
+	if model.Quantization != nil && model.Quantization.Format == types.QuantizationFormatGGUF {
+		return "gguf"
+	}
+	var references []string
+	for _, artifact := range model.Artifacts {
+		references = append(references, artifact.URI)
+	}
+	scheme := metadata.DetectModelTraits(utils.StringValue(model.Name), references, "").Quantization
+	switch {
+	case scheme == "":
+		return ""
+	case scheme == "AWQ" || scheme == "GPTQ":
+		return strings.ToLower(scheme)
+	case strings.HasPrefix(scheme, "Q"):
+		// GGUF quantization names without a GGUF header
+		return "gguf"
+	default:
+		return "compressed-tensors"
+	}
+}
+
+// writeVLLMProfile writes the vLLM launch profile of a model next to its metadata.yaml and
+// returns the profile's path
+func writeVLLMProfile(output outputfs.FS, ref string, model types.ExtractedMetadata) (string, error) {
+	data, err := yaml.Marshal(BuildVLLMProfile(model))
+	if err != nil {
+		return "", fmt.Errorf("error marshaling vLLM profile of %s: %v", ref, err)
+	}
+	profilePath := outputfs.ModelPath(ref, VLLMProfileFileName)
+	if err := output.WriteFile(profilePath, data, 0644); err != nil {
+		return "", fmt.Errorf("error writing vLLM profile of %s: %v", ref, err)
+	}
+	return output.Path(profilePath), nil
+}
@@ -0,0 +1,74 @@
+package catalog
+
+import (
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestBuildVLLMProfile(t *testing.T) {
+	name := "granite-3.1-8b-instruct-quantized.w4a16"
+	contextLength := int64(131072)
+	profile := BuildVLLMProfile(types.ExtractedMetadata{
+		Name:                 &name,
+		MaxContextLength:     &contextLength,
+		HardwareRequirements: &types.HardwareRequirements{RecommendedAcceleratorCount: 2},
+		ModelConfig:          &types.ModelConfig{TorchDtype: "bfloat16"},
+		Artifacts:            []types.OCIArtifact{{URI: "oci://registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct-quantized-w4a16:1.5"}},
+	})
+
+	want := []string{"--tensor-parallel-size=2", "--max-model-len=131072", "--dtype=bfloat16", "--quantization=compressed-tensors"}
+	if !reflect.DeepEqual(profile.Args, want) {
+		t.Errorf("Args = %v, expected %v", profile.Args, want)
+	}
+	if profile.Model != "oci://registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct-quantized-w4a16:1.5" {
+		t.Errorf("Unexpected model %s", profile.Model)
+	}
+}
+
+func TestBuildVLLMProfile_Defaults(t *testing.T) {
+	name := "phi-4"
+	profile := BuildVLLMProfile(types.ExtractedMetadata{
+		Name:         &name,
+		ModelConfig:  &types.ModelConfig{TorchDtype: "int8"},
+		Quantization: &types.QuantizationInfo{Format: types.QuantizationFormatGGUF, Type: "Q4_K_M", ContextLength: 16384},
+	})
+	want := []string{"--tensor-parallel-size=1", "--max-model-len=16384", "--dtype=auto", "--quantization=gguf"}
+	if !reflect.DeepEqual(profile.Args, want) || profile.Model != "phi-4" {
+		t.Errorf("Unexpected profile %+v", profile)
+	}
+}
+
+func TestWriteVLLMProfile(t *testing.T) {
+	output := outputfs.Dir(t.TempDir())
+	ref := "registry.redhat.io/rhelai1/modelcar-phi-4:1.5"
+	if err := output.MkdirAll(outputfs.ModelPath(ref), 0755); err != nil {
+		t.Fatal(err)
+	}
+	name := "phi-4"
+	profilePath, err := writeVLLMProfile(output, ref, types.ExtractedMetadata{Name: &name})
+	if err != nil {
+		t.Fatalf("writeVLLMProfile failed: %v", err)
+	}
+	if profilePath != output.Path(outputfs.ModelPath(ref, VLLMProfileFileName)) {
+		t.Errorf("Unexpected profile path %s", profilePath)
+	}
+
+	data, err := output.ReadFile(outputfs.ModelPath(ref, VLLMProfileFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var profile VLLMProfile
+	if err := yaml.Unmarshal(data, &profile); err != nil || profile.TensorParallelSize != 1 {
+		t.Errorf("Unexpected profile %+v (%v)", profile, err)
+	}
+
+	catalogModel := convertExtractedToCatalogMetadata(types.ExtractedMetadata{Name: &name, VLLMProfilePath: profilePath}, nil)
+	if catalogModel.CustomProperties[VLLMProfileProperty].StringValue != profilePath {
+		t.Errorf("Expected the %s customProperty, got %+v", VLLMProfileProperty, catalogModel.CustomProperties)
+	}
+}
@@ -0,0 +1,40 @@
+package catalog
+
+import (
+	"log"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// excludeVulnerableModels drops models with an artifact whose scan found vulnerabilities of
+// threshold severity or higher; artifacts without scan results never exclude their model. An
+// empty threshold keeps every model.
+func excludeVulnerableModels(models []types.CatalogMetadata, threshold string) []types.CatalogMetadata {
+	if threshold == "" {
+		return models
+	}
+	var kept []types.CatalogMetadata
+	for _, model := range models {
+		if uri, count := vulnerableArtifact(model, threshold); count > 0 {
+			log.Printf("  Excluding model %s: %s has %d vulnerabilities of %s severity or higher", utils.StringValue(model.Name), uri, count, threshold)
+			continue
+		}
+		kept = append(kept, model)
+	}
+	return kept
+}
+
+// vulnerableArtifact returns the first artifact of a model with vulnerabilities of threshold
+// severity or higher, and their count
+func vulnerableArtifact(model types.CatalogMetadata, threshold string) (string, int64) {
+	for _, artifact := range model.Artifacts {
+		if artifact.Vulnerabilities == nil {
+			continue
+		}
+		if count := artifact.Vulnerabilities.CountAtOrAbove(threshold); count > 0 {
+			return artifact.URI, count
+		}
+	}
+	return "", 0
+}
@@ -0,0 +1,36 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestExcludeVulnerableModels(t *testing.T) {
+	models := []types.CatalogMetadata{
+		{Name: stringPtr("clean"), Artifacts: []types.CatalogOCIArtifact{{URI: "oci://quay.io/org/clean:1.0", Vulnerabilities: &types.VulnerabilitySummary{Medium: 3}}}},
+		{Name: stringPtr("vulnerable"), Artifacts: []types.CatalogOCIArtifact{
+			{URI: "oci://quay.io/org/vulnerable:2.0"},
+			{URI: "oci://quay.io/org/vulnerable:1.0", Vulnerabilities: &types.VulnerabilitySummary{Critical: 1}},
+		}},
+		{Name: stringPtr("unscanned"), Artifacts: []types.CatalogOCIArtifact{{URI: "oci://quay.io/org/unscanned:1.0"}}},
+	}
+
+	tests := map[string][]string{
+		"":                 {"clean", "vulnerable", "unscanned"},
+		types.SeverityHigh: {"clean", "unscanned"},
+		types.SeverityLow:  {"unscanned"},
+	}
+	for threshold, expected := range tests {
+		kept := excludeVulnerableModels(models, threshold)
+		if len(kept) != len(expected) {
+			t.Errorf("Threshold %q: expected %d models, got %d", threshold, len(expected), len(kept))
+			continue
+		}
+		for i, name := range expected {
+			if *kept[i].Name != name {
+				t.Errorf("Threshold %q: expected model %s at %d, got %s", threshold, name, i, *kept[i].Name)
+			}
+		}
+	}
+}
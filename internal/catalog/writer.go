@@ -0,0 +1,215 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	containertypes "github.com/containers/image/v5/types"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/embeddings"
+	"github.com/opendatahub-io/model-metadata-collection/internal/publish"
+	"github.com/opendatahub-io/model-metadata-collection/internal/search"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// CatalogWriter emits the validated models catalog to one destination. Catalog generation
+// builds the catalog once and hands it to each configured writer in order, so a new output only
+// needs a new writer.
+type CatalogWriter interface {
+	// Destination describes where the catalog is written, e.g. a file path or registry reference
+	Destination() string
+	// Write emits the catalog
+	Write(ctx context.Context, catalog *types.ModelsCatalog) error
+}
+
+// FileWriter writes the catalog to a file in the yaml, json or ndjson format
+type FileWriter struct {
+	Path   string
+	Format string
+}
+
+// NewFileWriter returns a writer for the catalog file at path in the given format
+func NewFileWriter(path, format string) *FileWriter {
+	return &FileWriter{Path: path, Format: format}
+}
+
+// Destination returns the catalog file path
+func (w *FileWriter) Destination() string {
+	return w.Path
+}
+
+// Write encodes the catalog and writes it to the file
+func (w *FileWriter) Write(_ context.Context, catalog *types.ModelsCatalog) error {
+	return WriteCatalog(catalog, w.Path, w.Format)
+}
+
+// ConfigMapWriter writes the catalog as Kubernetes ConfigMap manifests
+type ConfigMapWriter struct {
+	Options ConfigMapOptions
+}
+
+// NewConfigMapWriter returns a writer for the ConfigMap manifests described by opts
+func NewConfigMapWriter(opts ConfigMapOptions) *ConfigMapWriter {
+	return &ConfigMapWriter{Options: opts}
+}
+
+// Destination returns the manifest file path
+func (w *ConfigMapWriter) Destination() string {
+	return w.Options.Path
+}
+
+// Write writes the ConfigMap manifests
+func (w *ConfigMapWriter) Write(_ context.Context, catalog *types.ModelsCatalog) error {
+	return WriteConfigMaps(catalog, w.Options)
+}
+
+// OCIWriter pushes the catalog, and optionally the per-model readmes, to a registry as an OCI
+// artifact, like the publish subcommand does for an existing catalog file
+type OCIWriter struct {
+	// Reference is the destination, e.g. quay.io/org/model-catalog:latest or oci:/path/to/layout:tag
+	Reference string
+	// FileName is the title of the catalog layer; its extension selects the layer media type
+	FileName string
+	// Format is the encoding of the catalog layer
+	Format string
+	// ReadmesDir, when set, is the output directory whose modelcard.md readmes are pushed along
+	ReadmesDir string
+	// SystemContext carries registry credentials and TLS settings
+	SystemContext *containertypes.SystemContext
+}
+
+// NewOCIWriter returns a writer pushing the catalog, encoded in format and titled fileName, to
+// reference
+func NewOCIWriter(reference, fileName, format string) *OCIWriter {
+	return &OCIWriter{Reference: reference, FileName: fileName, Format: format}
+}
+
+// Destination returns the artifact reference
+func (w *OCIWriter) Destination() string {
+	return w.Reference
+}
+
+// Write encodes the catalog into a staging file and pushes it as the catalog artifact
+func (w *OCIWriter) Write(ctx context.Context, catalog *types.ModelsCatalog) error {
+	stagingDir, err := os.MkdirTemp("", "catalog-push-")
+	if err != nil {
+		return fmt.Errorf("error creating staging directory: %v", err)
+	}
+	defer func() { _ = os.RemoveAll(stagingDir) }()
+
+	catalogPath := filepath.Join(stagingDir, w.FileName)
+	if err := WriteCatalog(catalog, catalogPath, w.Format); err != nil {
+		return err
+	}
+	files, err := publish.CollectFiles(catalogPath, w.ReadmesDir, w.ReadmesDir != "")
+	if err != nil {
+		return err
+	}
+
+	if _, err := publish.Push(ctx, files, publish.Options{
+		Destination: w.Reference,
+		Annotations: map[string]string{
+			imgspecv1.AnnotationCreated: time.Now().UTC().Format(time.RFC3339),
+		},
+		SystemContext: w.SystemContext,
+	}); err != nil {
+		return fmt.Errorf("error pushing catalog to %s: %v", w.Reference, err)
+	}
+	return nil
+}
+
+// KServeWriter writes KServe ServingRuntime and InferenceService manifests for every catalog model
+type KServeWriter struct {
+	Options KServeOptions
+}
+
+// NewKServeWriter returns a writer for the KServe manifests described by opts
+func NewKServeWriter(opts KServeOptions) *KServeWriter {
+	return &KServeWriter{Options: opts}
+}
+
+// Destination returns the manifest directory
+func (w *KServeWriter) Destination() string {
+	return w.Options.Dir
+}
+
+// Write writes the KServe manifests
+func (w *KServeWriter) Write(_ context.Context, catalog *types.ModelsCatalog) error {
+	return WriteKServeManifests(catalog, w.Options)
+}
+
+// CatalogSourceWriter writes the catalog as ConfigMaps and a ModelCatalogSource custom resource
+type CatalogSourceWriter struct {
+	Options CatalogSourceOptions
+}
+
+// NewCatalogSourceWriter returns a writer for the ModelCatalogSource described by opts
+func NewCatalogSourceWriter(opts CatalogSourceOptions) *CatalogSourceWriter {
+	return &CatalogSourceWriter{Options: opts}
+}
+
+// Destination returns the manifest file path
+func (w *CatalogSourceWriter) Destination() string {
+	return w.Options.Path
+}
+
+// Write writes the ConfigMaps and the ModelCatalogSource
+func (w *CatalogSourceWriter) Write(_ context.Context, catalog *types.ModelsCatalog) error {
+	return WriteCatalogSource(catalog, w.Options)
+}
+
+// EmbeddingsWriter writes embeddings of the catalog models next to the catalog for semantic search
+type EmbeddingsWriter struct {
+	// Path is the embeddings JSON file; its previous content supplies the embeddings of unchanged
+	// models
+	Path   string
+	Client *embeddings.Client
+}
+
+// NewEmbeddingsWriter returns a writer of the embeddings computed by client to path
+func NewEmbeddingsWriter(path string, client *embeddings.Client) *EmbeddingsWriter {
+	return &EmbeddingsWriter{Path: path, Client: client}
+}
+
+// Destination returns the embeddings file path
+func (w *EmbeddingsWriter) Destination() string {
+	return w.Path
+}
+
+// Write computes the embeddings of the models that changed since the previous file and writes them
+func (w *EmbeddingsWriter) Write(ctx context.Context, catalog *types.ModelsCatalog) error {
+	previous, err := embeddings.ReadIndex(w.Path)
+	if err != nil {
+		log.Printf("  Warning: recomputing all embeddings: %v", err)
+	}
+	index, err := embeddings.Compute(ctx, w.Client, catalog, previous)
+	if err != nil {
+		return fmt.Errorf("error computing embeddings with %s: %v", w.Client.Model, err)
+	}
+	return embeddings.WriteIndex(w.Path, index)
+}
+
+// SearchIndexWriter writes a full-text search index of the catalog models for catalog UIs
+type SearchIndexWriter struct {
+	Path string
+}
+
+// NewSearchIndexWriter returns a writer of the search index file at path
+func NewSearchIndexWriter(path string) *SearchIndexWriter {
+	return &SearchIndexWriter{Path: path}
+}
+
+// Destination returns the search index file path
+func (w *SearchIndexWriter) Destination() string {
+	return w.Path
+}
+
+// Write indexes the catalog and writes the index
+func (w *SearchIndexWriter) Write(_ context.Context, catalog *types.ModelsCatalog) error {
+	return search.WriteIndex(w.Path, search.Build(catalog))
+}
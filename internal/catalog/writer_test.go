@@ -0,0 +1,85 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/publish"
+)
+
+func TestCatalogWriters(t *testing.T) {
+	tmpDir := t.TempDir()
+	catalogPath := filepath.Join(tmpDir, "models-catalog.json")
+	configMapPath := filepath.Join(tmpDir, "configmap.yaml")
+
+	writers := []CatalogWriter{
+		NewFileWriter(catalogPath, CatalogFormatJSON),
+		NewConfigMapWriter(ConfigMapOptions{Path: configMapPath, Name: "model-catalog"}),
+	}
+	for _, writer := range writers {
+		if err := writer.Write(context.Background(), sampleCatalog()); err != nil {
+			t.Fatalf("Writing to %s failed: %v", writer.Destination(), err)
+		}
+	}
+
+	written, err := ReadCatalog(catalogPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(written.Models) != 2 {
+		t.Errorf("Expected 2 models in %s, got %d", catalogPath, len(written.Models))
+	}
+	manifests, err := os.ReadFile(configMapPath)
+	if err != nil || !strings.Contains(string(manifests), "kind: ConfigMap") {
+		t.Errorf("Expected ConfigMap manifests, got %q (err %v)", manifests, err)
+	}
+}
+
+func TestOCIWriter(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputDir := filepath.Join(tmpDir, "output")
+	readme := filepath.Join(outputDir, "model-a", "models", "modelcard.md")
+	if err := os.MkdirAll(filepath.Dir(readme), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(readme, []byte("# A"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	layoutDir := filepath.Join(tmpDir, "layout")
+	writer := NewOCIWriter("oci:"+layoutDir+":v1", "models-catalog.yaml", CatalogFormatYAML)
+	writer.ReadmesDir = outputDir
+	if err := writer.Write(context.Background(), sampleCatalog()); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	var index imgspecv1.Index
+	data, err := os.ReadFile(filepath.Join(layoutDir, "index.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &index); err != nil || len(index.Manifests) != 1 {
+		t.Fatalf("Expected one manifest in the layout, got %s (err %v)", data, err)
+	}
+	manifestDigest := index.Manifests[0].Digest
+	data, err = os.ReadFile(filepath.Join(layoutDir, "blobs", manifestDigest.Algorithm().String(), manifestDigest.Encoded()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifest imgspecv1.Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatal(err)
+	}
+	if len(manifest.Layers) != 2 {
+		t.Fatalf("Expected the catalog and readme layers, got %d", len(manifest.Layers))
+	}
+	if manifest.Layers[0].MediaType != publish.MediaTypeCatalogYAML || manifest.Layers[0].Annotations[imgspecv1.AnnotationTitle] != "models-catalog.yaml" {
+		t.Errorf("Unexpected catalog layer: %+v", manifest.Layers[0])
+	}
+}
@@ -0,0 +1,158 @@
+package checkpoint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the checkpoint written to the output directory
+const FileName = "checkpoint.yaml"
+
+// Pipeline stages a model completes, in the order they run
+const (
+	StageExtraction = "extraction"
+	StageEnrichment = "enrichment"
+	StageArtifacts  = "artifacts"
+)
+
+var stageOrder = []string{StageExtraction, StageEnrichment, StageArtifacts}
+
+// Checkpoint records which pipeline stages each model has completed so an interrupted run can be
+// resumed. It is saved after every completed stage and is safe for concurrent use. A nil
+// Checkpoint reports no completed stages and records nothing.
+type Checkpoint struct {
+	path string
+
+	mu     sync.Mutex
+	models map[string][]string
+}
+
+// checkpointFile is the on-disk layout of the checkpoint
+type checkpointFile struct {
+	Models map[string][]string `yaml:"models"`
+}
+
+// New starts an empty checkpoint in outputDir, replacing the checkpoint of a previous run
+func New(outputDir string) (*Checkpoint, error) {
+	cp := &Checkpoint{path: filepath.Join(outputDir, FileName), models: make(map[string][]string)}
+	if err := cp.save(); err != nil {
+		return nil, err
+	}
+	return cp, nil
+}
+
+// Load reads the checkpoint of a previous run from outputDir. Without a checkpoint file, no model
+// has completed any stage.
+func Load(outputDir string) (*Checkpoint, error) {
+	cp := &Checkpoint{path: filepath.Join(outputDir, FileName), models: make(map[string][]string)}
+	data, err := os.ReadFile(cp.path)
+	if os.IsNotExist(err) {
+		return cp, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading checkpoint: %v", err)
+	}
+
+	var file checkpointFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("error parsing checkpoint %s: %v", cp.path, err)
+	}
+	for ref, stages := range file.Models {
+		cp.models[ref] = stages
+	}
+	return cp, nil
+}
+
+// Open loads the previous run's checkpoint when resuming and starts a new one otherwise
+func Open(outputDir string, resume bool) (*Checkpoint, error) {
+	if resume {
+		return Load(outputDir)
+	}
+	return New(outputDir)
+}
+
+// Done reports whether the model has completed the stage
+func (c *Checkpoint) Done(ref, stage string) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Contains(c.models[ref], stage)
+}
+
+// MarkDone records that the model completed the stage and saves the checkpoint. The stages after
+// it are cleared, since redoing a stage rewrites the output the later stages worked on.
+func (c *Checkpoint) MarkDone(ref, stage string) error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	position := slices.Index(stageOrder, stage)
+	if position < 0 {
+		return fmt.Errorf("unknown checkpoint stage %q", stage)
+	}
+	var stages []string
+	for _, done := range stageOrder[:position] {
+		if slices.Contains(c.models[ref], done) {
+			stages = append(stages, done)
+		}
+	}
+	c.models[ref] = append(stages, stage)
+	return c.save()
+}
+
+// MarkComplete records that the model completed every stage, so a resumed run keeps its output
+// as it is, and saves the checkpoint
+func (c *Checkpoint) MarkComplete(ref string) error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.models[ref] = slices.Clone(stageOrder)
+	return c.save()
+}
+
+// save writes the checkpoint; the caller holds c.mu unless c is not shared yet
+func (c *Checkpoint) save() error {
+	file := checkpointFile{Models: c.models}
+	data, err := yaml.Marshal(&file)
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %v", err)
+	}
+	// Write to a temporary file first so an interrupted write never leaves a truncated checkpoint
+	tmpPath := c.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %v", err)
+	}
+	if err := os.Rename(tmpPath, c.path); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %v", err)
+	}
+	return nil
+}
+
+// Completed returns the models that completed the stage, sorted
+func (c *Checkpoint) Completed(stage string) []string {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var refs []string
+	for ref, stages := range c.models {
+		if slices.Contains(stages, stage) {
+			refs = append(refs, ref)
+		}
+	}
+	sort.Strings(refs)
+	return refs
+}
@@ -0,0 +1,90 @@
+package checkpoint
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCheckpointResume(t *testing.T) {
+	dir := t.TempDir()
+	cp, err := New(dir)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if err := cp.MarkDone("registry.example.com/a:1", StageExtraction); err != nil {
+		t.Fatalf("MarkDone failed: %v", err)
+	}
+	if err := cp.MarkDone("registry.example.com/a:1", StageEnrichment); err != nil {
+		t.Fatalf("MarkDone failed: %v", err)
+	}
+	if err := cp.MarkDone("registry.example.com/b:1", StageExtraction); err != nil {
+		t.Fatalf("MarkDone failed: %v", err)
+	}
+
+	resumed, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !resumed.Done("registry.example.com/a:1", StageEnrichment) {
+		t.Error("model a should be enriched")
+	}
+	if resumed.Done("registry.example.com/b:1", StageEnrichment) {
+		t.Error("model b should not be enriched")
+	}
+	want := []string{"registry.example.com/a:1", "registry.example.com/b:1"}
+	if got := resumed.Completed(StageExtraction); !reflect.DeepEqual(got, want) {
+		t.Errorf("Completed(extraction) = %v, want %v", got, want)
+	}
+
+	// Extracting a model again invalidates its enrichment
+	if err := resumed.MarkDone("registry.example.com/a:1", StageExtraction); err != nil {
+		t.Fatalf("MarkDone failed: %v", err)
+	}
+	if resumed.Done("registry.example.com/a:1", StageEnrichment) {
+		t.Error("re-extraction should clear enrichment")
+	}
+
+	if err := resumed.MarkComplete("registry.example.com/b:1"); err != nil {
+		t.Fatalf("MarkComplete failed: %v", err)
+	}
+	if !resumed.Done("registry.example.com/b:1", StageArtifacts) {
+		t.Error("model b should have completed every stage")
+	}
+
+	// A new run replaces the previous checkpoint
+	if _, err := New(dir); err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	fresh, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(fresh.Completed(StageExtraction)) != 0 {
+		t.Errorf("new checkpoint should be empty, got %v", fresh.Completed(StageExtraction))
+	}
+}
+
+func TestCheckpointErrors(t *testing.T) {
+	dir := t.TempDir()
+	cp, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load without a checkpoint failed: %v", err)
+	}
+	if err := cp.MarkDone("model", "upload"); err == nil {
+		t.Error("expected an error for an unknown stage")
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte("models: [\n"), 0644); err != nil {
+		t.Fatalf("failed to write checkpoint: %v", err)
+	}
+	if _, err := Load(dir); err == nil {
+		t.Error("expected an error for a malformed checkpoint")
+	}
+
+	var none *Checkpoint
+	if none.Done("model", StageExtraction) || none.MarkDone("model", StageExtraction) != nil {
+		t.Error("a nil checkpoint should record nothing")
+	}
+}
@@ -45,6 +45,12 @@ func LoadModelsConfigFromYAML(filePath string) ([]types.ModelEntry, error) {
 		return nil, err
 	}
 
+	for _, model := range config.Models {
+		if err := types.ValidateLifecycle(model.EndOfLife, model.ReplacedBy); err != nil {
+			return nil, fmt.Errorf("model %s: %v", model.URI, err)
+		}
+	}
+
 	return config.Models, nil
 }
 
@@ -287,3 +287,44 @@ func TestModelNameConversion(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadModelsConfigFromYAML_Lifecycle(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	validPath := filepath.Join(tmpDir, "valid.yaml")
+	valid := `models:
+  - type: "oci"
+    uri: "registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5"
+    deprecated: true
+    endOfLife: "2026-06-30"
+    replacedBy: "RedHatAI/granite-3.3-8b-instruct"
+  - type: "oci"
+    uri: "registry.redhat.io/rhai/modelcar-granite-3-3-8b-instruct:3.0"`
+	if err := os.WriteFile(validPath, []byte(valid), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	entries, err := LoadModelsConfigFromYAML(validPath)
+	if err != nil {
+		t.Fatalf("LoadModelsConfigFromYAML failed: %v", err)
+	}
+	if !entries[0].Deprecated || entries[0].EndOfLife == nil || *entries[0].EndOfLife != "2026-06-30" ||
+		entries[0].ReplacedBy == nil || *entries[0].ReplacedBy != "RedHatAI/granite-3.3-8b-instruct" {
+		t.Errorf("Expected lifecycle fields to be loaded, got %+v", entries[0])
+	}
+	if entries[1].Deprecated || entries[1].EndOfLife != nil || entries[1].ReplacedBy != nil {
+		t.Errorf("Expected no lifecycle fields on second entry, got %+v", entries[1])
+	}
+
+	invalidPath := filepath.Join(tmpDir, "invalid.yaml")
+	invalid := `models:
+  - type: "oci"
+    uri: "registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5"
+    endOfLife: "June 2026"`
+	if err := os.WriteFile(invalidPath, []byte(invalid), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+	if _, err := LoadModelsConfigFromYAML(invalidPath); err == nil {
+		t.Error("Expected error for invalid endOfLife date")
+	}
+}
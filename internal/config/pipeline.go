@@ -0,0 +1,147 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// credentialsKey holds environment variables to set from references instead of pipeline options
+const credentialsKey = "credentials"
+
+// PipelineConfig is a pipeline configuration file: every command line option of the extractor by
+// flag name, plus references to the credentials the pipeline needs. Lists are accepted for
+// comma-separated options such as static-catalog-files.
+//
+//	output-dir: output
+//	skip-enrichment: true
+//	static-catalog-files:
+//	  - input/supplemental-catalog.yaml
+//	credentials:
+//	  HF_TOKEN: file:/run/secrets/hf-token
+//	  GITHUB_TOKEN: env:CI_GITHUB_TOKEN
+type PipelineConfig struct {
+	// Options maps flag names to their values as they would be written on the command line
+	Options map[string]string
+	// Credentials maps environment variables to env:NAME or file:PATH references to their values
+	Credentials map[string]string
+}
+
+// LoadPipelineConfig reads a pipeline configuration file
+func LoadPipelineConfig(path string) (*PipelineConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading pipeline config: %v", err)
+	}
+
+	var raw map[string]yaml.Node
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("error parsing pipeline config %s: %v", path, err)
+	}
+
+	cfg := &PipelineConfig{Options: make(map[string]string), Credentials: make(map[string]string)}
+	for key, node := range raw {
+		if key == credentialsKey {
+			if err := node.Decode(&cfg.Credentials); err != nil {
+				return nil, fmt.Errorf("invalid %s in %s: %v", credentialsKey, path, err)
+			}
+			continue
+		}
+		value, err := optionValue(&node)
+		if err != nil {
+			return nil, fmt.Errorf("invalid option %s in %s: %v", key, path, err)
+		}
+		cfg.Options[key] = value
+	}
+	return cfg, nil
+}
+
+// optionValue renders a YAML scalar or list of scalars as a command line flag value
+func optionValue(node *yaml.Node) (string, error) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return node.Value, nil
+	case yaml.SequenceNode:
+		values := make([]string, 0, len(node.Content))
+		for _, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				return "", fmt.Errorf("list items must be scalars")
+			}
+			values = append(values, item.Value)
+		}
+		return strings.Join(values, ","), nil
+	default:
+		return "", fmt.Errorf("expected a value or a list of values")
+	}
+}
+
+// Apply sets the flags of fs from the configuration. Flags in explicit were given on the command
+// line and keep their values. Unknown options are an error, so typos do not go unnoticed.
+func (c *PipelineConfig) Apply(fs *flag.FlagSet, explicit map[string]bool) error {
+	names := make([]string, 0, len(c.Options))
+	for name := range c.Options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if fs.Lookup(name) == nil || name == "config" {
+			return fmt.Errorf("unknown option %q", name)
+		}
+		if explicit[name] {
+			continue
+		}
+		if err := fs.Set(name, c.Options[name]); err != nil {
+			return fmt.Errorf("invalid value for %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// ResolveCredentials sets each credential environment variable that is not already set from its
+// reference: env:NAME copies another environment variable and file:PATH reads a file such as a
+// mounted secret. The configuration file itself never holds credential values.
+func (c *PipelineConfig) ResolveCredentials() error {
+	names := make([]string, 0, len(c.Credentials))
+	for name := range c.Credentials {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if os.Getenv(name) != "" {
+			continue
+		}
+		value, err := resolveCredential(c.Credentials[name])
+		if err != nil {
+			return fmt.Errorf("credential %s: %v", name, err)
+		}
+		if value != "" {
+			_ = os.Setenv(name, value)
+		}
+	}
+	return nil
+}
+
+func resolveCredential(reference string) (string, error) {
+	kind, target, found := strings.Cut(reference, ":")
+	if !found || target == "" {
+		return "", fmt.Errorf("reference %q must be env:NAME or file:PATH", reference)
+	}
+	switch kind {
+	case "env":
+		return os.Getenv(target), nil
+	case "file":
+		data, err := os.ReadFile(target)
+		if err != nil {
+			return "", fmt.Errorf("error reading %s: %v", target, err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	default:
+		return "", fmt.Errorf("reference %q must be env:NAME or file:PATH", reference)
+	}
+}
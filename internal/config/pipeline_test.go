@@ -0,0 +1,129 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writePipelineConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "pipeline.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write pipeline config: %v", err)
+	}
+	return path
+}
+
+func TestPipelineConfigApply(t *testing.T) {
+	path := writePipelineConfig(t, `output-dir: /tmp/out
+max-concurrent: 10
+skip-enrichment: true
+static-catalog-files:
+  - a.yaml
+  - b.yaml
+credentials:
+  HF_TOKEN: env:CI_HF_TOKEN
+`)
+	cfg, err := LoadPipelineConfig(path)
+	if err != nil {
+		t.Fatalf("LoadPipelineConfig failed: %v", err)
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	outputDir := fs.String("output-dir", "output", "")
+	maxConcurrent := fs.Int("max-concurrent", 5, "")
+	skipEnrichment := fs.Bool("skip-enrichment", false, "")
+	staticFiles := fs.String("static-catalog-files", "", "")
+	if err := fs.Parse([]string{"--max-concurrent", "2"}); err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if err := cfg.Apply(fs, map[string]bool{"max-concurrent": true}); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if *outputDir != "/tmp/out" {
+		t.Errorf("output-dir = %q, want /tmp/out", *outputDir)
+	}
+	if *maxConcurrent != 2 {
+		t.Errorf("max-concurrent = %d, want the command line value 2", *maxConcurrent)
+	}
+	if !*skipEnrichment {
+		t.Error("skip-enrichment should be set from the config")
+	}
+	if *staticFiles != "a.yaml,b.yaml" {
+		t.Errorf("static-catalog-files = %q, want a.yaml,b.yaml", *staticFiles)
+	}
+	if cfg.Credentials["HF_TOKEN"] != "env:CI_HF_TOKEN" {
+		t.Errorf("credentials = %v, want the HF_TOKEN reference", cfg.Credentials)
+	}
+}
+
+func TestPipelineConfigApplyErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "unknown option", content: "outputdir: out\n"},
+		{name: "config option", content: "config: other.yaml\n"},
+		{name: "invalid value", content: "max-concurrent: many\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := LoadPipelineConfig(writePipelineConfig(t, tt.content))
+			if err != nil {
+				t.Fatalf("LoadPipelineConfig failed: %v", err)
+			}
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.String("output-dir", "output", "")
+			fs.String("config", "", "")
+			fs.Int("max-concurrent", 5, "")
+			if err := cfg.Apply(fs, nil); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestLoadPipelineConfigRejectsNestedOptions(t *testing.T) {
+	if _, err := LoadPipelineConfig(writePipelineConfig(t, "output-dir:\n  path: out\n")); err == nil {
+		t.Error("expected an error for a mapping value")
+	}
+}
+
+func TestResolveCredentials(t *testing.T) {
+	secret := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(secret, []byte("file-token\n"), 0600); err != nil {
+		t.Fatalf("failed to write secret: %v", err)
+	}
+	t.Setenv("CI_TOKEN", "env-token")
+	t.Setenv("PIPELINE_TEST_FROM_ENV", "")
+	t.Setenv("PIPELINE_TEST_FROM_FILE", "")
+	t.Setenv("PIPELINE_TEST_PRESET", "preset")
+	t.Setenv("PIPELINE_TEST_UNSUPPORTED", "")
+
+	cfg := &PipelineConfig{Credentials: map[string]string{
+		"PIPELINE_TEST_FROM_ENV":  "env:CI_TOKEN",
+		"PIPELINE_TEST_FROM_FILE": "file:" + secret,
+		"PIPELINE_TEST_PRESET":    "env:CI_TOKEN",
+	}}
+	if err := cfg.ResolveCredentials(); err != nil {
+		t.Fatalf("ResolveCredentials failed: %v", err)
+	}
+
+	for name, want := range map[string]string{
+		"PIPELINE_TEST_FROM_ENV":  "env-token",
+		"PIPELINE_TEST_FROM_FILE": "file-token",
+		"PIPELINE_TEST_PRESET":    "preset",
+	} {
+		if got := os.Getenv(name); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+
+	bad := &PipelineConfig{Credentials: map[string]string{"PIPELINE_TEST_UNSUPPORTED": "vault:token"}}
+	if err := bad.ResolveCredentials(); err == nil {
+		t.Error("expected an error for an unsupported reference")
+	}
+}
@@ -0,0 +1,256 @@
+// Package embeddings computes vector embeddings of catalog models with an OpenAI-compatible
+// embeddings endpoint, such as those of OpenAI, vLLM, Ollama or Text Embeddings Inference, so
+// catalog UIs can offer semantic search over models.
+package embeddings
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/httpclient"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// DefaultBatchSize is the number of texts embedded per request unless Client.BatchSize is set
+const DefaultBatchSize = 32
+
+// MaxTextLength caps the characters of a model's text that are embedded; embedding models only
+// read their first few thousand tokens, and the name, description and start of the readme carry
+// what users search for
+const MaxTextLength = 8000
+
+// requestTimeout bounds a single embeddings request
+const requestTimeout = 2 * time.Minute
+
+// Client requests embeddings from an OpenAI-compatible /embeddings endpoint
+type Client struct {
+	// URL is the endpoint, e.g. https://api.openai.com/v1/embeddings, or the base URL of the API,
+	// e.g. http://localhost:11434/v1, that "/embeddings" is appended to
+	URL string
+	// Model is the embedding model, e.g. text-embedding-3-small or nomic-embed-text
+	Model string
+	// APIKey is sent as a bearer token when set
+	APIKey string
+	// BatchSize is the number of texts embedded per request (default DefaultBatchSize)
+	BatchSize int
+}
+
+// NewClient returns a client of the embeddings endpoint at url using model
+func NewClient(url, model, apiKey string) *Client {
+	return &Client{URL: url, Model: model, APIKey: apiKey}
+}
+
+// endpoint returns the URL embeddings requests are posted to
+func (c *Client) endpoint() string {
+	url := strings.TrimRight(c.URL, "/")
+	if strings.HasSuffix(url, "/embeddings") {
+		return url
+	}
+	return url + "/embeddings"
+}
+
+type embeddingsRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type embeddingsResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+// Embed returns the embeddings of texts, in order, batching them into requests of BatchSize texts
+func (c *Client) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	batchSize := c.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	vectors := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += batchSize {
+		end := min(start+batchSize, len(texts))
+		batch, err := c.embedBatch(ctx, texts[start:end])
+		if err != nil {
+			return nil, err
+		}
+		vectors = append(vectors, batch...)
+	}
+	return vectors, nil
+}
+
+// embedBatch requests the embeddings of texts in a single request
+func (c *Client) embedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	body, err := json.Marshal(embeddingsRequest{Model: c.Model, Input: texts})
+	if err != nil {
+		return nil, fmt.Errorf("error encoding embeddings request: %v", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("invalid embeddings URL: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.APIKey)
+	}
+
+	resp, err := httpclient.New("embeddings", requestTimeout).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting embeddings: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("embeddings endpoint returned %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+
+	var parsed embeddingsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("error decoding embeddings response: %v", err)
+	}
+	if len(parsed.Data) != len(texts) {
+		return nil, fmt.Errorf("embeddings endpoint returned %d embeddings for %d texts", len(parsed.Data), len(texts))
+	}
+	vectors := make([][]float32, len(texts))
+	for _, item := range parsed.Data {
+		if item.Index < 0 || item.Index >= len(texts) || vectors[item.Index] != nil {
+			return nil, fmt.Errorf("embeddings endpoint returned an unexpected index %d", item.Index)
+		}
+		vectors[item.Index] = item.Embedding
+	}
+	return vectors, nil
+}
+
+// Index holds the embeddings of the models of a catalog
+type Index struct {
+	// Model is the embedding model the vectors were computed with; search queries must be
+	// embedded with the same model
+	Model string `json:"model"`
+	// Dimensions is the length of every embedding
+	Dimensions int     `json:"dimensions"`
+	Models     []Entry `json:"models"`
+}
+
+// Entry is the embedding of one catalog model
+type Entry struct {
+	// Name is the catalog name of the model
+	Name string `json:"name"`
+	// TextSHA256 is the hex SHA-256 of the embedded text, so unchanged models keep their
+	// embedding on the next run
+	TextSHA256 string    `json:"text_sha256"`
+	Embedding  []float32 `json:"embedding"`
+}
+
+// Text returns the text embedded for a model: its name, description and readme, capped at
+// MaxTextLength characters
+func Text(model types.CatalogMetadata) string {
+	var parts []string
+	for _, value := range []*string{model.Name, model.Description, model.Readme} {
+		if value != nil && strings.TrimSpace(*value) != "" {
+			parts = append(parts, strings.TrimSpace(*value))
+		}
+	}
+	text := strings.Join(parts, "\n\n")
+	if runes := []rune(text); len(runes) > MaxTextLength {
+		text = string(runes[:MaxTextLength])
+	}
+	return text
+}
+
+func textHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// Compute returns the embeddings of the named models of a catalog. Embeddings in previous that
+// were computed with the same model from the same text are reused; the others are requested
+// from the client.
+func Compute(ctx context.Context, client *Client, catalog *types.ModelsCatalog, previous *Index) (*Index, error) {
+	reusable := make(map[string][]float32)
+	if previous != nil && previous.Model == client.Model {
+		for _, entry := range previous.Models {
+			reusable[entry.Name+"\x00"+entry.TextSHA256] = entry.Embedding
+		}
+	}
+
+	index := &Index{Model: client.Model, Models: []Entry{}}
+	var pending []int
+	var texts []string
+	for _, model := range catalog.Models {
+		if model.Name == nil || *model.Name == "" {
+			continue
+		}
+		text := Text(model)
+		entry := Entry{Name: *model.Name, TextSHA256: textHash(text)}
+		if embedding, ok := reusable[entry.Name+"\x00"+entry.TextSHA256]; ok {
+			entry.Embedding = embedding
+		} else {
+			pending = append(pending, len(index.Models))
+			texts = append(texts, text)
+		}
+		index.Models = append(index.Models, entry)
+	}
+
+	if len(texts) > 0 {
+		vectors, err := client.Embed(ctx, texts)
+		if err != nil {
+			return nil, err
+		}
+		for i, position := range pending {
+			index.Models[position].Embedding = vectors[i]
+		}
+	}
+
+	for _, entry := range index.Models {
+		if index.Dimensions == 0 {
+			index.Dimensions = len(entry.Embedding)
+		}
+		if len(entry.Embedding) != index.Dimensions {
+			return nil, fmt.Errorf("embedding of %s has %d dimensions, expected %d", entry.Name, len(entry.Embedding), index.Dimensions)
+		}
+	}
+	return index, nil
+}
+
+// ReadIndex reads an embeddings file; a missing file yields a nil index
+func ReadIndex(path string) (*Index, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading embeddings: %v", err)
+	}
+	var index Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("error parsing embeddings %s: %v", path, err)
+	}
+	return &index, nil
+}
+
+// WriteIndex writes an embeddings file as JSON
+func WriteIndex(path string, index *Index) error {
+	data, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("error encoding embeddings: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating embeddings directory: %v", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("error writing embeddings: %v", err)
+	}
+	return nil
+}
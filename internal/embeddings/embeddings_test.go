@@ -0,0 +1,152 @@
+package embeddings
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func stringPtr(s string) *string { return &s }
+
+// embeddingServer answers embeddings requests with the length of each input as its vector,
+// recording the inputs of every request
+func embeddingServer(t *testing.T, requests *[][]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/embeddings" || r.Header.Get("Authorization") != "Bearer test-key" {
+			t.Errorf("Unexpected request %s with Authorization %q", r.URL.Path, r.Header.Get("Authorization"))
+		}
+		var req embeddingsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Model == "" {
+			t.Errorf("Unexpected request body %+v: %v", req, err)
+		}
+		*requests = append(*requests, req.Input)
+
+		var resp embeddingsResponse
+		resp.Data = make([]struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		}, len(req.Input))
+		// Answer in reverse order; clients must place embeddings by index
+		for i := range req.Input {
+			j := len(req.Input) - 1 - i
+			resp.Data[i].Index = j
+			resp.Data[i].Embedding = []float32{float32(len(req.Input[j])), 1}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClientEmbed_Batches(t *testing.T) {
+	var requests [][]string
+	server := embeddingServer(t, &requests)
+
+	client := NewClient(server.URL+"/v1/", "nomic-embed-text", "test-key")
+	client.BatchSize = 2
+	vectors, err := client.Embed(context.Background(), []string{"a", "bb", "ccc"})
+	if err != nil {
+		t.Fatalf("Embed() error = %v", err)
+	}
+	if len(requests) != 2 || len(requests[0]) != 2 || len(requests[1]) != 1 {
+		t.Errorf("requests = %v, want batches of 2 and 1", requests)
+	}
+	for i, want := range []float32{1, 2, 3} {
+		if vectors[i][0] != want {
+			t.Errorf("vector %d = %v, want it to embed input %d", i, vectors[i], i)
+		}
+	}
+}
+
+func TestClientEmbed_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": "model not found"}`, http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := NewClient(server.URL+"/v1/embeddings", "missing", "").Embed(context.Background(), []string{"a"})
+	if err == nil || !strings.Contains(err.Error(), "model not found") {
+		t.Errorf("Expected the endpoint's error, got %v", err)
+	}
+}
+
+func TestText(t *testing.T) {
+	model := types.CatalogMetadata{
+		Name:        stringPtr("RedHatAI/granite-3.1-8b-instruct"),
+		Description: stringPtr(" An instruct model. "),
+		Readme:      stringPtr(strings.Repeat("é", MaxTextLength)),
+	}
+	text := Text(model)
+	if !strings.HasPrefix(text, "RedHatAI/granite-3.1-8b-instruct\n\nAn instruct model.\n\né") {
+		t.Errorf("Text() starts with %q", text[:60])
+	}
+	if n := len([]rune(text)); n != MaxTextLength {
+		t.Errorf("Text() has %d characters, want %d", n, MaxTextLength)
+	}
+}
+
+func TestCompute_ReusesUnchangedEmbeddings(t *testing.T) {
+	var requests [][]string
+	server := embeddingServer(t, &requests)
+	client := NewClient(server.URL+"/v1", "nomic-embed-text", "test-key")
+
+	catalog := &types.ModelsCatalog{Models: []types.CatalogMetadata{
+		{Name: stringPtr("granite"), Description: stringPtr("A granite model")},
+		{Description: stringPtr("A model without a name")},
+		{Name: stringPtr("llama"), Description: stringPtr("A llama model")},
+	}}
+	index, err := Compute(context.Background(), client, catalog, nil)
+	if err != nil {
+		t.Fatalf("Compute() error = %v", err)
+	}
+	if len(index.Models) != 2 || index.Dimensions != 2 || index.Model != "nomic-embed-text" {
+		t.Fatalf("Compute() = %+v", index)
+	}
+
+	path := filepath.Join(t.TempDir(), "embeddings.json")
+	if err := WriteIndex(path, index); err != nil {
+		t.Fatal(err)
+	}
+	previous, err := ReadIndex(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the changed model is embedded again
+	requests = nil
+	catalog.Models[2].Description = stringPtr("A llama model with a longer description")
+	index, err = Compute(context.Background(), client, catalog, previous)
+	if err != nil {
+		t.Fatalf("Compute() error = %v", err)
+	}
+	if len(requests) != 1 || len(requests[0]) != 1 || !strings.HasPrefix(requests[0][0], "llama") {
+		t.Errorf("requests = %v, want only the changed model", requests)
+	}
+	if index.Models[0].Embedding[0] != previous.Models[0].Embedding[0] || index.Models[1].Embedding[0] != float32(len(Text(catalog.Models[2]))) {
+		t.Errorf("Compute() = %+v", index.Models)
+	}
+
+	// Embeddings of another model are never reused
+	requests = nil
+	client.Model = "text-embedding-3-small"
+	if _, err := Compute(context.Background(), client, catalog, previous); err != nil {
+		t.Fatalf("Compute() error = %v", err)
+	}
+	if len(requests) != 1 || len(requests[0]) != 2 {
+		t.Errorf("requests = %v, want every model embedded with the new model", requests)
+	}
+}
+
+func TestReadIndex_Missing(t *testing.T) {
+	index, err := ReadIndex(filepath.Join(t.TempDir(), "missing.json"))
+	if index != nil || err != nil {
+		t.Errorf("ReadIndex() = %v, %v, want nil, nil", index, err)
+	}
+}
@@ -1,16 +1,23 @@
 package enrichment
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"slices"
 	"strings"
+	"sync"
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/opendatahub-io/model-metadata-collection/internal/checkpoint"
 	"github.com/opendatahub-io/model-metadata-collection/internal/config"
+	"github.com/opendatahub-io/model-metadata-collection/internal/errorreport"
 	"github.com/opendatahub-io/model-metadata-collection/internal/huggingface"
 	"github.com/opendatahub-io/model-metadata-collection/internal/metadata"
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+	"github.com/opendatahub-io/model-metadata-collection/internal/pyxis"
 	"github.com/opendatahub-io/model-metadata-collection/internal/registry"
 	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
 	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
@@ -51,8 +58,66 @@ func extractModelFamily(normalizedName string) string {
 	return ""
 }
 
-// EnrichMetadataFromHuggingFace enriches registry model metadata using HuggingFace data
-func EnrichMetadataFromHuggingFace(hfIndexPath, modelsIndexPath, outputDir, vllmConfigDir string) error {
+// DefaultMaxConcurrent is the default number of models enriched concurrently
+const DefaultMaxConcurrent = 5
+
+// Options configures an enrichment run
+type Options struct {
+	// Output holds the extracted metadata that is enriched in place
+	Output outputfs.FS
+	// VLLMConfigDir holds supplemental vLLM configuration files; empty skips them
+	VLLMConfigDir string
+	// MatchThreshold is the minimum similarity for matching a model to a HuggingFace model
+	// (default DefaultMatchThreshold)
+	MatchThreshold float64
+	// MaxConcurrent bounds the number of models enriched at the same time (default
+	// DefaultMaxConcurrent)
+	MaxConcurrent int
+	// Pyxis reads the Red Hat container catalog records of Red Hat registry images for
+	// EnrichMetadataFromPyxis; nil skips them
+	Pyxis *pyxis.Client
+	// Checkpoint records the enriched models; with a resumed checkpoint, models that already
+	// completed a stage are skipped. nil processes every model.
+	Checkpoint *checkpoint.Checkpoint
+	// Errors collects the models that failed to be enriched or to get OCI artifact metadata; nil
+	// only logs them
+	Errors *errorreport.Report
+	// HuggingFace fetches the model details, READMEs, license files and configs models are
+	// enriched with; nil uses a client with the default options
+	HuggingFace *huggingface.Client
+	// Readme configures how READMEs and modelcards become the readme field of each model
+	Readme metadata.ReadmeOptions
+	// RegistryAuth holds the credentials of registries that are used instead of the container
+	// auth files; nil uses the auth files for every registry
+	RegistryAuth registry.Auth
+}
+
+// enricher carries the options of an enrichment run to the per-model steps
+type enricher struct {
+	Options
+}
+
+func newEnricher(opts Options) *enricher {
+	if opts.MatchThreshold <= 0 {
+		opts.MatchThreshold = DefaultMatchThreshold
+	}
+	if opts.MaxConcurrent < 1 {
+		opts.MaxConcurrent = DefaultMaxConcurrent
+	}
+	if opts.HuggingFace == nil {
+		opts.HuggingFace = huggingface.NewClient(huggingface.Options{})
+	}
+	return &enricher{Options: opts}
+}
+
+// EnrichMetadataFromHuggingFace enriches registry model metadata using HuggingFace data.
+// A HuggingFace model is only used when its similarity score reaches opts.MatchThreshold;
+// the chosen candidate for every model is recorded in match-report.yaml in opts.Output, keeping the
+// entries of models a resumed checkpoint skips. Models whose enrichment failed are not
+// checkpointed, so a resumed run enriches them again. Canceling ctx, e.g. on SIGTERM, aborts the HuggingFace requests in flight and stops enrichment
+// from starting further models; the interrupted models are not checkpointed.
+func EnrichMetadataFromHuggingFace(ctx context.Context, hfIndexPath, modelsIndexPath string, opts Options) error {
+	e := newEnricher(opts)
 	log.Println("Enriching registry model metadata with HuggingFace data...")
 
 	// Load HuggingFace models
@@ -75,212 +140,430 @@ func EnrichMetadataFromHuggingFace(hfIndexPath, modelsIndexPath, outputDir, vllm
 	}
 
 	// Load vLLM recommended configurations from static files
-	vllmIndex, vllmErr := config.LoadVLLMConfigs(vllmConfigDir)
+	vllmIndex, vllmErr := config.LoadVLLMConfigs(e.VLLMConfigDir)
 	if vllmErr != nil {
 		log.Printf("Warning: Failed to load vLLM configs: %v", vllmErr)
 	} else {
 		log.Printf("Loaded %d vLLM recommended configurations", vllmIndex.ModelCount())
 	}
 
+	// Models are enriched concurrently; the matches are collected per model so the match report
+	// keeps the order of the models index
+	matches := make([]*modelMatch, len(regModels))
+	resumed := make([]bool, len(regModels))
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, e.MaxConcurrent)
+	for i, regModel := range regModels {
+		if ctx.Err() != nil {
+			log.Printf("Interrupted, not enriching the remaining models")
+			break
+		}
+		if e.Checkpoint.Done(regModel, checkpoint.StageEnrichment) {
+			log.Printf("Skipping model already enriched before resuming: %s", regModel)
+			resumed[i] = true
+			continue
+		}
+
+		semaphore <- struct{}{}
+		wg.Add(1)
+		go func(i int, regModel string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			match := e.enrichModel(ctx, regModel, hfIndex, vllmIndex)
+			if ctx.Err() != nil {
+				return
+			}
+			matches[i] = &match
+			if match.failed {
+				return
+			}
+			if err := e.Checkpoint.MarkDone(regModel, checkpoint.StageEnrichment); err != nil {
+				log.Printf("  Warning: %v", err)
+			}
+		}(i, regModel)
+	}
+	wg.Wait()
+
 	matchCount := 0
+	matchReport := NewMatchReport(e.MatchThreshold)
+	previousEntries := e.previousMatches(resumed)
+	for i, match := range matches {
+		if match == nil {
+			if entry, ok := previousEntries[regModels[i]]; ok && resumed[i] {
+				matchReport.Add(entry.RegistryModel, entry.HuggingFaceModel, entry.Score)
+			}
+			continue
+		}
+		matchReport.Add(regModels[i], match.hfModel, match.score)
+		if match.enriched {
+			matchCount++
+		}
+	}
 
-	// For each registry model, find the best HuggingFace match and enrich metadata
-	for _, regModel := range regModels {
-		log.Printf("Processing model: %s", regModel)
+	if err := matchReport.Write(e.Output); err != nil {
+		log.Printf("Warning: Failed to write match report: %v", err)
+	}
+
+	enrichmentRate := float64(matchCount) / float64(len(regModels)) * 100
+
+	log.Printf("Metadata enrichment complete:")
+	log.Printf("- Total registry models: %d", len(regModels))
+	log.Printf("- Successfully enriched: %d (%.1f%%)", matchCount, enrichmentRate)
+	log.Printf("- Match threshold: %.2f (see %s for details)", e.MatchThreshold, MatchReportFileName)
+	log.Printf("- Individual metadata.yaml files have been updated with enriched data")
+
+	return nil
+}
 
-		enriched := types.EnrichedModelMetadata{
-			RegistryModel:    regModel,
-			EnrichmentStatus: "no_match",
+// modelcardTaskSource returns the source of tasks extracted from a modelcard: the inferred task
+// sources when the tasks are the ones InferTasks guesses from the card, otherwise modelcard.regex
+func modelcardTaskSource(tasks []string, modelcardContent string) string {
+	if inferred, source := metadata.InferTasks(modelcardContent); source != "" && slices.Equal(inferred, tasks) {
+		return source
+	}
+	return "modelcard.regex"
+}
+
+// isInferredTaskSource reports whether tasks were guessed from the modelcard text, so that
+// tasks stated by HuggingFace take precedence
+func isInferredTaskSource(source string) bool {
+	return source == metadata.TaskSourceHeadings || source == metadata.TaskSourceInferred
+}
+
+// clearTextParsedSources resets the descriptive fields whose values were read from modelcard text
+func clearTextParsedSources(enriched *types.EnrichedModelMetadata) {
+	for _, field := range []*types.MetadataSource{
+		&enriched.Provider,
+		&enriched.Description,
+		&enriched.License,
+		&enriched.LicenseLink,
+		&enriched.Language,
+		&enriched.Tags,
+		&enriched.Tasks,
+	} {
+		if field.Source == "modelcard.regex" || isInferredTaskSource(field.Source) {
+			*field = metadata.CreateMetadataSource(nil, "null")
 		}
+	}
+}
 
-		// Try to load existing modelcard metadata
-		existingMetadata, err := metadata.LoadExistingMetadata(regModel, outputDir)
-		if err != nil {
-			log.Printf("  No existing metadata found for %s", regModel)
+// needsLicenseFile reports whether the license is missing or "other" without a license link
+func needsLicenseFile(enriched *types.EnrichedModelMetadata) bool {
+	if enriched.LicenseLink.Source != "null" {
+		return false
+	}
+	if enriched.License.Source == "null" {
+		return true
+	}
+	license, ok := enriched.License.Value.(string)
+	return !ok || strings.EqualFold(strings.TrimSpace(license), "other")
+}
+
+// storeLicenseFile fetches the license file from the HuggingFace repository, writes it next to
+// the model's modelcard output, and points the license link at the repository copy
+func storeLicenseFile(ctx context.Context, hf *huggingface.Client, enriched *types.EnrichedModelMetadata, hfModelName, regModel string, output outputfs.FS) {
+	fileName, content, err := hf.FetchLicenseFile(ctx, hfModelName)
+	if err != nil {
+		log.Printf("  No license file available for %s: %v", hfModelName, err)
+		return
+	}
+
+	if err := output.MkdirAll(outputfs.ModelPath(regModel), 0755); err != nil {
+		log.Printf("  Warning: Failed to create directory for license file: %v", err)
+		return
+	}
+
+	licensePath := outputfs.ModelPath(regModel, fileName)
+	if err := output.WriteFile(licensePath, []byte(content), 0644); err != nil {
+		log.Printf("  Warning: Failed to write license file %s: %v", output.Path(licensePath), err)
+		return
+	}
+
+	log.Printf("  Stored license file %s", output.Path(licensePath))
+
+	// Without a license identifier, the license text is classified to name the license and link
+	// its canonical text
+	if enriched.License.Source == "null" {
+		if licenseID, score := utils.DetectLicense(content); licenseID != "" {
+			log.Printf("  Detected license %s from %s (similarity %.2f)", licenseID, fileName, score)
+			enriched.License = metadata.CreateMetadataSource(licenseID, "huggingface.license-text")
+			if licenseURL := utils.GetLicenseURL(licenseID); licenseURL != "" {
+				enriched.LicenseLink = metadata.CreateMetadataSource(licenseURL, "huggingface.license-text")
+				return
+			}
 		}
+	}
+	enriched.LicenseLink = metadata.CreateMetadataSource(huggingface.LicenseFileURL(hfModelName, fileName), "huggingface.license")
+}
 
-		// Initialize metadata sources with existing data or nulls
-		enriched.Name = metadata.CreateMetadataSource(nil, "null")
-		enriched.Provider = metadata.CreateMetadataSource(nil, "null")
-		enriched.Description = metadata.CreateMetadataSource(nil, "null")
-		enriched.License = metadata.CreateMetadataSource(nil, "null")
-		enriched.LicenseLink = metadata.CreateMetadataSource(nil, "null")
-		enriched.Language = metadata.CreateMetadataSource(nil, "null")
-		enriched.LastModified = metadata.CreateMetadataSource(nil, "null")
-		enriched.CreateTimeSinceEpoch = metadata.CreateMetadataSource(nil, "null")
-		enriched.Tags = metadata.CreateMetadataSource(nil, "null")
-		enriched.Tasks = metadata.CreateMetadataSource(nil, "null")
-		enriched.Downloads = metadata.CreateMetadataSource(nil, "null")
-		enriched.Likes = metadata.CreateMetadataSource(nil, "null")
-		enriched.ModelSize = metadata.CreateMetadataSource(nil, "null")
-		enriched.ValidatedOn = metadata.CreateMetadataSource(nil, "null")
-		enriched.HardwareTag = metadata.CreateMetadataSource(nil, "null")
-		enriched.ValidatedTasks = metadata.CreateMetadataSource(nil, "null")
-
-		// Populate from existing modelcard metadata if available (only for non-empty values)
-		// We need to determine if the data came from YAML frontmatter or text parsing
-		if existingMetadata != nil {
-			// Try to load the modelcard.md file to analyze the source
-			sanitizedName := utils.SanitizeManifestRef(regModel)
-			modelcardPath := fmt.Sprintf("%s/%s/models/modelcard.md", outputDir, sanitizedName)
-
-			var modelcardContent string
-			var hasYAMLFrontmatter bool
-			if content, err := os.ReadFile(modelcardPath); err == nil {
-				modelcardContent = string(content)
-				// Check if modelcard has YAML frontmatter
-				if frontmatter, err := metadata.ExtractYAMLFrontmatterFromModelCard(modelcardContent); err == nil {
-					hasYAMLFrontmatter = true
-					// Determine sources based on YAML frontmatter presence
-					// Note: Only check fields that exist in ModelCardYAMLFrontmatter struct
-
-					// Name can come from YAML frontmatter
-					if existingMetadata.Name != nil && *existingMetadata.Name != "" {
-						source := "modelcard.regex"
-						if frontmatter.Name != "" && frontmatter.Name == *existingMetadata.Name {
-							source = "modelcard.yaml"
-						}
-						enriched.Name = metadata.CreateMetadataSource(*existingMetadata.Name, source)
+// modelMatch is the outcome of enriching one registry model
+type modelMatch struct {
+	hfModel  string
+	score    float64
+	enriched bool
+	// failed reports that an enrichment error was recorded for the model
+	failed bool
+}
+
+// previousMatches returns the entries of the existing match report by registry model when a
+// resumed checkpoint skipped any model, so the rewritten report still lists them
+func (e *enricher) previousMatches(resumed []bool) map[string]MatchReportEntry {
+	if !slices.Contains(resumed, true) {
+		return nil
+	}
+	previous, err := ReadMatchReport(e.Output)
+	if err != nil {
+		log.Printf("Warning: Failed to read the match report of the resumed run: %v", err)
+		return nil
+	}
+	entries := make(map[string]MatchReportEntry)
+	for _, entry := range append(previous.Matched, previous.Unmatched...) {
+		entries[entry.RegistryModel] = entry
+	}
+	return entries
+}
+
+// enrichModel finds the best HuggingFace match for a registry model and updates its metadata.yaml
+// with the enriched data
+func (e *enricher) enrichModel(ctx context.Context, regModel string, hfIndex types.VersionIndex, vllmIndex *config.VLLMConfigIndex) modelMatch {
+	log.Printf("Processing model: %s", regModel)
+
+	enriched := types.EnrichedModelMetadata{
+		RegistryModel:    regModel,
+		EnrichmentStatus: "no_match",
+	}
+
+	// Try to load existing modelcard metadata
+	existingMetadata, err := metadata.LoadExistingMetadata(e.Output, regModel)
+	if err != nil {
+		log.Printf("  No existing metadata found for %s", regModel)
+	}
+
+	// Initialize metadata sources with existing data or nulls
+	enriched.Name = metadata.CreateMetadataSource(nil, "null")
+	enriched.Provider = metadata.CreateMetadataSource(nil, "null")
+	enriched.Description = metadata.CreateMetadataSource(nil, "null")
+	enriched.License = metadata.CreateMetadataSource(nil, "null")
+	enriched.LicenseLink = metadata.CreateMetadataSource(nil, "null")
+	enriched.Language = metadata.CreateMetadataSource(nil, "null")
+	enriched.LastModified = metadata.CreateMetadataSource(nil, "null")
+	enriched.CreateTimeSinceEpoch = metadata.CreateMetadataSource(nil, "null")
+	enriched.Tags = metadata.CreateMetadataSource(nil, "null")
+	enriched.Tasks = metadata.CreateMetadataSource(nil, "null")
+	enriched.Downloads = metadata.CreateMetadataSource(nil, "null")
+	enriched.Likes = metadata.CreateMetadataSource(nil, "null")
+	enriched.ModelSize = metadata.CreateMetadataSource(nil, "null")
+	enriched.ValidatedOn = metadata.CreateMetadataSource(nil, "null")
+	enriched.HardwareTag = metadata.CreateMetadataSource(nil, "null")
+	enriched.ValidatedTasks = metadata.CreateMetadataSource(nil, "null")
+	enriched.TrainingDatasets = metadata.CreateMetadataSource(nil, "null")
+	enriched.BaseModel = metadata.CreateMetadataSource(nil, "null")
+	enriched.Quantization = metadata.CreateMetadataSource(nil, "null")
+	enriched.Evaluations = metadata.CreateMetadataSource(nil, "null")
+	enriched.MaxContextLength = metadata.CreateMetadataSource(nil, "null")
+
+	// Populate from existing modelcard metadata if available (only for non-empty values)
+	// We need to determine if the data came from YAML frontmatter or text parsing
+	if existingMetadata != nil {
+		// Try to load the modelcard.md file to analyze the source
+		var modelcardContent string
+		var hasYAMLFrontmatter bool
+		if content, err := e.Output.ReadFile(outputfs.ModelPath(regModel, "modelcard.md")); err == nil {
+			modelcardContent = string(content)
+			// Check if modelcard has YAML frontmatter
+			if frontmatter, err := metadata.ExtractYAMLFrontmatterFromModelCard(modelcardContent); err == nil {
+				hasYAMLFrontmatter = true
+				// Determine sources based on YAML frontmatter presence
+				// Note: Only check fields that exist in ModelCardYAMLFrontmatter struct
+
+				// Name can come from YAML frontmatter
+				if existingMetadata.Name != nil && *existingMetadata.Name != "" {
+					source := "modelcard.regex"
+					if frontmatter.Name != "" && frontmatter.Name == *existingMetadata.Name {
+						source = "modelcard.yaml"
 					}
+					enriched.Name = metadata.CreateMetadataSource(*existingMetadata.Name, source)
+				}
 
-					// Provider can come from YAML frontmatter
-					if existingMetadata.Provider != nil && *existingMetadata.Provider != "" {
-						source := "modelcard.regex"
-						if frontmatter.Provider != "" && frontmatter.Provider == *existingMetadata.Provider {
-							source = "modelcard.yaml"
-						}
-						enriched.Provider = metadata.CreateMetadataSource(*existingMetadata.Provider, source)
+				// Provider can come from YAML frontmatter
+				if existingMetadata.Provider != nil && *existingMetadata.Provider != "" {
+					source := "modelcard.regex"
+					if frontmatter.Provider != "" && frontmatter.Provider == *existingMetadata.Provider {
+						source = "modelcard.yaml"
 					}
+					enriched.Provider = metadata.CreateMetadataSource(*existingMetadata.Provider, source)
+				}
 
-					// Description can come from YAML frontmatter
-					if existingMetadata.Description != nil && *existingMetadata.Description != "" {
-						source := "modelcard.regex"
-						if frontmatter.Description != "" && frontmatter.Description == *existingMetadata.Description {
-							source = "modelcard.yaml"
-						}
-						enriched.Description = metadata.CreateMetadataSource(*existingMetadata.Description, source)
+				// Description can come from YAML frontmatter
+				if existingMetadata.Description != nil && *existingMetadata.Description != "" {
+					source := "modelcard.regex"
+					if frontmatter.Description != "" && frontmatter.Description == *existingMetadata.Description {
+						source = "modelcard.yaml"
 					}
+					enriched.Description = metadata.CreateMetadataSource(*existingMetadata.Description, source)
+				}
 
-					// License can come from YAML frontmatter
-					if existingMetadata.License != nil && *existingMetadata.License != "" {
-						source := "modelcard.regex"
-						if frontmatter.License != "" && frontmatter.License == *existingMetadata.License {
-							source = "modelcard.yaml"
-						} else if frontmatter.LicenseName != "" && frontmatter.LicenseName == *existingMetadata.License {
-							source = "modelcard.yaml"
-						}
-						enriched.License = metadata.CreateMetadataSource(*existingMetadata.License, source)
+				// License can come from YAML frontmatter
+				if existingMetadata.License != nil && *existingMetadata.License != "" {
+					source := "modelcard.regex"
+					if frontmatter.License != "" && frontmatter.License == *existingMetadata.License {
+						source = "modelcard.yaml"
+					} else if frontmatter.LicenseName != "" && frontmatter.LicenseName == *existingMetadata.License {
+						source = "modelcard.yaml"
 					}
+					enriched.License = metadata.CreateMetadataSource(*existingMetadata.License, source)
+				}
 
-					// LicenseLink can come from YAML frontmatter
-					if existingMetadata.LicenseLink != nil && *existingMetadata.LicenseLink != "" {
-						source := "modelcard.regex"
-						if frontmatter.LicenseLink != "" && frontmatter.LicenseLink == *existingMetadata.LicenseLink {
-							source = "modelcard.yaml"
-						}
-						enriched.LicenseLink = metadata.CreateMetadataSource(*existingMetadata.LicenseLink, source)
+				// LicenseLink can come from YAML frontmatter
+				if existingMetadata.LicenseLink != nil && *existingMetadata.LicenseLink != "" {
+					source := "modelcard.regex"
+					if frontmatter.LicenseLink != "" && frontmatter.LicenseLink == *existingMetadata.LicenseLink {
+						source = "modelcard.yaml"
 					}
+					enriched.LicenseLink = metadata.CreateMetadataSource(*existingMetadata.LicenseLink, source)
+				}
 
-					// Tasks can come from YAML frontmatter (tasks field or pipeline_tag)
-					if len(existingMetadata.Tasks) > 0 {
-						source := "modelcard.regex"
-						// Check if tasks match the tasks field or pipeline_tag
-						if len(frontmatter.Tasks) > 0 && len(existingMetadata.Tasks) == len(frontmatter.Tasks) {
-							allMatch := true
-							for i, task := range existingMetadata.Tasks {
-								if i >= len(frontmatter.Tasks) || task != frontmatter.Tasks[i] {
-									allMatch = false
-									break
-								}
-							}
-							if allMatch {
-								source = "modelcard.yaml"
+				// Tasks can come from YAML frontmatter (tasks field or pipeline_tag)
+				if len(existingMetadata.Tasks) > 0 {
+					source := "modelcard.regex"
+					// Check if tasks match the tasks field or pipeline_tag
+					if len(frontmatter.Tasks) > 0 && len(existingMetadata.Tasks) == len(frontmatter.Tasks) {
+						allMatch := true
+						for i, task := range existingMetadata.Tasks {
+							if i >= len(frontmatter.Tasks) || task != frontmatter.Tasks[i] {
+								allMatch = false
+								break
 							}
-						} else if frontmatter.PipelineTag != "" && len(existingMetadata.Tasks) == 1 && existingMetadata.Tasks[0] == frontmatter.PipelineTag {
+						}
+						if allMatch {
 							source = "modelcard.yaml"
 						}
-						enriched.Tasks = metadata.CreateMetadataSource(existingMetadata.Tasks, source)
+					} else if frontmatter.PipelineTag != "" && len(existingMetadata.Tasks) == 1 && existingMetadata.Tasks[0] == frontmatter.PipelineTag {
+						source = "modelcard.yaml"
+					} else {
+						source = modelcardTaskSource(existingMetadata.Tasks, modelcardContent)
 					}
+					enriched.Tasks = metadata.CreateMetadataSource(existingMetadata.Tasks, source)
+				}
 
-					// Language can come from YAML frontmatter
-					if len(existingMetadata.Language) > 0 {
-						source := "modelcard.regex"
-						if len(frontmatter.Language) > 0 && len(existingMetadata.Language) == len(frontmatter.Language) {
-							allMatch := true
-							for i, lang := range existingMetadata.Language {
-								if i >= len(frontmatter.Language) || lang != frontmatter.Language[i] {
-									allMatch = false
-									break
-								}
-							}
-							if allMatch {
-								source = "modelcard.yaml"
+				// Language can come from YAML frontmatter
+				if len(existingMetadata.Language) > 0 {
+					source := "modelcard.regex"
+					if len(frontmatter.Language) > 0 && len(existingMetadata.Language) == len(frontmatter.Language) {
+						allMatch := true
+						for i, lang := range existingMetadata.Language {
+							if i >= len(frontmatter.Language) || lang != frontmatter.Language[i] {
+								allMatch = false
+								break
 							}
 						}
-						enriched.Language = metadata.CreateMetadataSource(existingMetadata.Language, source)
+						if allMatch {
+							source = "modelcard.yaml"
+						}
 					}
+					enriched.Language = metadata.CreateMetadataSource(existingMetadata.Language, source)
+				}
 
-					// Tags can come from YAML frontmatter
-					if len(existingMetadata.Tags) > 0 {
-						source := "modelcard.regex"
-						if len(frontmatter.Tags) > 0 && len(existingMetadata.Tags) == len(frontmatter.Tags) {
-							allMatch := true
-							for i, tag := range existingMetadata.Tags {
-								if i >= len(frontmatter.Tags) || tag != frontmatter.Tags[i] {
-									allMatch = false
-									break
-								}
-							}
-							if allMatch {
-								source = "modelcard.yaml"
+				// Tags can come from YAML frontmatter
+				if len(existingMetadata.Tags) > 0 {
+					source := "modelcard.regex"
+					if len(frontmatter.Tags) > 0 && len(existingMetadata.Tags) == len(frontmatter.Tags) {
+						allMatch := true
+						for i, tag := range existingMetadata.Tags {
+							if i >= len(frontmatter.Tags) || tag != frontmatter.Tags[i] {
+								allMatch = false
+								break
 							}
 						}
-						enriched.Tags = metadata.CreateMetadataSource(existingMetadata.Tags, source)
+						if allMatch {
+							source = "modelcard.yaml"
+						}
 					}
+					enriched.Tags = metadata.CreateMetadataSource(existingMetadata.Tags, source)
 				}
 			}
+		}
 
-			// If no YAML frontmatter analysis was possible, assume all modelcard data comes from regex/text parsing
-			if !hasYAMLFrontmatter {
-				if existingMetadata.Name != nil && *existingMetadata.Name != "" {
-					enriched.Name = metadata.CreateMetadataSource(*existingMetadata.Name, "modelcard.regex")
-				}
-				if existingMetadata.Provider != nil && *existingMetadata.Provider != "" {
-					enriched.Provider = metadata.CreateMetadataSource(*existingMetadata.Provider, "modelcard.regex")
-				}
-				if existingMetadata.Description != nil && *existingMetadata.Description != "" {
-					enriched.Description = metadata.CreateMetadataSource(*existingMetadata.Description, "modelcard.regex")
-				}
-				if existingMetadata.License != nil && *existingMetadata.License != "" {
-					enriched.License = metadata.CreateMetadataSource(*existingMetadata.License, "modelcard.regex")
-				}
-				if existingMetadata.LicenseLink != nil && *existingMetadata.LicenseLink != "" {
-					enriched.LicenseLink = metadata.CreateMetadataSource(*existingMetadata.LicenseLink, "modelcard.regex")
-				}
-				if len(existingMetadata.Language) > 0 {
-					enriched.Language = metadata.CreateMetadataSource(existingMetadata.Language, "modelcard.regex")
-				}
-				if len(existingMetadata.Tags) > 0 {
-					enriched.Tags = metadata.CreateMetadataSource(existingMetadata.Tags, "modelcard.regex")
-				}
-				if len(existingMetadata.Tasks) > 0 {
-					enriched.Tasks = metadata.CreateMetadataSource(existingMetadata.Tasks, "modelcard.regex")
-				}
+		// If no YAML frontmatter analysis was possible, assume all modelcard data comes from regex/text parsing
+		if !hasYAMLFrontmatter {
+			if existingMetadata.Name != nil && *existingMetadata.Name != "" {
+				enriched.Name = metadata.CreateMetadataSource(*existingMetadata.Name, "modelcard.regex")
 			}
-
-			// Handle timestamps (these are typically from text parsing, not YAML)
-			if existingMetadata.LastUpdateTimeSinceEpoch != nil {
-				enriched.LastModified = metadata.CreateMetadataSource(*existingMetadata.LastUpdateTimeSinceEpoch, "modelcard.regex")
+			if existingMetadata.Provider != nil && *existingMetadata.Provider != "" {
+				enriched.Provider = metadata.CreateMetadataSource(*existingMetadata.Provider, "modelcard.regex")
+			}
+			if existingMetadata.Description != nil && *existingMetadata.Description != "" {
+				enriched.Description = metadata.CreateMetadataSource(*existingMetadata.Description, "modelcard.regex")
 			}
-			if existingMetadata.CreateTimeSinceEpoch != nil {
-				enriched.CreateTimeSinceEpoch = metadata.CreateMetadataSource(*existingMetadata.CreateTimeSinceEpoch, "modelcard.regex")
+			if existingMetadata.License != nil && *existingMetadata.License != "" {
+				enriched.License = metadata.CreateMetadataSource(*existingMetadata.License, "modelcard.regex")
+			}
+			if existingMetadata.LicenseLink != nil && *existingMetadata.LicenseLink != "" {
+				enriched.LicenseLink = metadata.CreateMetadataSource(*existingMetadata.LicenseLink, "modelcard.regex")
+			}
+			if len(existingMetadata.Language) > 0 {
+				enriched.Language = metadata.CreateMetadataSource(existingMetadata.Language, "modelcard.regex")
+			}
+			if len(existingMetadata.Tags) > 0 {
+				enriched.Tags = metadata.CreateMetadataSource(existingMetadata.Tags, "modelcard.regex")
+			}
+			if len(existingMetadata.Tasks) > 0 {
+				enriched.Tasks = metadata.CreateMetadataSource(existingMetadata.Tasks, modelcardTaskSource(existingMetadata.Tasks, modelcardContent))
 			}
 		}
 
-		// Find best matching HuggingFace model
-		bestMatch := types.ModelIndex{}
-		bestScore := 0.0
+		// Training datasets are only ever read from modelcard YAML frontmatter
+		if len(existingMetadata.TrainingDatasets) > 0 {
+			enriched.TrainingDatasets = metadata.CreateMetadataSource(existingMetadata.TrainingDatasets, "modelcard.yaml")
+		}
+
+		// Base models come from the base_model frontmatter key or "fine-tuned from"-style card text
+		if len(existingMetadata.BaseModel) > 0 {
+			source := "modelcard.regex"
+			if frontmatter, err := metadata.ExtractYAMLFrontmatterFromModelCard(modelcardContent); err == nil && len(frontmatter.BaseModel) > 0 {
+				source = "modelcard.yaml"
+			}
+			enriched.BaseModel = metadata.CreateMetadataSource(existingMetadata.BaseModel, source)
+		}
 
+		if existingMetadata.MaxContextLength != nil {
+			enriched.MaxContextLength = metadata.CreateMetadataSource(*existingMetadata.MaxContextLength, "modelcard.regex")
+		}
+
+		// Quantization read from a GGUF weights layer describes the exact artifact being shipped
+		if existingMetadata.Quantization != nil {
+			enriched.Quantization = metadata.CreateMetadataSource(existingMetadata.Quantization, "registry.gguf")
+		}
+
+		// Handle timestamps (these are typically from text parsing, not YAML)
+		if existingMetadata.LastUpdateTimeSinceEpoch != nil {
+			enriched.LastModified = metadata.CreateMetadataSource(*existingMetadata.LastUpdateTimeSinceEpoch, "modelcard.regex")
+		}
+		if existingMetadata.CreateTimeSinceEpoch != nil {
+			enriched.CreateTimeSinceEpoch = metadata.CreateMetadataSource(*existingMetadata.CreateTimeSinceEpoch, "modelcard.regex")
+		}
+
+		// Text patterns assume English prose, so values they read from cards in other languages
+		// are left for the HuggingFace frontmatter, API and tags to fill
+		cardLanguage := existingMetadata.CardLanguage
+		if cardLanguage == "" && modelcardContent != "" {
+			cardLanguage = metadata.DetectCardLanguage(modelcardContent)
+		}
+		if metadata.IsNonEnglishCard(cardLanguage) {
+			log.Printf("  Modelcard is written in %q, preferring HuggingFace values over text parsing", cardLanguage)
+			clearTextParsedSources(&enriched)
+		}
+	}
+
+	// Find best matching HuggingFace model
+	bestMatch := types.ModelIndex{}
+	bestScore := 0.0
+
+	// hf:// entries name their repository, which need not be in the HuggingFace index
+	if repository := utils.HuggingFaceRepositoryOf(regModel); repository != "" {
+		bestMatch, bestScore = huggingface.IndexEntry(repository), 1.0
+	} else {
 		for _, hfModel := range hfIndex.Models {
 			// Skip cross-family matches to prevent llama containers from matching granite HF entries
 			if !isCompatibleModelFamily(regModel, hfModel.Name) {
@@ -293,318 +576,374 @@ func EnrichMetadataFromHuggingFace(hfIndexPath, modelsIndexPath, outputDir, vllm
 				bestMatch = hfModel
 			}
 		}
+	}
 
-		// Enrich with HuggingFace data if we found a good match
-		threshold := 0.5
-		if bestScore >= threshold {
-			enriched.HuggingFaceModel = bestMatch.Name
-			enriched.HuggingFaceURL = bestMatch.URL
-			enriched.ReadmePath = bestMatch.ReadmePath
-			enriched.EnrichmentStatus = "enriched"
-
-			// Set confidence level
-			if bestScore >= 0.8 {
-				enriched.MatchConfidence = "high"
-			} else {
-				enriched.MatchConfidence = "medium"
-			}
+	match := modelMatch{hfModel: bestMatch.Name, score: bestScore}
 
-			// Try to fetch detailed HuggingFace metadata
-			log.Printf("  Fetching HuggingFace details for: %s", bestMatch.Name)
-			hfDetails, err := huggingface.FetchModelDetails(bestMatch.Name)
-			if err != nil {
-				log.Printf("  Warning: Failed to fetch HF details: %v", err)
-			} else {
-				// Always store HuggingFace name when available - the confidence-based override logic will decide whether to use it
-				if hfDetails.ID != "" {
-					// For high-confidence matches, always set the HuggingFace name so it can be used by confidence-based override logic
-					if enriched.MatchConfidence == "high" {
-						enriched.Name = metadata.CreateMetadataSource(hfDetails.ID, "huggingface.api")
-					} else if enriched.Name.Source == "null" {
-						// For medium/low confidence, only set if no existing name
-						enriched.Name = metadata.CreateMetadataSource(hfDetails.ID, "huggingface.api")
-					}
-				}
-				if enriched.License.Source == "null" && hfDetails.License != "" {
-					enriched.License = metadata.CreateMetadataSource(hfDetails.License, "huggingface.api")
-				}
-				if enriched.LastModified.Source == "null" && hfDetails.LastModified != "" {
-					enriched.LastModified = metadata.CreateMetadataSource(hfDetails.LastModified, "huggingface.api")
+	// Enrich with HuggingFace data if we found a good match
+	if bestScore >= e.MatchThreshold {
+		enriched.HuggingFaceModel = bestMatch.Name
+		enriched.HuggingFaceURL = bestMatch.URL
+		enriched.ReadmePath = bestMatch.ReadmePath
+		enriched.EnrichmentStatus = "enriched"
+		enriched.MatchConfidence = matchConfidence(bestScore)
+
+		// Try to fetch detailed HuggingFace metadata
+		log.Printf("  Fetching HuggingFace details for: %s", bestMatch.Name)
+		hfDetails, err := e.HuggingFace.FetchModelDetails(ctx, bestMatch.Name)
+		if err != nil {
+			log.Printf("  Warning: Failed to fetch HF details: %v", err)
+			e.Errors.Record(regModel, checkpoint.StageEnrichment, fmt.Errorf("failed to fetch HuggingFace details of %s: %w", bestMatch.Name, err))
+			match.failed = true
+		} else {
+			// Always store HuggingFace name when available - the confidence-based override logic will decide whether to use it
+			if hfDetails.ID != "" {
+				// For high-confidence matches, always set the HuggingFace name so it can be used by confidence-based override logic
+				if enriched.MatchConfidence == "high" {
+					enriched.Name = metadata.CreateMetadataSource(hfDetails.ID, "huggingface.api")
+				} else if enriched.Name.Source == "null" {
+					// For medium/low confidence, only set if no existing name
+					enriched.Name = metadata.CreateMetadataSource(hfDetails.ID, "huggingface.api")
 				}
-				if len(hfDetails.Tags) > 0 {
-					// Parse tags for structured data and potentially extract license
-					languages, tagLicense, tasks := huggingface.ParseTagsForStructuredData(hfDetails.Tags)
-					log.Printf("  Parsed from tags - Languages: %v, License: %s, Tasks: %v", languages, tagLicense, tasks)
+			}
+			if enriched.License.Source == "null" && hfDetails.License != "" {
+				enriched.License = metadata.CreateMetadataSource(hfDetails.License, "huggingface.api")
+			}
+			if enriched.LastModified.Source == "null" && hfDetails.LastModified != "" {
+				enriched.LastModified = metadata.CreateMetadataSource(hfDetails.LastModified, "huggingface.api")
+			}
+			if len(hfDetails.Tags) > 0 {
+				// Parse tags for structured data and potentially extract license
+				languages, tagLicense, tasks := huggingface.ParseTagsForStructuredData(hfDetails.Tags)
+				log.Printf("  Parsed from tags - Languages: %v, License: %s, Tasks: %v", languages, tagLicense, tasks)
 
-					// NOTE: Do NOT store raw repository tags here - they will be used as fallback later
-					// Raw repository tags contain language codes, arxiv refs, and other metadata that should be filtered
+				// NOTE: Do NOT store raw repository tags here - they will be used as fallback later
+				// Raw repository tags contain language codes, arxiv refs, and other metadata that should be filtered
 
-					// Store parsed languages (if no YAML frontmatter languages available)
-					if enriched.Language.Source == "null" && len(languages) > 0 {
-						enriched.Language = metadata.CreateMetadataSource(languages, "huggingface.tags")
-					}
+				// Store parsed languages (if no YAML frontmatter languages available)
+				if enriched.Language.Source == "null" && len(languages) > 0 {
+					enriched.Language = metadata.CreateMetadataSource(languages, "huggingface.tags")
+				}
 
-					// Use license from tags if not already set
-					if enriched.License.Source == "null" && tagLicense != "" {
-						enriched.License = metadata.CreateMetadataSource(tagLicense, "huggingface.tags")
-					}
+				// Use license from tags if not already set
+				if enriched.License.Source == "null" && tagLicense != "" {
+					enriched.License = metadata.CreateMetadataSource(tagLicense, "huggingface.tags")
+				}
 
-					// Store tasks if found
-					if enriched.Tasks.Source == "null" && len(tasks) > 0 {
-						enriched.Tasks = metadata.CreateMetadataSource(tasks, "huggingface.tags")
-					}
+				// Store tasks if found
+				if (enriched.Tasks.Source == "null" || isInferredTaskSource(enriched.Tasks.Source)) && len(tasks) > 0 {
+					enriched.Tasks = metadata.CreateMetadataSource(tasks, "huggingface.tags")
 				}
-				if enriched.Downloads.Source == "null" && hfDetails.Downloads > 0 {
-					enriched.Downloads = metadata.CreateMetadataSource(hfDetails.Downloads, "huggingface.api")
+
+				// Store training datasets referenced by dataset: tags
+				datasets := huggingface.ExtractDatasetsFromTags(hfDetails.Tags)
+				if enriched.TrainingDatasets.Source == "null" && len(datasets) > 0 {
+					enriched.TrainingDatasets = metadata.CreateMetadataSource(datasets, "huggingface.tags")
 				}
-				if enriched.Likes.Source == "null" && hfDetails.Likes > 0 {
-					enriched.Likes = metadata.CreateMetadataSource(hfDetails.Likes, "huggingface.api")
+
+				// Store base models referenced by base_model: tags, which HuggingFace derives from the card
+				baseModels := huggingface.ExtractBaseModelsFromTags(hfDetails.Tags)
+				if (enriched.BaseModel.Source == "null" || enriched.BaseModel.Source == "modelcard.regex") && len(baseModels) > 0 {
+					enriched.BaseModel = metadata.CreateMetadataSource(baseModels, "huggingface.tags")
 				}
 			}
+			if enriched.Downloads.Source == "null" && hfDetails.Downloads > 0 {
+				enriched.Downloads = metadata.CreateMetadataSource(hfDetails.Downloads, "huggingface.api")
+			}
+			if enriched.Likes.Source == "null" && hfDetails.Likes > 0 {
+				enriched.Likes = metadata.CreateMetadataSource(hfDetails.Likes, "huggingface.api")
+			}
 
-			// Always fetch HuggingFace README to check for YAML frontmatter (highest priority)
-			// Also extract release date and other metadata information as needed
-			needsProvider := enriched.Provider.Source == "null"
-			// Extract release date if we don't have a valid date yet (even from modelcard.regex with null value)
-			needsReleaseDate := enriched.LastModified.Source == "null" ||
-				(enriched.LastModified.Source == "modelcard.regex" && enriched.LastModified.Value == nil)
+			// Use evaluation results published in the card's model-index (including Open LLM Leaderboard results)
+			if evaluations := huggingface.ExtractEvaluations(hfDetails.CardData.ModelIndex); len(evaluations) > 0 {
+				enriched.Evaluations = metadata.CreateMetadataSource(evaluations, "huggingface.api")
+				log.Printf("  Extracted %d evaluation results from HuggingFace card data", len(evaluations))
+			}
 
-			log.Printf("  DEBUG: LastModified source='%s', value=%v, needsReleaseDate=%v",
-				enriched.LastModified.Source, enriched.LastModified.Value, needsReleaseDate)
-			log.Printf("  Fetching HuggingFace README for additional metadata: %s", bestMatch.Name)
-			hfReadme, err := huggingface.FetchReadme(bestMatch.Name)
-			if err != nil {
-				log.Printf("  Warning: Failed to fetch HF README: %v", err)
-			} else {
-				// Try to extract YAML frontmatter first
-				frontmatter, err := huggingface.ExtractYAMLFrontmatter(hfReadme)
-				if err == nil {
-					log.Printf("  Successfully extracted YAML frontmatter from HF README")
-
-					// Use name from HuggingFace YAML only when no canonical API name is available.
-					// The huggingface.api source provides the canonical model path (e.g. "RedHatAI/Qwen3.5-122B-A10B-FP8-dynamic"),
-					// which must not be overridden by the README's human-readable display name.
-					if frontmatter.Name != "" && enriched.Name.Source != "huggingface.api" {
-						enriched.Name = metadata.CreateMetadataSource(frontmatter.Name, "huggingface.yaml")
-						log.Printf("  Found name in YAML frontmatter: %s", frontmatter.Name)
-					}
+			// config.json declares the exact context window and wins over statements in the card text
+			if config, err := e.HuggingFace.FetchModelConfig(ctx, bestMatch.Name); err == nil {
+				if contextLength := huggingface.ContextLengthFromConfig(config); contextLength > 0 {
+					enriched.MaxContextLength = metadata.CreateMetadataSource(contextLength, "huggingface.config")
+					log.Printf("  Extracted max context length from config.json: %d", contextLength)
+				}
+			}
 
-					// Always use provider from HuggingFace YAML (highest priority)
-					if frontmatter.Provider != "" {
-						enriched.Provider = metadata.CreateMetadataSource(frontmatter.Provider, "huggingface.yaml")
-						log.Printf("  Found provider in YAML frontmatter: %s", frontmatter.Provider)
-					}
+			// Read quantization details from the GGUF header for GGUF-distributed models
+			if enriched.Quantization.Source == "null" && e.HuggingFace.IsGGUFModel(hfDetails) {
+				quantization, err := e.HuggingFace.FetchGGUFQuantization(ctx, hfDetails, regModel)
+				if err != nil {
+					log.Printf("  Warning: Failed to read GGUF header for %s: %v", bestMatch.Name, err)
+				} else {
+					enriched.Quantization = metadata.CreateMetadataSource(quantization, "huggingface.gguf")
+					log.Printf("  Extracted GGUF quantization: %+v", *quantization)
+				}
+			}
+		}
 
-					// Always use description from HuggingFace YAML (highest priority)
-					if frontmatter.Description != "" {
-						enriched.Description = metadata.CreateMetadataSource(frontmatter.Description, "huggingface.yaml")
-						log.Printf("  Found description in YAML frontmatter: %s", frontmatter.Description)
-					}
+		// Always fetch HuggingFace README to check for YAML frontmatter (highest priority)
+		// Also extract release date and other metadata information as needed
+		needsProvider := enriched.Provider.Source == "null"
+		// Extract release date if we don't have a valid date yet (even from modelcard.regex with null value)
+		needsReleaseDate := enriched.LastModified.Source == "null" ||
+			(enriched.LastModified.Source == "modelcard.regex" && enriched.LastModified.Value == nil)
+
+		log.Printf("  DEBUG: LastModified source='%s', value=%v, needsReleaseDate=%v",
+			enriched.LastModified.Source, enriched.LastModified.Value, needsReleaseDate)
+		log.Printf("  Fetching HuggingFace README for additional metadata: %s", bestMatch.Name)
+		hfReadme, err := e.HuggingFace.FetchReadme(ctx, bestMatch.Name)
+		if err != nil {
+			log.Printf("  Warning: Failed to fetch HF README: %v", err)
+			e.Errors.Record(regModel, checkpoint.StageEnrichment, fmt.Errorf("failed to fetch HuggingFace README of %s: %w", bestMatch.Name, err))
+			match.failed = true
+		} else {
+			// Try to extract YAML frontmatter first
+			frontmatter, err := huggingface.ExtractYAMLFrontmatter(hfReadme)
+			if err == nil {
+				log.Printf("  Successfully extracted YAML frontmatter from HF README")
+
+				// Use name from HuggingFace YAML only when no canonical API name is available.
+				// The huggingface.api source provides the canonical model path (e.g. "RedHatAI/Qwen3.5-122B-A10B-FP8-dynamic"),
+				// which must not be overridden by the README's human-readable display name.
+				if frontmatter.Name != "" && enriched.Name.Source != "huggingface.api" {
+					enriched.Name = metadata.CreateMetadataSource(frontmatter.Name, "huggingface.yaml")
+					log.Printf("  Found name in YAML frontmatter: %s", frontmatter.Name)
+				}
 
-					// Always use language from HuggingFace YAML frontmatter (highest priority)
-					if len(frontmatter.Language) > 0 {
-						// Convert to []string to ensure type compatibility
-						enriched.Language = metadata.CreateMetadataSource([]string(frontmatter.Language), "huggingface.yaml")
-						log.Printf("  Found languages in YAML frontmatter: %v", frontmatter.Language)
-					}
+				// Always use provider from HuggingFace YAML (highest priority)
+				if frontmatter.Provider != "" {
+					enriched.Provider = metadata.CreateMetadataSource(frontmatter.Provider, "huggingface.yaml")
+					log.Printf("  Found provider in YAML frontmatter: %s", frontmatter.Provider)
+				}
 
-					// Always use tags from HuggingFace YAML frontmatter (highest priority)
-					if len(frontmatter.Tags) > 0 {
-						enriched.Tags = metadata.CreateMetadataSource(frontmatter.Tags, "huggingface.yaml")
-						log.Printf("  Found tags in YAML frontmatter: %v", frontmatter.Tags)
-					}
+				// Always use description from HuggingFace YAML (highest priority)
+				if frontmatter.Description != "" {
+					enriched.Description = metadata.CreateMetadataSource(frontmatter.Description, "huggingface.yaml")
+					log.Printf("  Found description in YAML frontmatter: %s", frontmatter.Description)
+				}
 
-					// Always use license from HuggingFace YAML frontmatter (highest priority)
-					if frontmatter.License != "" {
-						enriched.License = metadata.CreateMetadataSource(frontmatter.License, "huggingface.yaml")
-						log.Printf("  Extracted license from YAML frontmatter: %s", frontmatter.License)
-					}
+				// Always use language from HuggingFace YAML frontmatter (highest priority)
+				if len(frontmatter.Language) > 0 {
+					// Convert to []string to ensure type compatibility
+					enriched.Language = metadata.CreateMetadataSource([]string(frontmatter.Language), "huggingface.yaml")
+					log.Printf("  Found languages in YAML frontmatter: %v", frontmatter.Language)
+				}
 
-					// Always use license_name if available and more specific (highest priority)
-					if frontmatter.LicenseName != "" {
-						enriched.License = metadata.CreateMetadataSource(frontmatter.LicenseName, "huggingface.yaml")
-						log.Printf("  Extracted license_name from YAML frontmatter: %s", frontmatter.LicenseName)
-					}
+				// Always use tags from HuggingFace YAML frontmatter (highest priority)
+				if len(frontmatter.Tags) > 0 {
+					enriched.Tags = metadata.CreateMetadataSource(frontmatter.Tags, "huggingface.yaml")
+					log.Printf("  Found tags in YAML frontmatter: %v", frontmatter.Tags)
+				}
 
-					// Always use license_link from HuggingFace YAML frontmatter (highest priority)
-					if frontmatter.LicenseLink != "" {
-						enriched.LicenseLink = metadata.CreateMetadataSource(frontmatter.LicenseLink, "huggingface.yaml")
-						log.Printf("  Extracted license_link from YAML frontmatter: %s", frontmatter.LicenseLink)
-					}
+				// Always use license from HuggingFace YAML frontmatter (highest priority)
+				if frontmatter.License != "" {
+					enriched.License = metadata.CreateMetadataSource(frontmatter.License, "huggingface.yaml")
+					log.Printf("  Extracted license from YAML frontmatter: %s", frontmatter.License)
+				}
 
-					// Always use tasks from HuggingFace YAML (highest priority)
-					if len(frontmatter.Tasks) > 0 {
-						enriched.Tasks = metadata.CreateMetadataSource(frontmatter.Tasks, "huggingface.yaml")
-						log.Printf("  Extracted tasks from YAML frontmatter: %v", frontmatter.Tasks)
-					} else if frontmatter.PipelineTag != "" {
-						// Fallback to pipeline_tag for tasks if tasks field is not available
-						tasks := []string{frontmatter.PipelineTag}
-						enriched.Tasks = metadata.CreateMetadataSource(tasks, "huggingface.yaml")
-						log.Printf("  Extracted pipeline_tag from YAML frontmatter: %s", frontmatter.PipelineTag)
-					}
-					// Always use validated_on from HuggingFace YAML (highest priority)
-					if len(frontmatter.ValidatedOn) > 0 {
-						enriched.ValidatedOn = metadata.CreateMetadataSource([]string(frontmatter.ValidatedOn), "huggingface.yaml")
-						log.Printf("  Extracted validated_on from YAML frontmatter: %v", frontmatter.ValidatedOn)
-					}
-					// Always use hardware_tag from HuggingFace YAML (highest priority)
-					if len(frontmatter.HardwareTag) > 0 {
-						enriched.HardwareTag = metadata.CreateMetadataSource([]string(frontmatter.HardwareTag), "huggingface.yaml")
-						log.Printf("  Extracted hardware_tag from YAML frontmatter: %v", frontmatter.HardwareTag)
-					}
+				// Always use license_name if available and more specific (highest priority)
+				if frontmatter.LicenseName != "" {
+					enriched.License = metadata.CreateMetadataSource(frontmatter.LicenseName, "huggingface.yaml")
+					log.Printf("  Extracted license_name from YAML frontmatter: %s", frontmatter.LicenseName)
+				}
 
-					// Extract validated_tasks from HuggingFace YAML (highest priority)
-					if len(frontmatter.ValidatedTasks) > 0 {
-						enriched.ValidatedTasks = metadata.CreateMetadataSource([]string(frontmatter.ValidatedTasks), "huggingface.yaml")
-						log.Printf("  Extracted validated_tasks from YAML frontmatter: %v", frontmatter.ValidatedTasks)
-					}
+				// Always use license_link from HuggingFace YAML frontmatter (highest priority)
+				if frontmatter.LicenseLink != "" {
+					enriched.LicenseLink = metadata.CreateMetadataSource(frontmatter.LicenseLink, "huggingface.yaml")
+					log.Printf("  Extracted license_link from YAML frontmatter: %s", frontmatter.LicenseLink)
+				}
 
-					// Extract tool-calling configuration from HuggingFace YAML frontmatter ONLY
-					// NOTE: We do NOT extract this from container modelcard YAML - only from HuggingFace
-					var toolCallingConfig *types.ToolCallingConfig
-					if frontmatter.ToolCallingSupported || len(frontmatter.RequiredCLIArgs) > 0 || frontmatter.ToolCallParser != "" {
-						toolCallingConfig = &types.ToolCallingConfig{
-							Supported:        frontmatter.ToolCallingSupported,
-							RequiredCLIArgs:  []string(frontmatter.RequiredCLIArgs),
-							ChatTemplateFile: frontmatter.ChatTemplateFileName,
-							ChatTemplatePath: frontmatter.ChatTemplatePath,
-							ToolCallParser:   frontmatter.ToolCallParser,
-						}
-						log.Printf("  Extracted tool-calling config from HuggingFace: %+v", toolCallingConfig)
+				// Always use tasks from HuggingFace YAML (highest priority)
+				if len(frontmatter.Tasks) > 0 {
+					enriched.Tasks = metadata.CreateMetadataSource(frontmatter.Tasks, "huggingface.yaml")
+					log.Printf("  Extracted tasks from YAML frontmatter: %v", frontmatter.Tasks)
+				} else if frontmatter.PipelineTag != "" {
+					// Fallback to pipeline_tag for tasks if tasks field is not available
+					tasks := []string{frontmatter.PipelineTag}
+					enriched.Tasks = metadata.CreateMetadataSource(tasks, "huggingface.yaml")
+					log.Printf("  Extracted pipeline_tag from YAML frontmatter: %s", frontmatter.PipelineTag)
+				}
+				// Always use validated_on from HuggingFace YAML (highest priority)
+				if len(frontmatter.ValidatedOn) > 0 {
+					enriched.ValidatedOn = metadata.CreateMetadataSource([]string(frontmatter.ValidatedOn), "huggingface.yaml")
+					log.Printf("  Extracted validated_on from YAML frontmatter: %v", frontmatter.ValidatedOn)
+				}
+				// Always use hardware_tag from HuggingFace YAML (highest priority)
+				if len(frontmatter.HardwareTag) > 0 {
+					enriched.HardwareTag = metadata.CreateMetadataSource([]string(frontmatter.HardwareTag), "huggingface.yaml")
+					log.Printf("  Extracted hardware_tag from YAML frontmatter: %v", frontmatter.HardwareTag)
+				}
 
-						// Validate the tool-calling configuration
-						if err := toolCallingConfig.Validate(); err != nil {
-							log.Printf("  Warning: Invalid tool-calling config for %s: %v", regModel, err)
-							toolCallingConfig = nil // Discard invalid config
-						}
-					}
+				// Extract validated_tasks from HuggingFace YAML (highest priority)
+				if len(frontmatter.ValidatedTasks) > 0 {
+					enriched.ValidatedTasks = metadata.CreateMetadataSource([]string(frontmatter.ValidatedTasks), "huggingface.yaml")
+					log.Printf("  Extracted validated_tasks from YAML frontmatter: %v", frontmatter.ValidatedTasks)
+				}
 
-					// Store for use during metadata update (will be nil if no tool-calling metadata)
-					enriched.ToolCallingConfig = toolCallingConfig
-				} else {
-					log.Printf("  No valid YAML frontmatter found in HF README: %v", err)
+				// Always use datasets from HuggingFace YAML (highest priority)
+				if len(frontmatter.Datasets) > 0 {
+					enriched.TrainingDatasets = metadata.CreateMetadataSource([]string(frontmatter.Datasets), "huggingface.yaml")
+					log.Printf("  Extracted datasets from YAML frontmatter: %v", frontmatter.Datasets)
 				}
 
-				// Store the README content (strip YAML frontmatter first) for use during metadata update
-				readmeContent := utils.StripYAMLFrontmatter(hfReadme)
-				if readmeContent != "" {
-					enriched.ReadmeContent = readmeContent
-					log.Printf("  Stored HuggingFace README content (%d chars)", len(readmeContent))
+				// Always use base_model from HuggingFace YAML (highest priority)
+				if len(frontmatter.BaseModel) > 0 {
+					enriched.BaseModel = metadata.CreateMetadataSource([]string(frontmatter.BaseModel), "huggingface.yaml")
+					log.Printf("  Extracted base_model from YAML frontmatter: %v", frontmatter.BaseModel)
 				}
 
-				// Fallback to text parsing for provider if needed
-				if needsProvider && enriched.Provider.Source == "null" {
-					provider := huggingface.ExtractProviderFromReadme(hfReadme)
-					if provider != "" {
-						enriched.Provider = metadata.CreateMetadataSource(provider, "huggingface.regex")
-						log.Printf("  Extracted provider from HF README text: %s", provider)
+				// Fall back to the README model-index when the API returned no card data (e.g. offline snapshots)
+				if enriched.Evaluations.Source == "null" {
+					if evaluations := huggingface.ExtractEvaluations(frontmatter.ModelIndex); len(evaluations) > 0 {
+						enriched.Evaluations = metadata.CreateMetadataSource(evaluations, "huggingface.yaml")
+						log.Printf("  Extracted %d evaluation results from YAML frontmatter", len(evaluations))
 					}
 				}
 
-				// Try to extract explicit release date from README (high priority)
-				releaseDate := huggingface.ExtractReleaseDateFromReadme(hfReadme)
-				if releaseDate != "" {
-					if epoch := utils.ParseDateToEpoch(releaseDate); epoch != nil {
-						// Use this for createTimeSinceEpoch if we don't have it from modelcard
-						if enriched.CreateTimeSinceEpoch.Source == "null" {
-							enriched.CreateTimeSinceEpoch = metadata.CreateMetadataSource(*epoch, "huggingface.regex")
-							log.Printf("  Extracted createTimeSinceEpoch from HF README release date: %s (epoch: %d)", releaseDate, *epoch)
-						}
-						// Also update lastModified if we don't have a more recent one
-						if needsReleaseDate {
-							enriched.LastModified = metadata.CreateMetadataSource(*epoch, "huggingface.regex")
-							log.Printf("  Extracted lastModified from HF README release date: %s (epoch: %d)", releaseDate, *epoch)
-						}
+				// Extract tool-calling configuration from HuggingFace YAML frontmatter ONLY
+				// NOTE: We do NOT extract this from container modelcard YAML - only from HuggingFace
+				var toolCallingConfig *types.ToolCallingConfig
+				if frontmatter.ToolCallingSupported || len(frontmatter.RequiredCLIArgs) > 0 || frontmatter.ToolCallParser != "" {
+					toolCallingConfig = &types.ToolCallingConfig{
+						Supported:        frontmatter.ToolCallingSupported,
+						RequiredCLIArgs:  []string(frontmatter.RequiredCLIArgs),
+						ChatTemplateFile: frontmatter.ChatTemplateFileName,
+						ChatTemplatePath: frontmatter.ChatTemplatePath,
+						ToolCallParser:   frontmatter.ToolCallParser,
+					}
+					log.Printf("  Extracted tool-calling config from HuggingFace: %+v", toolCallingConfig)
+
+					// Validate the tool-calling configuration
+					if err := toolCallingConfig.Validate(); err != nil {
+						log.Printf("  Warning: Invalid tool-calling config for %s: %v", regModel, err)
+						toolCallingConfig = nil // Discard invalid config
 					}
 				}
+
+				// Store for use during metadata update (will be nil if no tool-calling metadata)
+				enriched.ToolCallingConfig = toolCallingConfig
+			} else {
+				log.Printf("  No valid YAML frontmatter found in HF README: %v", err)
 			}
 
-			// Use repository tags as additional enrichment: Apply if no YAML frontmatter tags were found
-			// This will merge with existing modelcard tags (like "validated"/"featured") during update phase
-			if enriched.Tags.Source == "null" && len(hfDetails.Tags) > 0 {
-				log.Printf("  No YAML frontmatter tags found, using filtered repository tags")
-				// Filter out language codes, arxiv references, and other non-tag metadata
-				filteredTags := huggingface.FilterTagsForCleanTagList(hfDetails.Tags)
-				if len(filteredTags) > 0 {
-					enriched.Tags = metadata.CreateMetadataSource(filteredTags, "huggingface.tags")
-					log.Printf("  Using filtered repository tags: %v", filteredTags)
-				}
-			} else if enriched.Tags.Source == "modelcard.regex" && len(hfDetails.Tags) > 0 {
-				log.Printf("  Found modelcard tags, merging with filtered repository tags")
-				// Filter out language codes, arxiv references, and other non-tag metadata
-				filteredTags := huggingface.FilterTagsForCleanTagList(hfDetails.Tags)
-				if len(filteredTags) > 0 {
-					// Merge existing modelcard tags with HuggingFace tags
-					existingTags := enriched.Tags.Value.([]string)
-					allTags := make([]string, 0)
-
-					// First add existing tags
-					allTags = append(allTags, existingTags...)
-
-					// Then add new tags, avoiding duplicates
-					for _, newTag := range filteredTags {
-						found := false
-						for _, existingTag := range allTags {
-							if existingTag == newTag {
-								found = true
-								break
-							}
-						}
-						if !found {
-							allTags = append(allTags, newTag)
-						}
-					}
+			// Store the README content (strip YAML frontmatter first) for use during metadata update
+			readmeContent := utils.StripYAMLFrontmatter(hfReadme)
+			if readmeContent != "" {
+				enriched.ReadmeContent = readmeContent
+				log.Printf("  Stored HuggingFace README content (%d chars)", len(readmeContent))
+			}
 
-					enriched.Tags = metadata.CreateMetadataSource(allTags, "huggingface.tags")
-					log.Printf("  Merged modelcard + repository tags: %v", allTags)
+			// Fallback to text parsing for provider if needed
+			if needsProvider && enriched.Provider.Source == "null" {
+				provider := huggingface.ExtractProviderFromReadme(hfReadme)
+				if provider != "" {
+					enriched.Provider = metadata.CreateMetadataSource(provider, "huggingface.regex")
+					log.Printf("  Extracted provider from HF README text: %s", provider)
 				}
 			}
 
-			// Look up vLLM recommended configuration by exact model name match
-			if vllmIndex != nil && enriched.HuggingFaceModel != "" {
-				if vllmCfg := vllmIndex.GetConfig(enriched.HuggingFaceModel); vllmCfg != nil {
-					enriched.VLLMConfig = vllmCfg
-					log.Printf("  Found vLLM recommended config for: %s", enriched.HuggingFaceModel)
+			// Try to extract explicit release date from README (high priority)
+			releaseDate := huggingface.ExtractReleaseDateFromReadme(hfReadme)
+			if releaseDate != "" {
+				if epoch := utils.ParseDateToEpoch(releaseDate); epoch != nil {
+					// Use this for createTimeSinceEpoch if we don't have it from modelcard
+					if enriched.CreateTimeSinceEpoch.Source == "null" {
+						enriched.CreateTimeSinceEpoch = metadata.CreateMetadataSource(*epoch, "huggingface.regex")
+						log.Printf("  Extracted createTimeSinceEpoch from HF README release date: %s (epoch: %d)", releaseDate, *epoch)
+					}
+					// Also update lastModified if we don't have a more recent one
+					if needsReleaseDate {
+						enriched.LastModified = metadata.CreateMetadataSource(*epoch, "huggingface.regex")
+						log.Printf("  Extracted lastModified from HF README release date: %s (epoch: %d)", releaseDate, *epoch)
+					}
 				}
 			}
+		}
 
-			// Update the model's metadata.yaml file with enriched data
-			err = UpdateModelMetadataFile(regModel, &enriched, outputDir)
-			if err != nil {
-				log.Printf("  Warning: Failed to update metadata file for %s: %v", regModel, err)
-			} else {
-				log.Printf("  Successfully updated metadata file for: %s", regModel)
-
-				// Also update artifacts with OCI metadata
-				log.Printf("  Updating OCI artifacts for: %s", regModel)
-				err = UpdateOCIArtifacts(regModel, outputDir)
-				if err != nil {
-					log.Printf("  Warning: Failed to update OCI artifacts for %s: %v", regModel, err)
-				} else {
-					log.Printf("  Successfully updated OCI artifacts for: %s", regModel)
+		// Use repository tags as additional enrichment: Apply if no YAML frontmatter tags were found
+		// This will merge with existing modelcard tags (like "validated"/"featured") during update phase
+		if enriched.Tags.Source == "null" && len(hfDetails.Tags) > 0 {
+			log.Printf("  No YAML frontmatter tags found, using filtered repository tags")
+			// Filter out language codes, arxiv references, and other non-tag metadata
+			filteredTags := huggingface.FilterTagsForCleanTagList(hfDetails.Tags)
+			if len(filteredTags) > 0 {
+				enriched.Tags = metadata.CreateMetadataSource(filteredTags, "huggingface.tags")
+				log.Printf("  Using filtered repository tags: %v", filteredTags)
+			}
+		} else if enriched.Tags.Source == "modelcard.regex" && len(hfDetails.Tags) > 0 {
+			log.Printf("  Found modelcard tags, merging with filtered repository tags")
+			// Filter out language codes, arxiv references, and other non-tag metadata
+			filteredTags := huggingface.FilterTagsForCleanTagList(hfDetails.Tags)
+			if len(filteredTags) > 0 {
+				// Merge existing modelcard tags with HuggingFace tags
+				existingTags := enriched.Tags.Value.([]string)
+				allTags := make([]string, 0)
+
+				// First add existing tags
+				allTags = append(allTags, existingTags...)
+
+				// Then add new tags, avoiding duplicates
+				for _, newTag := range filteredTags {
+					found := false
+					for _, existingTag := range allTags {
+						if existingTag == newTag {
+							found = true
+							break
+						}
+					}
+					if !found {
+						allTags = append(allTags, newTag)
+					}
 				}
+
+				enriched.Tags = metadata.CreateMetadataSource(allTags, "huggingface.tags")
+				log.Printf("  Merged modelcard + repository tags: %v", allTags)
 			}
+		}
 
-			matchCount++
+		// Retrieve the repository license file when the license is unknown or "other",
+		// so the catalog never points at an empty license
+		if needsLicenseFile(&enriched) {
+			storeLicenseFile(ctx, e.HuggingFace, &enriched, bestMatch.Name, regModel, e.Output)
 		}
 
-	}
+		// Look up vLLM recommended configuration by exact model name match
+		if vllmIndex != nil && enriched.HuggingFaceModel != "" {
+			if vllmCfg := vllmIndex.GetConfig(enriched.HuggingFaceModel); vllmCfg != nil {
+				enriched.VLLMConfig = vllmCfg
+				log.Printf("  Found vLLM recommended config for: %s", enriched.HuggingFaceModel)
+			}
+		}
 
-	// Clean up the old enriched metadata file if it exists
-	_ = os.Remove("data/enriched-model-metadata.yaml")
+		// Update the model's metadata.yaml file with enriched data
+		err = UpdateModelMetadataFile(e.Output, regModel, &enriched, e.Readme)
+		if err != nil {
+			log.Printf("  Warning: Failed to update metadata file for %s: %v", regModel, err)
+			e.Errors.Record(regModel, checkpoint.StageEnrichment, fmt.Errorf("failed to update metadata file: %w", err))
+			match.failed = true
+		} else {
+			log.Printf("  Successfully updated metadata file for: %s", regModel)
 
-	enrichmentRate := float64(matchCount) / float64(len(regModels)) * 100
+			// Also update artifacts with OCI metadata
+			log.Printf("  Updating OCI artifacts for: %s", regModel)
+			err = UpdateOCIArtifacts(ctx, e.Output, e.RegistryAuth, regModel)
+			if err != nil {
+				log.Printf("  Warning: Failed to update OCI artifacts for %s: %v", regModel, err)
+				e.Errors.Record(regModel, checkpoint.StageArtifacts, fmt.Errorf("failed to update OCI artifacts: %w", err))
+			} else {
+				log.Printf("  Successfully updated OCI artifacts for: %s", regModel)
+			}
+		}
 
-	log.Printf("Metadata enrichment complete:")
-	log.Printf("- Total registry models: %d", len(regModels))
-	log.Printf("- Successfully enriched: %d (%.1f%%)", matchCount, enrichmentRate)
-	log.Printf("- Individual metadata.yaml files have been updated with enriched data")
+		match.enriched = true
+	}
 
-	return nil
+	return match
 }
 
-// UpdateAllModelsWithOCIArtifacts updates all existing models with OCI artifact metadata
-func UpdateAllModelsWithOCIArtifacts(modelsIndexPath, outputDir string) error {
+// UpdateAllModelsWithOCIArtifacts updates all existing models in opts.Output with OCI artifact
+// metadata. It stops after the model in flight when ctx is canceled.
+func UpdateAllModelsWithOCIArtifacts(ctx context.Context, modelsIndexPath string, opts Options) error {
+	e := newEnricher(opts)
 	log.Println("Updating all existing models with OCI artifact metadata...")
 
 	// Load all models from the index
@@ -617,18 +956,27 @@ func UpdateAllModelsWithOCIArtifacts(modelsIndexPath, outputDir string) error {
 
 	// Update each model that has existing metadata
 	for _, regModel := range regModels {
-		// Check if metadata file exists
-		sanitizedName := utils.SanitizeManifestRef(regModel)
-		metadataPath := fmt.Sprintf("%s/%s/models/metadata.yaml", outputDir, sanitizedName)
+		if ctx.Err() != nil {
+			log.Printf("Interrupted, not updating the remaining models")
+			break
+		}
+		if e.Checkpoint.Done(regModel, checkpoint.StageArtifacts) {
+			continue
+		}
 
-		if _, err := os.Stat(metadataPath); err == nil {
+		// Check if metadata file exists
+		if _, err := e.Output.Stat(outputfs.ModelPath(regModel, "metadata.yaml")); err == nil {
 			log.Printf("  Updating OCI artifacts for: %s", regModel)
-			err = UpdateOCIArtifacts(regModel, outputDir)
+			err = UpdateOCIArtifacts(ctx, e.Output, e.RegistryAuth, regModel)
 			if err != nil {
 				log.Printf("  Warning: Failed to update OCI artifacts for %s: %v", regModel, err)
+				e.Errors.Record(regModel, checkpoint.StageArtifacts, fmt.Errorf("failed to update OCI artifacts: %w", err))
 			} else {
 				log.Printf("  Successfully updated OCI artifacts for: %s", regModel)
 				updateCount++
+				if err := e.Checkpoint.MarkDone(regModel, checkpoint.StageArtifacts); err != nil {
+					log.Printf("  Warning: %v", err)
+				}
 			}
 		} else {
 			log.Printf("  No existing metadata found for: %s", regModel)
@@ -642,16 +990,22 @@ func UpdateAllModelsWithOCIArtifacts(modelsIndexPath, outputDir string) error {
 	return nil
 }
 
-// UpdateOCIArtifacts updates the artifacts field with proper OCI metadata for existing models
-func UpdateOCIArtifacts(registryModel, outputDir string) error {
+// UpdateOCIArtifacts updates the artifacts field with proper OCI metadata for existing models,
+// reading the registry with auth
+func UpdateOCIArtifacts(ctx context.Context, output outputfs.FS, auth registry.Auth, registryModel string) error {
+	// Artifacts hosted outside registries were described by their host during extraction
+	if parsed, err := utils.ParseArtifactURI(registryModel); err == nil && parsed.Scheme != utils.URISchemeOCI {
+		return nil
+	}
+
 	// Load existing metadata
-	existingMetadata, err := metadata.LoadExistingMetadata(registryModel, outputDir)
+	existingMetadata, err := metadata.LoadExistingMetadata(output, registryModel)
 	if err != nil {
-		return fmt.Errorf("failed to load existing metadata: %v", err)
+		return fmt.Errorf("failed to load existing metadata: %w", err)
 	}
 
 	// Generate OCI artifacts from the registry model reference
-	ociArtifacts := registry.ExtractOCIArtifactsFromRegistry(registryModel)
+	ociArtifacts := registry.ExtractOCIArtifactsFromRegistry(ctx, auth, registryModel)
 
 	// Preserve existing data when updating artifacts
 	for i := range ociArtifacts {
@@ -664,6 +1018,18 @@ func UpdateOCIArtifacts(registryModel, outputDir string) error {
 				ociArtifacts[i].LastUpdateTimeSinceEpoch = existingMetadata.Artifacts[i].LastUpdateTimeSinceEpoch
 			}
 
+			// The verification status and vulnerability counts are only refreshed by extraction, and
+			// the Red Hat container catalog record by EnrichMetadataFromPyxis
+			if ociArtifacts[i].Verification == nil {
+				ociArtifacts[i].Verification = existingMetadata.Artifacts[i].Verification
+			}
+			if ociArtifacts[i].Vulnerabilities == nil {
+				ociArtifacts[i].Vulnerabilities = existingMetadata.Artifacts[i].Vulnerabilities
+			}
+			if ociArtifacts[i].RedHatCatalog == nil {
+				ociArtifacts[i].RedHatCatalog = existingMetadata.Artifacts[i].RedHatCatalog
+			}
+
 			// Preserve customProperties from existing artifacts, merging with new ones
 			// This is critical to avoid losing architecture and other metadata on re-enrichment
 			if existingMetadata.Artifacts[i].CustomProperties != nil {
@@ -686,15 +1052,12 @@ func UpdateOCIArtifacts(registryModel, outputDir string) error {
 	existingMetadata.Artifacts = ociArtifacts
 
 	// Write updated metadata back to file
-	sanitizedName := utils.SanitizeManifestRef(registryModel)
-	metadataPath := fmt.Sprintf("%s/%s/models/metadata.yaml", outputDir, sanitizedName)
-
 	updatedData, err := yaml.Marshal(existingMetadata)
 	if err != nil {
 		return fmt.Errorf("failed to marshal updated metadata: %v", err)
 	}
 
-	err = os.WriteFile(metadataPath, updatedData, 0644)
+	err = output.WriteFile(outputfs.ModelPath(registryModel, "metadata.yaml"), updatedData, 0644)
 	if err != nil {
 		return fmt.Errorf("failed to write updated metadata: %v", err)
 	}
@@ -1,37 +1,31 @@
 package enrichment
 
 import (
+	"context"
 	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/opendatahub-io/model-metadata-collection/internal/checkpoint"
+	"github.com/opendatahub-io/model-metadata-collection/internal/errorreport"
 	"github.com/opendatahub-io/model-metadata-collection/internal/huggingface"
+	"github.com/opendatahub-io/model-metadata-collection/internal/metadata"
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
 	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
 )
 
 func TestEnrichMetadataFromHuggingFace_FilesNotExist(t *testing.T) {
 	// Test with non-existent files
-	originalDir, err := os.Getwd()
-	if err != nil {
-		t.Fatalf("Failed to get working directory: %v", err)
-	}
-	defer func() {
-		err := os.Chdir(originalDir)
-		if err != nil {
-			t.Errorf("Failed to restore working directory: %v", err)
-		}
-	}()
-
 	tmpDir := t.TempDir()
-	err = os.Chdir(tmpDir)
-	if err != nil {
-		t.Fatalf("Failed to change to temp directory: %v", err)
-	}
+	output := outputfs.Dir(filepath.Join(tmpDir, "output"))
 
 	// Test with missing HuggingFace index file
-	err = EnrichMetadataFromHuggingFace("nonexistent-hf.yaml", "nonexistent-models.yaml", "output", "")
+	err := EnrichMetadataFromHuggingFace(context.Background(), filepath.Join(tmpDir, "nonexistent-hf.yaml"), filepath.Join(tmpDir, "nonexistent-models.yaml"), Options{Output: output})
 	if err == nil {
 		t.Error("Expected error when HuggingFace index file doesn't exist")
 	}
@@ -39,39 +33,20 @@ func TestEnrichMetadataFromHuggingFace_FilesNotExist(t *testing.T) {
 
 func TestEnrichMetadataFromHuggingFace_InvalidHFFile(t *testing.T) {
 	// Test with invalid HuggingFace file
-	originalDir, err := os.Getwd()
-	if err != nil {
-		t.Fatalf("Failed to get working directory: %v", err)
-	}
-	defer func() {
-		err := os.Chdir(originalDir)
-		if err != nil {
-			t.Errorf("Failed to restore working directory: %v", err)
-		}
-	}()
-
 	tmpDir := t.TempDir()
-	err = os.Chdir(tmpDir)
-	if err != nil {
-		t.Fatalf("Failed to change to temp directory: %v", err)
-	}
-
-	// Create data directory and invalid HF file
-	err = os.MkdirAll(huggingface.CollectionsDir, 0755)
-	if err != nil {
-		t.Fatalf("Failed to create data directory: %v", err)
-	}
+	output := outputfs.Dir(filepath.Join(tmpDir, "output"))
 
 	// Create invalid YAML file
+	hfIndexPath := filepath.Join(tmpDir, "hf-index.yaml")
 	invalidYAML := "invalid: yaml: content: ["
-	err = os.WriteFile(huggingface.CollectionFilePath("v1-0"), []byte(invalidYAML), 0644)
+	err := os.WriteFile(hfIndexPath, []byte(invalidYAML), 0644)
 	if err != nil {
 		t.Fatalf("Failed to create invalid HF file: %v", err)
 	}
 
 	// Test with invalid HuggingFace file — must pass the prepared file so we
 	// actually exercise the YAML parse path, not a file-not-found error.
-	err = EnrichMetadataFromHuggingFace(huggingface.CollectionFilePath("v1-0"), "nonexistent-models.yaml", "output", "")
+	err = EnrichMetadataFromHuggingFace(context.Background(), hfIndexPath, filepath.Join(tmpDir, "nonexistent-models.yaml"), Options{Output: output})
 	if err == nil {
 		t.Error("Expected error when HuggingFace index file is invalid")
 	}
@@ -82,28 +57,8 @@ func TestEnrichMetadataFromHuggingFace_InvalidHFFile(t *testing.T) {
 
 func TestEnrichMetadataFromHuggingFace_MissingModelsIndex(t *testing.T) {
 	// Test with valid HF file but missing models index
-	originalDir, err := os.Getwd()
-	if err != nil {
-		t.Fatalf("Failed to get working directory: %v", err)
-	}
-	defer func() {
-		err := os.Chdir(originalDir)
-		if err != nil {
-			t.Errorf("Failed to restore working directory: %v", err)
-		}
-	}()
-
 	tmpDir := t.TempDir()
-	err = os.Chdir(tmpDir)
-	if err != nil {
-		t.Fatalf("Failed to change to temp directory: %v", err)
-	}
-
-	// Create data directory and valid HF file
-	err = os.MkdirAll(huggingface.CollectionsDir, 0755)
-	if err != nil {
-		t.Fatalf("Failed to create data directory: %v", err)
-	}
+	output := outputfs.Dir(filepath.Join(tmpDir, "output"))
 
 	// Create valid HF index file
 	hfIndex := types.VersionIndex{
@@ -122,14 +77,15 @@ func TestEnrichMetadataFromHuggingFace_MissingModelsIndex(t *testing.T) {
 		t.Fatalf("Failed to marshal HF index: %v", err)
 	}
 
-	err = os.WriteFile(huggingface.CollectionFilePath("v1-0"), hfData, 0644)
+	hfIndexPath := filepath.Join(tmpDir, "hf-index.yaml")
+	err = os.WriteFile(hfIndexPath, hfData, 0644)
 	if err != nil {
 		t.Fatalf("Failed to create HF file: %v", err)
 	}
 
 	// Test with missing models-index.yaml — must pass the prepared valid HF file
 	// so we exercise the models index load path, not a file-not-found on the HF file.
-	err = EnrichMetadataFromHuggingFace(huggingface.CollectionFilePath("v1-0"), "nonexistent-models.yaml", "output", "")
+	err = EnrichMetadataFromHuggingFace(context.Background(), hfIndexPath, filepath.Join(tmpDir, "nonexistent-models.yaml"), Options{Output: output})
 	if err == nil {
 		t.Error("Expected error when models-index.yaml doesn't exist")
 	}
@@ -140,32 +96,8 @@ func TestEnrichMetadataFromHuggingFace_MissingModelsIndex(t *testing.T) {
 
 func TestEnrichMetadataFromHuggingFace_EmptyFiles(t *testing.T) {
 	// Test with empty but valid files
-	originalDir, err := os.Getwd()
-	if err != nil {
-		t.Fatalf("Failed to get working directory: %v", err)
-	}
-	defer func() {
-		err := os.Chdir(originalDir)
-		if err != nil {
-			t.Errorf("Failed to restore working directory: %v", err)
-		}
-	}()
-
 	tmpDir := t.TempDir()
-	err = os.Chdir(tmpDir)
-	if err != nil {
-		t.Fatalf("Failed to change to temp directory: %v", err)
-	}
-
-	// Create collections directory and data directory
-	err = os.MkdirAll(huggingface.CollectionsDir, 0755)
-	if err != nil {
-		t.Fatalf("Failed to create collections directory: %v", err)
-	}
-	err = os.MkdirAll("data", 0755)
-	if err != nil {
-		t.Fatalf("Failed to create data directory: %v", err)
-	}
+	output := outputfs.Dir(filepath.Join(tmpDir, "output"))
 
 	// Create empty HF index file
 	hfIndex := types.VersionIndex{
@@ -178,7 +110,8 @@ func TestEnrichMetadataFromHuggingFace_EmptyFiles(t *testing.T) {
 		t.Fatalf("Failed to marshal HF index: %v", err)
 	}
 
-	err = os.WriteFile(huggingface.CollectionFilePath("v1-0"), hfData, 0644)
+	hfIndexPath := filepath.Join(tmpDir, "hf-index.yaml")
+	err = os.WriteFile(hfIndexPath, hfData, 0644)
 	if err != nil {
 		t.Fatalf("Failed to create HF file: %v", err)
 	}
@@ -193,36 +126,25 @@ func TestEnrichMetadataFromHuggingFace_EmptyFiles(t *testing.T) {
 		t.Fatalf("Failed to marshal models config: %v", err)
 	}
 
-	err = os.WriteFile("data/models-index.yaml", modelsData, 0644)
+	modelsIndexPath := filepath.Join(tmpDir, "models-index.yaml")
+	err = os.WriteFile(modelsIndexPath, modelsData, 0644)
 	if err != nil {
 		t.Fatalf("Failed to create models file: %v", err)
 	}
 
 	// Test with empty files - should succeed
-	err = EnrichMetadataFromHuggingFace(huggingface.CollectionFilePath("v1-0"), "data/models-index.yaml", "output", "")
+	err = EnrichMetadataFromHuggingFace(context.Background(), hfIndexPath, modelsIndexPath, Options{Output: output})
 	if err != nil {
 		t.Errorf("Unexpected error with empty files: %v", err)
 	}
+	if _, err := output.Stat(MatchReportFileName); err != nil {
+		t.Errorf("Expected the match report in the output directory: %v", err)
+	}
 }
 
 func TestUpdateModelMetadataFile_NoExistingFile(t *testing.T) {
 	// Test updating metadata file when it doesn't exist yet
-	originalDir, err := os.Getwd()
-	if err != nil {
-		t.Fatalf("Failed to get working directory: %v", err)
-	}
-	defer func() {
-		err := os.Chdir(originalDir)
-		if err != nil {
-			t.Errorf("Failed to restore working directory: %v", err)
-		}
-	}()
-
-	tmpDir := t.TempDir()
-	err = os.Chdir(tmpDir)
-	if err != nil {
-		t.Fatalf("Failed to change to temp directory: %v", err)
-	}
+	output := outputfs.Dir(t.TempDir())
 
 	// Test data
 	registryModel := "registry.example.com/test/model:latest"
@@ -236,48 +158,32 @@ func TestUpdateModelMetadataFile_NoExistingFile(t *testing.T) {
 	}
 
 	// Create output directory structure
-	outputDir := "output/registry.example.com_test_model_latest/models"
-	err = os.MkdirAll(outputDir, 0755)
+	err := output.MkdirAll(outputfs.ModelPath(registryModel), 0755)
 	if err != nil {
 		t.Fatalf("Failed to create output directory: %v", err)
 	}
 
 	// Call UpdateModelMetadataFile
-	err = UpdateModelMetadataFile(registryModel, enrichedData, "output")
+	err = UpdateModelMetadataFile(output, registryModel, enrichedData, metadata.ReadmeOptions{})
 	if err != nil {
 		t.Errorf("UpdateModelMetadataFile failed: %v", err)
 	}
 
 	// Verify enrichment.yaml was created
-	enrichmentPath := "output/registry.example.com_test_model_latest/models/enrichment.yaml"
-	if _, err := os.Stat(enrichmentPath); os.IsNotExist(err) {
+	enrichmentPath := outputfs.ModelPath(registryModel, "enrichment.yaml")
+	if _, err := output.Stat(enrichmentPath); os.IsNotExist(err) {
 		t.Errorf("Enrichment file was not created at %s", enrichmentPath)
 	}
 }
 
 func TestUpdateModelMetadataFile_WithExistingFile(t *testing.T) {
 	// Test updating metadata file when it already exists
-	originalDir, err := os.Getwd()
-	if err != nil {
-		t.Fatalf("Failed to get working directory: %v", err)
-	}
-	defer func() {
-		err := os.Chdir(originalDir)
-		if err != nil {
-			t.Errorf("Failed to restore working directory: %v", err)
-		}
-	}()
-
-	tmpDir := t.TempDir()
-	err = os.Chdir(tmpDir)
-	if err != nil {
-		t.Fatalf("Failed to change to temp directory: %v", err)
-	}
+	output := outputfs.Dir(t.TempDir())
 
 	// Create output directory structure
 	registryModel := "registry.example.com/test/model:latest"
-	outputDir := "output/registry.example.com_test_model_latest/models"
-	err = os.MkdirAll(outputDir, 0755)
+	modelDir := outputfs.ModelPath(registryModel)
+	err := output.MkdirAll(modelDir, 0755)
 	if err != nil {
 		t.Fatalf("Failed to create output directory: %v", err)
 	}
@@ -296,8 +202,8 @@ func TestUpdateModelMetadataFile_WithExistingFile(t *testing.T) {
 		t.Fatalf("Failed to marshal existing metadata: %v", err)
 	}
 
-	metadataPath := outputDir + "/metadata.yaml"
-	err = os.WriteFile(metadataPath, metadataData, 0644)
+	metadataPath := modelDir + "/metadata.yaml"
+	err = output.WriteFile(metadataPath, metadataData, 0644)
 	if err != nil {
 		t.Fatalf("Failed to create existing metadata file: %v", err)
 	}
@@ -313,51 +219,26 @@ func TestUpdateModelMetadataFile_WithExistingFile(t *testing.T) {
 	}
 
 	// Call UpdateModelMetadataFile
-	err = UpdateModelMetadataFile(registryModel, enrichedData, "output")
+	err = UpdateModelMetadataFile(output, registryModel, enrichedData, metadata.ReadmeOptions{})
 	if err != nil {
 		t.Errorf("UpdateModelMetadataFile failed: %v", err)
 	}
 
 	// Verify files were created/updated
-	enrichmentPath := outputDir + "/enrichment.yaml"
-	if _, err := os.Stat(enrichmentPath); os.IsNotExist(err) {
+	enrichmentPath := modelDir + "/enrichment.yaml"
+	if _, err := output.Stat(enrichmentPath); os.IsNotExist(err) {
 		t.Errorf("Enrichment file was not created")
 	}
 
 	// Verify metadata file still exists
-	if _, err := os.Stat(metadataPath); os.IsNotExist(err) {
+	if _, err := output.Stat(metadataPath); os.IsNotExist(err) {
 		t.Errorf("Metadata file should still exist")
 	}
 }
 
 func TestUpdateAllModelsWithOCIArtifacts(t *testing.T) {
 	// Test UpdateAllModelsWithOCIArtifacts function
-	originalDir, err := os.Getwd()
-	if err != nil {
-		t.Fatalf("Failed to get working directory: %v", err)
-	}
-	defer func() {
-		err := os.Chdir(originalDir)
-		if err != nil {
-			t.Errorf("Failed to restore working directory: %v", err)
-		}
-	}()
-
 	tmpDir := t.TempDir()
-	err = os.Chdir(tmpDir)
-	if err != nil {
-		t.Fatalf("Failed to change to temp directory: %v", err)
-	}
-
-	// Create directories
-	err = os.MkdirAll(huggingface.CollectionsDir, 0755)
-	if err != nil {
-		t.Fatalf("Failed to create collections directory: %v", err)
-	}
-	err = os.MkdirAll("data", 0755)
-	if err != nil {
-		t.Fatalf("Failed to create data directory: %v", err)
-	}
 
 	// Create models config with test models
 	modelsConfig := types.ModelsConfig{
@@ -372,13 +253,14 @@ func TestUpdateAllModelsWithOCIArtifacts(t *testing.T) {
 		t.Fatalf("Failed to marshal models config: %v", err)
 	}
 
-	err = os.WriteFile("data/models-index.yaml", modelsData, 0644)
+	modelsIndexPath := filepath.Join(tmpDir, "models-index.yaml")
+	err = os.WriteFile(modelsIndexPath, modelsData, 0644)
 	if err != nil {
 		t.Fatalf("Failed to create models file: %v", err)
 	}
 
 	// Call UpdateAllModelsWithOCIArtifacts
-	err = UpdateAllModelsWithOCIArtifacts("data/models-index.yaml", "output")
+	err = UpdateAllModelsWithOCIArtifacts(context.Background(), modelsIndexPath, Options{Output: outputfs.Dir(filepath.Join(tmpDir, "output"))})
 	// This will likely fail due to network calls to registries, but we test that it doesn't panic
 	// and that it attempts to process the models
 	if err != nil {
@@ -388,7 +270,7 @@ func TestUpdateAllModelsWithOCIArtifacts(t *testing.T) {
 
 func TestUpdateOCIArtifacts_InvalidModel(t *testing.T) {
 	// Test UpdateOCIArtifacts with invalid model reference
-	err := UpdateOCIArtifacts("invalid-model-reference", "output")
+	err := UpdateOCIArtifacts(context.Background(), outputfs.Dir(t.TempDir()), nil, "invalid-model-reference")
 	if err == nil {
 		t.Error("Expected error for invalid model reference")
 	}
@@ -509,3 +391,302 @@ func TestIsLowQualityModelName(t *testing.T) {
 		})
 	}
 }
+
+func TestNeedsLicenseFile(t *testing.T) {
+	tests := []struct {
+		name        string
+		license     types.MetadataSource
+		licenseLink types.MetadataSource
+		expected    bool
+	}{
+		{
+			name:        "missing license",
+			license:     types.MetadataSource{Source: "null"},
+			licenseLink: types.MetadataSource{Source: "null"},
+			expected:    true,
+		},
+		{
+			name:        "other license without link",
+			license:     types.MetadataSource{Value: "Other", Source: "huggingface.yaml"},
+			licenseLink: types.MetadataSource{Source: "null"},
+			expected:    true,
+		},
+		{
+			name:        "other license with link",
+			license:     types.MetadataSource{Value: "other", Source: "huggingface.yaml"},
+			licenseLink: types.MetadataSource{Value: "https://example.com/license", Source: "huggingface.yaml"},
+			expected:    false,
+		},
+		{
+			name:        "well-known license",
+			license:     types.MetadataSource{Value: "apache-2.0", Source: "huggingface.yaml"},
+			licenseLink: types.MetadataSource{Source: "null"},
+			expected:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enriched := &types.EnrichedModelMetadata{License: tt.license, LicenseLink: tt.licenseLink}
+			if got := needsLicenseFile(enriched); got != tt.expected {
+				t.Errorf("needsLicenseFile() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestModelcardTaskSource(t *testing.T) {
+	card := "# Granite Embedding\n\n## Embeddings\n"
+	if got := modelcardTaskSource([]string{"text-embedding"}, card); got != metadata.TaskSourceHeadings {
+		t.Errorf("Expected inferred tasks to get %s, got %s", metadata.TaskSourceHeadings, got)
+	}
+	if got := modelcardTaskSource([]string{"text-generation"}, card); got != "modelcard.regex" {
+		t.Errorf("Expected stated tasks to get modelcard.regex, got %s", got)
+	}
+	if !isInferredTaskSource(metadata.TaskSourceInferred) || isInferredTaskSource("modelcard.regex") {
+		t.Error("isInferredTaskSource() misclassified a source")
+	}
+}
+
+func TestStoreLicenseFile_FromSnapshot(t *testing.T) {
+	snapshotDir := t.TempDir()
+	outputDir := t.TempDir()
+
+	hf := huggingface.NewClient(huggingface.Options{SnapshotDir: snapshotDir})
+
+	modelDir := filepath.Join(snapshotDir, "RedHatAI", "custom-license-model")
+	if err := os.MkdirAll(modelDir, 0755); err != nil {
+		t.Fatalf("Failed to create snapshot dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(modelDir, "LICENSE.md"), []byte("Custom license terms"), 0644); err != nil {
+		t.Fatalf("Failed to write license file: %v", err)
+	}
+
+	regModel := "registry.example.com/test/custom-license-model:1.0"
+	enriched := &types.EnrichedModelMetadata{
+		License:     types.MetadataSource{Value: "other", Source: "huggingface.yaml"},
+		LicenseLink: types.MetadataSource{Source: "null"},
+	}
+
+	storeLicenseFile(context.Background(), hf, enriched, "RedHatAI/custom-license-model", regModel, outputfs.Dir(outputDir))
+
+	expectedLink := "https://huggingface.co/RedHatAI/custom-license-model/blob/main/LICENSE.md"
+	if enriched.LicenseLink.Value != expectedLink || enriched.LicenseLink.Source != "huggingface.license" {
+		t.Errorf("Unexpected license link: %+v", enriched.LicenseLink)
+	}
+
+	storedPath := filepath.Join(outputDir, utils.SanitizeManifestRef(regModel), "models", "LICENSE.md")
+	content, err := os.ReadFile(storedPath)
+	if err != nil {
+		t.Fatalf("Expected license file to be stored at %s: %v", storedPath, err)
+	}
+	if string(content) != "Custom license terms" {
+		t.Errorf("Unexpected stored license content: %q", content)
+	}
+}
+
+func TestStoreLicenseFile_DetectsLicense(t *testing.T) {
+	snapshotDir := t.TempDir()
+	hf := huggingface.NewClient(huggingface.Options{SnapshotDir: snapshotDir})
+
+	modelDir := filepath.Join(snapshotDir, "RedHatAI", "unlabeled-model")
+	if err := os.MkdirAll(modelDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	mit := "MIT License\n\nCopyright (c) 2025 Example Labs\n\n" +
+		"Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated " +
+		"documentation files (the \"Software\"), to deal in the Software without restriction, including without limitation " +
+		"the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to " +
+		"permit persons to whom the Software is furnished to do so, subject to the following conditions:\n\n" +
+		"The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.\n\n" +
+		"THE SOFTWARE IS PROVIDED \"AS IS\", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE " +
+		"WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR " +
+		"COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR " +
+		"OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.\n"
+	if err := os.WriteFile(filepath.Join(modelDir, "LICENSE"), []byte(mit), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	enriched := &types.EnrichedModelMetadata{
+		License:     types.MetadataSource{Source: "null"},
+		LicenseLink: types.MetadataSource{Source: "null"},
+	}
+	storeLicenseFile(context.Background(), hf, enriched, "RedHatAI/unlabeled-model", "registry.example.com/test/unlabeled-model:1.0", outputfs.Dir(t.TempDir()))
+
+	if enriched.License.Value != "mit" || enriched.License.Source != "huggingface.license-text" {
+		t.Errorf("Expected the license to be detected as mit, got %+v", enriched.License)
+	}
+	if enriched.LicenseLink.Value != "https://opensource.org/licenses/MIT" {
+		t.Errorf("Expected the canonical MIT link, got %+v", enriched.LicenseLink)
+	}
+}
+
+func TestUpdateModelMetadataFile_EvaluationsAndQuantization(t *testing.T) {
+	outputDir := t.TempDir()
+	registryModel := "registry.example.com/test/model-gguf:latest"
+	modelDir := filepath.Join(outputDir, utils.SanitizeManifestRef(registryModel), "models")
+	if err := os.MkdirAll(modelDir, 0755); err != nil {
+		t.Fatalf("Failed to create output directory: %v", err)
+	}
+
+	evaluations := []types.Evaluation{{Benchmark: "MMLU", Metric: "acc", Score: 0.61, Task: "text-generation"}}
+	quantization := &types.QuantizationInfo{Format: types.QuantizationFormatGGUF, Type: "Q4_K_M", ContextLength: 4096}
+	null := types.MetadataSource{Source: "null"}
+	enrichedData := &types.EnrichedModelMetadata{
+		RegistryModel:        registryModel,
+		EnrichmentStatus:     "enriched",
+		Name:                 null,
+		Provider:             null,
+		Description:          null,
+		License:              null,
+		LicenseLink:          null,
+		Language:             null,
+		LastModified:         null,
+		CreateTimeSinceEpoch: null,
+		Tags:                 null,
+		Tasks:                null,
+		ValidatedOn:          null,
+		HardwareTag:          null,
+		ValidatedTasks:       null,
+		TrainingDatasets:     null,
+		Evaluations:          types.MetadataSource{Value: evaluations, Source: "huggingface.api"},
+		Quantization:         types.MetadataSource{Value: quantization, Source: "huggingface.gguf"},
+	}
+
+	if err := UpdateModelMetadataFile(outputfs.Dir(outputDir), registryModel, enrichedData, metadata.ReadmeOptions{}); err != nil {
+		t.Fatalf("UpdateModelMetadataFile failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(modelDir, "metadata.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to read metadata.yaml: %v", err)
+	}
+	var result types.ExtractedMetadata
+	if err := yaml.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Failed to parse metadata.yaml: %v", err)
+	}
+
+	if len(result.Evaluations) != 1 || result.Evaluations[0] != evaluations[0] {
+		t.Errorf("Expected evaluations %+v, got %+v", evaluations, result.Evaluations)
+	}
+	if result.Quantization == nil || *result.Quantization != *quantization {
+		t.Errorf("Expected quantization %+v, got %+v", quantization, result.Quantization)
+	}
+
+	enrichmentData, err := os.ReadFile(filepath.Join(modelDir, "enrichment.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to read enrichment.yaml: %v", err)
+	}
+	for _, expected := range []string{"evaluations: huggingface.api", "quantization: huggingface.gguf"} {
+		if !strings.Contains(string(enrichmentData), expected) {
+			t.Errorf("Expected enrichment.yaml to contain %q, got:\n%s", expected, enrichmentData)
+		}
+	}
+}
+
+func TestClearTextParsedSources(t *testing.T) {
+	enriched := types.EnrichedModelMetadata{
+		Name:     metadata.CreateMetadataSource("Qwen2.5-7B-Instruct", "modelcard.regex"),
+		Provider: metadata.CreateMetadataSource("阿里云", "modelcard.regex"),
+		License:  metadata.CreateMetadataSource("apache-2.0", "modelcard.yaml"),
+		Tasks:    metadata.CreateMetadataSource([]string{"text-generation"}, metadata.TaskSourceInferred),
+	}
+
+	clearTextParsedSources(&enriched)
+
+	if enriched.Provider.Source != "null" || enriched.Tasks.Source != "null" {
+		t.Errorf("Expected text-parsed provider and tasks to be cleared, got %s and %s", enriched.Provider.Source, enriched.Tasks.Source)
+	}
+	if enriched.License.Source != "modelcard.yaml" {
+		t.Errorf("Expected frontmatter license to be kept, got %s", enriched.License.Source)
+	}
+	if enriched.Name.Source != "modelcard.regex" {
+		t.Errorf("Expected the name to be kept, got %s", enriched.Name.Source)
+	}
+}
+
+func TestEnrichModel_HFEntryUsesItsRepository(t *testing.T) {
+	snapshotDir := t.TempDir()
+	hf := huggingface.NewClient(huggingface.Options{SnapshotDir: snapshotDir})
+
+	modelDir := filepath.Join(snapshotDir, "RedHatAI", "hf-only-model")
+	if err := os.MkdirAll(modelDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(modelDir, "model_info.json"), []byte(`{"id": "RedHatAI/hf-only-model", "license": "apache-2.0"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	e := newEnricher(Options{Output: outputfs.Dir(t.TempDir()), HuggingFace: hf})
+	// The repository is not in the HuggingFace index, and the index holds a similarly named model
+	hfIndex := types.VersionIndex{Models: []types.ModelIndex{huggingface.IndexEntry("RedHatAI/hf-only-model-FP8")}}
+	match := e.enrichModel(context.Background(), "hf://RedHatAI/hf-only-model:main", hfIndex, nil)
+
+	if match.hfModel != "RedHatAI/hf-only-model" || match.score != 1.0 || !match.enriched {
+		t.Errorf("enrichModel() = %+v, want an enriched match of the entry's repository", match)
+	}
+}
+
+func TestEnrichMetadataFromHuggingFace_Resume(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputDir := filepath.Join(tmpDir, "output")
+	output := outputfs.Dir(outputDir)
+	failingRef := "hf://RedHatAI/hf-only-model:main"
+	resumedRef := "registry.example.com/org/resumed:1.0"
+
+	// The snapshot has no README, so enriching the model records an error
+	snapshotDir := filepath.Join(tmpDir, "snapshot")
+	writeTestFile(t, filepath.Join(snapshotDir, "RedHatAI", "hf-only-model", "model_info.json"), `{"id": "RedHatAI/hf-only-model"}`)
+	hfIndexPath := filepath.Join(tmpDir, "hf-index.yaml")
+	writeTestFile(t, hfIndexPath, "version: v1.0\nmodels: []\n")
+	modelsIndexPath := filepath.Join(tmpDir, "models-index.yaml")
+	writeTestFile(t, modelsIndexPath, "models:\n  - type: hf\n    uri: "+failingRef+"\n  - type: oci\n    uri: "+resumedRef+"\n")
+
+	previous := NewMatchReport(DefaultMatchThreshold)
+	previous.Add(resumedRef, "Org/resumed", 0.9)
+	if err := previous.Write(output); err != nil {
+		t.Fatal(err)
+	}
+	cp, err := checkpoint.New(outputDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cp.MarkDone(resumedRef, checkpoint.StageEnrichment); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := Options{
+		Output:      output,
+		Checkpoint:  cp,
+		Errors:      errorreport.New(),
+		HuggingFace: huggingface.NewClient(huggingface.Options{SnapshotDir: snapshotDir}),
+	}
+	if err := EnrichMetadataFromHuggingFace(context.Background(), hfIndexPath, modelsIndexPath, opts); err != nil {
+		t.Fatalf("EnrichMetadataFromHuggingFace() error = %v", err)
+	}
+
+	if cp.Done(failingRef, checkpoint.StageEnrichment) {
+		t.Error("a model whose enrichment failed should not be checkpointed")
+	}
+	report, err := ReadMatchReport(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var models []string
+	for _, entry := range report.Matched {
+		models = append(models, entry.RegistryModel)
+	}
+	if want := []string{failingRef, resumedRef}; report.TotalModels != 2 || !reflect.DeepEqual(models, want) {
+		t.Errorf("match report lists %v of %d models, want %v", models, report.TotalModels, want)
+	}
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
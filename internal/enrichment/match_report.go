@@ -0,0 +1,112 @@
+package enrichment
+
+import (
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+)
+
+// DefaultMatchThreshold is the minimum similarity score required to treat a
+// HuggingFace model as a match for a registry model
+const DefaultMatchThreshold = 0.5
+
+// MatchReportFileName is the name of the match report written to the output directory
+const MatchReportFileName = "match-report.yaml"
+
+// MatchReportEntry records the HuggingFace candidate chosen for a single registry model
+type MatchReportEntry struct {
+	RegistryModel    string  `yaml:"registry_model"`
+	HuggingFaceModel string  `yaml:"huggingface_model,omitempty"`
+	Score            float64 `yaml:"score"`
+	Confidence       string  `yaml:"confidence,omitempty"`
+}
+
+// MatchReport summarizes registry-to-HuggingFace name matching for a single enrichment run
+type MatchReport struct {
+	Threshold      float64            `yaml:"threshold"`
+	TotalModels    int                `yaml:"total_models"`
+	MatchedCount   int                `yaml:"matched_count"`
+	UnmatchedCount int                `yaml:"unmatched_count"`
+	Matched        []MatchReportEntry `yaml:"matched"`
+	Unmatched      []MatchReportEntry `yaml:"unmatched"`
+}
+
+// NewMatchReport creates an empty match report for the given threshold
+func NewMatchReport(threshold float64) *MatchReport {
+	return &MatchReport{
+		Threshold: threshold,
+		Matched:   []MatchReportEntry{},
+		Unmatched: []MatchReportEntry{},
+	}
+}
+
+// Add records the best candidate for a registry model. Models scoring below the
+// threshold are listed as unmatched along with the closest candidate considered.
+func (r *MatchReport) Add(registryModel, hfModel string, score float64) {
+	entry := MatchReportEntry{
+		RegistryModel:    registryModel,
+		HuggingFaceModel: hfModel,
+		Score:            score,
+	}
+
+	r.TotalModels++
+	if hfModel != "" && score >= r.Threshold {
+		entry.Confidence = matchConfidence(score)
+		r.Matched = append(r.Matched, entry)
+		r.MatchedCount++
+		return
+	}
+
+	r.Unmatched = append(r.Unmatched, entry)
+	r.UnmatchedCount++
+}
+
+// ReadMatchReport reads match-report.yaml from the output directory
+func ReadMatchReport(output outputfs.FS) (*MatchReport, error) {
+	data, err := output.ReadFile(MatchReportFileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read match report: %v", err)
+	}
+	var report MatchReport
+	if err := yaml.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse match report: %v", err)
+	}
+	return &report, nil
+}
+
+// Write saves the report to match-report.yaml in the output directory.
+// Unmatched models are sorted by ascending score so the weakest candidates come first.
+func (r *MatchReport) Write(output outputfs.FS) error {
+	sort.SliceStable(r.Matched, func(i, j int) bool {
+		return r.Matched[i].RegistryModel < r.Matched[j].RegistryModel
+	})
+	sort.SliceStable(r.Unmatched, func(i, j int) bool {
+		return r.Unmatched[i].Score < r.Unmatched[j].Score
+	})
+
+	data, err := yaml.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to marshal match report: %v", err)
+	}
+
+	if err := output.MkdirAll(".", 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %v", err)
+	}
+
+	if err := output.WriteFile(MatchReportFileName, data, 0644); err != nil {
+		return fmt.Errorf("failed to write match report: %v", err)
+	}
+
+	return nil
+}
+
+// matchConfidence maps a similarity score to the confidence level stored in enrichment.yaml
+func matchConfidence(score float64) string {
+	if score >= 0.8 {
+		return "high"
+	}
+	return "medium"
+}
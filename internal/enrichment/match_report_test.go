@@ -0,0 +1,77 @@
+package enrichment
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+)
+
+func TestMatchReport_Add(t *testing.T) {
+	report := NewMatchReport(0.6)
+
+	report.Add("registry.example.com/model-a:1.0", "Org/model-a", 1.0)
+	report.Add("registry.example.com/model-b:1.0", "Org/model-b-variant", 0.65)
+	report.Add("registry.example.com/model-c:1.0", "Org/unrelated", 0.4)
+	report.Add("registry.example.com/model-d:1.0", "", 0.0)
+
+	if report.TotalModels != 4 {
+		t.Errorf("Expected 4 total models, got %d", report.TotalModels)
+	}
+	if report.MatchedCount != 2 || len(report.Matched) != 2 {
+		t.Fatalf("Expected 2 matched models, got %d", report.MatchedCount)
+	}
+	if report.UnmatchedCount != 2 || len(report.Unmatched) != 2 {
+		t.Fatalf("Expected 2 unmatched models, got %d", report.UnmatchedCount)
+	}
+
+	if report.Matched[0].Confidence != "high" {
+		t.Errorf("Expected high confidence for score 1.0, got %q", report.Matched[0].Confidence)
+	}
+	if report.Matched[1].Confidence != "medium" {
+		t.Errorf("Expected medium confidence for score 0.65, got %q", report.Matched[1].Confidence)
+	}
+	if report.Unmatched[0].HuggingFaceModel != "Org/unrelated" {
+		t.Errorf("Expected unmatched entry to keep best candidate, got %q", report.Unmatched[0].HuggingFaceModel)
+	}
+	if report.Unmatched[0].Confidence != "" {
+		t.Errorf("Expected no confidence for unmatched entry, got %q", report.Unmatched[0].Confidence)
+	}
+}
+
+func TestMatchReport_Write(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "output")
+
+	report := NewMatchReport(DefaultMatchThreshold)
+	report.Add("registry.example.com/model-b:1.0", "Org/model-b", 0.9)
+	report.Add("registry.example.com/model-a:1.0", "Org/model-a", 0.7)
+	report.Add("registry.example.com/model-c:1.0", "Org/model-x", 0.3)
+	report.Add("registry.example.com/model-d:1.0", "Org/model-y", 0.1)
+
+	if err := report.Write(outputfs.Dir(outputDir)); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(outputDir, MatchReportFileName))
+	if err != nil {
+		t.Fatalf("Failed to read match report: %v", err)
+	}
+
+	var written MatchReport
+	if err := yaml.Unmarshal(data, &written); err != nil {
+		t.Fatalf("Failed to parse match report: %v", err)
+	}
+
+	if written.Threshold != DefaultMatchThreshold {
+		t.Errorf("Expected threshold %.2f, got %.2f", DefaultMatchThreshold, written.Threshold)
+	}
+	if len(written.Matched) != 2 || written.Matched[0].RegistryModel != "registry.example.com/model-a:1.0" {
+		t.Errorf("Expected matched models sorted by name, got %+v", written.Matched)
+	}
+	if len(written.Unmatched) != 2 || written.Unmatched[0].Score != 0.1 {
+		t.Errorf("Expected unmatched models sorted by ascending score, got %+v", written.Unmatched)
+	}
+}
@@ -0,0 +1,245 @@
+package enrichment
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/metadata"
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+)
+
+// ProvenanceFileName is the name of the per-model provenance file written next to metadata.yaml
+const ProvenanceFileName = "provenance.yaml"
+
+// Provenance categories group the detailed MetadataSource strings into the broad origins
+// auditors care about
+const (
+	ProvenanceModelcard              = "modelcard"
+	ProvenanceHuggingFaceFrontmatter = "huggingface-frontmatter"
+	ProvenanceHuggingFaceAPI         = "huggingface-api"
+	ProvenanceHuggingFaceReadme      = "huggingface-readme"
+	ProvenanceRegistry               = "registry"
+	ProvenanceRedHatCatalog          = "redhat-catalog"
+	ProvenanceGenerated              = "generated"
+	ProvenanceUnknown                = "unknown"
+	ProvenanceNone                   = "none"
+)
+
+// Confidence levels of extracted values: exact values were read from structured data (frontmatter,
+// the HuggingFace API, config.json, GGUF headers, the registry, the Red Hat container catalog), heuristic values were matched in
+// free text, and guesses were inferred or generated when nothing stated them
+const (
+	ConfidenceExact     = "exact"
+	ConfidenceHeuristic = "heuristic"
+	ConfidenceGuess     = "guess"
+)
+
+// FieldProvenance records where a single metadata field came from and how much to trust it
+type FieldProvenance struct {
+	Category   string `yaml:"category"`
+	Source     string `yaml:"source"`
+	Confidence string `yaml:"confidence,omitempty"`
+}
+
+// ModelProvenance is the machine-readable provenance report for one model
+type ModelProvenance struct {
+	RegistryModel    string                     `yaml:"registry_model"`
+	HuggingFaceModel string                     `yaml:"huggingface_model,omitempty"`
+	MatchConfidence  string                     `yaml:"match_confidence,omitempty"`
+	Fields           map[string]FieldProvenance `yaml:"fields"`
+}
+
+// enrichmentFile mirrors the enrichment.yaml written by UpdateModelMetadataFile
+type enrichmentFile struct {
+	HuggingFaceModel string            `yaml:"huggingface_model"`
+	MatchConfidence  string            `yaml:"match_confidence"`
+	DataSources      map[string]string `yaml:"data_sources"`
+}
+
+// enrichmentSourceKeys maps metadata.yaml field names to their enrichment.yaml data_sources keys
+var enrichmentSourceKeys = map[string]string{
+	"name":                     "name",
+	"provider":                 "provider",
+	"description":              "description",
+	"readme":                   "readme",
+	"language":                 "language",
+	"license":                  "license",
+	"licenseLink":              "license_link",
+	"tags":                     "tags",
+	"tasks":                    "tasks",
+	"createTimeSinceEpoch":     "create_time_since_epoch",
+	"lastUpdateTimeSinceEpoch": "last_modified",
+	"validatedOn":              "validated_on",
+	"hardwareTag":              "hardware_tag",
+	"validatedTasks":           "validated_tasks",
+	"trainingDatasets":         "training_datasets",
+	"baseModel":                "base_model",
+	"evaluations":              "evaluations",
+	"maxContextLength":         "max_context_length",
+}
+
+// provenanceCategory maps a detailed MetadataSource string to its provenance category
+func provenanceCategory(source string) string {
+	switch {
+	case source == "" || source == "null":
+		return ProvenanceNone
+	case strings.HasPrefix(source, "modelcard"):
+		return ProvenanceModelcard
+	case source == "huggingface.yaml":
+		return ProvenanceHuggingFaceFrontmatter
+	case source == "huggingface.api", source == "huggingface.tags", source == "huggingface.license", source == "huggingface.gguf", source == "huggingface.config",
+		source == "huggingface.license-text":
+		return ProvenanceHuggingFaceAPI
+	case source == "huggingface.readme", source == "huggingface.regex":
+		return ProvenanceHuggingFaceReadme
+	case source == "registry", source == "registry.gguf":
+		return ProvenanceRegistry
+	case source == pyxisRepositorySource:
+		return ProvenanceRedHatCatalog
+	case source == "generated":
+		return ProvenanceGenerated
+	default:
+		return ProvenanceUnknown
+	}
+}
+
+// sourceConfidence returns the confidence of a value from source. HuggingFace values of a
+// medium-confidence match may describe a sibling model, so they are never better than heuristic.
+func sourceConfidence(source, matchConfidence string) string {
+	var confidence string
+	switch source {
+	case "", "null":
+		return ""
+	case "modelcard.yaml", "huggingface.yaml", "huggingface.api", "huggingface.tags", "huggingface.license",
+		"huggingface.gguf", "huggingface.config", "registry", "registry.gguf", pyxisRepositorySource:
+		confidence = ConfidenceExact
+	case "modelcard.regex", "modelcard.headings", "modelcard.md", "huggingface.readme", "huggingface.regex",
+		"huggingface.license-text":
+		confidence = ConfidenceHeuristic
+	default:
+		// modelcard.inferred, generated and unknown sources
+		return ConfidenceGuess
+	}
+	if confidence == ConfidenceExact && matchConfidence == "medium" && strings.HasPrefix(source, "huggingface.") {
+		return ConfidenceHeuristic
+	}
+	return confidence
+}
+
+// BuildModelProvenance determines the source of every metadata field for a model by combining
+// its metadata.yaml with the data sources recorded in enrichment.yaml. Fields populated without
+// an enrichment source were extracted from the container modelcard.
+func BuildModelProvenance(output outputfs.FS, registryModel string) (*ModelProvenance, error) {
+	existing, err := metadata.LoadExistingMetadata(output, registryModel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load metadata: %v", err)
+	}
+
+	var enrichment enrichmentFile
+	if data, err := output.ReadFile(outputfs.ModelPath(registryModel, "enrichment.yaml")); err == nil {
+		if err := yaml.Unmarshal(data, &enrichment); err != nil {
+			log.Printf("  Warning: Failed to parse enrichment.yaml for %s: %v", registryModel, err)
+		}
+	}
+
+	fallbackSource := "unknown"
+	if _, err := output.Stat(outputfs.ModelPath(registryModel, "modelcard.md")); err == nil {
+		fallbackSource = "modelcard.md"
+	}
+
+	populated := map[string]bool{
+		"name":                     existing.Name != nil && *existing.Name != "",
+		"provider":                 existing.Provider != nil && *existing.Provider != "",
+		"description":              existing.Description != nil && *existing.Description != "",
+		"readme":                   existing.Readme != nil && *existing.Readme != "",
+		"language":                 len(existing.Language) > 0,
+		"license":                  existing.License != nil && *existing.License != "",
+		"licenseLink":              existing.LicenseLink != nil && *existing.LicenseLink != "",
+		"tags":                     len(existing.Tags) > 0,
+		"tasks":                    len(existing.Tasks) > 0,
+		"createTimeSinceEpoch":     existing.CreateTimeSinceEpoch != nil,
+		"lastUpdateTimeSinceEpoch": existing.LastUpdateTimeSinceEpoch != nil,
+		"validatedOn":              len(existing.ValidatedOn) > 0,
+		"hardwareTag":              len(existing.HardwareTag) > 0,
+		"validatedTasks":           len(existing.ValidatedTasks) > 0,
+		"trainingDatasets":         len(existing.TrainingDatasets) > 0,
+		"baseModel":                len(existing.BaseModel) > 0,
+		"trainingData":             existing.TrainingData != nil && *existing.TrainingData != "",
+		"evaluations":              len(existing.Evaluations) > 0,
+		"hardwareRequirements":     !existing.HardwareRequirements.IsEmpty(),
+		"responsibleUse":           !existing.ResponsibleUse.IsEmpty(),
+		"maxContextLength":         existing.MaxContextLength != nil,
+		"modelConfig":              !existing.ModelConfig.IsEmpty(),
+	}
+
+	provenance := &ModelProvenance{
+		RegistryModel:    registryModel,
+		HuggingFaceModel: enrichment.HuggingFaceModel,
+		MatchConfidence:  enrichment.MatchConfidence,
+		Fields:           make(map[string]FieldProvenance),
+	}
+
+	for field, isPopulated := range populated {
+		source := "null"
+		if isPopulated {
+			source = enrichment.DataSources[enrichmentSourceKeys[field]]
+			if source == "" {
+				source = fallbackSource
+			}
+		}
+		provenance.Fields[field] = FieldProvenance{Category: provenanceCategory(source), Source: source, Confidence: sourceConfidence(source, enrichment.MatchConfidence)}
+	}
+
+	// Tool-calling configuration is only ever read from HuggingFace YAML frontmatter
+	toolCallingSource := "null"
+	if existing.ToolCallingConfig != nil {
+		toolCallingSource = "huggingface.yaml"
+	}
+	provenance.Fields["toolCallingConfig"] = FieldProvenance{Category: provenanceCategory(toolCallingSource), Source: toolCallingSource, Confidence: sourceConfidence(toolCallingSource, enrichment.MatchConfidence)}
+
+	// Quantization comes from a GGUF header, either on HuggingFace or in the image's weights layer
+	quantizationSource := "null"
+	if existing.Quantization != nil {
+		quantizationSource = enrichment.DataSources["quantization"]
+		if quantizationSource == "" {
+			quantizationSource = "registry.gguf"
+		}
+	}
+	provenance.Fields["quantization"] = FieldProvenance{Category: provenanceCategory(quantizationSource), Source: quantizationSource, Confidence: sourceConfidence(quantizationSource, enrichment.MatchConfidence)}
+
+	artifactsSource := "null"
+	if len(existing.Artifacts) > 0 {
+		artifactsSource = "registry"
+	}
+	provenance.Fields["artifacts"] = FieldProvenance{Category: provenanceCategory(artifactsSource), Source: artifactsSource, Confidence: sourceConfidence(artifactsSource, enrichment.MatchConfidence)}
+
+	return provenance, nil
+}
+
+// WriteProvenanceReports writes provenance.yaml next to metadata.yaml for each model
+func WriteProvenanceReports(output outputfs.FS, registryModels []string) error {
+	written := 0
+	for _, registryModel := range registryModels {
+		provenance, err := BuildModelProvenance(output, registryModel)
+		if err != nil {
+			log.Printf("  Warning: Skipping provenance for %s: %v", registryModel, err)
+			continue
+		}
+
+		data, err := yaml.Marshal(provenance)
+		if err != nil {
+			return fmt.Errorf("failed to marshal provenance for %s: %v", registryModel, err)
+		}
+
+		if err := output.WriteFile(outputfs.ModelPath(registryModel, ProvenanceFileName), data, 0644); err != nil {
+			return fmt.Errorf("failed to write provenance for %s: %v", registryModel, err)
+		}
+		written++
+	}
+
+	log.Printf("Wrote provenance reports for %d of %d models", written, len(registryModels))
+	return nil
+}
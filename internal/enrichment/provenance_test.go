@@ -0,0 +1,132 @@
+package enrichment
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+func TestProvenanceCategory(t *testing.T) {
+	tests := []struct {
+		source   string
+		expected string
+	}{
+		{"null", ProvenanceNone},
+		{"modelcard.yaml", ProvenanceModelcard},
+		{"modelcard.regex", ProvenanceModelcard},
+		{"modelcard.md", ProvenanceModelcard},
+		{"huggingface.yaml", ProvenanceHuggingFaceFrontmatter},
+		{"huggingface.api", ProvenanceHuggingFaceAPI},
+		{"huggingface.tags", ProvenanceHuggingFaceAPI},
+		{"huggingface.readme", ProvenanceHuggingFaceReadme},
+		{"registry", ProvenanceRegistry},
+		{"generated", ProvenanceGenerated},
+		{"something-else", ProvenanceUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			if got := provenanceCategory(tt.source); got != tt.expected {
+				t.Errorf("provenanceCategory(%q) = %q, expected %q", tt.source, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSourceConfidence(t *testing.T) {
+	tests := []struct {
+		source          string
+		matchConfidence string
+		expected        string
+	}{
+		{"null", "high", ""},
+		{"modelcard.yaml", "", ConfidenceExact},
+		{"huggingface.api", "high", ConfidenceExact},
+		{"huggingface.api", "medium", ConfidenceHeuristic},
+		{"registry.gguf", "medium", ConfidenceExact},
+		{"modelcard.regex", "", ConfidenceHeuristic},
+		{"huggingface.readme", "high", ConfidenceHeuristic},
+		{"modelcard.inferred", "", ConfidenceGuess},
+		{"generated", "high", ConfidenceGuess},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source+"/"+tt.matchConfidence, func(t *testing.T) {
+			if got := sourceConfidence(tt.source, tt.matchConfidence); got != tt.expected {
+				t.Errorf("sourceConfidence(%q, %q) = %q, expected %q", tt.source, tt.matchConfidence, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWriteProvenanceReports(t *testing.T) {
+	outputDir := t.TempDir()
+	regModel := "registry.example.com/test/model:1.0"
+	modelDir := filepath.Join(outputDir, utils.SanitizeManifestRef(regModel), "models")
+	if err := os.MkdirAll(modelDir, 0755); err != nil {
+		t.Fatalf("Failed to create model dir: %v", err)
+	}
+
+	metadataYAML := `name: Test Model
+provider: Example Org
+license: apache-2.0
+tags:
+  - validated
+tasks: []
+artifacts:
+  - uri: oci://registry.example.com/test/model:1.0
+`
+	enrichmentYAML := `huggingface_model: Org/test-model
+match_confidence: high
+data_sources:
+  license: huggingface.yaml
+  tags: huggingface.tags
+`
+	files := map[string]string{
+		"metadata.yaml":   metadataYAML,
+		"enrichment.yaml": enrichmentYAML,
+		"modelcard.md":    "# Test Model\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(modelDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	if err := WriteProvenanceReports(outputfs.Dir(outputDir), []string{regModel, "registry.example.com/missing:1.0"}); err != nil {
+		t.Fatalf("WriteProvenanceReports() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(modelDir, ProvenanceFileName))
+	if err != nil {
+		t.Fatalf("Failed to read provenance file: %v", err)
+	}
+
+	var provenance ModelProvenance
+	if err := yaml.Unmarshal(data, &provenance); err != nil {
+		t.Fatalf("Failed to parse provenance file: %v", err)
+	}
+
+	if provenance.HuggingFaceModel != "Org/test-model" || provenance.MatchConfidence != "high" {
+		t.Errorf("Unexpected match info: %+v", provenance)
+	}
+
+	expected := map[string]FieldProvenance{
+		"name":        {Category: ProvenanceModelcard, Source: "modelcard.md", Confidence: ConfidenceHeuristic},
+		"license":     {Category: ProvenanceHuggingFaceFrontmatter, Source: "huggingface.yaml", Confidence: ConfidenceExact},
+		"tags":        {Category: ProvenanceHuggingFaceAPI, Source: "huggingface.tags", Confidence: ConfidenceExact},
+		"tasks":       {Category: ProvenanceNone, Source: "null"},
+		"artifacts":   {Category: ProvenanceRegistry, Source: "registry", Confidence: ConfidenceExact},
+		"description": {Category: ProvenanceNone, Source: "null"},
+	}
+	for field, want := range expected {
+		if got := provenance.Fields[field]; got != want {
+			t.Errorf("Field %s provenance = %+v, expected %+v", field, got, want)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package enrichment
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/config"
+	"github.com/opendatahub-io/model-metadata-collection/internal/metadata"
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+	"github.com/opendatahub-io/model-metadata-collection/internal/pyxis"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// pyxisRepositorySource is the enrichment source of descriptions read from the Red Hat container
+// catalog repository of an image
+const pyxisRepositorySource = "pyxis.repository"
+
+// EnrichMetadataFromPyxis records the Red Hat container catalog record (freshness grade,
+// advisories and catalog page) on the artifact of every Red Hat registry image in
+// opts.Output, and uses the description of the image's repository when the model has none or
+// only one generated from its name. It does nothing when opts.Pyxis is nil and stops after the
+// model in flight when ctx is canceled.
+func EnrichMetadataFromPyxis(ctx context.Context, modelsIndexPath string, opts Options) error {
+	e := newEnricher(opts)
+	if e.Pyxis == nil {
+		return nil
+	}
+	log.Println("Enriching Red Hat registry images with container catalog data...")
+
+	regModels, err := config.LoadModelsFromYAML(modelsIndexPath)
+	if err != nil {
+		return fmt.Errorf("failed to load registry models: %v", err)
+	}
+
+	checked, enriched := 0, 0
+	for _, regModel := range regModels {
+		if ctx.Err() != nil {
+			log.Printf("Interrupted, not reading the container catalog for the remaining models")
+			break
+		}
+		if !pyxis.Supported(regModel) {
+			continue
+		}
+		if _, err := e.Output.Stat(outputfs.ModelPath(regModel, "metadata.yaml")); err != nil {
+			continue
+		}
+		checked++
+		if err := e.enrichFromPyxis(ctx, regModel, time.Now()); err != nil {
+			log.Printf("  Warning: Failed to read the container catalog record of %s: %v", regModel, err)
+			continue
+		}
+		enriched++
+	}
+
+	log.Printf("Container catalog enrichment complete:")
+	log.Printf("- Red Hat registry models checked: %d", checked)
+	log.Printf("- Successfully enriched: %d", enriched)
+	return nil
+}
+
+// enrichFromPyxis looks up one image in the container catalog and updates its metadata.yaml and,
+// when the description is replaced, its enrichment.yaml
+func (e *enricher) enrichFromPyxis(ctx context.Context, regModel string, now time.Time) error {
+	info, summary, err := e.Pyxis.Lookup(ctx, regModel, now)
+	if err != nil {
+		return err
+	}
+
+	existing, err := metadata.LoadExistingMetadata(e.Output, regModel)
+	if err != nil {
+		return fmt.Errorf("failed to load existing metadata: %w", err)
+	}
+	normalized := utils.NormalizeArtifactURI(regModel)
+	for i := range existing.Artifacts {
+		if utils.NormalizeArtifactURI(existing.Artifacts[i].URI) == normalized {
+			existing.Artifacts[i].RedHatCatalog = info
+		}
+	}
+	log.Printf("  Container catalog record of %s: freshness grade %q, %d advisories", regModel, info.FreshnessGrade, len(info.Advisories))
+
+	var record enrichmentRecord
+	enrichmentPath := outputfs.ModelPath(regModel, "enrichment.yaml")
+	if data, err := e.Output.ReadFile(enrichmentPath); err == nil {
+		if err := yaml.Unmarshal(data, &record); err != nil {
+			return fmt.Errorf("failed to parse enrichment.yaml: %v", err)
+		}
+	}
+
+	// Curated repository descriptions beat descriptions generated from the model name, but not
+	// descriptions from the modelcard or HuggingFace
+	replaceDescription := summary != "" && (existing.Description == nil || *existing.Description == "" ||
+		(record.DataSources.Description == "" && isGeneratedDescription(*existing.Description, existing.Name, record.HuggingFaceModel)))
+	if replaceDescription {
+		existing.Description = &summary
+		record.DataSources.Description = pyxisRepositorySource
+		log.Printf("  Using the container catalog repository description for: %s", regModel)
+	}
+
+	updatedData, err := yaml.Marshal(existing)
+	if err != nil {
+		return fmt.Errorf("failed to marshal updated metadata: %v", err)
+	}
+	if err := e.Output.WriteFile(outputfs.ModelPath(regModel, "metadata.yaml"), updatedData, 0644); err != nil {
+		return fmt.Errorf("failed to write updated metadata: %v", err)
+	}
+	if !replaceDescription {
+		return nil
+	}
+
+	enrichmentData, err := yaml.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal enrichment data: %v", err)
+	}
+	if err := e.Output.WriteFile(enrichmentPath, enrichmentData, 0644); err != nil {
+		return fmt.Errorf("failed to write enrichment file: %v", err)
+	}
+	return nil
+}
+
+// isGeneratedDescription reports whether description is the one UpdateModelMetadataFile generates
+// from the model name or the HuggingFace model name
+func isGeneratedDescription(description string, name *string, huggingFaceModel string) bool {
+	if huggingFaceModel != "" && description == utils.GenerateDescriptionFromModelName(huggingFaceModel) {
+		return true
+	}
+	return name != nil && description == utils.GenerateDescriptionFromModelName(*name)
+}
@@ -0,0 +1,88 @@
+package enrichment
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/metadata"
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+	"github.com/opendatahub-io/model-metadata-collection/internal/pyxis"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+func TestEnrichMetadataFromPyxis(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/repositories/registry/registry.access.redhat.com/repository/rhelai1/modelcar-granite", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"_id": "65a1b2", "display_data": {"short_description": "Granite modelcar"}}`))
+	})
+	mux.HandleFunc("/v1/images", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data": [{"freshness_grades": [{"grade": "B", "start_date": "2025-01-01T00:00:00Z"}],
+			"repositories": [{"registry": "registry.access.redhat.com", "repository": "rhelai1/modelcar-granite", "image_advisory_id": "RHBA-2025:1234"}]}]}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	client, err := pyxis.NewClient(server.URL, "")
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	tmpDir := t.TempDir()
+	output := outputfs.Dir(filepath.Join(tmpDir, "output"))
+	redHatModel := "registry.redhat.io/rhelai1/modelcar-granite:1.5"
+	otherModel := "quay.io/org/model:1.0"
+	for _, ref := range []string{redHatModel, otherModel} {
+		name := "granite-3.1-8b-instruct"
+		description := utils.GenerateDescriptionFromModelName(name)
+		data, _ := yaml.Marshal(types.ExtractedMetadata{
+			Name:        &name,
+			Description: &description,
+			Artifacts:   []types.OCIArtifact{{URI: "oci://" + ref}},
+		})
+		if err := output.MkdirAll(outputfs.ModelPath(ref), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := output.WriteFile(outputfs.ModelPath(ref, "metadata.yaml"), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	modelsData, _ := yaml.Marshal(types.ModelsConfig{Models: []types.ModelEntry{{Type: "oci", URI: redHatModel}, {Type: "oci", URI: otherModel}}})
+	modelsIndexPath := filepath.Join(tmpDir, "models-index.yaml")
+	if err := os.WriteFile(modelsIndexPath, modelsData, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := EnrichMetadataFromPyxis(context.Background(), modelsIndexPath, Options{Output: output, Pyxis: client}); err != nil {
+		t.Fatalf("EnrichMetadataFromPyxis failed: %v", err)
+	}
+
+	enriched, err := metadata.LoadExistingMetadata(output, redHatModel)
+	if err != nil {
+		t.Fatalf("Failed to load metadata: %v", err)
+	}
+	if enriched.Description == nil || *enriched.Description != "Granite modelcar" {
+		t.Errorf("Expected the repository description to replace the generated one, got %v", enriched.Description)
+	}
+	catalogInfo := enriched.Artifacts[0].RedHatCatalog
+	if catalogInfo == nil || catalogInfo.FreshnessGrade != "B" || len(catalogInfo.Advisories) != 1 {
+		t.Errorf("Expected the container catalog record on the artifact, got %+v", catalogInfo)
+	}
+	provenance, err := BuildModelProvenance(output, redHatModel)
+	if err != nil {
+		t.Fatalf("BuildModelProvenance failed: %v", err)
+	}
+	if field := provenance.Fields["description"]; field.Category != ProvenanceRedHatCatalog || field.Confidence != ConfidenceExact {
+		t.Errorf("Expected the description to come from the Red Hat catalog, got %+v", field)
+	}
+
+	other, _ := metadata.LoadExistingMetadata(output, otherModel)
+	if other.Artifacts[0].RedHatCatalog != nil {
+		t.Errorf("Expected no container catalog record for a quay.io image, got %+v", other.Artifacts[0].RedHatCatalog)
+	}
+}
@@ -9,7 +9,9 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/opendatahub-io/model-metadata-collection/internal/metadata"
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
 	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
 )
 
 func TestToolCallingIntegration_WithToolCalling(t *testing.T) {
@@ -48,7 +50,7 @@ func TestToolCallingIntegration_WithToolCalling(t *testing.T) {
 	}
 
 	// Create modelcard.md in expected location
-	sanitizedName := "registry.redhat.io_rhai_modelcar-mistral-7b_1.0"
+	sanitizedName := utils.SanitizeManifestRef(registryModel)
 	modelcardDir := filepath.Join(tmpDir, sanitizedName, "models")
 	if err := os.MkdirAll(modelcardDir, 0755); err != nil {
 		t.Fatalf("Failed to create modelcard dir: %v", err)
@@ -77,12 +79,12 @@ This is a base model for testing.`
 	}
 
 	// Execute UpdateModelMetadataFile
-	if err := UpdateModelMetadataFile(registryModel, enrichedData, tmpDir); err != nil {
+	if err := UpdateModelMetadataFile(outputfs.Dir(tmpDir), registryModel, enrichedData, metadata.ReadmeOptions{}); err != nil {
 		t.Fatalf("UpdateModelMetadataFile() failed: %v", err)
 	}
 
 	// Load updated metadata
-	updatedMetadata, err := metadata.LoadExistingMetadata(registryModel, tmpDir)
+	updatedMetadata, err := metadata.LoadExistingMetadata(outputfs.Dir(tmpDir), registryModel)
 	if err != nil {
 		t.Fatalf("Failed to load updated metadata: %v", err)
 	}
@@ -144,7 +146,7 @@ func TestToolCallingIntegration_WithoutToolCalling(t *testing.T) {
 	}
 
 	// Create modelcard.md in expected location
-	sanitizedName := "registry.redhat.io_rhai_modelcar-granite-3b_1.0"
+	sanitizedName := utils.SanitizeManifestRef(registryModel)
 	modelcardDir := filepath.Join(tmpDir, sanitizedName, "models")
 	if err := os.MkdirAll(modelcardDir, 0755); err != nil {
 		t.Fatalf("Failed to create modelcard dir: %v", err)
@@ -170,12 +172,12 @@ This is a model without tool calling support.`
 	}
 
 	// Execute UpdateModelMetadataFile
-	if err := UpdateModelMetadataFile(registryModel, enrichedData, tmpDir); err != nil {
+	if err := UpdateModelMetadataFile(outputfs.Dir(tmpDir), registryModel, enrichedData, metadata.ReadmeOptions{}); err != nil {
 		t.Fatalf("UpdateModelMetadataFile() failed: %v", err)
 	}
 
 	// Load updated metadata
-	updatedMetadata, err := metadata.LoadExistingMetadata(registryModel, tmpDir)
+	updatedMetadata, err := metadata.LoadExistingMetadata(outputfs.Dir(tmpDir), registryModel)
 	if err != nil {
 		t.Fatalf("Failed to load updated metadata: %v", err)
 	}
@@ -229,7 +231,7 @@ func TestToolCallingIntegration_EmptyConfig(t *testing.T) {
 	}
 
 	// Create modelcard.md
-	sanitizedName := "registry.redhat.io_rhai_modelcar-test_1.0"
+	sanitizedName := utils.SanitizeManifestRef(registryModel)
 	modelcardDir := filepath.Join(tmpDir, sanitizedName, "models")
 	if err := os.MkdirAll(modelcardDir, 0755); err != nil {
 		t.Fatalf("Failed to create modelcard dir: %v", err)
@@ -255,12 +257,12 @@ Basic test model.`
 	}
 
 	// Execute
-	if err := UpdateModelMetadataFile(registryModel, enrichedData, tmpDir); err != nil {
+	if err := UpdateModelMetadataFile(outputfs.Dir(tmpDir), registryModel, enrichedData, metadata.ReadmeOptions{}); err != nil {
 		t.Fatalf("UpdateModelMetadataFile() failed: %v", err)
 	}
 
 	// Load and verify
-	updatedMetadata, err := metadata.LoadExistingMetadata(registryModel, tmpDir)
+	updatedMetadata, err := metadata.LoadExistingMetadata(outputfs.Dir(tmpDir), registryModel)
 	if err != nil {
 		t.Fatalf("Failed to load updated metadata: %v", err)
 	}
@@ -310,7 +312,7 @@ func TestToolCallingIntegration_WithoutValidatedOn(t *testing.T) {
 	}
 
 	// Create modelcard.md
-	sanitizedName := "registry.redhat.io_rhai_modelcar-qwen-7b_1.0"
+	sanitizedName := utils.SanitizeManifestRef(registryModel)
 	modelcardDir := filepath.Join(tmpDir, sanitizedName, "models")
 	if err := os.MkdirAll(modelcardDir, 0755); err != nil {
 		t.Fatalf("Failed to create modelcard dir: %v", err)
@@ -336,12 +338,12 @@ This model supports tool calling without validated_on field.`
 	}
 
 	// Execute UpdateModelMetadataFile
-	if err := UpdateModelMetadataFile(registryModel, enrichedData, tmpDir); err != nil {
+	if err := UpdateModelMetadataFile(outputfs.Dir(tmpDir), registryModel, enrichedData, metadata.ReadmeOptions{}); err != nil {
 		t.Fatalf("UpdateModelMetadataFile() failed: %v", err)
 	}
 
 	// Load updated metadata
-	updatedMetadata, err := metadata.LoadExistingMetadata(registryModel, tmpDir)
+	updatedMetadata, err := metadata.LoadExistingMetadata(outputfs.Dir(tmpDir), registryModel)
 	if err != nil {
 		t.Fatalf("Failed to load updated metadata: %v", err)
 	}
@@ -405,7 +407,7 @@ func TestToolCallingIntegration_WithValidatedTasks(t *testing.T) {
 		ValidatedTasks:       metadata.CreateMetadataSource([]string{"tool-calling"}, "huggingface.yaml"),
 	}
 
-	sanitizedName := "registry.redhat.io_rhai_modelcar-granite-4-0_3.0"
+	sanitizedName := utils.SanitizeManifestRef(registryModel)
 	modelcardDir := filepath.Join(tmpDir, sanitizedName, "models")
 	if err := os.MkdirAll(modelcardDir, 0755); err != nil {
 		t.Fatalf("Failed to create modelcard dir: %v", err)
@@ -425,11 +427,11 @@ func TestToolCallingIntegration_WithValidatedTasks(t *testing.T) {
 		t.Fatalf("Failed to write initial metadata: %v", err)
 	}
 
-	if err := UpdateModelMetadataFile(registryModel, enrichedData, tmpDir); err != nil {
+	if err := UpdateModelMetadataFile(outputfs.Dir(tmpDir), registryModel, enrichedData, metadata.ReadmeOptions{}); err != nil {
 		t.Fatalf("UpdateModelMetadataFile() failed: %v", err)
 	}
 
-	updatedMetadata, err := metadata.LoadExistingMetadata(registryModel, tmpDir)
+	updatedMetadata, err := metadata.LoadExistingMetadata(outputfs.Dir(tmpDir), registryModel)
 	if err != nil {
 		t.Fatalf("Failed to load updated metadata: %v", err)
 	}
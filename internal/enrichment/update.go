@@ -3,13 +3,13 @@ package enrichment
 import (
 	"fmt"
 	"log"
-	"os"
 	"strings"
 
 	"gopkg.in/yaml.v3"
 
 	"github.com/opendatahub-io/model-metadata-collection/internal/huggingface"
 	"github.com/opendatahub-io/model-metadata-collection/internal/metadata"
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
 	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
 	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
 )
@@ -69,15 +69,43 @@ func isLowQualityModelName(name string) bool {
 	return false
 }
 
-// UpdateModelMetadataFile updates an existing metadata.yaml file with enriched data and creates separate enrichment.yaml
-func UpdateModelMetadataFile(registryModel string, enrichedData *types.EnrichedModelMetadata, outputDir string) error {
-	// Create sanitized directory name for the model
-	sanitizedName := utils.SanitizeManifestRef(registryModel)
-	metadataPath := fmt.Sprintf("%s/%s/models/metadata.yaml", outputDir, sanitizedName)
-	enrichmentPath := fmt.Sprintf("%s/%s/models/enrichment.yaml", outputDir, sanitizedName)
+// enrichmentRecord is the enrichment.yaml written next to metadata.yaml: the HuggingFace match and
+// the source of each enriched field
+type enrichmentRecord struct {
+	HuggingFaceModel string `yaml:"huggingface_model,omitempty"`
+	HuggingFaceURL   string `yaml:"huggingface_url,omitempty"`
+	MatchConfidence  string `yaml:"match_confidence,omitempty"`
+	DataSources      struct {
+		Name                 string `yaml:"name,omitempty"`
+		Provider             string `yaml:"provider,omitempty"`
+		Description          string `yaml:"description,omitempty"`
+		License              string `yaml:"license,omitempty"`
+		LicenseLink          string `yaml:"license_link,omitempty"`
+		Language             string `yaml:"language,omitempty"`
+		Tags                 string `yaml:"tags,omitempty"`
+		Tasks                string `yaml:"tasks,omitempty"`
+		LastModified         string `yaml:"last_modified,omitempty"`
+		CreateTimeSinceEpoch string `yaml:"create_time_since_epoch,omitempty"`
+		ValidatedOn          string `yaml:"validated_on,omitempty"`
+		HardwareTag          string `yaml:"hardware_tag,omitempty"`
+		ValidatedTasks       string `yaml:"validated_tasks,omitempty"`
+		TrainingDatasets     string `yaml:"training_datasets,omitempty"`
+		BaseModel            string `yaml:"base_model,omitempty"`
+		Quantization         string `yaml:"quantization,omitempty"`
+		Evaluations          string `yaml:"evaluations,omitempty"`
+		MaxContextLength     string `yaml:"max_context_length,omitempty"`
+		Readme               string `yaml:"readme,omitempty"`
+	} `yaml:"data_sources"`
+}
+
+// UpdateModelMetadataFile updates an existing metadata.yaml file with enriched data and creates separate enrichment.yaml.
+// readme configures how the HuggingFace README or modelcard becomes the readme field.
+func UpdateModelMetadataFile(output outputfs.FS, registryModel string, enrichedData *types.EnrichedModelMetadata, readme metadata.ReadmeOptions) error {
+	metadataPath := outputfs.ModelPath(registryModel, "metadata.yaml")
+	enrichmentPath := outputfs.ModelPath(registryModel, "enrichment.yaml")
 
 	// Try to load existing metadata using migration logic
-	existingMetadataPtr, err := metadata.LoadExistingMetadata(registryModel, outputDir)
+	existingMetadataPtr, err := metadata.LoadExistingMetadata(output, registryModel)
 	var existingMetadata types.ExtractedMetadata
 	if err == nil && existingMetadataPtr != nil {
 		existingMetadata = *existingMetadataPtr
@@ -87,28 +115,11 @@ func UpdateModelMetadataFile(registryModel string, enrichedData *types.EnrichedM
 		log.Printf("  Warning: Could not load existing metadata for %s: %v", registryModel, err)
 	}
 
+	// Values parsed from the text of a card not written in English yield to HuggingFace values
+	nonEnglishCard := metadata.IsNonEnglishCard(existingMetadata.CardLanguage)
+
 	// Create enrichment structure with granular source tracking
-	enrichmentInfo := struct {
-		HuggingFaceModel string `yaml:"huggingface_model,omitempty"`
-		HuggingFaceURL   string `yaml:"huggingface_url,omitempty"`
-		MatchConfidence  string `yaml:"match_confidence,omitempty"`
-		DataSources      struct {
-			Name                 string `yaml:"name,omitempty"`
-			Provider             string `yaml:"provider,omitempty"`
-			Description          string `yaml:"description,omitempty"`
-			License              string `yaml:"license,omitempty"`
-			LicenseLink          string `yaml:"license_link,omitempty"`
-			Language             string `yaml:"language,omitempty"`
-			Tags                 string `yaml:"tags,omitempty"`
-			Tasks                string `yaml:"tasks,omitempty"`
-			LastModified         string `yaml:"last_modified,omitempty"`
-			CreateTimeSinceEpoch string `yaml:"create_time_since_epoch,omitempty"`
-			ValidatedOn          string `yaml:"validated_on,omitempty"`
-			HardwareTag          string `yaml:"hardware_tag,omitempty"`
-			ValidatedTasks       string `yaml:"validated_tasks,omitempty"`
-			Readme               string `yaml:"readme,omitempty"`
-		} `yaml:"data_sources"`
-	}{}
+	var enrichmentInfo enrichmentRecord
 
 	// Set enrichment info
 	enrichmentInfo.HuggingFaceModel = enrichedData.HuggingFaceModel
@@ -151,7 +162,7 @@ func UpdateModelMetadataFile(registryModel string, enrichedData *types.EnrichedM
 
 	if enrichedData.Provider.Source != "null" {
 		// Always override with HuggingFace YAML data (highest priority)
-		shouldOverride := existingMetadata.Provider == nil || enrichedData.Provider.Source == "huggingface.yaml"
+		shouldOverride := existingMetadata.Provider == nil || enrichedData.Provider.Source == "huggingface.yaml" || nonEnglishCard
 		if shouldOverride {
 			providerStr := enrichedData.Provider.Value.(string)
 			existingMetadata.Provider = &providerStr
@@ -161,7 +172,7 @@ func UpdateModelMetadataFile(registryModel string, enrichedData *types.EnrichedM
 
 	if enrichedData.Description.Source != "null" {
 		// Always override with HuggingFace YAML data (highest priority)
-		shouldOverride := existingMetadata.Description == nil || enrichedData.Description.Source == "huggingface.yaml"
+		shouldOverride := existingMetadata.Description == nil || enrichedData.Description.Source == "huggingface.yaml" || nonEnglishCard
 		if shouldOverride {
 			descStr := enrichedData.Description.Value.(string)
 			existingMetadata.Description = &descStr
@@ -171,7 +182,7 @@ func UpdateModelMetadataFile(registryModel string, enrichedData *types.EnrichedM
 
 	if enrichedData.License.Source != "null" {
 		// Always override with HuggingFace YAML data (highest priority)
-		shouldOverride := existingMetadata.License == nil || enrichedData.License.Source == "huggingface.yaml"
+		shouldOverride := existingMetadata.License == nil || enrichedData.License.Source == "huggingface.yaml" || nonEnglishCard
 		if shouldOverride {
 			licenseStr := enrichedData.License.Value.(string)
 			existingMetadata.License = &licenseStr
@@ -186,7 +197,7 @@ func UpdateModelMetadataFile(registryModel string, enrichedData *types.EnrichedM
 
 	if enrichedData.LicenseLink.Source != "null" {
 		// Always override with HuggingFace YAML data (highest priority)
-		shouldOverride := existingMetadata.LicenseLink == nil || enrichedData.LicenseLink.Source == "huggingface.yaml"
+		shouldOverride := existingMetadata.LicenseLink == nil || enrichedData.LicenseLink.Source == "huggingface.yaml" || nonEnglishCard
 		if shouldOverride {
 			licenseLinkStr := enrichedData.LicenseLink.Value.(string)
 			existingMetadata.LicenseLink = &licenseLinkStr
@@ -215,7 +226,7 @@ func UpdateModelMetadataFile(registryModel string, enrichedData *types.EnrichedM
 	if enrichedData.Language.Source != "null" && enrichedData.Language.Value != nil {
 		if languages, ok := enrichedData.Language.Value.([]string); ok && len(languages) > 0 {
 			// Always override with enriched language data (highest priority sources)
-			shouldOverride := len(existingMetadata.Language) == 0 || enrichedData.Language.Source == "huggingface.yaml"
+			shouldOverride := len(existingMetadata.Language) == 0 || enrichedData.Language.Source == "huggingface.yaml" || nonEnglishCard
 			if shouldOverride {
 				existingMetadata.Language = languages
 			}
@@ -263,8 +274,9 @@ func UpdateModelMetadataFile(registryModel string, enrichedData *types.EnrichedM
 	if enrichedData.Tasks.Source != "null" && enrichedData.Tasks.Value != nil {
 		tasks, ok := enrichedData.Tasks.Value.([]string)
 		if ok && len(tasks) > 0 {
-			// Always override with HuggingFace YAML tasks (highest priority)
-			shouldOverride := len(existingMetadata.Tasks) == 0 || enrichedData.Tasks.Source == "huggingface.yaml"
+			// Always override with HuggingFace YAML tasks (highest priority); HuggingFace tags are
+			// only chosen over the modelcard when its tasks were inferred
+			shouldOverride := len(existingMetadata.Tasks) == 0 || enrichedData.Tasks.Source == "huggingface.yaml" || enrichedData.Tasks.Source == "huggingface.tags" || nonEnglishCard
 			if shouldOverride {
 				log.Printf("  Debug: Using tasks from enrichedData.Tasks: %v", tasks)
 				existingMetadata.Tasks = tasks
@@ -293,6 +305,8 @@ func UpdateModelMetadataFile(registryModel string, enrichedData *types.EnrichedM
 		}
 	}
 
+	existingMetadata.Tasks = types.NormalizeTasks(existingMetadata.Tasks)
+
 	// Handle enriched ValidatedOn data from HuggingFace YAML
 	if enrichedData.ValidatedOn.Source != "null" && enrichedData.ValidatedOn.Value != nil {
 		if raw, ok := enrichedData.ValidatedOn.Value.([]string); ok && len(raw) > 0 {
@@ -332,6 +346,57 @@ func UpdateModelMetadataFile(registryModel string, enrichedData *types.EnrichedM
 		}
 	}
 
+	// Handle enriched TrainingDatasets data from HuggingFace YAML or dataset: tags
+	if enrichedData.TrainingDatasets.Source != "null" && enrichedData.TrainingDatasets.Value != nil {
+		if raw, ok := enrichedData.TrainingDatasets.Value.([]string); ok && len(raw) > 0 {
+			if normalized := normalizeAndDedup(raw); len(normalized) > 0 {
+				if len(existingMetadata.TrainingDatasets) == 0 || enrichedData.TrainingDatasets.Source == "huggingface.yaml" {
+					log.Printf("  Using training datasets from enrichedData: %v", normalized)
+					existingMetadata.TrainingDatasets = normalized
+				}
+				enrichmentInfo.DataSources.TrainingDatasets = enrichedData.TrainingDatasets.Source
+			}
+		}
+	}
+
+	// Handle base models from HuggingFace YAML or base_model: tags
+	if enrichedData.BaseModel.Source != "null" && enrichedData.BaseModel.Value != nil {
+		if raw, ok := enrichedData.BaseModel.Value.([]string); ok && len(raw) > 0 {
+			if normalized := normalizeAndDedup(raw); len(normalized) > 0 {
+				if len(existingMetadata.BaseModel) == 0 || enrichedData.BaseModel.Source == "huggingface.yaml" || enrichedData.BaseModel.Source == "huggingface.tags" {
+					existingMetadata.BaseModel = normalized
+				}
+				enrichmentInfo.DataSources.BaseModel = enrichedData.BaseModel.Source
+			}
+		}
+	}
+
+	// Handle quantization details read from a GGUF header
+	if enrichedData.Quantization.Source != "null" && enrichedData.Quantization.Value != nil {
+		if quantization, ok := enrichedData.Quantization.Value.(*types.QuantizationInfo); ok && quantization != nil {
+			if existingMetadata.Quantization == nil {
+				existingMetadata.Quantization = quantization
+			}
+			enrichmentInfo.DataSources.Quantization = enrichedData.Quantization.Source
+		}
+	}
+
+	// Handle evaluation results from the HuggingFace model-index
+	if enrichedData.Evaluations.Source != "null" && enrichedData.Evaluations.Value != nil {
+		if evaluations, ok := enrichedData.Evaluations.Value.([]types.Evaluation); ok && len(evaluations) > 0 {
+			existingMetadata.Evaluations = evaluations
+			enrichmentInfo.DataSources.Evaluations = enrichedData.Evaluations.Source
+		}
+	}
+
+	// Handle the context window from config.json or the card text
+	if enrichedData.MaxContextLength.Source != "null" && enrichedData.MaxContextLength.Value != nil {
+		if contextLength, ok := enrichedData.MaxContextLength.Value.(int64); ok && contextLength > 0 {
+			existingMetadata.MaxContextLength = &contextLength
+			enrichmentInfo.DataSources.MaxContextLength = enrichedData.MaxContextLength.Source
+		}
+	}
+
 	// Persist tool-calling config to metadata for catalog generation
 	if enrichedData.ToolCallingConfig != nil && enrichedData.ToolCallingConfig.HasToolCalling() {
 		existingMetadata.ToolCallingConfig = enrichedData.ToolCallingConfig
@@ -372,29 +437,41 @@ func UpdateModelMetadataFile(registryModel string, enrichedData *types.EnrichedM
 		}
 	}
 
+	// Readmes left from runs without --skip-readme are dropped when readmes are turned off
+	if !readme.Enabled() {
+		existingMetadata.Readme = nil
+	}
+
 	// IMPORTANT: Apply HuggingFace README content if available (highest priority)
 	if existingMetadata.Readme == nil && enrichedData.ReadmeContent != "" {
-		existingMetadata.Readme = &enrichedData.ReadmeContent
-		enrichmentInfo.DataSources.Readme = "huggingface.readme"
-		log.Printf("  Applied HuggingFace README content (%d chars) for: %s", len(enrichedData.ReadmeContent), registryModel)
+		if hfReadme := readme.FromModelCard(enrichedData.ReadmeContent); hfReadme != nil {
+			existingMetadata.Readme = hfReadme
+			enrichmentInfo.DataSources.Readme = "huggingface.readme"
+			log.Printf("  Applied HuggingFace README content (%d chars) for: %s", len(*hfReadme), registryModel)
+		}
 	}
 
 	// Fallback: Preserve readme content if it's missing but modelcard file exists
 	if existingMetadata.Readme == nil {
-		modelcardPath := fmt.Sprintf("%s/%s/models/modelcard.md", outputDir, sanitizedName)
-		if modelcardContent, err := os.ReadFile(modelcardPath); err == nil && len(modelcardContent) > 0 {
-			// Strip YAML frontmatter from the readme content
-			readme := utils.StripYAMLFrontmatter(string(modelcardContent))
-			existingMetadata.Readme = &readme
-			enrichmentInfo.DataSources.Readme = "modelcard.md"
-			log.Printf("  Restored readme content from modelcard.md for: %s", registryModel)
+		if modelcardContent, err := output.ReadFile(outputfs.ModelPath(registryModel, "modelcard.md")); err == nil && len(modelcardContent) > 0 {
+			if modelcardReadme := readme.FromModelCard(string(modelcardContent)); modelcardReadme != nil {
+				existingMetadata.Readme = modelcardReadme
+				enrichmentInfo.DataSources.Readme = "modelcard.md"
+				log.Printf("  Restored readme content from modelcard.md for: %s", registryModel)
+			}
 		}
 	}
 
+	// Relative links in the readme point into the HuggingFace repository it was written for
+	if existingMetadata.Readme != nil && enrichedData.HuggingFaceModel != "" {
+		resolved := metadata.ResolveReadmeLinks(*existingMetadata.Readme, "https://huggingface.co/"+enrichedData.HuggingFaceModel)
+		existingMetadata.Readme = &resolved
+	}
+
 	// Append vLLM recommended configurations section to README ONLY if config exists
 	// Section is NOT added when VLLMConfig is nil or HasPresets() returns false
 	// Guard against duplicate sections on re-enrichment runs
-	if enrichedData.VLLMConfig != nil && enrichedData.VLLMConfig.HasPresets() {
+	if enrichedData.VLLMConfig != nil && enrichedData.VLLMConfig.HasPresets() && readme.Enabled() {
 		alreadyPresent := existingMetadata.Readme != nil && strings.Contains(*existingMetadata.Readme, "## vLLM Recommended Configurations")
 		if alreadyPresent {
 			log.Printf("  vLLM config section already present in README, skipping for: %s", registryModel)
@@ -440,7 +517,7 @@ func UpdateModelMetadataFile(registryModel string, enrichedData *types.EnrichedM
 		return fmt.Errorf("failed to marshal updated metadata: %v", err)
 	}
 
-	err = os.WriteFile(metadataPath, updatedData, 0644)
+	err = output.WriteFile(metadataPath, updatedData, 0644)
 	if err != nil {
 		return fmt.Errorf("failed to write updated metadata: %v", err)
 	}
@@ -451,7 +528,7 @@ func UpdateModelMetadataFile(registryModel string, enrichedData *types.EnrichedM
 		return fmt.Errorf("failed to marshal enrichment data: %v", err)
 	}
 
-	err = os.WriteFile(enrichmentPath, enrichmentData, 0644)
+	err = output.WriteFile(enrichmentPath, enrichmentData, 0644)
 	if err != nil {
 		return fmt.Errorf("failed to write enrichment file: %v", err)
 	}
@@ -9,7 +9,9 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/opendatahub-io/model-metadata-collection/internal/metadata"
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
 	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
 )
 
 // newNullEnriched creates an EnrichedModelMetadata with all metadata sources set to "null"
@@ -63,7 +65,7 @@ func setupTestDir(t *testing.T, sanitizedName, modelcardContent string) string {
 
 func TestVLLMConfigIntegration_WithConfig(t *testing.T) {
 	registryModel := "registry.redhat.io/rhai/modelcar-llama-3-3-70b-fp8:1.0"
-	sanitizedName := "registry.redhat.io_rhai_modelcar-llama-3-3-70b-fp8_1.0"
+	sanitizedName := utils.SanitizeManifestRef(registryModel)
 
 	tmpDir := setupTestDir(t, sanitizedName,
 		"# Llama 3.3 70B\n\nThis is a test model.")
@@ -108,12 +110,12 @@ func TestVLLMConfigIntegration_WithConfig(t *testing.T) {
 		},
 	}
 
-	err := UpdateModelMetadataFile(registryModel, enrichedData, tmpDir)
+	err := UpdateModelMetadataFile(outputfs.Dir(tmpDir), registryModel, enrichedData, metadata.ReadmeOptions{})
 	if err != nil {
 		t.Fatalf("UpdateModelMetadataFile() failed: %v", err)
 	}
 
-	updatedMetadata, err := metadata.LoadExistingMetadata(registryModel, tmpDir)
+	updatedMetadata, err := metadata.LoadExistingMetadata(outputfs.Dir(tmpDir), registryModel)
 	if err != nil {
 		t.Fatalf("Failed to load updated metadata: %v", err)
 	}
@@ -155,19 +157,19 @@ func TestVLLMConfigIntegration_WithConfig(t *testing.T) {
 
 func TestVLLMConfigIntegration_WithoutConfig(t *testing.T) {
 	registryModel := "registry.redhat.io/rhai/modelcar-granite-3b:1.0"
-	sanitizedName := "registry.redhat.io_rhai_modelcar-granite-3b_1.0"
+	sanitizedName := utils.SanitizeManifestRef(registryModel)
 
 	tmpDir := setupTestDir(t, sanitizedName,
 		"# Granite 3B\n\nThis model has no vLLM config.")
 
 	enrichedData := newNullEnriched(registryModel, "RedHatAI/Granite-3B")
 
-	err := UpdateModelMetadataFile(registryModel, enrichedData, tmpDir)
+	err := UpdateModelMetadataFile(outputfs.Dir(tmpDir), registryModel, enrichedData, metadata.ReadmeOptions{})
 	if err != nil {
 		t.Fatalf("UpdateModelMetadataFile() failed: %v", err)
 	}
 
-	updatedMetadata, err := metadata.LoadExistingMetadata(registryModel, tmpDir)
+	updatedMetadata, err := metadata.LoadExistingMetadata(outputfs.Dir(tmpDir), registryModel)
 	if err != nil {
 		t.Fatalf("Failed to load updated metadata: %v", err)
 	}
@@ -185,7 +187,7 @@ func TestVLLMConfigIntegration_WithoutConfig(t *testing.T) {
 
 func TestVLLMConfigIntegration_WithConstraintsAndEnvVars(t *testing.T) {
 	registryModel := "registry.redhat.io/rhai/modelcar-test-constrained:1.0"
-	sanitizedName := "registry.redhat.io_rhai_modelcar-test-constrained_1.0"
+	sanitizedName := utils.SanitizeManifestRef(registryModel)
 
 	tmpDir := setupTestDir(t, sanitizedName,
 		"# Test Model\n\nBase content.")
@@ -215,12 +217,12 @@ func TestVLLMConfigIntegration_WithConstraintsAndEnvVars(t *testing.T) {
 		},
 	}
 
-	err := UpdateModelMetadataFile(registryModel, enrichedData, tmpDir)
+	err := UpdateModelMetadataFile(outputfs.Dir(tmpDir), registryModel, enrichedData, metadata.ReadmeOptions{})
 	if err != nil {
 		t.Fatalf("UpdateModelMetadataFile() failed: %v", err)
 	}
 
-	updatedMetadata, err := metadata.LoadExistingMetadata(registryModel, tmpDir)
+	updatedMetadata, err := metadata.LoadExistingMetadata(outputfs.Dir(tmpDir), registryModel)
 	if err != nil {
 		t.Fatalf("Failed to load updated metadata: %v", err)
 	}
@@ -247,7 +249,7 @@ func TestVLLMConfigIntegration_WithConstraintsAndEnvVars(t *testing.T) {
 
 func TestVLLMConfigIntegration_BothToolCallingAndVLLMConfig(t *testing.T) {
 	registryModel := "registry.redhat.io/rhai/modelcar-dual-config:1.0"
-	sanitizedName := "registry.redhat.io_rhai_modelcar-dual-config_1.0"
+	sanitizedName := utils.SanitizeManifestRef(registryModel)
 
 	tmpDir := setupTestDir(t, sanitizedName,
 		"# Dual Config Model\n\nBase content.")
@@ -273,12 +275,12 @@ func TestVLLMConfigIntegration_BothToolCallingAndVLLMConfig(t *testing.T) {
 		},
 	}
 
-	err := UpdateModelMetadataFile(registryModel, enrichedData, tmpDir)
+	err := UpdateModelMetadataFile(outputfs.Dir(tmpDir), registryModel, enrichedData, metadata.ReadmeOptions{})
 	if err != nil {
 		t.Fatalf("UpdateModelMetadataFile() failed: %v", err)
 	}
 
-	updatedMetadata, err := metadata.LoadExistingMetadata(registryModel, tmpDir)
+	updatedMetadata, err := metadata.LoadExistingMetadata(outputfs.Dir(tmpDir), registryModel)
 	if err != nil {
 		t.Fatalf("Failed to load updated metadata: %v", err)
 	}
@@ -310,7 +312,7 @@ func TestVLLMConfigIntegration_BothToolCallingAndVLLMConfig(t *testing.T) {
 
 func TestVLLMConfigIntegration_IdempotentReEnrichment(t *testing.T) {
 	registryModel := "registry.redhat.io/rhai/modelcar-idempotent-test:1.0"
-	sanitizedName := "registry.redhat.io_rhai_modelcar-idempotent-test_1.0"
+	sanitizedName := utils.SanitizeManifestRef(registryModel)
 
 	tmpDir := setupTestDir(t, sanitizedName,
 		"# Idempotent Test Model\n\nBase content.")
@@ -338,13 +340,13 @@ func TestVLLMConfigIntegration_IdempotentReEnrichment(t *testing.T) {
 
 	// Run enrichment twice
 	for i := 0; i < 2; i++ {
-		err := UpdateModelMetadataFile(registryModel, enrichedData, tmpDir)
+		err := UpdateModelMetadataFile(outputfs.Dir(tmpDir), registryModel, enrichedData, metadata.ReadmeOptions{})
 		if err != nil {
 			t.Fatalf("UpdateModelMetadataFile() run %d failed: %v", i+1, err)
 		}
 	}
 
-	updatedMetadata, err := metadata.LoadExistingMetadata(registryModel, tmpDir)
+	updatedMetadata, err := metadata.LoadExistingMetadata(outputfs.Dir(tmpDir), registryModel)
 	if err != nil {
 		t.Fatalf("Failed to load updated metadata: %v", err)
 	}
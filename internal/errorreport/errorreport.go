@@ -0,0 +1,151 @@
+package errorreport
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/errdefs"
+)
+
+// FileName is the name of the error report written to the output directory
+const FileName = "errors.yaml"
+
+// Report collects the per-model failures of a pipeline run. It is safe for concurrent use. A nil
+// Report records nothing.
+type Report struct {
+	mu        sync.Mutex
+	order     []string
+	failures  map[string][]Failure
+	skeletons map[string]bool
+}
+
+// ModelErrors lists the failures of one model
+type ModelErrors struct {
+	Model string `yaml:"model"`
+	// SkeletonCreated reports whether skeleton metadata was written for the model in place of
+	// metadata extracted from a modelcard
+	SkeletonCreated bool      `yaml:"skeletonCreated"`
+	Failures        []Failure `yaml:"failures"`
+}
+
+// Failure is one error a model hit in a pipeline stage
+type Failure struct {
+	Stage string `yaml:"stage"`
+	// Kind classifies the failure, e.g. rate-limited or manifest-not-found (see errdefs.Classify);
+	// empty for unclassified errors
+	Kind string `yaml:"kind,omitempty"`
+	// Errors is the error chain, outermost first
+	Errors []string `yaml:"errors"`
+}
+
+// reportFile is the on-disk layout of the error report
+type reportFile struct {
+	Models []ModelErrors `yaml:"models"`
+}
+
+// New returns an empty report
+func New() *Report {
+	return &Report{failures: make(map[string][]Failure), skeletons: make(map[string]bool)}
+}
+
+// Record adds a failure of the model in a pipeline stage, such as checkpoint.StageEnrichment
+func (r *Report) Record(model, stage string, err error) {
+	if r == nil || err == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.failures[model]; !ok {
+		r.order = append(r.order, model)
+	}
+	r.failures[model] = append(r.failures[model], Failure{Stage: stage, Kind: errdefs.Classify(err), Errors: Chain(err)})
+}
+
+// MarkSkeleton records that skeleton metadata was written for the model
+func (r *Report) MarkSkeleton(model string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.skeletons[model] = true
+}
+
+// Models returns the failed models in the order of their first failure
+func (r *Report) Models() []ModelErrors {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	models := make([]ModelErrors, 0, len(r.order))
+	for _, model := range r.order {
+		models = append(models, ModelErrors{
+			Model:           model,
+			SkeletonCreated: r.skeletons[model],
+			Failures:        append([]Failure(nil), r.failures[model]...),
+		})
+	}
+	return models
+}
+
+// Write writes the report to outputDir/errors.yaml. The file is written even when no model
+// failed, so its absence never hides failures of a run.
+func (r *Report) Write(outputDir string) error {
+	file := reportFile{Models: r.Models()}
+	if file.Models == nil {
+		file.Models = []ModelErrors{}
+	}
+	data, err := yaml.Marshal(&file)
+	if err != nil {
+		return fmt.Errorf("failed to marshal error report: %v", err)
+	}
+	path := filepath.Join(outputDir, FileName)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write error report: %v", err)
+	}
+	log.Printf("Wrote error report for %d failed models to %s", len(file.Models), path)
+	return nil
+}
+
+// ReadFailedModels returns the models listed in the error report of a previous run in outputDir
+func ReadFailedModels(outputDir string) ([]string, error) {
+	path := filepath.Join(outputDir, FileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading error report: %v", err)
+	}
+	var file reportFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("error parsing error report %s: %v", path, err)
+	}
+	models := make([]string, 0, len(file.Models))
+	for _, model := range file.Models {
+		models = append(models, model.Model)
+	}
+	return models, nil
+}
+
+// Chain returns the messages of an error and the errors it wraps, outermost first. Each message
+// omits the wrapped error's text it ends with, e.g. "failed to fetch README: status 404" becomes
+// ["failed to fetch README", "status 404"] when the status error is wrapped with %w.
+func Chain(err error) []string {
+	var chain []string
+	for err != nil {
+		next := errors.Unwrap(err)
+		message := err.Error()
+		if next != nil {
+			message = strings.TrimSuffix(message, ": "+next.Error())
+		}
+		chain = append(chain, message)
+		err = next
+	}
+	return chain
+}
@@ -0,0 +1,101 @@
+package errorreport
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/errdefs"
+)
+
+func TestChain(t *testing.T) {
+	inner := errors.New("README not found, status 404")
+	err := fmt.Errorf("failed to fetch HuggingFace README of org/model: %w", inner)
+
+	want := []string{"failed to fetch HuggingFace README of org/model", "README not found, status 404"}
+	if got := Chain(err); !reflect.DeepEqual(got, want) {
+		t.Errorf("Chain() = %v, want %v", got, want)
+	}
+
+	// Errors wrapped with %v cannot be unwrapped and stay one message
+	flat := fmt.Errorf("failed to load: %v", inner)
+	if got := Chain(flat); len(got) != 1 || got[0] != flat.Error() {
+		t.Errorf("Chain() = %v, want the single message", got)
+	}
+}
+
+func TestReportWrite(t *testing.T) {
+	dir := t.TempDir()
+	report := New()
+
+	var wg sync.WaitGroup
+	for _, stage := range []string{"enrichment", "artifacts"} {
+		wg.Add(1)
+		go func(stage string) {
+			defer wg.Done()
+			report.Record("registry.example.com/org/b:1.0", stage, errors.New(stage+" failed"))
+		}(stage)
+	}
+	wg.Wait()
+	report.Record("registry.example.com/org/a:1.0", "extraction", errdefs.ErrNoModelcardLayer)
+	report.MarkSkeleton("registry.example.com/org/a:1.0")
+	report.Record("registry.example.com/org/c:1.0", "enrichment", nil)
+
+	if err := report.Write(dir); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, FileName))
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	var file reportFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		t.Fatalf("failed to parse report: %v", err)
+	}
+
+	if len(file.Models) != 2 {
+		t.Fatalf("expected 2 failed models, got %d: %+v", len(file.Models), file.Models)
+	}
+	b, a := file.Models[0], file.Models[1]
+	if b.Model != "registry.example.com/org/b:1.0" || len(b.Failures) != 2 || b.SkeletonCreated || b.Failures[0].Kind != "" {
+		t.Errorf("unexpected entry for b: %+v", b)
+	}
+	if a.Model != "registry.example.com/org/a:1.0" || !a.SkeletonCreated || a.Failures[0].Stage != "extraction" || a.Failures[0].Kind != errdefs.KindNoModelcardLayer {
+		t.Errorf("unexpected entry for a: %+v", a)
+	}
+
+	failed, err := ReadFailedModels(dir)
+	if err != nil {
+		t.Fatalf("ReadFailedModels failed: %v", err)
+	}
+	if want := []string{"registry.example.com/org/b:1.0", "registry.example.com/org/a:1.0"}; !reflect.DeepEqual(failed, want) {
+		t.Errorf("ReadFailedModels() = %v, want %v", failed, want)
+	}
+	if _, err := ReadFailedModels(t.TempDir()); err == nil {
+		t.Error("expected an error without a previous error report")
+	}
+}
+
+func TestReportWrite_NoFailures(t *testing.T) {
+	dir := t.TempDir()
+	if err := New().Write(dir); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, FileName))
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	if string(data) != "models: []\n" {
+		t.Errorf("expected an empty model list, got %q", data)
+	}
+
+	var report *Report
+	report.Record("ref", "extraction", errors.New("ignored"))
+	report.MarkSkeleton("ref")
+}
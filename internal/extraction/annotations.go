@@ -0,0 +1,76 @@
+package extraction
+
+import (
+	"fmt"
+	"strings"
+
+	containertypes "github.com/containers/image/v5/types"
+)
+
+// LayerAnnotation identifies modelcard layers by a layer annotation. An empty Value accepts any
+// value of the annotation.
+type LayerAnnotation struct {
+	Key   string
+	Value string
+}
+
+// DefaultModelcardAnnotation is the annotation of modelcard layers in OpenDataHub modelcars
+var DefaultModelcardAnnotation = LayerAnnotation{Key: "io.opendatahub.modelcar.layer.type", Value: "modelcard"}
+
+// String returns the annotation as key=value, or key when any value is accepted
+func (a LayerAnnotation) String() string {
+	if a.Value == "" {
+		return a.Key
+	}
+	return a.Key + "=" + a.Value
+}
+
+// matches reports whether layer annotations include the annotation
+func (a LayerAnnotation) matches(layerAnnotations map[string]string) bool {
+	value, exists := layerAnnotations[a.Key]
+	return exists && (a.Value == "" || value == a.Value)
+}
+
+// ParseLayerAnnotations parses a comma-separated list of key=value annotations; an annotation
+// given as a bare key accepts any value
+func ParseLayerAnnotations(spec string) ([]LayerAnnotation, error) {
+	var annotations []LayerAnnotation
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(item, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if key == "" {
+			return nil, fmt.Errorf("annotation %q has no key", item)
+		}
+		annotations = append(annotations, LayerAnnotation{Key: key, Value: value})
+	}
+	return annotations, nil
+}
+
+// ModelcardAnnotationsOrDefault returns annotations, or DefaultModelcardAnnotation when none are set
+func ModelcardAnnotationsOrDefault(annotations []LayerAnnotation) []LayerAnnotation {
+	if len(annotations) == 0 {
+		return []LayerAnnotation{DefaultModelcardAnnotation}
+	}
+	return annotations
+}
+
+// IsModelcardLayer reports whether layer annotations include any of the accepted modelcard
+// annotations, DefaultModelcardAnnotation when annotations is empty
+func IsModelcardLayer(annotations []LayerAnnotation, layerAnnotations map[string]string) bool {
+	for _, annotation := range ModelcardAnnotationsOrDefault(annotations) {
+		if annotation.matches(layerAnnotations) {
+			return true
+		}
+	}
+	return false
+}
+
+// isModelcardLayer reports whether a layer carries any of the accepted modelcard annotations,
+// DefaultModelcardAnnotation unless Options.ModelcardAnnotations is set
+func (e *extractor) isModelcardLayer(layer containertypes.BlobInfo) bool {
+	return IsModelcardLayer(e.ModelcardAnnotations, layer.Annotations)
+}
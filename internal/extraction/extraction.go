@@ -0,0 +1,388 @@
+package extraction
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/manifest"
+	containertypes "github.com/containers/image/v5/types"
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/checkpoint"
+	"github.com/opendatahub-io/model-metadata-collection/internal/config"
+	"github.com/opendatahub-io/model-metadata-collection/internal/enrichment"
+	"github.com/opendatahub-io/model-metadata-collection/internal/errorreport"
+	"github.com/opendatahub-io/model-metadata-collection/internal/huggingface"
+	"github.com/opendatahub-io/model-metadata-collection/internal/metadata"
+	"github.com/opendatahub-io/model-metadata-collection/internal/metrics"
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+	"github.com/opendatahub-io/model-metadata-collection/internal/registry"
+	"github.com/opendatahub-io/model-metadata-collection/internal/verification"
+	"github.com/opendatahub-io/model-metadata-collection/internal/vulnscan"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// Options configures model extraction
+type Options struct {
+	// Output receives a directory per model with its modelcard and metadata.yaml
+	Output outputfs.FS
+	// MaxConcurrent bounds the number of models extracted at the same time
+	MaxConcurrent int
+	// MatchThreshold is the minimum similarity of the HuggingFace model whose README replaces a
+	// missing modelcard; defaults to the enrichment threshold
+	MatchThreshold float64
+	// ModelcardAnnotations are the layer annotations marking modelcard layers, any of which is
+	// accepted; defaults to DefaultModelcardAnnotation
+	ModelcardAnnotations []LayerAnnotation
+	// KeepIntermediate keeps each model's raw manifest, config blob and modelcard layer in its
+	// debug directory
+	KeepIntermediate bool
+	// Verifier checks the signatures, attestations and SBOMs of each image and records the
+	// result on its artifact; nil skips the checks
+	Verifier *verification.Verifier
+	// VulnerabilityScanner returns the vulnerability counts of each image, recorded on its
+	// artifact; nil skips the scans
+	VulnerabilityScanner vulnscan.Scanner
+	// Checkpoint records the extracted models; nil records nothing
+	Checkpoint *checkpoint.Checkpoint
+	// Errors collects the models whose extraction failed; nil only logs them
+	Errors *errorreport.Report
+	// HuggingFace fetches the READMEs that replace missing modelcards and describes hf:// models;
+	// nil uses a client with the default options
+	HuggingFace *huggingface.Client
+	// Readme configures how modelcards become the readme field of each model
+	Readme metadata.ReadmeOptions
+	// RegistryAuth holds the credentials of registries that are used instead of the container
+	// auth files; nil uses the auth files for every registry
+	RegistryAuth registry.Auth
+}
+
+// ModelResult represents the result of processing a single model
+type ModelResult struct {
+	Ref            string
+	ModelCardFound bool
+	Metadata       types.ModelMetadata
+}
+
+// extractor carries the options of a ProcessModels call to the per-model steps
+type extractor struct {
+	Options
+}
+
+// LoadModels loads models with their metadata from various sources with fallback logic
+func LoadModels(modelsIndexPath string) ([]types.ModelEntry, error) {
+	// First try to load from specified models index file
+	if _, err := os.Stat(modelsIndexPath); err == nil {
+		log.Printf("Loading models from: %s", modelsIndexPath)
+		// Report every problem of the index before any registry call is made for its models
+		issues, err := ValidateModelsIndexFile(modelsIndexPath)
+		if err != nil {
+			return nil, err
+		}
+		if len(issues) > 0 {
+			return nil, &IndexError{Path: modelsIndexPath, Issues: issues}
+		}
+		return config.LoadModelsConfigFromYAML(modelsIndexPath)
+	}
+
+	// Try to load from latest version index file as fallback
+	latestIndexFile, err := huggingface.GetLatestVersionIndexFile()
+	if err == nil {
+		log.Printf("Using latest version index file: %s", latestIndexFile)
+		// Convert version index to model entries (all validated=true, featured=false by default)
+		modelURIs, err := config.LoadModelsFromVersionIndex(latestIndexFile)
+		if err != nil {
+			return nil, err
+		}
+
+		var modelEntries []types.ModelEntry
+		for _, uri := range modelURIs {
+			modelEntries = append(modelEntries, types.ModelEntry{
+				Type:   "oci",
+				URI:    uri,
+				Labels: []string{"validated"},
+			})
+		}
+		return modelEntries, nil
+	}
+
+	return nil, fmt.Errorf("no valid models index file found at %s and no version index files available", modelsIndexPath)
+}
+
+// ProcessModels extracts the metadata of the models concurrently, recording each completed model
+// in the checkpoint. Once ctx is canceled, no further models are started and models still
+// fetching from the registry are left out of the results.
+func ProcessModels(ctx context.Context, modelEntries []types.ModelEntry, opts Options) []ModelResult {
+	e := &extractor{Options: opts}
+	if e.MaxConcurrent < 1 {
+		e.MaxConcurrent = 1
+	}
+	if e.MatchThreshold <= 0 {
+		e.MatchThreshold = enrichment.DefaultMatchThreshold
+	}
+	if e.HuggingFace == nil {
+		e.HuggingFace = huggingface.NewClient(huggingface.Options{})
+	}
+
+	// Extract URIs for processing
+	var manifestRefs []string
+	uriToEntry := make(map[string]types.ModelEntry)
+
+	for _, entry := range modelEntries {
+		manifestRefs = append(manifestRefs, entry.URI)
+		uriToEntry[entry.URI] = entry
+	}
+
+	sys := &containertypes.SystemContext{
+		ArchitectureChoice: "amd64",
+		OSChoice:           "linux",
+	}
+
+	// Create a WaitGroup to wait for all goroutines to complete
+	var wg sync.WaitGroup
+
+	// Create a semaphore to limit concurrent goroutines
+	semaphore := make(chan struct{}, e.MaxConcurrent)
+
+	// Channel to collect results from goroutines
+	results := make(chan ModelResult, len(manifestRefs))
+
+	// Process each manifest reference in parallel with concurrency limit
+	for _, manifestRef := range manifestRefs {
+		// Acquire semaphore (blocks if max goroutines are already running)
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			log.Printf("Interrupted, not starting the remaining models")
+			break
+		}
+
+		wg.Add(1)
+		go func(ref string, entry types.ModelEntry) {
+			defer wg.Done()
+			defer func() { <-semaphore }() // Release semaphore when done
+
+			log.Printf("Starting processing for: %s", ref)
+			if isRemoteEntry(entry) {
+				result, err := e.processRemoteModel(ctx, ref, entry)
+				if err != nil {
+					log.Printf("Warning: Failed to describe %s: %v", ref, err)
+					e.Errors.Record(ref, checkpoint.StageExtraction, err)
+				}
+				results <- result
+				return
+			}
+			image, err := e.fetchImage(ctx, ref, sys)
+			if err != nil && ctx.Err() != nil {
+				log.Printf("Interrupted before %s was extracted: %v", ref, err)
+				return
+			}
+			if err != nil {
+				// The model is reported without metadata; it is not checkpointed, so it is
+				// extracted again when the run is resumed
+				log.Printf("Warning: Failed to fetch %s from its registry: %v", ref, err)
+				e.Errors.Record(ref, checkpoint.StageExtraction, err)
+				results <- ModelResult{Ref: ref}
+				return
+			}
+			defer func() { _ = image.src.Close() }()
+			src, layers := image.src, image.layers
+			modelCardFound, metadata := e.scanLayersForModelCardWithTags(ctx, layers, src, ref, image.configBlob, entry)
+			if weights := e.scanLayersForGGUF(ctx, layers, src); weights != nil {
+				e.addQuantizationToMetadata(ref, weights.Metadata.ToQuantizationInfo(weights.FileName))
+				e.writeModelfile(ref, weights)
+			}
+			if modelConfig := e.scanLayersForModelConfig(ctx, layers, src); modelConfig != nil {
+				e.addModelConfigToMetadata(ref, modelConfig)
+			}
+			if performance := performanceFromImage(ref, image.annotations, image.configBlob); performance != nil {
+				e.addPerformanceToMetadata(ref, performance)
+			}
+			if ctx.Err() != nil {
+				log.Printf("Interrupted while extracting %s", ref)
+				return
+			}
+			log.Printf("Completed processing for: %s", ref)
+			e.recordVersion(ref, image.digest)
+			e.recordVerification(ctx, ref, image.digest)
+			e.recordVulnerabilities(ctx, ref, image.digest)
+			if err := e.Checkpoint.MarkDone(ref, checkpoint.StageExtraction); err != nil {
+				log.Printf("Warning: %v", err)
+			}
+
+			// Send result to channel
+			results <- ModelResult{
+				Ref:            ref,
+				ModelCardFound: modelCardFound,
+				Metadata:       metadata,
+			}
+		}(manifestRef, uriToEntry[manifestRef])
+	}
+
+	// Wait for all goroutines to complete
+	wg.Wait()
+	close(results)
+
+	// Collect all results
+	var modelResults []ModelResult
+	for result := range results {
+		modelResults = append(modelResults, result)
+	}
+
+	return modelResults
+}
+
+// fetchedImage is an image read from its registry by fetchImage
+type fetchedImage struct {
+	// src is the image source layers are read from; the caller closes it
+	src        containertypes.ImageSource
+	layers     []containertypes.BlobInfo
+	configBlob []byte
+	// digest is the digest of the manifest the reference resolves to
+	digest string
+	// annotations are the annotations of the image manifest, over those of its image index
+	annotations map[string]string
+}
+
+// fetchImage fetches the manifest, layers and config blob of an image from its container
+// registry. Registry errors are classified with registry.ClassifyError; when ctx was canceled,
+// ctx.Err() is returned instead.
+func (e *extractor) fetchImage(ctx context.Context, manifestRef string, sys *containertypes.SystemContext) (*fetchedImage, error) {
+	start := time.Now()
+	log.Printf("Parsing reference...")
+	ref, err := docker.ParseReference("//" + manifestRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse reference: %v", err)
+	}
+
+	// Create a new image source (later will use to get "the" blob)
+	log.Printf("Creating image source...")
+	// Service-account credentials apply to their registry only, so they are chosen per image
+	sys = e.RegistryAuth.SystemContext(sys, manifestRef)
+	src, err := ref.NewImageSource(ctx, sys)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("failed to create image source: %w", registry.ClassifyError(err))
+	}
+	// not closing `src` given it is returned to the caller
+
+	// Get the manifest
+	manifestBlob, manifestType, err := src.GetManifest(ctx, nil)
+	if err != nil {
+		_ = src.Close()
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("failed to get manifest: %w", registry.ClassifyError(err))
+	}
+
+	log.Printf("Manifest type: %s", manifestType)
+	manifestDigest, err := manifest.Digest(manifestBlob)
+	if err != nil {
+		_ = src.Close()
+		return nil, fmt.Errorf("failed to compute manifest digest: %v", err)
+	}
+	log.Printf("Manifest digest: %s", manifestDigest)
+	log.Printf("Manifest size: %d bytes", len(manifestBlob))
+	e.keepIntermediateFile(manifestRef, "manifest.json", manifestBlob)
+
+	// Get the image
+	img, err := ref.NewImage(ctx, sys)
+	if err != nil {
+		_ = src.Close()
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("failed to create image: %w", registry.ClassifyError(err))
+	}
+	defer func() { _ = img.Close() }()
+
+	// Get the image configuration
+	log.Printf("Getting config blob...")
+	configBlob, err := img.ConfigBlob(ctx)
+	if err != nil {
+		_ = src.Close()
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("failed to get config blob: %w", registry.ClassifyError(err))
+	}
+
+	annotations := manifestAnnotations(manifestBlob)
+	if imageManifest, _, err := img.Manifest(ctx); err == nil {
+		for key, value := range manifestAnnotations(imageManifest) {
+			annotations[key] = value
+		}
+	}
+
+	log.Printf("Config blob size: %d bytes", len(configBlob))
+	e.keepIntermediateFile(manifestRef, "config.json", configBlob)
+
+	// Get layer information
+	log.Printf("Getting layer infos...")
+	layers := img.LayerInfos()
+	log.Printf("Number of layers: %d", len(layers))
+
+	// Get layer digests from layer infos
+	log.Printf("Layer digests:")
+	for i, layer := range layers {
+		log.Printf("  Layer %d: %s", i+1, layer.Digest)
+	}
+	metrics.Default.ObserveDuration(metrics.RegistryFetchDuration, start)
+	return &fetchedImage{
+		src:         src,
+		layers:      layers,
+		configBlob:  configBlob,
+		digest:      manifestDigest.String(),
+		annotations: annotations,
+	}, nil
+}
+
+// WriteManifests creates the manifests.yaml file tracking all processed models in the output
+// directory
+func WriteManifests(output outputfs.FS, modelResults []ModelResult) error {
+	var manifests types.ManifestsData
+
+	for _, result := range modelResults {
+		manifest := types.ModelManifest{
+			Ref: result.Ref,
+			ModelCard: types.ModelCard{
+				Present:  result.ModelCardFound,
+				Metadata: result.Metadata,
+			},
+		}
+		manifests.Models = append(manifests.Models, manifest)
+	}
+
+	// Marshal to YAML
+	yamlData, err := yaml.Marshal(&manifests)
+	if err != nil {
+		return err
+	}
+
+	// Ensure output directory exists
+	err = output.MkdirAll(".", 0755)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so a run stopped mid-write never leaves a truncated file
+	if err := output.WriteFile("manifests.yaml.tmp", yamlData, 0644); err != nil {
+		return err
+	}
+	if err := output.Rename("manifests.yaml.tmp", "manifests.yaml"); err != nil {
+		return err
+	}
+
+	log.Printf("Generated manifests.yaml with %d models", len(manifests.Models))
+	return nil
+}
@@ -0,0 +1,350 @@
+package extraction
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	containertypes "github.com/containers/image/v5/types"
+	"github.com/opencontainers/go-digest"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/checkpoint"
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+func TestSelectModelCardFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    []modelCardFile
+		expected string
+	}{
+		{
+			name:     "single file",
+			files:    []modelCardFile{{Name: "models/modelcard.md", Content: []byte("# Card")}},
+			expected: "models/modelcard.md",
+		},
+		{
+			name: "README.md preferred over larger files",
+			files: []modelCardFile{
+				{Name: "models/USE_POLICY.md", Content: []byte("# A much longer acceptable use policy")},
+				{Name: "models/README.md", Content: []byte("# Card")},
+			},
+			expected: "models/README.md",
+		},
+		{
+			name: "shallowest README.md",
+			files: []modelCardFile{
+				{Name: "models/docs/readme.md", Content: []byte("# Docs")},
+				{Name: "models/README.md", Content: []byte("# Card")},
+			},
+			expected: "models/README.md",
+		},
+		{
+			name: "largest file without a README.md",
+			files: []modelCardFile{
+				{Name: "models/NOTICE.md", Content: []byte("Notice")},
+				{Name: "models/granite.md", Content: []byte("# Granite 3.1 8B Instruct")},
+			},
+			expected: "models/granite.md",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := selectModelCardFile(tt.files); got.Name != tt.expected {
+				t.Errorf("selectModelCardFile() = %s, want %s", got.Name, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSelectLicenseFile(t *testing.T) {
+	if got := selectLicenseFile(nil); got != nil {
+		t.Errorf("Expected no license file, got %s", got.Name)
+	}
+
+	files := []modelCardFile{
+		{Name: "models/LICENSE.md", Content: []byte("# License")},
+		{Name: "models/license.txt", Content: []byte("Apache License")},
+		{Name: "models/LICENSE", Content: []byte("  \n")},
+	}
+	got := selectLicenseFile(files)
+	if got == nil || got.Name != "models/license.txt" {
+		t.Errorf("Expected models/license.txt (empty LICENSE skipped), got %+v", got)
+	}
+
+	if licenseFilePriority("models/README.md") != -1 {
+		t.Error("Expected README.md not to be a license file")
+	}
+}
+
+func TestReadModelConfigFiles(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	writeFile := func(name string, size int, content []byte) {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(size), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if content == nil {
+			content = make([]byte, size)
+		}
+		if _, err := tw.Write(content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	config := []byte(`{"model_type": "llama"}`)
+	writeFile("models/tokenizer_config.json", 2, []byte("{}"))
+	writeFile("models/config.json", len(config), config)
+	writeFile("models/model-00001-of-00002.safetensors", maxConfigScanFileSize+1, nil)
+	writeFile("models/generation_config.json", 2, []byte("{}"))
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := readModelConfigFiles(&buf)
+	if err != nil {
+		t.Fatalf("readModelConfigFiles() error = %v", err)
+	}
+	if string(files["config.json"]) != string(config) {
+		t.Errorf("Expected config.json content, got %q", files["config.json"])
+	}
+	// The scan stops at the weights, so files packed after them are not read
+	if _, ok := files["generation_config.json"]; ok {
+		t.Error("Expected generation_config.json after the weights not to be read")
+	}
+}
+
+func TestResumedModels(t *testing.T) {
+	tmpDir := t.TempDir()
+	cp, err := checkpoint.New(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create checkpoint: %v", err)
+	}
+	if err := cp.MarkDone("registry.example.com/done:1", checkpoint.StageExtraction); err != nil {
+		t.Fatalf("Failed to update checkpoint: %v", err)
+	}
+	manifests := "models:\n  - ref: registry.example.com/done:1\n    modelcard:\n      present: true\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "manifests.yaml"), []byte(manifests), 0644); err != nil {
+		t.Fatalf("Failed to write manifests: %v", err)
+	}
+
+	entries := []types.ModelEntry{{URI: "registry.example.com/done:1"}, {URI: "registry.example.com/pending:1"}}
+	pending, resumed := SplitResumed(entries, cp)
+	if len(pending) != 1 || pending[0].URI != "registry.example.com/pending:1" {
+		t.Errorf("Expected only the pending model to be extracted, got %v", pending)
+	}
+	if len(resumed) != 1 || resumed[0] != "registry.example.com/done:1" {
+		t.Fatalf("Expected the extracted model to be resumed, got %v", resumed)
+	}
+
+	results := ResumedResults(outputfs.Dir(tmpDir), resumed)
+	if len(results) != 1 || !results[0].ModelCardFound {
+		t.Errorf("Expected the resumed result from manifests.yaml, got %+v", results)
+	}
+}
+
+func TestKeepModelcardLayer(t *testing.T) {
+	tmpDir := t.TempDir()
+	e := &extractor{Options: Options{Output: outputfs.Dir(tmpDir)}}
+
+	layer := containertypes.BlobInfo{Digest: digest.FromString("layer"), MediaType: "application/vnd.oci.image.layer.v1.tar+gzip"}
+	ref := "registry.example.com/org/model:1.0"
+
+	// Without KeepIntermediate nothing is written
+	e.KeepIntermediate = false
+	if _, err := io.ReadAll(e.keepModelcardLayer(ref, layer, strings.NewReader("blob"))); err != nil {
+		t.Fatalf("Failed to read layer: %v", err)
+	}
+	debugDir := filepath.Join(tmpDir, utils.SanitizeManifestRef(ref), IntermediateDirName)
+	if _, err := os.Stat(debugDir); !os.IsNotExist(err) {
+		t.Fatalf("Expected no debug directory, got %v", err)
+	}
+
+	e.KeepIntermediate = true
+	content, err := io.ReadAll(e.keepModelcardLayer(ref, layer, strings.NewReader("blob")))
+	if err != nil || string(content) != "blob" {
+		t.Fatalf("Expected the layer content to be passed through, got %q, %v", content, err)
+	}
+	saved, err := os.ReadFile(filepath.Join(debugDir, "modelcard-layer-"+layer.Digest.Encoded()+".tar.gz"))
+	if err != nil || string(saved) != "blob" {
+		t.Errorf("Expected the raw layer to be kept, got %q, %v", saved, err)
+	}
+}
+
+func TestAddModelLabelTags_OutputDir(t *testing.T) {
+	ref := "registry.example.com/org/model:1.0"
+	first := &extractor{Options: Options{Output: outputfs.Dir(t.TempDir())}}
+	second := &extractor{Options: Options{Output: outputfs.Dir(t.TempDir())}}
+
+	// Each extractor only updates the metadata in its own output directory
+	for _, e := range []*extractor{first, second} {
+		if err := e.Output.MkdirAll(outputfs.ModelPath(ref), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := e.Output.WriteFile(outputfs.ModelPath(ref, "metadata.yaml"), []byte("tags:\n  - existing\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	first.addModelLabelTags(ref, types.ModelEntry{URI: ref, Labels: []string{"validated"}})
+
+	for _, tt := range []struct {
+		e       *extractor
+		labeled bool
+	}{
+		{first, true},
+		{second, false},
+	} {
+		data, err := tt.e.Output.ReadFile(outputfs.ModelPath(ref, "metadata.yaml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(data), "validated") != tt.labeled {
+			t.Errorf("Expected label in %s: %v, got metadata %q", tt.e.Output.Path("."), tt.labeled, data)
+		}
+	}
+}
+
+func TestIsModelcardLayer(t *testing.T) {
+	layer := func(annotations map[string]string) containertypes.BlobInfo {
+		return containertypes.BlobInfo{Annotations: annotations}
+	}
+	odh := layer(map[string]string{"io.opendatahub.modelcar.layer.type": "modelcard"})
+	custom := layer(map[string]string{"com.example.layer.kind": "docs"})
+	weights := layer(map[string]string{"io.opendatahub.modelcar.layer.type": "weights"})
+
+	defaults := &extractor{}
+	if !defaults.isModelcardLayer(odh) || defaults.isModelcardLayer(custom) || defaults.isModelcardLayer(weights) {
+		t.Error("Expected only the OpenDataHub modelcard annotation to be accepted by default")
+	}
+
+	annotations, err := ParseLayerAnnotations("io.opendatahub.modelcar.layer.type=modelcard, com.example.layer.kind")
+	if err != nil {
+		t.Fatalf("ParseLayerAnnotations failed: %v", err)
+	}
+	configured := &extractor{Options: Options{ModelcardAnnotations: annotations}}
+	if !configured.isModelcardLayer(odh) || !configured.isModelcardLayer(custom) || configured.isModelcardLayer(weights) {
+		t.Errorf("Expected any of %v to mark a modelcard layer", annotations)
+	}
+	if configured.isModelcardLayer(layer(nil)) {
+		t.Error("Expected a layer without annotations not to be a modelcard layer")
+	}
+
+	if _, err := ParseLayerAnnotations("=modelcard"); err == nil {
+		t.Error("Expected an error for an annotation without a key")
+	}
+}
+
+func TestPerformanceFromImage(t *testing.T) {
+	configBlob := []byte(`{"config":{"Labels":{
+		"io.opendatahub.modelcar.performance.tokens-per-second":"1800",
+		"io.opendatahub.modelcar.performance.hardware":"1x NVIDIA L40S"
+	}}}`)
+	annotations := map[string]string{
+		"io.opendatahub.modelcar.performance.tokens-per-second":  "2450.5",
+		"io.opendatahub.modelcar.performance.request-latency-ms": "fast",
+		"io.opendatahub.modelcar.performance.concurrency":        "32",
+		"org.opencontainers.image.title":                         "granite",
+	}
+
+	performance := performanceFromImage("registry.example.com/models/granite:1.0", annotations, configBlob)
+	expected := types.PerformanceInfo{TokensPerSecond: 2450.5, Hardware: "1x NVIDIA L40S", Concurrency: 32}
+	if performance == nil || *performance != expected {
+		t.Errorf("Expected %+v, got %+v", expected, performance)
+	}
+
+	if performance := performanceFromImage("registry.example.com/models/granite:1.0", map[string]string{}, nil); performance != nil {
+		t.Errorf("Expected no performance for an image without annotations, got %+v", performance)
+	}
+}
+
+// blobSource serves layer blobs from memory and counts how many were opened
+type blobSource struct {
+	containertypes.ImageSource
+	blobs  map[digest.Digest][]byte
+	opened []digest.Digest
+}
+
+func (s *blobSource) GetBlob(_ context.Context, info containertypes.BlobInfo, _ containertypes.BlobInfoCache) (io.ReadCloser, int64, error) {
+	s.opened = append(s.opened, info.Digest)
+	data := s.blobs[info.Digest]
+	return io.NopCloser(bytes.NewReader(data)), int64(len(data)), nil
+}
+
+func TestScanLayersForGGUF_StopsAtOtherWeights(t *testing.T) {
+	tarLayer := func(name string) []byte {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		_ = tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: 4})
+		_, _ = tw.Write([]byte("data"))
+		_ = tw.Close()
+		return buf.Bytes()
+	}
+	src := &blobSource{blobs: map[digest.Digest][]byte{}}
+	var layers []containertypes.BlobInfo
+	for _, name := range []string{"usr/bin/sh", "models/model-00001-of-00002.safetensors", "models/model-00002-of-00002.safetensors"} {
+		data := tarLayer(name)
+		layer := containertypes.BlobInfo{Digest: digest.FromBytes(data), Size: -1, MediaType: "application/vnd.oci.image.layer.v1.tar"}
+		src.blobs[layer.Digest] = data
+		layers = append(layers, layer)
+	}
+	// Raw files that are not GGUF are never opened
+	layers = append([]containertypes.BlobInfo{{
+		Digest:      digest.FromString("tokenizer"),
+		Size:        2 << 20,
+		MediaType:   "application/octet-stream",
+		Annotations: map[string]string{"org.opencontainers.image.title": "tokenizer.json"},
+	}}, layers...)
+
+	e := &extractor{}
+	if weights := e.scanLayersForGGUF(context.Background(), layers, src); weights != nil {
+		t.Fatalf("Expected no GGUF weights, got %+v", weights)
+	}
+	if len(src.opened) != 2 || src.opened[0] != layers[1].Digest || src.opened[1] != layers[2].Digest {
+		t.Errorf("Expected only the base layer and the first weight shard to be probed, got %v", src.opened)
+	}
+
+	// A safetensors title annotation ends the scan without opening any blob
+	src.opened = nil
+	annotated := []containertypes.BlobInfo{{
+		Digest:      digest.FromString("weights"),
+		Size:        2 << 20,
+		MediaType:   "application/octet-stream",
+		Annotations: map[string]string{"org.opencontainers.image.title": "model.safetensors"},
+	}, layers[1]}
+	if weights := e.scanLayersForGGUF(context.Background(), annotated, src); weights != nil || len(src.opened) != 0 {
+		t.Errorf("Expected no layer to be probed after a safetensors layer, got %v", src.opened)
+	}
+}
+
+func TestApplyModelLifecycle(t *testing.T) {
+	endOfLife := "2026-01-31"
+	replacedBy := "model-v2"
+	entry := types.ModelEntry{Type: "oci", URI: "registry.redhat.io/org/model:1.0", Deprecated: true, EndOfLife: &endOfLife, ReplacedBy: &replacedBy}
+
+	metadata := types.ExtractedMetadata{}
+	if !applyModelLifecycle(&metadata, entry) {
+		t.Fatal("Expected deprecating a model to report a change")
+	}
+	if !metadata.Deprecated || metadata.EndOfLife == nil || *metadata.EndOfLife != endOfLife || metadata.ReplacedBy == nil || *metadata.ReplacedBy != replacedBy {
+		t.Fatalf("Expected lifecycle fields to be copied, got %+v", metadata)
+	}
+	if applyModelLifecycle(&metadata, entry) {
+		t.Error("Expected re-applying the same entry to report no change")
+	}
+
+	// Un-deprecating a model in the index clears the fields written by the earlier run
+	entry = types.ModelEntry{Type: "oci", URI: entry.URI}
+	if !applyModelLifecycle(&metadata, entry) {
+		t.Fatal("Expected un-deprecating a model to report a change")
+	}
+	if metadata.Deprecated || metadata.EndOfLife != nil || metadata.ReplacedBy != nil {
+		t.Errorf("Expected stale lifecycle fields to be cleared, got %+v", metadata)
+	}
+}
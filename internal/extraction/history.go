@@ -0,0 +1,27 @@
+package extraction
+
+import (
+	"log"
+	"time"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/history"
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+)
+
+// recordVersion adds the extracted version of a model to its version history, warning when the
+// model rolled back to the digest of an earlier version
+func (e *extractor) recordVersion(ref, digest string) {
+	metadata, err := e.Output.ReadFile(outputfs.ModelPath(ref, "metadata.yaml"))
+	if err != nil {
+		log.Printf("  Warning: Not recording version history of %s: %v", ref, err)
+		return
+	}
+	version, err := history.Record(e.Output, ref, digest, metadata, time.Now())
+	if err != nil {
+		log.Printf("  Warning: %v", err)
+		return
+	}
+	if version != nil && version.Rollback {
+		log.Printf("  Warning: %s rolled back to %s, the digest of an earlier version", ref, digest)
+	}
+}
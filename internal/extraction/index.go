@@ -0,0 +1,190 @@
+package extraction
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// IndexIssue is a single problem found in a models index
+type IndexIssue struct {
+	// Line is the 1-based line the problem was found on, or 0 when it applies to the whole file
+	Line    int
+	Message string
+}
+
+// IndexError reports every problem of a models index that failed validation
+type IndexError struct {
+	Path   string
+	Issues []IndexIssue
+}
+
+func (e *IndexError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s has %d problems:", e.Path, len(e.Issues))
+	for _, issue := range e.Issues {
+		if issue.Line > 0 {
+			fmt.Fprintf(&b, "\n  %s:%d: %s", e.Path, issue.Line, issue.Message)
+		} else {
+			fmt.Fprintf(&b, "\n  %s: %s", e.Path, issue.Message)
+		}
+	}
+	return b.String()
+}
+
+// indexYAMLErrorLine matches the line prefix of yaml.v3 syntax and type errors
+var indexYAMLErrorLine = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// ValidateModelsIndexFile checks a models index and returns every problem found, with the line it
+// occurs on where known: unknown fields, unknown entry types and labels, malformed URIs, duplicate
+// entries and invalid lifecycle fields. An error is returned only when the file cannot be read.
+func ValidateModelsIndexFile(path string) ([]IndexIssue, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading models index: %v", err)
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return indexYAMLIssues(err), nil
+	}
+
+	// Unknown fields are usually typos, such as "lables", that would otherwise be dropped silently
+	var index types.ModelsConfig
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&index); err != nil && !errors.Is(err, io.EOF) {
+		return indexYAMLIssues(err), nil
+	}
+
+	return modelsIndexIssues(index.Models, indexEntryLines(&node)), nil
+}
+
+// indexYAMLIssues converts a YAML syntax or type error into issues, one per reported line
+func indexYAMLIssues(err error) []IndexIssue {
+	messages := []string{err.Error()}
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		messages = typeErr.Errors
+	}
+
+	issues := make([]IndexIssue, 0, len(messages))
+	for _, message := range messages {
+		issue := IndexIssue{Message: message}
+		if match := indexYAMLErrorLine.FindStringSubmatch(message); match != nil {
+			issue.Line, _ = strconv.Atoi(match[1])
+			issue.Message = match[2]
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// entryURIScheme returns the artifact URI scheme of a models index entry type
+func entryURIScheme(entryType string) string {
+	switch entryType {
+	case types.ModelEntryTypeHTTPS:
+		return utils.URISchemeHTTPS
+	case types.ModelEntryTypeS3:
+		return utils.URISchemeS3
+	case types.ModelEntryTypeHF:
+		return utils.URISchemeHF
+	}
+	return utils.URISchemeOCI
+}
+
+// quoteAll returns values quoted as Go strings
+func quoteAll(values []string) []string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = strconv.Quote(value)
+	}
+	return quoted
+}
+
+// indexEntryLines returns the line of every entry of a parsed models index document
+func indexEntryLines(document *yaml.Node) []int {
+	if len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		return nil
+	}
+	root := document.Content[0]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != "models" || root.Content[i+1].Kind != yaml.SequenceNode {
+			continue
+		}
+		var lines []int
+		for _, entry := range root.Content[i+1].Content {
+			lines = append(lines, entry.Line)
+		}
+		return lines
+	}
+	return nil
+}
+
+// modelsIndexIssues returns every problem of the entries of a models index, in document order
+func modelsIndexIssues(entries []types.ModelEntry, lines []int) []IndexIssue {
+	var issues []IndexIssue
+
+	// firstLine maps normalized URIs to the line of the entry first listing them
+	firstLine := make(map[string]int)
+	for i, entry := range entries {
+		line := 0
+		if i < len(lines) {
+			line = lines[i]
+		}
+		report := func(format string, args ...interface{}) {
+			issues = append(issues, IndexIssue{Line: line, Message: fmt.Sprintf(format, args...)})
+		}
+
+		allowedTypes := strings.Join(quoteAll(types.ModelEntryTypes), ", ")
+		knownType := slices.Contains(types.ModelEntryTypes, entry.Type)
+		if entry.Type == "" {
+			report("model at index %d missing required 'type' field (allowed values: %s)", i, allowedTypes)
+		} else if !knownType {
+			report("model at index %d has unknown type %q (allowed values: %s)", i, entry.Type, allowedTypes)
+		}
+
+		if entry.URI == "" {
+			report("model at index %d missing required 'uri' field", i)
+		} else if parsed, err := utils.ParseArtifactURI(entry.URI); err != nil {
+			report("%v", err)
+		} else if knownType && parsed.Scheme != entryURIScheme(entry.Type) {
+			report("model %s of type %q must have an %s:// URI", entry.URI, entry.Type, entryURIScheme(entry.Type))
+		} else {
+			normalized := utils.NormalizeArtifactURI(entry.URI)
+			if first, ok := firstLine[normalized]; ok {
+				report("duplicate entry for %s (first listed on line %d)", entry.URI, first)
+			} else {
+				firstLine[normalized] = line
+			}
+		}
+
+		for _, label := range entry.Labels {
+			if !slices.Contains(types.KnownModelLabels, label) {
+				report("model %s has unknown label %q (known labels: %s)", entry.URI, label, strings.Join(types.KnownModelLabels, ", "))
+			}
+		}
+
+		if entry.ModelType != "" {
+			if err := types.ValidateModelType(entry.ModelType); err != nil {
+				report("model %s: %v", entry.URI, err)
+			}
+		}
+
+		if err := types.ValidateLifecycle(entry.EndOfLife, entry.ReplacedBy); err != nil {
+			report("model %s: %v", entry.URI, err)
+		}
+	}
+	return issues
+}
@@ -0,0 +1,106 @@
+package extraction
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeIndex(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "models-index.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestValidateModelsIndexFile(t *testing.T) {
+	path := writeIndex(t, `models:
+  - type: oci
+    uri: registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5
+    labels: [validated, featured]
+  - type: docker
+    uri: registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-base:1.5
+  - type: oci
+    uri: granite-3-1-8b-instruct:1.5
+  - type: oci
+    uri: oci://registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5
+  - type: oci
+    uri: registry.redhat.io/rhelai1/modelcar-llama-3-1-8b-instruct:1.5
+    labels: [validatd]
+    model_type: chat
+  - uri: registry.redhat.io/rhelai1/modelcar-mistral-7b-instruct:1.5
+    endOfLife: "June 2026"
+  - type: https
+    uri: https://huggingface.co/ibm-granite/granite-3.1-8b-instruct-GGUF/resolve/main/granite-3.1-8b-instruct-Q4_K_M.gguf
+  - type: https
+    uri: registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-base:1.5
+  - type: s3
+    uri: s3://models-bucket/granite/granite-3.1-8b-instruct.safetensors
+  - type: s3
+    uri: https://models-bucket.s3.amazonaws.com/granite/granite-3.1-8b-instruct.safetensors
+  - type: hf
+    uri: hf://ibm-granite/granite-3.1-8b-instruct:main
+  - type: hf
+    uri: hf://granite-3.1-8b-instruct
+`)
+	issues, err := ValidateModelsIndexFile(path)
+	if err != nil {
+		t.Fatalf("ValidateModelsIndexFile() error = %v", err)
+	}
+	want := []IndexIssue{
+		{Line: 5, Message: `model at index 1 has unknown type "docker" (allowed values: "oci", "https", "s3", "hf")`},
+		{Line: 7, Message: `artifact URI "granite-3-1-8b-instruct:1.5" does not start with a registry host`},
+		{Line: 9, Message: "duplicate entry for oci://registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5 (first listed on line 2)"},
+		{Line: 11, Message: `model registry.redhat.io/rhelai1/modelcar-llama-3-1-8b-instruct:1.5 has unknown label "validatd"`},
+		{Line: 11, Message: `model registry.redhat.io/rhelai1/modelcar-llama-3-1-8b-instruct:1.5: invalid model_type: "chat"`},
+		{Line: 15, Message: `model at index 5 missing required 'type' field`},
+		{Line: 15, Message: "model registry.redhat.io/rhelai1/modelcar-mistral-7b-instruct:1.5: invalid endOfLife"},
+		{Line: 19, Message: `model registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-base:1.5 of type "https" must have an https:// URI`},
+		{Line: 23, Message: `model https://models-bucket.s3.amazonaws.com/granite/granite-3.1-8b-instruct.safetensors of type "s3" must have an s3:// URI`},
+		{Line: 27, Message: `artifact URI "hf://granite-3.1-8b-instruct" has invalid HuggingFace repository "granite-3.1-8b-instruct" (expected org/model)`},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("ValidateModelsIndexFile() = %+v, want %d issues", issues, len(want))
+	}
+	for i, issue := range issues {
+		if issue.Line != want[i].Line || !strings.HasPrefix(issue.Message, want[i].Message) {
+			t.Errorf("issue %d = %d: %s, want %d: %s", i, issue.Line, issue.Message, want[i].Line, want[i].Message)
+		}
+	}
+}
+
+func TestValidateModelsIndexFile_UnknownField(t *testing.T) {
+	path := writeIndex(t, "models:\n  - type: oci\n    uri: registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5\n    lables: [validated]\n")
+	issues, err := ValidateModelsIndexFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 || issues[0].Line != 4 || !strings.Contains(issues[0].Message, "lables") {
+		t.Errorf("ValidateModelsIndexFile() = %+v, want the unknown field on line 4", issues)
+	}
+}
+
+func TestLoadModels_InvalidIndex(t *testing.T) {
+	path := writeIndex(t, "models:\n  - type: oci\n    uri: not a reference\n  - type: hf\n    uri: registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5\n")
+	_, err := LoadModels(path)
+	var indexErr *IndexError
+	if !errors.As(err, &indexErr) || len(indexErr.Issues) != 2 {
+		t.Fatalf("LoadModels() error = %v, want both problems reported", err)
+	}
+	if !strings.Contains(err.Error(), path+":2: ") || !strings.Contains(err.Error(), path+":4: ") {
+		t.Errorf("error does not report the lines of the problems:\n%v", err)
+	}
+}
+
+func TestValidateModelsIndexFile_DataIndexes(t *testing.T) {
+	for _, name := range []string{"models-index.yaml", "other-models-index.yaml", "validated-models-index.yaml"} {
+		issues, err := ValidateModelsIndexFile(filepath.Join("..", "..", "data", name))
+		if err != nil || len(issues) > 0 {
+			t.Errorf("%s: %+v, %v", name, issues, err)
+		}
+	}
+}
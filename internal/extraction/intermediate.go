@@ -0,0 +1,53 @@
+package extraction
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"path"
+	"strings"
+
+	containertypes "github.com/containers/image/v5/types"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// IntermediateDirName is the directory next to a model's models/ output that KeepIntermediate
+// writes the raw registry data to
+const IntermediateDirName = "debug"
+
+// keepIntermediateFile writes raw registry data of a model to its debug directory when
+// KeepIntermediate is set
+func (e *extractor) keepIntermediateFile(manifestRef, name string, data []byte) {
+	if !e.KeepIntermediate {
+		return
+	}
+	dir := path.Join(utils.SanitizeManifestRef(manifestRef), IntermediateDirName)
+	if err := e.Output.MkdirAll(dir, 0755); err != nil {
+		log.Printf("  Warning: Failed to create debug directory: %v", err)
+		return
+	}
+	if err := e.Output.WriteFile(path.Join(dir, name), data, 0644); err != nil {
+		log.Printf("  Warning: Failed to keep %s: %v", e.Output.Path(path.Join(dir, name)), err)
+	}
+}
+
+// keepModelcardLayer saves the modelcard layer blob as pulled, still compressed when the layer is,
+// and returns a reader of its content for parsing. Without KeepIntermediate the blob is
+// streamed as it is.
+func (e *extractor) keepModelcardLayer(manifestRef string, layer containertypes.BlobInfo, blob io.Reader) io.Reader {
+	if !e.KeepIntermediate {
+		return blob
+	}
+	data, err := io.ReadAll(blob)
+	if err != nil {
+		log.Printf("  Warning: Failed to read modelcard layer for %s: %v", manifestRef, err)
+		return bytes.NewReader(data)
+	}
+	name := "modelcard-layer-" + layer.Digest.Encoded() + ".tar"
+	if strings.Contains(layer.MediaType, "+gzip") {
+		name += ".gz"
+	}
+	e.keepIntermediateFile(manifestRef, name, data)
+	return bytes.NewReader(data)
+}
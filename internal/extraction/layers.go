@@ -0,0 +1,285 @@
+package extraction
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"strings"
+
+	blobinfocachememory "github.com/containers/image/v5/pkg/blobinfocache/memory"
+	containertypes "github.com/containers/image/v5/types"
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/gguf"
+	"github.com/opendatahub-io/model-metadata-collection/internal/huggingface"
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// minGGUFLayerSize skips small layers (modelcards, base image files) when looking for GGUF weights
+// in layers that are not marked as GGUF
+const minGGUFLayerSize = 1 << 20
+
+// ggufWeights is the header of a GGUF weights file found in an image layer
+type ggufWeights struct {
+	Metadata *gguf.Metadata
+	FileName string
+}
+
+// scanLayersForGGUF looks for a GGUF weights file in the image layers and returns its header.
+// Layers whose title annotation or media type marks them as GGUF are always read; other tar
+// layers of at least minGGUFLayerSize are probed by their first file. The scan stops at the first layer
+// holding weights in another format, so safetensors modelcars open at most one weight shard.
+func (e *extractor) scanLayersForGGUF(ctx context.Context, layers []containertypes.BlobInfo, src containertypes.ImageSource) *ggufWeights {
+	for _, layer := range layers {
+		if e.isModelcardLayer(layer) {
+			continue
+		}
+		title := layer.Annotations["org.opencontainers.image.title"]
+		if gguf.IsNonGGUFWeightsFile(title) {
+			log.Printf("  Layer %s holds %s, not GGUF weights", layer.Digest, title)
+			return nil
+		}
+		// Raw file layers are named by their title; only tar layers need their first file probed
+		markedGGUF := gguf.IsGGUFFile(title) || strings.Contains(strings.ToLower(layer.MediaType), "gguf")
+		rawFile := title != "" && !strings.Contains(layer.MediaType, "tar")
+		if !markedGGUF && (rawFile || (layer.Size >= 0 && layer.Size < minGGUFLayerSize)) {
+			continue
+		}
+
+		weights, firstFile, err := readGGUFLayer(ctx, layer, src, title)
+		if err != nil {
+			log.Printf("  Layer %s is not a GGUF weights layer: %v", layer.Digest, err)
+			if gguf.IsNonGGUFWeightsFile(firstFile) {
+				return nil
+			}
+			continue
+		}
+		log.Printf("  Found GGUF weights layer %s: %+v", layer.Digest, *weights.Metadata.ToQuantizationInfo(weights.FileName))
+		return weights
+	}
+	return nil
+}
+
+// readGGUFLayer parses the GGUF header from a layer blob, which is either a (gzipped) tar
+// archive or, for OCI artifacts annotated with a .gguf title, the raw file itself. The name of the
+// first file of a tar layer is returned with the error when it is not a GGUF file.
+func readGGUFLayer(ctx context.Context, layer containertypes.BlobInfo, src containertypes.ImageSource, title string) (*ggufWeights, string, error) {
+	layerBlob, _, err := src.GetBlob(ctx, containertypes.BlobInfo{
+		Digest: layer.Digest,
+	}, blobinfocachememory.New())
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get layer blob: %v", err)
+	}
+	defer func() { _ = layerBlob.Close() }()
+
+	var reader io.Reader = layerBlob
+	if strings.Contains(layer.MediaType, "+gzip") {
+		gzReader, err := gzip.NewReader(layerBlob)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to create gzip reader: %v", err)
+		}
+		defer func() { _ = gzReader.Close() }()
+		reader = gzReader
+	}
+
+	if gguf.IsGGUFFile(title) && !strings.Contains(layer.MediaType, "tar") {
+		md, err := gguf.ParseHeader(reader)
+		if err != nil {
+			return nil, title, err
+		}
+		return &ggufWeights{Metadata: md, FileName: title}, title, nil
+	}
+
+	md, fileName, err := gguf.ParseFromTar(reader)
+	if err != nil {
+		return nil, fileName, err
+	}
+	return &ggufWeights{Metadata: md, FileName: fileName}, fileName, nil
+}
+
+// addQuantizationToMetadata records GGUF quantization details in the model's metadata.yaml
+func (e *extractor) addQuantizationToMetadata(manifestRef string, quantization *types.QuantizationInfo) {
+	e.updateMetadataFile(manifestRef, func(metadata *types.ExtractedMetadata) {
+		metadata.Quantization = quantization
+	})
+}
+
+// writeModelfile writes an Ollama Modelfile stub for the model's GGUF weights next to its
+// metadata.yaml
+func (e *extractor) writeModelfile(manifestRef string, weights *ggufWeights) {
+	modelfilePath := outputfs.ModelPath(manifestRef, gguf.ModelfileName)
+	data := weights.Metadata.Modelfile(weights.FileName, manifestRef)
+	if err := e.Output.WriteFile(modelfilePath, data, 0644); err != nil {
+		log.Printf("Warning: Could not write Modelfile %s: %v", e.Output.Path(modelfilePath), err)
+	}
+}
+
+// addModelConfigToMetadata records the architecture read from config.json in the model's metadata.yaml
+func (e *extractor) addModelConfigToMetadata(manifestRef string, modelConfig *types.ModelConfig) {
+	e.updateMetadataFile(manifestRef, func(metadata *types.ExtractedMetadata) {
+		metadata.ModelConfig = modelConfig
+	})
+}
+
+// updateMetadataFile applies update to the model's metadata.yaml and writes it back
+func (e *extractor) updateMetadataFile(manifestRef string, update func(*types.ExtractedMetadata)) {
+	metadataPath := outputfs.ModelPath(manifestRef, "metadata.yaml")
+
+	data, err := e.Output.ReadFile(metadataPath)
+	if err != nil {
+		log.Printf("Warning: Could not read metadata file %s: %v", e.Output.Path(metadataPath), err)
+		return
+	}
+
+	var metadata types.ExtractedMetadata
+	if err := yaml.Unmarshal(data, &metadata); err != nil {
+		log.Printf("Warning: Could not parse metadata file %s: %v", e.Output.Path(metadataPath), err)
+		return
+	}
+
+	update(&metadata)
+
+	updatedData, err := yaml.Marshal(&metadata)
+	if err != nil {
+		log.Printf("Warning: Could not marshal updated metadata for %s: %v", manifestRef, err)
+		return
+	}
+	if err := e.Output.WriteFile(metadataPath, updatedData, 0644); err != nil {
+		log.Printf("Warning: Could not write updated metadata file %s: %v", e.Output.Path(metadataPath), err)
+	}
+}
+
+// Transformers configuration files read from weight layers
+const (
+	modelConfigFileName      = "config.json"
+	generationConfigFileName = "generation_config.json"
+)
+
+// maxConfigScanFileSize bounds the files skipped while looking for config.json in a layer; the
+// scan stops at the first larger file so weight files are never downloaded
+const maxConfigScanFileSize = 1 << 20
+
+// scanLayersForModelConfig looks for config.json and generation_config.json in the image's
+// weight layers and returns the architecture they declare
+func (e *extractor) scanLayersForModelConfig(ctx context.Context, layers []containertypes.BlobInfo, src containertypes.ImageSource) *types.ModelConfig {
+	var configData, generationConfigData []byte
+	for _, layer := range layers {
+		if e.isModelcardLayer(layer) {
+			continue
+		}
+		title := layer.Annotations["org.opencontainers.image.title"]
+		if gguf.IsGGUFFile(title) {
+			continue
+		}
+
+		files, err := readModelConfigLayer(ctx, layer, src, title)
+		if err != nil {
+			log.Printf("  Could not read config files from layer %s: %v", layer.Digest, err)
+			continue
+		}
+		if data, ok := files[modelConfigFileName]; ok && configData == nil {
+			configData = data
+		}
+		if data, ok := files[generationConfigFileName]; ok && generationConfigData == nil {
+			generationConfigData = data
+		}
+		if configData != nil && generationConfigData != nil {
+			break
+		}
+	}
+
+	if configData == nil {
+		return nil
+	}
+	modelConfig, err := huggingface.ParseModelConfig(configData, generationConfigData)
+	if err != nil {
+		log.Printf("  Warning: %v", err)
+		return nil
+	}
+	if modelConfig.IsEmpty() {
+		return nil
+	}
+	log.Printf("  Found model config: %s (%s)", modelConfig.Architecture, modelConfig.ModelType)
+	return modelConfig
+}
+
+// readModelConfigLayer returns the transformers config files in a layer blob, which is either a
+// (gzipped) tar archive or, for OCI artifacts annotated with a file title, the raw file itself
+func readModelConfigLayer(ctx context.Context, layer containertypes.BlobInfo, src containertypes.ImageSource, title string) (map[string][]byte, error) {
+	isConfigTitle := title == modelConfigFileName || title == generationConfigFileName
+	if title != "" && !isConfigTitle {
+		// A titled layer holds a single named file, e.g. model.safetensors
+		return nil, nil
+	}
+
+	layerBlob, _, err := src.GetBlob(ctx, containertypes.BlobInfo{
+		Digest: layer.Digest,
+	}, blobinfocachememory.New())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get layer blob: %v", err)
+	}
+	defer func() { _ = layerBlob.Close() }()
+
+	var reader io.Reader = layerBlob
+	if strings.Contains(layer.MediaType, "+gzip") {
+		gzReader, err := gzip.NewReader(layerBlob)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create gzip reader: %v", err)
+		}
+		defer func() { _ = gzReader.Close() }()
+		reader = gzReader
+	}
+
+	if isConfigTitle && !strings.Contains(layer.MediaType, "tar") {
+		data, err := io.ReadAll(io.LimitReader(reader, maxConfigScanFileSize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %v", title, err)
+		}
+		return map[string][]byte{title: data}, nil
+	}
+	return readModelConfigFiles(reader)
+}
+
+// readModelConfigFiles collects config.json and generation_config.json from a tar stream by base
+// name. Reading stops at the first file larger than maxConfigScanFileSize that is not a config
+// file, so only the small files packed ahead of the weights are downloaded.
+func readModelConfigFiles(r io.Reader) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return files, fmt.Errorf("failed to read tar: %v", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		base := path.Base(header.Name)
+		if (base == modelConfigFileName || base == generationConfigFileName) && header.Size <= maxConfigScanFileSize {
+			if _, seen := files[base]; seen {
+				continue
+			}
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return files, fmt.Errorf("failed to read %s: %v", header.Name, err)
+			}
+			files[base] = data
+			if len(files) == 2 {
+				return files, nil
+			}
+			continue
+		}
+		if header.Size > maxConfigScanFileSize {
+			return files, nil
+		}
+	}
+}
@@ -0,0 +1,574 @@
+package extraction
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	blobinfocachememory "github.com/containers/image/v5/pkg/blobinfocache/memory"
+	containertypes "github.com/containers/image/v5/types"
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/checkpoint"
+	"github.com/opendatahub-io/model-metadata-collection/internal/huggingface"
+	"github.com/opendatahub-io/model-metadata-collection/internal/metadata"
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+	"github.com/opendatahub-io/model-metadata-collection/internal/registry"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/errdefs"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// scanLayersForModelCardWithTags scans container layers for model card content and adds model labels as tags
+func (e *extractor) scanLayersForModelCardWithTags(ctx context.Context, layers []containertypes.BlobInfo, src containertypes.ImageSource, manifestRef string, configBlob []byte, entry types.ModelEntry) (bool, types.ModelMetadata) {
+	modelCardFound, metadata := e.scanLayersForModelCard(ctx, layers, src, manifestRef, configBlob)
+
+	// Add labels and lifecycle fields from the model entry to the extracted metadata
+	// This works for both successful extractions and skeleton metadata
+	e.addModelLabelTags(manifestRef, entry)
+
+	return modelCardFound, metadata
+}
+
+// addModelLabelTags adds model labels as tags and the entry's lifecycle fields to the extracted metadata
+func (e *extractor) addModelLabelTags(manifestRef string, entry types.ModelEntry) {
+	metadataPath := outputfs.ModelPath(manifestRef, "metadata.yaml")
+
+	// Read existing metadata
+	data, err := e.Output.ReadFile(metadataPath)
+	if err != nil {
+		log.Printf("Warning: Could not read metadata file %s: %v", e.Output.Path(metadataPath), err)
+		return
+	}
+
+	// Parse existing metadata
+	var metadata types.ExtractedMetadata
+	err = yaml.Unmarshal(data, &metadata)
+	if err != nil {
+		log.Printf("Warning: Could not parse metadata file %s: %v", e.Output.Path(metadataPath), err)
+		return
+	}
+
+	// Initialize tags slice if nil
+	if metadata.Tags == nil {
+		metadata.Tags = []string{}
+	}
+
+	// Track if we made changes
+	changed := false
+
+	// Add each label from the model entry as a tag if not already present
+	for _, label := range entry.Labels {
+		if label != "" && !slices.Contains(metadata.Tags, label) {
+			metadata.Tags = append(metadata.Tags, label)
+			changed = true
+			log.Printf("Added '%s' tag to %s", label, manifestRef)
+		}
+	}
+
+	if applyModelLifecycle(&metadata, entry) {
+		changed = true
+		log.Printf("Applied lifecycle metadata to %s", manifestRef)
+	}
+
+	// Write back the metadata if changes were made
+	if changed {
+		updatedData, err := yaml.Marshal(&metadata)
+		if err != nil {
+			log.Printf("Warning: Could not marshal updated metadata for %s: %v", manifestRef, err)
+			return
+		}
+
+		err = e.Output.WriteFile(metadataPath, updatedData, 0644)
+		if err != nil {
+			log.Printf("Warning: Could not write updated metadata file %s: %v", e.Output.Path(metadataPath), err)
+			return
+		}
+	}
+}
+
+// applyModelLifecycle copies the deprecation fields of a models index entry onto extracted metadata
+// and reports whether anything changed. The entry is authoritative, so fields removed from the index
+// are cleared rather than left over from an earlier run.
+func applyModelLifecycle(metadata *types.ExtractedMetadata, entry types.ModelEntry) bool {
+	changed := metadata.Deprecated != entry.Deprecated ||
+		!equalStringPtr(metadata.EndOfLife, entry.EndOfLife) ||
+		!equalStringPtr(metadata.ReplacedBy, entry.ReplacedBy)
+	metadata.Deprecated = entry.Deprecated
+	metadata.EndOfLife = entry.EndOfLife
+	metadata.ReplacedBy = entry.ReplacedBy
+	return changed
+}
+
+// equalStringPtr reports whether two optional strings are both unset or hold the same value
+func equalStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// scanLayersForModelCard scans container layers for model card content
+func (e *extractor) scanLayersForModelCard(ctx context.Context, layers []containertypes.BlobInfo, src containertypes.ImageSource, manifestRef string, configBlob []byte) (bool, types.ModelMetadata) {
+	for i, layer := range layers {
+		log.Printf("Layer %d:", i+1)
+		log.Printf("  Digest: %s", layer.Digest)
+		log.Printf("  MediaType: %s", layer.MediaType)
+		log.Printf("  Size: %d bytes", layer.Size)
+		if layer.Annotations != nil {
+			log.Printf("  Annotations: %v", layer.Annotations)
+
+			// Check if this layer has a modelcard annotation
+			if e.isModelcardLayer(layer) {
+				log.Printf("  Found modelcard layer! Attempting to access modelcard layer blob with digest: %s", layer.Digest)
+
+				var layerBlob io.ReadCloser
+				var err error
+
+				layerBlob, _, err = src.GetBlob(ctx, containertypes.BlobInfo{
+					Digest: layer.Digest,
+				}, blobinfocachememory.New())
+				if err != nil {
+					log.Fatalf("Failed to get modelcard layer blob: %v", err)
+				}
+
+				if layerBlob == nil {
+					log.Printf("layerBlob is nil for modelcard layer")
+				} else {
+					defer func() { _ = layerBlob.Close() }()
+					blob := e.keepModelcardLayer(manifestRef, layer, layerBlob)
+					reader := blob
+					log.Printf("  Successfully fetched modelcard layer blob. Attempting to read as tar...")
+
+					// Check if it's a gzipped tar file
+					if strings.Contains(layer.MediaType, "+gzip") {
+						log.Printf("  Detected gzipped tar file, decompressing...")
+						gzReader, err := gzip.NewReader(blob)
+						if err != nil {
+							log.Printf("Error creating gzip reader: %v", err)
+							continue
+						}
+						defer func() { _ = gzReader.Close() }()
+						reader = gzReader
+					}
+
+					tr := tar.NewReader(reader)
+					var mdFiles, licenseFiles []modelCardFile
+
+					for {
+						header, err := tr.Next()
+						if err == io.EOF {
+							break
+						}
+						if err != nil {
+							log.Printf("Error reading tar: %v", err)
+							break
+						}
+						log.Printf("  Found file in tar: %s (size: %d bytes)", header.Name, header.Size)
+						isLicense := licenseFilePriority(header.Name) >= 0
+						if isLicense || strings.HasSuffix(header.Name, ".md") {
+							var content bytes.Buffer
+							_, err := io.Copy(&content, tr)
+							if err != nil {
+								log.Printf("Error reading %s: %v", header.Name, err)
+								continue
+							}
+							file := modelCardFile{Name: header.Name, Content: content.Bytes()}
+							if isLicense {
+								licenseFiles = append(licenseFiles, file)
+							} else {
+								mdFiles = append(mdFiles, file)
+							}
+						} else {
+							// Skip non-.md files
+							_, err := io.Copy(io.Discard, tr)
+							if err != nil {
+								log.Printf("Error skipping %s: %v", header.Name, err)
+								continue
+							}
+						}
+					}
+
+					if len(mdFiles) > 0 {
+						selected := selectModelCardFile(mdFiles)
+						singleMdFileName, singleMdContent := selected.Name, selected.Content
+						if len(mdFiles) > 1 {
+							log.Printf("  Found %d .md files, using %s (size: %d bytes)", len(mdFiles), singleMdFileName, len(singleMdContent))
+						} else {
+							log.Printf("  Found single .md file: %s (size: %d bytes)", singleMdFileName, len(singleMdContent))
+						}
+
+						// Create the full directory path for the file (including subdirectories)
+						outputFilePath := path.Join(utils.SanitizeManifestRef(manifestRef), singleMdFileName)
+						outputFileDir := path.Dir(outputFilePath)
+						err := e.Output.MkdirAll(outputFileDir, 0755)
+						if err != nil {
+							log.Fatalf("Failed to create output directory: %v", err)
+						}
+
+						// Write modelcard content to file
+						err = e.Output.WriteFile(outputFilePath, singleMdContent, 0644)
+						if err != nil {
+							log.Fatalf("Failed to write modelcard content to file: %v", err)
+						}
+
+						log.Printf("  Successfully wrote modelcard content to: %s", e.Output.Path(outputFilePath))
+
+						// Parse metadata from the modelcard content
+						metadataFlags := metadata.ParseModelCardMetadata(singleMdContent)
+
+						// HuggingFace frontmatter retained in the modelcard takes precedence over text parsing
+						if frontmatter, err := metadata.ExtractYAMLFrontmatterFromModelCard(string(singleMdContent)); err == nil {
+							log.Printf("  Found YAML frontmatter in modelcard (license: %q, language: %v, tags: %v)",
+								frontmatter.License, []string(frontmatter.Language), []string(frontmatter.Tags))
+						}
+
+						// Extract actual metadata values
+						extractedMetadata := metadata.ExtractMetadataValues(singleMdContent)
+						extractedMetadata.Readme = e.Readme.FromModelCard(string(singleMdContent))
+
+						// Store the license shipped in the layer next to the modelcard
+						if licenseFile := selectLicenseFile(licenseFiles); licenseFile != nil {
+							licenseName := path.Join(outputFileDir, path.Base(licenseFile.Name))
+							licensePath := e.Output.Path(licenseName)
+							if err := e.Output.WriteFile(licenseName, licenseFile.Content, 0644); err != nil {
+								log.Printf("  Warning: Failed to write license file %s: %v", licensePath, err)
+							} else {
+								log.Printf("  Successfully wrote license file to: %s", licensePath)
+								// A license file without an identifier in the modelcard is classified by its text
+								if extractedMetadata.License == nil {
+									if licenseID, score := utils.DetectLicense(string(licenseFile.Content)); licenseID != "" {
+										log.Printf("  Detected license %s from license file text (similarity %.2f)", licenseID, score)
+										extractedMetadata.License = &licenseID
+										if licenseURL := utils.GetLicenseURL(licenseID); licenseURL != "" {
+											extractedMetadata.LicenseLink = &licenseURL
+										}
+									}
+								}
+								// Well-known licenses already link to their canonical URL
+								if extractedMetadata.LicenseLink == nil {
+									extractedMetadata.LicenseLink = &licensePath
+								}
+							}
+						}
+
+						// Populate artifacts with OCI registry metadata and real timestamps
+						extractedMetadata.Artifacts = registry.ExtractOCIArtifactsFromRegistry(ctx, e.RegistryAuth, manifestRef)
+
+						// Extract real timestamps from config blob and update artifacts
+						createTime, updateTime := extractTimestampsFromConfig(configBlob)
+						for i := range extractedMetadata.Artifacts {
+							if extractedMetadata.Artifacts[i].CreateTimeSinceEpoch == nil {
+								extractedMetadata.Artifacts[i].CreateTimeSinceEpoch = createTime
+							}
+							if extractedMetadata.Artifacts[i].LastUpdateTimeSinceEpoch == nil {
+								extractedMetadata.Artifacts[i].LastUpdateTimeSinceEpoch = updateTime
+							}
+						}
+
+						// Generate metadata.yaml file in the same directory
+						metadataFilePath := path.Join(outputFileDir, "metadata.yaml")
+						metadataYaml, err := yaml.Marshal(&extractedMetadata)
+						if err != nil {
+							log.Printf("Failed to marshal metadata to YAML: %v", err)
+						} else {
+							err = e.Output.WriteFile(metadataFilePath, metadataYaml, 0644)
+							if err != nil {
+								log.Printf("Failed to write metadata.yaml: %v", err)
+							} else {
+								log.Printf("  Successfully wrote metadata.yaml to: %s", e.Output.Path(metadataFilePath))
+							}
+						}
+
+						return true, metadataFlags
+					} else {
+						log.Printf("  No .md files found in the blob")
+					}
+				}
+			}
+		}
+	}
+
+	// If no modelcard was found, create a skeleton metadata.yaml for enrichment processing
+	log.Printf("  No modelcard layer found, creating skeleton metadata for enrichment")
+	e.Errors.Record(manifestRef, checkpoint.StageExtraction, errdefs.ErrNoModelcardLayer)
+	e.createSkeletonMetadata(ctx, manifestRef, configBlob)
+
+	return false, types.ModelMetadata{}
+}
+
+// modelCardFile is a markdown file read from a modelcard layer
+type modelCardFile struct {
+	Name    string
+	Content []byte
+}
+
+// selectModelCardFile picks the modelcard among the markdown files of a modelcard layer: a
+// README.md (the shallowest one when several directories have one), otherwise the largest file.
+// files must not be empty.
+func selectModelCardFile(files []modelCardFile) modelCardFile {
+	readme := -1
+	for i, file := range files {
+		if !strings.EqualFold(path.Base(file.Name), "README.md") {
+			continue
+		}
+		if readme < 0 || strings.Count(path.Clean(file.Name), "/") < strings.Count(path.Clean(files[readme].Name), "/") {
+			readme = i
+		}
+	}
+	if readme >= 0 {
+		return files[readme]
+	}
+
+	largest := 0
+	for i, file := range files {
+		if len(file.Content) > len(files[largest].Content) {
+			largest = i
+		}
+	}
+	return files[largest]
+}
+
+// licenseFileNames lists the license file names read from a modelcard layer, in priority order
+var licenseFileNames = []string{"LICENSE", "LICENSE.txt", "LICENSE.md"}
+
+// licenseFilePriority returns the index of a tar entry's base name in licenseFileNames
+// (case-insensitive), or -1 when it is not a license file
+func licenseFilePriority(name string) int {
+	base := path.Base(name)
+	for i, licenseName := range licenseFileNames {
+		if strings.EqualFold(base, licenseName) {
+			return i
+		}
+	}
+	return -1
+}
+
+// selectLicenseFile picks the license file with the most preferred name, or nil when there is none
+func selectLicenseFile(files []modelCardFile) *modelCardFile {
+	var selected *modelCardFile
+	for i := range files {
+		if strings.TrimSpace(string(files[i].Content)) == "" {
+			continue
+		}
+		if selected == nil || licenseFilePriority(files[i].Name) < licenseFilePriority(selected.Name) {
+			selected = &files[i]
+		}
+	}
+	return selected
+}
+
+// createSkeletonMetadata creates a basic metadata.yaml file when modelcard extraction fails
+// and attempts to fetch HuggingFace README as a fallback modelcard
+func (e *extractor) createSkeletonMetadata(ctx context.Context, manifestRef string, configBlob []byte) {
+	artifacts := registry.ExtractOCIArtifactsFromRegistry(ctx, e.RegistryAuth, manifestRef)
+
+	// Extract timestamps from config blob if available
+	createTime, updateTime := extractTimestampsFromConfig(configBlob)
+	for i := range artifacts {
+		if artifacts[i].CreateTimeSinceEpoch == nil {
+			artifacts[i].CreateTimeSinceEpoch = createTime
+		}
+		if artifacts[i].LastUpdateTimeSinceEpoch == nil {
+			artifacts[i].LastUpdateTimeSinceEpoch = updateTime
+		}
+	}
+
+	e.writeSkeletonMetadata(ctx, manifestRef, artifacts)
+}
+
+// writeSkeletonMetadata writes a basic metadata.yaml file with the given artifacts for enrichment
+// to complete, with a HuggingFace README as fallback modelcard when one matches
+func (e *extractor) writeSkeletonMetadata(ctx context.Context, manifestRef string, artifacts []types.OCIArtifact) {
+	// Create output directory
+	modelDir := outputfs.ModelPath(manifestRef)
+
+	err := e.Output.MkdirAll(modelDir, 0755)
+	if err != nil {
+		log.Printf("  Warning: Failed to create skeleton output directory: %v", err)
+		e.Errors.Record(manifestRef, checkpoint.StageExtraction, fmt.Errorf("failed to create skeleton output directory: %w", err))
+		return
+	}
+
+	// Try to find matching HuggingFace model and fetch README as fallback
+	e.tryHuggingFaceFallback(ctx, manifestRef, modelDir)
+
+	// Keep the README fetched as a fallback modelcard so the catalog has a readme without enrichment
+	var readme *string
+	if content, err := e.Output.ReadFile(path.Join(modelDir, "modelcard.md")); err == nil {
+		readme = e.Readme.FromModelCard(string(content))
+	}
+
+	// Create basic metadata with minimal information
+	metadata := types.ExtractedMetadata{
+		Readme:    readme,
+		Tags:      []string{}, // Empty tags slice for enrichment to populate
+		Language:  []string{},
+		Tasks:     []string{},
+		Artifacts: artifacts,
+	}
+
+	// Write skeleton metadata.yaml
+	metadataFilePath := path.Join(modelDir, "metadata.yaml")
+	metadataYaml, err := yaml.Marshal(&metadata)
+	if err != nil {
+		log.Printf("  Warning: Failed to marshal skeleton metadata to YAML: %v", err)
+		e.Errors.Record(manifestRef, checkpoint.StageExtraction, fmt.Errorf("failed to marshal skeleton metadata: %w", err))
+		return
+	}
+
+	err = e.Output.WriteFile(metadataFilePath, metadataYaml, 0644)
+	if err != nil {
+		log.Printf("  Warning: Failed to write skeleton metadata.yaml: %v", err)
+		e.Errors.Record(manifestRef, checkpoint.StageExtraction, fmt.Errorf("failed to write skeleton metadata: %w", err))
+		return
+	}
+	e.Errors.MarkSkeleton(manifestRef)
+
+	log.Printf("  Successfully created skeleton metadata.yaml: %s", e.Output.Path(metadataFilePath))
+}
+
+// tryHuggingFaceFallback attempts to find a matching HuggingFace model and fetch its README as a fallback modelcard
+func (e *extractor) tryHuggingFaceFallback(ctx context.Context, manifestRef string, modelDir string) {
+	log.Printf("  Attempting HuggingFace README fallback for: %s", manifestRef)
+
+	hfModelName, ok := e.findFallbackModel(manifestRef)
+	if !ok {
+		return
+	}
+
+	// Fetch README content from HuggingFace
+	hfReadme, err := e.HuggingFace.FetchReadme(ctx, hfModelName)
+	if err != nil {
+		log.Printf("  Warning: Failed to fetch HuggingFace README for fallback: %v", err)
+		return
+	}
+
+	// Strip YAML frontmatter to match container modelcard format
+	processedContent := utils.StripYAMLFrontmatter(hfReadme)
+
+	// Write the README content as modelcard.md
+	modelcardPath := path.Join(modelDir, "modelcard.md")
+	err = e.Output.WriteFile(modelcardPath, []byte(processedContent), 0644)
+	if err != nil {
+		log.Printf("  Warning: Failed to write HuggingFace README as modelcard.md: %v", err)
+		return
+	}
+
+	log.Printf("  Successfully created fallback modelcard.md from HuggingFace README: %s", e.Output.Path(modelcardPath))
+}
+
+// findFallbackModel returns the HuggingFace repository whose README is the fallback modelcard of
+// a model: the repository of hf:// entries, otherwise the best match in the latest HuggingFace index
+func (e *extractor) findFallbackModel(manifestRef string) (string, bool) {
+	if repository := utils.HuggingFaceRepositoryOf(manifestRef); repository != "" {
+		return repository, true
+	}
+
+	// Try to get the latest HuggingFace index file
+	latestIndexFile, err := huggingface.GetLatestVersionIndexFile()
+	if err != nil {
+		log.Printf("  Warning: Failed to find HuggingFace index file for fallback: %v", err)
+		return "", false
+	}
+
+	// Load HuggingFace index to find matching models
+	hfData, err := os.ReadFile(latestIndexFile)
+	if err != nil {
+		log.Printf("  Warning: Failed to read HuggingFace index file for fallback: %v", err)
+		return "", false
+	}
+
+	var hfIndex types.VersionIndex
+	err = yaml.Unmarshal(hfData, &hfIndex)
+	if err != nil {
+		log.Printf("  Warning: Failed to parse HuggingFace index for fallback: %v", err)
+		return "", false
+	}
+
+	// Find best matching HuggingFace model using similar logic to enrichment
+	bestMatch := types.ModelIndex{}
+	bestScore := 0.0
+
+	for _, hfModel := range hfIndex.Models {
+		score := utils.CalculateSimilarity(manifestRef, hfModel.Name)
+		if score > bestScore {
+			bestScore = score
+			bestMatch = hfModel
+		}
+	}
+
+	// Only proceed if we have a reasonable match
+	if bestScore < e.MatchThreshold {
+		log.Printf("  No suitable HuggingFace model found for fallback (best score: %.2f)", bestScore)
+		return "", false
+	}
+
+	log.Printf("  Found HuggingFace match for fallback: %s (score: %.2f)", bestMatch.Name, bestScore)
+	return bestMatch.Name, true
+}
+
+// OCI Image Config structure for timestamp extraction
+type OCIImageConfig struct {
+	Created string `json:"created"`
+	History []struct {
+		Created string `json:"created"`
+	} `json:"history"`
+}
+
+// extractTimestampsFromConfig extracts creation and update timestamps from OCI config blob
+func extractTimestampsFromConfig(configBlob []byte) (*int64, *int64) {
+	if len(configBlob) == 0 {
+		return nil, nil
+	}
+
+	var config OCIImageConfig
+	if err := json.Unmarshal(configBlob, &config); err != nil {
+		log.Printf("Warning: Failed to parse config blob for timestamps: %v", err)
+		return nil, nil
+	}
+
+	// Parse creation timestamp
+	var createTime *int64
+	if config.Created != "" {
+		if parsedTime, err := time.Parse(time.RFC3339, config.Created); err == nil {
+			epochMs := parsedTime.Unix() * 1000
+			createTime = &epochMs
+		} else {
+			log.Printf("Warning: Failed to parse creation time '%s': %v", config.Created, err)
+		}
+	}
+
+	// Use the most recent history entry for update time, fallback to creation time
+	updateTime := createTime
+	if len(config.History) > 0 {
+		lastHistoryEntry := config.History[len(config.History)-1]
+		if lastHistoryEntry.Created != "" {
+			if parsedTime, err := time.Parse(time.RFC3339, lastHistoryEntry.Created); err == nil {
+				epochMs := parsedTime.Unix() * 1000
+				updateTime = &epochMs
+			}
+		}
+	}
+
+	log.Printf("Extracted timestamps - Create: %v, Update: %v", formatTimestamp(createTime), formatTimestamp(updateTime))
+	return createTime, updateTime
+}
+
+// formatTimestamp formats a timestamp pointer for logging
+func formatTimestamp(ts *int64) string {
+	if ts == nil {
+		return "nil"
+	}
+	return time.Unix(*ts/1000, 0).Format(time.RFC3339)
+}
@@ -0,0 +1,115 @@
+package extraction
+
+import (
+	"encoding/json"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// PerformanceAnnotationPrefix is the prefix of the manifest annotations and image labels that
+// declare the serving performance of a modelcar image
+const PerformanceAnnotationPrefix = "io.opendatahub.modelcar.performance."
+
+// Performance annotations, after PerformanceAnnotationPrefix
+const (
+	annotationTokensPerSecond     = "tokens-per-second"
+	annotationTimeToFirstTokenMs  = "time-to-first-token-ms"
+	annotationInterTokenLatencyMs = "inter-token-latency-ms"
+	annotationRequestLatencyMs    = "request-latency-ms"
+	annotationHardware            = "hardware"
+	annotationConcurrency         = "concurrency"
+)
+
+// manifestAnnotations returns the annotations of an image manifest or index; Docker schema 2
+// manifests have none
+func manifestAnnotations(manifestBlob []byte) map[string]string {
+	var manifest struct {
+		Annotations map[string]string `json:"annotations"`
+	}
+	annotations := make(map[string]string)
+	if err := json.Unmarshal(manifestBlob, &manifest); err == nil {
+		for key, value := range manifest.Annotations {
+			annotations[key] = value
+		}
+	}
+	return annotations
+}
+
+// configLabels returns the labels of an image config blob
+func configLabels(configBlob []byte) map[string]string {
+	var config struct {
+		Config struct {
+			Labels map[string]string `json:"Labels"`
+		} `json:"config"`
+	}
+	if err := json.Unmarshal(configBlob, &config); err != nil {
+		return nil
+	}
+	return config.Config.Labels
+}
+
+// performanceFromImage reads the performance annotations of an image, with manifest annotations
+// taking precedence over image labels of the same name. Values that are not positive numbers are
+// skipped with a warning; nil is returned when the image declares no performance.
+func performanceFromImage(manifestRef string, annotations map[string]string, configBlob []byte) *types.PerformanceInfo {
+	values := make(map[string]string)
+	for _, source := range []map[string]string{configLabels(configBlob), annotations} {
+		for key, value := range source {
+			if name, ok := strings.CutPrefix(key, PerformanceAnnotationPrefix); ok && strings.TrimSpace(value) != "" {
+				values[name] = strings.TrimSpace(value)
+			}
+		}
+	}
+
+	var performance types.PerformanceInfo
+	for name, value := range values {
+		switch name {
+		case annotationTokensPerSecond:
+			performance.TokensPerSecond = parsePerformanceFloat(manifestRef, name, value)
+		case annotationTimeToFirstTokenMs:
+			performance.TimeToFirstTokenMs = parsePerformanceFloat(manifestRef, name, value)
+		case annotationInterTokenLatencyMs:
+			performance.InterTokenLatencyMs = parsePerformanceFloat(manifestRef, name, value)
+		case annotationRequestLatencyMs:
+			performance.RequestLatencyMs = parsePerformanceFloat(manifestRef, name, value)
+		case annotationHardware:
+			performance.Hardware = value
+		case annotationConcurrency:
+			concurrency, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || concurrency <= 0 {
+				log.Printf("  Warning: Ignoring %s%s=%q of %s: not a positive integer", PerformanceAnnotationPrefix, name, value, manifestRef)
+				continue
+			}
+			performance.Concurrency = concurrency
+		default:
+			log.Printf("  Warning: Ignoring unknown performance annotation %s%s of %s", PerformanceAnnotationPrefix, name, manifestRef)
+		}
+	}
+	if performance.IsEmpty() {
+		return nil
+	}
+	return &performance
+}
+
+// parsePerformanceFloat parses a positive performance measurement, returning 0 with a warning
+// when value is not one
+func parsePerformanceFloat(manifestRef, name, value string) float64 {
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || !(number > 0) || math.IsInf(number, 1) {
+		log.Printf("  Warning: Ignoring %s%s=%q of %s: not a positive number", PerformanceAnnotationPrefix, name, value, manifestRef)
+		return 0
+	}
+	return number
+}
+
+// addPerformanceToMetadata records the performance annotations of an image in the model's
+// metadata.yaml
+func (e *extractor) addPerformanceToMetadata(manifestRef string, performance *types.PerformanceInfo) {
+	e.updateMetadataFile(manifestRef, func(metadata *types.ExtractedMetadata) {
+		metadata.Performance = performance
+	})
+}
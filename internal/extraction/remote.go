@@ -0,0 +1,54 @@
+package extraction
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/artifacts"
+	"github.com/opendatahub-io/model-metadata-collection/internal/checkpoint"
+	"github.com/opendatahub-io/model-metadata-collection/internal/huggingface"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// isRemoteEntry reports whether a models index entry is hosted outside a container registry, so
+// it has no image to extract and is described from its host instead
+func isRemoteEntry(entry types.ModelEntry) bool {
+	return entry.Type == types.ModelEntryTypeHTTPS || entry.Type == types.ModelEntryTypeS3 || entry.Type == types.ModelEntryTypeHF
+}
+
+// describeRemoteArtifact describes the artifact of a remote models index entry
+func describeRemoteArtifact(ctx context.Context, hf *huggingface.Client, entry types.ModelEntry) (*types.OCIArtifact, error) {
+	switch entry.Type {
+	case types.ModelEntryTypeHTTPS:
+		return artifacts.DescribeHTTPS(ctx, entry.URI)
+	case types.ModelEntryTypeS3:
+		return artifacts.DescribeS3(ctx, entry.URI)
+	case types.ModelEntryTypeHF:
+		return artifacts.DescribeHF(ctx, hf, entry.URI)
+	default:
+		return nil, fmt.Errorf("unsupported models index entry type %q", entry.Type)
+	}
+}
+
+// processRemoteModel writes skeleton metadata for a model hosted outside a container registry,
+// with the artifact described by its host, for enrichment to complete
+func (e *extractor) processRemoteModel(ctx context.Context, ref string, entry types.ModelEntry) (ModelResult, error) {
+	artifact, err := describeRemoteArtifact(ctx, e.HuggingFace, entry)
+	if err != nil {
+		return ModelResult{Ref: ref}, err
+	}
+	log.Printf("  Described %s artifact %s", entry.Type, artifact.URI)
+
+	e.writeSkeletonMetadata(ctx, ref, []types.OCIArtifact{*artifact})
+	e.addModelLabelTags(ref, entry)
+	digest, err := artifacts.Revision(artifact)
+	if err != nil {
+		log.Printf("  Warning: %v", err)
+	}
+	e.recordVersion(ref, digest)
+	if err := e.Checkpoint.MarkDone(ref, checkpoint.StageExtraction); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	return ModelResult{Ref: ref}, nil
+}
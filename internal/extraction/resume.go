@@ -0,0 +1,52 @@
+package extraction
+
+import (
+	"log"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/checkpoint"
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// SplitResumed separates the models still to be extracted from those a previous run already
+// extracted
+func SplitResumed(modelEntries []types.ModelEntry, cp *checkpoint.Checkpoint) ([]types.ModelEntry, []string) {
+	var pending []types.ModelEntry
+	var resumed []string
+	for _, entry := range modelEntries {
+		if cp.Done(entry.URI, checkpoint.StageExtraction) {
+			resumed = append(resumed, entry.URI)
+		} else {
+			pending = append(pending, entry)
+		}
+	}
+	return pending, resumed
+}
+
+// ResumedResults rebuilds the extraction results of models a previous run extracted from its
+// manifests.yaml, falling back to whether a modelcard was saved for models it does not list
+func ResumedResults(output outputfs.FS, refs []string) []ModelResult {
+	previous := make(map[string]types.ModelCard)
+	if data, err := output.ReadFile("manifests.yaml"); err == nil {
+		var manifests types.ManifestsData
+		if err := yaml.Unmarshal(data, &manifests); err != nil {
+			log.Printf("Warning: Failed to parse previous manifests.yaml: %v", err)
+		}
+		for _, manifest := range manifests.Models {
+			previous[manifest.Ref] = manifest.ModelCard
+		}
+	}
+
+	var results []ModelResult
+	for _, ref := range refs {
+		card, found := previous[ref]
+		if !found {
+			_, err := output.Stat(outputfs.ModelPath(ref, "modelcard.md"))
+			card.Present = err == nil
+		}
+		results = append(results, ModelResult{Ref: ref, ModelCardFound: card.Present, Metadata: card.Metadata})
+	}
+	return results
+}
@@ -0,0 +1,33 @@
+package extraction
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// recordVerification checks the signatures, attestations and SBOMs of an extracted image and
+// records the result on its artifact in metadata.yaml
+func (e *extractor) recordVerification(ctx context.Context, ref, digest string) {
+	if e.Verifier == nil {
+		return
+	}
+	status, err := e.Verifier.Check(ctx, ref, digest, time.Now())
+	if err != nil {
+		log.Printf("  Warning: Could not verify %s: %v", ref, err)
+		return
+	}
+	log.Printf("  Verification of %s: signature verified %v, SBOM %v, attestation %v", ref, status.SignatureVerified, status.SBOMPresent, status.AttestationPresent)
+
+	normalized := utils.NormalizeArtifactURI(ref)
+	e.updateMetadataFile(ref, func(metadata *types.ExtractedMetadata) {
+		for i := range metadata.Artifacts {
+			if utils.NormalizeArtifactURI(metadata.Artifacts[i].URI) == normalized {
+				metadata.Artifacts[i].Verification = status
+			}
+		}
+	})
+}
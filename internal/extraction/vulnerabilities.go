@@ -0,0 +1,36 @@
+package extraction
+
+import (
+	"context"
+	"log"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// recordVulnerabilities records the vulnerability counts of an extracted image on its artifact in
+// metadata.yaml
+func (e *extractor) recordVulnerabilities(ctx context.Context, ref, digest string) {
+	if e.VulnerabilityScanner == nil {
+		return
+	}
+	summary, err := e.VulnerabilityScanner.Scan(ctx, ref, digest)
+	if err != nil {
+		log.Printf("  Warning: Could not scan %s for vulnerabilities: %v", ref, err)
+		return
+	}
+	if summary == nil {
+		log.Printf("  No vulnerability report for %s", ref)
+		return
+	}
+	log.Printf("  Vulnerabilities of %s: %d critical, %d high, %d medium, %d low, %d unknown", ref, summary.Critical, summary.High, summary.Medium, summary.Low, summary.Unknown)
+
+	normalized := utils.NormalizeArtifactURI(ref)
+	e.updateMetadataFile(ref, func(metadata *types.ExtractedMetadata) {
+		for i := range metadata.Artifacts {
+			if utils.NormalizeArtifactURI(metadata.Artifacts[i].URI) == normalized {
+				metadata.Artifacts[i].Vulnerabilities = summary
+			}
+		}
+	})
+}
@@ -0,0 +1,364 @@
+package gguf
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// Magic is the four-byte signature at the start of every GGUF file
+const Magic = "GGUF"
+
+// Sanity limits guarding against corrupt or malicious headers
+const (
+	maxStringLength = 16 << 20
+	maxArrayLength  = 1 << 24
+	maxTensorCount  = 1 << 20
+	maxKVCount      = 1 << 16
+	maxTensorDims   = 8
+	// maxArrayDepth bounds the nesting of arrays of arrays, which are skipped recursively
+	maxArrayDepth = 8
+)
+
+// GGUF metadata value types
+const (
+	typeUint8   = 0
+	typeInt8    = 1
+	typeUint16  = 2
+	typeInt16   = 3
+	typeUint32  = 4
+	typeInt32   = 5
+	typeFloat32 = 6
+	typeBool    = 7
+	typeString  = 8
+	typeArray   = 9
+	typeUint64  = 10
+	typeInt64   = 11
+	typeFloat64 = 12
+)
+
+// fileTypeNames maps general.file_type (llama_ftype) values to their quantization names
+var fileTypeNames = map[int64]string{
+	0:  "F32",
+	1:  "F16",
+	2:  "Q4_0",
+	3:  "Q4_1",
+	7:  "Q8_0",
+	8:  "Q5_0",
+	9:  "Q5_1",
+	10: "Q2_K",
+	11: "Q3_K_S",
+	12: "Q3_K_M",
+	13: "Q3_K_L",
+	14: "Q4_K_S",
+	15: "Q4_K_M",
+	16: "Q5_K_S",
+	17: "Q5_K_M",
+	18: "Q6_K",
+	19: "IQ2_XXS",
+	20: "IQ2_XS",
+	21: "Q2_K_S",
+	22: "IQ3_XS",
+	23: "IQ3_XXS",
+	24: "IQ1_S",
+	25: "IQ4_NL",
+	26: "IQ3_S",
+	27: "IQ3_M",
+	28: "IQ2_S",
+	29: "IQ2_M",
+	30: "IQ4_XS",
+	31: "IQ1_M",
+	32: "BF16",
+	36: "TQ1_0",
+	37: "TQ2_0",
+}
+
+// fileNameQuantPattern matches quantization names embedded in GGUF file names (e.g. model-Q4_K_M.gguf)
+var fileNameQuantPattern = regexp.MustCompile(`(?i)(?:^|[-._])(IQ\d_[A-Z]+|TQ\d_\d|Q\d_K(?:_[SML])?|Q\d_\d|BF16|F16|F32)(?:[-._]|$)`)
+
+// Metadata holds the fields extracted from a GGUF header
+type Metadata struct {
+	Version          uint32
+	Architecture     string
+	Name             string
+	QuantizationType string
+	ParameterCount   int64
+	ContextLength    int64
+	SplitCount       int64
+	// ChatTemplate is the Jinja chat template of tokenizer.chat_template
+	ChatTemplate string
+}
+
+// IsGGUFFile reports whether a file name refers to a GGUF file
+func IsGGUFFile(name string) bool {
+	return strings.HasSuffix(strings.ToLower(name), ".gguf")
+}
+
+// nonGGUFWeightsExtensions are the file extensions of model weights in other formats
+var nonGGUFWeightsExtensions = []string{".safetensors", ".bin", ".pt", ".pth", ".ckpt", ".onnx", ".h5", ".msgpack"}
+
+// IsNonGGUFWeightsFile reports whether a file name refers to model weights in a format other than
+// GGUF, such as safetensors or PyTorch checkpoints
+func IsNonGGUFWeightsFile(name string) bool {
+	lower := strings.ToLower(name)
+	for _, extension := range nonGGUFWeightsExtensions {
+		if strings.HasSuffix(lower, extension) {
+			return true
+		}
+	}
+	return false
+}
+
+// QuantizationFromFileName extracts a quantization name such as Q4_K_M from a GGUF file name
+func QuantizationFromFileName(name string) string {
+	base := name
+	if idx := strings.LastIndex(base, "/"); idx != -1 {
+		base = base[idx+1:]
+	}
+	base = strings.TrimSuffix(base, ".gguf")
+	if match := fileNameQuantPattern.FindStringSubmatch(base); match != nil {
+		return strings.ToUpper(match[1])
+	}
+	return ""
+}
+
+// ParseHeader reads the GGUF header (metadata key/values and tensor descriptors) from r.
+// Only the header is consumed, so r may be a stream over a multi-gigabyte file.
+func ParseHeader(r io.Reader) (*Metadata, error) {
+	p := &parser{r: bufio.NewReader(r)}
+
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(p.r, magic); err != nil {
+		return nil, fmt.Errorf("failed to read magic: %v", err)
+	}
+	if string(magic) != Magic {
+		return nil, fmt.Errorf("not a GGUF file (magic %q)", magic)
+	}
+
+	version, err := p.uint32()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read version: %v", err)
+	}
+	if version < 2 || version > 3 {
+		return nil, fmt.Errorf("unsupported GGUF version %d", version)
+	}
+
+	tensorCount, err := p.uint64()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tensor count: %v", err)
+	}
+	kvCount, err := p.uint64()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metadata count: %v", err)
+	}
+	if tensorCount > maxTensorCount || kvCount > maxKVCount {
+		return nil, fmt.Errorf("implausible header counts (tensors=%d, metadata=%d)", tensorCount, kvCount)
+	}
+
+	md := &Metadata{Version: version}
+	values := make(map[string]interface{})
+	for i := uint64(0); i < kvCount; i++ {
+		key, err := p.string()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read metadata key %d: %v", i, err)
+		}
+		valueType, err := p.uint32()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read type of %s: %v", key, err)
+		}
+		value, err := p.value(valueType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read value of %s: %v", key, err)
+		}
+		if value != nil {
+			values[key] = value
+		}
+	}
+
+	md.Architecture, _ = values["general.architecture"].(string)
+	md.Name, _ = values["general.name"].(string)
+	md.ChatTemplate, _ = values["tokenizer.chat_template"].(string)
+	if fileType, ok := toInt64(values["general.file_type"]); ok {
+		if name, exists := fileTypeNames[fileType]; exists {
+			md.QuantizationType = name
+		}
+	}
+	if md.Architecture != "" {
+		md.ContextLength, _ = toInt64(values[md.Architecture+".context_length"])
+	}
+	md.SplitCount, _ = toInt64(values["split.count"])
+
+	// Sum tensor element counts for the parameter count. Split files only describe
+	// their own tensors, so the total is only meaningful for single-file models.
+	var parameterCount int64
+	for i := uint64(0); i < tensorCount; i++ {
+		elements, err := p.tensorInfo()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tensor %d: %v", i, err)
+		}
+		parameterCount += elements
+	}
+	if md.SplitCount <= 1 {
+		md.ParameterCount = parameterCount
+	}
+
+	return md, nil
+}
+
+// ToQuantizationInfo converts GGUF header metadata to the catalog quantization field.
+// fileName is used to infer the quantization type when the header does not record one.
+func (m *Metadata) ToQuantizationInfo(fileName string) *types.QuantizationInfo {
+	quantType := m.QuantizationType
+	if quantType == "" {
+		quantType = QuantizationFromFileName(fileName)
+	}
+	return &types.QuantizationInfo{
+		Format:         types.QuantizationFormatGGUF,
+		Type:           quantType,
+		ParameterCount: m.ParameterCount,
+		ContextLength:  m.ContextLength,
+	}
+}
+
+// parser reads little-endian GGUF primitives
+type parser struct {
+	r *bufio.Reader
+	// depth is the number of arrays being skipped
+	depth int
+}
+
+func (p *parser) uint32() (uint32, error) {
+	var v uint32
+	err := binary.Read(p.r, binary.LittleEndian, &v)
+	return v, err
+}
+
+func (p *parser) uint64() (uint64, error) {
+	var v uint64
+	err := binary.Read(p.r, binary.LittleEndian, &v)
+	return v, err
+}
+
+func (p *parser) string() (string, error) {
+	length, err := p.uint64()
+	if err != nil {
+		return "", err
+	}
+	if length > maxStringLength {
+		return "", fmt.Errorf("string length %d exceeds limit", length)
+	}
+	buf := make([]byte, length)
+	if _, err := io.ReadFull(p.r, buf); err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+// value reads a metadata value. Scalars and strings are returned; arrays are skipped and return nil.
+func (p *parser) value(valueType uint32) (interface{}, error) {
+	switch valueType {
+	case typeUint8, typeInt8, typeBool:
+		b, err := p.r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		if valueType == typeInt8 {
+			return int64(int8(b)), nil
+		}
+		return int64(b), nil
+	case typeUint16, typeInt16:
+		var v uint16
+		if err := binary.Read(p.r, binary.LittleEndian, &v); err != nil {
+			return nil, err
+		}
+		if valueType == typeInt16 {
+			return int64(int16(v)), nil
+		}
+		return int64(v), nil
+	case typeUint32, typeInt32:
+		v, err := p.uint32()
+		if err != nil {
+			return nil, err
+		}
+		if valueType == typeInt32 {
+			return int64(int32(v)), nil
+		}
+		return int64(v), nil
+	case typeFloat32:
+		_, err := p.uint32()
+		return nil, err
+	case typeUint64, typeInt64:
+		v, err := p.uint64()
+		return int64(v), err
+	case typeFloat64:
+		_, err := p.uint64()
+		return nil, err
+	case typeString:
+		return p.string()
+	case typeArray:
+		elemType, err := p.uint32()
+		if err != nil {
+			return nil, err
+		}
+		count, err := p.uint64()
+		if err != nil {
+			return nil, err
+		}
+		if count > maxArrayLength {
+			return nil, fmt.Errorf("array length %d exceeds limit", count)
+		}
+		if p.depth >= maxArrayDepth {
+			return nil, fmt.Errorf("arrays nested deeper than %d", maxArrayDepth)
+		}
+		p.depth++
+		defer func() { p.depth-- }()
+		for i := uint64(0); i < count; i++ {
+			if _, err := p.value(elemType); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown value type %d", valueType)
+	}
+}
+
+// tensorInfo reads a tensor descriptor and returns its element count
+func (p *parser) tensorInfo() (int64, error) {
+	if _, err := p.string(); err != nil {
+		return 0, err
+	}
+	dims, err := p.uint32()
+	if err != nil {
+		return 0, err
+	}
+	if dims > maxTensorDims {
+		return 0, fmt.Errorf("tensor has %d dimensions", dims)
+	}
+	elements := int64(1)
+	for i := uint32(0); i < dims; i++ {
+		dim, err := p.uint64()
+		if err != nil {
+			return 0, err
+		}
+		elements *= int64(dim)
+	}
+	// Skip the tensor type and data offset
+	if _, err := p.uint32(); err != nil {
+		return 0, err
+	}
+	if _, err := p.uint64(); err != nil {
+		return 0, err
+	}
+	return elements, nil
+}
+
+func toInt64(v interface{}) (int64, bool) {
+	i, ok := v.(int64)
+	return i, ok
+}
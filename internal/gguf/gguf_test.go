@@ -0,0 +1,219 @@
+package gguf
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// ggufBuilder writes a minimal little-endian GGUF header for tests
+type ggufBuilder struct {
+	buf     bytes.Buffer
+	kvs     bytes.Buffer
+	kvCount uint64
+	tensors bytes.Buffer
+	tCount  uint64
+}
+
+func writeString(b *bytes.Buffer, s string) {
+	_ = binary.Write(b, binary.LittleEndian, uint64(len(s)))
+	b.WriteString(s)
+}
+
+func (g *ggufBuilder) addString(key, value string) {
+	writeString(&g.kvs, key)
+	_ = binary.Write(&g.kvs, binary.LittleEndian, uint32(typeString))
+	writeString(&g.kvs, value)
+	g.kvCount++
+}
+
+func (g *ggufBuilder) addUint32(key string, value uint32) {
+	writeString(&g.kvs, key)
+	_ = binary.Write(&g.kvs, binary.LittleEndian, uint32(typeUint32))
+	_ = binary.Write(&g.kvs, binary.LittleEndian, value)
+	g.kvCount++
+}
+
+func (g *ggufBuilder) addStringArray(key string, values []string) {
+	writeString(&g.kvs, key)
+	_ = binary.Write(&g.kvs, binary.LittleEndian, uint32(typeArray))
+	_ = binary.Write(&g.kvs, binary.LittleEndian, uint32(typeString))
+	_ = binary.Write(&g.kvs, binary.LittleEndian, uint64(len(values)))
+	for _, v := range values {
+		writeString(&g.kvs, v)
+	}
+	g.kvCount++
+}
+
+// addNestedArray adds depth arrays nested in each other, the innermost one empty
+func (g *ggufBuilder) addNestedArray(key string, depth int) {
+	writeString(&g.kvs, key)
+	_ = binary.Write(&g.kvs, binary.LittleEndian, uint32(typeArray))
+	for i := 1; i < depth; i++ {
+		_ = binary.Write(&g.kvs, binary.LittleEndian, uint32(typeArray))
+		_ = binary.Write(&g.kvs, binary.LittleEndian, uint64(1))
+	}
+	_ = binary.Write(&g.kvs, binary.LittleEndian, uint32(typeString))
+	_ = binary.Write(&g.kvs, binary.LittleEndian, uint64(0))
+	g.kvCount++
+}
+
+func (g *ggufBuilder) addTensor(name string, dims ...uint64) {
+	writeString(&g.tensors, name)
+	_ = binary.Write(&g.tensors, binary.LittleEndian, uint32(len(dims)))
+	for _, d := range dims {
+		_ = binary.Write(&g.tensors, binary.LittleEndian, d)
+	}
+	_ = binary.Write(&g.tensors, binary.LittleEndian, uint32(0))
+	_ = binary.Write(&g.tensors, binary.LittleEndian, uint64(0))
+	g.tCount++
+}
+
+func (g *ggufBuilder) bytes() []byte {
+	g.buf.Reset()
+	g.buf.WriteString(Magic)
+	_ = binary.Write(&g.buf, binary.LittleEndian, uint32(3))
+	_ = binary.Write(&g.buf, binary.LittleEndian, g.tCount)
+	_ = binary.Write(&g.buf, binary.LittleEndian, g.kvCount)
+	g.buf.Write(g.kvs.Bytes())
+	g.buf.Write(g.tensors.Bytes())
+	// Trailing bytes stand in for tensor data and must not be read
+	g.buf.Write(make([]byte, 64))
+	return g.buf.Bytes()
+}
+
+func sampleGGUF() []byte {
+	g := &ggufBuilder{}
+	g.addString("general.architecture", "llama")
+	g.addString("general.name", "Test Llama")
+	g.addUint32("general.file_type", 15)
+	g.addStringArray("tokenizer.ggml.tokens", []string{"<s>", "</s>", "hello"})
+	g.addUint32("llama.context_length", 8192)
+	g.addTensor("token_embd.weight", 4096, 32000)
+	g.addTensor("output_norm.weight", 4096)
+	return g.bytes()
+}
+
+func TestParseHeader(t *testing.T) {
+	md, err := ParseHeader(bytes.NewReader(sampleGGUF()))
+	if err != nil {
+		t.Fatalf("ParseHeader() error = %v", err)
+	}
+
+	if md.Version != 3 {
+		t.Errorf("Version = %d, expected 3", md.Version)
+	}
+	if md.Architecture != "llama" || md.Name != "Test Llama" {
+		t.Errorf("Unexpected architecture/name: %q/%q", md.Architecture, md.Name)
+	}
+	if md.QuantizationType != "Q4_K_M" {
+		t.Errorf("QuantizationType = %q, expected Q4_K_M", md.QuantizationType)
+	}
+	if md.ContextLength != 8192 {
+		t.Errorf("ContextLength = %d, expected 8192", md.ContextLength)
+	}
+	if expected := int64(4096*32000 + 4096); md.ParameterCount != expected {
+		t.Errorf("ParameterCount = %d, expected %d", md.ParameterCount, expected)
+	}
+}
+
+func TestParseHeader_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"wrong magic", []byte("GGML\x03\x00\x00\x00")},
+		{"unsupported version", append([]byte(Magic), 1, 0, 0, 0)},
+		{"truncated", sampleGGUF()[:40]},
+		{"arrays nested too deeply", nestedArrayGGUF(maxArrayDepth + 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseHeader(bytes.NewReader(tt.data)); err == nil {
+				t.Error("Expected error, got nil")
+			}
+		})
+	}
+}
+
+func nestedArrayGGUF(depth int) []byte {
+	g := &ggufBuilder{}
+	g.addNestedArray("test.nested", depth)
+	g.addString("general.architecture", "llama")
+	return g.bytes()
+}
+
+func TestParseHeader_NestedArrays(t *testing.T) {
+	md, err := ParseHeader(bytes.NewReader(nestedArrayGGUF(maxArrayDepth)))
+	if err != nil {
+		t.Fatalf("ParseHeader() error = %v", err)
+	}
+	if md.Architecture != "llama" {
+		t.Errorf("Architecture = %q, expected the key after the nested arrays to be read", md.Architecture)
+	}
+}
+
+func TestQuantizationFromFileName(t *testing.T) {
+	tests := []struct {
+		fileName string
+		expected string
+	}{
+		{"granite-3.1-8b-instruct-Q4_K_M.gguf", "Q4_K_M"},
+		{"models/llama-3-8b.q8_0.gguf", "Q8_0"},
+		{"mistral-7b-IQ2_XXS.gguf", "IQ2_XXS"},
+		{"phi-3-mini-f16.gguf", "F16"},
+		{"qwen2-7b-Q5_K_S-00001-of-00002.gguf", "Q5_K_S"},
+		{"model.gguf", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fileName, func(t *testing.T) {
+			if got := QuantizationFromFileName(tt.fileName); got != tt.expected {
+				t.Errorf("QuantizationFromFileName(%q) = %q, expected %q", tt.fileName, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestToQuantizationInfo_FileNameFallback(t *testing.T) {
+	md := &Metadata{ParameterCount: 100, ContextLength: 2048}
+	info := md.ToQuantizationInfo("model-Q6_K.gguf")
+	if info.Format != "gguf" || info.Type != "Q6_K" || info.ParameterCount != 100 || info.ContextLength != 2048 {
+		t.Errorf("Unexpected quantization info: %+v", info)
+	}
+}
+
+func TestParseFromTar(t *testing.T) {
+	content := sampleGGUF()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	_ = tw.WriteHeader(&tar.Header{Name: "models/", Typeflag: tar.TypeDir, Mode: 0755})
+	_ = tw.WriteHeader(&tar.Header{Name: "models/test-Q4_K_M.gguf", Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(content))})
+	_, _ = tw.Write(content)
+	_ = tw.Close()
+
+	md, fileName, err := ParseFromTar(&buf)
+	if err != nil {
+		t.Fatalf("ParseFromTar() error = %v", err)
+	}
+	if fileName != "models/test-Q4_K_M.gguf" || md.QuantizationType != "Q4_K_M" {
+		t.Errorf("Unexpected result: %s %+v", fileName, md)
+	}
+
+	var other bytes.Buffer
+	tw = tar.NewWriter(&other)
+	_ = tw.WriteHeader(&tar.Header{Name: "models/model.safetensors", Typeflag: tar.TypeReg, Mode: 0644, Size: 4})
+	_, _ = tw.Write([]byte("data"))
+	_ = tw.Close()
+
+	if _, name, err := ParseFromTar(&other); err == nil || name != "models/model.safetensors" {
+		t.Errorf("Expected error naming the first file for layer without GGUF file, got %q, %v", name, err)
+	}
+	if !IsNonGGUFWeightsFile("models/model.safetensors") || IsNonGGUFWeightsFile("models/model-Q4_K_M.gguf") || IsNonGGUFWeightsFile("README.md") {
+		t.Error("Expected only safetensors weights to be recognized as non-GGUF weights")
+	}
+}
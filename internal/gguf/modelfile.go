@@ -0,0 +1,121 @@
+package gguf
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ModelfileName is the name of the Ollama Modelfile written next to a model's metadata
+const ModelfileName = "Modelfile"
+
+// maxModelfileContext caps the num_ctx default of generated Modelfiles so models with very long
+// context windows still fit in the memory of a developer machine
+const maxModelfileContext = 8192
+
+// ollamaTemplate is the Ollama (Go template) equivalent of a family of Jinja chat templates
+type ollamaTemplate struct {
+	// marker is a special token that identifies the family in tokenizer.chat_template
+	marker   string
+	template string
+	stop     []string
+}
+
+// ollamaTemplates lists the chat template families recognized in GGUF headers, most specific first
+var ollamaTemplates = []ollamaTemplate{
+	{
+		marker: "<|start_of_role|>",
+		template: `{{ if .System }}<|start_of_role|>system<|end_of_role|>{{ .System }}<|end_of_text|>
+{{ end }}{{ if .Prompt }}<|start_of_role|>user<|end_of_role|>{{ .Prompt }}<|end_of_text|>
+{{ end }}<|start_of_role|>assistant<|end_of_role|>{{ .Response }}<|end_of_text|>`,
+		stop: []string{"<|start_of_role|>", "<|end_of_role|>", "<|end_of_text|>"},
+	},
+	{
+		marker: "<|start_header_id|>",
+		template: `{{ if .System }}<|start_header_id|>system<|end_header_id|>
+
+{{ .System }}<|eot_id|>{{ end }}{{ if .Prompt }}<|start_header_id|>user<|end_header_id|>
+
+{{ .Prompt }}<|eot_id|>{{ end }}<|start_header_id|>assistant<|end_header_id|>
+
+{{ .Response }}<|eot_id|>`,
+		stop: []string{"<|start_header_id|>", "<|end_header_id|>", "<|eot_id|>"},
+	},
+	{
+		marker: "<|im_start|>",
+		template: `{{ if .System }}<|im_start|>system
+{{ .System }}<|im_end|>
+{{ end }}{{ if .Prompt }}<|im_start|>user
+{{ .Prompt }}<|im_end|>
+{{ end }}<|im_start|>assistant
+{{ .Response }}<|im_end|>`,
+		stop: []string{"<|im_start|>", "<|im_end|>"},
+	},
+	{
+		marker: "<start_of_turn>",
+		template: `<start_of_turn>user
+{{ if .System }}{{ .System }} {{ end }}{{ .Prompt }}<end_of_turn>
+<start_of_turn>model
+{{ .Response }}<end_of_turn>`,
+		stop: []string{"<start_of_turn>", "<end_of_turn>"},
+	},
+	{
+		marker: "[INST]",
+		template: `[INST] {{ if .System }}{{ .System }}
+
+{{ end }}{{ .Prompt }} [/INST]{{ .Response }}`,
+		stop: []string{"[INST]", "[/INST]"},
+	},
+}
+
+// Modelfile returns an Ollama Modelfile stub for the GGUF weights file weightsFile, found in the
+// model image source: FROM the file's base name, a TEMPLATE translated from the header's chat
+// template when its family is recognized, and num_ctx and stop PARAMETER defaults
+func (m *Metadata) Modelfile(weightsFile, source string) []byte {
+	fileName := path.Base(weightsFile)
+	modelName := strings.ToLower(strings.TrimSuffix(fileName, path.Ext(fileName)))
+
+	var b strings.Builder
+	title := m.Name
+	if title == "" {
+		title = modelName
+	}
+	if m.QuantizationType != "" {
+		title += " (" + m.QuantizationType + ")"
+	}
+	fmt.Fprintf(&b, "# Ollama Modelfile for %s\n", title)
+	fmt.Fprintf(&b, "# Copy %s out of %s next to this file, then run:\n", weightsFile, source)
+	fmt.Fprintf(&b, "#   ollama create %s -f Modelfile\n", modelName)
+	fmt.Fprintf(&b, "FROM ./%s\n", fileName)
+
+	tmpl := m.ollamaTemplate()
+	switch {
+	case tmpl != nil:
+		fmt.Fprintf(&b, "TEMPLATE \"\"\"%s\"\"\"\n", tmpl.template)
+	case m.ChatTemplate != "":
+		b.WriteString("# The chat template is not a recognized format; Ollama uses the one in the GGUF file\n")
+	}
+
+	if m.ContextLength > 0 {
+		fmt.Fprintf(&b, "PARAMETER num_ctx %d\n", min(m.ContextLength, maxModelfileContext))
+	}
+	if tmpl != nil {
+		for _, stop := range tmpl.stop {
+			fmt.Fprintf(&b, "PARAMETER stop %q\n", stop)
+		}
+	}
+	return []byte(b.String())
+}
+
+// ollamaTemplate returns the Ollama template of the header's chat template family, or nil
+func (m *Metadata) ollamaTemplate() *ollamaTemplate {
+	if m.ChatTemplate == "" {
+		return nil
+	}
+	for i := range ollamaTemplates {
+		if strings.Contains(m.ChatTemplate, ollamaTemplates[i].marker) {
+			return &ollamaTemplates[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package gguf
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestModelfile(t *testing.T) {
+	g := &ggufBuilder{}
+	g.addString("general.architecture", "granite")
+	g.addString("general.name", "Granite 3.1 8B Instruct")
+	g.addUint32("general.file_type", 15)
+	g.addString("tokenizer.chat_template", "{%- for message in messages %}<|start_of_role|>{{ message['role'] }}<|end_of_role|>{%- endfor %}")
+	g.addUint32("granite.context_length", 131072)
+	md, err := ParseHeader(bytes.NewReader(g.bytes()))
+	if err != nil {
+		t.Fatalf("ParseHeader() error = %v", err)
+	}
+
+	modelfile := string(md.Modelfile("models/granite-3.1-8b-instruct-Q4_K_M.gguf", "registry.example.com/granite:1.5"))
+	for _, want := range []string{
+		"# Ollama Modelfile for Granite 3.1 8B Instruct (Q4_K_M)\n",
+		"# Copy models/granite-3.1-8b-instruct-Q4_K_M.gguf out of registry.example.com/granite:1.5",
+		"ollama create granite-3.1-8b-instruct-q4_k_m -f Modelfile\n",
+		"FROM ./granite-3.1-8b-instruct-Q4_K_M.gguf\n",
+		"TEMPLATE \"\"\"{{ if .System }}<|start_of_role|>system<|end_of_role|>",
+		"PARAMETER num_ctx 8192\n",
+		"PARAMETER stop \"<|end_of_text|>\"\n",
+	} {
+		if !strings.Contains(modelfile, want) {
+			t.Errorf("Modelfile does not contain %q:\n%s", want, modelfile)
+		}
+	}
+}
+
+func TestModelfile_UnknownTemplate(t *testing.T) {
+	md := &Metadata{ChatTemplate: "{{ messages }}", ContextLength: 4096}
+	modelfile := string(md.Modelfile("model.gguf", "registry.example.com/model:1"))
+	if strings.Contains(modelfile, "TEMPLATE") || strings.Contains(modelfile, "PARAMETER stop") {
+		t.Errorf("Expected no template for an unknown chat template:\n%s", modelfile)
+	}
+	if !strings.Contains(modelfile, "PARAMETER num_ctx 4096\n") {
+		t.Errorf("Expected the full context length:\n%s", modelfile)
+	}
+}
@@ -0,0 +1,36 @@
+package gguf
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+)
+
+// ParseFromTar reads a GGUF header from the first regular file in a tar stream, as found in
+// modelcar weight layers. Only the first file is inspected so that non-GGUF layers can be
+// rejected without downloading their contents. Returns the metadata and the file name; the name
+// of the first file is also returned when it is not a GGUF file.
+func ParseFromTar(r io.Reader) (*Metadata, string, error) {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil, "", fmt.Errorf("no files found in layer")
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read tar: %v", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if !IsGGUFFile(header.Name) {
+			return nil, header.Name, fmt.Errorf("layer does not contain a GGUF file (first file: %s)", header.Name)
+		}
+
+		md, err := ParseHeader(tr)
+		if err != nil {
+			return nil, header.Name, err
+		}
+		return md, header.Name, nil
+	}
+}
@@ -0,0 +1,214 @@
+// Package gitcommit commits the data files updated by a run to a branch, pushes it and optionally
+// opens a pull request, replacing the scripting publishing workflows used to do after a run.
+package gitcommit
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/github"
+)
+
+// Fallback commit identity used when git has no user configured, as in CI containers
+const (
+	fallbackName  = "model-metadata-collection"
+	fallbackEmail = "model-metadata-collection@users.noreply.github.com"
+)
+
+// githubRemotePattern extracts owner/name from https and ssh GitHub remote URLs
+var githubRemotePattern = regexp.MustCompile(`github\.com[:/]([^/]+/[^/]+?)(?:\.git)?/?$`)
+
+// Options controls Commit
+type Options struct {
+	// Dir is the git working tree; empty means the current directory
+	Dir string
+	// Paths are the files and directories whose changes are committed, e.g. data
+	Paths []string
+	// Branch receives the commit. It is created, or reset, at the current HEAD without being
+	// checked out, and must differ from the checked-out branch and from PullRequestBase.
+	Branch string
+	// Message is the commit message; its first line is also the pull request title
+	Message string
+	// Remote the branch is pushed to, replacing the branch of the previous run; empty commits
+	// locally only
+	Remote string
+	// PullRequestBase opens a pull request from Branch into this branch when non-empty
+	PullRequestBase string
+	// Repo is the GitHub repository ("owner/name") of the pull request; defaults to the
+	// repository of Remote's URL
+	Repo string
+}
+
+// Result reports what Commit did
+type Result struct {
+	// Commit is the SHA of the new commit, empty when nothing changed
+	Commit string
+	// PullRequestURL is the URL of the opened or existing pull request
+	PullRequestURL string
+}
+
+// Commit commits the changes under opts.Paths to opts.Branch, pushes the branch and opens a pull
+// request. The commit is built in a temporary index, so the checked-out branch, the index and the
+// working tree are left as they are. Nothing is committed when the paths have no changes.
+func Commit(ctx context.Context, opts Options) (Result, error) {
+	if len(opts.Paths) == 0 {
+		return Result{}, fmt.Errorf("no paths to commit")
+	}
+	if opts.Branch == "" {
+		return Result{}, fmt.Errorf("no branch to commit to")
+	}
+	if opts.Branch == opts.PullRequestBase {
+		return Result{}, fmt.Errorf("branch %s is the pull request base; commit to a separate branch", opts.Branch)
+	}
+	// A detached HEAD has no current branch
+	if current, _ := git(ctx, opts.Dir, "symbolic-ref", "--quiet", "--short", "HEAD"); current == opts.Branch {
+		return Result{}, fmt.Errorf("branch %s is checked out; commit to a separate branch", opts.Branch)
+	}
+
+	status, err := git(ctx, opts.Dir, append([]string{"status", "--porcelain", "--"}, opts.Paths...)...)
+	if err != nil {
+		return Result{}, err
+	}
+	if status == "" {
+		log.Printf("No changes under %s to commit", strings.Join(opts.Paths, ", "))
+		return Result{}, nil
+	}
+
+	result := Result{}
+	if result.Commit, err = commitToBranch(ctx, opts); err != nil {
+		return Result{}, err
+	}
+	log.Printf("Committed data changes to %s as %s", opts.Branch, result.Commit)
+
+	if opts.Remote == "" {
+		return result, nil
+	}
+	if err := push(ctx, opts.Dir, opts.Remote, opts.Branch); err != nil {
+		return result, err
+	}
+	log.Printf("Pushed %s to %s", opts.Branch, opts.Remote)
+
+	if opts.PullRequestBase == "" {
+		return result, nil
+	}
+	repo := opts.Repo
+	if repo == "" {
+		remoteURL, err := git(ctx, opts.Dir, "remote", "get-url", opts.Remote)
+		if err != nil {
+			return result, err
+		}
+		if repo = RepoFromRemoteURL(remoteURL); repo == "" {
+			return result, fmt.Errorf("remote %s (%s) is not a GitHub repository; set the repository explicitly", opts.Remote, remoteURL)
+		}
+	}
+	title, body, _ := strings.Cut(opts.Message, "\n")
+	result.PullRequestURL, err = github.OpenPullRequest(ctx, repo, github.PullRequest{
+		Title: title,
+		Head:  opts.Branch,
+		Base:  opts.PullRequestBase,
+		Body:  strings.TrimSpace(body),
+	})
+	if err != nil {
+		return result, err
+	}
+	log.Printf("Pull request: %s", result.PullRequestURL)
+	return result, nil
+}
+
+// commitToBranch commits HEAD plus the changes under opts.Paths and points opts.Branch at the new
+// commit. The changes are staged in a temporary index, so the real index keeps what the user
+// staged and HEAD does not move.
+func commitToBranch(ctx context.Context, opts Options) (string, error) {
+	head, err := git(ctx, opts.Dir, "rev-parse", "--verify", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	tmpDir, err := os.MkdirTemp("", "gitcommit-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary index: %v", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+	env := []string{"GIT_INDEX_FILE=" + filepath.Join(tmpDir, "index")}
+
+	if _, err := gitEnv(ctx, opts.Dir, env, "read-tree", head); err != nil {
+		return "", err
+	}
+	if _, err := gitEnv(ctx, opts.Dir, env, append([]string{"add", "-A", "--"}, opts.Paths...)...); err != nil {
+		return "", err
+	}
+	tree, err := gitEnv(ctx, opts.Dir, env, "write-tree")
+	if err != nil {
+		return "", err
+	}
+
+	commitArgs := []string{"commit-tree", tree, "-p", head, "-m", opts.Message}
+	if email, _ := git(ctx, opts.Dir, "config", "user.email"); email == "" {
+		commitArgs = append([]string{"-c", "user.name=" + fallbackName, "-c", "user.email=" + fallbackEmail}, commitArgs...)
+	}
+	commit, err := git(ctx, opts.Dir, commitArgs...)
+	if err != nil {
+		return "", err
+	}
+	if _, err := git(ctx, opts.Dir, "update-ref", "-m", "gitcommit: commit data changes", "refs/heads/"+opts.Branch, commit); err != nil {
+		return "", err
+	}
+	return commit, nil
+}
+
+// push replaces the remote branch with the local one. The branch is recreated from HEAD on every
+// run, so the push is forced, but only over the commit last seen on the remote: the remote-tracking
+// branch, or the remote's current value when the clone never fetched the branch. Commits pushed
+// to the branch by someone else since then make the push fail instead of being discarded.
+func push(ctx context.Context, dir, remote, branch string) error {
+	ref := "refs/heads/" + branch
+	expected, err := git(ctx, dir, "rev-parse", "--verify", "--quiet", "refs/remotes/"+remote+"/"+branch)
+	if err != nil {
+		// An empty expected value requires the remote branch not to exist
+		listed, err := git(ctx, dir, "ls-remote", remote, ref)
+		if err != nil {
+			return err
+		}
+		expected, _, _ = strings.Cut(listed, "\t")
+	}
+	_, err = git(ctx, dir, "push", "--force-with-lease="+ref+":"+expected, remote, ref+":"+ref)
+	return err
+}
+
+// RepoFromRemoteURL returns the "owner/name" of a GitHub remote URL such as
+// https://github.com/opendatahub-io/model-metadata-collection.git or
+// git@github.com:opendatahub-io/model-metadata-collection.git, or "" for other hosts
+func RepoFromRemoteURL(remoteURL string) string {
+	match := githubRemotePattern.FindStringSubmatch(strings.TrimSpace(remoteURL))
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
+
+// git runs a git command in dir and returns its trimmed standard output
+func git(ctx context.Context, dir string, args ...string) (string, error) {
+	return gitEnv(ctx, dir, nil, args...)
+}
+
+// gitEnv runs a git command in dir with additional environment variables
+func gitEnv(ctx context.Context, dir string, env []string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s failed: %v: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,174 @@
+package gitcommit
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestRepoFromRemoteURL(t *testing.T) {
+	tests := map[string]string{
+		"https://github.com/opendatahub-io/model-metadata-collection.git": "opendatahub-io/model-metadata-collection",
+		"https://github.com/opendatahub-io/model-metadata-collection":     "opendatahub-io/model-metadata-collection",
+		"git@github.com:opendatahub-io/model-metadata-collection.git":     "opendatahub-io/model-metadata-collection",
+		"https://gitlab.com/opendatahub-io/model-metadata-collection.git": "",
+		"/srv/git/catalog.git": "",
+	}
+	for remoteURL, want := range tests {
+		if got := RepoFromRemoteURL(remoteURL); got != want {
+			t.Errorf("RepoFromRemoteURL(%q) = %q, want %q", remoteURL, got, want)
+		}
+	}
+}
+
+// initRepo creates a repository with one commit of data/models-catalog.yaml and a bare remote
+func initRepo(t *testing.T) (dir, remote string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	root := t.TempDir()
+	dir, remote = filepath.Join(root, "work"), filepath.Join(root, "remote.git")
+	ctx := context.Background()
+	for _, args := range [][]string{
+		{"init", "-q", "--bare", remote},
+		{"init", "-q", "-b", "main", dir},
+	} {
+		if _, err := git(ctx, root, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeData(t, dir, "models-catalog.yaml", "models: []\n")
+	for _, args := range [][]string{
+		{"add", "-A"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "Initial catalog"},
+		{"remote", "add", "origin", remote},
+	} {
+		if _, err := git(ctx, dir, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir, remote
+}
+
+func writeData(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(dir, "data"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "data", name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCommit(t *testing.T) {
+	dir, remote := initRepo(t)
+	ctx := context.Background()
+	writeData(t, dir, "models-catalog.yaml", "models:\n  - name: RedHatAI/granite-3.1-8b-instruct\n")
+	writeData(t, dir, "CATALOG.md", "# Catalog\n")
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Commit(ctx, Options{
+		Dir:     dir,
+		Paths:   []string{"data"},
+		Branch:  "catalog-update",
+		Message: "Update model catalog data\n\nNew models: RedHatAI/granite-3.1-8b-instruct",
+		Remote:  "origin",
+	})
+	if err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	if result.Commit == "" || result.PullRequestURL != "" {
+		t.Fatalf("Commit() = %+v, want a commit and no pull request", result)
+	}
+
+	files, err := git(ctx, dir, "show", "--name-only", "--format=%s", "catalog-update")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if files != "Update model catalog data\n\ndata/CATALOG.md\ndata/models-catalog.yaml" {
+		t.Errorf("commit = %q, want only the data files", files)
+	}
+	pushed, err := git(ctx, remote, "rev-parse", "catalog-update")
+	if err != nil || pushed != result.Commit {
+		t.Errorf("remote catalog-update = %q, %v, want %s", pushed, err, result.Commit)
+	}
+
+	// The checkout stays on main with the run's files in the working tree
+	if current, _ := git(ctx, dir, "symbolic-ref", "--short", "HEAD"); current != "main" {
+		t.Errorf("checked-out branch = %q, want main", current)
+	}
+	if status, _ := git(ctx, dir, "status", "--porcelain"); status != "M data/models-catalog.yaml\n?? data/CATALOG.md\n?? notes.txt" {
+		t.Errorf("working tree status = %q, want the run's changes left in place", status)
+	}
+
+	// A second run replaces the branch it pushed before
+	writeData(t, dir, "CATALOG.md", "# Catalog\n\nUpdated\n")
+	second, err := Commit(ctx, Options{Dir: dir, Paths: []string{"data"}, Branch: "catalog-update", Message: "Update", Remote: "origin"})
+	if err != nil || second.Commit == "" {
+		t.Fatalf("Commit() = %+v, %v, want a new commit", second, err)
+	}
+	if pushed, _ := git(ctx, remote, "rev-parse", "catalog-update"); pushed != second.Commit {
+		t.Errorf("remote catalog-update = %q, want %s", pushed, second.Commit)
+	}
+}
+
+func TestCommit_RefusesProtectedBranches(t *testing.T) {
+	dir, _ := initRepo(t)
+	ctx := context.Background()
+	writeData(t, dir, "models-catalog.yaml", "models:\n  - name: RedHatAI/granite-3.1-8b-instruct\n")
+	head, err := git(ctx, dir, "rev-parse", "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, opts := range []Options{
+		{Dir: dir, Paths: []string{"data"}, Branch: "main", Message: "Update"},
+		{Dir: dir, Paths: []string{"data"}, Branch: "release", Message: "Update", Remote: "origin", PullRequestBase: "release"},
+	} {
+		if _, err := Commit(ctx, opts); err == nil {
+			t.Errorf("Commit() to %s succeeded, want an error", opts.Branch)
+		}
+	}
+	if after, _ := git(ctx, dir, "rev-parse", "HEAD"); after != head {
+		t.Errorf("HEAD moved from %s to %s", head, after)
+	}
+}
+
+func TestCommit_KeepsRemoteChanges(t *testing.T) {
+	dir, remote := initRepo(t)
+	ctx := context.Background()
+	writeData(t, dir, "models-catalog.yaml", "models:\n  - name: RedHatAI/granite-3.1-8b-instruct\n")
+	opts := Options{Dir: dir, Paths: []string{"data"}, Branch: "catalog-update", Message: "Update", Remote: "origin"}
+	if _, err := Commit(ctx, opts); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+
+	// Someone else pushes to the branch after the run
+	other := filepath.Join(t.TempDir(), "other")
+	for _, args := range [][]string{
+		{"clone", "-q", "-b", "catalog-update", remote, other},
+		{"-C", other, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "Review fix"},
+		{"-C", other, "push", "-q", "origin", "catalog-update"},
+	} {
+		if _, err := git(ctx, dir, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	reviewed, err := git(ctx, remote, "rev-parse", "catalog-update")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writeData(t, dir, "CATALOG.md", "# Catalog\n")
+	if _, err := Commit(ctx, opts); err == nil {
+		t.Error("Commit() overwrote a commit pushed by someone else")
+	}
+	if after, _ := git(ctx, remote, "rev-parse", "catalog-update"); after != reviewed {
+		t.Errorf("remote catalog-update = %s, want %s", after, reviewed)
+	}
+}
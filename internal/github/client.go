@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -13,14 +14,16 @@ import (
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/opendatahub-io/model-metadata-collection/internal/httpclient"
 	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
 )
 
 const maxResponseSize = 5 * 1024 * 1024 // 5 MiB safety cap for HTTP response bodies
 
-var httpClient = &http.Client{
-	Timeout: 30 * time.Second,
-}
+var httpClient = httpclient.New("github", 30*time.Second)
+
+// apiBaseURL is the GitHub REST API endpoint; tests point it at a local server
+var apiBaseURL = "https://api.github.com"
 
 var (
 	ghToken     string
@@ -34,8 +37,8 @@ func getGHToken() string {
 	return ghToken
 }
 
-func doGet(url string) (*http.Response, error) {
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+func doGet(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -75,10 +78,10 @@ var ErrNotFound = fmt.Errorf("not found")
 // ValidateBranch checks that a branch exists in the given GitHub repository.
 // Returns the resolved commit SHA on success (safe for use in raw URLs even
 // when the branch name contains slashes), or an error if the branch does not exist.
-func ValidateBranch(repo, branch string) (string, error) {
-	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/branches/%s", escapeRepoPath(repo), url.PathEscape(branch))
+func ValidateBranch(ctx context.Context, repo, branch string) (string, error) {
+	apiURL := fmt.Sprintf("%s/repos/%s/branches/%s", apiBaseURL, escapeRepoPath(repo), url.PathEscape(branch))
 
-	resp, err := doGet(apiURL)
+	resp, err := doGet(ctx, apiURL)
 	if err != nil {
 		return "", fmt.Errorf("failed to validate branch %q: %v", branch, err)
 	}
@@ -114,10 +117,10 @@ func ValidateBranch(repo, branch string) (string, error) {
 }
 
 // FetchAgentYAML fetches and parses an agent.yaml file from a GitHub repository.
-func FetchAgentYAML(repo, branch, agentPath string) (*types.UpstreamAgentYAML, error) {
+func FetchAgentYAML(ctx context.Context, repo, branch, agentPath string) (*types.UpstreamAgentYAML, error) {
 	url := buildRawURL(repo, branch, agentPath, "agent.yaml")
 
-	resp, err := doGet(url)
+	resp, err := doGet(ctx, url)
 	if err != nil {
 		return nil, fmt.Errorf("HTTP request failed for %s: %v", url, err)
 	}
@@ -161,10 +164,10 @@ func FetchAgentYAML(repo, branch, agentPath string) (*types.UpstreamAgentYAML, e
 
 // FetchReadme fetches the README.md content from a GitHub repository path.
 // Returns empty string (not error) when README is not found (404).
-func FetchReadme(repo, branch, agentPath string) (string, error) {
+func FetchReadme(ctx context.Context, repo, branch, agentPath string) (string, error) {
 	url := buildRawURL(repo, branch, agentPath, "README.md")
 
-	resp, err := doGet(url)
+	resp, err := doGet(ctx, url)
 	if err != nil {
 		return "", fmt.Errorf("HTTP request failed for %s: %v", url, err)
 	}
@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"errors"
 	"testing"
 )
@@ -45,7 +46,7 @@ func TestRawGitHubURLConstruction(t *testing.T) {
 const testRepo = "red-hat-data-services/agentic-starter-kits"
 
 func TestValidateBranchMain(t *testing.T) {
-	sha, err := ValidateBranch(testRepo, "main")
+	sha, err := ValidateBranch(context.Background(), testRepo, "main")
 	if err != nil {
 		t.Fatalf("expected main branch to be valid, got error: %v", err)
 	}
@@ -58,7 +59,7 @@ func TestValidateBranchMain(t *testing.T) {
 }
 
 func TestValidateBranchNonExistent(t *testing.T) {
-	_, err := ValidateBranch(testRepo, "this-branch-does-not-exist-xyz-12345")
+	_, err := ValidateBranch(context.Background(), testRepo, "this-branch-does-not-exist-xyz-12345")
 	if err == nil {
 		t.Fatal("expected error for non-existent branch")
 	}
@@ -66,7 +67,7 @@ func TestValidateBranchNonExistent(t *testing.T) {
 
 func TestFetchAgentYAMLMainBranch(t *testing.T) {
 
-	agent, err := FetchAgentYAML(testRepo, "main", "agents/langgraph/templates/react_agent")
+	agent, err := FetchAgentYAML(context.Background(), testRepo, "main", "agents/langgraph/templates/react_agent")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -83,7 +84,7 @@ func TestFetchAgentYAMLMainBranch(t *testing.T) {
 
 func TestFetchAgentYAMLNonExistentBranch(t *testing.T) {
 
-	_, err := FetchAgentYAML(testRepo, "this-branch-does-not-exist-xyz-12345", "agents/langgraph/templates/react_agent")
+	_, err := FetchAgentYAML(context.Background(), testRepo, "this-branch-does-not-exist-xyz-12345", "agents/langgraph/templates/react_agent")
 	if err == nil {
 		t.Fatal("expected error for non-existent branch")
 	}
@@ -94,7 +95,7 @@ func TestFetchAgentYAMLNonExistentBranch(t *testing.T) {
 
 func TestFetchAgentYAMLNonExistentPath(t *testing.T) {
 
-	_, err := FetchAgentYAML(testRepo, "main", "agents/does-not-exist")
+	_, err := FetchAgentYAML(context.Background(), testRepo, "main", "agents/does-not-exist")
 	if err == nil {
 		t.Fatal("expected error for non-existent path")
 	}
@@ -105,7 +106,7 @@ func TestFetchAgentYAMLNonExistentPath(t *testing.T) {
 
 func TestFetchReadmeMainBranch(t *testing.T) {
 
-	readme, err := FetchReadme(testRepo, "main", "agents/langgraph/templates/react_agent")
+	readme, err := FetchReadme(context.Background(), testRepo, "main", "agents/langgraph/templates/react_agent")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -116,7 +117,7 @@ func TestFetchReadmeMainBranch(t *testing.T) {
 
 func TestFetchReadmeNonExistentBranch(t *testing.T) {
 
-	readme, err := FetchReadme(testRepo, "this-branch-does-not-exist-xyz-12345", "agents/langgraph/templates/react_agent")
+	readme, err := FetchReadme(context.Background(), testRepo, "this-branch-does-not-exist-xyz-12345", "agents/langgraph/templates/react_agent")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -0,0 +1,100 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// PullRequest describes a pull request to open
+type PullRequest struct {
+	Title string `json:"title"`
+	// Head is the branch with the changes
+	Head string `json:"head"`
+	// Base is the branch the changes are merged into
+	Base string `json:"base"`
+	Body string `json:"body,omitempty"`
+}
+
+type pullRequestResponse struct {
+	HTMLURL string `json:"html_url"`
+}
+
+// OpenPullRequest opens a pull request in repo ("owner/name") and returns its URL. When an open
+// pull request for the head branch already exists, its URL is returned instead, so repeated runs
+// pushing to the same branch update one pull request. Requires GITHUB_TOKEN.
+func OpenPullRequest(ctx context.Context, repo string, pr PullRequest) (string, error) {
+	if getGHToken() == "" {
+		return "", errors.New("GITHUB_TOKEN is required to open pull requests")
+	}
+	body, err := json.Marshal(pr)
+	if err != nil {
+		return "", fmt.Errorf("error encoding pull request: %v", err)
+	}
+	apiURL := fmt.Sprintf("%s/repos/%s/pulls", apiBaseURL, escapeRepoPath(repo))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+getGHToken())
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to open pull request in %s: %v", repo, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	respBody, err := readLimitedBody(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read pull request response from %s: %v", repo, err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		var created pullRequestResponse
+		if err := json.Unmarshal(respBody, &created); err != nil {
+			return "", fmt.Errorf("failed to parse pull request response from %s: %v", repo, err)
+		}
+		return created.HTMLURL, nil
+	case http.StatusUnprocessableEntity:
+		// GitHub rejects a second pull request for the same head and base
+		if strings.Contains(string(respBody), "already exists") {
+			return findPullRequest(ctx, repo, pr)
+		}
+	}
+	return "", fmt.Errorf("unexpected status %d opening pull request in %s: %s", resp.StatusCode, repo, strings.TrimSpace(string(respBody)))
+}
+
+// findPullRequest returns the URL of the open pull request from pr.Head into pr.Base
+func findPullRequest(ctx context.Context, repo string, pr PullRequest) (string, error) {
+	owner, _, _ := strings.Cut(repo, "/")
+	query := url.Values{"state": {"open"}, "head": {owner + ":" + pr.Head}, "base": {pr.Base}}
+	apiURL := fmt.Sprintf("%s/repos/%s/pulls?%s", apiBaseURL, escapeRepoPath(repo), query.Encode())
+
+	resp, err := doGet(ctx, apiURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to list pull requests in %s: %v", repo, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %d listing pull requests in %s", resp.StatusCode, repo)
+	}
+	body, err := readLimitedBody(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read pull requests of %s: %v", repo, err)
+	}
+	var pulls []pullRequestResponse
+	if err := json.Unmarshal(body, &pulls); err != nil {
+		return "", fmt.Errorf("failed to parse pull requests of %s: %v", repo, err)
+	}
+	if len(pulls) == 0 {
+		return "", fmt.Errorf("no open pull request from %s into %s in %s", pr.Head, pr.Base, repo)
+	}
+	return pulls[0].HTMLURL, nil
+}
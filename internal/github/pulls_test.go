@@ -0,0 +1,77 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// useTestAPI points the GitHub API at server with a test token for the duration of the test
+func useTestAPI(t *testing.T, server *httptest.Server, token string) {
+	t.Helper()
+	getGHToken()
+	previousURL, previousToken := apiBaseURL, ghToken
+	apiBaseURL, ghToken = server.URL, token
+	t.Cleanup(func() { apiBaseURL, ghToken = previousURL, previousToken })
+}
+
+func TestOpenPullRequest(t *testing.T) {
+	var received PullRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repos/opendatahub-io/model-metadata-collection/pulls" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+		}
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"html_url": "https://github.com/opendatahub-io/model-metadata-collection/pull/7"}`))
+	}))
+	defer server.Close()
+	useTestAPI(t, server, "test-token")
+
+	pr := PullRequest{Title: "Update model catalog data", Head: "catalog-update", Base: "main"}
+	prURL, err := OpenPullRequest(context.Background(), "opendatahub-io/model-metadata-collection", pr)
+	if err != nil {
+		t.Fatalf("OpenPullRequest() error = %v", err)
+	}
+	if prURL != "https://github.com/opendatahub-io/model-metadata-collection/pull/7" || received != pr {
+		t.Errorf("OpenPullRequest() = %s, sent %+v", prURL, received)
+	}
+}
+
+func TestOpenPullRequest_Existing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"message": "Validation Failed", "errors": [{"message": "A pull request already exists for opendatahub-io:catalog-update."}]}`))
+		case http.MethodGet:
+			if got := r.URL.Query().Get("head"); got != "opendatahub-io:catalog-update" {
+				t.Errorf("head = %q", got)
+			}
+			_, _ = w.Write([]byte(`[{"html_url": "https://github.com/opendatahub-io/model-metadata-collection/pull/5"}]`))
+		}
+	}))
+	defer server.Close()
+	useTestAPI(t, server, "test-token")
+
+	prURL, err := OpenPullRequest(context.Background(), "opendatahub-io/model-metadata-collection", PullRequest{Title: "t", Head: "catalog-update", Base: "main"})
+	if err != nil || !strings.HasSuffix(prURL, "/pull/5") {
+		t.Errorf("OpenPullRequest() = %s, %v, want the existing pull request", prURL, err)
+	}
+}
+
+func TestOpenPullRequest_NoToken(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	useTestAPI(t, server, "")
+
+	if _, err := OpenPullRequest(context.Background(), "opendatahub-io/model-metadata-collection", PullRequest{}); err == nil {
+		t.Error("OpenPullRequest() without a token succeeded")
+	}
+}
@@ -0,0 +1,137 @@
+// Package history keeps a per-model record of the versions seen across runs: the tag, digest,
+// extraction date and metadata hash of every extraction that differed from the previous one. It
+// lets the catalog list a model's previous versions and detects digest rollbacks.
+package history
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// FileName is the name of the history file in each model's output directory
+const FileName = "history.yaml"
+
+// Version is one version of a model seen by an extraction
+type Version struct {
+	// Tag is the image tag, or the revision of HuggingFace repositories; empty for other artifacts
+	Tag string `yaml:"tag,omitempty" json:"tag,omitempty"`
+	// Digest is the manifest digest of images, or the checksum, ETag or commit of other artifacts
+	Digest string `yaml:"digest,omitempty" json:"digest,omitempty"`
+	// ExtractedAt is the time of the extraction in RFC 3339 format
+	ExtractedAt string `yaml:"extractedAt" json:"extractedAt"`
+	// MetadataSHA256 is the hex SHA-256 of the extracted metadata.yaml
+	MetadataSHA256 string `yaml:"metadataSha256" json:"metadataSha256,omitempty"`
+	// Rollback marks a digest that an earlier version had before the previous one
+	Rollback bool `yaml:"rollback,omitempty" json:"rollback,omitempty"`
+}
+
+// History lists the versions of a model, oldest first
+type History struct {
+	Ref      string    `yaml:"ref"`
+	Versions []Version `yaml:"versions"`
+}
+
+// Read returns the history of a model; a model without a history file has no versions
+func Read(output outputfs.FS, ref string) (*History, error) {
+	history := &History{Ref: ref}
+	data, err := output.ReadFile(outputfs.ModelPath(ref, FileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return history, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading version history of %s: %v", ref, err)
+	}
+	if err := yaml.Unmarshal(data, history); err != nil {
+		return nil, fmt.Errorf("error parsing version history of %s: %v", ref, err)
+	}
+	return history, nil
+}
+
+// Record adds the version of a model found by an extraction to its history. Nothing is recorded
+// when the tag, digest and metadata are those of the latest version; otherwise the added version
+// is returned, marked as a rollback when its digest is that of a version older than the latest.
+func Record(output outputfs.FS, ref, digest string, metadata []byte, now time.Time) (*Version, error) {
+	history, err := Read(output, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(metadata)
+	version := Version{
+		Tag:            tagOf(ref),
+		Digest:         digest,
+		ExtractedAt:    now.UTC().Format(time.RFC3339),
+		MetadataSHA256: hex.EncodeToString(sum[:]),
+	}
+	if n := len(history.Versions); n > 0 {
+		latest := history.Versions[n-1]
+		if latest.Tag == version.Tag && latest.Digest == version.Digest && latest.MetadataSHA256 == version.MetadataSHA256 {
+			return nil, nil
+		}
+		if digest != "" && latest.Digest != digest {
+			for _, earlier := range history.Versions[:n-1] {
+				if earlier.Digest == digest {
+					version.Rollback = true
+					break
+				}
+			}
+		}
+	}
+	history.Versions = append(history.Versions, version)
+
+	data, err := yaml.Marshal(history)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling version history of %s: %v", ref, err)
+	}
+	if err := output.MkdirAll(outputfs.ModelPath(ref), 0755); err != nil {
+		return nil, fmt.Errorf("error creating output directory of %s: %v", ref, err)
+	}
+	if err := output.WriteFile(outputfs.ModelPath(ref, FileName), data, 0644); err != nil {
+		return nil, fmt.Errorf("error writing version history of %s: %v", ref, err)
+	}
+	return &version, nil
+}
+
+// Previous returns the versions with another digest than the latest one, newest first, keeping
+// the most recent version of each digest
+func (h *History) Previous() []Version {
+	if len(h.Versions) == 0 {
+		return nil
+	}
+	seen := map[string]bool{h.Versions[len(h.Versions)-1].Digest: true}
+	var previous []Version
+	for i := len(h.Versions) - 2; i >= 0; i-- {
+		version := h.Versions[i]
+		if version.Digest == "" || seen[version.Digest] {
+			continue
+		}
+		seen[version.Digest] = true
+		previous = append(previous, version)
+	}
+	return previous
+}
+
+// tagOf returns the tag of an image reference or the revision of a HuggingFace repository
+func tagOf(ref string) string {
+	parsed, err := utils.ParseArtifactURI(ref)
+	if err != nil {
+		return ""
+	}
+	switch parsed.Scheme {
+	case utils.URISchemeOCI:
+		return parsed.Tag
+	case utils.URISchemeHF:
+		return parsed.Revision
+	default:
+		return ""
+	}
+}
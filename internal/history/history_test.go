@@ -0,0 +1,80 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+)
+
+const testRef = "registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5"
+
+func TestRecord(t *testing.T) {
+	output := outputfs.Dir(t.TempDir())
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 9, 0, 0, 0, time.UTC) }
+
+	steps := []struct {
+		digest   string
+		metadata string
+		recorded bool
+		rollback bool
+	}{
+		{"sha256:aaa", "name: granite", true, false},
+		// Unchanged versions are not recorded again
+		{"sha256:aaa", "name: granite", false, false},
+		// A metadata change of the same image is a new version
+		{"sha256:aaa", "name: granite\nlicense: apache-2.0", true, false},
+		{"sha256:bbb", "name: granite\nlicense: apache-2.0", true, false},
+		// Going back to the digest of an older version is a rollback
+		{"sha256:aaa", "name: granite\nlicense: apache-2.0", true, true},
+	}
+	for i, step := range steps {
+		version, err := Record(output, testRef, step.digest, []byte(step.metadata), day(i+1))
+		if err != nil {
+			t.Fatalf("step %d: Record() error = %v", i, err)
+		}
+		if (version != nil) != step.recorded {
+			t.Fatalf("step %d: Record() = %+v, want recorded %v", i, version, step.recorded)
+		}
+		if version != nil && version.Rollback != step.rollback {
+			t.Errorf("step %d: Rollback = %v, want %v", i, version.Rollback, step.rollback)
+		}
+	}
+
+	history, err := Read(output, testRef)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if len(history.Versions) != 4 {
+		t.Fatalf("Expected 4 versions, got %+v", history.Versions)
+	}
+	first := history.Versions[0]
+	if first.Tag != "1.5" || first.Digest != "sha256:aaa" || first.ExtractedAt != "2026-10-01T09:00:00Z" || len(first.MetadataSHA256) != 64 {
+		t.Errorf("Unexpected first version %+v", first)
+	}
+}
+
+func TestHistoryPrevious(t *testing.T) {
+	history := &History{Versions: []Version{
+		{Digest: "sha256:aaa", ExtractedAt: "2026-10-01T09:00:00Z"},
+		{Digest: "sha256:bbb", ExtractedAt: "2026-10-02T09:00:00Z"},
+		{Digest: "sha256:bbb", ExtractedAt: "2026-10-03T09:00:00Z"},
+		{Digest: "sha256:ccc", ExtractedAt: "2026-10-04T09:00:00Z"},
+		{Digest: "sha256:aaa", ExtractedAt: "2026-10-05T09:00:00Z"},
+	}}
+
+	previous := history.Previous()
+	if len(previous) != 2 || previous[0].Digest != "sha256:ccc" || previous[1].ExtractedAt != "2026-10-03T09:00:00Z" {
+		t.Errorf("Previous() = %+v, want ccc then the latest bbb", previous)
+	}
+	if got := (&History{}).Previous(); got != nil {
+		t.Errorf("Previous() of an empty history = %+v", got)
+	}
+}
+
+func TestRead_Missing(t *testing.T) {
+	history, err := Read(outputfs.Dir(t.TempDir()), testRef)
+	if err != nil || history.Ref != testRef || len(history.Versions) != 0 {
+		t.Errorf("Read() = %+v, %v, want an empty history", history, err)
+	}
+}
@@ -0,0 +1,145 @@
+// Package httpclient creates the HTTP clients of the HuggingFace, registry, GitHub and object
+// storage calls. The clients share one transport carrying the proxy, TLS and User-Agent settings
+// of the run, and record every request in the HTTP request metrics.
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/metrics"
+)
+
+// DefaultUserAgent identifies the pipeline's requests unless Options.UserAgent overrides it
+const DefaultUserAgent = "model-metadata-collection"
+
+// Options configures the transport shared by all clients
+type Options struct {
+	// Proxy is the URL of the proxy all requests go through; empty uses the HTTPS_PROXY,
+	// HTTP_PROXY and NO_PROXY environment variables
+	Proxy string
+	// CACertFile is a PEM file of certificate authorities trusted in addition to the system ones
+	CACertFile string
+	// InsecureSkipVerify disables TLS certificate verification
+	InsecureSkipVerify bool
+	// UserAgent is sent with requests that do not set their own (default DefaultUserAgent)
+	UserAgent string
+	// Metrics receives the request outcomes (default metrics.Default)
+	Metrics *metrics.Registry
+	// Transport, when set, sends the requests instead of a transport built from the options
+	// above, e.g. to record or stub requests in tests
+	Transport http.RoundTripper
+}
+
+// shared is the transport configuration in effect, swapped as a whole by Configure
+type shared struct {
+	base      http.RoundTripper
+	userAgent string
+	metrics   *metrics.Registry
+}
+
+var current atomic.Pointer[shared]
+
+func init() {
+	current.Store(&shared{
+		base:      http.DefaultTransport.(*http.Transport).Clone(),
+		userAgent: DefaultUserAgent,
+		metrics:   metrics.Default,
+	})
+}
+
+// Configure applies opts to every client, including those created before the call, so packages
+// can create their clients at initialization and main configures them after parsing flags
+func Configure(opts Options) error {
+	base := opts.Transport
+	if base == nil {
+		transport, err := NewTransport(opts)
+		if err != nil {
+			return err
+		}
+		base = transport
+	}
+	s := &shared{base: base, userAgent: opts.UserAgent, metrics: opts.Metrics}
+	if s.userAgent == "" {
+		s.userAgent = DefaultUserAgent
+	}
+	if s.metrics == nil {
+		s.metrics = metrics.Default
+	}
+	current.Store(s)
+	return nil
+}
+
+// NewTransport returns a transport with the proxy and TLS settings of opts
+func NewTransport(opts Options) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if opts.Proxy != "" {
+		proxyURL, err := url.Parse(opts.Proxy)
+		if err != nil || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", opts.Proxy)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if opts.CACertFile != "" || opts.InsecureSkipVerify {
+		tlsConfig := &tls.Config{InsecureSkipVerify: opts.InsecureSkipVerify} //nolint:gosec // explicitly requested
+		if opts.CACertFile != "" {
+			pem, err := os.ReadFile(opts.CACertFile)
+			if err != nil {
+				return nil, fmt.Errorf("error reading CA certificates: %v", err)
+			}
+			pool, err := x509.SystemCertPool()
+			if err != nil {
+				pool = x509.NewCertPool()
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificates found in %s", opts.CACertFile)
+			}
+			tlsConfig.RootCAs = pool
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+	return transport, nil
+}
+
+// New returns a client for service, the metrics label of its requests, with the given timeout
+// (0 means no timeout)
+func New(service string, timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &instrumentedTransport{service: service},
+	}
+}
+
+// instrumentedTransport sends requests through the shared transport, setting the User-Agent and
+// recording the outcome of each request
+type instrumentedTransport struct {
+	service string
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	s := current.Load()
+	if req.Header.Get("User-Agent") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("User-Agent", s.userAgent)
+	}
+
+	start := time.Now()
+	resp, err := s.base.RoundTrip(req)
+	outcome := "error"
+	if err == nil {
+		outcome = strconv.Itoa(resp.StatusCode/100) + "xx"
+	}
+	s.metrics.Add(metrics.HTTPRequests, 1, t.service, outcome)
+	s.metrics.ObserveDuration(metrics.HTTPRequestDuration, start, t.service)
+	return resp, err
+}
@@ -0,0 +1,88 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/metrics"
+)
+
+func TestClient_UserAgentAndMetrics(t *testing.T) {
+	var userAgents []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgents = append(userAgents, r.UserAgent())
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	reg := metrics.NewRegistry()
+	if err := Configure(Options{UserAgent: "catalog-test/1.0", Metrics: reg}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = Configure(Options{}) }()
+
+	client := New("test", 5*time.Second)
+	for _, path := range []string{"/", "/missing"} {
+		resp, err := client.Get(server.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	req.Header.Set("User-Agent", "custom")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+
+	if strings.Join(userAgents, ",") != "catalog-test/1.0,catalog-test/1.0,custom" {
+		t.Errorf("Unexpected User-Agent headers: %v", userAgents)
+	}
+	counts := make(map[string]float64)
+	for _, s := range reg.Snapshot() {
+		if s.Metric == metrics.HTTPRequests.Name && s.Labels["service"] == "test" {
+			counts[s.Labels["status"]] = s.Value
+		}
+	}
+	if counts["2xx"] != 2 || counts["4xx"] != 1 {
+		t.Errorf("Expected 2 2xx and 1 4xx requests, got %v", counts)
+	}
+}
+
+func TestNewTransport_InvalidOptions(t *testing.T) {
+	notPEM := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, opts := range map[string]Options{
+		"proxy without host": {Proxy: "not-a-url"},
+		"missing CA file":    {CACertFile: filepath.Join(t.TempDir(), "missing.pem")},
+		"CA file not PEM":    {CACertFile: notPEM},
+	} {
+		if _, err := NewTransport(opts); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	transport, err := NewTransport(Options{Proxy: "http://proxy.example.com:3128", InsecureSkipVerify: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxyURL, err := transport.Proxy(httptest.NewRequest(http.MethodGet, "https://huggingface.co", nil))
+	if err != nil || proxyURL == nil || proxyURL.Host != "proxy.example.com:3128" {
+		t.Errorf("Expected requests to use the proxy, got %v (err %v)", proxyURL, err)
+	}
+	if !transport.TLSClientConfig.InsecureSkipVerify {
+		t.Error("Expected TLS verification to be disabled")
+	}
+}
@@ -0,0 +1,88 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// MaxJSONResponseSize caps the response bodies read by JSONClient
+const MaxJSONResponseSize = 5 * 1024 * 1024
+
+// StatusError is returned by JSONClient for responses outside the 2xx range
+type StatusError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	// Body is the response body, up to MaxJSONResponseSize bytes
+	Body []byte
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s %s returned HTTP %d: %s", e.Method, e.Path, e.StatusCode, strings.TrimSpace(string(e.Body)))
+}
+
+// JSONClient calls a REST API exchanging JSON documents
+type JSONClient struct {
+	// BaseURL is prepended to the path of every request
+	BaseURL string
+	// Token, when set, is sent as a bearer token
+	Token string
+	// HTTP sends the requests
+	HTTP *http.Client
+}
+
+// Do sends a request with an optional JSON body and decodes the JSON response into out, unless
+// out is nil. Responses outside the 2xx range return a *StatusError.
+func (c *JSONClient) Do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	target := c.BaseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("error encoding request: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, MaxJSONResponseSize))
+	if err != nil {
+		return fmt.Errorf("error reading response of %s %s: %v", method, path, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &StatusError{Method: method, Path: path, StatusCode: resp.StatusCode, Body: data}
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("error decoding response of %s %s: %v", method, path, err)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestJSONClient_Do(t *testing.T) {
+	var gotAuth, gotContentType, gotQuery string
+	var gotBody map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/missing" {
+			http.Error(w, `{"error_code":"RESOURCE_DOES_NOT_EXIST"}`, http.StatusNotFound)
+			return
+		}
+		gotAuth = r.Header.Get("Authorization")
+		gotContentType = r.Header.Get("Content-Type")
+		gotQuery = r.URL.RawQuery
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		_, _ = w.Write([]byte(`{"id":"7"}`))
+	}))
+	defer server.Close()
+
+	client := &JSONClient{BaseURL: server.URL + "/api", Token: "secret", HTTP: New("test", 5*time.Second)}
+	var out struct {
+		ID string `json:"id"`
+	}
+	err := client.Do(context.Background(), http.MethodPost, "/models", url.Values{"name": {"granite"}}, map[string]string{"name": "granite"}, &out)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if out.ID != "7" || gotAuth != "Bearer secret" || gotContentType != "application/json" || gotQuery != "name=granite" || gotBody["name"] != "granite" {
+		t.Errorf("Unexpected exchange: out=%+v auth=%q contentType=%q query=%q body=%v", out, gotAuth, gotContentType, gotQuery, gotBody)
+	}
+
+	err = client.Do(context.Background(), http.MethodGet, "/missing", nil, nil, nil)
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound || string(statusErr.Body) != "{\"error_code\":\"RESOURCE_DOES_NOT_EXIST\"}\n" {
+		t.Errorf("Do() error = %v, want a 404 StatusError with the response body", err)
+	}
+}
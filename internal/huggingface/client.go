@@ -1,27 +1,79 @@
 package huggingface
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	neturl "net/url"
 	"os"
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/opendatahub-io/model-metadata-collection/internal/httpclient"
+	"github.com/opendatahub-io/model-metadata-collection/internal/metrics"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/errdefs"
 	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
 	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
 )
 
-// httpClient is a shared HTTP client with timeout for all HuggingFace API calls
-var httpClient = &http.Client{
-	Timeout: 30 * time.Second,
+// DefaultTimeout and DefaultRetries are the request timeout and retry count used unless
+// Options overrides them
+const (
+	DefaultTimeout = 30 * time.Second
+	DefaultRetries = 3
+)
+
+// retryBackoff is the first delay between retries without a Retry-After header; it doubles with
+// every attempt
+var retryBackoff = time.Second
+
+// Options configures a Client. The zero value talks to the HuggingFace API with the default
+// timeout and retry count.
+type Options struct {
+	// Timeout bounds every request, including reading its body (default DefaultTimeout)
+	Timeout time.Duration
+	// Retries is how often a request that fails with a network error, 429 Too Many Requests or a
+	// 5xx status is retried (default DefaultRetries); a negative value disables retries
+	Retries int
+	// SnapshotDir is the root of a pre-downloaded HuggingFace snapshot tree. When set, model
+	// details, READMEs, license files, config.json and GGUF headers are read from disk instead
+	// of the HuggingFace API (offline mode).
+	SnapshotDir string
+}
+
+// Client fetches collections and model files from HuggingFace
+type Client struct {
+	httpClient  *http.Client
+	retries     int
+	snapshotDir string
+}
+
+// NewClient creates a HuggingFace client configured by opts
+func NewClient(opts Options) *Client {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	switch {
+	case opts.Retries == 0:
+		opts.Retries = DefaultRetries
+	case opts.Retries < 0:
+		opts.Retries = 0
+	}
+	return &Client{
+		httpClient:  httpclient.New("huggingface", opts.Timeout),
+		retries:     opts.Retries,
+		snapshotDir: opts.SnapshotDir,
+	}
 }
 
 // hfToken caches the HuggingFace API token, read lazily on first use via sync.Once.
@@ -39,22 +91,101 @@ func getHFToken() string {
 	return hfToken
 }
 
+// maxConcurrentRequests bounds the HuggingFace requests in flight, so models enriched in
+// parallel stay within the API rate limits
+const maxConcurrentRequests = 4
+
+// requestSlots holds one token per HuggingFace request in flight
+var requestSlots = make(chan struct{}, maxConcurrentRequests)
+
 // doGet performs an authenticated GET request, adding the Bearer header when HF_TOKEN is set.
-func doGet(url string) (*http.Response, error) {
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+// Transient failures are retried; rate-limited requests wait for the delay the server asks for.
+// Canceling ctx aborts the request and any retry wait.
+func (c *Client) doGet(ctx context.Context, url string) (*http.Response, error) {
+	select {
+	case requestSlots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-requestSlots }()
+
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		if token := getHFToken(); token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := c.httpClient.Do(req)
+		metrics.Default.Add(metrics.HuggingFaceRequests, 1, requestOutcome(resp, err))
+		if !retryable(resp, err) || attempt >= c.retries || ctx.Err() != nil {
+			return resp, err
+		}
+		wait := retryBackoff << attempt
+		if err == nil {
+			_ = resp.Body.Close()
+			wait = retryAfter(resp, attempt)
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// retryable reports whether a request failed in a way that may succeed when it is sent again
+func retryable(resp *http.Response, err error) bool {
 	if err != nil {
-		return nil, err
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// requestOutcome classifies a HuggingFace response for the request metrics
+func requestOutcome(resp *http.Response, err error) string {
+	switch {
+	case err != nil:
+		return "error"
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return "rate_limited"
+	case resp.StatusCode == http.StatusNotFound:
+		return "not_found"
+	case resp.StatusCode < 400:
+		return "success"
+	default:
+		return "error"
+	}
+}
+
+// statusError describes a failed HuggingFace response, wrapping the errdefs failure class of rate
+// limited and unauthorized requests
+func statusError(statusCode int, format string, args ...any) error {
+	message := fmt.Sprintf(format, args...)
+	switch statusCode {
+	case http.StatusTooManyRequests:
+		return fmt.Errorf("%s: %w", message, errdefs.ErrHFRateLimited)
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%s: %w", message, errdefs.ErrUnauthorized)
+	default:
+		return errors.New(message)
 	}
-	if token := getHFToken(); token != "" {
-		req.Header.Set("Authorization", "Bearer "+token)
+}
+
+// retryAfter returns the delay requested by a response's Retry-After header in seconds, or an
+// exponential backoff starting at retryBackoff when it has none
+func retryAfter(resp *http.Response, attempt int) time.Duration {
+	if seconds, err := strconv.Atoi(strings.TrimSpace(resp.Header.Get("Retry-After"))); err == nil && seconds >= 0 {
+		return min(time.Duration(seconds)*time.Second, time.Minute)
 	}
-	return httpClient.Do(req)
+	return retryBackoff << attempt
 }
 
 // FetchCollections fetches collections from HuggingFace
-func FetchCollections() ([]types.HFCollection, error) {
+func (c *Client) FetchCollections(ctx context.Context) ([]types.HFCollection, error) {
 	// Fetch collections list from RedHatAI
-	resp, err := doGet("https://huggingface.co/api/collections?search=red-hat-ai-validated-models")
+	resp, err := c.doGet(ctx, "https://huggingface.co/api/collections?search=red-hat-ai-validated-models")
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch collections: %v", err)
 	}
@@ -75,9 +206,9 @@ func FetchCollections() ([]types.HFCollection, error) {
 }
 
 // FetchCollectionDetails fetches detailed information for a specific collection
-func FetchCollectionDetails(collectionID string) (*types.HFCollection, error) {
+func (c *Client) FetchCollectionDetails(ctx context.Context, collectionID string) (*types.HFCollection, error) {
 	url := fmt.Sprintf("https://huggingface.co/api/collections/%s", collectionID)
-	resp, err := doGet(url)
+	resp, err := c.doGet(ctx, url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch collection details: %v", err)
 	}
@@ -98,9 +229,9 @@ func FetchCollectionDetails(collectionID string) (*types.HFCollection, error) {
 }
 
 // DiscoverValidatedModelCollections finds all Red Hat AI validated model collections
-func DiscoverValidatedModelCollections() ([]string, error) {
+func (c *Client) DiscoverValidatedModelCollections(ctx context.Context) ([]string, error) {
 	// Fetch collections from RedHatAI user
-	resp, err := doGet("https://huggingface.co/api/users/RedHatAI/collections")
+	resp, err := c.doGet(ctx, "https://huggingface.co/api/users/RedHatAI/collections")
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch user collections: %v", err)
 	}
@@ -139,16 +270,20 @@ func DiscoverValidatedModelCollections() ([]string, error) {
 }
 
 // FetchModelDetails fetches detailed metadata for a specific model
-func FetchModelDetails(modelName string) (*types.HFModelDetails, error) {
+func (c *Client) FetchModelDetails(ctx context.Context, modelName string) (*types.HFModelDetails, error) {
+	if c.snapshotDir != "" {
+		return c.readSnapshotModelDetails(modelName)
+	}
+
 	url := fmt.Sprintf("https://huggingface.co/api/models/%s", modelName)
-	resp, err := doGet(url)
+	resp, err := c.doGet(ctx, url)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch model details: %v", err)
+		return nil, fmt.Errorf("failed to fetch model details: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+		return nil, statusError(resp.StatusCode, "API returned status %d", resp.StatusCode)
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -165,17 +300,47 @@ func FetchModelDetails(modelName string) (*types.HFModelDetails, error) {
 	return &details, nil
 }
 
+// FetchModelRevision fetches the metadata of a model repository at a branch, tag or commit; its
+// Sha is the commit the revision points at. An empty revision is the default branch. Snapshots
+// hold a single revision, which is returned whatever revision is asked for.
+func (c *Client) FetchModelRevision(ctx context.Context, modelName, revision string) (*types.HFModelDetails, error) {
+	if c.snapshotDir != "" || revision == "" {
+		return c.FetchModelDetails(ctx, modelName)
+	}
+
+	url := fmt.Sprintf("https://huggingface.co/api/models/%s/revision/%s", modelName, neturl.PathEscape(revision))
+	resp, err := c.doGet(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch model revision: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		return nil, statusError(resp.StatusCode, "API returned status %d for revision %s", resp.StatusCode, revision)
+	}
+
+	var details types.HFModelDetails
+	if err := json.NewDecoder(resp.Body).Decode(&details); err != nil {
+		return nil, fmt.Errorf("failed to parse model revision JSON: %v", err)
+	}
+	return &details, nil
+}
+
 // FetchReadme fetches the README content from HuggingFace
-func FetchReadme(modelName string) (string, error) {
+func (c *Client) FetchReadme(ctx context.Context, modelName string) (string, error) {
+	if c.snapshotDir != "" {
+		return c.readSnapshotReadme(modelName)
+	}
+
 	url := fmt.Sprintf("https://huggingface.co/%s/raw/main/README.md", modelName)
-	resp, err := doGet(url)
+	resp, err := c.doGet(ctx, url)
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch README: %v", err)
+		return "", fmt.Errorf("failed to fetch README: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != 200 {
-		return "", fmt.Errorf("README not found, status %d", resp.StatusCode)
+		return "", statusError(resp.StatusCode, "README not found, status %d", resp.StatusCode)
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -186,6 +351,47 @@ func FetchReadme(modelName string) (string, error) {
 	return string(body), nil
 }
 
+// licenseFileNames lists the license file names checked in a HuggingFace repository, in priority order
+var licenseFileNames = []string{"LICENSE", "LICENSE.md", "LICENSE.txt"}
+
+// FetchLicenseFile fetches the license file from a HuggingFace model repository.
+// It returns the name of the file that was found along with its content.
+func (c *Client) FetchLicenseFile(ctx context.Context, modelName string) (string, string, error) {
+	if c.snapshotDir != "" {
+		return c.readSnapshotLicenseFile(modelName)
+	}
+
+	for _, fileName := range licenseFileNames {
+		url := fmt.Sprintf("https://huggingface.co/%s/raw/main/%s", modelName, fileName)
+		resp, err := c.doGet(ctx, url)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to fetch %s: %v", fileName, err)
+		}
+
+		if resp.StatusCode != 200 {
+			_ = resp.Body.Close()
+			continue
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return "", "", fmt.Errorf("failed to read %s body: %v", fileName, err)
+		}
+
+		if strings.TrimSpace(string(body)) != "" {
+			return fileName, string(body), nil
+		}
+	}
+
+	return "", "", fmt.Errorf("no license file found for %s", modelName)
+}
+
+// LicenseFileURL returns the browsable HuggingFace URL of a license file in a model repository
+func LicenseFileURL(modelName, fileName string) string {
+	return fmt.Sprintf("https://huggingface.co/%s/blob/main/%s", modelName, fileName)
+}
+
 // GetLatestVersionIndexFile finds the latest version index file
 func GetLatestVersionIndexFile() (string, error) {
 	files, err := filepath.Glob(CollectionGlob("v*"))
@@ -349,6 +555,10 @@ type YAMLFrontmatter struct {
 	ValidatedOn    stringSlice `yaml:"validated_on"`
 	HardwareTag    stringSlice `yaml:"hardware_tag"`
 	ValidatedTasks stringSlice `yaml:"validated_tasks"`
+	Datasets       stringSlice `yaml:"datasets"`
+
+	// Evaluation results (model-index card metadata)
+	ModelIndex types.HFModelIndex `yaml:"model-index"`
 
 	// Tool-calling configuration fields (HuggingFace only)
 	ToolCallingSupported bool         `yaml:"tool_calling_supported"`
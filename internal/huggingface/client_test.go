@@ -1,16 +1,21 @@
 package huggingface
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/opendatahub-io/model-metadata-collection/pkg/errdefs"
 	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
 )
 
@@ -62,7 +67,7 @@ func TestDoGet_AuthHeader(t *testing.T) {
 			}))
 			defer srv.Close()
 
-			resp, err := doGet(srv.URL)
+			resp, err := NewClient(Options{}).doGet(context.Background(), srv.URL)
 			if err != nil {
 				t.Fatalf("doGet() error: %v", err)
 			}
@@ -82,10 +87,34 @@ func TestDoGet_AuthHeader(t *testing.T) {
 	}
 }
 
+func TestDoGet_RetriesRateLimitedRequests(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	resp, err := NewClient(Options{}).doGet(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatalf("doGet() error: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK || requests != 2 {
+		t.Errorf("got status %d after %d requests, want 200 after 2", resp.StatusCode, requests)
+	}
+}
+
 func TestFetchCollections(t *testing.T) {
 	// Test basic function structure - network calls will likely fail in test environment
 	// but we can test that the function returns an appropriate error
-	_, err := FetchCollections()
+	_, err := NewClient(Options{}).FetchCollections(context.Background())
 	if err != nil {
 		// Expected to fail due to network unavailability in tests
 		// Accept various types of network/API errors
@@ -99,7 +128,7 @@ func TestFetchCollections(t *testing.T) {
 
 func TestFetchCollectionDetails(t *testing.T) {
 	// Test with a test collection ID
-	_, err := FetchCollectionDetails("test-collection-id")
+	_, err := NewClient(Options{}).FetchCollectionDetails(context.Background(), "test-collection-id")
 	if err != nil {
 		// Expected to fail due to network unavailability in tests
 		if !strings.Contains(err.Error(), "failed to fetch collection details") {
@@ -110,7 +139,7 @@ func TestFetchCollectionDetails(t *testing.T) {
 
 func TestDiscoverValidatedModelCollections(t *testing.T) {
 	// Test basic function structure
-	_, err := DiscoverValidatedModelCollections()
+	_, err := NewClient(Options{}).DiscoverValidatedModelCollections(context.Background())
 	if err != nil {
 		// Expected to fail due to network unavailability in tests
 		// Accept various types of network/API errors
@@ -124,7 +153,7 @@ func TestDiscoverValidatedModelCollections(t *testing.T) {
 
 func TestFetchModelDetails(t *testing.T) {
 	// Test with a test model name
-	_, err := FetchModelDetails("test/model")
+	_, err := NewClient(Options{}).FetchModelDetails(context.Background(), "test/model")
 	if err != nil {
 		// Expected to fail due to network unavailability in tests
 		// Accept various types of network/API errors
@@ -137,7 +166,7 @@ func TestFetchModelDetails(t *testing.T) {
 
 func TestFetchReadme(t *testing.T) {
 	// Test with a test model name
-	_, err := FetchReadme("test/model")
+	_, err := NewClient(Options{}).FetchReadme(context.Background(), "test/model")
 	if err != nil {
 		// Expected to fail due to network unavailability in tests
 		// Accept various types of network/API errors
@@ -773,3 +802,74 @@ Content.
 		})
 	}
 }
+
+func TestDoGet_RetriesServerErrors(t *testing.T) {
+	previousBackoff := retryBackoff
+	retryBackoff = time.Millisecond
+	t.Cleanup(func() { retryBackoff = previousBackoff })
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	resp, err := NewClient(Options{Retries: 2}).doGet(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatalf("doGet() error: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusBadGateway || requests.Load() != 3 {
+		t.Errorf("got status %d after %d requests, want 502 after 3", resp.StatusCode, requests.Load())
+	}
+
+	// Without retries, a timed out request fails at once
+	requests.Store(0)
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer slow.Close()
+
+	client := NewClient(Options{Timeout: 10 * time.Millisecond, Retries: -1})
+	if _, err := client.doGet(context.Background(), slow.URL); err == nil || requests.Load() != 1 {
+		t.Errorf("expected a single timed out request, got %d requests, error %v", requests.Load(), err)
+	}
+}
+
+func TestStatusError(t *testing.T) {
+	if err := statusError(http.StatusTooManyRequests, "README not found, status %d", 429); !errors.Is(err, errdefs.ErrHFRateLimited) {
+		t.Errorf("Expected a rate limit error, got %v", err)
+	}
+	if err := statusError(http.StatusUnauthorized, "API returned status %d", 401); !errors.Is(err, errdefs.ErrUnauthorized) {
+		t.Errorf("Expected an unauthorized error, got %v", err)
+	}
+	if err := statusError(http.StatusNotFound, "README not found, status %d", 404); errdefs.Classify(err) != "" || err.Error() != "README not found, status 404" {
+		t.Errorf("Expected an unclassified error, got %v", err)
+	}
+}
+
+func TestDoGet_Canceled(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	// A canceled context stops the retries instead of waiting for the backoff
+	client := NewClient(Options{Retries: 5})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := client.doGet(ctx, srv.URL); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the deadline to be reported, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected doGet to return when the context ends, took %s", elapsed)
+	}
+	if requests.Load() == 0 {
+		t.Error("Expected the request to be sent before the deadline")
+	}
+}
@@ -1,6 +1,8 @@
 package huggingface
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -40,6 +42,20 @@ func MergedFilePath() string {
 	return filepath.Join(CollectionsDir, MergedFileName)
 }
 
+// ResolveIndexFile returns the HuggingFace index file used for enrichment: the merged index, so
+// models of all collections can be matched, or the latest version index when it does not exist.
+func ResolveIndexFile() (string, error) {
+	mergedFile := MergedFilePath()
+	if _, err := os.Stat(mergedFile); err == nil {
+		return mergedFile, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("failed to access merged index file %s: %v", mergedFile, err)
+	}
+
+	log.Printf("Warning: Merged index file not found, falling back to latest version file")
+	return GetLatestVersionIndexFile()
+}
+
 // parseVersionFromTitle extracts version from collection title using semver patterns and date patterns
 func parseVersionFromTitle(title string) string {
 	// Look for version patterns like "v1.0", "v2.1", "v1.0.0", etc.
@@ -84,18 +100,21 @@ func parseVersionFromTitle(title string) string {
 	return ""
 }
 
+// IndexEntry returns the version index entry of a HuggingFace model repository
+func IndexEntry(modelID string) types.ModelIndex {
+	return types.ModelIndex{
+		Name:       modelID,
+		URL:        fmt.Sprintf("https://huggingface.co/%s", modelID),
+		ReadmePath: fmt.Sprintf("/%s/README.md", modelID),
+	}
+}
+
 // generateVersionIndex creates an index file for a specific version
 func generateVersionIndex(collection *types.HFCollection, version string) error {
 	var models []types.ModelIndex
 
 	for _, model := range collection.Items {
-		// Create model index entry
-		modelIndex := types.ModelIndex{
-			Name:       model.ID,
-			URL:        fmt.Sprintf("https://huggingface.co/%s", model.ID),
-			ReadmePath: fmt.Sprintf("/%s/README.md", model.ID),
-		}
-		models = append(models, modelIndex)
+		models = append(models, IndexEntry(model.ID))
 	}
 
 	versionIndex := types.VersionIndex{
@@ -211,12 +230,13 @@ func generateMergedIndex() error {
 	return nil
 }
 
-// ProcessCollections processes all HuggingFace collections and generates index files
-func ProcessCollections() error {
+// ProcessCollections processes all HuggingFace collections and generates index files. It stops
+// with the context's error when ctx is canceled.
+func (c *Client) ProcessCollections(ctx context.Context) error {
 	log.Println("Discovering Red Hat AI validated model collections...")
 
 	// Try to discover collections automatically
-	collectionSlugs, err := DiscoverValidatedModelCollections()
+	collectionSlugs, err := c.DiscoverValidatedModelCollections(ctx)
 	if err != nil {
 		log.Printf("Failed to discover collections, using known collections: %v", err)
 		// Fall back to known collections - include May, September, October 2025 and January through May 2026, plus Granite Quantized and Embedding Models
@@ -243,7 +263,10 @@ func ProcessCollections() error {
 	for _, slug := range collectionSlugs {
 		log.Printf("Processing collection: %s", slug)
 
-		collection, err := FetchCollectionDetails(slug)
+		collection, err := c.FetchCollectionDetails(ctx, slug)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 		if err != nil {
 			log.Printf("Failed to fetch collection details for %s: %v", slug, err)
 			continue
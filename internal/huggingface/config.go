@@ -0,0 +1,148 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// contextLengthKeys lists the config.json fields holding a model's maximum context length, in
+// priority order; multimodal models nest them under text_config
+var contextLengthKeys = []string{"max_position_embeddings", "max_sequence_length", "seq_length", "max_seq_len", "n_positions", "n_ctx"}
+
+// FetchModelConfig fetches config.json from a HuggingFace model repository
+func (c *Client) FetchModelConfig(ctx context.Context, modelName string) ([]byte, error) {
+	if c.snapshotDir != "" {
+		modelDir, err := c.snapshotModelDir(modelName)
+		if err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(filepath.Join(modelDir, "config.json"))
+		if err != nil {
+			return nil, fmt.Errorf("config.json not found in snapshot: %v", err)
+		}
+		return data, nil
+	}
+
+	url := fmt.Sprintf("https://huggingface.co/%s/raw/main/config.json", modelName)
+	resp, err := c.doGet(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch config.json: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		return nil, statusError(resp.StatusCode, "config.json not found, status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config.json body: %v", err)
+	}
+	return body, nil
+}
+
+// ContextLengthFromConfig returns the maximum context length declared in a config.json, or 0
+func ContextLengthFromConfig(data []byte) int64 {
+	var config map[string]interface{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return 0
+	}
+	if length := contextLengthFromFields(config); length > 0 {
+		return length
+	}
+	if textConfig, ok := config["text_config"].(map[string]interface{}); ok {
+		return contextLengthFromFields(textConfig)
+	}
+	return 0
+}
+
+func contextLengthFromFields(config map[string]interface{}) int64 {
+	for _, key := range contextLengthKeys {
+		if value, ok := config[key].(float64); ok && value > 0 {
+			return int64(value)
+		}
+	}
+	return 0
+}
+
+// ParseModelConfig reads architecture details from a transformers config.json and, when given,
+// the sampling defaults from generation_config.json. Values nested under text_config (as in
+// multimodal models) are used when the top level does not set them.
+func ParseModelConfig(configData, generationConfigData []byte) (*types.ModelConfig, error) {
+	var config map[string]interface{}
+	if err := json.Unmarshal(configData, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config.json: %v", err)
+	}
+	textConfig, _ := config["text_config"].(map[string]interface{})
+	lookup := func(key string) interface{} {
+		if value, ok := config[key]; ok && value != nil {
+			return value
+		}
+		if textConfig != nil {
+			return textConfig[key]
+		}
+		return nil
+	}
+
+	modelConfig := &types.ModelConfig{
+		ModelType:             stringField(lookup("model_type")),
+		VocabSize:             intField(lookup("vocab_size")),
+		HiddenSize:            intField(lookup("hidden_size")),
+		NumHiddenLayers:       intField(lookup("num_hidden_layers")),
+		MaxPositionEmbeddings: ContextLengthFromConfig(configData),
+		TorchDtype:            stringField(lookup("torch_dtype")),
+		RopeTheta:             floatField(lookup("rope_theta")),
+	}
+	if architectures, ok := config["architectures"].([]interface{}); ok && len(architectures) > 0 {
+		modelConfig.Architecture = stringField(architectures[0])
+	}
+	if scaling, ok := lookup("rope_scaling").(map[string]interface{}); ok {
+		ropeType := stringField(scaling["rope_type"])
+		if ropeType == "" {
+			ropeType = stringField(scaling["type"])
+		}
+		modelConfig.RopeScaling = &types.RopeScaling{
+			Type:                          ropeType,
+			Factor:                        floatField(scaling["factor"]),
+			OriginalMaxPositionEmbeddings: intField(scaling["original_max_position_embeddings"]),
+		}
+	}
+
+	if len(generationConfigData) > 0 {
+		var generationConfig map[string]interface{}
+		if err := json.Unmarshal(generationConfigData, &generationConfig); err != nil {
+			return nil, fmt.Errorf("failed to parse generation_config.json: %v", err)
+		}
+		defaults := types.GenerationDefaults{
+			Temperature: floatField(generationConfig["temperature"]),
+			TopP:        floatField(generationConfig["top_p"]),
+			TopK:        intField(generationConfig["top_k"]),
+		}
+		if defaults != (types.GenerationDefaults{}) {
+			modelConfig.GenerationDefaults = &defaults
+		}
+	}
+
+	return modelConfig, nil
+}
+
+func stringField(value interface{}) string {
+	s, _ := value.(string)
+	return s
+}
+
+func floatField(value interface{}) float64 {
+	f, _ := value.(float64)
+	return f
+}
+
+func intField(value interface{}) int64 {
+	f, _ := value.(float64)
+	return int64(f)
+}
@@ -0,0 +1,101 @@
+package huggingface
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestContextLengthFromConfig(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   string
+		expected int64
+	}{
+		{
+			name:     "max_position_embeddings",
+			config:   `{"model_type": "llama", "max_position_embeddings": 131072}`,
+			expected: 131072,
+		},
+		{
+			name:     "gpt2 style n_positions",
+			config:   `{"model_type": "gpt2", "n_positions": 1024}`,
+			expected: 1024,
+		},
+		{
+			name:     "nested text_config",
+			config:   `{"model_type": "llava", "text_config": {"max_position_embeddings": 32768}}`,
+			expected: 32768,
+		},
+		{
+			name:     "no context field",
+			config:   `{"model_type": "bert"}`,
+			expected: 0,
+		},
+		{
+			name:     "invalid json",
+			config:   `not json`,
+			expected: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ContextLengthFromConfig([]byte(tc.config)); got != tc.expected {
+				t.Errorf("ContextLengthFromConfig() = %d, want %d", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestParseModelConfig(t *testing.T) {
+	config := `{
+  "architectures": ["LlamaForCausalLM"],
+  "model_type": "llama",
+  "vocab_size": 128256,
+  "hidden_size": 4096,
+  "num_hidden_layers": 32,
+  "max_position_embeddings": 131072,
+  "torch_dtype": "bfloat16",
+  "rope_theta": 500000.0,
+  "rope_scaling": {"rope_type": "llama3", "factor": 8.0, "original_max_position_embeddings": 8192}
+}`
+	generationConfig := `{"temperature": 0.6, "top_p": 0.9, "do_sample": true}`
+
+	got, err := ParseModelConfig([]byte(config), []byte(generationConfig))
+	if err != nil {
+		t.Fatalf("ParseModelConfig() error = %v", err)
+	}
+	expected := types.ModelConfig{
+		Architecture:          "LlamaForCausalLM",
+		ModelType:             "llama",
+		VocabSize:             128256,
+		HiddenSize:            4096,
+		NumHiddenLayers:       32,
+		MaxPositionEmbeddings: 131072,
+		TorchDtype:            "bfloat16",
+		RopeTheta:             500000,
+		RopeScaling:           &types.RopeScaling{Type: "llama3", Factor: 8, OriginalMaxPositionEmbeddings: 8192},
+		GenerationDefaults:    &types.GenerationDefaults{Temperature: 0.6, TopP: 0.9},
+	}
+	if !reflect.DeepEqual(*got, expected) {
+		t.Errorf("ParseModelConfig() = %+v, want %+v", *got, expected)
+	}
+}
+
+func TestParseModelConfig_TextConfig(t *testing.T) {
+	config := `{"architectures": ["LlavaForConditionalGeneration"], "model_type": "llava", "text_config": {"vocab_size": 32064, "rope_theta": 10000.0}}`
+
+	got, err := ParseModelConfig([]byte(config), nil)
+	if err != nil {
+		t.Fatalf("ParseModelConfig() error = %v", err)
+	}
+	if got.ModelType != "llava" || got.VocabSize != 32064 || got.RopeTheta != 10000 || got.GenerationDefaults != nil {
+		t.Errorf("Expected top-level model type and text_config values, got %+v", *got)
+	}
+
+	if _, err := ParseModelConfig([]byte("not json"), nil); err == nil {
+		t.Error("Expected an error for invalid config.json")
+	}
+}
@@ -0,0 +1,78 @@
+package huggingface
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// ExtractEvaluations flattens model-index evaluation results into benchmark/metric/score entries.
+// Metrics without a numeric value are skipped and duplicate benchmark/metric pairs keep the first score.
+func ExtractEvaluations(modelIndex types.HFModelIndex) []types.Evaluation {
+	var evaluations []types.Evaluation
+	seen := make(map[string]bool)
+
+	for _, entry := range modelIndex {
+		for _, result := range entry.Results {
+			benchmark := strings.TrimSpace(result.Dataset.Name)
+			if benchmark == "" {
+				benchmark = strings.TrimSpace(result.Dataset.Type)
+			}
+			if benchmark == "" {
+				continue
+			}
+
+			for _, metric := range result.Metrics {
+				metricName := strings.TrimSpace(metric.Type)
+				if metricName == "" {
+					metricName = strings.TrimSpace(metric.Name)
+				}
+				score, ok := parseMetricValue(metric.Value)
+				if metricName == "" || !ok {
+					continue
+				}
+
+				key := strings.ToLower(benchmark + "|" + metricName)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+
+				evaluations = append(evaluations, types.Evaluation{
+					Benchmark: benchmark,
+					Metric:    metricName,
+					Score:     score,
+					Task:      result.Task.Type,
+					Source:    result.Source.Name,
+				})
+			}
+		}
+	}
+
+	return evaluations
+}
+
+// parseMetricValue converts a model-index metric value (number or numeric string, optionally
+// with a trailing %) to a float
+func parseMetricValue(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case string:
+		trimmed := strings.TrimSuffix(strings.TrimSpace(v), "%")
+		score, err := strconv.ParseFloat(strings.TrimSpace(trimmed), 64)
+		if err != nil {
+			return 0, false
+		}
+		return score, true
+	default:
+		return 0, false
+	}
+}
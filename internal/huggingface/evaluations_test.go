@@ -0,0 +1,93 @@
+package huggingface
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestExtractEvaluations_FromFrontmatter(t *testing.T) {
+	readme := `---
+license: apache-2.0
+model-index:
+- name: granite-3.1-8b-instruct
+  results:
+  - task:
+      type: text-generation
+    dataset:
+      name: IFEval (0-Shot)
+      type: HuggingFaceH4/ifeval
+    metrics:
+    - type: inst_level_strict_acc
+      value: 72.08
+    - type: prompt_level_strict_acc
+      value: "65.5%"
+    source:
+      name: Open LLM Leaderboard
+      url: https://huggingface.co/spaces/open-llm-leaderboard/open_llm_leaderboard
+  - task:
+      type: text-generation
+    dataset:
+      type: gsm8k
+    metrics:
+    - type: acc
+      value: not-a-number
+    - name: exact match
+      value: 44
+---
+# Model
+`
+	frontmatter, err := ExtractYAMLFrontmatter(readme)
+	if err != nil {
+		t.Fatalf("ExtractYAMLFrontmatter() error = %v", err)
+	}
+
+	expected := []types.Evaluation{
+		{Benchmark: "IFEval (0-Shot)", Metric: "inst_level_strict_acc", Score: 72.08, Task: "text-generation", Source: "Open LLM Leaderboard"},
+		{Benchmark: "IFEval (0-Shot)", Metric: "prompt_level_strict_acc", Score: 65.5, Task: "text-generation", Source: "Open LLM Leaderboard"},
+		{Benchmark: "gsm8k", Metric: "exact match", Score: 44, Task: "text-generation"},
+	}
+
+	got := ExtractEvaluations(frontmatter.ModelIndex)
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("ExtractEvaluations() = %+v, expected %+v", got, expected)
+	}
+}
+
+func TestExtractEvaluations_FromAPICardData(t *testing.T) {
+	body := `{"id": "org/model", "cardData": {"model-index": [{"name": "model", "results": [
+		{"task": {"type": "text-generation"}, "dataset": {"name": "MMLU"}, "metrics": [{"type": "acc", "value": 0.61}, {"type": "acc", "value": 0.5}]}
+	]}]}}`
+
+	var details types.HFModelDetails
+	if err := json.Unmarshal([]byte(body), &details); err != nil {
+		t.Fatalf("Failed to parse details: %v", err)
+	}
+
+	got := ExtractEvaluations(details.CardData.ModelIndex)
+	expected := []types.Evaluation{{Benchmark: "MMLU", Metric: "acc", Score: 0.61, Task: "text-generation"}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("ExtractEvaluations() = %+v, expected %+v", got, expected)
+	}
+}
+
+func TestMalformedModelIndexIsIgnored(t *testing.T) {
+	readme := "---\nlicense: mit\nmodel-index: this is not a list\n---\n# Model\n"
+	frontmatter, err := ExtractYAMLFrontmatter(readme)
+	if err != nil {
+		t.Fatalf("Malformed model-index should not fail frontmatter parsing: %v", err)
+	}
+	if frontmatter.License != "mit" || len(frontmatter.ModelIndex) != 0 {
+		t.Errorf("Unexpected frontmatter: %+v", frontmatter)
+	}
+
+	var details types.HFModelDetails
+	if err := json.Unmarshal([]byte(`{"id": "org/model", "cardData": {"model-index": {"bad": true}}}`), &details); err != nil {
+		t.Fatalf("Malformed model-index should not fail details parsing: %v", err)
+	}
+	if details.ID != "org/model" {
+		t.Errorf("Expected ID to be parsed, got %q", details.ID)
+	}
+}
@@ -0,0 +1,140 @@
+package huggingface
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/gguf"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// IsGGUFModel reports whether a HuggingFace repository distributes GGUF weights
+func (c *Client) IsGGUFModel(details *types.HFModelDetails) bool {
+	if details == nil {
+		return false
+	}
+	for _, tag := range details.Tags {
+		if strings.EqualFold(tag, "gguf") {
+			return true
+		}
+	}
+	for _, sibling := range details.Siblings {
+		if gguf.IsGGUFFile(sibling.RFilename) {
+			return true
+		}
+	}
+	return c.snapshotDir != "" && len(c.snapshotGGUFFiles(details.ID)) > 0
+}
+
+// SelectGGUFFile picks the GGUF file that best represents a model. Repositories often ship
+// several quantizations, so a file whose quantization name appears in hint (typically the
+// registry model reference) is preferred; otherwise the first file in sorted order is used,
+// which for split models is the first shard.
+func SelectGGUFFile(files []string, hint string) string {
+	var candidates []string
+	for _, file := range files {
+		if gguf.IsGGUFFile(file) {
+			candidates = append(candidates, file)
+		}
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+	sort.Strings(candidates)
+
+	lowerHint := strings.ToLower(hint)
+	for _, file := range candidates {
+		quant := strings.ToLower(gguf.QuantizationFromFileName(file))
+		if quant != "" && strings.Contains(lowerHint, quant) {
+			return file
+		}
+	}
+	return candidates[0]
+}
+
+// FetchGGUFQuantization reads the GGUF header of a model's weights and returns its quantization
+// details. Only the header is downloaded. hint is used to choose between multiple GGUF files.
+func (c *Client) FetchGGUFQuantization(ctx context.Context, details *types.HFModelDetails, hint string) (*types.QuantizationInfo, error) {
+	if c.snapshotDir != "" {
+		return c.readSnapshotGGUFQuantization(details.ID, hint)
+	}
+
+	var files []string
+	for _, sibling := range details.Siblings {
+		files = append(files, sibling.RFilename)
+	}
+	fileName := SelectGGUFFile(files, hint)
+	if fileName == "" {
+		return nil, fmt.Errorf("no GGUF file listed for %s", details.ID)
+	}
+
+	url := fmt.Sprintf("https://huggingface.co/%s/resolve/main/%s", details.ID, fileName)
+	resp, err := c.doGet(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %v", fileName, err)
+	}
+	// Closing the body early aborts the download once the header has been parsed
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		return nil, statusError(resp.StatusCode, "failed to fetch %s, status %d", fileName, resp.StatusCode)
+	}
+
+	md, err := gguf.ParseHeader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GGUF header of %s: %v", fileName, err)
+	}
+
+	return md.ToQuantizationInfo(fileName), nil
+}
+
+// snapshotGGUFFiles lists GGUF files in a model's snapshot directory, relative to that directory
+func (c *Client) snapshotGGUFFiles(modelName string) []string {
+	modelDir, err := c.snapshotModelDir(modelName)
+	if err != nil {
+		return nil
+	}
+
+	entries, err := os.ReadDir(modelDir)
+	if err != nil {
+		return nil
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && gguf.IsGGUFFile(entry.Name()) {
+			files = append(files, entry.Name())
+		}
+	}
+	return files
+}
+
+// readSnapshotGGUFQuantization parses the GGUF header of a model file in the snapshot directory
+func (c *Client) readSnapshotGGUFQuantization(modelName, hint string) (*types.QuantizationInfo, error) {
+	fileName := SelectGGUFFile(c.snapshotGGUFFiles(modelName), hint)
+	if fileName == "" {
+		return nil, fmt.Errorf("no GGUF file found in snapshot for %s", modelName)
+	}
+
+	modelDir, err := c.snapshotModelDir(modelName)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(filepath.Join(modelDir, fileName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %v", fileName, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	md, err := gguf.ParseHeader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GGUF header of %s: %v", fileName, err)
+	}
+
+	return md.ToQuantizationInfo(fileName), nil
+}
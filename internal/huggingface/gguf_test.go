@@ -0,0 +1,56 @@
+package huggingface
+
+import (
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestSelectGGUFFile(t *testing.T) {
+	files := []string{
+		"README.md",
+		"granite-8b-Q8_0.gguf",
+		"granite-8b-Q4_K_M.gguf",
+		"granite-8b-F16.gguf",
+	}
+
+	tests := []struct {
+		name     string
+		files    []string
+		hint     string
+		expected string
+	}{
+		{"matches quantization in hint", files, "registry.example.com/models/granite-8b-q4_k_m:1.0", "granite-8b-Q4_K_M.gguf"},
+		{"falls back to first sorted file", files, "registry.example.com/models/granite-8b:1.0", "granite-8b-F16.gguf"},
+		{"no GGUF files", []string{"README.md", "model.safetensors"}, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SelectGGUFFile(tt.files, tt.hint); got != tt.expected {
+				t.Errorf("SelectGGUFFile() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsGGUFModel(t *testing.T) {
+	tests := []struct {
+		name     string
+		details  *types.HFModelDetails
+		expected bool
+	}{
+		{"nil details", nil, false},
+		{"gguf tag", &types.HFModelDetails{Tags: []string{"transformers", "gguf"}}, true},
+		{"gguf sibling", &types.HFModelDetails{Siblings: []types.HFSibling{{RFilename: "model-Q4_0.gguf"}}}, true},
+		{"safetensors only", &types.HFModelDetails{Tags: []string{"safetensors"}, Siblings: []types.HFSibling{{RFilename: "model.safetensors"}}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewClient(Options{}).IsGGUFModel(tt.details); got != tt.expected {
+				t.Errorf("IsGGUFModel() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package huggingface
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// snapshotModelDir locates the directory holding the files for a model inside the snapshot tree.
+// Supported layouts (checked in order):
+//   - <dir>/<org>/<name>/                        (huggingface-cli download --local-dir <dir>/<org>/<name>)
+//   - <dir>/<org>--<name>/                       (flattened repo id)
+//   - <dir>/models--<org>--<name>/snapshots/<rev>/ (HuggingFace hub cache, latest revision wins)
+func (c *Client) snapshotModelDir(modelName string) (string, error) {
+	flatName := strings.ReplaceAll(modelName, "/", "--")
+
+	candidates := []string{
+		filepath.Join(c.snapshotDir, filepath.FromSlash(modelName)),
+		filepath.Join(c.snapshotDir, flatName),
+	}
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			return candidate, nil
+		}
+	}
+
+	revisions, _ := filepath.Glob(filepath.Join(c.snapshotDir, "models--"+flatName, "snapshots", "*"))
+	if len(revisions) > 0 {
+		// Prefer the most recently modified revision
+		sort.Slice(revisions, func(i, j int) bool {
+			infoI, errI := os.Stat(revisions[i])
+			infoJ, errJ := os.Stat(revisions[j])
+			if errI != nil || errJ != nil {
+				return revisions[i] > revisions[j]
+			}
+			return infoI.ModTime().After(infoJ.ModTime())
+		})
+		return revisions[0], nil
+	}
+
+	return "", fmt.Errorf("model %s not found in snapshot directory %s", modelName, c.snapshotDir)
+}
+
+// readSnapshotReadme reads README.md for a model from the snapshot directory
+func (c *Client) readSnapshotReadme(modelName string) (string, error) {
+	modelDir, err := c.snapshotModelDir(modelName)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := os.ReadFile(filepath.Join(modelDir, "README.md"))
+	if err != nil {
+		return "", fmt.Errorf("README not found in snapshot: %v", err)
+	}
+
+	return string(content), nil
+}
+
+// readSnapshotLicenseFile reads the first license file found for a model in the snapshot directory
+func (c *Client) readSnapshotLicenseFile(modelName string) (string, string, error) {
+	modelDir, err := c.snapshotModelDir(modelName)
+	if err != nil {
+		return "", "", err
+	}
+
+	for _, fileName := range licenseFileNames {
+		content, err := os.ReadFile(filepath.Join(modelDir, fileName))
+		if err == nil && strings.TrimSpace(string(content)) != "" {
+			return fileName, string(content), nil
+		}
+	}
+
+	return "", "", fmt.Errorf("no license file found in snapshot for %s", modelName)
+}
+
+// readSnapshotModelDetails builds model details from the snapshot directory.
+// A saved API response (model_info.json) is used verbatim when present; otherwise
+// details are derived from README.md frontmatter and config.json the same way the
+// HuggingFace API derives repository tags.
+func (c *Client) readSnapshotModelDetails(modelName string) (*types.HFModelDetails, error) {
+	modelDir, err := c.snapshotModelDir(modelName)
+	if err != nil {
+		return nil, err
+	}
+
+	if data, err := os.ReadFile(filepath.Join(modelDir, "model_info.json")); err == nil {
+		var details types.HFModelDetails
+		if err := json.Unmarshal(data, &details); err != nil {
+			return nil, fmt.Errorf("failed to parse snapshot model_info.json: %v", err)
+		}
+		return &details, nil
+	}
+
+	details := &types.HFModelDetails{ID: modelName}
+	if org, _, found := strings.Cut(modelName, "/"); found {
+		details.Author = org
+	}
+
+	var tags []string
+	if readme, err := os.ReadFile(filepath.Join(modelDir, "README.md")); err == nil {
+		if frontmatter, err := ExtractYAMLFrontmatter(string(readme)); err == nil {
+			tags = append(tags, frontmatter.Tags...)
+			tags = append(tags, frontmatter.Language...)
+			if frontmatter.PipelineTag != "" {
+				tags = append(tags, frontmatter.PipelineTag)
+			}
+			for _, dataset := range frontmatter.Datasets {
+				tags = append(tags, "dataset:"+dataset)
+			}
+			if frontmatter.License != "" {
+				details.License = frontmatter.License
+				tags = append(tags, "license:"+frontmatter.License)
+			}
+		}
+	}
+
+	if data, err := os.ReadFile(filepath.Join(modelDir, "config.json")); err == nil {
+		var modelConfig struct {
+			ModelType string `json:"model_type"`
+		}
+		if err := json.Unmarshal(data, &modelConfig); err == nil && modelConfig.ModelType != "" {
+			tags = append(tags, modelConfig.ModelType)
+		}
+	}
+
+	details.Tags = tags
+	return details, nil
+}
@@ -0,0 +1,123 @@
+package huggingface
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeSnapshotFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create snapshot directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write snapshot file: %v", err)
+	}
+}
+
+func TestSnapshotMode_FetchReadme(t *testing.T) {
+	dir := t.TempDir()
+	client := NewClient(Options{SnapshotDir: dir})
+
+	writeSnapshotFile(t, filepath.Join(dir, "RedHatAI", "model-a", "README.md"), "# Model A\n")
+	writeSnapshotFile(t, filepath.Join(dir, "RedHatAI--model-b", "README.md"), "# Model B\n")
+	writeSnapshotFile(t, filepath.Join(dir, "models--RedHatAI--model-c", "snapshots", "abc123", "README.md"), "# Model C\n")
+
+	tests := []struct {
+		modelName string
+		expected  string
+	}{
+		{"RedHatAI/model-a", "# Model A\n"},
+		{"RedHatAI/model-b", "# Model B\n"},
+		{"RedHatAI/model-c", "# Model C\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.modelName, func(t *testing.T) {
+			readme, err := client.FetchReadme(context.Background(), tt.modelName)
+			if err != nil {
+				t.Fatalf("FetchReadme() error = %v", err)
+			}
+			if readme != tt.expected {
+				t.Errorf("FetchReadme() = %q, expected %q", readme, tt.expected)
+			}
+		})
+	}
+
+	if _, err := client.FetchReadme(context.Background(), "RedHatAI/missing"); err == nil {
+		t.Error("Expected error for model missing from snapshot directory")
+	}
+}
+
+func TestSnapshotMode_FetchModelDetailsFromFiles(t *testing.T) {
+	dir := t.TempDir()
+	client := NewClient(Options{SnapshotDir: dir})
+
+	modelDir := filepath.Join(dir, "RedHatAI", "granite-test")
+	writeSnapshotFile(t, filepath.Join(modelDir, "README.md"), `---
+license: apache-2.0
+language:
+  - en
+pipeline_tag: text-generation
+tags:
+  - granite
+datasets:
+  - allenai/c4
+---
+# Granite Test
+`)
+	writeSnapshotFile(t, filepath.Join(modelDir, "config.json"), `{"model_type": "granite"}`)
+
+	details, err := client.FetchModelDetails(context.Background(), "RedHatAI/granite-test")
+	if err != nil {
+		t.Fatalf("FetchModelDetails() error = %v", err)
+	}
+
+	if details.ID != "RedHatAI/granite-test" || details.Author != "RedHatAI" {
+		t.Errorf("Unexpected ID/Author: %q/%q", details.ID, details.Author)
+	}
+	if details.License != "apache-2.0" {
+		t.Errorf("Expected license apache-2.0, got %q", details.License)
+	}
+
+	expectedTags := []string{"granite", "en", "text-generation", "dataset:allenai/c4", "license:apache-2.0", "granite"}
+	if !reflect.DeepEqual(details.Tags, expectedTags) {
+		t.Errorf("Tags = %v, expected %v", details.Tags, expectedTags)
+	}
+}
+
+func TestSnapshotMode_FetchModelDetailsFromModelInfo(t *testing.T) {
+	dir := t.TempDir()
+	client := NewClient(Options{SnapshotDir: dir})
+
+	writeSnapshotFile(t, filepath.Join(dir, "RedHatAI", "saved", "model_info.json"),
+		`{"id": "RedHatAI/saved", "author": "RedHatAI", "downloads": 42, "tags": ["license:mit"]}`)
+
+	details, err := client.FetchModelDetails(context.Background(), "RedHatAI/saved")
+	if err != nil {
+		t.Fatalf("FetchModelDetails() error = %v", err)
+	}
+	if details.Downloads != 42 || !reflect.DeepEqual(details.Tags, []string{"license:mit"}) {
+		t.Errorf("Expected details from model_info.json, got %+v", details)
+	}
+}
+
+func TestSnapshotMode_FetchModelRevision(t *testing.T) {
+	dir := t.TempDir()
+	client := NewClient(Options{SnapshotDir: dir})
+
+	writeSnapshotFile(t, filepath.Join(dir, "RedHatAI", "saved", "model_info.json"),
+		`{"id": "RedHatAI/saved", "sha": "0123abcd"}`)
+
+	// Snapshots hold a single revision
+	details, err := client.FetchModelRevision(context.Background(), "RedHatAI/saved", "v1.0")
+	if err != nil {
+		t.Fatalf("FetchModelRevision() error = %v", err)
+	}
+	if details.Sha != "0123abcd" {
+		t.Errorf("Sha = %q, want the snapshot's", details.Sha)
+	}
+}
@@ -24,19 +24,19 @@ func ParseTagsForStructuredData(tags []string) (languages []string, license stri
 		"summarization":                "text-generation",
 		"question-answering":           "question-answering",
 		"conversational":               "text-generation",
-		"text-to-speech":               "text-generation",
-		"automatic-speech-recognition": "text-generation",
+		"text-to-speech":               "text-to-audio",
+		"automatic-speech-recognition": "audio-to-text",
 		"image-classification":         "image-classification",
 		"image-to-text":                "image-to-text",
 		"text-to-image":                "text-generation",
-		"feature-extraction":           "text-generation",
+		"feature-extraction":           "text-embedding",
 		"sentence-similarity":          "sentence-similarity",
 		"zero-shot-classification":     "text-classification",
 		"token-classification":         "text-classification",
 		"fill-mask":                    "text-generation",
 		"multiple-choice":              "question-answering",
 		"table-question-answering":     "question-answering",
-		"visual-question-answering":    "question-answering",
+		"visual-question-answering":    "image-text-to-text",
 		"any-to-any":                   "any-to-any",
 		"image-text-to-text":           "image-text-to-text",
 		"image-to-image":               "image-to-image",
@@ -209,9 +209,60 @@ func FilterTagsForCleanTagList(tags []string) []string {
 			continue
 		}
 
+		// Skip dataset references (these should be in trainingDatasets field)
+		if strings.HasPrefix(lowerTag, "dataset:") {
+			continue
+		}
+
 		// Include everything else as legitimate tags
 		filteredTags = append(filteredTags, originalTag)
 	}
 
 	return filteredTags
 }
+
+// ExtractBaseModelsFromTags returns the base models referenced by "base_model:" repository tags,
+// e.g. "base_model:meta-llama/Llama-3.1-8B" or "base_model:quantized:meta-llama/Llama-3.1-8B"
+func ExtractBaseModelsFromTags(tags []string) []string {
+	var baseModels []string
+	seen := make(map[string]bool)
+
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if len(tag) <= len("base_model:") || !strings.EqualFold(tag[:len("base_model:")], "base_model:") {
+			continue
+		}
+
+		// The repository follows the last colon; a relation such as "finetune:" may precede it
+		baseModel := tag[strings.LastIndex(tag, ":")+1:]
+		if !strings.Contains(baseModel, "/") || seen[baseModel] {
+			continue
+		}
+		seen[baseModel] = true
+		baseModels = append(baseModels, baseModel)
+	}
+
+	return baseModels
+}
+
+// ExtractDatasetsFromTags returns the training datasets referenced by "dataset:" repository tags
+func ExtractDatasetsFromTags(tags []string) []string {
+	var datasets []string
+	seen := make(map[string]bool)
+
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if len(tag) <= len("dataset:") || !strings.EqualFold(tag[:len("dataset:")], "dataset:") {
+			continue
+		}
+
+		dataset := strings.TrimSpace(tag[len("dataset:"):])
+		if dataset == "" || seen[dataset] {
+			continue
+		}
+		seen[dataset] = true
+		datasets = append(datasets, dataset)
+	}
+
+	return datasets
+}
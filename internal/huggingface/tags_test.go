@@ -82,3 +82,59 @@ func TestParseTagsForStructuredData(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractDatasetsFromTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		tags     []string
+		expected []string
+	}{
+		{
+			name:     "dataset tags extracted",
+			tags:     []string{"text-generation", "dataset:HuggingFaceH4/ultrachat_200k", "dataset:openbmb/UltraFeedback"},
+			expected: []string{"HuggingFaceH4/ultrachat_200k", "openbmb/UltraFeedback"},
+		},
+		{
+			name:     "duplicates and empty values skipped",
+			tags:     []string{"dataset:allenai/c4", "Dataset:allenai/c4", "dataset:"},
+			expected: []string{"allenai/c4"},
+		},
+		{
+			name:     "no dataset tags",
+			tags:     []string{"en", "license:apache-2.0"},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			datasets := ExtractDatasetsFromTags(tt.tags)
+			if !reflect.DeepEqual(datasets, tt.expected) {
+				t.Errorf("ExtractDatasetsFromTags() = %v, expected %v", datasets, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExtractBaseModelsFromTags(t *testing.T) {
+	tags := []string{
+		"base_model:meta-llama/Llama-3.1-8B-Instruct",
+		"base_model:quantized:meta-llama/Llama-3.1-8B-Instruct",
+		"base_model:finetune:ibm-granite/granite-3.1-8b-base",
+		"base_model:quantized",
+		"license:llama3.1",
+	}
+	expected := []string{"meta-llama/Llama-3.1-8B-Instruct", "ibm-granite/granite-3.1-8b-base"}
+
+	if baseModels := ExtractBaseModelsFromTags(tags); !reflect.DeepEqual(baseModels, expected) {
+		t.Errorf("ExtractBaseModelsFromTags() = %v, expected %v", baseModels, expected)
+	}
+}
+
+func TestFilterTagsForCleanTagList_SkipsDatasets(t *testing.T) {
+	filtered := FilterTagsForCleanTagList([]string{"granite", "dataset:allenai/c4", "en"})
+	expected := []string{"granite"}
+	if !reflect.DeepEqual(filtered, expected) {
+		t.Errorf("FilterTagsForCleanTagList() = %v, expected %v", filtered, expected)
+	}
+}
@@ -0,0 +1,234 @@
+package metadata
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// BenchmarkTableSource is the Evaluation source of scores parsed from modelcard tables
+const BenchmarkTableSource = "modelcard"
+
+var (
+	// Table rows and the |---|:---:| separator under a table header
+	tableRowRegex       = regexp.MustCompile(`^\s*\|(.*)\|\s*$`)
+	tableSeparatorRegex = regexp.MustCompile(`^\s*\|?\s*:?-{3,}:?\s*(\|\s*:?-{3,}:?\s*)*\|?\s*$`)
+
+	// Benchmarks commonly reported in model cards; a table must name at least one to be read
+	knownBenchmarkRegex = regexp.MustCompile(`(?i)\b(mmlu|gsm-?8k|arc|hellaswag|winogrande|truthfulqa|humaneval|mbpp|ifeval|bbh|big-?bench|math|gpqa|musr|mt-?bench|alpacaeval|arena-?hard|drop|triviaqa|naturalquestions|piqa|boolq|openbookqa|lambada|agieval|livecodebench|aime)`)
+
+	// Leading numeric score, e.g. "68.3", "**71.2**", "54.1%" or "68.3 ± 0.4"
+	scoreRegex = regexp.MustCompile(`^[-+]?\d+(?:\.\d+)?`)
+
+	markdownEmphasisRegex = regexp.MustCompile("[*`]+")
+	markdownLinkRegex     = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+)
+
+// benchmarkHeaders name the column holding the benchmark, in order of preference
+var benchmarkHeaders = []string{"benchmark", "dataset", "evaluation", "task", "metric"}
+
+// ExtractBenchmarkTables parses markdown tables of evaluation scores from modelcard content.
+// A table is read when one column names known benchmarks (MMLU, GSM8K, ...) and a score column
+// for this model can be identified: a header marked "(this model)" or named after the model, a
+// "score"/"accuracy" header, or the only score column besides baseline and recovery columns.
+func ExtractBenchmarkTables(content, modelName string) []types.Evaluation {
+	content = codeBlockRegex.ReplaceAllString(content, "")
+	lines := strings.Split(content, "\n")
+
+	var evaluations []types.Evaluation
+	seen := make(map[string]bool)
+	for i := 0; i+1 < len(lines); i++ {
+		if !tableRowRegex.MatchString(lines[i]) || !tableSeparatorRegex.MatchString(lines[i+1]) {
+			continue
+		}
+		header := splitTableRow(lines[i])
+		var rows [][]string
+		j := i + 2
+		for ; j < len(lines) && tableRowRegex.MatchString(lines[j]); j++ {
+			rows = append(rows, splitTableRow(lines[j]))
+		}
+		i = j - 1
+
+		for _, evaluation := range parseBenchmarkTable(header, rows, modelName) {
+			key := strings.ToLower(evaluation.Benchmark + "|" + evaluation.Metric)
+			if !seen[key] {
+				seen[key] = true
+				evaluations = append(evaluations, evaluation)
+			}
+		}
+	}
+	return evaluations
+}
+
+// parseBenchmarkTable returns the evaluations of one table, or nil when it is not a benchmark table
+func parseBenchmarkTable(header []string, rows [][]string, modelName string) []types.Evaluation {
+	benchmarkCol := findBenchmarkColumn(header, rows)
+	if benchmarkCol < 0 {
+		return nil
+	}
+	metricCol := -1
+	for i, cell := range header {
+		if i != benchmarkCol && strings.EqualFold(cell, "metric") {
+			metricCol = i
+		}
+	}
+	scoreCol := findScoreColumn(header, rows, benchmarkCol, modelName)
+	if scoreCol < 0 {
+		return nil
+	}
+
+	var evaluations []types.Evaluation
+	for _, row := range rows {
+		if benchmarkCol >= len(row) || scoreCol >= len(row) {
+			continue
+		}
+		benchmark := row[benchmarkCol]
+		lower := strings.ToLower(benchmark)
+		if benchmark == "" || lower == "average" || lower == "avg" || lower == "mean" || strings.HasPrefix(lower, "average ") {
+			continue
+		}
+		score, ok := parseScore(row[scoreCol])
+		if !ok {
+			continue
+		}
+		metric := "score"
+		if metricCol >= 0 && metricCol < len(row) && row[metricCol] != "" {
+			metric = row[metricCol]
+		}
+		evaluations = append(evaluations, types.Evaluation{
+			Benchmark: benchmark,
+			Metric:    metric,
+			Score:     score,
+			Source:    BenchmarkTableSource,
+		})
+	}
+	return evaluations
+}
+
+// findBenchmarkColumn returns the column naming the benchmarks: a column with a benchmark-like
+// header or the first column, provided it mentions at least one known benchmark
+func findBenchmarkColumn(header []string, rows [][]string) int {
+	candidates := []int{}
+	for _, name := range benchmarkHeaders {
+		for i, cell := range header {
+			if strings.Contains(strings.ToLower(cell), name) {
+				candidates = append(candidates, i)
+			}
+		}
+	}
+	candidates = append(candidates, 0)
+
+	for _, col := range candidates {
+		for _, row := range rows {
+			if col < len(row) && knownBenchmarkRegex.MatchString(row[col]) {
+				return col
+			}
+		}
+	}
+	return -1
+}
+
+// findScoreColumn returns the column holding this model's scores, or -1 when it is ambiguous
+func findScoreColumn(header []string, rows [][]string, benchmarkCol int, modelName string) int {
+	var scoreCols []int
+	for i, cell := range header {
+		if i == benchmarkCol || isRecoveryHeader(cell) || !columnHasScores(rows, i) {
+			continue
+		}
+		scoreCols = append(scoreCols, i)
+	}
+	if len(scoreCols) == 0 {
+		return -1
+	}
+
+	for _, i := range scoreCols {
+		if strings.Contains(strings.ToLower(header[i]), "this model") {
+			return i
+		}
+	}
+	if shortName := modelShortName(modelName); shortName != "" {
+		for _, i := range scoreCols {
+			if strings.EqualFold(strings.TrimSpace(header[i]), shortName) {
+				return i
+			}
+		}
+	}
+	for _, i := range scoreCols {
+		lower := strings.ToLower(header[i])
+		if lower == "score" || lower == "accuracy" || lower == "result" || lower == "value" {
+			return i
+		}
+	}
+
+	// A single score column, or a baseline and this model followed by a recovery column
+	if len(scoreCols) == 1 {
+		return scoreCols[0]
+	}
+	if len(scoreCols) == 2 && hasRecoveryColumn(header) {
+		return scoreCols[1]
+	}
+	return -1
+}
+
+// modelShortName strips the organization from a model name, e.g. RedHatAI/granite -> granite
+func modelShortName(name string) string {
+	name = strings.TrimSpace(name)
+	if slash := strings.LastIndex(name, "/"); slash >= 0 {
+		name = name[slash+1:]
+	}
+	return name
+}
+
+func isRecoveryHeader(cell string) bool {
+	return strings.Contains(strings.ToLower(cell), "recovery")
+}
+
+func hasRecoveryColumn(header []string) bool {
+	for _, cell := range header {
+		if isRecoveryHeader(cell) {
+			return true
+		}
+	}
+	return false
+}
+
+func columnHasScores(rows [][]string, col int) bool {
+	for _, row := range rows {
+		if col < len(row) {
+			if _, ok := parseScore(row[col]); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseScore reads the leading number of a score cell
+func parseScore(cell string) (float64, bool) {
+	match := scoreRegex.FindString(strings.TrimSpace(cell))
+	if match == "" {
+		return 0, false
+	}
+	score, err := strconv.ParseFloat(match, 64)
+	if err != nil {
+		return 0, false
+	}
+	return score, true
+}
+
+// splitTableRow splits a markdown table row into cells with markdown emphasis and links removed
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	line = strings.TrimSuffix(line, "|")
+
+	cells := strings.Split(line, "|")
+	for i, cell := range cells {
+		cell = markdownLinkRegex.ReplaceAllString(cell, "$1")
+		cell = markdownEmphasisRegex.ReplaceAllString(cell, "")
+		cells[i] = strings.Join(strings.Fields(cell), " ")
+	}
+	return cells
+}
@@ -0,0 +1,116 @@
+package metadata
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestExtractBenchmarkTables(t *testing.T) {
+	testCases := []struct {
+		name      string
+		content   string
+		modelName string
+		expected  []types.Evaluation
+	}{
+		{
+			name: "baseline, quantized model and recovery",
+			content: `## Evaluation
+
+| Category | Metric | Llama-3.1-8B-Instruct | Llama-3.1-8B-Instruct-quantized.w4a16 | Recovery |
+|----------|--------|:---------------------:|:-------------------------------------:|:--------:|
+| OpenLLM v1 | MMLU (5-shot) | 68.3 | **67.8** | 99.3% |
+| | GSM8K (5-shot, strict-match) | 82.8 | 82.0 | 99.0% |
+| | **Average** | 75.6 | 74.9 | 99.1% |
+`,
+			modelName: "RedHatAI/Llama-3.1-8B-Instruct-quantized.w4a16",
+			expected: []types.Evaluation{
+				{Benchmark: "MMLU (5-shot)", Metric: "score", Score: 67.8, Source: "modelcard"},
+				{Benchmark: "GSM8K (5-shot, strict-match)", Metric: "score", Score: 82.0, Source: "modelcard"},
+			},
+		},
+		{
+			name: "column marked this model",
+			content: `| Benchmark | Baseline | Quantized (this model) | Other |
+|---|---|---|---|
+| ARC-Challenge | 83.4 | 83.1 | 70.0 |
+`,
+			expected: []types.Evaluation{
+				{Benchmark: "ARC-Challenge", Metric: "score", Score: 83.1, Source: "modelcard"},
+			},
+		},
+		{
+			name: "benchmark, metric and score columns",
+			content: `| Benchmark | Metric | Score |
+| --- | --- | --- |
+| [HumanEval](https://github.com/openai/human-eval) | pass@1 | 72.5% |
+| IFEval | prompt_level_strict_acc | 80.1 ± 0.4 |
+`,
+			expected: []types.Evaluation{
+				{Benchmark: "HumanEval", Metric: "pass@1", Score: 72.5, Source: "modelcard"},
+				{Benchmark: "IFEval", Metric: "prompt_level_strict_acc", Score: 80.1, Source: "modelcard"},
+			},
+		},
+		{
+			name: "column named after the model",
+			content: `| Task | granite-3.1-8b-instruct | llama-3.1-8b-instruct | qwen2.5-7b-instruct |
+|---|---|---|---|
+| MMLU | 65.5 | 68.3 | 74.2 |
+`,
+			modelName: "ibm-granite/granite-3.1-8b-instruct",
+			expected: []types.Evaluation{
+				{Benchmark: "MMLU", Metric: "score", Score: 65.5, Source: "modelcard"},
+			},
+		},
+		{
+			name: "ambiguous comparison table",
+			content: `| Task | Model A | Model B | Model C |
+|---|---|---|---|
+| MMLU | 65.5 | 68.3 | 74.2 |
+`,
+		},
+		{
+			name: "not a benchmark table",
+			content: `| GPU | Memory |
+|---|---|
+| A100 | 80 |
+`,
+		},
+		{
+			name:    "tables in code blocks are ignored",
+			content: "```\n| Benchmark | Score |\n|---|---|\n| MMLU | 65.5 |\n```\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			evaluations := ExtractBenchmarkTables(tc.content, tc.modelName)
+			if !reflect.DeepEqual(evaluations, tc.expected) {
+				t.Errorf("Expected %+v, got %+v", tc.expected, evaluations)
+			}
+		})
+	}
+}
+
+func TestExtractMetadataValues_BenchmarkTables(t *testing.T) {
+	content := []byte(`# Granite-3.1-8B-Instruct
+
+## Evaluation
+
+| Benchmark | Score |
+|---|---|
+| GSM8K | 71.2 |
+| MMLU | 65.5 |
+`)
+
+	metadata := ExtractMetadataValues(content)
+
+	expected := []types.Evaluation{
+		{Benchmark: "GSM8K", Metric: "score", Score: 71.2, Source: "modelcard"},
+		{Benchmark: "MMLU", Metric: "score", Score: 65.5, Source: "modelcard"},
+	}
+	if !reflect.DeepEqual(metadata.Evaluations, expected) {
+		t.Errorf("Expected evaluations %+v, got %+v", expected, metadata.Evaluations)
+	}
+}
@@ -0,0 +1,58 @@
+package metadata
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// "context length of 128K tokens", "Context window: 131,072", "maximum sequence length of 32k"
+	contextAfterRegex = regexp.MustCompile(`(?i)\b(?:context(?:\s+(?:length|window|size))?|(?:max(?:imum)?\s+)?sequence\s+length)\b[^.\n\d]{0,40}?(\d{1,3}(?:,\d{3})+|\d+(?:\.\d+)?)\s*([km])?\b`)
+	// "128K context", "a 32k-token context window", "131072 token context"
+	contextBeforeRegex = regexp.MustCompile(`(?i)\b(\d{1,3}(?:,\d{3})+|\d+(?:\.\d+)?)\s*([km])?[\s-]*(?:tokens?[\s-]+)?context\b`)
+)
+
+// Bounds of plausible context lengths; smaller or larger numbers are version numbers, dates or
+// parameter counts caught by the patterns
+const (
+	minContextLength = 512
+	maxContextLength = 100_000_000
+)
+
+// ExtractContextLength returns the largest context window stated in modelcard content, in tokens,
+// or 0 when none is stated. K and M suffixes are read as powers of two (128K is 131072 tokens),
+// matching how context sizes are configured.
+func ExtractContextLength(content string) int64 {
+	content = codeBlockRegex.ReplaceAllString(content, "")
+
+	var longest int64
+	for _, pattern := range []*regexp.Regexp{contextAfterRegex, contextBeforeRegex} {
+		for _, match := range pattern.FindAllStringSubmatch(content, -1) {
+			if length := parseTokenCount(match[1], match[2]); length > longest {
+				longest = length
+			}
+		}
+	}
+	return longest
+}
+
+// parseTokenCount converts a number with an optional K/M suffix to a token count within the
+// plausible context length range, or 0
+func parseTokenCount(number, suffix string) int64 {
+	value, err := strconv.ParseFloat(strings.ReplaceAll(number, ",", ""), 64)
+	if err != nil {
+		return 0
+	}
+	switch strings.ToLower(suffix) {
+	case "k":
+		value *= 1024
+	case "m":
+		value *= 1024 * 1024
+	}
+	length := int64(value)
+	if length < minContextLength || length > maxContextLength {
+		return 0
+	}
+	return length
+}
@@ -0,0 +1,60 @@
+package metadata
+
+import "testing"
+
+func TestExtractContextLength(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  string
+		expected int64
+	}{
+		{
+			name:     "context length with K suffix",
+			content:  "The model supports a context length of 128K tokens.",
+			expected: 131072,
+		},
+		{
+			name:     "context window with separators",
+			content:  "Context window: 131,072 tokens",
+			expected: 131072,
+		},
+		{
+			name:     "number before context",
+			content:  "Granite 3.1 models have a 32k-token context window.",
+			expected: 32768,
+		},
+		{
+			name:     "maximum sequence length",
+			content:  "Maximum sequence length: 8192",
+			expected: 8192,
+		},
+		{
+			name:     "largest statement wins",
+			content:  "Trained with 4K context, then extended to a 128K context length.",
+			expected: 131072,
+		},
+		{
+			name:     "version numbers are ignored",
+			content:  "Llama 3.1 context improvements are described in the paper.",
+			expected: 0,
+		},
+		{
+			name:     "code blocks are ignored",
+			content:  "```\nvllm serve model --max-model-len 4096 # 4096 context\n```",
+			expected: 0,
+		},
+		{
+			name:     "no statement",
+			content:  "# Model\n\nA helpful assistant.",
+			expected: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ExtractContextLength(tc.content); got != tc.expected {
+				t.Errorf("ExtractContextLength() = %d, want %d", got, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package metadata
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+var (
+	// Headings of the modelcard sections hardware requirements are read from. Whitespace includes
+	// ideographic and non-breaking spaces, and Chinese and Japanese headings are recognized too.
+	headingRegex         = regexp.MustCompile(`^(#{1,6})[\s\p{Zs}]+(.+?)[\s\p{Zs}]*#*[\s\p{Zs}]*$`)
+	hardwareHeadingRegex = regexp.MustCompile(`(?i)\b(hardware|gpus?|deploy(ment|ing)?|requirements?|serving|inference)\b|硬件|部署|推理|ハードウェア|デプロイ`)
+
+	// "at least 24GB of GPU memory", "Minimum VRAM: 80 GB", "requires 2x80 GiB memory"
+	gpuMemoryRegex  = regexp.MustCompile(`(?i)(\d+(?:\.\d+)?)\s*(?:GB|GiB)\b`)
+	memoryWordRegex = regexp.MustCompile(`(?i)\b(memory|vram|hbm)\b`)
+
+	// "2x H100", "4 x NVIDIA A100 80GB", "8 GPUs", "4 NVIDIA H100 GPUs", "--tensor-parallel-size 4"
+	acceleratorNames        = `(?:A100|H100|H200|B200|L40S|L40|L4|A10G|A10|T4|V100|MI300X|MI250|Gaudi\s*[23]?)`
+	acceleratorRegex        = regexp.MustCompile(`(?i)\b` + acceleratorNames + `\b`)
+	acceleratorCountRegex   = regexp.MustCompile(`(?i)\b(\d+)\s*[x×]\s*(?:NVIDIA\s+|AMD\s+|Intel\s+)?` + acceleratorNames + `\b`)
+	gpuCountRegex           = regexp.MustCompile(`(?i)\b(\d+)\s+(?:(?:NVIDIA|AMD|Intel)\s+)?(?:` + acceleratorNames + `\s+)?(?:GPUs|accelerators|cards)\b`)
+	tensorParallelSizeRegex = regexp.MustCompile(`(?i)(?:--tensor[-_]parallel[-_]size|tensor_parallel_size)[\s=]+(\d+)`)
+)
+
+// ExtractHardwareRequirements reads hardware requirements from the hardware, GPU and deployment
+// sections of modelcard content: the minimum GPU memory, the number of accelerators to deploy on
+// (including vLLM tensor parallel sizes in deployment examples) and the accelerator models named.
+// Returns nil when the card states none.
+func ExtractHardwareRequirements(content string) *types.HardwareRequirements {
+	requirements := &types.HardwareRequirements{}
+	seenAccelerators := make(map[string]bool)
+
+	for _, section := range hardwareSections(content) {
+		prose := codeBlockRegex.ReplaceAllString(section, "")
+		for _, line := range strings.Split(prose, "\n") {
+			if requirements.MinGPUMemoryGB == 0 && memoryWordRegex.MatchString(line) {
+				if match := gpuMemoryRegex.FindStringSubmatch(line); match != nil {
+					if gb, err := strconv.ParseFloat(match[1], 64); err == nil && gb > 0 {
+						requirements.MinGPUMemoryGB = gb
+					}
+				}
+			}
+			if requirements.RecommendedAcceleratorCount == 0 {
+				requirements.RecommendedAcceleratorCount = firstCount(line, acceleratorCountRegex, gpuCountRegex)
+			}
+			for _, name := range acceleratorRegex.FindAllString(line, -1) {
+				name = normalizeAcceleratorName(name)
+				if !seenAccelerators[name] {
+					seenAccelerators[name] = true
+					requirements.Accelerators = append(requirements.Accelerators, name)
+				}
+			}
+		}
+
+		// Deployment examples state the accelerator count as the vLLM tensor parallel size
+		if requirements.RecommendedAcceleratorCount == 0 {
+			requirements.RecommendedAcceleratorCount = firstCount(section, tensorParallelSizeRegex)
+		}
+	}
+
+	if requirements.IsEmpty() {
+		return nil
+	}
+	return requirements
+}
+
+// hardwareSections returns the text of the sections whose heading mentions hardware, GPUs,
+// deployment or requirements, each up to the next heading of the same or a higher level
+func hardwareSections(content string) []string {
+	var sections []string
+	var current []string
+	level := 0
+	inCode := false
+
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCode = !inCode
+		}
+		if !inCode {
+			if match := headingRegex.FindStringSubmatch(line); match != nil {
+				headingLevel := len(match[1])
+				if level > 0 && headingLevel <= level {
+					sections = append(sections, strings.Join(current, "\n"))
+					current, level = nil, 0
+				}
+				if level == 0 && hardwareHeadingRegex.MatchString(match[2]) {
+					level = headingLevel
+					continue
+				}
+			}
+		}
+		if level > 0 {
+			current = append(current, line)
+		}
+	}
+	if level > 0 {
+		sections = append(sections, strings.Join(current, "\n"))
+	}
+	return sections
+}
+
+// firstCount returns the first positive count captured by any of the patterns, or 0
+func firstCount(text string, patterns ...*regexp.Regexp) int {
+	for _, pattern := range patterns {
+		if match := pattern.FindStringSubmatch(text); match != nil {
+			if count, err := strconv.Atoi(match[1]); err == nil && count > 0 {
+				return count
+			}
+		}
+	}
+	return 0
+}
+
+// normalizeAcceleratorName upper-cases accelerator models, keeping "Gaudi" as Intel writes it
+func normalizeAcceleratorName(name string) string {
+	name = strings.Join(strings.Fields(name), " ")
+	if strings.HasPrefix(strings.ToLower(name), "gaudi") {
+		return "Gaudi" + strings.TrimPrefix(strings.ToLower(name), "gaudi")
+	}
+	return strings.ToUpper(name)
+}
@@ -0,0 +1,76 @@
+package metadata
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestExtractHardwareRequirements(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  string
+		expected *types.HardwareRequirements
+	}{
+		{
+			name: "hardware section",
+			content: `# Llama-3.3-70B-Instruct
+
+## Hardware Requirements
+
+Serving the model requires at least 140 GB of GPU memory, for example 2x NVIDIA H100 80GB.
+Two A100 GPUs also work.
+
+## License
+
+Llama 3.3 Community License
+`,
+			expected: &types.HardwareRequirements{
+				MinGPUMemoryGB:              140,
+				RecommendedAcceleratorCount: 2,
+				Accelerators:                []string{"H100", "A100"},
+			},
+		},
+		{
+			name: "tensor parallel size in a deployment example",
+			content: "## Deployment\n\nThis model can be deployed efficiently using vLLM:\n\n" +
+				"```bash\nvllm serve RedHatAI/Llama-3.3-70B-Instruct-FP8-dynamic --tensor-parallel-size 4\n```\n\n" +
+				"### Red Hat AI Inference Server\n\nRuns on MI300X accelerators.\n",
+			expected: &types.HardwareRequirements{
+				RecommendedAcceleratorCount: 4,
+				Accelerators:                []string{"MI300X"},
+			},
+		},
+		{
+			name: "GPU count and VRAM",
+			content: `### GPU
+
+- Minimum VRAM: 24 GiB
+- Recommended: 8 GPUs for full context length on Gaudi 3
+`,
+			expected: &types.HardwareRequirements{
+				MinGPUMemoryGB:              24,
+				RecommendedAcceleratorCount: 8,
+				Accelerators:                []string{"Gaudi 3"},
+			},
+		},
+		{
+			name: "mentions outside hardware sections are ignored",
+			content: `## Evaluation
+
+Evaluated on 8x H100 with 640 GB of memory.
+`,
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			requirements := ExtractHardwareRequirements(tc.content)
+			if !reflect.DeepEqual(requirements, tc.expected) {
+				t.Errorf("Expected %+v, got %+v", tc.expected, requirements)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package metadata
+
+import (
+	"regexp"
+	"unicode"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// minLanguageLetters is the number of prose letters below which a card's language is not guessed
+const minLanguageLetters = 20
+
+// nonLatinShare is the weighted share of non-Latin letters from which a card counts as written in
+// that script; cards that mix English with another language are usually translations or bilingual
+const nonLatinShare = 0.3
+
+var (
+	// Inline code and URLs, which are written in ASCII whatever the card's language
+	inlineCodeRegex = regexp.MustCompile("`[^`\n]*`")
+	urlRegex        = regexp.MustCompile(`https?://\S+`)
+)
+
+// scriptLanguages maps the scripts language detection counts to ISO 639-1 codes. Han is counted as
+// Chinese unless kana show the card is Japanese.
+var scriptLanguages = []struct {
+	script   *unicode.RangeTable
+	language string
+}{
+	{unicode.Han, "zh"},
+	{unicode.Hangul, "ko"},
+	{unicode.Cyrillic, "ru"},
+	{unicode.Arabic, "ar"},
+	{unicode.Hebrew, "he"},
+	{unicode.Thai, "th"},
+	{unicode.Devanagari, "hi"},
+	{unicode.Greek, "el"},
+}
+
+// DetectCardLanguage guesses the language of a modelcard's prose, ignoring code, URLs and
+// frontmatter, by the scripts its letters are written in. Returns an ISO 639-1 code such as "zh",
+// "ja", "ko" or "ru" when a non-Latin script makes up a large share of the text, "en" for cards in
+// Latin script (other Latin-script languages are not told apart), and "" when there is too little
+// text to tell.
+func DetectCardLanguage(content string) string {
+	prose := codeBlockRegex.ReplaceAllString(utils.StripYAMLFrontmatter(content), "")
+	prose = urlRegex.ReplaceAllString(inlineCodeRegex.ReplaceAllString(prose, ""), "")
+
+	counts := make(map[string]int)
+	latin, kana := 0, 0
+	for _, r := range prose {
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			kana++
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		default:
+			for _, candidate := range scriptLanguages {
+				if unicode.Is(candidate.script, r) {
+					counts[candidate.language]++
+					break
+				}
+			}
+		}
+	}
+	if kana > 0 {
+		counts["ja"] = counts["zh"] + kana
+		delete(counts, "zh")
+	}
+
+	// A CJK character carries about as much text as a short word, so it outweighs a Latin letter
+	weighted := float64(latin)
+	best, bestWeight := "", 0.0
+	for _, language := range append([]string{"ja"}, languageCodes()...) {
+		weight := float64(counts[language])
+		if language == "zh" || language == "ja" || language == "ko" {
+			weight *= 3
+		}
+		weighted += weight
+		if weight > bestWeight {
+			best, bestWeight = language, weight
+		}
+	}
+
+	switch {
+	case weighted < minLanguageLetters:
+		return ""
+	case best != "" && bestWeight/weighted >= nonLatinShare:
+		return best
+	case latin > 0:
+		return "en"
+	default:
+		return ""
+	}
+}
+
+// languageCodes returns the languages of scriptLanguages in order
+func languageCodes() []string {
+	codes := make([]string, 0, len(scriptLanguages))
+	for _, candidate := range scriptLanguages {
+		codes = append(codes, candidate.language)
+	}
+	return codes
+}
+
+// IsNonEnglishCard reports whether a detected card language is known not to be English
+func IsNonEnglishCard(language string) bool {
+	return language != "" && language != "en"
+}
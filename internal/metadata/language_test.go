@@ -0,0 +1,45 @@
+package metadata
+
+import "testing"
+
+func TestDetectCardLanguage(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "english",
+			content:  "# Granite\n\nGranite is a family of open language models built for enterprise use.\n",
+			expected: "en",
+		},
+		{
+			name:     "chinese with english code and links",
+			content:  "# Qwen2.5\n\n通义千问是阿里云研发的大语言模型，支持多种语言。\n\n```python\nfrom transformers import AutoModelForCausalLM\n```\n\n详见 https://qwenlm.github.io/blog/qwen2.5/\n",
+			expected: "zh",
+		},
+		{
+			name:     "japanese",
+			content:  "# Model\n\nこのモデルは日本語の対話に特化した大規模言語モデルです。\n",
+			expected: "ja",
+		},
+		{
+			name:     "english card with a chinese name",
+			content:  "# Qwen\n\nQwen (通义千问) is a series of large language models developed by Alibaba Cloud for chat, code and math.\n",
+			expected: "en",
+		},
+		{
+			name:     "too short",
+			content:  "# M\n",
+			expected: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := DetectCardLanguage(tc.content); got != tc.expected {
+				t.Errorf("DetectCardLanguage() = %q, want %q", got, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package metadata
+
+import (
+	"regexp"
+	"strings"
+)
+
+// repoIDPattern matches a HuggingFace repository ID such as meta-llama/Llama-3.1-8B-Instruct;
+// baseModelTarget matches one written plainly, in brackets, or as the target of a huggingface.co link
+const (
+	repoIDPattern   = `([A-Za-z0-9][\w.-]*/[\w.-]*\w)`
+	baseModelTarget = `(?:\[[^\]]*\]\(https?://huggingface\.co/` + repoIDPattern + `[^)]*\)|\[?` + repoIDPattern + `\]?)`
+)
+
+var (
+	// "fine-tuned from meta-llama/Llama-3.1-8B", "quantized version of [Llama](https://huggingface.co/...)"
+	lineagePhraseRegex = regexp.MustCompile(`(?i)\b(?:fine-?tuned|quantized|distilled|derived|converted|adapted|built)\s+(?:version\s+|variant\s+)?(?:from|of|on\s+top\s+of)\s+(?:the\s+)?` + baseModelTarget)
+	// "Base model: ibm-granite/granite-3.1-8b-base" or "**Base Model:** [...](https://huggingface.co/...)"
+	baseModelLineRegex = regexp.MustCompile(`(?im)^[\s>*-]*\**base[\s_-]+models?\**\s*[:：]\s*\**\s*` + baseModelTarget)
+)
+
+// ExtractBaseModels returns the HuggingFace repositories modelcard text names as the model's base,
+// from "Base model:" lines and phrases such as "fine-tuned from" or "quantized version of". Only
+// org/name repository IDs are returned, so mentions of a model family alone are ignored.
+func ExtractBaseModels(content string) []string {
+	content = codeBlockRegex.ReplaceAllString(content, "")
+
+	var baseModels []string
+	seen := make(map[string]bool)
+	for _, pattern := range []*regexp.Regexp{baseModelLineRegex, lineagePhraseRegex} {
+		for _, match := range pattern.FindAllStringSubmatch(content, -1) {
+			repoID := match[1]
+			if repoID == "" {
+				repoID = match[2]
+			}
+			repoID = strings.TrimSuffix(repoID, ".")
+			if key := strings.ToLower(repoID); !seen[key] {
+				seen[key] = true
+				baseModels = append(baseModels, repoID)
+			}
+		}
+	}
+	return baseModels
+}
@@ -0,0 +1,43 @@
+package metadata
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractBaseModels(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{
+			name:     "fine-tuned from",
+			content:  "This model was fine-tuned from meta-llama/Llama-3.1-8B on chat data.",
+			expected: []string{"meta-llama/Llama-3.1-8B"},
+		},
+		{
+			name:     "quantized version of a linked model",
+			content:  "This model is a quantized version of [Meta-Llama-3.1-8B-Instruct](https://huggingface.co/meta-llama/Meta-Llama-3.1-8B-Instruct).",
+			expected: []string{"meta-llama/Meta-Llama-3.1-8B-Instruct"},
+		},
+		{
+			name:     "base model line",
+			content:  "## Model Overview\n- **Base Model:** ibm-granite/granite-3.1-8b-base\n\nDistilled from ibm-granite/granite-3.1-8b-base.",
+			expected: []string{"ibm-granite/granite-3.1-8b-base"},
+		},
+		{
+			name:     "family names and datasets ignored",
+			content:  "Quantized version of Llama 3.1. Fine-tuned on HuggingFaceH4/ultrachat_200k.\n```\nderived from org/in-code\n```",
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ExtractBaseModels(tc.content); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("ExtractBaseModels() = %v, want %v", got, tc.expected)
+			}
+		})
+	}
+}
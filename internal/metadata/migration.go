@@ -2,23 +2,18 @@ package metadata
 
 import (
 	"fmt"
-	"os"
 	"reflect"
 	"strconv"
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
 	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
-	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
 )
 
 // LoadExistingMetadata attempts to load existing metadata from processed models
-func LoadExistingMetadata(registryModel, outputDir string) (*types.ExtractedMetadata, error) {
-	// Create sanitized directory name for the model
-	sanitizedName := utils.SanitizeManifestRef(registryModel)
-	metadataPath := fmt.Sprintf("%s/%s/models/metadata.yaml", outputDir, sanitizedName)
-
-	data, err := os.ReadFile(metadataPath)
+func LoadExistingMetadata(output outputfs.FS, registryModel string) (*types.ExtractedMetadata, error) {
+	data, err := output.ReadFile(outputfs.ModelPath(registryModel, "metadata.yaml"))
 	if err != nil {
 		return nil, err // File doesn't exist or can't read
 	}
@@ -15,7 +15,7 @@ import (
 var (
 	// Code block and title extraction
 	codeBlockRegex = regexp.MustCompile("(?s)```.*?```")
-	titleRegex     = regexp.MustCompile(`(?m)^#\s+(.+)$`)
+	titleRegex     = regexp.MustCompile(`(?m)^#[ \t\p{Zs}]+(.+)$`)
 
 	// Model name pattern matching
 	versionNumberRegex = regexp.MustCompile(`\d+[.-]\d+`)
@@ -23,9 +23,9 @@ var (
 
 	// Provider extraction patterns
 	providerPatterns = []*regexp.Regexp{
-		regexp.MustCompile(`(?i)^-?\s*\*?\*?(?:Model Developers?|Developers?|Author|Provider|Authors?):\*?\*?\s*(.+)$`),
-		regexp.MustCompile(`(?i)^-?\s*\*?\*?(?:Developed by|Created by|Made by):\*?\*?\s*(.+)$`),
-		regexp.MustCompile(`(?i)^-?\s*\*?\*?(?:Company|Organization|Team):\*?\*?\s*(.+)$`),
+		regexp.MustCompile(`(?i)^-?\s*\*?\*?(?:Model Developers?|Developers?|Author|Provider|Authors?)[:：]\*?\*?\s*(.+)$`),
+		regexp.MustCompile(`(?i)^-?\s*\*?\*?(?:Developed by|Created by|Made by)[:：]\*?\*?\s*(.+)$`),
+		regexp.MustCompile(`(?i)^-?\s*\*?\*?(?:Company|Organization|Team)[:：]\*?\*?\s*(.+)$`),
 	}
 	companyRegex = regexp.MustCompile(`(?i)(IBM|Microsoft|Meta|Google|OpenAI|Anthropic|Mistral|Neural Magic|Red Hat|Hugging Face|Facebook)\s+(?:Research|AI|Inc\.?|Corporation|Corp\.?)?`)
 
@@ -35,19 +35,19 @@ var (
 	descFallbackRegex = regexp.MustCompile(`(?s)^#[^\n]+\n\n([^\n#]+(?:\n[^\n#]+)*?)(?:\n\n|\n#|$)`)
 
 	// License extraction
-	licenseRegex     = regexp.MustCompile(`(?i)^-?\s*\*?\*?(?:License(?:\(s\))?|Licensing):\*?\*?\s*(?:\[([^\]]+)\]|\*?([A-Za-z0-9\.\-_]+)\*?)`)
+	licenseRegex     = regexp.MustCompile(`(?i)^-?\s*\*?\*?(?:License(?:\(s\))?|Licensing)[:：]\*?\*?\s*(?:\[([^\]]+)\]|\*?([A-Za-z0-9\.\-_]+)\*?)`)
 	licenseLinkRegex = regexp.MustCompile(`(?i)(?:license|licensing)[^\(]*\((https?://[^\)]+)\)`)
 
 	// Date extraction
-	releaseDateRegex = regexp.MustCompile(`(?i)^-?\s*\*?\*?(?:Release Date|Date):\*?\*?\s*([0-9]{1,2}[\/\-][0-9]{1,2}[\/\-][0-9]{4})`)
-	versionRegex     = regexp.MustCompile(`(?i)^-?\s*\*?\*?Version:\*?\*?\s*([0-9]+\.[0-9]+(?:\.[0-9]+)?)`)
+	releaseDateRegex = regexp.MustCompile(`(?i)^-?\s*\*?\*?(?:Release Date|Date)[:：]\*?\*?\s*([0-9]{1,2}[\/\-][0-9]{1,2}[\/\-][0-9]{4})`)
+	versionRegex     = regexp.MustCompile(`(?i)^-?\s*\*?\*?Version[:：]\*?\*?\s*([0-9]+\.[0-9]+(?:\.[0-9]+)?)`)
 	updateDateRegex  = regexp.MustCompile(`(?i)(?:updated?|modified|last\s+update).*?([0-9]{1,2}[\/\-][0-9]{1,2}[\/\-][0-9]{4})`)
 
 	// Task extraction
-	taskRegex = regexp.MustCompile(`(?i)^-?\s*\*?\*?(?:Intended Use Cases?|Tasks?):\*?\*?\s*(.+)$`)
+	taskRegex = regexp.MustCompile(`(?i)^-?\s*\*?\*?(?:Intended Use Cases?|Tasks?)[:：]\*?\*?\s*(.+)$`)
 
 	// Language extraction
-	supportedLangsRegex = regexp.MustCompile(`(?i)(?:(?:supported\s+languages?|languages?\s+supported):\s*([^.\n]+)|supports\s+\d+\s+languages?\s+in\s+addition\s+to\s+English:\s*([^.]+))`)
+	supportedLangsRegex = regexp.MustCompile(`(?i)(?:(?:supported\s+languages?|languages?\s+supported)[:：]\s*([^.\n]+)|supports\s+\d+\s+languages?\s+in\s+addition\s+to\s+English:\s*([^.]+))`)
 	langFallbackRegex   = regexp.MustCompile(`(?i)(?:language|languages?).*?(?:in\s+)?([A-Z][a-z]+(?:\s+and\s+[A-Z][a-z]+)*)`)
 )
 
@@ -90,25 +90,26 @@ func (s *stringSlice) UnmarshalYAML(value *yaml.Node) error {
 		*s = out
 		return nil
 	default:
-		return fmt.Errorf("validated_on: unsupported YAML node kind %v", value.Kind)
+		return fmt.Errorf("expected a string or a list of strings, got YAML node kind %v", value.Kind)
 	}
 }
 
 // ModelCardYAMLFrontmatter represents the YAML frontmatter in modelcard.md files
 type ModelCardYAMLFrontmatter struct {
-	Language    []string    `yaml:"language"`
-	BaseModel   []string    `yaml:"base_model"`
+	Language    stringSlice `yaml:"language"`
+	BaseModel   stringSlice `yaml:"base_model"`
 	PipelineTag string      `yaml:"pipeline_tag"`
 	License     string      `yaml:"license"`
 	LicenseName string      `yaml:"license_name"`
 	LicenseLink string      `yaml:"license_link"`
-	Tags        []string    `yaml:"tags"`
+	Tags        stringSlice `yaml:"tags"`
 	Name        string      `yaml:"name"`
 	Description string      `yaml:"description"`
-	Tasks       []string    `yaml:"tasks"`
+	Tasks       stringSlice `yaml:"tasks"`
 	Provider    string      `yaml:"provider"`
 	ValidatedOn stringSlice `yaml:"validated_on"`
 	HardwareTag stringSlice `yaml:"hardware_tag"`
+	Datasets    stringSlice `yaml:"datasets"`
 }
 
 // ExtractYAMLFrontmatterFromModelCard extracts YAML frontmatter from modelcard.md content
@@ -117,6 +118,9 @@ func ExtractYAMLFrontmatterFromModelCard(content string) (*ModelCardYAMLFrontmat
 		return nil, fmt.Errorf("empty modelcard content")
 	}
 
+	// Modelcards copied into images may carry a byte order mark or leading blank lines
+	content = strings.TrimLeft(strings.TrimPrefix(content, "\ufeff"), " \t\r\n")
+
 	// Check if content starts with YAML frontmatter (---)
 	if !strings.HasPrefix(content, "---") {
 		return nil, fmt.Errorf("no YAML frontmatter found")
@@ -190,7 +194,7 @@ func splitTaskString(taskStr string) []string {
 func ParseModelCardMetadata(content []byte) types.ModelMetadata {
 	contentStr := strings.ToLower(string(content))
 
-	return types.ModelMetadata{
+	flags := types.ModelMetadata{
 		Name:                     utils.ContainsMetadataField(contentStr, []string{"name:", "model name", "# "}),
 		Provider:                 utils.ContainsMetadataField(contentStr, []string{"provider:", "model developers:", "developers:", "author"}),
 		Description:              utils.ContainsMetadataField(contentStr, []string{"description:", "## model overview", "overview"}),
@@ -204,6 +208,20 @@ func ParseModelCardMetadata(content []byte) types.ModelMetadata {
 		LastUpdateTimeSinceEpoch: utils.ContainsMetadataField(contentStr, []string{"updated:", "last update", "modified:", "version:"}),
 		Artifacts:                utils.ContainsMetadataField(contentStr, []string{"artifact:", "model file", "download", "huggingface", "registry.redhat.io"}),
 	}
+
+	// Frontmatter keeps the HuggingFace card data, e.g. "language: en", that the text checks miss
+	if frontmatter, err := ExtractYAMLFrontmatterFromModelCard(string(content)); err == nil {
+		flags.Name = flags.Name || frontmatter.Name != ""
+		flags.Provider = flags.Provider || frontmatter.Provider != ""
+		flags.Description = flags.Description || frontmatter.Description != ""
+		flags.Language = flags.Language || len(frontmatter.Language) > 0
+		flags.License = flags.License || frontmatter.License != "" || frontmatter.LicenseName != ""
+		flags.LicenseLink = flags.LicenseLink || frontmatter.LicenseLink != ""
+		flags.Tags = flags.Tags || len(frontmatter.Tags) > 0
+		flags.Tasks = flags.Tasks || len(frontmatter.Tasks) > 0 || frontmatter.PipelineTag != ""
+	}
+
+	return flags
 }
 
 // ExtractMetadataValues extracts actual values from modelcard markdown content with validation
@@ -211,7 +229,7 @@ func ExtractMetadataValues(content []byte) types.ExtractedMetadata {
 	contentStr := string(content)
 	lines := strings.Split(contentStr, "\n")
 
-	metadata := types.ExtractedMetadata{}
+	metadata := types.ExtractedMetadata{CardLanguage: DetectCardLanguage(contentStr)}
 
 	// First, try to extract YAML frontmatter
 	frontmatter, err := ExtractYAMLFrontmatterFromModelCard(contentStr)
@@ -235,7 +253,7 @@ func ExtractMetadataValues(content []byte) types.ExtractedMetadata {
 
 		// Language from YAML
 		if len(frontmatter.Language) > 0 {
-			metadata.Language = frontmatter.Language
+			metadata.Language = []string(frontmatter.Language)
 		}
 
 		// License from YAML (prefer license_name if available)
@@ -259,14 +277,14 @@ func ExtractMetadataValues(content []byte) types.ExtractedMetadata {
 
 		// Tasks from YAML (prefer tasks field over pipeline_tag)
 		if len(frontmatter.Tasks) > 0 {
-			metadata.Tasks = frontmatter.Tasks
+			metadata.Tasks = []string(frontmatter.Tasks)
 		} else if frontmatter.PipelineTag != "" {
 			metadata.Tasks = []string{frontmatter.PipelineTag}
 		}
 
 		// Tags from YAML
 		if len(frontmatter.Tags) > 0 {
-			metadata.Tags = frontmatter.Tags
+			metadata.Tags = []string(frontmatter.Tags)
 		}
 
 		// ValidatedOn from YAML
@@ -278,6 +296,16 @@ func ExtractMetadataValues(content []byte) types.ExtractedMetadata {
 		if len(frontmatter.HardwareTag) > 0 {
 			metadata.HardwareTag = []string(frontmatter.HardwareTag)
 		}
+
+		// Training datasets from YAML
+		if len(frontmatter.Datasets) > 0 {
+			metadata.TrainingDatasets = []string(frontmatter.Datasets)
+		}
+
+		// Base models from YAML
+		if len(frontmatter.BaseModel) > 0 {
+			metadata.BaseModel = []string(frontmatter.BaseModel)
+		}
 	}
 
 	// Extract name from title - look for model-like headings, not code examples
@@ -381,10 +409,7 @@ func ExtractMetadataValues(content []byte) types.ExtractedMetadata {
 	}
 
 	// Readme is the content without YAML frontmatter
-	if len(content) > 0 {
-		readme := utils.StripYAMLFrontmatter(string(content))
-		metadata.Readme = &readme
-	}
+	metadata.Readme = ReadmeOptions{}.FromModelCard(contentStr)
 
 	// Extract license from structured fields (only if not already set by YAML frontmatter)
 	if metadata.License == nil {
@@ -469,6 +494,12 @@ func ExtractMetadataValues(content []byte) types.ExtractedMetadata {
 		}
 	}
 
+	// Fall back to tasks inferred from headings and phrases when the card states none
+	if len(metadata.Tasks) == 0 {
+		metadata.Tasks, _ = InferTasks(contentStr)
+	}
+	metadata.Tasks = types.NormalizeTasks(metadata.Tasks)
+
 	// Extract language from supported languages sections (only if not already set by YAML frontmatter)
 	if len(metadata.Language) == 0 {
 		if langMatch := supportedLangsRegex.FindStringSubmatch(contentStr); langMatch != nil {
@@ -482,8 +513,8 @@ func ExtractMetadataValues(content []byte) types.ExtractedMetadata {
 			if len(languages) > 0 {
 				metadata.Language = languages
 			}
-		} else {
-			// Fallback: Extract language from other structured fields
+		} else if !IsNonEnglishCard(metadata.CardLanguage) {
+			// Fallback: Extract language from other structured fields; English phrasing is assumed
 			for _, line := range lines {
 				if langMatch := langFallbackRegex.FindStringSubmatch(line); langMatch != nil {
 					langStr := utils.CleanExtractedValue(langMatch[1])
@@ -497,6 +528,32 @@ func ExtractMetadataValues(content []byte) types.ExtractedMetadata {
 		}
 	}
 
+	// Extract benchmark scores from evaluation tables
+	modelName := ""
+	if metadata.Name != nil {
+		modelName = *metadata.Name
+	}
+	metadata.Evaluations = ExtractBenchmarkTables(contentStr, modelName)
+
+	// Extract hardware requirements from hardware, GPU and deployment sections
+	metadata.HardwareRequirements = ExtractHardwareRequirements(contentStr)
+
+	// Extract base models named in the card text (only if not already set by YAML frontmatter)
+	if len(metadata.BaseModel) == 0 {
+		metadata.BaseModel = ExtractBaseModels(contentStr)
+	}
+
+	// Extract the description of the training corpus from "Training Data" sections
+	metadata.TrainingData = ExtractTrainingData(contentStr)
+
+	// Extract intended use, limitations, risks and ethical considerations sections
+	metadata.ResponsibleUse = ExtractResponsibleUse(contentStr)
+
+	// Extract the context window from statements such as "128K context length"
+	if contextLength := ExtractContextLength(contentStr); contextLength > 0 {
+		metadata.MaxContextLength = &contextLength
+	}
+
 	// Extract OCI image artifacts and model references
 	// For now, we'll extract from content but we'll populate with registry data later
 	metadata.Artifacts = []types.OCIArtifact{}
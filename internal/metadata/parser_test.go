@@ -2,6 +2,7 @@ package metadata
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
@@ -481,3 +482,105 @@ This is a test model validated on multiple platforms.
 		t.Error("Expected provider to be extracted from YAML frontmatter")
 	}
 }
+
+func TestExtractMetadataValues_TrainingDatasets(t *testing.T) {
+	t.Run("datasets list", func(t *testing.T) {
+		content := `---
+name: "Test Model"
+datasets:
+  - HuggingFaceH4/ultrachat_200k
+  - allenai/c4
+---
+# Test Model
+`
+		result := ExtractMetadataValues([]byte(content))
+
+		expected := []string{"HuggingFaceH4/ultrachat_200k", "allenai/c4"}
+		if !reflect.DeepEqual(result.TrainingDatasets, expected) {
+			t.Errorf("TrainingDatasets = %v, want %v", result.TrainingDatasets, expected)
+		}
+	})
+
+	t.Run("single dataset scalar", func(t *testing.T) {
+		content := `---
+name: "Test Model"
+datasets: allenai/c4
+---
+# Test Model
+`
+		result := ExtractMetadataValues([]byte(content))
+
+		expected := []string{"allenai/c4"}
+		if !reflect.DeepEqual(result.TrainingDatasets, expected) {
+			t.Errorf("TrainingDatasets = %v, want %v", result.TrainingDatasets, expected)
+		}
+	})
+
+	t.Run("no datasets", func(t *testing.T) {
+		result := ExtractMetadataValues([]byte("# Test Model\n"))
+
+		if len(result.TrainingDatasets) != 0 {
+			t.Errorf("Expected empty TrainingDatasets, got %v", result.TrainingDatasets)
+		}
+	})
+}
+
+func TestExtractMetadataValues_HuggingFaceFrontmatter(t *testing.T) {
+	// Frontmatter as kept in modelcards copied from HuggingFace: scalar language and base_model,
+	// a byte order mark and CRLF line endings must not prevent parsing
+	content := "\ufeff---\r\n" +
+		"license: apache-2.0\r\n" +
+		"language: en\r\n" +
+		"base_model: ibm-granite/granite-3.1-8b-instruct\r\n" +
+		"pipeline_tag: text-generation\r\n" +
+		"tags:\r\n" +
+		"  - granite\r\n" +
+		"  - int4\r\n" +
+		"---\r\n" +
+		"# granite-3.1-8b-instruct-quantized.w4a16\r\n"
+
+	result := ExtractMetadataValues([]byte(content))
+
+	if result.License == nil || *result.License != "apache-2.0" {
+		t.Errorf("License = %v, want apache-2.0", result.License)
+	}
+	if !reflect.DeepEqual(result.Language, []string{"en"}) {
+		t.Errorf("Language = %v, want [en]", result.Language)
+	}
+	if !reflect.DeepEqual(result.Tags, []string{"granite", "int4"}) {
+		t.Errorf("Tags = %v, want [granite int4]", result.Tags)
+	}
+	if !reflect.DeepEqual(result.Tasks, []string{"text-generation"}) {
+		t.Errorf("Tasks = %v, want [text-generation]", result.Tasks)
+	}
+
+	flags := ParseModelCardMetadata([]byte(content))
+	if !flags.Language || !flags.License || !flags.Tags || !flags.Tasks {
+		t.Errorf("Expected frontmatter fields to be reported present, got %+v", flags)
+	}
+}
+
+func TestExtractMetadataValues_ChineseCard(t *testing.T) {
+	content := "# Qwen2.5-7B-Instruct\n\n" +
+		"## 简介\n\n" +
+		"Qwen2.5 是通义千问系列的最新大语言模型，支持长上下文和多语言，适用于对话、代码生成和数学推理等任务。\n\n" +
+		"- **License：** apache-2.0\n\n" +
+		"##　训练数据\n\n" +
+		"模型在 18T tokens 的多语言数据上进行了预训练。\n\n" +
+		"The language model is released in China and Singapore.\n"
+
+	result := ExtractMetadataValues([]byte(content))
+
+	if result.CardLanguage != "zh" {
+		t.Errorf("CardLanguage = %q, want zh", result.CardLanguage)
+	}
+	if result.License == nil || *result.License != "apache-2.0" {
+		t.Errorf("License = %v, want apache-2.0 after a full-width colon", result.License)
+	}
+	if result.TrainingData == nil || !strings.Contains(*result.TrainingData, "18T tokens") {
+		t.Errorf("TrainingData = %v, want the section under an ideographic-space heading", result.TrainingData)
+	}
+	if len(result.Language) != 0 {
+		t.Errorf("Language = %v, want none guessed from English phrasing in a Chinese card", result.Language)
+	}
+}
@@ -0,0 +1,69 @@
+package metadata
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// DefaultMaxReadmeSize is the default cap, in bytes, on the readme kept for a model
+const DefaultMaxReadmeSize = 256 * 1024
+
+// readmeTruncatedNote ends readmes cut at the size cap
+const readmeTruncatedNote = "\n\n*README truncated at %d KB; see the model's source repository for the full text.*\n"
+
+// ReadmeOptions configures how modelcard content becomes the readme field. The zero value keeps
+// readmes, capped at DefaultMaxReadmeSize.
+type ReadmeOptions struct {
+	// Skip leaves readme fields empty
+	Skip bool
+	// MaxSize caps readmes in bytes (default DefaultMaxReadmeSize); a negative value disables the
+	// cap
+	MaxSize int
+}
+
+// Enabled reports whether readme fields are populated
+func (o ReadmeOptions) Enabled() bool {
+	return !o.Skip
+}
+
+// FromModelCard returns modelcard content for the readme field: YAML frontmatter stripped and cut
+// at the size cap. Returns nil when readmes are skipped or the content is empty.
+func (o ReadmeOptions) FromModelCard(content string) *string {
+	if o.Skip {
+		return nil
+	}
+	readme := utils.StripYAMLFrontmatter(content)
+	if strings.TrimSpace(readme) == "" {
+		return nil
+	}
+	maxSize := o.MaxSize
+	if maxSize == 0 {
+		maxSize = DefaultMaxReadmeSize
+	}
+	readme = truncateReadme(readme, maxSize)
+	return &readme
+}
+
+// truncateReadme cuts a readme longer than maxSize bytes at the last line break that leaves room
+// for the truncation note, closing a code block left open by the cut
+func truncateReadme(readme string, maxSize int) string {
+	if maxSize <= 0 || len(readme) <= maxSize {
+		return readme
+	}
+	note := fmt.Sprintf(readmeTruncatedNote, maxSize/1024)
+	limit := maxSize - len(note) - len("\n```")
+	if limit <= 0 {
+		return readme[:maxSize]
+	}
+
+	cut := readme[:limit]
+	if newline := strings.LastIndex(cut, "\n"); newline > 0 {
+		cut = cut[:newline]
+	}
+	if strings.Count(cut, "```")%2 == 1 {
+		cut += "\n```"
+	}
+	return cut + note
+}
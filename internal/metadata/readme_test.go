@@ -0,0 +1,61 @@
+package metadata
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadmeOptions_FromModelCard(t *testing.T) {
+	content := "---\nlicense: apache-2.0\n---\n# Model\n\nA model card.\n"
+	readme := ReadmeOptions{}.FromModelCard(content)
+	if readme == nil || *readme != "# Model\n\nA model card.\n" {
+		t.Errorf("Expected frontmatter to be stripped, got %v", readme)
+	}
+
+	if (ReadmeOptions{}).FromModelCard("---\nlicense: mit\n---\n") != nil {
+		t.Error("Expected no readme for a card with only frontmatter")
+	}
+
+	if (ReadmeOptions{Skip: true}).FromModelCard(content) != nil {
+		t.Error("Expected no readme when readmes are skipped")
+	}
+
+	long := "# Model\n\n" + strings.Repeat("A long model card line.\n", 20000)
+	if readme := (ReadmeOptions{}).FromModelCard(long); readme == nil || len(*readme) > DefaultMaxReadmeSize {
+		t.Error("Expected the default cap to apply")
+	}
+	if readme := (ReadmeOptions{MaxSize: -1}).FromModelCard(long); readme == nil || *readme != long {
+		t.Error("Expected a negative size to disable the cap")
+	}
+}
+
+func TestTruncateReadme(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("# Model\n\n```python\n")
+	for i := 0; i < 200; i++ {
+		b.WriteString("print('line of example code')\n")
+	}
+	b.WriteString("```\n")
+	readme := b.String()
+
+	if got := truncateReadme(readme, 0); got != readme {
+		t.Error("Expected no truncation without a cap")
+	}
+	if got := truncateReadme(readme, len(readme)); got != readme {
+		t.Error("Expected no truncation for a readme within the cap")
+	}
+
+	got := truncateReadme(readme, 2048)
+	if len(got) > 2048 {
+		t.Errorf("Expected at most 2048 bytes, got %d", len(got))
+	}
+	if !strings.Contains(got, "README truncated at 2 KB") {
+		t.Errorf("Expected truncation note, got %q", got[len(got)-120:])
+	}
+	if strings.Count(got, "```")%2 != 0 {
+		t.Error("Expected the open code block to be closed")
+	}
+	if !strings.Contains(got, "code')\n```\n") {
+		t.Error("Expected the cut to fall on a line break")
+	}
+}
@@ -0,0 +1,61 @@
+package metadata
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// responsibleSection names a field of types.ResponsibleUse
+type responsibleSection int
+
+const (
+	noResponsibleSection responsibleSection = iota
+	intendedUseSection
+	limitationsSection
+	risksSection
+	ethicsSection
+)
+
+// Headings of each responsible-AI section, checked in order so that "Ethical Considerations and
+// Limitations" counts as ethics and "Bias, Risks, and Limitations" as risks
+var responsibleHeadings = []struct {
+	section responsibleSection
+	regex   *regexp.Regexp
+}{
+	{ethicsSection, regexp.MustCompile(`(?i)\bethic(s|al)\b|\bresponsible\s+ai\b|伦理|倫理`)},
+	{risksSection, regexp.MustCompile(`(?i)\b(bias|biases|risks?)\b|风险|偏见|リスク|バイアス`)},
+	{limitationsSection, regexp.MustCompile(`(?i)\blimitations?\b|\bout[- ]of[- ]scope\b|局限|限制|制限`)},
+	{intendedUseSection, regexp.MustCompile(`(?i)\bintended\s+uses?\b|\buse\s+cases?\b|^(direct\s+|downstream\s+)?uses?$|用途|使用场景`)},
+}
+
+// ExtractResponsibleUse reads the intended use, limitations, bias/risks and ethical considerations
+// sections of modelcard content. A subsection naming another category (e.g. "### Out-of-Scope Use"
+// under "## Uses") starts that category instead. Returns nil when the card has none of these sections.
+func ExtractResponsibleUse(content string) *types.ResponsibleUse {
+	texts := collectSections(content, func(heading string) int {
+		return int(classifyResponsibleHeading(heading))
+	})
+	responsible := &types.ResponsibleUse{
+		IntendedUse:           strings.Join(texts[int(intendedUseSection)], "\n\n"),
+		Limitations:           strings.Join(texts[int(limitationsSection)], "\n\n"),
+		Risks:                 strings.Join(texts[int(risksSection)], "\n\n"),
+		EthicalConsiderations: strings.Join(texts[int(ethicsSection)], "\n\n"),
+	}
+	if responsible.IsEmpty() {
+		return nil
+	}
+	return responsible
+}
+
+// classifyResponsibleHeading returns the section a heading starts, or noResponsibleSection
+func classifyResponsibleHeading(heading string) responsibleSection {
+	heading = strings.TrimSpace(markdownEmphasisRegex.ReplaceAllString(heading, ""))
+	for _, candidate := range responsibleHeadings {
+		if candidate.regex.MatchString(heading) {
+			return candidate.section
+		}
+	}
+	return noResponsibleSection
+}
@@ -0,0 +1,82 @@
+package metadata
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func TestExtractResponsibleUse(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  string
+		expected *types.ResponsibleUse
+	}{
+		{
+			name: "huggingface template",
+			content: `# granite-3.1-8b-instruct
+
+## Uses
+
+### Direct Use
+
+Chat assistants and summarization.
+
+### Out-of-Scope Use
+
+Medical or legal advice.
+
+## Bias, Risks, and Limitations
+
+The model may produce biased output.
+
+## Training Details
+
+[More Information Needed]
+`,
+			expected: &types.ResponsibleUse{
+				IntendedUse: "### Direct Use\n\nChat assistants and summarization.",
+				Limitations: "Medical or legal advice.",
+				Risks:       "The model may produce biased output.",
+			},
+		},
+		{
+			name: "llama style sections",
+			content: `# Llama-3.1-8B-Instruct
+
+## Intended Use
+
+**Intended Use Cases** Llama 3.1 is intended for commercial and research use.
+
+## Ethical Considerations and Limitations
+
+Developers should perform safety testing.
+
+` + "```python\n# Limitations of this example\nprint(1)\n```" + `
+
+## Limitations
+
+[More Information Needed]
+`,
+			expected: &types.ResponsibleUse{
+				IntendedUse:           "**Intended Use Cases** Llama 3.1 is intended for commercial and research use.",
+				EthicalConsiderations: "Developers should perform safety testing.\n\n```python\n# Limitations of this example\nprint(1)\n```",
+			},
+		},
+		{
+			name:     "no sections",
+			content:  "# Model\n\n## Usage\n\nvllm serve model\n",
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			responsible := ExtractResponsibleUse(tc.content)
+			if !reflect.DeepEqual(responsible, tc.expected) {
+				t.Errorf("ExtractResponsibleUse() = %+v, want %+v", responsible, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package metadata
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// MaxInlineImageSize is the largest base64 data: image URI, in bytes, kept in a readme
+const MaxInlineImageSize = 8 * 1024
+
+var (
+	// Fenced code blocks and inline code spans, which sanitization leaves untouched
+	readmeCodeRegex = regexp.MustCompile("(?s)```.*?```|~~~.*?~~~|`[^`\n]+`")
+
+	htmlCommentRegex = regexp.MustCompile(`(?s)<!--.*?-->`)
+	// Scripts and styles are dropped with their content; embedding tags without a closing match
+	htmlScriptRegex = regexp.MustCompile(`(?is)<script\b[^>]*>.*?</script\s*>|<style\b[^>]*>.*?</style\s*>|<(?:script|style|iframe|object|embed)\b[^>]*>`)
+	htmlImgTagRegex = regexp.MustCompile(`(?i)<img\b[^>]*>`)
+	htmlAltRegex    = regexp.MustCompile(`(?i)\balt\s*=\s*["']([^"']*)["']`)
+	htmlBreakRegex  = regexp.MustCompile(`(?i)<br\s*/?>`)
+	htmlTagRegex    = regexp.MustCompile(`</?[a-zA-Z][a-zA-Z0-9-]*(?:\s[^<>]*)?/?>`)
+	// Anything still opening a tag, comment or processing instruction after stripping
+	htmlOpenRegex   = regexp.MustCompile(`<([a-zA-Z/!?])`)
+	blankLinesRegex = regexp.MustCompile(`\n{3,}`)
+
+	// Markdown links and images with an optional "title"; targets may contain balanced
+	// parentheses, e.g. javascript:alert(1)
+	readmeLinkRegex = regexp.MustCompile(`(!?)\[([^\]]*)\]\(\s*((?:[^()\s]|\([^()\s]*\))+)(\s+"[^"]*")?\s*\)`)
+)
+
+// SanitizeReadme makes readme markdown safe for UIs that render it: raw HTML is stripped (images
+// become their alt text, scripts and styles are dropped with their content), base64 images over
+// MaxInlineImageSize and links with other schemes than http, https and mailto become their text,
+// and relative links that were not resolved against the model's repository are unlinked.
+// Code blocks and inline code are kept as they are.
+func SanitizeReadme(readme string) string {
+	return outsideCode(readme, func(text string) string {
+		// Stripping a tag can join the text around it into a new tag, as in <scr<b>ipt>, so
+		// stripping repeats until nothing changes and any remaining tag opener is escaped
+		for {
+			stripped := stripHTML(text)
+			if stripped == text {
+				break
+			}
+			text = stripped
+		}
+		text = htmlOpenRegex.ReplaceAllString(text, "&lt;$1")
+		text = readmeLinkRegex.ReplaceAllStringFunc(text, sanitizeReadmeLink)
+		return blankLinesRegex.ReplaceAllString(text, "\n\n")
+	})
+}
+
+// stripHTML removes one layer of raw HTML from markdown text
+func stripHTML(text string) string {
+	text = htmlCommentRegex.ReplaceAllString(text, "")
+	text = htmlScriptRegex.ReplaceAllString(text, "")
+	text = htmlImgTagRegex.ReplaceAllStringFunc(text, func(tag string) string {
+		if alt := htmlAltRegex.FindStringSubmatch(tag); alt != nil {
+			return alt[1]
+		}
+		return ""
+	})
+	text = htmlBreakRegex.ReplaceAllString(text, " ")
+	return htmlTagRegex.ReplaceAllString(text, "")
+}
+
+// sanitizeReadmeLink keeps a markdown link or image only when its target is safe to render
+func sanitizeReadmeLink(match string) string {
+	parts := readmeLinkRegex.FindStringSubmatch(match)
+	isImage, text, href := parts[1] == "!", parts[2], parts[3]
+
+	if strings.HasPrefix(href, "#") {
+		return match
+	}
+	target, err := url.Parse(href)
+	if err != nil {
+		return text
+	}
+	switch strings.ToLower(target.Scheme) {
+	case "http", "https", "mailto":
+		return match
+	case "data":
+		if isImage && strings.HasPrefix(strings.ToLower(href), "data:image/") && len(href) <= MaxInlineImageSize {
+			return match
+		}
+	}
+	return text
+}
+
+// ResolveReadmeLinks rewrites relative markdown links in a readme to absolute URLs in the model's
+// HuggingFace repository, e.g. https://huggingface.co/org/model: links point at the file page
+// (blob/main) and images at the raw file (resolve/main) so they still render outside HuggingFace
+func ResolveReadmeLinks(readme, repoURL string) string {
+	repoURL = strings.TrimSuffix(repoURL, "/")
+	linkBase, err := url.Parse(repoURL + "/blob/main/")
+	if err != nil || repoURL == "" {
+		return readme
+	}
+	imageBase, _ := url.Parse(repoURL + "/resolve/main/")
+
+	return outsideCode(readme, func(text string) string {
+		return readmeLinkRegex.ReplaceAllStringFunc(text, func(match string) string {
+			parts := readmeLinkRegex.FindStringSubmatch(match)
+			isImage, label, href, title := parts[1], parts[2], parts[3], parts[4]
+			if strings.HasPrefix(href, "#") || strings.HasPrefix(href, "//") {
+				return match
+			}
+			ref, err := url.Parse(href)
+			if err != nil || ref.Scheme != "" {
+				return match
+			}
+			base := linkBase
+			if isImage == "!" {
+				base = imageBase
+			}
+			return isImage + "[" + label + "](" + base.ResolveReference(ref).String() + title + ")"
+		})
+	})
+}
+
+// outsideCode applies fn to the parts of markdown content outside code blocks and inline code
+func outsideCode(content string, fn func(string) string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range readmeCodeRegex.FindAllStringIndex(content, -1) {
+		b.WriteString(fn(content[last:loc[0]]))
+		b.WriteString(content[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	b.WriteString(fn(content[last:]))
+	return b.String()
+}
@@ -0,0 +1,102 @@
+package metadata
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSanitizeReadme(t *testing.T) {
+	tests := []struct {
+		name     string
+		readme   string
+		expected string
+	}{
+		{
+			name:     "script removed with content",
+			readme:   "Intro\n<script>alert('x')</script>\nBody",
+			expected: "Intro\n\nBody",
+		},
+		{
+			name:     "html stripped keeping text and alt",
+			readme:   `<p align="center"><img src="logo.png" alt="Granite logo" width="200"/></p>` + "\n<b>Bold</b> text<br>next",
+			expected: "Granite logo\nBold text next",
+		},
+		{
+			name:     "comments removed",
+			readme:   "Before<!-- hidden -->After",
+			expected: "BeforeAfter",
+		},
+		{
+			name:     "absolute and anchor links kept",
+			readme:   "[docs](https://example.com/docs) [top](#top) [mail](mailto:a@b.c)",
+			expected: "[docs](https://example.com/docs) [top](#top) [mail](mailto:a@b.c)",
+		},
+		{
+			name:     "unsafe and relative links unlinked",
+			readme:   "[click](javascript:void) [license](LICENSE) ![chart](assets/chart.png)",
+			expected: "click license chart",
+		},
+		{
+			name:     "small inline image kept",
+			readme:   "![dot](data:image/png;base64,iVBORw0KGgo=)",
+			expected: "![dot](data:image/png;base64,iVBORw0KGgo=)",
+		},
+		{
+			name:     "oversized inline image replaced",
+			readme:   "![diagram](data:image/png;base64," + strings.Repeat("A", MaxInlineImageSize) + ")",
+			expected: "diagram",
+		},
+		{
+			name:     "code untouched",
+			readme:   "Use `<|im_start|>` and:\n```html\n<script>run()</script>\n```\n<div>done</div>",
+			expected: "Use `<|im_start|>` and:\n```html\n<script>run()</script>\n```\ndone",
+		},
+		{
+			name:     "nested tags do not rebuild a script",
+			readme:   "<scr<b>ipt>alert(1)</scr<b>ipt>",
+			expected: "",
+		},
+		{
+			name:     "nested tags do not rebuild an image handler",
+			readme:   "a <<b>img src=x onerror=alert(1)> b",
+			expected: "a  b",
+		},
+		{
+			name:     "unclosed tag openers escaped",
+			readme:   "<<b>script src=x",
+			expected: "&lt;script src=x",
+		},
+		{
+			name:     "link targets with parentheses",
+			readme:   "[x](javascript:alert(1)) [wiki](https://en.wikipedia.org/wiki/Go_(language))",
+			expected: "x [wiki](https://en.wikipedia.org/wiki/Go_(language))",
+		},
+		{
+			name:     "comparisons are not tags",
+			readme:   "latency < 10ms and throughput > 5",
+			expected: "latency < 10ms and throughput > 5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeReadme(tt.readme); got != tt.expected {
+				t.Errorf("SanitizeReadme() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResolveReadmeLinks(t *testing.T) {
+	readme := "See [license](./LICENSE), [docs](https://example.com), [below](#usage) and " +
+		"![chart](assets/chart.png \"Results\").\n```\n[raw](notes.md)\n```"
+	expected := "See [license](https://huggingface.co/org/model/blob/main/LICENSE), [docs](https://example.com), [below](#usage) and " +
+		"![chart](https://huggingface.co/org/model/resolve/main/assets/chart.png \"Results\").\n```\n[raw](notes.md)\n```"
+
+	if got := ResolveReadmeLinks(readme, "https://huggingface.co/org/model"); got != expected {
+		t.Errorf("ResolveReadmeLinks() = %q, want %q", got, expected)
+	}
+	if got := ResolveReadmeLinks(readme, ""); got != readme {
+		t.Errorf("ResolveReadmeLinks() without a repository changed the readme: %q", got)
+	}
+}
@@ -0,0 +1,66 @@
+package metadata
+
+import (
+	"regexp"
+	"strings"
+)
+
+// placeholderRegex matches the "[More Information Needed]" lines of unfilled HuggingFace card templates
+var placeholderRegex = regexp.MustCompile(`(?i)^\s*\[?more information needed\]?\s*$`)
+
+// collectSections returns the text of the modelcard sections whose heading classify maps to a
+// category other than 0, keyed by category. A section runs to the next heading of the same or a
+// higher level, and a subsection heading of another category starts a section of that category.
+// Template placeholders are dropped, as are sections left empty.
+func collectSections(content string, classify func(heading string) int) map[int][]string {
+	texts := make(map[int][]string)
+	var current []string
+	category, level := 0, 0
+	inCode := false
+
+	closeSection := func() {
+		if text := sectionText(current); text != "" {
+			texts[category] = append(texts[category], text)
+		}
+		current, category, level = nil, 0, 0
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCode = !inCode
+		}
+		if !inCode {
+			if match := headingRegex.FindStringSubmatch(line); match != nil {
+				headingLevel := len(match[1])
+				if level > 0 && headingLevel <= level {
+					closeSection()
+				}
+				if heading := classify(match[2]); heading != 0 && heading != category {
+					if level > 0 {
+						closeSection()
+					}
+					category, level = heading, headingLevel
+					continue
+				}
+			}
+		}
+		if level > 0 {
+			current = append(current, line)
+		}
+	}
+	if level > 0 {
+		closeSection()
+	}
+	return texts
+}
+
+// sectionText joins the lines of a section without template placeholders and surrounding blank lines
+func sectionText(lines []string) string {
+	var kept []string
+	for _, line := range lines {
+		if !placeholderRegex.MatchString(line) {
+			kept = append(kept, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
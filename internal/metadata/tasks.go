@@ -0,0 +1,76 @@
+package metadata
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// Sources of tasks inferred from modelcard text when no task is stated. Headings naming a
+// capability ("## Chat Template", "## Embeddings") are a stronger signal than phrases in the body.
+const (
+	TaskSourceHeadings = "modelcard.headings"
+	TaskSourceInferred = "modelcard.inferred"
+)
+
+// taskRule infers a task from modelcard headings or, with lower confidence, body phrases
+type taskRule struct {
+	task     string
+	headings *regexp.Regexp
+	phrases  *regexp.Regexp
+}
+
+var taskRules = []taskRule{
+	{
+		task:     types.TaskTextGeneration,
+		headings: regexp.MustCompile(`(?i)\b(chat(?:\s+template)?|conversation(?:al)?|instruction[- ]following|prompt\s+(?:template|format)|code\s+(?:generation|completion)|coding)\b`),
+		phrases:  regexp.MustCompile(`(?i)\b(chat\s*bot|chat\s+assistant|assistant[- ]like\s+chat|instruction[- ]tuned|instruction[- ]following|conversational|code\s+(?:generation|completion))\b`),
+	},
+	{
+		task:     types.TaskTextEmbedding,
+		headings: regexp.MustCompile(`(?i)\b(embeddings?|sentence\s+similarity)\b`),
+		phrases:  regexp.MustCompile(`(?i)\b(embedding\s+model|(?:text|sentence|dense)\s+embeddings?|vector\s+representations?)\b`),
+	},
+	{
+		task:     types.TaskImageTextToText,
+		headings: regexp.MustCompile(`(?i)\b(vision|image\s+(?:understanding|inputs?)|multi-?modal|visual\s+question\s+answering)\b`),
+		phrases:  regexp.MustCompile(`(?i)\b(vision[- ]language|image\s+inputs?|images?\s+and\s+text|multi-?modal\s+(?:model|inputs?)|visual\s+question\s+answering)\b`),
+	},
+}
+
+var headingLineRegex = regexp.MustCompile(`(?m)^#{1,6}\s+(.+)$`)
+
+// InferTasks guesses tasks from modelcard content that states none. Tasks named by headings are
+// returned with TaskSourceHeadings; only when no heading matches are body phrases used, with the
+// lower-confidence TaskSourceInferred. Returns nil and "" when nothing matches.
+func InferTasks(content string) ([]string, string) {
+	content = codeBlockRegex.ReplaceAllString(content, "")
+
+	var headings []string
+	for _, match := range headingLineRegex.FindAllStringSubmatch(content, -1) {
+		headings = append(headings, match[1])
+	}
+	headingText := strings.Join(headings, "\n")
+
+	var tasks []string
+	for _, rule := range taskRules {
+		if rule.headings.MatchString(headingText) {
+			tasks = append(tasks, rule.task)
+		}
+	}
+	if len(tasks) > 0 {
+		return tasks, TaskSourceHeadings
+	}
+
+	body := headingLineRegex.ReplaceAllString(content, "")
+	for _, rule := range taskRules {
+		if rule.phrases.MatchString(body) {
+			tasks = append(tasks, rule.task)
+		}
+	}
+	if len(tasks) > 0 {
+		return tasks, TaskSourceInferred
+	}
+	return nil, ""
+}
@@ -0,0 +1,71 @@
+package metadata
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInferTasks(t *testing.T) {
+	testCases := []struct {
+		name           string
+		content        string
+		expectedTasks  []string
+		expectedSource string
+	}{
+		{
+			name:           "chat heading",
+			content:        "# Granite\n\n## Chat Template\n\nUse the template below.\n",
+			expectedTasks:  []string{"text-generation"},
+			expectedSource: TaskSourceHeadings,
+		},
+		{
+			name:           "embedding and vision headings",
+			content:        "# Model\n\n## Text Embeddings\n\n## Vision Inputs\n",
+			expectedTasks:  []string{"text-embedding", "image-text-to-text"},
+			expectedSource: TaskSourceHeadings,
+		},
+		{
+			name:           "body phrases only",
+			content:        "# Model\n\nAn embedding model that maps sentences to dense embeddings.\n",
+			expectedTasks:  []string{"text-embedding"},
+			expectedSource: TaskSourceInferred,
+		},
+		{
+			name:           "headings win over phrases",
+			content:        "# Model\n\n## Code Generation\n\nIt also accepts image inputs.\n",
+			expectedTasks:  []string{"text-generation"},
+			expectedSource: TaskSourceHeadings,
+		},
+		{
+			name:    "code blocks are ignored",
+			content: "# Model\n\n```python\n# chat with the model\nprint('conversational')\n```\n",
+		},
+		{
+			name:    "nothing to infer",
+			content: "# Model\n\n## License\n\nApache 2.0\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tasks, source := InferTasks(tc.content)
+			if !reflect.DeepEqual(tasks, tc.expectedTasks) || source != tc.expectedSource {
+				t.Errorf("InferTasks() = %v, %q; want %v, %q", tasks, source, tc.expectedTasks, tc.expectedSource)
+			}
+		})
+	}
+}
+
+func TestExtractMetadataValues_InferredTasks(t *testing.T) {
+	content := "# Granite Embedding\n\n## Sentence Similarity\n\nEncode queries and passages.\n"
+	result := ExtractMetadataValues([]byte(content))
+	if !reflect.DeepEqual(result.Tasks, []string{"text-embedding"}) {
+		t.Errorf("Tasks = %v, want [text-embedding]", result.Tasks)
+	}
+
+	stated := "# Model\n\n**Intended Use Cases:** text generation\n\n## Embeddings\n"
+	result = ExtractMetadataValues([]byte(stated))
+	if !reflect.DeepEqual(result.Tasks, []string{"text-generation"}) {
+		t.Errorf("Expected stated tasks to win over inference, got %v", result.Tasks)
+	}
+}
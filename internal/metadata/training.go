@@ -0,0 +1,26 @@
+package metadata
+
+import (
+	"regexp"
+	"strings"
+)
+
+// trainingDataHeadingRegex matches headings such as "Training Data", "Training Datasets",
+// "Pretraining Data" or "训练数据"
+var trainingDataHeadingRegex = regexp.MustCompile(`(?i)\b(pre-?)?training\s+(data|datasets?|corpus)\b|训练数据|訓練數據|训练集|学習データ|トレーニングデータ`)
+
+// ExtractTrainingData returns the markdown of the modelcard's "Training Data" sections, describing
+// the corpus the model was trained on, or nil when the card has none
+func ExtractTrainingData(content string) *string {
+	texts := collectSections(content, func(heading string) int {
+		if trainingDataHeadingRegex.MatchString(markdownEmphasisRegex.ReplaceAllString(heading, "")) {
+			return 1
+		}
+		return 0
+	})
+	if len(texts[1]) == 0 {
+		return nil
+	}
+	trainingData := strings.Join(texts[1], "\n\n")
+	return &trainingData
+}
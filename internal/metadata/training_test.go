@@ -0,0 +1,31 @@
+package metadata
+
+import "testing"
+
+func TestExtractTrainingData(t *testing.T) {
+	content := `# granite-3.1-8b-base
+
+## Training Details
+
+### Training Data
+
+Granite 3.1 was trained on **12T tokens** of open source and proprietary data.
+
+### Training Procedure
+
+[More Information Needed]
+
+## Pretraining Data
+
+Web, code and academic sources.
+`
+	trainingData := ExtractTrainingData(content)
+	expected := "Granite 3.1 was trained on **12T tokens** of open source and proprietary data.\n\nWeb, code and academic sources."
+	if trainingData == nil || *trainingData != expected {
+		t.Errorf("ExtractTrainingData() = %v, want %q", trainingData, expected)
+	}
+
+	if trainingData := ExtractTrainingData("# Model\n\n## Training Procedure\n\nSFT and DPO.\n"); trainingData != nil {
+		t.Errorf("ExtractTrainingData() = %q, want nil", *trainingData)
+	}
+}
@@ -0,0 +1,128 @@
+package metadata
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ModelTraits are size and quantization details derived heuristically from a model's name,
+// image references and modelcard
+type ModelTraits struct {
+	// Parameters is the size as written in model names, e.g. "8B", "1.5B", "135M" or "8x7B"
+	Parameters string
+	// ParameterCount is Parameters as a number, e.g. 8000000000 for "8B"
+	ParameterCount int64
+	// Quantization is the normalized quantization scheme, e.g. "W4A16", "FP8" or "INT4"
+	Quantization string
+}
+
+var (
+	// Size token delimited by name separators: "8b", "1.5B", "135M", "8x7B". Active parameter
+	// counts such as the "A3B" in Qwen3-30B-A3B are not preceded by a separator and so skipped.
+	nameParametersRegex = regexp.MustCompile(`(?i)(?:^|[-_/:.\s])((?:(\d+)x)?(\d+(?:\.\d+)?)([bm]))(?:$|[-_/:.\s])`)
+
+	// "8 billion parameters", "8B parameters", "Parameters: 8B", "Number of parameters | 70 billion"
+	cardParametersRegex = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)\b(\d+(?:\.\d+)?)\s*(billion|million|b|m)\s+(?:total\s+)?param`),
+		regexp.MustCompile(`(?i)\bparam(?:eter)?s?(?:\s+count|\s+size)?\s*[:|]\s*\**\s*(\d+(?:\.\d+)?)\s*(billion|million|b|m)\b`),
+	}
+
+	// Quantization schemes in names and image references, most specific first
+	nameQuantizationRegex = regexp.MustCompile(`(?i)(?:^|[-_/:.\s])(w\d+a\d+|nvfp4|mxfp4|fp8|fp4|int8|int4|awq|gptq|q\d_k_[sml]|q\d_k|q\d_\d)(?:$|[-_/:.\s])`)
+
+	// "quantized to FP8", "Weight quantization: INT4", "uses the W8A8 quantization scheme"
+	cardQuantizationRegex = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)quantiz\w*[^.\n]{0,60}?\b(w\d+a\d+|nvfp4|mxfp4|fp8|fp4|int8|int4|awq|gptq)\b`),
+		regexp.MustCompile(`(?i)\b(w\d+a\d+|nvfp4|mxfp4|fp8|fp4|int8|int4|awq|gptq)\b[^.\n]{0,20}?quantiz`),
+	}
+)
+
+// DetectModelTraits derives the parameter count and quantization scheme of a model. The model
+// name is the most reliable source, followed by image references (repository names such as
+// modelcar-granite-3-1-8b-instruct-quantized-w4a16) and statements in the modelcard text.
+func DetectModelTraits(name string, references []string, card string) ModelTraits {
+	var traits ModelTraits
+
+	sources := append([]string{name}, references...)
+	for _, source := range sources {
+		if traits.Parameters == "" {
+			traits.Parameters, traits.ParameterCount = parametersFromName(source)
+		}
+		if traits.Quantization == "" {
+			if match := nameQuantizationRegex.FindStringSubmatch(source); match != nil {
+				traits.Quantization = normalizeQuantization(match[1])
+			}
+		}
+	}
+
+	if card != "" {
+		card = codeBlockRegex.ReplaceAllString(card, "")
+		if traits.Parameters == "" {
+			traits.Parameters, traits.ParameterCount = parametersFromCard(card)
+		}
+		if traits.Quantization == "" {
+			for _, pattern := range cardQuantizationRegex {
+				if match := pattern.FindStringSubmatch(card); match != nil {
+					traits.Quantization = normalizeQuantization(match[1])
+					break
+				}
+			}
+		}
+	}
+
+	return traits
+}
+
+// parametersFromName returns the first size token of a model name or image reference
+func parametersFromName(name string) (string, int64) {
+	match := nameParametersRegex.FindStringSubmatch(name)
+	if match == nil {
+		return "", 0
+	}
+	experts, size, unit := match[2], match[3], strings.ToUpper(match[4])
+	count := parameterCount(size, unit)
+	if count == 0 {
+		return "", 0
+	}
+	if experts != "" {
+		n, _ := strconv.ParseInt(experts, 10, 64)
+		return experts + "x" + size + unit, n * count
+	}
+	return size + unit, count
+}
+
+// parametersFromCard returns the first parameter count stated in modelcard text
+func parametersFromCard(card string) (string, int64) {
+	for _, pattern := range cardParametersRegex {
+		match := pattern.FindStringSubmatch(card)
+		if match == nil {
+			continue
+		}
+		unit := "B"
+		if strings.HasPrefix(strings.ToLower(match[2]), "m") {
+			unit = "M"
+		}
+		if count := parameterCount(match[1], unit); count > 0 {
+			return match[1] + unit, count
+		}
+	}
+	return "", 0
+}
+
+// parameterCount converts a size with a B (billion) or M (million) unit to a count
+func parameterCount(size, unit string) int64 {
+	value, err := strconv.ParseFloat(size, 64)
+	if err != nil || value <= 0 {
+		return 0
+	}
+	if strings.EqualFold(unit, "m") {
+		return int64(value * 1e6)
+	}
+	return int64(value * 1e9)
+}
+
+// normalizeQuantization upper-cases a quantization scheme, e.g. w4a16 -> W4A16, q4_k_m -> Q4_K_M
+func normalizeQuantization(scheme string) string {
+	return strings.ToUpper(scheme)
+}
@@ -0,0 +1,80 @@
+package metadata
+
+import "testing"
+
+func TestDetectModelTraits(t *testing.T) {
+	testCases := []struct {
+		name       string
+		modelName  string
+		references []string
+		card       string
+		expected   ModelTraits
+	}{
+		{
+			name:      "size and scheme in model name",
+			modelName: "RedHatAI/Llama-3.1-8B-Instruct-quantized.w4a16",
+			expected:  ModelTraits{Parameters: "8B", ParameterCount: 8_000_000_000, Quantization: "W4A16"},
+		},
+		{
+			name:      "fractional size and fp8 dynamic",
+			modelName: "Qwen2.5-1.5B-Instruct-FP8-dynamic",
+			expected:  ModelTraits{Parameters: "1.5B", ParameterCount: 1_500_000_000, Quantization: "FP8"},
+		},
+		{
+			name:      "active parameters are ignored",
+			modelName: "Qwen3-30B-A3B",
+			expected:  ModelTraits{Parameters: "30B", ParameterCount: 30_000_000_000},
+		},
+		{
+			name:      "mixture of experts size",
+			modelName: "Mixtral-8x7B-Instruct-v0.1",
+			expected:  ModelTraits{Parameters: "8x7B", ParameterCount: 56_000_000_000},
+		},
+		{
+			name:      "millions",
+			modelName: "SmolLM2-135M-Instruct",
+			expected:  ModelTraits{Parameters: "135M", ParameterCount: 135_000_000},
+		},
+		{
+			name:       "image reference when the name has no size",
+			modelName:  "Granite 3.1 Instruct",
+			references: []string{"oci://registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct-quantized-w8a8:1.5"},
+			expected:   ModelTraits{Parameters: "8B", ParameterCount: 8_000_000_000, Quantization: "W8A8"},
+		},
+		{
+			name:      "card statements",
+			modelName: "Phi-3.5-mini-instruct",
+			card: `# Phi-3.5-mini
+
+Phi-3.5-mini has 3.8 billion parameters. The weights were quantized to INT4 with GPTQ.
+`,
+			expected: ModelTraits{Parameters: "3.8B", ParameterCount: 3_800_000_000, Quantization: "INT4"},
+		},
+		{
+			name:      "card parameter table",
+			modelName: "granite-guardian",
+			card:      "| Parameters | 70 billion |\n",
+			expected:  ModelTraits{Parameters: "70B", ParameterCount: 70_000_000_000},
+		},
+		{
+			name:      "gguf quantization type",
+			modelName: "Mistral-7B-Instruct-v0.3-Q4_K_M",
+			expected:  ModelTraits{Parameters: "7B", ParameterCount: 7_000_000_000, Quantization: "Q4_K_M"},
+		},
+		{
+			name:      "version numbers and context sizes are not sizes",
+			modelName: "gemma-3n-E4B-it",
+			card:      "Supports a 128k context window.",
+			expected:  ModelTraits{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := DetectModelTraits(tc.modelName, tc.references, tc.card)
+			if got != tc.expected {
+				t.Errorf("DetectModelTraits() = %+v, want %+v", got, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,355 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SnapshotFileName is the name of the metrics snapshot a pipeline run writes to its output
+// directory, from which the serve subcommand updates the metrics it exposes
+const SnapshotFileName = "metrics.json"
+
+// Kind is the Prometheus type of a metric
+type Kind string
+
+const (
+	Counter   Kind = "counter"
+	Gauge     Kind = "gauge"
+	Histogram Kind = "histogram"
+)
+
+// Definition describes a metric and the labels its series carry
+type Definition struct {
+	Name    string
+	Help    string
+	Kind    Kind
+	Labels  []string
+	Buckets []float64
+}
+
+var (
+	// durationBuckets suit single network requests
+	durationBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+	// refreshBuckets suit whole pipeline runs
+	refreshBuckets = []float64{60, 300, 600, 1200, 1800, 3600, 7200}
+)
+
+// Metrics of the model metadata pipeline
+var (
+	ModelsProcessed = Definition{
+		Name:   "model_catalog_models_processed_total",
+		Help:   "Models processed by pipeline runs, by outcome (modelcard, skeleton, failed).",
+		Kind:   Counter,
+		Labels: []string{"outcome"},
+	}
+	RegistryFetchDuration = Definition{
+		Name:    "model_catalog_registry_fetch_duration_seconds",
+		Help:    "Time to fetch a model image manifest and config from its container registry.",
+		Kind:    Histogram,
+		Buckets: durationBuckets,
+	}
+	HuggingFaceRequests = Definition{
+		Name:   "model_catalog_huggingface_requests_total",
+		Help:   "HuggingFace requests, by outcome (success, not_found, rate_limited, error).",
+		Kind:   Counter,
+		Labels: []string{"outcome"},
+	}
+	HTTPRequests = Definition{
+		Name:   "model_catalog_http_requests_total",
+		Help:   "Outgoing HTTP requests, by service and status class (2xx, 4xx, 5xx, error).",
+		Kind:   Counter,
+		Labels: []string{"service", "status"},
+	}
+	HTTPRequestDuration = Definition{
+		Name:    "model_catalog_http_request_duration_seconds",
+		Help:    "Time to receive the response headers of outgoing HTTP requests, by service.",
+		Kind:    Histogram,
+		Labels:  []string{"service"},
+		Buckets: durationBuckets,
+	}
+	EnrichmentCoverage = Definition{
+		Name:   "model_catalog_enrichment_field_models",
+		Help:   "Models of the last run per metadata field and state (enriched, populated, missing).",
+		Kind:   Gauge,
+		Labels: []string{"field", "state"},
+	}
+	CatalogModels = Definition{
+		Name: "model_catalog_catalog_models",
+		Help: "Models in the last generated catalog.",
+		Kind: Gauge,
+	}
+	CatalogSize = Definition{
+		Name: "model_catalog_catalog_size_bytes",
+		Help: "Size of the last generated catalog file.",
+		Kind: Gauge,
+	}
+	Refreshes = Definition{
+		Name:   "model_catalog_refreshes_total",
+		Help:   "Catalog refreshes by result (success, degraded, failed).",
+		Kind:   Counter,
+		Labels: []string{"result"},
+	}
+	RefreshDuration = Definition{
+		Name:    "model_catalog_refresh_duration_seconds",
+		Help:    "Wall time of catalog refreshes.",
+		Kind:    Histogram,
+		Buckets: refreshBuckets,
+	}
+	LastRefresh = Definition{
+		Name: "model_catalog_last_successful_refresh_timestamp_seconds",
+		Help: "Unix time of the last refresh that swapped in a new catalog.",
+		Kind: Gauge,
+	}
+)
+
+// definitions lists every metric by name, in exposition order
+var definitions = []Definition{
+	ModelsProcessed, RegistryFetchDuration, HuggingFaceRequests, HTTPRequests, HTTPRequestDuration,
+	EnrichmentCoverage, CatalogModels, CatalogSize, Refreshes, RefreshDuration, LastRefresh,
+}
+
+// Series is the value of one metric with one set of label values. Histograms keep cumulative
+// bucket counts aligned with the definition's buckets.
+type Series struct {
+	Metric  string            `json:"metric"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Value   float64           `json:"value"`
+	Buckets []uint64          `json:"buckets,omitempty"`
+	Count   uint64            `json:"count,omitempty"`
+}
+
+// Registry holds the series of a process. It is safe for concurrent use.
+type Registry struct {
+	mu     sync.Mutex
+	series map[string]*Series
+}
+
+// NewRegistry returns an empty registry
+func NewRegistry() *Registry {
+	return &Registry{series: make(map[string]*Series)}
+}
+
+// Default is the registry the pipeline records into
+var Default = NewRegistry()
+
+// lookup returns the series of def with the label values, creating it; the caller holds r.mu
+func (r *Registry) lookup(def Definition, labelValues []string) *Series {
+	labels := make(map[string]string, len(def.Labels))
+	for i, name := range def.Labels {
+		if i < len(labelValues) {
+			labels[name] = labelValues[i]
+		}
+	}
+	key := seriesKey(def.Name, labels)
+	s, ok := r.series[key]
+	if !ok {
+		s = &Series{Metric: def.Name, Labels: labels}
+		if def.Kind == Histogram {
+			s.Buckets = make([]uint64, len(def.Buckets))
+		}
+		r.series[key] = s
+	}
+	return s
+}
+
+// Add increases a counter
+func (r *Registry) Add(def Definition, value float64, labelValues ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lookup(def, labelValues).Value += value
+}
+
+// Set sets a gauge
+func (r *Registry) Set(def Definition, value float64, labelValues ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lookup(def, labelValues).Value = value
+}
+
+// Observe records a value in a histogram
+func (r *Registry) Observe(def Definition, value float64, labelValues ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := r.lookup(def, labelValues)
+	s.Value += value
+	s.Count++
+	for i, bound := range def.Buckets {
+		if value <= bound {
+			s.Buckets[i]++
+		}
+	}
+}
+
+// ObserveDuration records the time since start in a histogram, in seconds
+func (r *Registry) ObserveDuration(def Definition, start time.Time, labelValues ...string) {
+	r.Observe(def, time.Since(start).Seconds(), labelValues...)
+}
+
+// Snapshot returns a copy of all series
+func (r *Registry) Snapshot() []Series {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	snapshot := make([]Series, 0, len(r.series))
+	for _, s := range r.series {
+		copied := *s
+		copied.Buckets = append([]uint64(nil), s.Buckets...)
+		snapshot = append(snapshot, copied)
+	}
+	sort.Slice(snapshot, func(i, j int) bool {
+		return seriesKey(snapshot[i].Metric, snapshot[i].Labels) < seriesKey(snapshot[j].Metric, snapshot[j].Labels)
+	})
+	return snapshot
+}
+
+// Merge adds the series of another process, such as a pipeline run: counters and histograms are
+// added up and gauges replace the current values. A gauge family present in the snapshot replaces
+// all of its series, so fields that disappeared are not reported with stale values.
+func (r *Registry) Merge(snapshot []Series) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	replaced := make(map[string]bool)
+	for _, s := range snapshot {
+		def, ok := definition(s.Metric)
+		if !ok || def.Kind != Gauge || replaced[def.Name] {
+			continue
+		}
+		replaced[def.Name] = true
+		for key, existing := range r.series {
+			if existing.Metric == def.Name {
+				delete(r.series, key)
+			}
+		}
+	}
+
+	for _, s := range snapshot {
+		def, ok := definition(s.Metric)
+		if !ok {
+			continue
+		}
+		labelValues := make([]string, len(def.Labels))
+		for i, name := range def.Labels {
+			labelValues[i] = s.Labels[name]
+		}
+		target := r.lookup(def, labelValues)
+		switch def.Kind {
+		case Gauge:
+			target.Value = s.Value
+		case Counter:
+			target.Value += s.Value
+		case Histogram:
+			target.Value += s.Value
+			target.Count += s.Count
+			for i := range target.Buckets {
+				if i < len(s.Buckets) {
+					target.Buckets[i] += s.Buckets[i]
+				}
+			}
+		}
+	}
+}
+
+// WriteSnapshot writes the registry's series as JSON for another process to merge
+func (r *Registry) WriteSnapshot(path string) error {
+	data, err := json.MarshalIndent(r.Snapshot(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal metrics snapshot: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write metrics snapshot: %v", err)
+	}
+	return nil
+}
+
+// ReadSnapshot reads a metrics snapshot written by WriteSnapshot
+func ReadSnapshot(path string) ([]Series, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading metrics snapshot: %v", err)
+	}
+	var snapshot []Series
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("error parsing metrics snapshot %s: %v", path, err)
+	}
+	return snapshot, nil
+}
+
+// WriteText writes the registry in the Prometheus text exposition format
+func (r *Registry) WriteText(w io.Writer) error {
+	byMetric := make(map[string][]Series)
+	for _, s := range r.Snapshot() {
+		byMetric[s.Metric] = append(byMetric[s.Metric], s)
+	}
+
+	var b strings.Builder
+	for _, def := range definitions {
+		series := byMetric[def.Name]
+		if len(series) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", def.Name, def.Help, def.Name, def.Kind)
+		for _, s := range series {
+			if def.Kind != Histogram {
+				fmt.Fprintf(&b, "%s%s %s\n", def.Name, formatLabels(s.Labels, "", ""), formatValue(s.Value))
+				continue
+			}
+			for i, bound := range def.Buckets {
+				fmt.Fprintf(&b, "%s_bucket%s %d\n", def.Name, formatLabels(s.Labels, "le", formatValue(bound)), s.Buckets[i])
+			}
+			fmt.Fprintf(&b, "%s_bucket%s %d\n", def.Name, formatLabels(s.Labels, "le", "+Inf"), s.Count)
+			fmt.Fprintf(&b, "%s_sum%s %s\n", def.Name, formatLabels(s.Labels, "", ""), formatValue(s.Value))
+			fmt.Fprintf(&b, "%s_count%s %d\n", def.Name, formatLabels(s.Labels, "", ""), s.Count)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func definition(name string) (Definition, bool) {
+	for _, def := range definitions {
+		if def.Name == name {
+			return def, true
+		}
+	}
+	return Definition{}, false
+}
+
+func seriesKey(metric string, labels map[string]string) string {
+	return metric + formatLabels(labels, "", "")
+}
+
+// formatLabels renders labels sorted by name, with an optional extra label such as le
+func formatLabels(labels map[string]string, extraName, extraValue string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var pairs []string
+	for _, name := range names {
+		pairs = append(pairs, name+"="+strconv.Quote(labels[name]))
+	}
+	if extraName != "" {
+		pairs = append(pairs, extraName+"="+strconv.Quote(extraValue))
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatValue(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
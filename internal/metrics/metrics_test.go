@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteText(t *testing.T) {
+	reg := NewRegistry()
+	reg.Add(HuggingFaceRequests, 2, "success")
+	reg.Add(HuggingFaceRequests, 1, "rate_limited")
+	reg.Set(CatalogModels, 42)
+	reg.Observe(RegistryFetchDuration, 0.3)
+	reg.Observe(RegistryFetchDuration, 12)
+
+	var b strings.Builder
+	if err := reg.WriteText(&b); err != nil {
+		t.Fatalf("WriteText failed: %v", err)
+	}
+	text := b.String()
+
+	for _, want := range []string{
+		"# TYPE model_catalog_huggingface_requests_total counter",
+		`model_catalog_huggingface_requests_total{outcome="success"} 2`,
+		`model_catalog_huggingface_requests_total{outcome="rate_limited"} 1`,
+		"model_catalog_catalog_models 42",
+		`model_catalog_registry_fetch_duration_seconds_bucket{le="0.25"} 0`,
+		`model_catalog_registry_fetch_duration_seconds_bucket{le="0.5"} 1`,
+		`model_catalog_registry_fetch_duration_seconds_bucket{le="+Inf"} 2`,
+		"model_catalog_registry_fetch_duration_seconds_sum 12.3",
+		"model_catalog_registry_fetch_duration_seconds_count 2",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("metrics output is missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "model_catalog_refreshes_total") {
+		t.Error("metrics without series should not be written")
+	}
+}
+
+func TestMergeSnapshot(t *testing.T) {
+	run := NewRegistry()
+	run.Add(ModelsProcessed, 3, "modelcard")
+	run.Set(EnrichmentCoverage, 5, "license", "enriched")
+	run.Observe(RegistryFetchDuration, 1)
+
+	path := filepath.Join(t.TempDir(), SnapshotFileName)
+	if err := run.WriteSnapshot(path); err != nil {
+		t.Fatalf("WriteSnapshot failed: %v", err)
+	}
+	snapshot, err := ReadSnapshot(path)
+	if err != nil {
+		t.Fatalf("ReadSnapshot failed: %v", err)
+	}
+
+	server := NewRegistry()
+	server.Set(EnrichmentCoverage, 9, "readme", "missing")
+	server.Merge(snapshot)
+	server.Merge(snapshot)
+
+	var b strings.Builder
+	if err := server.WriteText(&b); err != nil {
+		t.Fatalf("WriteText failed: %v", err)
+	}
+	text := b.String()
+	for _, want := range []string{
+		`model_catalog_models_processed_total{outcome="modelcard"} 6`,
+		`model_catalog_enrichment_field_models{field="license",state="enriched"} 5`,
+		"model_catalog_registry_fetch_duration_seconds_count 2",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("merged metrics are missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, `field="readme"`) {
+		t.Error("gauges of a merged snapshot should replace stale series")
+	}
+}
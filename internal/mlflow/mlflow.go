@@ -0,0 +1,243 @@
+// Package mlflow registers catalog models in the model registry of an MLflow tracking server
+// through its REST API, for teams that standardize on MLflow.
+package mlflow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/httpclient"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// APIPath is the path of the MLflow REST API below the tracking server URL
+const APIPath = "/api/2.0/mlflow"
+
+// maxTagValueLength is the longest tag value MLflow accepts
+const maxTagValueLength = 5000
+
+// errNotFound is returned for MLflow's RESOURCE_DOES_NOT_EXIST errors
+var errNotFound = errors.New("not found")
+
+// Tag is an MLflow registered model or model version tag
+type Tag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Client calls the REST API of one MLflow tracking server
+type Client struct {
+	api *httpclient.JSONClient
+}
+
+// NewClient returns a client for the tracking server at trackingURL, e.g.
+// https://mlflow.example.com. A non-empty token is sent as a bearer token.
+func NewClient(trackingURL, token string) (*Client, error) {
+	u, err := url.Parse(trackingURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid MLflow tracking URL %q", trackingURL)
+	}
+	return &Client{api: &httpclient.JSONClient{
+		BaseURL: strings.TrimSuffix(u.String(), "/") + APIPath,
+		Token:   token,
+		HTTP:    httpclient.New("mlflow", 30*time.Second),
+	}}, nil
+}
+
+// Result counts the MLflow objects created and updated by Export
+type Result struct {
+	Created int
+	Updated int
+	// Failed lists the names of the models that could not be exported
+	Failed []string
+}
+
+// Export registers every catalog model as an MLflow registered model with the model's description
+// and tags, and every artifact URI the registered model has no version for as a new model version
+// with that source. Exporting the same catalog again updates descriptions and tags without adding
+// versions. A model that fails is logged and skipped; the returned error reports how many failed.
+func (c *Client) Export(ctx context.Context, catalog *types.ModelsCatalog) (Result, error) {
+	var result Result
+	for _, model := range catalog.Models {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+		if model.Name == nil || strings.TrimSpace(*model.Name) == "" {
+			log.Printf("  Warning: Skipping catalog model without a name")
+			continue
+		}
+		if err := c.exportModel(ctx, model, &result); err != nil {
+			log.Printf("  Warning: Failed to export %s to MLflow: %v", *model.Name, err)
+			result.Failed = append(result.Failed, *model.Name)
+		}
+	}
+	if len(result.Failed) > 0 {
+		return result, fmt.Errorf("%d of %d models could not be exported to MLflow", len(result.Failed), len(catalog.Models))
+	}
+	return result, nil
+}
+
+// exportModel creates or updates the registered model of a catalog model and adds its missing
+// versions
+func (c *Client) exportModel(ctx context.Context, model types.CatalogMetadata, result *Result) error {
+	name := *model.Name
+	description := ""
+	if model.Description != nil {
+		description = *model.Description
+	}
+	tags := ModelTags(model)
+
+	err := c.do(ctx, http.MethodGet, "/registered-models/get", url.Values{"name": {name}}, nil, nil)
+	switch {
+	case errors.Is(err, errNotFound):
+		request := map[string]interface{}{"name": name, "description": description, "tags": tags}
+		if err := c.do(ctx, http.MethodPost, "/registered-models/create", nil, request, nil); err != nil {
+			return fmt.Errorf("error creating registered model: %v", err)
+		}
+		result.Created++
+	case err != nil:
+		return fmt.Errorf("error looking up registered model: %v", err)
+	default:
+		request := map[string]interface{}{"name": name, "description": description}
+		if err := c.do(ctx, http.MethodPatch, "/registered-models/update", nil, request, nil); err != nil {
+			return fmt.Errorf("error updating registered model: %v", err)
+		}
+		for _, tag := range tags {
+			request := map[string]interface{}{"name": name, "key": tag.Key, "value": tag.Value}
+			if err := c.do(ctx, http.MethodPost, "/registered-models/set-tag", nil, request, nil); err != nil {
+				return fmt.Errorf("error setting tag %s: %v", tag.Key, err)
+			}
+		}
+		result.Updated++
+	}
+
+	sources, err := c.versionSources(ctx, name)
+	if err != nil {
+		return err
+	}
+	for _, artifact := range model.Artifacts {
+		if artifact.URI == "" || sources[artifact.URI] {
+			continue
+		}
+		request := map[string]interface{}{
+			"name":        name,
+			"source":      artifact.URI,
+			"description": description,
+			"tags":        versionTags(artifact.URI),
+		}
+		if err := c.do(ctx, http.MethodPost, "/model-versions/create", nil, request, nil); err != nil {
+			return fmt.Errorf("error creating model version for %s: %v", artifact.URI, err)
+		}
+		sources[artifact.URI] = true
+		result.Created++
+	}
+	return nil
+}
+
+// versionSources returns the sources of the existing versions of a registered model
+func (c *Client) versionSources(ctx context.Context, name string) (map[string]bool, error) {
+	sources := make(map[string]bool)
+	quote := "'"
+	if strings.Contains(name, "'") {
+		quote = `"`
+	}
+	query := url.Values{"filter": {"name=" + quote + name + quote}}
+	for {
+		var response struct {
+			ModelVersions []struct {
+				Source string `json:"source"`
+			} `json:"model_versions"`
+			NextPageToken string `json:"next_page_token"`
+		}
+		if err := c.do(ctx, http.MethodGet, "/model-versions/search", query, nil, &response); err != nil {
+			return nil, fmt.Errorf("error listing model versions: %v", err)
+		}
+		for _, version := range response.ModelVersions {
+			sources[version.Source] = true
+		}
+		if response.NextPageToken == "" {
+			return sources, nil
+		}
+		query.Set("page_token", response.NextPageToken)
+	}
+}
+
+// ModelTags returns the registered model tags of a catalog model: its provider, license, tasks,
+// languages and source, plus its custom properties (labels such as "validated" get an empty value)
+func ModelTags(model types.CatalogMetadata) []Tag {
+	values := make(map[string]string)
+	for key, value := range model.CustomProperties {
+		switch value.MetadataType {
+		case types.MetadataTypeInt:
+			values[key] = value.IntValue
+		case types.MetadataTypeDouble:
+			values[key] = fmt.Sprint(value.DoubleValue)
+		case types.MetadataTypeBool:
+			values[key] = fmt.Sprint(value.BoolValue)
+		default:
+			values[key] = value.StringValue
+		}
+	}
+	for key, value := range map[string]string{
+		"provider": utils.StringValue(model.Provider),
+		"license":  utils.StringValue(model.License),
+		"tasks":    strings.Join(model.Tasks, ","),
+		"language": strings.Join(model.Language, ","),
+		"source":   model.Source,
+	} {
+		if value != "" {
+			values[key] = value
+		}
+	}
+
+	tags := make([]Tag, 0, len(values))
+	for key, value := range values {
+		if len(value) > maxTagValueLength {
+			value = value[:maxTagValueLength]
+		}
+		tags = append(tags, Tag{Key: key, Value: value})
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Key < tags[j].Key })
+	return tags
+}
+
+// versionTags returns the tags of the model version of an artifact: its image tag and digest
+func versionTags(uri string) []Tag {
+	parsed, err := utils.ParseArtifactURI(uri)
+	if err != nil || parsed.Scheme != utils.URISchemeOCI {
+		return nil
+	}
+	var tags []Tag
+	if parsed.Tag != "" {
+		tags = append(tags, Tag{Key: "image_tag", Value: parsed.Tag})
+	}
+	if parsed.Digest != "" {
+		tags = append(tags, Tag{Key: "image_digest", Value: parsed.Digest})
+	}
+	return tags
+}
+
+// do sends a request through the JSON client, returning errNotFound for MLflow's
+// RESOURCE_DOES_NOT_EXIST errors
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	err := c.api.Do(ctx, method, path, query, body, out)
+	var statusErr *httpclient.StatusError
+	if errors.As(err, &statusErr) {
+		var apiError struct {
+			ErrorCode string `json:"error_code"`
+		}
+		if json.Unmarshal(statusErr.Body, &apiError) == nil && apiError.ErrorCode == "RESOURCE_DOES_NOT_EXIST" {
+			return errNotFound
+		}
+	}
+	return err
+}
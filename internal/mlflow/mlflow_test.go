@@ -0,0 +1,147 @@
+package mlflow
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// fakeTracking is an in-memory MLflow model registry serving the endpoints Export uses
+type fakeTracking struct {
+	mu       sync.Mutex
+	models   map[string]map[string]string // tags by model name
+	versions map[string][]string          // sources by model name
+	updates  int
+}
+
+func (f *fakeTracking) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var body struct {
+		Name   string `json:"name"`
+		Source string `json:"source"`
+		Key    string `json:"key"`
+		Value  string `json:"value"`
+		Tags   []Tag  `json:"tags"`
+	}
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	}
+	notFound := func() {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error_code":"RESOURCE_DOES_NOT_EXIST","message":"not found"}`))
+	}
+
+	switch strings.TrimPrefix(r.URL.Path, APIPath) {
+	case "/registered-models/get":
+		if _, exists := f.models[r.URL.Query().Get("name")]; !exists {
+			notFound()
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	case "/registered-models/create":
+		f.models[body.Name] = make(map[string]string)
+		for _, tag := range body.Tags {
+			f.models[body.Name][tag.Key] = tag.Value
+		}
+		_, _ = w.Write([]byte(`{}`))
+	case "/registered-models/update":
+		f.updates++
+		_, _ = w.Write([]byte(`{}`))
+	case "/registered-models/set-tag":
+		f.models[body.Name][body.Key] = body.Value
+		_, _ = w.Write([]byte(`{}`))
+	case "/model-versions/search":
+		name := strings.Trim(strings.TrimPrefix(r.URL.Query().Get("filter"), "name="), `'"`)
+		var versions []map[string]string
+		for _, source := range f.versions[name] {
+			versions = append(versions, map[string]string{"source": source})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"model_versions": versions})
+	case "/model-versions/create":
+		f.versions[body.Name] = append(f.versions[body.Name], body.Source)
+		_, _ = w.Write([]byte(`{}`))
+	default:
+		http.Error(w, "unexpected request "+r.URL.Path, http.StatusBadRequest)
+	}
+}
+
+func TestExport(t *testing.T) {
+	tracking := &fakeTracking{models: make(map[string]map[string]string), versions: make(map[string][]string)}
+	server := httptest.NewServer(tracking)
+	defer server.Close()
+
+	name, provider := "granite-3.1-8b-instruct", "IBM"
+	catalog := &types.ModelsCatalog{Models: []types.CatalogMetadata{
+		{
+			Name:     &name,
+			Provider: &provider,
+			Tasks:    []string{"text-generation"},
+			CustomProperties: map[string]types.MetadataValue{
+				"validated": types.NewStringValue(""),
+			},
+			Artifacts: []types.CatalogOCIArtifact{
+				{URI: "oci://registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5"},
+			},
+		},
+		{Provider: &provider},
+	}}
+
+	client, err := NewClient(server.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := client.Export(context.Background(), catalog)
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if result.Created != 2 || result.Updated != 0 {
+		t.Errorf("Expected 2 created objects, got %+v", result)
+	}
+	tags := tracking.models[name]
+	if tags["provider"] != "IBM" || tags["tasks"] != "text-generation" {
+		t.Errorf("Unexpected tags %v", tags)
+	}
+	if _, hasLabel := tags["validated"]; !hasLabel {
+		t.Errorf("Expected the validated label as a tag, got %v", tags)
+	}
+
+	// Exporting again updates the model without adding a version
+	catalog.Models[0].Artifacts = append(catalog.Models[0].Artifacts, types.CatalogOCIArtifact{URI: "oci://registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.6"})
+	result, err = client.Export(context.Background(), catalog)
+	if err != nil {
+		t.Fatalf("Second export failed: %v", err)
+	}
+	if result.Created != 1 || result.Updated != 1 || tracking.updates != 1 {
+		t.Errorf("Expected 1 new version and 1 updated model, got %+v", result)
+	}
+	if len(tracking.versions[name]) != 2 {
+		t.Errorf("Expected 2 versions, got %v", tracking.versions[name])
+	}
+}
+
+func TestModelTags_Sorted(t *testing.T) {
+	license := "apache-2.0"
+	tags := ModelTags(types.CatalogMetadata{
+		License:  &license,
+		Language: []string{"en", "ja"},
+		CustomProperties: map[string]types.MetadataValue{
+			"featured": types.NewStringValue(""),
+			"params":   types.NewIntValue(8),
+		},
+	})
+	var keys []string
+	for _, tag := range tags {
+		keys = append(keys, tag.Key+"="+tag.Value)
+	}
+	if got := strings.Join(keys, ","); got != "featured=,language=en,ja,license=apache-2.0,params=8" {
+		t.Errorf("Unexpected tags %s", got)
+	}
+}
@@ -0,0 +1,85 @@
+// Package modelregistry pushes catalog models to a Kubeflow Model Registry through its REST API,
+// so the collection can seed a live registry instead of only producing catalog files.
+package modelregistry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/httpclient"
+)
+
+// APIPath is the path of the model registry REST API below the registry URL
+const APIPath = "/api/model_registry/v1alpha3"
+
+// errNotFound is returned by lookups of objects the registry does not have
+var errNotFound = errors.New("not found")
+
+// Client calls the REST API of one model registry
+type Client struct {
+	api *httpclient.JSONClient
+}
+
+// NewClient returns a client for the registry at registryURL, e.g.
+// https://model-registry.example.com. A non-empty token is sent as a bearer token.
+func NewClient(registryURL, token string) (*Client, error) {
+	u, err := url.Parse(registryURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid model registry URL %q", registryURL)
+	}
+	return &Client{api: &httpclient.JSONClient{
+		BaseURL: strings.TrimSuffix(u.String(), "/") + APIPath,
+		Token:   token,
+		HTTP:    httpclient.New("model-registry", 30*time.Second),
+	}}, nil
+}
+
+// do sends a request through the JSON client, returning errNotFound for 404 responses
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	err := c.api.Do(ctx, method, path, query, body, out)
+	var statusErr *httpclient.StatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+		return errNotFound
+	}
+	return err
+}
+
+// findRegisteredModel returns the registered model named name
+func (c *Client) findRegisteredModel(ctx context.Context, name string) (*RegisteredModel, error) {
+	var model RegisteredModel
+	if err := c.do(ctx, http.MethodGet, "/registered_model", url.Values{"name": {name}}, nil, &model); err != nil {
+		return nil, err
+	}
+	return &model, nil
+}
+
+// findModelVersion returns the version named name of the registered model registeredModelID
+func (c *Client) findModelVersion(ctx context.Context, registeredModelID, name string) (*ModelVersion, error) {
+	var version ModelVersion
+	query := url.Values{"name": {name}, "parentResourceId": {registeredModelID}}
+	if err := c.do(ctx, http.MethodGet, "/model_version", query, nil, &version); err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+// findModelArtifact returns the model artifact named name of the model version versionID
+func (c *Client) findModelArtifact(ctx context.Context, versionID, name string) (*ModelArtifact, error) {
+	var list struct {
+		Items []ModelArtifact `json:"items"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/model_versions/"+url.PathEscape(versionID)+"/artifacts", nil, nil, &list); err != nil {
+		return nil, err
+	}
+	for i := range list.Items {
+		if list.Items[i].ArtifactType == ArtifactTypeModel && list.Items[i].Name == name {
+			return &list.Items[i], nil
+		}
+	}
+	return nil, errNotFound
+}
@@ -0,0 +1,194 @@
+package modelregistry
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// ArtifactTypeModel is the artifactType of model artifacts
+const ArtifactTypeModel = "model-artifact"
+
+// Value is a custom property value in the JSON form of the registry API. Only the field matching
+// MetadataType is set.
+type Value struct {
+	MetadataType string   `json:"metadataType"`
+	StringValue  *string  `json:"string_value,omitempty"`
+	IntValue     *string  `json:"int_value,omitempty"`
+	DoubleValue  *float64 `json:"double_value,omitempty"`
+	BoolValue    *bool    `json:"bool_value,omitempty"`
+}
+
+// RegisteredModel is a model of the registry
+type RegisteredModel struct {
+	ID               string           `json:"id,omitempty"`
+	Name             string           `json:"name,omitempty"`
+	Description      string           `json:"description,omitempty"`
+	Owner            string           `json:"owner,omitempty"`
+	CustomProperties map[string]Value `json:"customProperties,omitempty"`
+}
+
+// ModelVersion is a version of a registered model
+type ModelVersion struct {
+	ID                string           `json:"id,omitempty"`
+	Name              string           `json:"name,omitempty"`
+	RegisteredModelID string           `json:"registeredModelId,omitempty"`
+	Description       string           `json:"description,omitempty"`
+	Author            string           `json:"author,omitempty"`
+	CustomProperties  map[string]Value `json:"customProperties,omitempty"`
+}
+
+// ModelArtifact is the artifact of a model version, pointing at the modelcar image
+type ModelArtifact struct {
+	ID               string           `json:"id,omitempty"`
+	ArtifactType     string           `json:"artifactType"`
+	Name             string           `json:"name,omitempty"`
+	URI              string           `json:"uri"`
+	CustomProperties map[string]Value `json:"customProperties,omitempty"`
+}
+
+// Entry is a catalog model converted to the registry objects describing it
+type Entry struct {
+	Model    RegisteredModel
+	Versions []VersionEntry
+}
+
+// VersionEntry is a model version with its artifact; every catalog artifact becomes one version
+type VersionEntry struct {
+	Version  ModelVersion
+	Artifact ModelArtifact
+}
+
+// ConvertModel converts a catalog model to a registered model with one version per artifact. The
+// version is named after the artifact's image tag, or its digest or URI when it has no tag.
+func ConvertModel(model types.CatalogMetadata) (Entry, error) {
+	if model.Name == nil || strings.TrimSpace(*model.Name) == "" {
+		return Entry{}, fmt.Errorf("model has no name")
+	}
+	name := *model.Name
+
+	entry := Entry{Model: RegisteredModel{
+		Name:             name,
+		Description:      utils.StringValue(model.Description),
+		Owner:            utils.StringValue(model.Provider),
+		CustomProperties: modelProperties(model),
+	}}
+
+	seen := make(map[string]bool)
+	for _, artifact := range model.Artifacts {
+		if artifact.URI == "" {
+			continue
+		}
+		versionName := artifactVersionName(artifact.URI)
+		if seen[versionName] {
+			versionName = artifact.URI
+		}
+		seen[versionName] = true
+
+		entry.Versions = append(entry.Versions, VersionEntry{
+			Version: ModelVersion{
+				Name:        versionName,
+				Description: utils.StringValue(model.Description),
+				Author:      utils.StringValue(model.Provider),
+			},
+			Artifact: ModelArtifact{
+				ArtifactType:     ArtifactTypeModel,
+				Name:             name,
+				URI:              artifact.URI,
+				CustomProperties: artifactProperties(artifact.CustomProperties),
+			},
+		})
+	}
+	return entry, nil
+}
+
+// artifactVersionName returns the image tag of an artifact URI, falling back to its digest and
+// then to the URI itself
+func artifactVersionName(uri string) string {
+	parsed, err := utils.ParseArtifactURI(uri)
+	if err != nil || parsed.Scheme != utils.URISchemeOCI {
+		return uri
+	}
+	if parsed.Tag != "" {
+		return parsed.Tag
+	}
+	return parsed.Digest
+}
+
+// modelProperties returns the custom properties of a registered model: the catalog model's own
+// custom properties plus the catalog fields the registry has no dedicated field for
+func modelProperties(model types.CatalogMetadata) map[string]Value {
+	props := make(map[string]Value, len(model.CustomProperties)+5)
+	for key, value := range model.CustomProperties {
+		props[key] = convertValue(value)
+	}
+	for key, value := range map[string]string{
+		"license":     utils.StringValue(model.License),
+		"licenseLink": utils.StringValue(model.LicenseLink),
+		"tasks":       strings.Join(model.Tasks, ","),
+		"language":    strings.Join(model.Language, ","),
+		"source":      model.Source,
+	} {
+		if value != "" {
+			props[key] = convertValue(types.NewStringValue(value))
+		}
+	}
+	if len(props) == 0 {
+		return nil
+	}
+	return props
+}
+
+// convertValue converts a catalog metadata value to its registry JSON form
+func convertValue(value types.MetadataValue) Value {
+	converted := Value{MetadataType: value.MetadataType}
+	switch value.MetadataType {
+	case types.MetadataTypeInt:
+		converted.IntValue = &value.IntValue
+	case types.MetadataTypeDouble:
+		converted.DoubleValue = &value.DoubleValue
+	case types.MetadataTypeBool:
+		converted.BoolValue = &value.BoolValue
+	default:
+		converted.MetadataType = types.MetadataTypeString
+		converted.StringValue = &value.StringValue
+	}
+	return converted
+}
+
+// artifactProperties converts artifact custom properties, which are decoded from the catalog as
+// generic maps like {metadataType: MetadataStringValue, string_value: "..."}
+func artifactProperties(props map[string]interface{}) map[string]Value {
+	if len(props) == 0 {
+		return nil
+	}
+	converted := make(map[string]Value, len(props))
+	for key, prop := range props {
+		fields, ok := prop.(map[string]interface{})
+		if !ok {
+			converted[key] = convertValue(types.NewStringValue(fmt.Sprint(prop)))
+			continue
+		}
+		metadataType, _ := fields["metadataType"].(string)
+		switch metadataType {
+		case types.MetadataTypeInt:
+			converted[key] = convertValue(types.MetadataValue{MetadataType: metadataType, IntValue: fmt.Sprint(fields["int_value"])})
+		case types.MetadataTypeDouble:
+			double, _ := strconv.ParseFloat(fmt.Sprint(fields["double_value"]), 64)
+			converted[key] = convertValue(types.NewDoubleValue(double))
+		case types.MetadataTypeBool:
+			flag, _ := fields["bool_value"].(bool)
+			converted[key] = convertValue(types.NewBoolValue(flag))
+		default:
+			var str string
+			if value, exists := fields["string_value"]; exists && value != nil {
+				str = fmt.Sprint(value)
+			}
+			converted[key] = convertValue(types.NewStringValue(str))
+		}
+	}
+	return converted
+}
@@ -0,0 +1,216 @@
+package modelregistry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// fakeRegistry is an in-memory model registry serving the endpoints Push uses
+type fakeRegistry struct {
+	mu        sync.Mutex
+	nextID    int
+	models    map[string]*RegisteredModel
+	versions  map[string]*ModelVersion
+	artifacts map[string][]*ModelArtifact // by version ID
+	patches   int
+	tokens    []string
+}
+
+func newFakeRegistry() *fakeRegistry {
+	return &fakeRegistry{
+		models:    make(map[string]*RegisteredModel),
+		versions:  make(map[string]*ModelVersion),
+		artifacts: make(map[string][]*ModelArtifact),
+	}
+}
+
+func (f *fakeRegistry) id() string {
+	f.nextID++
+	return strconv.Itoa(f.nextID)
+}
+
+func (f *fakeRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tokens = append(f.tokens, r.Header.Get("Authorization"))
+
+	path := strings.TrimPrefix(r.URL.Path, APIPath)
+	reply := func(v interface{}) { _ = json.NewEncoder(w).Encode(v) }
+	switch {
+	case r.Method == http.MethodGet && path == "/registered_model":
+		for _, model := range f.models {
+			if model.Name == r.URL.Query().Get("name") {
+				reply(model)
+				return
+			}
+		}
+		http.NotFound(w, r)
+	case r.Method == http.MethodPost && path == "/registered_models":
+		var model RegisteredModel
+		_ = json.NewDecoder(r.Body).Decode(&model)
+		model.ID = f.id()
+		f.models[model.ID] = &model
+		w.WriteHeader(http.StatusCreated)
+		reply(model)
+	case r.Method == http.MethodGet && path == "/model_version":
+		for _, version := range f.versions {
+			if version.Name == r.URL.Query().Get("name") && version.RegisteredModelID == r.URL.Query().Get("parentResourceId") {
+				reply(version)
+				return
+			}
+		}
+		http.NotFound(w, r)
+	case r.Method == http.MethodPost && path == "/model_versions":
+		var version ModelVersion
+		_ = json.NewDecoder(r.Body).Decode(&version)
+		version.ID = f.id()
+		f.versions[version.ID] = &version
+		w.WriteHeader(http.StatusCreated)
+		reply(version)
+	case strings.HasPrefix(path, "/model_versions/") && strings.HasSuffix(path, "/artifacts"):
+		versionID := strings.TrimSuffix(strings.TrimPrefix(path, "/model_versions/"), "/artifacts")
+		if r.Method == http.MethodGet {
+			reply(map[string]interface{}{"items": f.artifacts[versionID]})
+			return
+		}
+		var artifact ModelArtifact
+		_ = json.NewDecoder(r.Body).Decode(&artifact)
+		artifact.ID = f.id()
+		f.artifacts[versionID] = append(f.artifacts[versionID], &artifact)
+		w.WriteHeader(http.StatusCreated)
+		reply(artifact)
+	case r.Method == http.MethodPatch:
+		f.patches++
+		var update map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&update)
+		if _, hasName := update["name"]; hasName {
+			http.Error(w, "name cannot be updated", http.StatusBadRequest)
+			return
+		}
+		id := path[strings.LastIndex(path, "/")+1:]
+		switch {
+		case strings.HasPrefix(path, "/registered_models/"):
+			reply(f.models[id])
+		case strings.HasPrefix(path, "/model_versions/"):
+			reply(f.versions[id])
+		default:
+			reply(update)
+		}
+	default:
+		http.Error(w, "unexpected request "+r.Method+" "+path, http.StatusBadRequest)
+	}
+}
+
+func testCatalog() *types.ModelsCatalog {
+	name, provider, license := "granite-3.1-8b-instruct", "IBM", "apache-2.0"
+	return &types.ModelsCatalog{
+		Source: "Red Hat",
+		Models: []types.CatalogMetadata{
+			{
+				Name:     &name,
+				Provider: &provider,
+				License:  &license,
+				Tasks:    []string{"text-generation"},
+				CustomProperties: map[string]types.MetadataValue{
+					"validated": types.NewStringValue(""),
+				},
+				Artifacts: []types.CatalogOCIArtifact{
+					{URI: "oci://registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.4"},
+					{
+						URI: "oci://registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5",
+						CustomProperties: map[string]interface{}{
+							"architecture": map[string]interface{}{"metadataType": types.MetadataTypeString, "string_value": "amd64"},
+						},
+					},
+				},
+			},
+			{Provider: &provider},
+		},
+	}
+}
+
+func TestPush(t *testing.T) {
+	registry := newFakeRegistry()
+	server := httptest.NewServer(registry)
+	defer server.Close()
+
+	client, err := NewClient(server.URL+"/", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := client.Push(context.Background(), testCatalog())
+	if err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+	// One registered model, two versions and two artifacts; the unnamed model is skipped
+	if result.Created != 5 || result.Updated != 0 {
+		t.Errorf("Expected 5 created and 0 updated objects, got %+v", result)
+	}
+	if len(registry.models) != 1 || len(registry.versions) != 2 {
+		t.Fatalf("Expected 1 model and 2 versions, got %d and %d", len(registry.models), len(registry.versions))
+	}
+	for _, model := range registry.models {
+		if model.Owner != "IBM" || model.CustomProperties["license"].StringValue == nil || *model.CustomProperties["license"].StringValue != "apache-2.0" {
+			t.Errorf("Unexpected registered model: %+v", model)
+		}
+	}
+	for _, version := range registry.versions {
+		if version.Name != "1.4" && version.Name != "1.5" {
+			t.Errorf("Unexpected version name %q", version.Name)
+		}
+		artifacts := registry.artifacts[version.ID]
+		if len(artifacts) != 1 || !strings.HasSuffix(artifacts[0].URI, ":"+version.Name) || artifacts[0].ArtifactType != ArtifactTypeModel {
+			t.Errorf("Unexpected artifacts of version %s: %+v", version.Name, artifacts)
+		}
+	}
+	for _, token := range registry.tokens {
+		if token != "Bearer secret" {
+			t.Fatalf("Expected every request to carry the token, got %q", token)
+		}
+	}
+
+	// Pushing again updates the existing objects instead of creating new ones
+	result, err = client.Push(context.Background(), testCatalog())
+	if err != nil {
+		t.Fatalf("Second push failed: %v", err)
+	}
+	if result.Created != 0 || result.Updated != 5 || registry.patches != 5 {
+		t.Errorf("Expected 5 updated objects, got %+v (%d patches)", result, registry.patches)
+	}
+	if len(registry.models) != 1 || len(registry.versions) != 2 {
+		t.Errorf("Expected no new objects, got %d models and %d versions", len(registry.models), len(registry.versions))
+	}
+}
+
+func TestPush_Failure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := client.Push(context.Background(), testCatalog())
+	if err == nil || len(result.Failed) != 1 || result.Failed[0] != "granite-3.1-8b-instruct" {
+		t.Errorf("Expected the model to fail, got %+v (err %v)", result, err)
+	}
+}
+
+func TestNewClient_InvalidURL(t *testing.T) {
+	for _, registryURL := range []string{"", "model-registry:8080", "ftp://example.com"} {
+		if _, err := NewClient(registryURL, ""); err == nil {
+			t.Errorf("NewClient(%q): expected an error", registryURL)
+		}
+	}
+}
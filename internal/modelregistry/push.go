@@ -0,0 +1,119 @@
+package modelregistry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// Result counts the registry objects created and updated by Push
+type Result struct {
+	Created int
+	Updated int
+	// Failed lists the names of the models that could not be pushed
+	Failed []string
+}
+
+// Push creates or updates a registered model, model versions and model artifacts for every
+// catalog model. Objects are matched by name, so pushing the same catalog again updates the
+// existing objects instead of duplicating them. A model that fails is logged and skipped; the
+// returned error reports how many failed.
+func (c *Client) Push(ctx context.Context, catalog *types.ModelsCatalog) (Result, error) {
+	var result Result
+	for _, model := range catalog.Models {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+		entry, err := ConvertModel(model)
+		if err != nil {
+			log.Printf("  Warning: Skipping catalog model: %v", err)
+			continue
+		}
+		if err := c.pushEntry(ctx, entry, &result); err != nil {
+			log.Printf("  Warning: Failed to push %s to the model registry: %v", entry.Model.Name, err)
+			result.Failed = append(result.Failed, entry.Model.Name)
+		}
+	}
+	if len(result.Failed) > 0 {
+		return result, fmt.Errorf("%d of %d models could not be pushed to the model registry", len(result.Failed), len(catalog.Models))
+	}
+	return result, nil
+}
+
+// pushEntry creates or updates the registered model of entry and its versions
+func (c *Client) pushEntry(ctx context.Context, entry Entry, result *Result) error {
+	model, err := c.findRegisteredModel(ctx, entry.Model.Name)
+	switch {
+	case errors.Is(err, errNotFound):
+		model = &RegisteredModel{}
+		if err := c.do(ctx, http.MethodPost, "/registered_models", nil, entry.Model, model); err != nil {
+			return fmt.Errorf("error creating registered model: %v", err)
+		}
+		result.Created++
+	case err != nil:
+		return fmt.Errorf("error looking up registered model: %v", err)
+	default:
+		update := entry.Model
+		update.Name = ""
+		if err := c.do(ctx, http.MethodPatch, "/registered_models/"+url.PathEscape(model.ID), nil, update, model); err != nil {
+			return fmt.Errorf("error updating registered model: %v", err)
+		}
+		result.Updated++
+	}
+
+	for _, versionEntry := range entry.Versions {
+		if err := c.pushVersion(ctx, model.ID, versionEntry, result); err != nil {
+			return fmt.Errorf("version %s: %v", versionEntry.Version.Name, err)
+		}
+	}
+	return nil
+}
+
+// pushVersion creates or updates a model version of the registered model registeredModelID and
+// its model artifact
+func (c *Client) pushVersion(ctx context.Context, registeredModelID string, entry VersionEntry, result *Result) error {
+	version, err := c.findModelVersion(ctx, registeredModelID, entry.Version.Name)
+	switch {
+	case errors.Is(err, errNotFound):
+		create := entry.Version
+		create.RegisteredModelID = registeredModelID
+		version = &ModelVersion{}
+		if err := c.do(ctx, http.MethodPost, "/model_versions", nil, create, version); err != nil {
+			return fmt.Errorf("error creating model version: %v", err)
+		}
+		result.Created++
+	case err != nil:
+		return fmt.Errorf("error looking up model version: %v", err)
+	default:
+		update := entry.Version
+		update.Name = ""
+		if err := c.do(ctx, http.MethodPatch, "/model_versions/"+url.PathEscape(version.ID), nil, update, version); err != nil {
+			return fmt.Errorf("error updating model version: %v", err)
+		}
+		result.Updated++
+	}
+
+	artifact, err := c.findModelArtifact(ctx, version.ID, entry.Artifact.Name)
+	switch {
+	case errors.Is(err, errNotFound):
+		if err := c.do(ctx, http.MethodPost, "/model_versions/"+url.PathEscape(version.ID)+"/artifacts", nil, entry.Artifact, nil); err != nil {
+			return fmt.Errorf("error creating model artifact: %v", err)
+		}
+		result.Created++
+	case err != nil:
+		return fmt.Errorf("error looking up model artifact: %v", err)
+	default:
+		update := entry.Artifact
+		update.Name = ""
+		if err := c.do(ctx, http.MethodPatch, "/model_artifacts/"+url.PathEscape(artifact.ID), nil, update, nil); err != nil {
+			return fmt.Errorf("error updating model artifact: %v", err)
+		}
+		result.Updated++
+	}
+	return nil
+}
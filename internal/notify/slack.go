@@ -0,0 +1,173 @@
+// Package notify posts pipeline run results to chat, so the team operating the nightly catalog
+// build learns about failures and coverage gaps without reading the job logs.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/httpclient"
+	"github.com/opendatahub-io/model-metadata-collection/internal/summary"
+)
+
+// Run outcomes reported by Slack notifications
+const (
+	StatusSucceeded = "succeeded"
+	StatusDegraded  = "degraded"
+	StatusFailed    = "failed"
+)
+
+// maxListedModels caps the new and removed models named in a message
+const maxListedModels = 10
+
+// maxListedGaps caps the enrichment fields listed as gaps in a message
+const maxListedGaps = 5
+
+// slackTimeout bounds the webhook request so a Slack outage does not hold up the run
+const slackTimeout = 30 * time.Second
+
+var statusIcons = map[string]string{
+	StatusSucceeded: ":white_check_mark:",
+	StatusDegraded:  ":warning:",
+	StatusFailed:    ":x:",
+}
+
+// SlackOptions controls Slack notifications
+type SlackOptions struct {
+	// WebhookURL is the Slack incoming webhook the message is posted to
+	WebhookURL string
+	// Catalog names the catalog in the message title, e.g. models-catalog.yaml
+	Catalog string
+	// Status is StatusSucceeded, StatusDegraded or StatusFailed
+	Status string
+	// Reason explains a failed run
+	Reason string
+}
+
+// slackMessage is the payload of a Slack incoming webhook
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+// PostSlack posts the run summary to a Slack incoming webhook: the model success rate, the models
+// new or removed since the previous run, the fields most often left missing by enrichment and the
+// reasons a run was degraded
+func PostSlack(ctx context.Context, runSummary summary.RunSummary, opts SlackOptions) error {
+	body, err := json.Marshal(slackMessage{Text: SlackText(runSummary, opts)})
+	if err != nil {
+		return fmt.Errorf("error encoding Slack message: %v", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, opts.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid Slack webhook URL: %v", redactURL(err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpclient.New("slack", slackTimeout).Do(req)
+	if err != nil {
+		return fmt.Errorf("error posting to Slack: %v", redactURL(err))
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("slack webhook returned %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
+
+// redactURL drops the request URL from err: the webhook URL is the secret that authorizes posts
+func redactURL(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
+
+// SlackText renders the run summary as a Slack mrkdwn message
+func SlackText(runSummary summary.RunSummary, opts SlackOptions) string {
+	var b strings.Builder
+	status := opts.Status
+	if status == "" {
+		status = StatusSucceeded
+	}
+	title := "Model catalog build"
+	if opts.Catalog != "" {
+		title += " of " + opts.Catalog
+	}
+	fmt.Fprintf(&b, "%s *%s %s* in %s\n", statusIcons[status], title, status, formatDuration(runSummary.DurationSeconds))
+	if opts.Reason != "" {
+		fmt.Fprintf(&b, "*Reason:* %s\n", opts.Reason)
+	}
+
+	models := runSummary.Models
+	if models.Processed > 0 {
+		succeeded := models.Processed - models.Failures()
+		fmt.Fprintf(&b, "*Models:* %d of %d extracted with a modelcard (%.1f%%), %d skeletons, %d failed\n",
+			succeeded, models.Processed, 100*float64(succeeded)/float64(models.Processed), models.SkeletonsCreated, models.Failed)
+	}
+
+	writeModelList(&b, "New models", runSummary.NewModels)
+	writeModelList(&b, "Removed models", runSummary.RemovedModels)
+
+	if gaps := enrichmentGaps(runSummary.Enrichment); len(gaps) > 0 {
+		fmt.Fprintf(&b, "*Enrichment gaps:* %s\n", strings.Join(gaps, ", "))
+	}
+	for _, reason := range runSummary.Degraded {
+		fmt.Fprintf(&b, "• %s\n", reason)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// writeModelList writes a labeled, truncated list of model names
+func writeModelList(b *strings.Builder, label string, names []string) {
+	if len(names) == 0 {
+		return
+	}
+	listed := names
+	if len(listed) > maxListedModels {
+		listed = listed[:maxListedModels]
+	}
+	fmt.Fprintf(b, "*%s (%d):* %s", label, len(names), strings.Join(listed, ", "))
+	if len(names) > len(listed) {
+		fmt.Fprintf(b, " and %d more", len(names)-len(listed))
+	}
+	b.WriteString("\n")
+}
+
+// enrichmentGaps returns the fields most models are missing, e.g. "license (12 missing)"
+func enrichmentGaps(fields map[string]summary.FieldSummary) []string {
+	var names []string
+	for name, counts := range fields {
+		if counts.Missing > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if fields[names[i]].Missing != fields[names[j]].Missing {
+			return fields[names[i]].Missing > fields[names[j]].Missing
+		}
+		return names[i] < names[j]
+	})
+	if len(names) > maxListedGaps {
+		names = names[:maxListedGaps]
+	}
+	gaps := make([]string, len(names))
+	for i, name := range names {
+		gaps[i] = fmt.Sprintf("%s (%d missing)", name, fields[name].Missing)
+	}
+	return gaps
+}
+
+func formatDuration(seconds float64) string {
+	return time.Duration(seconds * float64(time.Second)).Round(time.Second).String()
+}
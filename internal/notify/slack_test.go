@@ -0,0 +1,106 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/summary"
+)
+
+func testSummary() summary.RunSummary {
+	var newModels []string
+	for i := 1; i <= 12; i++ {
+		newModels = append(newModels, fmt.Sprintf("RedHatAI/model-%d", i))
+	}
+	return summary.RunSummary{
+		DurationSeconds: 754.2,
+		Models:          summary.ModelCounts{Processed: 40, ModelcardsFound: 37, SkeletonsCreated: 2, Failed: 1},
+		Enrichment: map[string]summary.FieldSummary{
+			"license":     {Populated: 38, Missing: 2},
+			"description": {Populated: 30, Missing: 10},
+			"provider":    {Populated: 40},
+		},
+		NewModels:     newModels,
+		RemovedModels: []string{"RedHatAI/old-model"},
+		Degraded:      []string{"2 models have skeleton metadata without a modelcard"},
+	}
+}
+
+func TestSlackText(t *testing.T) {
+	text := SlackText(testSummary(), SlackOptions{Catalog: "models-catalog.yaml", Status: StatusDegraded})
+	for _, want := range []string{
+		":warning: *Model catalog build of models-catalog.yaml degraded* in 12m34s",
+		"*Models:* 37 of 40 extracted with a modelcard (92.5%), 2 skeletons, 1 failed",
+		"*New models (12):* RedHatAI/model-1, ",
+		"RedHatAI/model-10 and 2 more",
+		"*Removed models (1):* RedHatAI/old-model",
+		"*Enrichment gaps:* description (10 missing), license (2 missing)",
+		"• 2 models have skeleton metadata without a modelcard",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("SlackText() missing %q in:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "provider") {
+		t.Errorf("SlackText() lists a field without gaps:\n%s", text)
+	}
+}
+
+func TestSlackText_Failed(t *testing.T) {
+	text := SlackText(summary.RunSummary{}, SlackOptions{Status: StatusFailed, Reason: "12 of 40 models failed"})
+	want := ":x: *Model catalog build failed* in 0s\n*Reason:* 12 of 40 models failed"
+	if text != want {
+		t.Errorf("SlackText() = %q, want %q", text, want)
+	}
+}
+
+func TestPostSlack(t *testing.T) {
+	var received slackMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected request %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("decoding message: %v", err)
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	if err := PostSlack(context.Background(), testSummary(), SlackOptions{WebhookURL: server.URL, Status: StatusSucceeded}); err != nil {
+		t.Fatalf("PostSlack() error = %v", err)
+	}
+	if !strings.HasPrefix(received.Text, ":white_check_mark: *Model catalog build succeeded*") {
+		t.Errorf("posted text = %q", received.Text)
+	}
+}
+
+func TestPostSlack_Rejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	err := PostSlack(context.Background(), testSummary(), SlackOptions{WebhookURL: server.URL})
+	if err == nil || !strings.Contains(err.Error(), "invalid_token") {
+		t.Errorf("PostSlack() error = %v, want the webhook's rejection", err)
+	}
+}
+
+func TestPostSlack_RedactsWebhookURL(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	webhookURL := server.URL + "/services/T000/B000/secret"
+	server.Close()
+
+	for _, url := range []string{webhookURL, "http://hooks.example.com/services/T000/B000/secret\x7f"} {
+		err := PostSlack(context.Background(), testSummary(), SlackOptions{WebhookURL: url})
+		if err == nil || strings.Contains(err.Error(), "secret") {
+			t.Errorf("PostSlack(%q) error = %v, want an error without the webhook URL", url, err)
+		}
+	}
+}
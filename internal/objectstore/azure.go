@@ -0,0 +1,93 @@
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/httpclient"
+)
+
+// azureUploader uploads block blobs to Azure Blob Storage authorized by a SAS token
+type azureUploader struct {
+	endpoint  string // e.g. https://account.blob.core.windows.net
+	container string
+	sasToken  string
+	client    *http.Client
+}
+
+func newAzureUploaderFromEnv(account, container string) (*azureUploader, error) {
+	sasToken := strings.TrimPrefix(os.Getenv("AZURE_STORAGE_SAS_TOKEN"), "?")
+	if sasToken == "" {
+		return nil, fmt.Errorf("azblob upload requires AZURE_STORAGE_SAS_TOKEN")
+	}
+
+	endpoint := os.Getenv("AZURE_STORAGE_BLOB_ENDPOINT")
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net", account)
+	}
+
+	return &azureUploader{
+		endpoint:  strings.TrimRight(endpoint, "/"),
+		container: container,
+		sasToken:  sasToken,
+		client:    httpclient.New("objectstore", 0),
+	}, nil
+}
+
+// Upload stores body as a block blob named key in the container
+func (u *azureUploader) Upload(ctx context.Context, key string, body []byte, contentType string) error {
+	blobURL := fmt.Sprintf("%s/%s/%s?%s", u.endpoint, u.container, escapeBlobPath(key), u.sasToken)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, blobURL, bytes.NewReader(body))
+	if err != nil {
+		return u.uploadError(key, err)
+	}
+	req.Header.Set("x-ms-blob-type", "BlockBlob")
+	req.Header.Set("x-ms-blob-content-type", contentType)
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return u.uploadError(key, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("upload failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
+
+// uploadError reports a failed request for a blob without its URL, whose query string holds the
+// SAS token
+func (u *azureUploader) uploadError(key string, err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+	return fmt.Errorf("upload of %s/%s failed: %v", u.container, key, err)
+}
+
+// escapeBlobPath URI-encodes each segment of a blob name, keeping the slashes between them
+func escapeBlobPath(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		var b strings.Builder
+		for _, c := range []byte(segment) {
+			if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+				c == '-' || c == '_' || c == '.' || c == '~' {
+				b.WriteByte(c)
+			} else {
+				fmt.Fprintf(&b, "%%%02X", c)
+			}
+		}
+		segments[i] = b.String()
+	}
+	return strings.Join(segments, "/")
+}
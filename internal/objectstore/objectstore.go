@@ -0,0 +1,147 @@
+package objectstore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// uploadTimeout bounds a single object upload
+const uploadTimeout = 2 * time.Minute
+
+// Uploader writes objects to a bucket or container
+type Uploader interface {
+	// Upload stores body under key, relative to the bucket or container root
+	Upload(ctx context.Context, key string, body []byte, contentType string) error
+}
+
+// Destination is a parsed object storage URI
+type Destination struct {
+	Uploader Uploader
+	// Prefix is prepended to every object key (no leading or trailing slash)
+	Prefix string
+	// URI is the original destination, for logging
+	URI string
+}
+
+// ParseDestination parses an object storage URI and configures an uploader for it from the
+// environment. Supported schemes:
+//
+//	s3://bucket/prefix               default AWS credential chain (environment, AWS_PROFILE, web identity,
+//	                                 instance roles), AWS_REGION (default us-east-1), AWS_ENDPOINT_URL
+//	gs://bucket/prefix               GCS_HMAC_ACCESS_KEY_ID, GCS_HMAC_SECRET (interoperability HMAC keys)
+//	azblob://account/container/prefix AZURE_STORAGE_SAS_TOKEN, AZURE_STORAGE_BLOB_ENDPOINT (optional)
+func ParseDestination(uri string) (*Destination, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("invalid object storage URI %q: %v", uri, err)
+	}
+	if parsed.Host == "" {
+		return nil, fmt.Errorf("invalid object storage URI %q: missing bucket", uri)
+	}
+	prefix := strings.Trim(parsed.Path, "/")
+
+	switch parsed.Scheme {
+	case "s3":
+		uploader, err := newS3UploaderFromEnv(parsed.Host)
+		if err != nil {
+			return nil, err
+		}
+		return &Destination{Uploader: uploader, Prefix: prefix, URI: uri}, nil
+	case "gs":
+		uploader, err := newGCSUploaderFromEnv(parsed.Host)
+		if err != nil {
+			return nil, err
+		}
+		return &Destination{Uploader: uploader, Prefix: prefix, URI: uri}, nil
+	case "azblob":
+		container, blobPrefix, _ := strings.Cut(prefix, "/")
+		if container == "" {
+			return nil, fmt.Errorf("invalid object storage URI %q: missing container", uri)
+		}
+		uploader, err := newAzureUploaderFromEnv(parsed.Host, container)
+		if err != nil {
+			return nil, err
+		}
+		return &Destination{Uploader: uploader, Prefix: blobPrefix, URI: uri}, nil
+	default:
+		return nil, fmt.Errorf("unsupported object storage scheme %q (allowed values: \"s3\", \"gs\", \"azblob\")", parsed.Scheme)
+	}
+}
+
+// key joins the destination prefix and a relative object name
+func (d *Destination) key(name string) string {
+	if d.Prefix == "" {
+		return name
+	}
+	return path.Join(d.Prefix, name)
+}
+
+// PublishCatalog uploads the catalog file to <prefix>/<catalog file name> and every file in
+// outputDir/<model>/models/ of the given model directories to <prefix>/models/<model>/<file>.
+// Returns the number of objects uploaded.
+func PublishCatalog(ctx context.Context, dest *Destination, catalogPath, outputDir string, modelDirs []string) (int, error) {
+	uploads := map[string]string{
+		dest.key(filepath.Base(catalogPath)): catalogPath,
+	}
+
+	for _, modelDir := range modelDirs {
+		modelsDir := filepath.Join(outputDir, modelDir, "models")
+		entries, err := os.ReadDir(modelsDir)
+		if err != nil {
+			log.Printf("  Warning: Skipping %s: %v", modelsDir, err)
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			uploads[dest.key(path.Join("models", modelDir, entry.Name()))] = filepath.Join(modelsDir, entry.Name())
+		}
+	}
+
+	keys := make([]string, 0, len(uploads))
+	for key := range uploads {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		body, err := os.ReadFile(uploads[key])
+		if err != nil {
+			return 0, fmt.Errorf("failed to read %s: %v", uploads[key], err)
+		}
+
+		uploadCtx, cancel := context.WithTimeout(ctx, uploadTimeout)
+		err = dest.Uploader.Upload(uploadCtx, key, body, contentTypeFor(key))
+		cancel()
+		if err != nil {
+			return 0, fmt.Errorf("failed to upload %s: %v", key, err)
+		}
+	}
+
+	return len(keys), nil
+}
+
+// contentTypeFor returns the content type of an uploaded file based on its extension
+func contentTypeFor(name string) string {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".yaml", ".yml":
+		return "application/yaml"
+	case ".json":
+		return "application/json"
+	case ".ndjson":
+		return "application/x-ndjson"
+	case ".md":
+		return "text/markdown; charset=utf-8"
+	default:
+		return "text/plain; charset=utf-8"
+	}
+}
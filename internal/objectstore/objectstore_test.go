@@ -0,0 +1,154 @@
+package objectstore
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+type recordingUploader struct {
+	objects map[string]string
+}
+
+func (r *recordingUploader) Upload(_ context.Context, key string, body []byte, _ string) error {
+	r.objects[key] = string(body)
+	return nil
+}
+
+func TestParseDestination(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("GCS_HMAC_ACCESS_KEY_ID", "gkey")
+	t.Setenv("GCS_HMAC_SECRET", "gsecret")
+	t.Setenv("AZURE_STORAGE_SAS_TOKEN", "?sv=2022-11-02&sig=abc")
+
+	tests := []struct {
+		uri    string
+		prefix string
+	}{
+		{"s3://catalogs/prod/v1/", "prod/v1"},
+		{"s3://catalogs", ""},
+		{"gs://catalogs/prod", "prod"},
+		{"azblob://account/container/prod/v1", "prod/v1"},
+		{"azblob://account/container", ""},
+	}
+	for _, tt := range tests {
+		dest, err := ParseDestination(tt.uri)
+		if err != nil {
+			t.Errorf("ParseDestination(%q) failed: %v", tt.uri, err)
+			continue
+		}
+		if dest.Prefix != tt.prefix {
+			t.Errorf("ParseDestination(%q) prefix = %q, expected %q", tt.uri, dest.Prefix, tt.prefix)
+		}
+	}
+
+	for _, uri := range []string{"ftp://host/path", "s3:///prefix", "azblob://account", "data/catalog.yaml"} {
+		if _, err := ParseDestination(uri); err == nil {
+			t.Errorf("Expected error for %q", uri)
+		}
+	}
+}
+
+func TestParseDestination_MissingCredentials(t *testing.T) {
+	for _, name := range []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "GCS_HMAC_ACCESS_KEY_ID", "GCS_HMAC_SECRET", "AZURE_STORAGE_SAS_TOKEN"} {
+		t.Setenv(name, "")
+	}
+	// s3:// credentials come from the default AWS chain and are only resolved on first use
+	for _, uri := range []string{"gs://bucket", "azblob://account/container"} {
+		if _, err := ParseDestination(uri); err == nil {
+			t.Errorf("Expected missing credentials error for %q", uri)
+		}
+	}
+}
+
+func TestPublishCatalog(t *testing.T) {
+	tmpDir := t.TempDir()
+	catalogPath := filepath.Join(tmpDir, "models-catalog.yaml")
+	outputDir := filepath.Join(tmpDir, "output")
+	modelDir := filepath.Join(outputDir, "registry.example.com_model_1.0", "models")
+	if err := os.MkdirAll(modelDir, 0755); err != nil {
+		t.Fatalf("Failed to create model dir: %v", err)
+	}
+	files := map[string]string{
+		catalogPath:                              "source: Red Hat\n",
+		filepath.Join(modelDir, "metadata.yaml"): "name: model\n",
+		filepath.Join(modelDir, "modelcard.md"):  "# Model\n",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+
+	uploader := &recordingUploader{objects: map[string]string{}}
+	dest := &Destination{Uploader: uploader, Prefix: "prod"}
+
+	count, err := PublishCatalog(context.Background(), dest, catalogPath, outputDir, []string{"registry.example.com_model_1.0", "missing-model"})
+	if err != nil {
+		t.Fatalf("PublishCatalog failed: %v", err)
+	}
+
+	expected := []string{
+		"prod/models-catalog.yaml",
+		"prod/models/registry.example.com_model_1.0/metadata.yaml",
+		"prod/models/registry.example.com_model_1.0/modelcard.md",
+	}
+	var keys []string
+	for key := range uploader.objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if count != len(expected) || !reflect.DeepEqual(keys, expected) {
+		t.Errorf("Expected objects %v, got %v (count %d)", expected, keys, count)
+	}
+	if uploader.objects["prod/models-catalog.yaml"] != "source: Red Hat\n" {
+		t.Errorf("Unexpected catalog content %q", uploader.objects["prod/models-catalog.yaml"])
+	}
+}
+
+func TestAzureUpload(t *testing.T) {
+	var gotPath, gotQuery, gotBlobType, gotContentType, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotQuery = r.URL.RawQuery
+		gotBlobType = r.Header.Get("x-ms-blob-type")
+		gotContentType = r.Header.Get("x-ms-blob-content-type")
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	t.Setenv("AZURE_STORAGE_SAS_TOKEN", "?sv=2022-11-02&sig=abc")
+	t.Setenv("AZURE_STORAGE_BLOB_ENDPOINT", server.URL)
+
+	dest, err := ParseDestination("azblob://account/catalogs/prod")
+	if err != nil {
+		t.Fatalf("ParseDestination failed: %v", err)
+	}
+	if err := dest.Uploader.Upload(context.Background(), dest.key("models-catalog.yaml"), []byte("source: Red Hat\n"), "application/yaml"); err != nil {
+		t.Fatalf("Upload failed: %v", err)
+	}
+
+	if gotPath != "/catalogs/prod/models-catalog.yaml" || gotQuery != "sv=2022-11-02&sig=abc" {
+		t.Errorf("Unexpected request %s?%s", gotPath, gotQuery)
+	}
+	if gotBlobType != "BlockBlob" || gotContentType != "application/yaml" || gotBody != "source: Red Hat\n" {
+		t.Errorf("Unexpected upload: blobType=%q contentType=%q body=%q", gotBlobType, gotContentType, gotBody)
+	}
+
+	// Connection errors must not leak the SAS token of the blob URL
+	server.Close()
+	err = dest.Uploader.Upload(context.Background(), dest.key("models-catalog.yaml"), []byte("x"), "application/yaml")
+	if err == nil || strings.Contains(err.Error(), "sig=") || !strings.Contains(err.Error(), "catalogs/prod/models-catalog.yaml") {
+		t.Errorf("Upload() error = %v, want the blob path without the SAS token", err)
+	}
+}
@@ -0,0 +1,131 @@
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/httpclient"
+)
+
+// s3Uploader uploads objects with the AWS SDK. It also serves Google Cloud Storage through its
+// S3-compatible XML API.
+type s3Uploader struct {
+	bucket string
+	client *s3.Client
+}
+
+// newS3UploaderFromEnv configures an S3 client from the default AWS credential chain:
+// environment variables, the shared config and credentials files (AWS_PROFILE), web identity
+// tokens (IRSA on EKS), and ECS or EC2 instance roles. Credentials are resolved on first use.
+func newS3UploaderFromEnv(bucket string) (*s3Uploader, error) {
+	cfg, err := config.LoadDefaultConfig(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %v", err)
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+
+	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.HTTPClient = httpclient.New("objectstore", 0)
+		// S3-compatible stores (MinIO, Ceph RGW, ...) set AWS_ENDPOINT_URL, are addressed
+		// path-style and often reject the SDK's default flexible checksums
+		if os.Getenv("AWS_ENDPOINT_URL") != "" || os.Getenv("AWS_ENDPOINT_URL_S3") != "" {
+			o.UsePathStyle = true
+			o.RequestChecksumCalculation = aws.RequestChecksumCalculationWhenRequired
+			o.ResponseChecksumValidation = aws.ResponseChecksumValidationWhenRequired
+		}
+	})
+	return &s3Uploader{bucket: bucket, client: client}, nil
+}
+
+func newGCSUploaderFromEnv(bucket string) (*s3Uploader, error) {
+	accessKeyID := os.Getenv("GCS_HMAC_ACCESS_KEY_ID")
+	secret := os.Getenv("GCS_HMAC_SECRET")
+	if accessKeyID == "" || secret == "" {
+		return nil, fmt.Errorf("gs upload requires GCS_HMAC_ACCESS_KEY_ID and GCS_HMAC_SECRET")
+	}
+
+	client := s3.New(s3.Options{
+		Region:                     "auto",
+		BaseEndpoint:               aws.String("https://storage.googleapis.com"),
+		UsePathStyle:               true,
+		Credentials:                credentials.NewStaticCredentialsProvider(accessKeyID, secret, ""),
+		HTTPClient:                 httpclient.New("objectstore", 0),
+		RequestChecksumCalculation: aws.RequestChecksumCalculationWhenRequired,
+		ResponseChecksumValidation: aws.ResponseChecksumValidationWhenRequired,
+	})
+	return &s3Uploader{bucket: bucket, client: client}, nil
+}
+
+// Upload stores body under key with a single PUT request
+func (u *s3Uploader) Upload(ctx context.Context, key string, body []byte, contentType string) error {
+	_, err := u.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(u.bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(body),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return fmt.Errorf("upload failed: %v", err)
+	}
+	return nil
+}
+
+// ObjectInfo describes a stored object
+type ObjectInfo struct {
+	Size         int64
+	ETag         string
+	LastModified time.Time
+	// ChecksumSHA256 is the base64-encoded SHA-256 checksum of the object, when it was uploaded
+	// with one
+	ChecksumSHA256 string
+}
+
+// StatS3Object describes an object in an S3 bucket with a HEAD request. Credentials and the
+// endpoint are resolved the same way as for s3:// destinations.
+func StatS3Object(ctx context.Context, bucket, key string) (*ObjectInfo, error) {
+	u, err := newS3UploaderFromEnv(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return u.Stat(ctx, key)
+}
+
+// Stat describes the object stored under key from a HEAD request
+func (u *s3Uploader) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	output, err := u.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(u.bucket),
+		Key:    aws.String(key),
+		// Objects uploaded with a checksum only report it when asked to
+		ChecksumMode: s3types.ChecksumModeEnabled,
+	})
+	if err != nil {
+		var notFound *s3types.NotFound
+		if errors.As(err, &notFound) {
+			return nil, fmt.Errorf("s3://%s/%s does not exist", u.bucket, key)
+		}
+		return nil, fmt.Errorf("HEAD s3://%s/%s failed: %v", u.bucket, key, err)
+	}
+
+	info := &ObjectInfo{
+		Size:           aws.ToInt64(output.ContentLength),
+		ETag:           strings.Trim(aws.ToString(output.ETag), `"`),
+		ChecksumSHA256: aws.ToString(output.ChecksumSHA256),
+	}
+	if output.LastModified != nil {
+		info.LastModified = *output.LastModified
+	}
+	return info, nil
+}
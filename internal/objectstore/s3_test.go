@@ -0,0 +1,132 @@
+package objectstore
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setS3TestEnv points the default AWS credential chain at static test credentials and endpoint,
+// isolated from the shared config files and instance metadata of the machine running the tests
+func setS3TestEnv(t *testing.T, endpoint string) {
+	t.Helper()
+	t.Setenv("AWS_ACCESS_KEY_ID", "test-key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test-secret")
+	t.Setenv("AWS_SESSION_TOKEN", "test-token")
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("AWS_ENDPOINT_URL", endpoint)
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(t.TempDir(), "credentials"))
+	t.Setenv("AWS_PROFILE", "")
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+}
+
+func TestS3Upload(t *testing.T) {
+	var gotPath, gotAuth, gotBody, gotContentType, gotToken string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.EscapedPath()
+		gotAuth = r.Header.Get("Authorization")
+		gotContentType = r.Header.Get("Content-Type")
+		gotToken = r.Header.Get("X-Amz-Security-Token")
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	setS3TestEnv(t, server.URL)
+
+	u, err := newS3UploaderFromEnv("catalogs")
+	if err != nil {
+		t.Fatalf("newS3UploaderFromEnv failed: %v", err)
+	}
+
+	if err := u.Upload(context.Background(), "prod/models:1.5.yaml", []byte("source: Red Hat\n"), "application/yaml"); err != nil {
+		t.Fatalf("Upload failed: %v", err)
+	}
+
+	if gotPath != "/catalogs/prod/models%3A1.5.yaml" {
+		t.Errorf("Unexpected request path %q", gotPath)
+	}
+	if !strings.HasPrefix(gotAuth, "AWS4-HMAC-SHA256 Credential=test-key/") || !strings.Contains(gotAuth, "x-amz-security-token") {
+		t.Errorf("Unexpected Authorization header %q", gotAuth)
+	}
+	if gotBody != "source: Red Hat\n" || gotContentType != "application/yaml" || gotToken != "test-token" {
+		t.Errorf("Unexpected request: body=%q contentType=%q token=%q", gotBody, gotContentType, gotToken)
+	}
+}
+
+func TestS3Upload_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("<Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>"))
+	}))
+	defer server.Close()
+	setS3TestEnv(t, server.URL)
+
+	u, err := newS3UploaderFromEnv("b")
+	if err != nil {
+		t.Fatalf("newS3UploaderFromEnv failed: %v", err)
+	}
+	err = u.Upload(context.Background(), "key", []byte("x"), "text/plain")
+	if err == nil || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Errorf("Expected AccessDenied error, got %v", err)
+	}
+}
+
+func TestS3Stat(t *testing.T) {
+	var gotMethod, gotPath, gotAuth, gotChecksumMode string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.EscapedPath()
+		gotAuth = r.Header.Get("Authorization")
+		gotChecksumMode = r.Header.Get("X-Amz-Checksum-Mode")
+		if r.URL.Path == "/models/missing.gguf" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Length", "4096")
+		w.Header().Set("ETag", `"9b2cf535f27731c974343645a3985328"`)
+		w.Header().Set("Last-Modified", "Wed, 21 Oct 2025 07:28:00 GMT")
+		w.Header().Set("X-Amz-Checksum-Sha256", "n4bQgYhMfWWaL+qgxVrQFaO/TxsrC4Is0V1sFbDwCgg=")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	setS3TestEnv(t, server.URL)
+
+	info, err := StatS3Object(context.Background(), "models", "granite/model.gguf")
+	if err != nil {
+		t.Fatalf("StatS3Object failed: %v", err)
+	}
+	if gotMethod != http.MethodHead || gotPath != "/models/granite/model.gguf" || gotChecksumMode != "ENABLED" ||
+		!strings.HasPrefix(gotAuth, "AWS4-HMAC-SHA256 Credential=test-key/") {
+		t.Errorf("Unexpected request: method=%s path=%s checksumMode=%q auth=%q", gotMethod, gotPath, gotChecksumMode, gotAuth)
+	}
+	if info.Size != 4096 || info.ETag != "9b2cf535f27731c974343645a3985328" ||
+		!info.LastModified.Equal(time.Date(2025, 10, 21, 7, 28, 0, 0, time.UTC)) ||
+		info.ChecksumSHA256 != "n4bQgYhMfWWaL+qgxVrQFaO/TxsrC4Is0V1sFbDwCgg=" {
+		t.Errorf("Unexpected object info: %+v", info)
+	}
+
+	if _, err := StatS3Object(context.Background(), "models", "missing.gguf"); err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("Expected a missing object error, got %v", err)
+	}
+}
+
+func TestEscapeBlobPath(t *testing.T) {
+	tests := map[string]string{
+		"catalog/models-catalog.yaml":                "catalog/models-catalog.yaml",
+		"models/registry.redhat.io_model:1.5/x.yaml": "models/registry.redhat.io_model%3A1.5/x.yaml",
+		"a b/c+d": "a%20b/c%2Bd",
+	}
+	for input, expected := range tests {
+		if got := escapeBlobPath(input); got != expected {
+			t.Errorf("escapeBlobPath(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
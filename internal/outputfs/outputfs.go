@@ -0,0 +1,152 @@
+// Package outputfs is the filesystem the pipeline reads and writes its per-model output through.
+// Names are slash-separated and relative to the output directory, so the code handling the output
+// does not depend on the working directory and can be pointed at any directory.
+package outputfs
+
+import (
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// FS is a writable filesystem rooted at the output directory. Names follow the io/fs rules:
+// slash-separated, unrooted and without "." or ".." elements, so nothing outside the root can be
+// reached.
+type FS interface {
+	fs.ReadFileFS
+	fs.ReadDirFS
+	fs.StatFS
+
+	// WriteFile writes data to the named file, creating it with perm if needed
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	// MkdirAll creates the named directory along with any missing parents
+	MkdirAll(name string, perm fs.FileMode) error
+	// Rename moves oldname to newname, replacing newname if it exists
+	Rename(oldname, newname string) error
+	// Remove removes the named file or empty directory
+	Remove(name string) error
+	// Path returns the location of name on disk, for messages and for tools that need a real path
+	Path(name string) string
+}
+
+// dirFS is an FS backed by a directory on disk
+type dirFS struct {
+	fs.FS
+	root string
+}
+
+// Dir returns an FS rooted at the directory root. The directory does not have to exist yet.
+func Dir(root string) FS {
+	return &dirFS{FS: os.DirFS(root), root: root}
+}
+
+// ModelPath returns the name of a file in the output directory of the model, e.g.
+// ModelPath(ref, "metadata.yaml") is <sanitized ref>_<ref digest>/models/metadata.yaml
+func ModelPath(ref string, elem ...string) string {
+	return path.Join(append([]string{utils.SanitizeManifestRef(ref), "models"}, elem...)...)
+}
+
+// resolve returns the OS path of name, rejecting names that are not valid io/fs names
+func (d *dirFS) resolve(op, name string) (string, error) {
+	if !fs.ValidPath(name) {
+		return "", &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	return d.Path(name), nil
+}
+
+func (d *dirFS) ReadFile(name string) ([]byte, error) {
+	return fs.ReadFile(d.FS, name)
+}
+
+func (d *dirFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return fs.ReadDir(d.FS, name)
+}
+
+func (d *dirFS) Stat(name string) (fs.FileInfo, error) {
+	return fs.Stat(d.FS, name)
+}
+
+func (d *dirFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	p, err := d.resolve("write", name)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p, data, perm)
+}
+
+func (d *dirFS) MkdirAll(name string, perm fs.FileMode) error {
+	p, err := d.resolve("mkdir", name)
+	if err != nil {
+		return err
+	}
+	return os.MkdirAll(p, perm)
+}
+
+func (d *dirFS) Rename(oldname, newname string) error {
+	oldPath, err := d.resolve("rename", oldname)
+	if err != nil {
+		return err
+	}
+	newPath, err := d.resolve("rename", newname)
+	if err != nil {
+		return err
+	}
+	return os.Rename(oldPath, newPath)
+}
+
+func (d *dirFS) Remove(name string) error {
+	p, err := d.resolve("remove", name)
+	if err != nil {
+		return err
+	}
+	return os.Remove(p)
+}
+
+func (d *dirFS) Path(name string) string {
+	return filepath.Join(d.root, filepath.FromSlash(name))
+}
+
+// MigrateModelDirs renames model output directories written before directory names carried a
+// digest of the ref (see utils.LegacySanitizeManifestRef) to their current names, so their output
+// is reused. A legacy directory shared by several refs is left in place, since its content belongs
+// to only one of them; those models are extracted again. It returns the number of renamed
+// directories.
+func MigrateModelDirs(output FS, refs []string) (int, error) {
+	legacyOwner := make(map[string]string)
+	shared := make(map[string]bool)
+	for _, ref := range refs {
+		legacy := utils.LegacySanitizeManifestRef(ref)
+		if owner, exists := legacyOwner[legacy]; exists && owner != ref {
+			shared[legacy] = true
+		}
+		legacyOwner[legacy] = ref
+	}
+
+	migrated := 0
+	for _, ref := range refs {
+		legacy, current := utils.LegacySanitizeManifestRef(ref), utils.SanitizeManifestRef(ref)
+		if legacy == current {
+			continue
+		}
+		if _, err := output.Stat(legacy); err != nil {
+			continue
+		}
+		if _, err := output.Stat(current); err == nil {
+			continue
+		}
+		if shared[legacy] {
+			log.Printf("  Warning: Not migrating %s, it is shared by several models that will be extracted again", output.Path(legacy))
+			continue
+		}
+		if err := output.Rename(legacy, current); err != nil {
+			return migrated, fmt.Errorf("error migrating %s: %v", output.Path(legacy), err)
+		}
+		migrated++
+	}
+	return migrated, nil
+}
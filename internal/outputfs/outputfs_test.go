@@ -0,0 +1,94 @@
+package outputfs
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+func TestDir(t *testing.T) {
+	root := t.TempDir()
+	output := Dir(root)
+	ref := "registry.example.com/org/model:1.0"
+
+	if err := output.MkdirAll(ModelPath(ref), 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := output.WriteFile(ModelPath(ref, "metadata.yaml.tmp"), []byte("name: model\n"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := output.Rename(ModelPath(ref, "metadata.yaml.tmp"), ModelPath(ref, "metadata.yaml")); err != nil {
+		t.Fatalf("Rename failed: %v", err)
+	}
+
+	want := filepath.Join(root, utils.SanitizeManifestRef(ref), "models", "metadata.yaml")
+	if got := output.Path(ModelPath(ref, "metadata.yaml")); got != want {
+		t.Errorf("Path() = %s, want %s", got, want)
+	}
+	data, err := os.ReadFile(want)
+	if err != nil || string(data) != "name: model\n" {
+		t.Errorf("Expected the file on disk, got %q (err %v)", data, err)
+	}
+	if data, err := output.ReadFile(ModelPath(ref, "metadata.yaml")); err != nil || string(data) != "name: model\n" {
+		t.Errorf("ReadFile() = %q, %v", data, err)
+	}
+
+	if err := output.Remove(ModelPath(ref, "metadata.yaml")); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if _, err := output.Stat(ModelPath(ref, "metadata.yaml")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected the removed file to be gone, got %v", err)
+	}
+}
+
+func TestDir_InvalidNames(t *testing.T) {
+	output := Dir(t.TempDir())
+	for _, name := range []string{"../escape.yaml", "/abs.yaml", "a/../../b"} {
+		if err := output.WriteFile(name, []byte("x"), 0644); !errors.Is(err, fs.ErrInvalid) {
+			t.Errorf("WriteFile(%q) error = %v, want fs.ErrInvalid", name, err)
+		}
+		if _, err := output.ReadFile(name); err == nil {
+			t.Errorf("ReadFile(%q) succeeded outside the output directory", name)
+		}
+	}
+}
+
+func TestMigrateModelDirs(t *testing.T) {
+	root := t.TempDir()
+	output := Dir(root)
+	migratedRef := "registry.example.com/org/model:1.0"
+	sharedRefs := []string{"registry.example.com/a/b:1", "registry.example.com/a:b/1"}
+
+	for _, ref := range []string{migratedRef, sharedRefs[0]} {
+		legacy := utils.LegacySanitizeManifestRef(ref)
+		if err := output.MkdirAll(legacy+"/models", 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := output.WriteFile(legacy+"/models/metadata.yaml", []byte("name: "+ref+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	migrated, err := MigrateModelDirs(output, append([]string{migratedRef}, sharedRefs...))
+	if err != nil {
+		t.Fatalf("MigrateModelDirs failed: %v", err)
+	}
+	if migrated != 1 {
+		t.Errorf("Expected 1 migrated directory, got %d", migrated)
+	}
+	if data, err := output.ReadFile(ModelPath(migratedRef, "metadata.yaml")); err != nil || string(data) != "name: "+migratedRef+"\n" {
+		t.Errorf("Expected the output under the new name, got %q (err %v)", data, err)
+	}
+	if _, err := output.Stat(utils.LegacySanitizeManifestRef(sharedRefs[0])); err != nil {
+		t.Errorf("Expected the shared legacy directory to be left in place: %v", err)
+	}
+	for _, ref := range sharedRefs {
+		if _, err := output.Stat(ModelPath(ref)); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("Expected no migrated output for %s, got %v", ref, err)
+		}
+	}
+}
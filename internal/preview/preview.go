@@ -0,0 +1,108 @@
+package preview
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/catalog"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+//go:embed templates/catalog.html.tmpl
+var templateFS embed.FS
+
+var pageTemplate = template.Must(template.ParseFS(templateFS, "templates/catalog.html.tmpl"))
+
+// highlightLabels are the model labels shown as badges on each card
+var highlightLabels = []string{"validated", "featured"}
+
+// page is the data rendered by the catalog template
+type page struct {
+	Title  string
+	Source string
+	Models []card
+}
+
+// card is the template-friendly view of one catalog model
+type card struct {
+	Name        string
+	Provider    string
+	Description string
+	Logo        template.URL
+	License     string
+	LicenseLink string
+	Tasks       []string
+	Labels      []string
+	Artifacts   []string
+	Deprecated  bool
+	EndOfLife   string
+	ReplacedBy  string
+}
+
+// Render writes a static HTML page with one card per catalog model to w
+func Render(w io.Writer, modelsCatalog *types.ModelsCatalog, title string) error {
+	data := page{Title: title, Source: modelsCatalog.Source}
+	for _, model := range modelsCatalog.Models {
+		data.Models = append(data.Models, newCard(model))
+	}
+	return pageTemplate.Execute(w, data)
+}
+
+// newCard converts a catalog model to its card view
+func newCard(model types.CatalogMetadata) card {
+	c := card{
+		Name:        utils.StringValue(model.Name),
+		Provider:    utils.StringValue(model.Provider),
+		Description: utils.StringValue(model.Description),
+		License:     utils.StringValue(model.License),
+		Tasks:       model.Tasks,
+		Deprecated:  model.Deprecated,
+		EndOfLife:   utils.StringValue(model.EndOfLife),
+		ReplacedBy:  utils.StringValue(model.ReplacedBy),
+	}
+	if c.Name == "" {
+		c.Name = "(unnamed model)"
+	}
+
+	// Logos are generated data URIs, which html/template would otherwise reject as unsafe
+	if logo := utils.StringValue(model.Logo); strings.HasPrefix(logo, "data:image/") {
+		c.Logo = template.URL(logo)
+	}
+	if link := utils.StringValue(model.LicenseLink); strings.HasPrefix(link, "https://") || strings.HasPrefix(link, "http://") {
+		c.LicenseLink = link
+	}
+
+	for _, label := range highlightLabels {
+		if catalog.HasLabel(model, label) {
+			c.Labels = append(c.Labels, label)
+		}
+	}
+	for _, artifact := range model.Artifacts {
+		c.Artifacts = append(c.Artifacts, artifact.URI)
+	}
+	return c
+}
+
+// WriteFile renders the catalog at catalogPath to an HTML file at outputPath
+func WriteFile(catalogPath, outputPath string) (int, error) {
+	modelsCatalog, err := catalog.ReadCatalog(catalogPath)
+	if err != nil {
+		return 0, err
+	}
+
+	var b strings.Builder
+	title := fmt.Sprintf("Catalog preview: %s", filepath.Base(catalogPath))
+	if err := Render(&b, modelsCatalog, title); err != nil {
+		return 0, fmt.Errorf("error rendering catalog preview: %v", err)
+	}
+	if err := os.WriteFile(outputPath, []byte(b.String()), 0644); err != nil {
+		return 0, fmt.Errorf("error writing catalog preview: %v", err)
+	}
+	return len(modelsCatalog.Models), nil
+}
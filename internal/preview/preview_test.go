@@ -0,0 +1,104 @@
+package preview
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestRender(t *testing.T) {
+	modelsCatalog := &types.ModelsCatalog{
+		Source: "Red Hat",
+		Models: []types.CatalogMetadata{
+			{
+				Name:        stringPtr("granite-3.1-8b-instruct"),
+				Provider:    stringPtr("IBM"),
+				Description: stringPtr("A <b>granite</b> model"),
+				Logo:        stringPtr("data:image/svg+xml;base64,PHN2Zy8+"),
+				License:     stringPtr("apache-2.0"),
+				LicenseLink: stringPtr("https://www.apache.org/licenses/LICENSE-2.0"),
+				Tasks:       []string{"text-generation"},
+				CustomProperties: map[string]types.MetadataValue{
+					"validated": {MetadataType: "MetadataStringValue"},
+					"chat":      {MetadataType: "MetadataStringValue"},
+				},
+				Artifacts: []types.CatalogOCIArtifact{{URI: "oci://registry.example.com/granite:1.5"}},
+			},
+			{
+				Name:       stringPtr("old-model"),
+				Logo:       stringPtr("javascript:alert(1)"),
+				Deprecated: true,
+				EndOfLife:  stringPtr("2026-12-31"),
+				ReplacedBy: stringPtr("granite-3.1-8b-instruct"),
+			},
+		},
+	}
+
+	var b strings.Builder
+	if err := Render(&b, modelsCatalog, "Catalog preview"); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	html := b.String()
+
+	for _, expected := range []string{
+		"<title>Catalog preview</title>",
+		"2 models from Red Hat",
+		`<img src="data:image/svg&#43;xml;base64,PHN2Zy8&#43;"`,
+		"<h2>granite-3.1-8b-instruct</h2>",
+		`<div class="provider">IBM</div>`,
+		"A &lt;b&gt;granite&lt;/b&gt; model",
+		`<a href="https://www.apache.org/licenses/LICENSE-2.0">apache-2.0</a>`,
+		`<span class="badge">text-generation</span>`,
+		`<span class="badge label">validated</span>`,
+		"oci://registry.example.com/granite:1.5",
+		`class="card deprecated"`,
+		"end of life 2026-12-31",
+		"<dd>granite-3.1-8b-instruct</dd>",
+	} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("Expected preview to contain %q", expected)
+		}
+	}
+
+	if strings.Contains(html, "javascript:") {
+		t.Error("Expected non-image logos to be dropped")
+	}
+	if strings.Contains(html, `badge label">chat`) {
+		t.Error("Expected only highlighted labels to be shown")
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	catalogPath := filepath.Join(tmpDir, "models-catalog.yaml")
+	if err := os.WriteFile(catalogPath, []byte("source: Red Hat\nmodels:\n  - name: model-a\n"), 0644); err != nil {
+		t.Fatalf("Failed to write catalog: %v", err)
+	}
+
+	outputPath := filepath.Join(tmpDir, "preview.html")
+	models, err := WriteFile(catalogPath, outputPath)
+	if err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if models != 1 {
+		t.Errorf("Expected 1 model, got %d", models)
+	}
+	data, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to read preview: %v", err)
+	}
+	if !strings.Contains(string(data), "<h2>model-a</h2>") {
+		t.Error("Expected preview to contain the model card")
+	}
+
+	if _, err := WriteFile(filepath.Join(tmpDir, "missing.yaml"), outputPath); err == nil {
+		t.Error("Expected error for a missing catalog")
+	}
+}
@@ -0,0 +1,193 @@
+package publish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/oci/layout"
+	blobinfocachememory "github.com/containers/image/v5/pkg/blobinfocache/memory"
+	containertypes "github.com/containers/image/v5/types"
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Media types of the catalog artifact. Like ORAS, the manifest carries an artifactType and an
+// empty config, and each file is a layer whose title annotation is the file's relative path.
+const (
+	ArtifactType           = "application/vnd.opendatahub.model-catalog.v1"
+	MediaTypeCatalogYAML   = "application/vnd.opendatahub.model-catalog.v1+yaml"
+	MediaTypeCatalogJSON   = "application/vnd.opendatahub.model-catalog.v1+json"
+	MediaTypeCatalogNDJSON = "application/vnd.opendatahub.model-catalog.v1+ndjson"
+	MediaTypeModelReadme   = "text/markdown"
+)
+
+// emptyConfig is the content of the OCI empty descriptor used as the artifact config
+var emptyConfig = []byte("{}")
+
+// File is a file packaged as one layer of the catalog artifact
+type File struct {
+	// Path is the location of the file on disk
+	Path string
+	// Title is the relative path clients such as `oras pull` restore the file to
+	Title string
+	// MediaType is the layer media type
+	MediaType string
+}
+
+// Options controls where and how the catalog artifact is pushed
+type Options struct {
+	// Destination is a registry reference (quay.io/org/catalog:tag, optionally prefixed with
+	// docker://) or a local OCI layout (oci:/path/to/layout[:tag])
+	Destination string
+	// Annotations are added to the artifact manifest
+	Annotations map[string]string
+	// SystemContext carries registry credentials and TLS settings
+	SystemContext *containertypes.SystemContext
+}
+
+// CatalogMediaType returns the layer media type for a catalog file based on its extension
+func CatalogMediaType(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return MediaTypeCatalogJSON
+	case ".ndjson":
+		return MediaTypeCatalogNDJSON
+	default:
+		return MediaTypeCatalogYAML
+	}
+}
+
+// CollectFiles returns the files making up the catalog artifact: the catalog itself and, when
+// includeReadmes is set, the modelcard.md of every model in outputDir, titled readmes/<model>.md
+func CollectFiles(catalogPath, outputDir string, includeReadmes bool) ([]File, error) {
+	if _, err := os.Stat(catalogPath); err != nil {
+		return nil, fmt.Errorf("catalog not found: %v", err)
+	}
+
+	files := []File{{
+		Path:      catalogPath,
+		Title:     filepath.Base(catalogPath),
+		MediaType: CatalogMediaType(catalogPath),
+	}}
+
+	if !includeReadmes {
+		return files, nil
+	}
+
+	readmes, err := filepath.Glob(filepath.Join(outputDir, "*", "models", "modelcard.md"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list model readmes: %v", err)
+	}
+	sort.Strings(readmes)
+
+	for _, readme := range readmes {
+		modelDir := filepath.Base(filepath.Dir(filepath.Dir(readme)))
+		files = append(files, File{
+			Path:      readme,
+			Title:     "readmes/" + modelDir + ".md",
+			MediaType: MediaTypeModelReadme,
+		})
+	}
+
+	return files, nil
+}
+
+// ParseReference resolves a registry reference (optionally prefixed with docker://) or a local OCI
+// layout (oci:/path/to/layout[:tag]) to an image reference
+func ParseReference(reference string) (containertypes.ImageReference, error) {
+	if strings.HasPrefix(reference, "oci:") {
+		return layout.ParseReference(strings.TrimPrefix(reference, "oci:"))
+	}
+	return docker.ParseReference("//" + strings.TrimPrefix(reference, "docker://"))
+}
+
+// Push uploads files as a single OCI artifact and returns the digest of its manifest
+func Push(ctx context.Context, files []File, opts Options) (digest.Digest, error) {
+	if len(files) == 0 {
+		return "", fmt.Errorf("no files to publish")
+	}
+
+	ref, err := ParseReference(opts.Destination)
+	if err != nil {
+		return "", fmt.Errorf("invalid destination %q: %v", opts.Destination, err)
+	}
+
+	dest, err := ref.NewImageDestination(ctx, opts.SystemContext)
+	if err != nil {
+		return "", fmt.Errorf("failed to open destination %s: %v", opts.Destination, err)
+	}
+	defer func() { _ = dest.Close() }()
+
+	cache := blobinfocachememory.New()
+
+	config, err := putBlob(ctx, dest, cache, emptyConfig, imgspecv1.MediaTypeEmptyJSON, nil, true)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload artifact config: %v", err)
+	}
+
+	var layers []imgspecv1.Descriptor
+	for _, file := range files {
+		content, err := os.ReadFile(file.Path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %v", file.Path, err)
+		}
+		layer, err := putBlob(ctx, dest, cache, content, file.MediaType, map[string]string{
+			imgspecv1.AnnotationTitle: file.Title,
+		}, false)
+		if err != nil {
+			return "", fmt.Errorf("failed to upload %s: %v", file.Path, err)
+		}
+		log.Printf("  Uploaded %s (%d bytes)", file.Title, layer.Size)
+		layers = append(layers, layer)
+	}
+
+	manifest := imgspecv1.Manifest{
+		Versioned:    specs.Versioned{SchemaVersion: 2},
+		MediaType:    imgspecv1.MediaTypeImageManifest,
+		ArtifactType: ArtifactType,
+		Config:       config,
+		Layers:       layers,
+		Annotations:  opts.Annotations,
+	}
+	manifestBytes, err := json.Marshal(manifest)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode manifest: %v", err)
+	}
+
+	if err := dest.PutManifest(ctx, manifestBytes, nil); err != nil {
+		return "", fmt.Errorf("failed to upload manifest: %v", err)
+	}
+	if err := dest.Commit(ctx, nil); err != nil {
+		return "", fmt.Errorf("failed to commit artifact: %v", err)
+	}
+
+	return digest.FromBytes(manifestBytes), nil
+}
+
+// putBlob uploads content and returns its descriptor
+func putBlob(ctx context.Context, dest containertypes.ImageDestination, cache containertypes.BlobInfoCache, content []byte, mediaType string, annotations map[string]string, isConfig bool) (imgspecv1.Descriptor, error) {
+	info := containertypes.BlobInfo{
+		Digest:    digest.FromBytes(content),
+		Size:      int64(len(content)),
+		MediaType: mediaType,
+	}
+	uploaded, err := dest.PutBlob(ctx, bytes.NewReader(content), info, cache, isConfig)
+	if err != nil {
+		return imgspecv1.Descriptor{}, err
+	}
+	return imgspecv1.Descriptor{
+		MediaType:   mediaType,
+		Digest:      uploaded.Digest,
+		Size:        uploaded.Size,
+		Annotations: annotations,
+	}, nil
+}
@@ -0,0 +1,130 @@
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+func TestCatalogMediaType(t *testing.T) {
+	tests := map[string]string{
+		"data/models-catalog.yaml":   MediaTypeCatalogYAML,
+		"data/models-catalog.yml":    MediaTypeCatalogYAML,
+		"data/models-catalog.json":   MediaTypeCatalogJSON,
+		"data/models-catalog.ndjson": MediaTypeCatalogNDJSON,
+	}
+	for path, expected := range tests {
+		if got := CatalogMediaType(path); got != expected {
+			t.Errorf("CatalogMediaType(%q) = %q, expected %q", path, got, expected)
+		}
+	}
+}
+
+func TestCollectFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	catalogPath := filepath.Join(tmpDir, "models-catalog.yaml")
+	outputDir := filepath.Join(tmpDir, "output")
+	writeFile(t, catalogPath, "source: Red Hat\nmodels: []\n")
+	writeFile(t, filepath.Join(outputDir, "model-b", "models", "modelcard.md"), "# B")
+	writeFile(t, filepath.Join(outputDir, "model-a", "models", "modelcard.md"), "# A")
+	writeFile(t, filepath.Join(outputDir, "model-c", "models", "metadata.yaml"), "name: c")
+
+	files, err := CollectFiles(catalogPath, outputDir, true)
+	if err != nil {
+		t.Fatalf("CollectFiles failed: %v", err)
+	}
+
+	expected := []string{"models-catalog.yaml", "readmes/model-a.md", "readmes/model-b.md"}
+	if len(files) != len(expected) {
+		t.Fatalf("Expected %d files, got %d: %+v", len(expected), len(files), files)
+	}
+	for i, title := range expected {
+		if files[i].Title != title {
+			t.Errorf("Expected file %d to be titled %q, got %q", i, title, files[i].Title)
+		}
+	}
+
+	files, err = CollectFiles(catalogPath, outputDir, false)
+	if err != nil || len(files) != 1 {
+		t.Errorf("Expected only the catalog without readmes, got %+v (err %v)", files, err)
+	}
+
+	if _, err := CollectFiles(filepath.Join(tmpDir, "missing.yaml"), outputDir, true); err == nil {
+		t.Error("Expected error for missing catalog")
+	}
+}
+
+func TestPush_OCILayout(t *testing.T) {
+	tmpDir := t.TempDir()
+	catalogPath := filepath.Join(tmpDir, "models-catalog.yaml")
+	outputDir := filepath.Join(tmpDir, "output")
+	writeFile(t, catalogPath, "source: Red Hat\nmodels: []\n")
+	writeFile(t, filepath.Join(outputDir, "model-a", "models", "modelcard.md"), "# A")
+
+	files, err := CollectFiles(catalogPath, outputDir, true)
+	if err != nil {
+		t.Fatalf("CollectFiles failed: %v", err)
+	}
+
+	layoutDir := filepath.Join(tmpDir, "layout")
+	manifestDigest, err := Push(context.Background(), files, Options{
+		Destination: "oci:" + layoutDir + ":v1",
+		Annotations: map[string]string{imgspecv1.AnnotationCreated: "2026-01-01T00:00:00Z"},
+	})
+	if err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(layoutDir, "blobs", manifestDigest.Algorithm().String(), manifestDigest.Encoded()))
+	if err != nil {
+		t.Fatalf("Failed to read pushed manifest: %v", err)
+	}
+	var manifest imgspecv1.Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+
+	if manifest.ArtifactType != ArtifactType {
+		t.Errorf("Expected artifactType %q, got %q", ArtifactType, manifest.ArtifactType)
+	}
+	if manifest.Config.MediaType != imgspecv1.MediaTypeEmptyJSON {
+		t.Errorf("Expected empty config, got %q", manifest.Config.MediaType)
+	}
+	if manifest.Annotations[imgspecv1.AnnotationCreated] != "2026-01-01T00:00:00Z" {
+		t.Errorf("Expected manifest annotations to be set, got %v", manifest.Annotations)
+	}
+	if len(manifest.Layers) != 2 {
+		t.Fatalf("Expected 2 layers, got %d", len(manifest.Layers))
+	}
+	if manifest.Layers[0].MediaType != MediaTypeCatalogYAML || manifest.Layers[0].Annotations[imgspecv1.AnnotationTitle] != "models-catalog.yaml" {
+		t.Errorf("Unexpected catalog layer: %+v", manifest.Layers[0])
+	}
+	if manifest.Layers[1].MediaType != MediaTypeModelReadme || manifest.Layers[1].Annotations[imgspecv1.AnnotationTitle] != "readmes/model-a.md" {
+		t.Errorf("Unexpected readme layer: %+v", manifest.Layers[1])
+	}
+
+	catalogBlob := filepath.Join(layoutDir, "blobs", "sha256", manifest.Layers[0].Digest.Encoded())
+	if content, err := os.ReadFile(catalogBlob); err != nil || string(content) != "source: Red Hat\nmodels: []\n" {
+		t.Errorf("Expected catalog content in layer blob, got %q (err %v)", content, err)
+	}
+}
+
+func TestPush_NoFiles(t *testing.T) {
+	if _, err := Push(context.Background(), nil, Options{Destination: "oci:" + t.TempDir()}); err == nil {
+		t.Error("Expected error when there are no files to publish")
+	}
+}
@@ -0,0 +1,231 @@
+// Package pyxis reads the certified-image metadata of Red Hat registry images from the Red Hat
+// container catalog API (Pyxis): freshness grades, the advisories that shipped an image and the
+// description of its repository.
+package pyxis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/httpclient"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// DefaultURL is the public Pyxis API
+const DefaultURL = "https://catalog.redhat.com/api/containers"
+
+// catalogURL is the web front end of the container catalog
+const catalogURL = "https://catalog.redhat.com/software/containers"
+
+// advisoryURL is the errata page of an advisory ID such as RHBA-2025:1234
+const advisoryURL = "https://access.redhat.com/errata/"
+
+// maxResponseSize caps the response bodies read from Pyxis
+const maxResponseSize = 5 * 1024 * 1024
+
+// pyxisRegistries maps the registries whose images Pyxis describes to the registry name Pyxis
+// files them under; registry.redhat.io serves the images Pyxis lists for registry.access.redhat.com
+var pyxisRegistries = map[string]string{
+	"registry.redhat.io":          "registry.access.redhat.com",
+	"registry.access.redhat.com":  "registry.access.redhat.com",
+	"registry.connect.redhat.com": "registry.connect.redhat.com",
+}
+
+// ErrNotFound is returned when Pyxis has no repository or image for a reference
+var ErrNotFound = errors.New("not found in the Red Hat container catalog")
+
+// Client calls the Pyxis API
+type Client struct {
+	baseURL string
+	apiKey  string
+	http    *http.Client
+}
+
+// NewClient returns a client for the Pyxis API at apiURL (DefaultURL when empty). A non-empty
+// apiKey is sent as X-API-KEY; the public catalog does not need one.
+func NewClient(apiURL, apiKey string) (*Client, error) {
+	if apiURL == "" {
+		apiURL = DefaultURL
+	}
+	u, err := url.Parse(apiURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid Pyxis URL %q", apiURL)
+	}
+	return &Client{
+		baseURL: strings.TrimSuffix(u.String(), "/"),
+		apiKey:  apiKey,
+		http:    httpclient.New("pyxis", 30*time.Second),
+	}, nil
+}
+
+// Supported reports whether Pyxis describes the images of an artifact URI's registry
+func Supported(uri string) bool {
+	parsed, err := utils.ParseArtifactURI(uri)
+	if err != nil || parsed.Scheme != utils.URISchemeOCI {
+		return false
+	}
+	_, ok := pyxisRegistries[parsed.Registry]
+	return ok
+}
+
+// Repository is the part of a Pyxis container repository that is recorded
+type Repository struct {
+	ID          string `json:"_id"`
+	Registry    string `json:"registry"`
+	Repository  string `json:"repository"`
+	Description string `json:"description"`
+	DisplayData struct {
+		ShortDescription string `json:"short_description"`
+	} `json:"display_data"`
+}
+
+// Summary returns the short description of the repository, or its description
+func (r *Repository) Summary() string {
+	if summary := strings.TrimSpace(r.DisplayData.ShortDescription); summary != "" {
+		return summary
+	}
+	return strings.TrimSpace(r.Description)
+}
+
+// Image is the part of a Pyxis container image that is recorded
+type Image struct {
+	ImageID         string `json:"image_id"`
+	FreshnessGrades []struct {
+		Grade     string `json:"grade"`
+		StartDate string `json:"start_date"`
+		EndDate   string `json:"end_date"`
+	} `json:"freshness_grades"`
+	Repositories []struct {
+		Registry              string `json:"registry"`
+		Repository            string `json:"repository"`
+		ImageAdvisoryID       string `json:"image_advisory_id"`
+		ManifestListDigest    string `json:"manifest_list_digest"`
+		ManifestSchema2Digest string `json:"manifest_schema2_digest"`
+	} `json:"repositories"`
+}
+
+// FreshnessGrade returns the grade of the image at now: the grade whose period contains now, or
+// "" when none does
+func (i *Image) FreshnessGrade(now time.Time) string {
+	for _, grade := range i.FreshnessGrades {
+		start, err := time.Parse(time.RFC3339, grade.StartDate)
+		if err != nil || now.Before(start) {
+			continue
+		}
+		if grade.EndDate != "" {
+			if end, err := time.Parse(time.RFC3339, grade.EndDate); err == nil && !now.Before(end) {
+				continue
+			}
+		}
+		return grade.Grade
+	}
+	return ""
+}
+
+// Lookup returns the Red Hat container catalog record of an image reference of a supported
+// registry, and the summary of its repository. It fails with ErrNotFound when Pyxis has no
+// repository or image for the reference.
+func (c *Client) Lookup(ctx context.Context, imageRef string, now time.Time) (*types.RedHatCatalogInfo, string, error) {
+	parsed, err := utils.ParseArtifactURI(imageRef)
+	if err != nil || parsed.Scheme != utils.URISchemeOCI {
+		return nil, "", fmt.Errorf("%s is not an image reference", imageRef)
+	}
+	registry, ok := pyxisRegistries[parsed.Registry]
+	if !ok {
+		return nil, "", fmt.Errorf("images of %s are not in the Red Hat container catalog", parsed.Registry)
+	}
+
+	// Parsed repositories only hold path-safe characters; Pyxis takes their slashes unescaped
+	var repository Repository
+	repositoryPath := "/v1/repositories/registry/" + registry + "/repository/" + parsed.Repository
+	if err := c.get(ctx, repositoryPath, nil, &repository); err != nil {
+		return nil, "", err
+	}
+
+	image, err := c.image(ctx, registry, parsed)
+	if err != nil {
+		return nil, "", err
+	}
+
+	info := &types.RedHatCatalogInfo{FreshnessGrade: image.FreshnessGrade(now)}
+	if repository.ID != "" {
+		info.CatalogURL = catalogURL + "/" + parsed.Repository + "/" + repository.ID
+	}
+	seen := make(map[string]bool)
+	for _, repo := range image.Repositories {
+		if repo.Registry != registry || repo.Repository != parsed.Repository || repo.ImageAdvisoryID == "" || seen[repo.ImageAdvisoryID] {
+			continue
+		}
+		seen[repo.ImageAdvisoryID] = true
+		info.Advisories = append(info.Advisories, advisoryURL+repo.ImageAdvisoryID)
+	}
+	return info, repository.Summary(), nil
+}
+
+// image returns the image of a reference by its digest, or else by its tag. Multi-architecture
+// images are listed once per architecture; their grades and advisories are the same.
+func (c *Client) image(ctx context.Context, registry string, parsed *utils.ArtifactURI) (*Image, error) {
+	scope := fmt.Sprintf("repositories.registry==%s;repositories.repository==%s", registry, parsed.Repository)
+	filter := fmt.Sprintf("%s;repositories.tags.name==%s", scope, parsed.Tag)
+	if parsed.Digest != "" {
+		filter = fmt.Sprintf("%s;(image_id==%s,repositories.manifest_list_digest==%s,repositories.manifest_schema2_digest==%s)", scope, parsed.Digest, parsed.Digest, parsed.Digest)
+	}
+
+	var images struct {
+		Data []Image `json:"data"`
+	}
+	query := url.Values{"filter": {filter}, "page_size": {"10"}}
+	if err := c.get(ctx, "/v1/images", query, &images); err != nil {
+		return nil, err
+	}
+	if len(images.Data) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, parsed.ImageReference())
+	}
+	return &images.Data[0], nil
+}
+
+// get sends a GET request and decodes the JSON response into out. A 404 response returns
+// ErrNotFound.
+func (c *Client) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("X-API-KEY", c.apiKey)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return fmt.Errorf("error reading response of %s: %v", path, err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", ErrNotFound, path)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("GET %s returned HTTP %d: %s", path, resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("error decoding response of %s: %v", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package pyxis
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newTestServer serves the repository and the images of rhelai1/modelcar-granite
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/repositories/registry/registry.access.redhat.com/repository/rhelai1/modelcar-granite", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-KEY") != "secret" {
+			t.Errorf("Expected the API key to be sent, got %q", r.Header.Get("X-API-KEY"))
+		}
+		_, _ = w.Write([]byte(`{"_id": "65a1b2", "description": "Long description", "display_data": {"short_description": "Granite modelcar"}}`))
+	})
+	mux.HandleFunc("/v1/images", func(w http.ResponseWriter, r *http.Request) {
+		expected := "repositories.registry==registry.access.redhat.com;repositories.repository==rhelai1/modelcar-granite;repositories.tags.name==1.5"
+		if filter := r.URL.Query().Get("filter"); filter != expected {
+			_, _ = w.Write([]byte(`{"data": []}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": [{
+			"image_id": "sha256:aaaa",
+			"freshness_grades": [
+				{"grade": "A", "start_date": "2025-01-01T00:00:00+00:00", "end_date": "2025-04-01T00:00:00+00:00"},
+				{"grade": "C", "start_date": "2025-04-01T00:00:00+00:00"}
+			],
+			"repositories": [
+				{"registry": "registry.access.redhat.com", "repository": "rhelai1/modelcar-granite", "image_advisory_id": "RHBA-2025:1234"},
+				{"registry": "registry.access.redhat.com", "repository": "rhelai1/other", "image_advisory_id": "RHBA-2025:9999"}
+			]
+		}]}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestLookup(t *testing.T) {
+	server := newTestServer(t)
+	client, err := NewClient(server.URL, "secret")
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	ctx := context.Background()
+
+	info, summary, err := client.Lookup(ctx, "oci://registry.redhat.io/rhelai1/modelcar-granite:1.5", time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Lookup failed: %v", err)
+	}
+	if summary != "Granite modelcar" {
+		t.Errorf("Expected the short description, got %q", summary)
+	}
+	if info.FreshnessGrade != "A" {
+		t.Errorf("Expected freshness grade A, got %q", info.FreshnessGrade)
+	}
+	if len(info.Advisories) != 1 || info.Advisories[0] != "https://access.redhat.com/errata/RHBA-2025:1234" {
+		t.Errorf("Expected the advisory of the image's repository, got %v", info.Advisories)
+	}
+	if info.CatalogURL != "https://catalog.redhat.com/software/containers/rhelai1/modelcar-granite/65a1b2" {
+		t.Errorf("Unexpected catalog URL %q", info.CatalogURL)
+	}
+
+	later, _, err := client.Lookup(ctx, "registry.redhat.io/rhelai1/modelcar-granite:1.5", time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil || later.FreshnessGrade != "C" {
+		t.Errorf("Expected freshness grade C after April, got %+v %v", later, err)
+	}
+
+	if _, _, err := client.Lookup(ctx, "registry.redhat.io/rhelai1/modelcar-granite:2.0", time.Now()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for an unknown tag, got %v", err)
+	}
+	if _, _, err := client.Lookup(ctx, "registry.redhat.io/rhelai1/unknown:1.0", time.Now()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for an unknown repository, got %v", err)
+	}
+	if _, _, err := client.Lookup(ctx, "quay.io/org/model:1.0", time.Now()); err == nil {
+		t.Error("Expected an error for an image outside Red Hat registries")
+	}
+}
+
+func TestSupported(t *testing.T) {
+	tests := map[string]bool{
+		"oci://registry.redhat.io/rhelai1/modelcar-granite:1.5": true,
+		"registry.connect.redhat.com/partner/model:1.0":         true,
+		"quay.io/redhat-ai-services/modelcar-catalog:granite":   false,
+		"hf://ibm-granite/granite-3.1-8b-instruct":              false,
+	}
+	for uri, expected := range tests {
+		if Supported(uri) != expected {
+			t.Errorf("Supported(%q) = %v, expected %v", uri, !expected, expected)
+		}
+	}
+}
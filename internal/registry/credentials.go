@@ -0,0 +1,84 @@
+package registry
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	containertypes "github.com/containers/image/v5/types"
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// RedHatRegistry is the registry that Red Hat registry service accounts authenticate to
+const RedHatRegistry = "registry.redhat.io"
+
+// Environment variables holding the username and token of a registry.redhat.io service account,
+// as shown on its token page
+const (
+	RedHatRegistryUsernameEnv = "REDHAT_REGISTRY_USERNAME"
+	RedHatRegistryTokenEnv    = "REDHAT_REGISTRY_TOKEN"
+)
+
+// Credentials are the username and token (or password) of a registry account
+type Credentials struct {
+	Username string `yaml:"username"`
+	Token    string `yaml:"token"`
+}
+
+// Auth maps registry hosts to the credentials that registry operations on their images
+// authenticate with instead of the container auth files. A nil Auth uses the auth files for every
+// registry.
+type Auth map[string]Credentials
+
+// LoadRedHatCredentials returns the registry.redhat.io service-account credentials of a secrets
+// file, a YAML file with username and token keys, or else of the REDHAT_REGISTRY_USERNAME and
+// REDHAT_REGISTRY_TOKEN environment variables. It returns nil when neither is configured, and an
+// error when only half of a pair is.
+func LoadRedHatCredentials(secretsPath string) (*Credentials, error) {
+	var creds Credentials
+	source := fmt.Sprintf("$%s and $%s", RedHatRegistryUsernameEnv, RedHatRegistryTokenEnv)
+	if secretsPath != "" {
+		data, err := os.ReadFile(secretsPath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading registry credentials: %v", err)
+		}
+		if err := yaml.Unmarshal(data, &creds); err != nil {
+			return nil, fmt.Errorf("error parsing registry credentials %s: %v", secretsPath, err)
+		}
+		source = secretsPath
+	} else {
+		creds = Credentials{Username: os.Getenv(RedHatRegistryUsernameEnv), Token: os.Getenv(RedHatRegistryTokenEnv)}
+	}
+
+	creds.Username = strings.TrimSpace(creds.Username)
+	creds.Token = strings.TrimSpace(creds.Token)
+	switch {
+	case creds.Username == "" && creds.Token == "" && secretsPath == "":
+		return nil, nil
+	case creds.Username == "" || creds.Token == "":
+		return nil, fmt.Errorf("%s must set both a username and a token", source)
+	}
+	return &creds, nil
+}
+
+// SystemContext returns sys, or a copy of it authenticating with the credentials of the registry
+// of imageRef. Credentials are only ever sent to the registry they are listed for.
+func (a Auth) SystemContext(sys *containertypes.SystemContext, imageRef string) *containertypes.SystemContext {
+	parsed, err := utils.ParseArtifactURI(imageRef)
+	if err != nil || parsed.Scheme != utils.URISchemeOCI {
+		return sys
+	}
+	creds, ok := a[parsed.Registry]
+	if !ok || (creds.Username == "" && creds.Token == "") {
+		return sys
+	}
+
+	authenticated := containertypes.SystemContext{}
+	if sys != nil {
+		authenticated = *sys
+	}
+	authenticated.DockerAuthConfig = &containertypes.DockerAuthConfig{Username: creds.Username, Password: creds.Token}
+	return &authenticated
+}
@@ -0,0 +1,62 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	containertypes "github.com/containers/image/v5/types"
+)
+
+func TestLoadRedHatCredentials(t *testing.T) {
+	t.Setenv(RedHatRegistryUsernameEnv, "")
+	t.Setenv(RedHatRegistryTokenEnv, "")
+	if creds, err := LoadRedHatCredentials(""); creds != nil || err != nil {
+		t.Errorf("Expected no credentials without configuration, got %v %v", creds, err)
+	}
+
+	t.Setenv(RedHatRegistryUsernameEnv, "12345678|model-catalog")
+	if _, err := LoadRedHatCredentials(""); err == nil {
+		t.Error("Expected an error for a username without a token")
+	}
+	t.Setenv(RedHatRegistryTokenEnv, "eyJhbGciOi")
+	creds, err := LoadRedHatCredentials("")
+	if err != nil || creds.Username != "12345678|model-catalog" || creds.Token != "eyJhbGciOi" {
+		t.Errorf("Expected the credentials of the environment, got %+v %v", creds, err)
+	}
+
+	// A secrets file takes precedence over the environment
+	path := filepath.Join(t.TempDir(), "redhat-registry.yaml")
+	if err := os.WriteFile(path, []byte("username: \"11111111|ci\"\ntoken: secret-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	creds, err = LoadRedHatCredentials(path)
+	if err != nil || creds.Username != "11111111|ci" || creds.Token != "secret-token" {
+		t.Errorf("Expected the credentials of the secrets file, got %+v %v", creds, err)
+	}
+
+	empty := filepath.Join(t.TempDir(), "empty.yaml")
+	_ = os.WriteFile(empty, []byte("username: \"11111111|ci\"\n"), 0600)
+	if _, err := LoadRedHatCredentials(empty); err == nil {
+		t.Error("Expected an error for a secrets file without a token")
+	}
+}
+
+func TestAuthSystemContext(t *testing.T) {
+	auth := Auth{RedHatRegistry: {Username: "12345678|model-catalog", Token: "eyJhbGciOi"}}
+
+	base := &containertypes.SystemContext{ArchitectureChoice: "amd64"}
+	sys := auth.SystemContext(base, "registry.redhat.io/rhelai1/modelcar-granite:1.5")
+	if sys.DockerAuthConfig == nil || sys.DockerAuthConfig.Username != "12345678|model-catalog" || sys.DockerAuthConfig.Password != "eyJhbGciOi" {
+		t.Errorf("Expected the service account for registry.redhat.io, got %+v", sys.DockerAuthConfig)
+	}
+	if sys.ArchitectureChoice != "amd64" || base.DockerAuthConfig != nil {
+		t.Error("Expected a copy of the base context with its settings")
+	}
+	if other := auth.SystemContext(base, "quay.io/org/model:1.0"); other != base {
+		t.Error("Expected credentials never to be sent to other registries")
+	}
+	if none := Auth(nil).SystemContext(base, "registry.redhat.io/rhelai1/modelcar-granite:1.5"); none != base {
+		t.Error("Expected a nil Auth to keep the base context")
+	}
+}
@@ -0,0 +1,42 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/containers/image/v5/docker"
+	"github.com/docker/distribution/registry/api/errcode"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/errdefs"
+)
+
+// ClassifyError wraps a registry error in its errdefs failure class: ErrManifestNotFound for
+// unknown manifests and repositories, ErrUnauthorized for rejected credentials. Other errors are
+// returned unchanged.
+func ClassifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var unauthorized docker.ErrUnauthorizedForCredentials
+	if errors.As(err, &unauthorized) {
+		return fmt.Errorf("%w: %v", errdefs.ErrUnauthorized, err)
+	}
+
+	var coder errcode.ErrorCoder
+	if errors.As(err, &coder) {
+		switch coder.ErrorCode().Descriptor().HTTPStatusCode {
+		case http.StatusNotFound:
+			return fmt.Errorf("%w: %v", errdefs.ErrManifestNotFound, err)
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return fmt.Errorf("%w: %v", errdefs.ErrUnauthorized, err)
+		}
+	}
+	// registry.redhat.io reports unknown manifests as an unknown error with this message
+	var registryErr errcode.Error
+	if errors.As(err, &registryErr) && registryErr.Message == "Not Found" {
+		return fmt.Errorf("%w: %v", errdefs.ErrManifestNotFound, err)
+	}
+	return err
+}
@@ -12,15 +12,16 @@ import (
 	"time"
 
 	"github.com/containers/image/v5/docker"
+	blobinfocachememory "github.com/containers/image/v5/pkg/blobinfocache/memory"
 	containertypes "github.com/containers/image/v5/types"
+	"github.com/opencontainers/go-digest"
+	"github.com/opendatahub-io/model-metadata-collection/internal/httpclient"
 	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
 	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
 )
 
 // HTTP client with timeout for registry API calls
-var httpClient = &http.Client{
-	Timeout: 30 * time.Second,
-}
+var httpClient = httpclient.New("registry", 30*time.Second)
 
 // RegistryManifest represents container registry manifest metadata
 type RegistryManifest struct {
@@ -74,7 +75,7 @@ type manifestListSchema struct {
 
 // FetchImageArchitectures inspects an OCI image reference and returns all supported architectures.
 // Used by model catalog enrichment and MCP server enrichment.
-func FetchImageArchitectures(imageRef string) ([]string, error) {
+func FetchImageArchitectures(ctx context.Context, auth Auth, imageRef string) ([]string, error) {
 	// Parse the image reference
 	ref, err := docker.ParseReference("//" + imageRef)
 	if err != nil {
@@ -82,23 +83,23 @@ func FetchImageArchitectures(imageRef string) ([]string, error) {
 	}
 
 	// Create a system context
-	sys := &containertypes.SystemContext{}
+	sys := auth.SystemContext(&containertypes.SystemContext{}, imageRef)
 
-	// Create a context with timeout for registry operations
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	// Bound the registry operations with a timeout
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	// Create an image source to access the raw manifest
 	src, err := ref.NewImageSource(ctx, sys)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create image source: %v", err)
+		return nil, fmt.Errorf("failed to create image source: %w", ClassifyError(err))
 	}
 	defer func() { _ = src.Close() }()
 
 	// Get the raw manifest
 	manifestBytes, manifestMIMEType, err := src.GetManifest(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get manifest: %v", err)
+		return nil, fmt.Errorf("failed to get manifest: %w", ClassifyError(err))
 	}
 
 	var architectures []string
@@ -131,7 +132,7 @@ func FetchImageArchitectures(imageRef string) ([]string, error) {
 		// Single-arch image - need to get architecture from config
 		img, err := ref.NewImage(ctx, sys)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create image: %v", err)
+			return nil, fmt.Errorf("failed to create image: %w", ClassifyError(err))
 		}
 		defer func() { _ = img.Close() }()
 
@@ -162,7 +163,7 @@ func FetchImageArchitectures(imageRef string) ([]string, error) {
 
 // FetchImageTimestamps fetches creation and last-update timestamps from an OCI
 // image's config blob. Returns epoch milliseconds or nil if unavailable.
-func FetchImageTimestamps(imageRef string) (createTime *int64, updateTime *int64, err error) {
+func FetchImageTimestamps(ctx context.Context, auth Auth, imageRef string) (createTime *int64, updateTime *int64, err error) {
 	ref, err := docker.ParseReference("//" + imageRef)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to parse reference: %v", err)
@@ -170,16 +171,16 @@ func FetchImageTimestamps(imageRef string) (createTime *int64, updateTime *int64
 
 	// Use explicit platform choice to avoid manifest list resolution failures
 	// on hosts whose native arch/OS (e.g., darwin/arm64) is absent from the image.
-	sys := &containertypes.SystemContext{
+	sys := auth.SystemContext(&containertypes.SystemContext{
 		ArchitectureChoice: "amd64",
 		OSChoice:           "linux",
-	}
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	}, imageRef)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	img, err := ref.NewImage(ctx, sys)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create image: %v", err)
+		return nil, nil, fmt.Errorf("failed to create image: %w", ClassifyError(err))
 	}
 	defer func() { _ = img.Close() }()
 
@@ -215,20 +216,102 @@ func FetchImageTimestamps(imageRef string) (createTime *int64, updateTime *int64
 	return createTime, updateTime, nil
 }
 
+// FetchManifestDigest resolves an image reference to the digest of its manifest, which changes
+// whenever the tag is moved to a different image
+func FetchManifestDigest(ctx context.Context, auth Auth, imageRef string) (string, error) {
+	ref, err := docker.ParseReference("//" + imageRef)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse reference: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	manifestDigest, err := docker.GetDigest(ctx, auth.SystemContext(&containertypes.SystemContext{}, imageRef), ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to get manifest digest: %w", ClassifyError(err))
+	}
+	return manifestDigest.String(), nil
+}
+
+// maxBlobSize caps the blobs read by FetchBlob, which are small JSON payloads such as signatures
+const maxBlobSize = 1 << 20
+
+// FetchManifest returns the raw manifest an image reference resolves to
+func FetchManifest(ctx context.Context, auth Auth, imageRef string) ([]byte, error) {
+	ref, err := docker.ParseReference("//" + imageRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse reference: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	src, err := ref.NewImageSource(ctx, auth.SystemContext(&containertypes.SystemContext{}, imageRef))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create image source: %w", ClassifyError(err))
+	}
+	defer func() { _ = src.Close() }()
+
+	manifestBytes, _, err := src.GetManifest(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get manifest: %w", ClassifyError(err))
+	}
+	return manifestBytes, nil
+}
+
+// FetchBlob returns a blob of the repository of an image reference by its digest, failing for
+// blobs larger than 1 MiB
+func FetchBlob(ctx context.Context, auth Auth, imageRef, blobDigest string) ([]byte, error) {
+	ref, err := docker.ParseReference("//" + imageRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse reference: %v", err)
+	}
+	parsedDigest, err := digest.Parse(blobDigest)
+	if err != nil {
+		return nil, fmt.Errorf("invalid blob digest %q: %v", blobDigest, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	src, err := ref.NewImageSource(ctx, auth.SystemContext(&containertypes.SystemContext{}, imageRef))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create image source: %w", ClassifyError(err))
+	}
+	defer func() { _ = src.Close() }()
+
+	blob, _, err := src.GetBlob(ctx, containertypes.BlobInfo{Digest: parsedDigest, Size: -1}, blobinfocachememory.New())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob %s: %w", blobDigest, ClassifyError(err))
+	}
+	defer func() { _ = blob.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(blob, maxBlobSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob %s: %v", blobDigest, err)
+	}
+	if len(data) > maxBlobSize {
+		return nil, fmt.Errorf("blob %s is larger than %d bytes", blobDigest, maxBlobSize)
+	}
+	return data, nil
+}
+
 // AddArchitectureToArtifactProps fetches architectures and adds them to artifact custom properties (exported)
 // Returns true if architecture was successfully added, false otherwise.
-func AddArchitectureToArtifactProps(imageRef string, customProps map[string]interface{}) bool {
-	return addArchitectureToCustomProps(imageRef, customProps)
+func AddArchitectureToArtifactProps(ctx context.Context, auth Auth, imageRef string, customProps map[string]interface{}) bool {
+	return addArchitectureToCustomProps(ctx, auth, imageRef, customProps)
 }
 
 // addArchitectureToCustomProps fetches architectures and adds them to custom properties
 // Returns true if architecture was successfully added, false otherwise.
-func addArchitectureToCustomProps(imageRef string, customProps map[string]interface{}) bool {
+func addArchitectureToCustomProps(ctx context.Context, auth Auth, imageRef string, customProps map[string]interface{}) bool {
 	// Fetch architectures with retry logic to handle transient failures
 	architectures, err := utils.RetryWithExponentialBackoff(
+		ctx,
 		utils.DefaultRetryConfig,
 		func() ([]string, error) {
-			return FetchImageArchitectures(imageRef)
+			return FetchImageArchitectures(ctx, auth, imageRef)
 		},
 		fmt.Sprintf("fetch architectures for %s", imageRef),
 	)
@@ -246,14 +329,14 @@ func addArchitectureToCustomProps(imageRef string, customProps map[string]interf
 
 	// Add architecture to custom properties in the required format
 	customProps["architecture"] = map[string]interface{}{
-		"metadataType": "MetadataStringValue",
+		"metadataType": types.MetadataTypeString,
 		"string_value": string(archJSON),
 	}
 	return true
 }
 
 // FetchRegistryMetadata fetches OCI artifact metadata from registry API
-func FetchRegistryMetadata(imageRef string) (*types.OCIArtifact, error) {
+func FetchRegistryMetadata(ctx context.Context, auth Auth, imageRef string) (*types.OCIArtifact, error) {
 	registry, repository, imageName, tag, err := parseRegistryImageRef(imageRef)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse image reference: %v", err)
@@ -268,7 +351,7 @@ func FetchRegistryMetadata(imageRef string) (*types.OCIArtifact, error) {
 		// Try to fetch manifest via registry API v2
 		manifestURL := fmt.Sprintf("https://%s/v2/%s/%s/manifests/%s", registry, repository, imageName, tag)
 
-		resp, err := httpClient.Get(manifestURL)
+		resp, err := getManifest(ctx, manifestURL)
 		if err != nil {
 			// If we can't fetch from API, create artifact with nil timestamps
 			customProps := map[string]interface{}{
@@ -280,7 +363,7 @@ func FetchRegistryMetadata(imageRef string) (*types.OCIArtifact, error) {
 				},
 			}
 			// Add architecture information
-			addArchitectureToCustomProps(imageRef, customProps)
+			addArchitectureToCustomProps(ctx, auth, imageRef, customProps)
 
 			return &types.OCIArtifact{
 				URI:                      ociURI,
@@ -326,7 +409,7 @@ func FetchRegistryMetadata(imageRef string) (*types.OCIArtifact, error) {
 					}
 
 					// Add architecture information
-					addArchitectureToCustomProps(imageRef, customProps)
+					addArchitectureToCustomProps(ctx, auth, imageRef, customProps)
 
 					return &types.OCIArtifact{
 						URI:                      ociURI,
@@ -349,7 +432,7 @@ func FetchRegistryMetadata(imageRef string) (*types.OCIArtifact, error) {
 		},
 	}
 	// Add architecture information
-	addArchitectureToCustomProps(imageRef, customProps)
+	addArchitectureToCustomProps(ctx, auth, imageRef, customProps)
 
 	return &types.OCIArtifact{
 		URI:                      ociURI,
@@ -359,12 +442,21 @@ func FetchRegistryMetadata(imageRef string) (*types.OCIArtifact, error) {
 	}, nil
 }
 
+// getManifest requests a manifest from the registry API
+func getManifest(ctx context.Context, manifestURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	return httpClient.Do(req)
+}
+
 // ExtractOCIArtifactsFromRegistry creates structured OCI artifacts from registry references
-func ExtractOCIArtifactsFromRegistry(manifestRef string) []types.OCIArtifact {
+func ExtractOCIArtifactsFromRegistry(ctx context.Context, auth Auth, manifestRef string) []types.OCIArtifact {
 	var artifacts []types.OCIArtifact
 
 	// The manifestRef itself is the primary OCI artifact
-	if artifact, err := FetchRegistryMetadata(manifestRef); err == nil {
+	if artifact, err := FetchRegistryMetadata(ctx, auth, manifestRef); err == nil {
 		artifacts = append(artifacts, *artifact)
 	} else {
 		log.Printf("Warning: Failed to fetch registry metadata for %s: %v", manifestRef, err)
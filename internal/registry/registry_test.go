@@ -1,9 +1,17 @@
 package registry
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
+
+	"github.com/containers/image/v5/docker"
+	"github.com/docker/distribution/registry/api/errcode"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/errdefs"
 )
 
 func TestParseRegistryImageRef(t *testing.T) {
@@ -133,7 +141,7 @@ func TestFetchRegistryMetadata(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := FetchRegistryMetadata(tt.imageRef)
+			result, err := FetchRegistryMetadata(context.Background(), nil, tt.imageRef)
 
 			if tt.expectError {
 				if err == nil {
@@ -250,7 +258,7 @@ func TestExtractOCIArtifactsFromRegistry(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := ExtractOCIArtifactsFromRegistry(tt.manifestRef)
+			result := ExtractOCIArtifactsFromRegistry(context.Background(), nil, tt.manifestRef)
 
 			if len(result) != tt.expectArtifacts {
 				t.Errorf("Expected %d artifacts, got %d", tt.expectArtifacts, len(result))
@@ -288,7 +296,7 @@ func TestFetchRegistryMetadata_ErrorHandling(t *testing.T) {
 	// (using a non-existent domain to ensure network failure)
 	imageRef := "nonexistent.registry.example.com/test/model:1.0"
 
-	result, err := FetchRegistryMetadata(imageRef)
+	result, err := FetchRegistryMetadata(context.Background(), nil, imageRef)
 	if err != nil {
 		t.Errorf("FetchRegistryMetadata should not return error for network failures, got: %v", err)
 		return
@@ -373,7 +381,7 @@ func TestRegistryManifest_StructureCompatibility(t *testing.T) {
 
 func TestExtractOCIArtifactsFromRegistry_Properties(t *testing.T) {
 	manifestRef := "registry.redhat.io/rhelai1/test-model:1.0"
-	artifacts := ExtractOCIArtifactsFromRegistry(manifestRef)
+	artifacts := ExtractOCIArtifactsFromRegistry(context.Background(), nil, manifestRef)
 
 	if len(artifacts) != 1 {
 		t.Fatalf("Expected 1 artifact, got %d", len(artifacts))
@@ -412,7 +420,7 @@ func TestExtractOCIArtifactsFromRegistry_Properties(t *testing.T) {
 // Test to ensure artifacts slice is never nil
 func TestExtractOCIArtifactsFromRegistry_NeverNil(t *testing.T) {
 	// Even with invalid input, should return empty slice, not nil
-	result := ExtractOCIArtifactsFromRegistry("completely/invalid")
+	result := ExtractOCIArtifactsFromRegistry(context.Background(), nil, "completely/invalid")
 
 	if result == nil {
 		t.Error("Result should never be nil, should be empty slice instead")
@@ -584,7 +592,7 @@ func TestAddArchitectureToCustomProps(t *testing.T) {
 			}
 
 			customProps := make(map[string]interface{})
-			addArchitectureToCustomProps(tt.imageRef, customProps)
+			addArchitectureToCustomProps(context.Background(), nil, tt.imageRef, customProps)
 
 			archProp, exists := customProps["architecture"]
 			if tt.expectArchProperty && !exists {
@@ -679,7 +687,7 @@ func TestFetchImageArchitectures(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			architectures, err := FetchImageArchitectures(tt.imageRef)
+			architectures, err := FetchImageArchitectures(context.Background(), nil, tt.imageRef)
 
 			if tt.expectError {
 				if err == nil {
@@ -735,7 +743,7 @@ func TestAddArchitectureToArtifactProps(t *testing.T) {
 		"string_value": "modelcar",
 	}
 
-	AddArchitectureToArtifactProps(imageRef, customProps)
+	AddArchitectureToArtifactProps(context.Background(), nil, imageRef, customProps)
 
 	// Verify architecture was added
 	if _, exists := customProps["architecture"]; !exists {
@@ -784,7 +792,7 @@ func TestFetchImageArchitectures_ErrorHandling(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := FetchImageArchitectures(tt.imageRef)
+			_, err := FetchImageArchitectures(context.Background(), nil, tt.imageRef)
 			if err == nil {
 				t.Error("Expected error for invalid input but got none")
 			}
@@ -820,7 +828,7 @@ func TestFetchImageTimestamps(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			createTime, updateTime, err := FetchImageTimestamps(tt.imageRef)
+			createTime, updateTime, err := FetchImageTimestamps(context.Background(), nil, tt.imageRef)
 
 			if tt.expectError {
 				if err == nil {
@@ -862,7 +870,7 @@ func TestFetchImageTimestamps_PointerIndependence(t *testing.T) {
 	// that FetchImageTimestamps returns non-aliased pointers.
 	t.Skip("Skipping integration test that makes network calls - should be run separately with -integration flag")
 
-	createTime, updateTime, err := FetchImageTimestamps(
+	createTime, updateTime, err := FetchImageTimestamps(context.Background(), nil,
 		"quay.io/redhat-user-workloads/crt-nshift-lightspeed-tenant/openshift-mcp-server:latest",
 	)
 	if err != nil {
@@ -903,7 +911,7 @@ func TestFetchImageTimestamps_ErrorHandling(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, _, err := FetchImageTimestamps(tt.imageRef)
+			_, _, err := FetchImageTimestamps(context.Background(), nil, tt.imageRef)
 			if err == nil {
 				t.Error("Expected error for invalid input but got none")
 			}
@@ -964,3 +972,21 @@ func TestArchitectureJSONFormatting(t *testing.T) {
 		})
 	}
 }
+
+func TestClassifyError(t *testing.T) {
+	notFound := fmt.Errorf("reading manifest 1.0 in registry.example.com/org/model: %w", errcode.ErrorCodeUnknown.WithMessage("Not Found"))
+	if err := ClassifyError(notFound); !errors.Is(err, errdefs.ErrManifestNotFound) {
+		t.Errorf("Expected a manifest not found error, got %v", err)
+	}
+	denied := fmt.Errorf("reading manifest: %w", errcode.ErrorCodeDenied.WithMessage("requested access to the resource is denied"))
+	if err := ClassifyError(denied); !errors.Is(err, errdefs.ErrUnauthorized) {
+		t.Errorf("Expected an unauthorized error, got %v", err)
+	}
+	if err := ClassifyError(docker.ErrUnauthorizedForCredentials{Err: errors.New("invalid username/password")}); !errors.Is(err, errdefs.ErrUnauthorized) {
+		t.Errorf("Expected an unauthorized error, got %v", err)
+	}
+	other := errors.New("connection refused")
+	if err := ClassifyError(other); err != other {
+		t.Errorf("Expected other errors to be returned unchanged, got %v", err)
+	}
+}
@@ -0,0 +1,336 @@
+// Package repack writes a modelcard back into a modelcar image as a modelcard layer, so images
+// that shipped without a card carry the readme and metadata the catalog has for them.
+package repack
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/containers/image/v5/manifest"
+	blobinfocachememory "github.com/containers/image/v5/pkg/blobinfocache/memory"
+	containertypes "github.com/containers/image/v5/types"
+	"github.com/opencontainers/go-digest"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/extraction"
+	"github.com/opendatahub-io/model-metadata-collection/internal/publish"
+	"github.com/opendatahub-io/model-metadata-collection/internal/registry"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// ModelcardPath is the path of the modelcard in the layer, next to the model files of a modelcar
+const ModelcardPath = "models/modelcard.md"
+
+// ociMediaTypes converts the media types of Docker schema 2 images, which cannot carry layer
+// annotations, to their OCI equivalents
+var ociMediaTypes = map[string]string{
+	manifest.DockerV2Schema2MediaType:                 imgspecv1.MediaTypeImageManifest,
+	manifest.DockerV2Schema2ConfigMediaType:           imgspecv1.MediaTypeImageConfig,
+	manifest.DockerV2Schema2LayerMediaType:            imgspecv1.MediaTypeImageLayerGzip,
+	manifest.DockerV2SchemaLayerMediaTypeUncompressed: imgspecv1.MediaTypeImageLayer,
+}
+
+// Options controls Repack
+type Options struct {
+	// Source is the modelcar image (a registry reference or oci:/path/to/layout[:tag])
+	Source string
+	// Destination receives the repacked image, in the same forms as Source
+	Destination string
+	// Modelcard is the content written to ModelcardPath
+	Modelcard []byte
+	// Replace replaces an existing modelcard layer instead of refusing to repack the image
+	Replace bool
+	// ModelcardAnnotations are the layer annotations marking modelcard layers, as configured for
+	// extraction; defaults to extraction.DefaultModelcardAnnotation. A layer carrying any of them
+	// is an existing modelcard layer, and the new layer is annotated with the first.
+	ModelcardAnnotations []extraction.LayerAnnotation
+	// SystemContext carries registry credentials and TLS settings
+	SystemContext *containertypes.SystemContext
+	// RegistryAuth holds service-account credentials the source image is pulled with instead of
+	// the SystemContext's, for the registries it lists
+	RegistryAuth registry.Auth
+}
+
+// frontmatter is the HuggingFace-style YAML frontmatter of a generated modelcard, using the keys
+// extraction reads back from modelcard layers
+type frontmatter struct {
+	Name        string   `yaml:"name,omitempty"`
+	Provider    string   `yaml:"provider,omitempty"`
+	Description string   `yaml:"description,omitempty"`
+	License     string   `yaml:"license,omitempty"`
+	LicenseLink string   `yaml:"license_link,omitempty"`
+	Language    []string `yaml:"language,omitempty"`
+	BaseModel   []string `yaml:"base_model,omitempty"`
+	Tasks       []string `yaml:"tasks,omitempty"`
+	Datasets    []string `yaml:"datasets,omitempty"`
+}
+
+// Modelcard renders the modelcard of a catalog model: its readme preceded by YAML frontmatter with
+// the model's metadata, so extracting the repacked image yields the same metadata
+func Modelcard(model types.CatalogMetadata) ([]byte, error) {
+	if model.Readme == nil || strings.TrimSpace(*model.Readme) == "" {
+		return nil, fmt.Errorf("model %s has no readme", utils.StringValue(model.Name))
+	}
+	header, err := yaml.Marshal(frontmatter{
+		Name:        utils.StringValue(model.Name),
+		Provider:    utils.StringValue(model.Provider),
+		Description: utils.StringValue(model.Description),
+		License:     utils.StringValue(model.License),
+		LicenseLink: utils.StringValue(model.LicenseLink),
+		Language:    model.Language,
+		BaseModel:   model.BaseModel,
+		Tasks:       model.Tasks,
+		Datasets:    model.TrainingDatasets,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode modelcard frontmatter: %v", err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	buf.Write(header)
+	buf.WriteString("---\n\n")
+	buf.WriteString(strings.TrimSpace(*model.Readme))
+	buf.WriteString("\n")
+	return buf.Bytes(), nil
+}
+
+// FindModel returns the catalog model with an artifact for ref, which may include the oci:// scheme
+func FindModel(catalog *types.ModelsCatalog, ref string) (types.CatalogMetadata, bool) {
+	uri := "oci://" + strings.TrimPrefix(ref, "oci://")
+	for _, model := range catalog.Models {
+		for _, artifact := range model.Artifacts {
+			if artifact.URI == uri {
+				return model, true
+			}
+		}
+	}
+	return types.CatalogMetadata{}, false
+}
+
+// Repack copies the source image to the destination with opts.Modelcard added as a modelcard
+// layer, and returns the digest of the new manifest. Model layers are reused when the destination
+// already has them, e.g. when repacking within a registry.
+func Repack(ctx context.Context, opts Options) (digest.Digest, error) {
+	if len(opts.Modelcard) == 0 {
+		return "", fmt.Errorf("no modelcard to add")
+	}
+	srcRef, err := publish.ParseReference(opts.Source)
+	if err != nil {
+		return "", fmt.Errorf("invalid source %q: %v", opts.Source, err)
+	}
+	destRef, err := publish.ParseReference(opts.Destination)
+	if err != nil {
+		return "", fmt.Errorf("invalid destination %q: %v", opts.Destination, err)
+	}
+
+	src, err := srcRef.NewImageSource(ctx, opts.RegistryAuth.SystemContext(opts.SystemContext, strings.TrimPrefix(opts.Source, "docker://")))
+	if err != nil {
+		return "", fmt.Errorf("failed to open source %s: %v", opts.Source, err)
+	}
+	defer func() { _ = src.Close() }()
+
+	srcManifest, err := readManifest(ctx, src)
+	if err != nil {
+		return "", fmt.Errorf("%s: %v", opts.Source, err)
+	}
+	cache := blobinfocachememory.New()
+	config, err := readConfig(ctx, src, cache, srcManifest.Config)
+	if err != nil {
+		return "", fmt.Errorf("%s: %v", opts.Source, err)
+	}
+	if len(config.RootFS.DiffIDs) != len(srcManifest.Layers) {
+		return "", fmt.Errorf("%s: config lists %d layers, manifest %d", opts.Source, len(config.RootFS.DiffIDs), len(srcManifest.Layers))
+	}
+
+	dest, err := destRef.NewImageDestination(ctx, opts.SystemContext)
+	if err != nil {
+		return "", fmt.Errorf("failed to open destination %s: %v", opts.Destination, err)
+	}
+	defer func() { _ = dest.Close() }()
+
+	var layers []imgspecv1.Descriptor
+	var diffIDs []digest.Digest
+	for i, layer := range srcManifest.Layers {
+		if extraction.IsModelcardLayer(opts.ModelcardAnnotations, layer.Annotations) {
+			if !opts.Replace {
+				return "", fmt.Errorf("%s already has a modelcard layer", opts.Source)
+			}
+			log.Printf("  Replacing modelcard layer %s", layer.Digest)
+			continue
+		}
+		if err := copyBlob(ctx, src, dest, cache, layer); err != nil {
+			return "", fmt.Errorf("failed to copy layer %s: %v", layer.Digest, err)
+		}
+		layers = append(layers, layer)
+		diffIDs = append(diffIDs, config.RootFS.DiffIDs[i])
+	}
+	// Layer history entries describe the removed modelcard layer too, so drop the history rather than
+	// leaving the history out of step with the layers
+	if len(diffIDs) != len(config.RootFS.DiffIDs) {
+		config.History = nil
+	}
+
+	layerTar, err := modelcardLayer(opts.Modelcard)
+	if err != nil {
+		return "", err
+	}
+	modelcard, err := putBlob(ctx, dest, cache, layerTar, imgspecv1.MediaTypeImageLayer, false)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload modelcard layer: %v", err)
+	}
+	annotation := extraction.ModelcardAnnotationsOrDefault(opts.ModelcardAnnotations)[0]
+	modelcard.Annotations = map[string]string{annotation.Key: annotation.Value}
+	layers = append(layers, modelcard)
+	// The layer is uncompressed, so its digest is also its diff ID
+	config.RootFS.DiffIDs = append(diffIDs, modelcard.Digest)
+	if config.History != nil {
+		now := time.Now().UTC()
+		config.History = append(config.History, imgspecv1.History{
+			Created:   &now,
+			CreatedBy: "model-extractor repack",
+			Comment:   "Add " + ModelcardPath,
+		})
+	}
+
+	configBytes, err := json.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode image config: %v", err)
+	}
+	configDescriptor, err := putBlob(ctx, dest, cache, configBytes, imgspecv1.MediaTypeImageConfig, true)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload image config: %v", err)
+	}
+
+	repacked := srcManifest
+	repacked.MediaType = imgspecv1.MediaTypeImageManifest
+	repacked.Config = configDescriptor
+	repacked.Layers = layers
+	manifestBytes, err := json.Marshal(repacked)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode manifest: %v", err)
+	}
+	if err := dest.PutManifest(ctx, manifestBytes, nil); err != nil {
+		return "", fmt.Errorf("failed to upload manifest: %v", err)
+	}
+	if err := dest.Commit(ctx, nil); err != nil {
+		return "", fmt.Errorf("failed to commit image: %v", err)
+	}
+	return digest.FromBytes(manifestBytes), nil
+}
+
+// readManifest reads the image manifest of src as an OCI manifest, converting Docker schema 2
+// media types. Manifest lists are rejected: a modelcar is repacked one platform at a time.
+func readManifest(ctx context.Context, src containertypes.ImageSource) (imgspecv1.Manifest, error) {
+	manifestBytes, mimeType, err := src.GetManifest(ctx, nil)
+	if err != nil {
+		return imgspecv1.Manifest{}, fmt.Errorf("failed to read manifest: %v", err)
+	}
+	mimeType = manifest.NormalizedMIMEType(mimeType)
+	if manifest.MIMETypeIsMultiImage(mimeType) {
+		return imgspecv1.Manifest{}, fmt.Errorf("image is a manifest list; repack the manifest of one platform by digest")
+	}
+	if mimeType != imgspecv1.MediaTypeImageManifest && mimeType != manifest.DockerV2Schema2MediaType {
+		return imgspecv1.Manifest{}, fmt.Errorf("unsupported manifest type %s", mimeType)
+	}
+
+	var m imgspecv1.Manifest
+	if err := json.Unmarshal(manifestBytes, &m); err != nil {
+		return imgspecv1.Manifest{}, fmt.Errorf("failed to parse manifest: %v", err)
+	}
+	m.Config.MediaType = ociMediaType(m.Config.MediaType)
+	for i := range m.Layers {
+		m.Layers[i].MediaType = ociMediaType(m.Layers[i].MediaType)
+	}
+	return m, nil
+}
+
+// readConfig reads and parses the image config blob
+func readConfig(ctx context.Context, src containertypes.ImageSource, cache containertypes.BlobInfoCache, descriptor imgspecv1.Descriptor) (imgspecv1.Image, error) {
+	blob, _, err := src.GetBlob(ctx, containertypes.BlobInfo{Digest: descriptor.Digest, Size: descriptor.Size}, cache)
+	if err != nil {
+		return imgspecv1.Image{}, fmt.Errorf("failed to read image config: %v", err)
+	}
+	defer func() { _ = blob.Close() }()
+
+	var config imgspecv1.Image
+	if err := json.NewDecoder(blob).Decode(&config); err != nil {
+		return imgspecv1.Image{}, fmt.Errorf("failed to parse image config: %v", err)
+	}
+	return config, nil
+}
+
+// copyBlob copies a layer from src to dest unless dest already has it
+func copyBlob(ctx context.Context, src containertypes.ImageSource, dest containertypes.ImageDestination, cache containertypes.BlobInfoCache, layer imgspecv1.Descriptor) error {
+	info := containertypes.BlobInfo{Digest: layer.Digest, Size: layer.Size, MediaType: layer.MediaType}
+	reused, _, err := dest.TryReusingBlob(ctx, info, cache, false)
+	if err != nil {
+		return err
+	}
+	if reused {
+		log.Printf("  Reused layer %s", layer.Digest)
+		return nil
+	}
+
+	blob, _, err := src.GetBlob(ctx, info, cache)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = blob.Close() }()
+	if _, err := dest.PutBlob(ctx, blob, info, cache, false); err != nil {
+		return err
+	}
+	log.Printf("  Copied layer %s (%d bytes)", layer.Digest, layer.Size)
+	return nil
+}
+
+// modelcardLayer returns an uncompressed tar holding the modelcard at ModelcardPath
+func modelcardLayer(modelcard []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     ModelcardPath,
+		Mode:     0644,
+		Size:     int64(len(modelcard)),
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to write modelcard layer: %v", err)
+	}
+	if _, err := tw.Write(modelcard); err != nil {
+		return nil, fmt.Errorf("failed to write modelcard layer: %v", err)
+	}
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write modelcard layer: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// putBlob uploads content and returns its descriptor
+func putBlob(ctx context.Context, dest containertypes.ImageDestination, cache containertypes.BlobInfoCache, content []byte, mediaType string, isConfig bool) (imgspecv1.Descriptor, error) {
+	info := containertypes.BlobInfo{
+		Digest:    digest.FromBytes(content),
+		Size:      int64(len(content)),
+		MediaType: mediaType,
+	}
+	uploaded, err := dest.PutBlob(ctx, bytes.NewReader(content), info, cache, isConfig)
+	if err != nil {
+		return imgspecv1.Descriptor{}, err
+	}
+	return imgspecv1.Descriptor{MediaType: mediaType, Digest: uploaded.Digest, Size: uploaded.Size}, nil
+}
+
+// ociMediaType returns the OCI equivalent of a Docker schema 2 media type
+func ociMediaType(mediaType string) string {
+	if converted, ok := ociMediaTypes[mediaType]; ok {
+		return converted
+	}
+	return mediaType
+}
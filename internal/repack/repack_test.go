@@ -0,0 +1,210 @@
+package repack
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	blobinfocachememory "github.com/containers/image/v5/pkg/blobinfocache/memory"
+	containertypes "github.com/containers/image/v5/types"
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/extraction"
+	"github.com/opendatahub-io/model-metadata-collection/internal/publish"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func stringPtr(s string) *string { return &s }
+
+// writeModelcar writes a modelcar with one model file layer to an OCI layout and returns its reference
+func writeModelcar(t *testing.T, dir string) string {
+	t.Helper()
+	ctx := context.Background()
+	reference := "oci:" + filepath.Join(dir, "source") + ":1.0"
+	ref, err := publish.ParseReference(reference)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dest, err := ref.NewImageDestination(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = dest.Close() }()
+	cache := blobinfocachememory.New()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	content := []byte(`{"model_type": "granite"}`)
+	_ = tw.WriteHeader(&tar.Header{Name: "models/config.json", Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+	_, _ = tw.Write(content)
+	_ = tw.Close()
+	layer, err := putBlob(ctx, dest, cache, buf.Bytes(), imgspecv1.MediaTypeImageLayer, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := imgspecv1.Image{
+		Platform: imgspecv1.Platform{OS: "linux", Architecture: "amd64"},
+		RootFS:   imgspecv1.RootFS{Type: "layers", DiffIDs: []digest.Digest{layer.Digest}},
+		History:  []imgspecv1.History{{CreatedBy: "COPY models /models"}},
+	}
+	configBytes, _ := json.Marshal(config)
+	configDescriptor, err := putBlob(ctx, dest, cache, configBytes, imgspecv1.MediaTypeImageConfig, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifestBytes, _ := json.Marshal(imgspecv1.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: imgspecv1.MediaTypeImageManifest,
+		Config:    configDescriptor,
+		Layers:    []imgspecv1.Descriptor{layer},
+	})
+	if err := dest.PutManifest(ctx, manifestBytes, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := dest.Commit(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	return reference
+}
+
+// readImage returns the manifest, config and modelcard layer content of an image in an OCI layout
+func readImage(t *testing.T, reference string, annotations []extraction.LayerAnnotation) (imgspecv1.Manifest, imgspecv1.Image, string) {
+	t.Helper()
+	ctx := context.Background()
+	ref, err := publish.ParseReference(reference)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := ref.NewImageSource(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = src.Close() }()
+
+	m, err := readManifest(ctx, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache := blobinfocachememory.New()
+	config, err := readConfig(ctx, src, cache, m.Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var modelcard string
+	for _, layer := range m.Layers {
+		if !extraction.IsModelcardLayer(annotations, layer.Annotations) {
+			continue
+		}
+		blob, _, err := src.GetBlob(ctx, containertypes.BlobInfo{Digest: layer.Digest, Size: layer.Size}, cache)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tr := tar.NewReader(blob)
+		header, err := tr.Next()
+		if err != nil || header.Name != ModelcardPath {
+			t.Fatalf("modelcard layer entry = %v, %v", header, err)
+		}
+		content, _ := io.ReadAll(tr)
+		_ = blob.Close()
+		modelcard = string(content)
+	}
+	return m, config, modelcard
+}
+
+func TestModelcard(t *testing.T) {
+	model := types.CatalogMetadata{
+		Name:     stringPtr("RedHatAI/granite-3.1-8b-instruct"),
+		Provider: stringPtr("IBM"),
+		License:  stringPtr("apache-2.0"),
+		Language: []string{"en", "de"},
+		Tasks:    []string{"text-generation"},
+		Readme:   stringPtr("# Granite\n\nAn instruct model.\n\n"),
+	}
+	card, err := Modelcard(model)
+	if err != nil {
+		t.Fatalf("Modelcard() error = %v", err)
+	}
+	want := "---\nname: RedHatAI/granite-3.1-8b-instruct\nprovider: IBM\nlicense: apache-2.0\nlanguage:\n    - en\n    - de\ntasks:\n    - text-generation\n---\n\n# Granite\n\nAn instruct model.\n"
+	if string(card) != want {
+		t.Errorf("Modelcard() = %q, want %q", card, want)
+	}
+
+	model.Readme = nil
+	if _, err := Modelcard(model); err == nil {
+		t.Error("Modelcard() accepted a model without a readme")
+	}
+}
+
+func TestFindModel(t *testing.T) {
+	catalog := &types.ModelsCatalog{Models: []types.CatalogMetadata{
+		{Name: stringPtr("a"), Artifacts: []types.CatalogOCIArtifact{{URI: "oci://quay.io/org/a:1.0"}}},
+		{Name: stringPtr("b"), Artifacts: []types.CatalogOCIArtifact{{URI: "oci://quay.io/org/b:1.0"}, {URI: "oci://quay.io/org/b:2.0"}}},
+	}}
+	if model, ok := FindModel(catalog, "quay.io/org/b:2.0"); !ok || *model.Name != "b" {
+		t.Errorf("FindModel() = %v, %v, want b", model.Name, ok)
+	}
+	if _, ok := FindModel(catalog, "oci://quay.io/org/a:1.0"); !ok {
+		t.Error("FindModel() did not match an oci:// reference")
+	}
+	if _, ok := FindModel(catalog, "quay.io/org/c:1.0"); ok {
+		t.Error("FindModel() matched an unknown reference")
+	}
+}
+
+func TestRepack(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	source := writeModelcar(t, dir)
+	destination := "oci:" + filepath.Join(dir, "repacked") + ":1.0"
+
+	if _, err := Repack(ctx, Options{Source: source, Destination: destination, Modelcard: []byte("# Granite v1\n")}); err != nil {
+		t.Fatalf("Repack() error = %v", err)
+	}
+	m, config, modelcard := readImage(t, destination, nil)
+	if len(m.Layers) != 2 || m.Layers[1].Annotations[extraction.DefaultModelcardAnnotation.Key] != extraction.DefaultModelcardAnnotation.Value {
+		t.Fatalf("layers = %+v, want the model layer and a modelcard layer", m.Layers)
+	}
+	if modelcard != "# Granite v1\n" {
+		t.Errorf("modelcard = %q", modelcard)
+	}
+	if len(config.RootFS.DiffIDs) != 2 || config.RootFS.DiffIDs[1] != m.Layers[1].Digest {
+		t.Errorf("diff IDs = %v, want the modelcard layer digest appended", config.RootFS.DiffIDs)
+	}
+	if len(config.History) != 2 || !strings.Contains(config.History[1].Comment, ModelcardPath) {
+		t.Errorf("history = %+v", config.History)
+	}
+
+	// An image with a modelcard is only repacked when replacing it
+	if _, err := Repack(ctx, Options{Source: destination, Destination: destination, Modelcard: []byte("# Granite v2\n")}); err == nil {
+		t.Error("Repack() replaced an existing modelcard without Replace")
+	}
+	if _, err := Repack(ctx, Options{Source: destination, Destination: destination, Modelcard: []byte("# Granite v2\n"), Replace: true}); err != nil {
+		t.Fatalf("Repack() with Replace error = %v", err)
+	}
+	m, config, modelcard = readImage(t, destination, nil)
+	if len(m.Layers) != 2 || len(config.RootFS.DiffIDs) != 2 || modelcard != "# Granite v2\n" {
+		t.Errorf("replaced image has %d layers, %d diff IDs and modelcard %q", len(m.Layers), len(config.RootFS.DiffIDs), modelcard)
+	}
+
+	// Configured annotations mark the new layer and identify the layer to replace
+	custom := []extraction.LayerAnnotation{{Key: "com.example.layer.kind", Value: "docs"}}
+	customDestination := "oci:" + filepath.Join(dir, "custom") + ":1.0"
+	if _, err := Repack(ctx, Options{Source: source, Destination: customDestination, Modelcard: []byte("# Granite v3\n"), ModelcardAnnotations: custom}); err != nil {
+		t.Fatalf("Repack() with annotations error = %v", err)
+	}
+	if _, err := Repack(ctx, Options{Source: customDestination, Destination: customDestination, Modelcard: []byte("# Granite v4\n"), ModelcardAnnotations: custom}); err == nil {
+		t.Error("Repack() did not recognize the layer with the configured annotation as a modelcard")
+	}
+	m, _, modelcard = readImage(t, customDestination, custom)
+	if len(m.Layers) != 2 || m.Layers[1].Annotations["com.example.layer.kind"] != "docs" || modelcard != "# Granite v3\n" {
+		t.Errorf("layers = %+v with modelcard %q, want a modelcard layer with the configured annotation", m.Layers, modelcard)
+	}
+}
@@ -0,0 +1,175 @@
+// Package search builds a full-text inverted index over the names, descriptions, tasks and
+// readmes of catalog models, so lightweight catalog UIs can search the catalog without running
+// their own indexer.
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// Field weights: a term in a model's name counts more than one in its tasks, description or
+// readme
+const (
+	NameWeight        = 8
+	TaskWeight        = 4
+	DescriptionWeight = 2
+	ReadmeWeight      = 1
+)
+
+// maxOccurrences caps how often a term counts per field, so a long readme repeating a word does
+// not outrank a model named after it
+const maxOccurrences = 5
+
+// stopWords are common English words left out of the index
+var stopWords = map[string]bool{
+	"an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "by": true,
+	"for": true, "from": true, "has": true, "in": true, "is": true, "it": true, "its": true,
+	"of": true, "on": true, "or": true, "that": true, "the": true, "this": true, "to": true,
+	"was": true, "were": true, "will": true, "with": true,
+}
+
+// Index is an inverted index of catalog models
+type Index struct {
+	// Documents are the indexed models; postings refer to them by position
+	Documents []Document `json:"documents"`
+	// Terms maps every indexed term to the models containing it, in document order
+	Terms map[string][]Posting `json:"terms"`
+}
+
+// Document is the summary of an indexed model shown in search results
+type Document struct {
+	Name        string   `json:"name"`
+	Provider    string   `json:"provider,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Tasks       []string `json:"tasks,omitempty"`
+}
+
+// Posting records that a term occurs in a document, with the weighted number of occurrences
+type Posting struct {
+	Doc   int `json:"doc"`
+	Score int `json:"score"`
+}
+
+// Result is a document matching a query with its score
+type Result struct {
+	Document
+	Score int
+}
+
+// Tokenize splits text into lowercase terms on every character other than a letter or digit,
+// dropping single characters and stop words. Queries must be tokenized the same way.
+func Tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if len([]rune(field)) < 2 || stopWords[field] {
+			continue
+		}
+		terms = append(terms, field)
+	}
+	return terms
+}
+
+// Build indexes the named models of a catalog
+func Build(catalog *types.ModelsCatalog) *Index {
+	index := &Index{Documents: []Document{}, Terms: make(map[string][]Posting)}
+	for _, model := range catalog.Models {
+		if model.Name == nil || *model.Name == "" {
+			continue
+		}
+		doc := Document{Name: *model.Name, Tasks: model.Tasks}
+		if model.Provider != nil {
+			doc.Provider = *model.Provider
+		}
+		if model.Description != nil {
+			doc.Description = *model.Description
+		}
+
+		scores := make(map[string]int)
+		addField(scores, doc.Name, NameWeight)
+		addField(scores, strings.Join(model.Tasks, " "), TaskWeight)
+		addField(scores, doc.Description, DescriptionWeight)
+		if model.Readme != nil {
+			addField(scores, *model.Readme, ReadmeWeight)
+		}
+
+		position := len(index.Documents)
+		index.Documents = append(index.Documents, doc)
+		for term, score := range scores {
+			index.Terms[term] = append(index.Terms[term], Posting{Doc: position, Score: score})
+		}
+	}
+	return index
+}
+
+// addField adds the weighted occurrences of the terms of a field's text to scores
+func addField(scores map[string]int, text string, weight int) {
+	counts := make(map[string]int)
+	for _, term := range Tokenize(text) {
+		counts[term]++
+	}
+	for term, count := range counts {
+		scores[term] += weight * min(count, maxOccurrences)
+	}
+}
+
+// Search returns the documents containing every term of query, highest score first
+func (i *Index) Search(query string) []Result {
+	terms := Tokenize(query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	scores := make(map[int]int)
+	matched := make(map[int]int)
+	seen := make(map[string]bool)
+	for _, term := range terms {
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+		for _, posting := range i.Terms[term] {
+			scores[posting.Doc] += posting.Score
+			matched[posting.Doc]++
+		}
+	}
+
+	var results []Result
+	for doc, count := range matched {
+		if count == len(seen) {
+			results = append(results, Result{Document: i.Documents[doc], Score: scores[doc]})
+		}
+	}
+	sort.Slice(results, func(a, b int) bool {
+		if results[a].Score != results[b].Score {
+			return results[a].Score > results[b].Score
+		}
+		return results[a].Name < results[b].Name
+	})
+	return results
+}
+
+// WriteIndex writes a search index file as JSON
+func WriteIndex(path string, index *Index) error {
+	data, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("error encoding search index: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating search index directory: %v", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("error writing search index: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package search
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func stringPtr(s string) *string { return &s }
+
+func testCatalog() *types.ModelsCatalog {
+	return &types.ModelsCatalog{Models: []types.CatalogMetadata{
+		{
+			Name:        stringPtr("RedHatAI/granite-3.1-8b-instruct"),
+			Provider:    stringPtr("IBM"),
+			Description: stringPtr("An instruction-tuned model for code and chat"),
+			Tasks:       []string{"text-generation"},
+			Readme:      stringPtr("# Granite\n\nGranite is trained on code. Code code code code code code code."),
+		},
+		{
+			Name:        stringPtr("RedHatAI/Llama-3.1-8B-Instruct"),
+			Description: stringPtr("Multilingual chat model"),
+			Tasks:       []string{"text-generation"},
+			Readme:      stringPtr("Llama writes code too."),
+		},
+		{
+			Name:  stringPtr("RedHatAI/whisper-large-v3"),
+			Tasks: []string{"automatic-speech-recognition"},
+		},
+		{Description: stringPtr("A model without a name is not indexed")},
+	}}
+}
+
+func TestTokenize(t *testing.T) {
+	got := Tokenize("The Granite-3.1 model, for CODE and chat!")
+	want := []string{"granite", "model", "code", "chat"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tokenize() = %v, want %v", got, want)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	index := Build(testCatalog())
+
+	if len(index.Documents) != 3 {
+		t.Fatalf("Build() indexed %d documents, want 3", len(index.Documents))
+	}
+	if index.Documents[0].Provider != "IBM" || index.Documents[0].Tasks[0] != "text-generation" {
+		t.Errorf("Documents[0] = %+v", index.Documents[0])
+	}
+	// "granite" occurs in the name, and twice in the readme
+	if got := index.Terms["granite"]; !reflect.DeepEqual(got, []Posting{{Doc: 0, Score: NameWeight + 2*ReadmeWeight}}) {
+		t.Errorf("Terms[granite] = %v", got)
+	}
+	// "code" occurs in the description and nine times in the readme, capped at maxOccurrences
+	if got := index.Terms["code"]; !reflect.DeepEqual(got, []Posting{
+		{Doc: 0, Score: DescriptionWeight + maxOccurrences*ReadmeWeight},
+		{Doc: 1, Score: ReadmeWeight},
+	}) {
+		t.Errorf("Terms[code] = %v", got)
+	}
+	if _, ok := index.Terms["without"]; ok {
+		t.Error("Build() indexed a model without a name")
+	}
+}
+
+func TestSearch(t *testing.T) {
+	index := Build(testCatalog())
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		// Equal scores are ordered by name
+		{"chat", []string{"RedHatAI/Llama-3.1-8B-Instruct", "RedHatAI/granite-3.1-8b-instruct"}},
+		{"llama chat", []string{"RedHatAI/Llama-3.1-8B-Instruct"}},
+		{"speech recognition", []string{"RedHatAI/whisper-large-v3"}},
+		{"Code", []string{"RedHatAI/granite-3.1-8b-instruct", "RedHatAI/Llama-3.1-8B-Instruct"}},
+		{"granite whisper", nil},
+		{"the", nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, result := range index.Search(tt.query) {
+			got = append(got, result.Name)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestWriteIndex(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "search", "models-search-index.json")
+	if err := WriteIndex(path, Build(testCatalog())); err != nil {
+		t.Fatalf("WriteIndex() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read search index: %v", err)
+	}
+	var index Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		t.Fatalf("Search index is not valid JSON: %v", err)
+	}
+	if results := index.Search("whisper"); len(results) != 1 || results[0].Name != "RedHatAI/whisper-large-v3" {
+		t.Errorf("Search(whisper) on the written index = %+v", results)
+	}
+}
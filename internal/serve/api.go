@@ -0,0 +1,209 @@
+package serve
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/catalog"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// Catalog holds the models catalog served by the REST API. It is reloaded after every refresh
+// and is safe for concurrent use.
+type Catalog struct {
+	mu      sync.RWMutex
+	catalog *types.ModelsCatalog
+}
+
+// NewCatalog returns an empty catalog; the API answers 503 until a catalog is loaded
+func NewCatalog() *Catalog {
+	return &Catalog{}
+}
+
+// Load reads the catalog file and replaces the served catalog
+func (c *Catalog) Load(path string) error {
+	loaded, err := catalog.ReadCatalog(path)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.catalog = loaded
+	c.mu.Unlock()
+	return nil
+}
+
+// current returns the served catalog, or nil before the first load
+func (c *Catalog) current() *types.ModelsCatalog {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.catalog
+}
+
+// modelsResponse is the body of GET /models. Models are encoded with the catalog's field names.
+type modelsResponse struct {
+	Source string            `json:"source"`
+	Count  int               `json:"count"`
+	Models []json.RawMessage `json:"models"`
+}
+
+// registerCatalogRoutes adds the REST API over the catalog: GET /models lists models matching the
+// query filters, GET /models/{name} returns one model and GET /models/{name}/readme its readme.
+// Model names contain slashes, e.g. /models/RedHatAI/granite-3.1-8b-instruct/readme.
+func registerCatalogRoutes(mux *http.ServeMux, cat *Catalog) {
+	mux.HandleFunc("GET /models", func(w http.ResponseWriter, r *http.Request) {
+		current := cat.current()
+		if current == nil {
+			http.Error(w, "catalog not loaded yet", http.StatusServiceUnavailable)
+			return
+		}
+		filter, err := parseModelFilter(r.URL.Query())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		response := modelsResponse{Source: current.Source, Models: []json.RawMessage{}}
+		for _, model := range current.Models {
+			if !filter.matches(model) {
+				continue
+			}
+			// Readmes are served separately to keep listings small
+			model.Readme = nil
+			data, err := catalog.EncodeModelJSON(&model)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("error encoding model: %v", err), http.StatusInternalServerError)
+				return
+			}
+			response.Models = append(response.Models, data)
+		}
+		response.Count = len(response.Models)
+		writeJSON(w, response)
+	})
+
+	mux.HandleFunc("GET /models/{path...}", func(w http.ResponseWriter, r *http.Request) {
+		current := cat.current()
+		if current == nil {
+			http.Error(w, "catalog not loaded yet", http.StatusServiceUnavailable)
+			return
+		}
+		path := r.PathValue("path")
+
+		if model := findModel(current, path); model != nil {
+			data, err := catalog.EncodeModelJSON(model)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("error encoding model: %v", err), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write(data)
+			return
+		}
+
+		if name, ok := strings.CutSuffix(path, "/readme"); ok {
+			if model := findModel(current, name); model != nil {
+				if model.Readme == nil {
+					http.Error(w, "model has no readme", http.StatusNotFound)
+					return
+				}
+				w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+				_, _ = w.Write([]byte(*model.Readme))
+				return
+			}
+		}
+		http.Error(w, fmt.Sprintf("model %q not found", path), http.StatusNotFound)
+	})
+}
+
+// findModel returns the catalog model with the given name
+func findModel(current *types.ModelsCatalog, name string) *types.CatalogMetadata {
+	for i := range current.Models {
+		if model := &current.Models[i]; model.Name != nil && *model.Name == name {
+			return model
+		}
+	}
+	return nil
+}
+
+// modelFilter holds the query parameters of GET /models; empty fields match every model
+type modelFilter struct {
+	provider   string
+	license    string
+	task       string
+	labels     []string
+	query      string
+	deprecated *bool
+}
+
+func parseModelFilter(values url.Values) (modelFilter, error) {
+	filter := modelFilter{
+		provider: values.Get("provider"),
+		license:  values.Get("license"),
+		task:     values.Get("task"),
+		labels:   values["label"],
+		query:    strings.ToLower(values.Get("q")),
+	}
+	if value := values.Get("deprecated"); value != "" {
+		deprecated, err := strconv.ParseBool(value)
+		if err != nil {
+			return modelFilter{}, fmt.Errorf("invalid deprecated value %q: must be true or false", value)
+		}
+		filter.deprecated = &deprecated
+	}
+	return filter, nil
+}
+
+// matches reports whether a model satisfies every filter: provider, license and task compare
+// case-insensitively, every label must be present, and q searches the name and description
+func (f modelFilter) matches(model types.CatalogMetadata) bool {
+	if f.provider != "" && !strings.EqualFold(utils.StringValue(model.Provider), f.provider) {
+		return false
+	}
+	if f.license != "" && !strings.EqualFold(utils.StringValue(model.License), f.license) {
+		return false
+	}
+	if f.task != "" && !containsFold(model.Tasks, f.task) {
+		return false
+	}
+	for _, label := range f.labels {
+		if !catalog.HasLabel(model, label) {
+			return false
+		}
+	}
+	if f.query != "" &&
+		!strings.Contains(strings.ToLower(utils.StringValue(model.Name)), f.query) &&
+		!strings.Contains(strings.ToLower(utils.StringValue(model.Description)), f.query) {
+		return false
+	}
+	if f.deprecated != nil && model.Deprecated != *f.deprecated {
+		return false
+	}
+	return true
+}
+
+func containsFold(values []string, want string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, want) {
+			return true
+		}
+	}
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error encoding response: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		log.Printf("Warning: Failed to write response: %v", err)
+	}
+}
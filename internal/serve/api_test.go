@@ -0,0 +1,130 @@
+package serve
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+const testCatalog = `source: Red Hat
+models:
+  - name: RedHatAI/granite-3.1-8b-instruct
+    provider: IBM
+    license: apache-2.0
+    description: Granite instruct model
+    readme: "# Granite"
+    tasks: [text-generation]
+    customProperties:
+      validated: {metadataType: MetadataStringValue, string_value: ""}
+    artifacts: []
+  - name: RedHatAI/Llama-3.1-8B-Instruct
+    provider: Meta
+    license: llama3.1
+    description: Llama instruct model
+    tasks: [text-generation]
+    deprecated: true
+    artifacts: []
+`
+
+func testCatalogHandler(t *testing.T) http.Handler {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "models-catalog.yaml")
+	writeFile(t, path, testCatalog)
+	cat := NewCatalog()
+	if err := cat.Load(path); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	return NewHandler(nil, cat)
+}
+
+func get(handler http.Handler, target string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+	return recorder
+}
+
+func TestModelsEndpoint_Filters(t *testing.T) {
+	handler := testCatalogHandler(t)
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"RedHatAI/granite-3.1-8b-instruct", "RedHatAI/Llama-3.1-8B-Instruct"}},
+		{"?provider=ibm", []string{"RedHatAI/granite-3.1-8b-instruct"}},
+		{"?license=llama3.1", []string{"RedHatAI/Llama-3.1-8B-Instruct"}},
+		{"?task=text-generation&label=validated", []string{"RedHatAI/granite-3.1-8b-instruct"}},
+		{"?q=LLAMA", []string{"RedHatAI/Llama-3.1-8B-Instruct"}},
+		{"?deprecated=false", []string{"RedHatAI/granite-3.1-8b-instruct"}},
+		{"?task=image-classification", nil},
+	}
+	for _, tt := range tests {
+		recorder := get(handler, "/models"+tt.query)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("GET /models%s returned %d", tt.query, recorder.Code)
+		}
+		var response struct {
+			Count  int `json:"count"`
+			Models []struct {
+				Name   string  `json:"name"`
+				Readme *string `json:"readme"`
+			} `json:"models"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("invalid response for %q: %v", tt.query, err)
+		}
+		if response.Count != len(tt.want) || len(response.Models) != len(tt.want) {
+			t.Errorf("GET /models%s returned %d models, want %d", tt.query, len(response.Models), len(tt.want))
+			continue
+		}
+		for i, model := range response.Models {
+			if model.Name != tt.want[i] {
+				t.Errorf("GET /models%s model %d = %s, want %s", tt.query, i, model.Name, tt.want[i])
+			}
+			if model.Readme != nil {
+				t.Errorf("GET /models%s should omit readmes", tt.query)
+			}
+		}
+	}
+
+	if recorder := get(handler, "/models?deprecated=maybe"); recorder.Code != http.StatusBadRequest {
+		t.Errorf("invalid filter returned %d, want 400", recorder.Code)
+	}
+}
+
+func TestModelEndpoints(t *testing.T) {
+	handler := testCatalogHandler(t)
+
+	recorder := get(handler, "/models/RedHatAI/granite-3.1-8b-instruct")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("GET model returned %d", recorder.Code)
+	}
+	var model map[string]interface{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &model); err != nil {
+		t.Fatalf("invalid model response: %v", err)
+	}
+	if model["provider"] != "IBM" || model["readme"] != "# Granite" {
+		t.Errorf("unexpected model: %v", model)
+	}
+
+	// Escaped slashes address the same model
+	recorder = get(handler, "/models/RedHatAI%2Fgranite-3.1-8b-instruct/readme")
+	if recorder.Code != http.StatusOK || recorder.Body.String() != "# Granite" {
+		t.Errorf("GET readme returned %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	if recorder := get(handler, "/models/RedHatAI/Llama-3.1-8B-Instruct/readme"); recorder.Code != http.StatusNotFound {
+		t.Errorf("readme of a model without one returned %d, want 404", recorder.Code)
+	}
+	if recorder := get(handler, "/models/RedHatAI/unknown"); recorder.Code != http.StatusNotFound {
+		t.Errorf("unknown model returned %d, want 404", recorder.Code)
+	}
+}
+
+func TestModelsEndpoint_NotLoaded(t *testing.T) {
+	if recorder := get(NewHandler(nil, NewCatalog()), "/models"); recorder.Code != http.StatusServiceUnavailable {
+		t.Errorf("GET /models before a catalog is loaded returned %d, want 503", recorder.Code)
+	}
+}
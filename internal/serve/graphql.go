@@ -0,0 +1,640 @@
+package serve
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/lexer"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/catalog"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// maxGraphQLRequestSize caps the body of POST /graphql
+const maxGraphQLRequestSize = 1 << 20
+
+// maxGraphQLQuerySize caps the query string of GET /graphql; larger queries are sent with POST
+const maxGraphQLQuerySize = 16 << 10
+
+// maxGraphQLDepth bounds the nesting of selection sets and list values in a document, so a
+// deeply nested query cannot exhaust the stack of the parser. Catalog queries nest three
+// selection sets at most; introspection queries nest about ten.
+const maxGraphQLDepth = 32
+
+// maxGraphQLComplexity caps the number of fields a query resolves, counting every field of every
+// model in a list, so aliases and fragments cannot multiply the work of a small document
+const maxGraphQLComplexity = 100000
+
+// gqlField describes a field of the catalog GraphQL schema, in the order of the schema document
+type gqlField struct {
+	name        string
+	typ         graphql.Output
+	args        []gqlArgument
+	description string
+	resolve     graphql.FieldResolveFn
+}
+
+// gqlArgument is a field argument
+type gqlArgument struct {
+	name string
+	typ  graphql.Input
+}
+
+// gqlObjectType is an object type of the catalog GraphQL schema with its fields in schema order
+type gqlObjectType struct {
+	object *graphql.Object
+	fields []gqlField
+}
+
+// gqlJSON is the scalar of nested catalog structures and customProperties, returned as the JSON
+// encoding of the catalog
+var gqlJSON = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "JSON",
+	Description: "Arbitrary JSON value, used for nested catalog structures and customProperties",
+	Serialize:   func(value interface{}) interface{} { return value },
+})
+
+// gqlModelFilterArguments are the filters of the models and modelCount queries, matching the
+// query parameters of GET /models
+var gqlModelFilterArguments = []gqlArgument{
+	{"provider", graphql.String},
+	{"license", graphql.String},
+	{"task", graphql.String},
+	{"label", graphql.NewList(graphql.NewNonNull(graphql.String))},
+	{"q", graphql.String},
+	{"deprecated", graphql.Boolean},
+}
+
+// gqlCatalogSchema is the catalog GraphQL schema and its object types in schema order
+type gqlCatalogSchema struct {
+	schema graphql.Schema
+	types  []gqlObjectType
+	// listLength bounds the length of the lists of the introspection types, which is at most
+	// the number of types, fields or enum values of the schema
+	listLength int
+}
+
+// catalogGraphQLSchema builds the catalog GraphQL schema on first use
+var catalogGraphQLSchema = sync.OnceValues(newCatalogGraphQLSchema)
+
+// newCatalogGraphQLSchema builds the Query, Model and Artifact types. Model and Artifact fields
+// are named after the catalog fields; nested structures are JSON scalars.
+func newCatalogGraphQLSchema() (*gqlCatalogSchema, error) {
+	artifact := newGQLObjectType("Artifact", []gqlField{
+		artifactField("uri", graphql.String),
+		artifactField("createTimeSinceEpoch", graphql.String),
+		artifactField("lastUpdateTimeSinceEpoch", graphql.String),
+		artifactField("customProperties", gqlJSON),
+		artifactField("verification", gqlJSON),
+		artifactField("vulnerabilities", gqlJSON),
+		artifactField("redHatCatalog", gqlJSON),
+	})
+
+	stringList := graphql.NewList(graphql.NewNonNull(graphql.String))
+	model := newGQLObjectType("Model", []gqlField{
+		modelField("name", graphql.String, ""),
+		modelField("provider", graphql.String, ""),
+		modelField("description", graphql.String, ""),
+		modelField("description_i18n", gqlJSON, "Localized descriptions keyed by language tag"),
+		modelField("readme", graphql.String, ""),
+		modelField("language", stringList, ""),
+		modelField("license", graphql.String, ""),
+		modelField("licenseLink", graphql.String, ""),
+		modelField("tasks", stringList, ""),
+		modelField("validatedTasks", stringList, ""),
+		modelField("trainingDatasets", stringList, ""),
+		modelField("baseModel", stringList, ""),
+		modelField("trainingData", graphql.String, ""),
+		{name: "labels", typ: graphql.NewNonNull(stringList), description: "Labels of the model: customProperties without a value",
+			resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return modelLabels(p.Source.(*gqlModel).model), nil
+			}},
+		modelField("servingConfig", gqlJSON, ""),
+		modelField("quantization", gqlJSON, ""),
+		modelField("hardwareRequirements", gqlJSON, ""),
+		modelField("responsibleUse", gqlJSON, ""),
+		modelField("maxContextLength", graphql.Int, ""),
+		modelField("modelConfig", gqlJSON, ""),
+		modelField("evaluations", gqlJSON, ""),
+		modelField("deprecated", graphql.Boolean, ""),
+		modelField("endOfLife", graphql.String, ""),
+		modelField("replacedBy", graphql.String, ""),
+		modelField("createTimeSinceEpoch", graphql.String, ""),
+		modelField("lastUpdateTimeSinceEpoch", graphql.String, ""),
+		modelField("customProperties", gqlJSON, ""),
+		{name: "artifacts", typ: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(artifact.object))),
+			resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if artifacts, ok := p.Source.(*gqlModel).fields["artifacts"].([]interface{}); ok {
+					return artifacts, nil
+				}
+				return []interface{}{}, nil
+			}},
+		modelField("logo", graphql.String, ""),
+		modelField("source", graphql.String, ""),
+	})
+
+	query := newGQLObjectType("Query", []gqlField{
+		{name: "source", typ: graphql.String, description: "Source name of the catalog",
+			resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*types.ModelsCatalog).Source, nil
+			}},
+		{name: "models", typ: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(model.object))), description: "Models matching every filter, in catalog order",
+			args: append(append([]gqlArgument{}, gqlModelFilterArguments...), gqlArgument{"first", graphql.Int}, gqlArgument{"offset", graphql.Int}),
+			resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolveModels(p.Source.(*types.ModelsCatalog), p.Args)
+			}},
+		{name: "modelCount", typ: graphql.NewNonNull(graphql.Int), description: "Number of models matching every filter", args: gqlModelFilterArguments,
+			resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return len(matchingModels(p.Source.(*types.ModelsCatalog), p.Args)), nil
+			}},
+		{name: "model", typ: model.object, description: "The model with the given name", args: []gqlArgument{{"name", graphql.NewNonNull(graphql.String)}},
+			resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				found := findModel(p.Source.(*types.ModelsCatalog), p.Args["name"].(string))
+				if found == nil {
+					return nil, nil
+				}
+				return newGQLModel(*found)
+			}},
+	})
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: query.object})
+	if err != nil {
+		return nil, fmt.Errorf("error building the GraphQL schema: %v", err)
+	}
+	s := &gqlCatalogSchema{schema: schema, types: []gqlObjectType{query, model, artifact}, listLength: len(schema.TypeMap())}
+	for _, typ := range schema.TypeMap() {
+		switch typ := typ.(type) {
+		case *graphql.Object:
+			s.listLength = max(s.listLength, len(typ.Fields()))
+		case *graphql.Enum:
+			s.listLength = max(s.listLength, len(typ.Values()))
+		}
+	}
+	return s, nil
+}
+
+// newGQLObjectType creates an object type with the given fields
+func newGQLObjectType(name string, fields []gqlField) gqlObjectType {
+	config := graphql.Fields{}
+	for _, field := range fields {
+		args := graphql.FieldConfigArgument{}
+		for _, arg := range field.args {
+			args[arg.name] = &graphql.ArgumentConfig{Type: arg.typ}
+		}
+		config[field.name] = &graphql.Field{Type: field.typ, Args: args, Description: field.description, Resolve: field.resolve}
+	}
+	return gqlObjectType{object: graphql.NewObject(graphql.ObjectConfig{Name: name, Fields: config}), fields: fields}
+}
+
+// modelField is a Model field resolved from the catalog's JSON encoding of the model
+func modelField(name string, typ graphql.Output, description string) gqlField {
+	return gqlField{name: name, typ: typ, description: description, resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		value := p.Source.(*gqlModel).fields[name]
+		// Model encodings keep their numbers as json.Number, which the Int scalar cannot serialize
+		if number, ok := value.(json.Number); ok && typ == graphql.Int {
+			return number.Int64()
+		}
+		return value, nil
+	}}
+}
+
+// artifactField is an Artifact field resolved from the catalog's JSON encoding of the artifact
+func artifactField(name string, typ graphql.Output) gqlField {
+	return gqlField{name: name, typ: typ, resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		if artifact, ok := p.Source.(map[string]interface{}); ok {
+			return artifact[name], nil
+		}
+		return nil, nil
+	}}
+}
+
+// GraphQLSchema returns the schema of the catalog GraphQL API in the schema definition language
+func GraphQLSchema() string {
+	s, err := catalogGraphQLSchema()
+	if err != nil {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%q\nscalar %s\n", gqlJSON.Description(), gqlJSON.Name())
+	for _, typ := range s.types {
+		fmt.Fprintf(&b, "\ntype %s {\n", typ.object.Name())
+		for _, field := range typ.fields {
+			if field.description != "" {
+				fmt.Fprintf(&b, "  %q\n", field.description)
+			}
+			b.WriteString("  " + field.name)
+			if len(field.args) > 0 {
+				var args []string
+				for _, arg := range field.args {
+					args = append(args, arg.name+": "+arg.typ.String())
+				}
+				b.WriteString("(" + strings.Join(args, ", ") + ")")
+			}
+			b.WriteString(": " + field.typ.String() + "\n")
+		}
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
+
+// graphQLRequest is a GraphQL request in the GraphQL-over-HTTP JSON encoding
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type graphQLResponse struct {
+	Data   interface{}                `json:"data"`
+	Errors []gqlerrors.FormattedError `json:"errors,omitempty"`
+}
+
+// registerGraphQLRoutes adds the GraphQL API over the catalog: GET and POST /graphql execute
+// queries and GET /graphql/schema returns the schema
+func registerGraphQLRoutes(mux *http.ServeMux, cat *Catalog) {
+	handle := func(w http.ResponseWriter, r *http.Request, request graphQLRequest) {
+		current := cat.current()
+		if current == nil {
+			http.Error(w, "catalog not loaded yet", http.StatusServiceUnavailable)
+			return
+		}
+		data, errs := executeGraphQL(r.Context(), current, request)
+		if len(errs) > 0 {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(graphQLResponse{Errors: errs})
+			return
+		}
+		writeJSON(w, graphQLResponse{Data: data})
+	}
+
+	mux.HandleFunc("POST /graphql", func(w http.ResponseWriter, r *http.Request) {
+		var request graphQLRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxGraphQLRequestSize)).Decode(&request); err != nil {
+			http.Error(w, fmt.Sprintf("invalid GraphQL request: %v", err), http.StatusBadRequest)
+			return
+		}
+		handle(w, r, request)
+	})
+
+	mux.HandleFunc("GET /graphql", func(w http.ResponseWriter, r *http.Request) {
+		if len(r.URL.RawQuery) > maxGraphQLQuerySize {
+			http.Error(w, fmt.Sprintf("GraphQL query string exceeds %d bytes; send large queries with POST", maxGraphQLQuerySize), http.StatusRequestURITooLong)
+			return
+		}
+		query := r.URL.Query()
+		request := graphQLRequest{Query: query.Get("query"), OperationName: query.Get("operationName")}
+		if variables := query.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &request.Variables); err != nil {
+				http.Error(w, fmt.Sprintf("invalid GraphQL variables: %v", err), http.StatusBadRequest)
+				return
+			}
+		}
+		handle(w, r, request)
+	})
+
+	mux.HandleFunc("GET /graphql/schema", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte(GraphQLSchema()))
+	})
+}
+
+// executeGraphQL runs a query against the catalog and returns its data. The catalog is static,
+// so every error is a request error and no partial data is returned.
+func executeGraphQL(ctx context.Context, current *types.ModelsCatalog, request graphQLRequest) (gqlObject, []gqlerrors.FormattedError) {
+	fail := func(format string, args ...interface{}) (gqlObject, []gqlerrors.FormattedError) {
+		return nil, []gqlerrors.FormattedError{gqlerrors.NewFormattedError(fmt.Sprintf(format, args...))}
+	}
+	if strings.TrimSpace(request.Query) == "" {
+		return fail("missing query")
+	}
+	s, err := catalogGraphQLSchema()
+	if err != nil {
+		return fail("%v", err)
+	}
+
+	src := source.NewSource(&source.Source{Body: []byte(request.Query), Name: "GraphQL request"})
+	if err := checkGraphQLDepth(src); err != nil {
+		return fail("%v", err)
+	}
+	doc, err := parser.Parse(parser.ParseParams{Source: src})
+	if err != nil {
+		return nil, gqlerrors.FormatErrors(err)
+	}
+	if validation := graphql.ValidateDocument(&s.schema, doc, nil); !validation.IsValid {
+		return nil, validation.Errors
+	}
+
+	q := newGQLQuery(doc, request.OperationName)
+	if q.operation == nil {
+		return fail("unknown operation %q", request.OperationName)
+	}
+	if complexity := q.complexity(s, current, s.schema.QueryType(), []*ast.SelectionSet{q.operation.SelectionSet}); complexity > maxGraphQLComplexity {
+		return fail("query exceeds the maximum complexity of %d resolved fields", maxGraphQLComplexity)
+	}
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        s.schema,
+		Root:          current,
+		AST:           doc,
+		OperationName: request.OperationName,
+		Args:          request.Variables,
+		Context:       ctx,
+	})
+	if len(result.Errors) > 0 {
+		return nil, result.Errors
+	}
+	data, _ := q.order(result.Data, []*ast.SelectionSet{q.operation.SelectionSet}).(gqlObject)
+	return data, nil
+}
+
+// checkGraphQLDepth fails for documents nesting selection sets and list values deeper than
+// maxGraphQLDepth. Lexer errors are left to the parser to report.
+func checkGraphQLDepth(src *source.Source) error {
+	next := lexer.Lex(src)
+	depth := 0
+	for {
+		token, err := next(0)
+		if err != nil || token.Kind == lexer.EOF {
+			return nil
+		}
+		switch token.Kind {
+		case lexer.BRACE_L, lexer.BRACKET_L:
+			depth++
+			if depth > maxGraphQLDepth {
+				return fmt.Errorf("query exceeds the maximum nesting depth of %d at offset %d", maxGraphQLDepth, token.Start)
+			}
+		case lexer.BRACE_R, lexer.BRACKET_R:
+			depth--
+		}
+	}
+}
+
+// gqlQuery is a validated request document and the operation it executes
+type gqlQuery struct {
+	operation *ast.OperationDefinition
+	fragments map[string]*ast.FragmentDefinition
+}
+
+func newGQLQuery(doc *ast.Document, operationName string) *gqlQuery {
+	q := &gqlQuery{fragments: make(map[string]*ast.FragmentDefinition)}
+	for _, definition := range doc.Definitions {
+		switch definition := definition.(type) {
+		case *ast.OperationDefinition:
+			if operationName == "" || (definition.Name != nil && definition.Name.Value == operationName) {
+				q.operation = definition
+			}
+		case *ast.FragmentDefinition:
+			q.fragments[definition.Name.Value] = definition
+		}
+	}
+	return q
+}
+
+// collectFields groups the fields of selection sets by response key, in selection order,
+// expanding each fragment once like the executor does
+func (q *gqlQuery) collectFields(sets []*ast.SelectionSet) ([]string, map[string][]*ast.Field) {
+	var keys []string
+	fields := make(map[string][]*ast.Field)
+	visited := make(map[string]bool)
+	var collect func(set *ast.SelectionSet)
+	collect = func(set *ast.SelectionSet) {
+		if set == nil {
+			return
+		}
+		for _, selection := range set.Selections {
+			switch selection := selection.(type) {
+			case *ast.Field:
+				key := selection.Name.Value
+				if selection.Alias != nil {
+					key = selection.Alias.Value
+				}
+				if _, exists := fields[key]; !exists {
+					keys = append(keys, key)
+				}
+				fields[key] = append(fields[key], selection)
+			case *ast.InlineFragment:
+				collect(selection.SelectionSet)
+			case *ast.FragmentSpread:
+				name := selection.Name.Value
+				if fragment, exists := q.fragments[name]; exists && !visited[name] {
+					visited[name] = true
+					collect(fragment.SelectionSet)
+				}
+			}
+		}
+	}
+	for _, set := range sets {
+		collect(set)
+	}
+	return keys, fields
+}
+
+// subSelections returns the selection sets of fields merged under one response key
+func subSelections(fields []*ast.Field) []*ast.SelectionSet {
+	var sets []*ast.SelectionSet
+	for _, field := range fields {
+		if field.SelectionSet != nil {
+			sets = append(sets, field.SelectionSet)
+		}
+	}
+	return sets
+}
+
+// complexity returns an upper bound of the number of fields the selection sets resolve on a value
+// of typ: list fields multiply their selections by the number of models or artifacts of the
+// catalog, or by the schema's list length for introspection lists
+func (q *gqlQuery) complexity(s *gqlCatalogSchema, current *types.ModelsCatalog, typ graphql.Type, sets []*ast.SelectionSet) int {
+	object, ok := graphql.GetNamed(typ).(*graphql.Object)
+	if !ok {
+		return 0
+	}
+	keys, fields := q.collectFields(sets)
+	total := 0
+	for _, key := range keys {
+		total++
+		definition, exists := object.Fields()[fields[key][0].Name.Value]
+		sub := subSelections(fields[key])
+		if !exists || len(sub) == 0 {
+			continue
+		}
+		items := 1
+		if _, isList := graphql.GetNullable(definition.Type).(*graphql.List); isList {
+			items = s.listLength
+			switch definition.Name {
+			case "models":
+				items = len(current.Models)
+			case "artifacts":
+				items = 0
+				for _, model := range current.Models {
+					items = max(items, len(model.Artifacts))
+				}
+			}
+		}
+		total += items * q.complexity(s, current, definition.Type, sub)
+		if total > maxGraphQLComplexity {
+			break
+		}
+	}
+	return total
+}
+
+// order converts executor results, whose objects are maps, to objects with their fields in the
+// order of the selection sets
+func (q *gqlQuery) order(value interface{}, sets []*ast.SelectionSet) interface{} {
+	if len(sets) == 0 {
+		// Leaf values, including JSON scalars holding maps, are returned as they are
+		return value
+	}
+	switch value := value.(type) {
+	case map[string]interface{}:
+		keys, fields := q.collectFields(sets)
+		object := gqlObject{}
+		for _, key := range keys {
+			if fieldValue, exists := value[key]; exists {
+				object = append(object, gqlObjectField{key, q.order(fieldValue, subSelections(fields[key]))})
+			}
+		}
+		return object
+	case []interface{}:
+		items := make([]interface{}, len(value))
+		for i, item := range value {
+			items[i] = q.order(item, sets)
+		}
+		return items
+	}
+	return value
+}
+
+// gqlObject is a response object whose fields keep the order of the selection set
+type gqlObject []gqlObjectField
+
+type gqlObjectField struct {
+	key   string
+	value interface{}
+}
+
+// MarshalJSON encodes the object with its fields in selection order
+func (o gqlObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(field.key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// gqlModel is a Model resolved from the catalog: the model and its JSON encoding
+type gqlModel struct {
+	model  types.CatalogMetadata
+	fields map[string]interface{}
+}
+
+// matchingModels returns the models matching the filter arguments
+func matchingModels(current *types.ModelsCatalog, args map[string]interface{}) []types.CatalogMetadata {
+	filter := modelFilter{}
+	filter.provider, _ = args["provider"].(string)
+	filter.license, _ = args["license"].(string)
+	filter.task, _ = args["task"].(string)
+	if labels, ok := args["label"].([]interface{}); ok {
+		for _, label := range labels {
+			if label, ok := label.(string); ok {
+				filter.labels = append(filter.labels, label)
+			}
+		}
+	}
+	if query, ok := args["q"].(string); ok {
+		filter.query = strings.ToLower(query)
+	}
+	if deprecated, ok := args["deprecated"].(bool); ok {
+		filter.deprecated = &deprecated
+	}
+
+	var matches []types.CatalogMetadata
+	for _, model := range current.Models {
+		if filter.matches(model) {
+			matches = append(matches, model)
+		}
+	}
+	return matches
+}
+
+// resolveModels returns the page of models matching the filter arguments selected by first and
+// offset
+func resolveModels(current *types.ModelsCatalog, args map[string]interface{}) (interface{}, error) {
+	matches := matchingModels(current, args)
+	if offset, ok := args["offset"].(int); ok {
+		if offset < 0 {
+			return nil, fmt.Errorf("offset must not be negative")
+		}
+		matches = matches[min(offset, len(matches)):]
+	}
+	if first, ok := args["first"].(int); ok {
+		if first < 0 {
+			return nil, fmt.Errorf("first must not be negative")
+		}
+		matches = matches[:min(first, len(matches))]
+	}
+
+	models := make([]interface{}, 0, len(matches))
+	for _, model := range matches {
+		resolved, err := newGQLModel(model)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, resolved)
+	}
+	return models, nil
+}
+
+// newGQLModel encodes a catalog model so its fields resolve with the catalog's JSON encoding
+func newGQLModel(model types.CatalogMetadata) (*gqlModel, error) {
+	data, err := catalog.EncodeModelJSON(&model)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding model: %v", err)
+	}
+	resolved := &gqlModel{model: model}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&resolved.fields); err != nil {
+		return nil, fmt.Errorf("error encoding model: %v", err)
+	}
+	return resolved, nil
+}
+
+// modelLabels returns the labels of a model: its customProperties with an empty string value
+func modelLabels(model types.CatalogMetadata) []string {
+	labels := []string{}
+	for key, value := range model.CustomProperties {
+		if value.MetadataType == types.MetadataTypeString && value.StringValue == "" {
+			labels = append(labels, key)
+		}
+	}
+	sort.Strings(labels)
+	return labels
+}
@@ -0,0 +1,194 @@
+package serve
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+func postGraphQL(handler http.Handler, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+	handler.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestGraphQL_Query(t *testing.T) {
+	handler := testCatalogHandler(t)
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			"filters and selected fields in order",
+			`{"query": "{ models(provider: \"IBM\") { provider name labels } }"}`,
+			`{"data":{"models":[{"provider":"IBM","name":"RedHatAI/granite-3.1-8b-instruct","labels":["validated"]}]}}`,
+		},
+		{
+			"variables, aliases and count",
+			`{"query": "query Deprecated($deprecated: Boolean = true) { total: modelCount all: modelCount(deprecated: $deprecated) models(deprecated: $deprecated) { name } }", "variables": {"deprecated": false}}`,
+			`{"data":{"total":2,"all":1,"models":[{"name":"RedHatAI/granite-3.1-8b-instruct"}]}}`,
+		},
+		{
+			"labels and pagination",
+			`{"query": "{ models(label: \"validated\", first: 1, offset: 0) { name } second: models(first: 1, offset: 1) { name } }"}`,
+			`{"data":{"models":[{"name":"RedHatAI/granite-3.1-8b-instruct"}],"second":[{"name":"RedHatAI/Llama-3.1-8B-Instruct"}]}}`,
+		},
+		{
+			"single model with fragments and directives",
+			`{"query": "query { model(name: \"RedHatAI/Llama-3.1-8B-Instruct\") { __typename ...Basics ... on Model { deprecated } readme @skip(if: true) artifacts { uri } } } fragment Basics on Model { license }"}`,
+			`{"data":{"model":{"__typename":"Model","license":"llama3.1","deprecated":true,"artifacts":[]}}}`,
+		},
+		{
+			"block strings and unicode escapes",
+			`{"query": "{ a: model(name: \"\\u0052edHatAI/Llama-3.1-8B-Instruct\") { name } b: model(name: \"\"\"\n    RedHatAI/granite-3.1-8b-instruct\n  \"\"\") { name } }"}`,
+			`{"data":{"a":{"name":"RedHatAI/Llama-3.1-8B-Instruct"},"b":{"name":"RedHatAI/granite-3.1-8b-instruct"}}}`,
+		},
+		{
+			"unknown model",
+			`{"query": "{ model(name: \"missing\") { name } source }"}`,
+			`{"data":{"model":null,"source":"Red Hat"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := postGraphQL(handler, tt.body)
+			if response.Code != http.StatusOK {
+				t.Fatalf("Expected 200, got %d: %s", response.Code, response.Body.String())
+			}
+			if got := strings.TrimSpace(response.Body.String()); got != tt.want {
+				t.Errorf("Response = %s\nexpected   %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraphQL_Errors(t *testing.T) {
+	handler := testCatalogHandler(t)
+
+	for _, query := range []string{
+		`{ models { unknown } }`,
+		`{ models }`,
+		`{ source { name } }`,
+		`{ models(vendor: "IBM") { name } }`,
+		`{ model { name } }`,
+		`{ models(first: "ten") { name } }`,
+		`mutation { deleteModel }`,
+		`{ models { ...Missing } }`,
+		`{ models { name `,
+	} {
+		response := get(handler, "/graphql?query="+url.QueryEscape(query))
+		if response.Code != http.StatusBadRequest || !strings.Contains(response.Body.String(), `"errors":[{"message":`) {
+			t.Errorf("Query %s: expected a GraphQL error, got %d: %s", query, response.Code, response.Body.String())
+		}
+	}
+}
+
+func TestGraphQL_Schema(t *testing.T) {
+	response := get(testCatalogHandler(t), "/graphql/schema")
+	schema := response.Body.String()
+	for _, want := range []string{
+		"scalar JSON",
+		"models(provider: String, license: String, task: String, label: [String!], q: String, deprecated: Boolean, first: Int, offset: Int): [Model!]!",
+		"artifacts: [Artifact!]!",
+	} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Schema does not contain %q:\n%s", want, schema)
+		}
+	}
+}
+
+func TestGraphQL_Introspection(t *testing.T) {
+	body := `{"query": "{ __type(name: \"Model\") { name fields { name } } __schema { queryType { name } } }"}`
+	response := postGraphQL(testCatalogHandler(t), body)
+	if response.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", response.Code, response.Body.String())
+	}
+	var result struct {
+		Data struct {
+			Type struct {
+				Name   string `json:"name"`
+				Fields []struct {
+					Name string `json:"name"`
+				} `json:"fields"`
+			} `json:"__type"`
+			Schema struct {
+				QueryType struct {
+					Name string `json:"name"`
+				} `json:"queryType"`
+			} `json:"__schema"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(response.Body.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Data.Type.Name != "Model" || len(result.Data.Type.Fields) != 30 || result.Data.Schema.QueryType.Name != "Query" {
+		t.Errorf("Unexpected introspection result: %s", response.Body.String())
+	}
+}
+
+func TestGraphQL_Limits(t *testing.T) {
+	handler := testCatalogHandler(t)
+
+	deep := strings.Repeat("{ model(name: \"a\") ", maxGraphQLDepth+1) + strings.Repeat("}", maxGraphQLDepth+1)
+	nestedValue := "{ models(q: " + strings.Repeat("[", maxGraphQLDepth) + strings.Repeat("]", maxGraphQLDepth) + ") { name } }"
+	for _, query := range []string{deep, nestedValue} {
+		response := get(handler, "/graphql?query="+url.QueryEscape(query))
+		if response.Code != http.StatusBadRequest || !strings.Contains(response.Body.String(), "maximum nesting depth") {
+			t.Errorf("Expected the nesting depth to be limited, got %d: %s", response.Code, response.Body.String())
+		}
+	}
+
+	// Aliases of models multiply the fields of a fragment by the number of models
+	var fragment, query strings.Builder
+	for i := 0; i < 1000; i++ {
+		fmt.Fprintf(&fragment, "n%d: name ", i)
+	}
+	query.WriteString("{ ")
+	for i := 0; i < maxGraphQLComplexity/1000; i++ {
+		fmt.Fprintf(&query, "m%d: models { ...F } ", i)
+	}
+	query.WriteString("} fragment F on Model { " + fragment.String() + "}")
+	body, err := json.Marshal(graphQLRequest{Query: query.String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	response := postGraphQL(handler, string(body))
+	if response.Code != http.StatusBadRequest || !strings.Contains(response.Body.String(), "maximum complexity") {
+		t.Errorf("Expected the query complexity to be limited, got %d: %.200s", response.Code, response.Body.String())
+	}
+
+	response = get(handler, "/graphql?query="+url.QueryEscape("{ source }"+strings.Repeat(" ", maxGraphQLQuerySize)))
+	if response.Code != http.StatusRequestURITooLong {
+		t.Errorf("Expected a long GET query to be rejected, got %d", response.Code)
+	}
+}
+
+func FuzzGraphQL(f *testing.F) {
+	for _, seed := range []string{
+		`{ models(provider: "IBM") { provider name labels } }`,
+		`query Q($d: Boolean = true, $l: [String!]) { total: modelCount models(deprecated: $d, label: $l) { name } }`,
+		`{ model(name: "m") { __typename ...F ... on Model { deprecated } readme @skip(if: true) artifacts { uri } } } fragment F on Model { license }`,
+		"{ model(name: \"\"\"\n  block\n\"\"\") { name } x: model(name: \"\\u0062\") { name } }",
+		`{ models(first: 1e3, offset: -1, q: [[{a: null}]]) { name } }`,
+	} {
+		f.Add(seed)
+	}
+	name := "m"
+	current := &types.ModelsCatalog{Source: "test", Models: []types.CatalogMetadata{{Name: &name}}}
+
+	f.Fuzz(func(t *testing.T, query string) {
+		// Any document either fails with an error or executes; none may panic
+		_, _ = executeGraphQL(context.Background(), current, graphQLRequest{Query: query})
+	})
+}
@@ -0,0 +1,25 @@
+package serve
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/metrics"
+)
+
+// NewHandler returns the HTTP handler of the serve subcommand: /metrics exposes the registry in
+// the Prometheus text format, /models and /graphql the served catalog, and /openapi.yaml the API's
+// OpenAPI document
+func NewHandler(reg *metrics.Registry, cat *Catalog) http.Handler {
+	mux := http.NewServeMux()
+	registerCatalogRoutes(mux, cat)
+	registerGraphQLRoutes(mux, cat)
+	registerOpenAPIRoutes(mux)
+	mux.HandleFunc("GET /metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		if err := reg.WriteText(w); err != nil {
+			log.Printf("Warning: Failed to write metrics: %v", err)
+		}
+	})
+	return mux
+}
@@ -0,0 +1,25 @@
+package serve
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// openAPISpec is the OpenAPI document of the endpoints served by NewHandler. Its info.version is
+// bumped on incompatible changes; pkg/client implements it.
+//
+//go:embed openapi.yaml
+var openAPISpec []byte
+
+// OpenAPISpec returns the OpenAPI document of the serve API
+func OpenAPISpec() []byte {
+	return openAPISpec
+}
+
+// registerOpenAPIRoutes serves the OpenAPI document on GET /openapi.yaml
+func registerOpenAPIRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /openapi.yaml", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/yaml")
+		_, _ = w.Write(openAPISpec)
+	})
+}
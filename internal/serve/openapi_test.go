@@ -0,0 +1,69 @@
+package serve
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/metrics"
+)
+
+// TestOpenAPISpecCoversRoutes checks that every operation of the OpenAPI document is served, so
+// the document cannot drift from the handler
+func TestOpenAPISpecCoversRoutes(t *testing.T) {
+	var spec struct {
+		OpenAPI string                                       `yaml:"openapi"`
+		Paths   map[string]map[string]map[string]interface{} `yaml:"paths"`
+	}
+	if err := yaml.Unmarshal(OpenAPISpec(), &spec); err != nil {
+		t.Fatalf("parsing OpenAPI document: %v", err)
+	}
+	if spec.OpenAPI == "" || len(spec.Paths) == 0 {
+		t.Fatalf("OpenAPI document has no version or paths")
+	}
+
+	catalogPath := filepath.Join(t.TempDir(), "models-catalog.yaml")
+	writeFile(t, catalogPath, testCatalog)
+	cat := NewCatalog()
+	if err := cat.Load(catalogPath); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	handler := NewHandler(metrics.NewRegistry(), cat)
+	for path, operations := range spec.Paths {
+		target := strings.ReplaceAll(path, "{name}", "RedHatAI/granite-3.1-8b-instruct")
+		for method := range operations {
+			var body *strings.Reader
+			switch method {
+			case "post":
+				body = strings.NewReader(`{"query": "{ modelCount }"}`)
+			case "get":
+				body = strings.NewReader("")
+				if path == "/graphql" {
+					target += "?query=%7B+modelCount+%7D"
+				}
+			default:
+				t.Errorf("%s %s: unexpected method", method, path)
+				continue
+			}
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(strings.ToUpper(method), target, body))
+			if recorder.Code != http.StatusOK {
+				t.Errorf("%s %s = %d, want 200", strings.ToUpper(method), target, recorder.Code)
+			}
+		}
+	}
+}
+
+func TestOpenAPIEndpoint(t *testing.T) {
+	recorder := get(testCatalogHandler(t), "/openapi.yaml")
+	if recorder.Code != http.StatusOK || recorder.Header().Get("Content-Type") != "application/yaml" {
+		t.Fatalf("GET /openapi.yaml = %d %s", recorder.Code, recorder.Header().Get("Content-Type"))
+	}
+	if recorder.Body.String() != string(OpenAPISpec()) {
+		t.Errorf("GET /openapi.yaml did not return the embedded document")
+	}
+}
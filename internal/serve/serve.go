@@ -0,0 +1,436 @@
+package serve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/artifacts"
+	"github.com/opendatahub-io/model-metadata-collection/internal/checkpoint"
+	"github.com/opendatahub-io/model-metadata-collection/internal/config"
+	"github.com/opendatahub-io/model-metadata-collection/internal/errorreport"
+	"github.com/opendatahub-io/model-metadata-collection/internal/history"
+	"github.com/opendatahub-io/model-metadata-collection/internal/huggingface"
+	"github.com/opendatahub-io/model-metadata-collection/internal/metrics"
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+	"github.com/opendatahub-io/model-metadata-collection/internal/registry"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// DigestsFileName records the manifest digest each model had when its output was generated
+const DigestsFileName = "digests.yaml"
+
+// exitDegraded is the exit code of a pipeline run that completed with degraded models; its
+// output is still swapped in
+const exitDegraded = 3
+
+// Options configures the periodic catalog refresh
+type Options struct {
+	// OutputDir is a symlink to the current output generation, swapped after each refresh
+	OutputDir string
+	// CatalogName is the file name of the models catalog inside the output directory
+	CatalogName string
+	// ModelsIndex is the models index whose models are checked for changes
+	ModelsIndex string
+	// PipelineArgs are passed to every pipeline run, e.g. --config pipeline.yaml
+	PipelineArgs []string
+	// Executable runs the pipeline; defaults to the running binary
+	Executable string
+	// ResolveDigest returns a model's current manifest digest; defaults to the registry lookup,
+	// or the checksum of artifacts downloadable over HTTPS
+	ResolveDigest func(ctx context.Context, ref string) (string, error)
+	// RegistryAuth holds the registry credentials of the default digest lookups; nil uses the
+	// container auth files
+	RegistryAuth registry.Auth
+	// Metrics receives the refresh outcomes and the metrics of each pipeline run
+	Metrics *metrics.Registry
+	// Catalog is reloaded from each new generation for the REST API
+	Catalog *Catalog
+}
+
+// Run refreshes the catalog every interval until ctx is canceled. A failed refresh keeps the
+// previous output in place.
+func Run(ctx context.Context, opts Options, interval time.Duration) error {
+	for {
+		if err := Refresh(ctx, opts); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			log.Printf("Warning: Catalog refresh failed, keeping the previous output: %v", err)
+		}
+
+		log.Printf("Next catalog refresh in %s", interval)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Refresh runs the pipeline once into a new output generation and swaps it in. Models whose
+// manifest digest did not change since the current generation keep their output and are not
+// extracted or enriched again. The outcome and the metrics of the pipeline run are recorded in
+// opts.Metrics when set.
+func Refresh(ctx context.Context, opts Options) error {
+	start := time.Now()
+	generation, degraded, err := refresh(ctx, opts)
+	if opts.Metrics == nil {
+		return err
+	}
+
+	opts.Metrics.ObserveDuration(metrics.RefreshDuration, start)
+	switch {
+	case err != nil:
+		opts.Metrics.Add(metrics.Refreshes, 1, "failed")
+		return err
+	case degraded:
+		opts.Metrics.Add(metrics.Refreshes, 1, "degraded")
+	default:
+		opts.Metrics.Add(metrics.Refreshes, 1, "success")
+	}
+	opts.Metrics.Set(metrics.LastRefresh, float64(time.Now().Unix()))
+
+	snapshot, snapshotErr := metrics.ReadSnapshot(filepath.Join(generation, metrics.SnapshotFileName))
+	if snapshotErr != nil {
+		log.Printf("Warning: %v", snapshotErr)
+		return nil
+	}
+	opts.Metrics.Merge(snapshot)
+	return nil
+}
+
+// refresh performs a refresh and returns the generation that was swapped in and whether the
+// pipeline run was degraded
+func refresh(ctx context.Context, opts Options) (string, bool, error) {
+	if opts.Executable == "" {
+		executable, err := os.Executable()
+		if err != nil {
+			return "", false, fmt.Errorf("error locating the pipeline executable: %v", err)
+		}
+		opts.Executable = executable
+	}
+	if opts.ResolveDigest == nil {
+		opts.ResolveDigest = func(ctx context.Context, ref string) (string, error) {
+			return resolveDigest(ctx, opts.RegistryAuth, ref)
+		}
+	}
+
+	previous, err := currentGeneration(opts.OutputDir)
+	if err != nil {
+		return "", false, err
+	}
+	generation, changed, err := prepareGeneration(ctx, opts, previous)
+	if err != nil {
+		return "", false, err
+	}
+	log.Printf("Refreshing catalog: %d changed models", changed)
+
+	args := append([]string{"--catalog-markdown=", "--changelog-output="}, opts.PipelineArgs...)
+	args = append(args,
+		"--resume",
+		"--input", opts.ModelsIndex,
+		"--output-dir", generation,
+		"--catalog-output", filepath.Join(generation, opts.CatalogName),
+	)
+	cmd := exec.CommandContext(ctx, opts.Executable, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	degraded := false
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || exitErr.ExitCode() != exitDegraded {
+			_ = os.RemoveAll(generation)
+			return "", false, fmt.Errorf("pipeline run failed: %v", err)
+		}
+		log.Printf("Warning: Pipeline run completed with degraded models")
+		degraded = true
+	}
+
+	if err := swapOutput(opts.OutputDir, generation); err != nil {
+		_ = os.RemoveAll(generation)
+		return "", false, err
+	}
+	log.Printf("Catalog refreshed: %s now points at %s", opts.OutputDir, filepath.Base(generation))
+	if opts.Catalog != nil {
+		if err := opts.Catalog.Load(filepath.Join(generation, opts.CatalogName)); err != nil {
+			log.Printf("Warning: Failed to load the refreshed catalog, serving the previous one: %v", err)
+		}
+	}
+	return generation, degraded, nil
+}
+
+// generationPrefix names the generation directories next to the output directory symlink
+func generationPrefix(outputDir string) string {
+	return filepath.Join(filepath.Dir(outputDir), "."+filepath.Base(outputDir)+"-")
+}
+
+// currentGeneration returns the directory the output directory points at, or "" before the first
+// refresh. An existing plain output directory becomes the first generation.
+func currentGeneration(outputDir string) (string, error) {
+	info, err := os.Lstat(outputDir)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("error reading output directory: %v", err)
+	}
+
+	if info.Mode()&os.ModeSymlink != 0 {
+		target, err := os.Readlink(outputDir)
+		if err != nil {
+			return "", fmt.Errorf("error reading output directory link: %v", err)
+		}
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(outputDir), target)
+		}
+		return target, nil
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("output directory %s is not a directory", outputDir)
+	}
+
+	generation := generationPrefix(outputDir) + "initial"
+	if err := os.Rename(outputDir, generation); err != nil {
+		return "", fmt.Errorf("error moving existing output directory: %v", err)
+	}
+	if err := swapOutput(outputDir, generation); err != nil {
+		return "", err
+	}
+	return generation, nil
+}
+
+// resolveDigest returns the manifest digest of a modelcar image, or the checksum or ETag of an
+// artifact downloadable over HTTPS or stored in S3, or the commit of a HuggingFace repository
+func resolveDigest(ctx context.Context, auth registry.Auth, ref string) (string, error) {
+	if parsed, err := utils.ParseArtifactURI(ref); err == nil {
+		switch parsed.Scheme {
+		case utils.URISchemeHTTPS:
+			return artifacts.HTTPSRevision(ctx, ref)
+		case utils.URISchemeS3:
+			return artifacts.S3Revision(ctx, ref)
+		case utils.URISchemeHF:
+			return artifacts.HFRevision(ctx, huggingface.NewClient(huggingface.Options{}), ref)
+		}
+	}
+	return registry.FetchManifestDigest(ctx, auth, ref)
+}
+
+// prepareGeneration creates the directory of the next output generation. The output of models
+// whose digest is unchanged is copied from the previous generation and recorded as complete in
+// the checkpoint, so the resumed pipeline run only processes the changed models. Models that
+// failed in the previous generation are always processed again.
+func prepareGeneration(ctx context.Context, opts Options, previous string) (string, int, error) {
+	refs, err := config.LoadModelsFromYAML(opts.ModelsIndex)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to load models index: %v", err)
+	}
+
+	generation := generationPrefix(opts.OutputDir) + time.Now().UTC().Format("20060102-150405.000000000")
+	if err := os.MkdirAll(generation, 0755); err != nil {
+		return "", 0, fmt.Errorf("error creating output generation: %v", err)
+	}
+	cp, err := checkpoint.New(generation)
+	if err != nil {
+		_ = os.RemoveAll(generation)
+		return "", 0, err
+	}
+
+	previousDigests := readDigests(previous)
+	previousFailures := readFailedModels(previous)
+	digests := make(map[string]string)
+	changed := 0
+	for _, ref := range refs {
+		if ctx.Err() != nil {
+			_ = os.RemoveAll(generation)
+			return "", 0, ctx.Err()
+		}
+		if previousFailures[ref] {
+			// Its previous output is a skeleton or incomplete, and the digest is left unrecorded
+			// so it is not reused later either
+			changed++
+			carryVersionHistory(ref, previous, generation)
+			continue
+		}
+		digest, err := opts.ResolveDigest(ctx, ref)
+		if err != nil {
+			log.Printf("  Warning: Failed to resolve digest of %s, extracting it again: %v", ref, err)
+		} else {
+			digests[ref] = digest
+		}
+
+		if err != nil || previous == "" || previousDigests[ref] != digest || !reuseModelOutput(ref, previous, generation, cp) {
+			changed++
+			if previous != "" {
+				carryVersionHistory(ref, previous, generation)
+			}
+		}
+	}
+
+	if previous != "" {
+		if err := copyFile(filepath.Join(previous, "manifests.yaml"), filepath.Join(generation, "manifests.yaml")); err != nil && !os.IsNotExist(err) {
+			log.Printf("  Warning: Failed to copy manifests.yaml: %v", err)
+		}
+	}
+
+	data, err := yaml.Marshal(digests)
+	if err == nil {
+		err = os.WriteFile(filepath.Join(generation, DigestsFileName), data, 0644)
+	}
+	if err != nil {
+		_ = os.RemoveAll(generation)
+		return "", 0, fmt.Errorf("error writing %s: %v", DigestsFileName, err)
+	}
+	return generation, changed, nil
+}
+
+// reuseModelOutput copies an unchanged model's output into the new generation and marks all of
+// its stages complete; it reports false when there was no output to reuse
+func reuseModelOutput(ref, previous, generation string, cp *checkpoint.Checkpoint) bool {
+	modelDir := utils.SanitizeManifestRef(ref)
+	previousDir := modelDir
+	if _, err := os.Stat(filepath.Join(previous, previousDir, "models", "metadata.yaml")); err != nil {
+		// Generations written by earlier versions name model directories without the ref digest
+		previousDir = utils.LegacySanitizeManifestRef(ref)
+		if _, err := os.Stat(filepath.Join(previous, previousDir, "models", "metadata.yaml")); err != nil {
+			return false
+		}
+	}
+	if err := copyDir(filepath.Join(previous, previousDir), filepath.Join(generation, modelDir)); err != nil {
+		log.Printf("  Warning: Failed to reuse output of %s: %v", ref, err)
+		_ = os.RemoveAll(filepath.Join(generation, modelDir))
+		return false
+	}
+	if err := cp.MarkComplete(ref); err != nil {
+		log.Printf("  Warning: %v", err)
+		return false
+	}
+	return true
+}
+
+// carryVersionHistory copies the version history of a changed model into the new generation, so
+// its re-extraction adds to the versions seen so far
+func carryVersionHistory(ref, previous, generation string) {
+	historyPath := outputfs.ModelPath(ref, history.FileName)
+	src := filepath.Join(previous, filepath.FromSlash(historyPath))
+	dst := filepath.Join(generation, filepath.FromSlash(historyPath))
+	if _, err := os.Stat(src); err != nil {
+		return
+	}
+	err := os.MkdirAll(filepath.Dir(dst), 0755)
+	if err == nil {
+		err = copyFile(src, dst)
+	}
+	if err != nil {
+		log.Printf("  Warning: Failed to carry version history of %s: %v", ref, err)
+	}
+}
+
+// readDigests reads the model digests of a generation; a missing file means nothing is known
+func readDigests(generation string) map[string]string {
+	digests := make(map[string]string)
+	if generation == "" {
+		return digests
+	}
+	data, err := os.ReadFile(filepath.Join(generation, DigestsFileName))
+	if err != nil {
+		return digests
+	}
+	if err := yaml.Unmarshal(data, &digests); err != nil {
+		log.Printf("  Warning: Failed to parse %s of the previous output: %v", DigestsFileName, err)
+		return make(map[string]string)
+	}
+	return digests
+}
+
+// readFailedModels returns the models listed in the error report of a generation; a generation
+// without one had no failures recorded
+func readFailedModels(generation string) map[string]bool {
+	failed := make(map[string]bool)
+	if generation == "" {
+		return failed
+	}
+	if _, err := os.Stat(filepath.Join(generation, errorreport.FileName)); err != nil {
+		return failed
+	}
+	models, err := errorreport.ReadFailedModels(generation)
+	if err != nil {
+		log.Printf("  Warning: %v", err)
+		return failed
+	}
+	for _, model := range models {
+		failed[model] = true
+	}
+	return failed
+}
+
+// swapOutput atomically points the output directory symlink at generation and removes the
+// generations it no longer references
+func swapOutput(outputDir, generation string) error {
+	link := outputDir + ".swap"
+	_ = os.Remove(link)
+	if err := os.Symlink(filepath.Base(generation), link); err != nil {
+		return fmt.Errorf("error linking output generation: %v", err)
+	}
+	if err := os.Rename(link, outputDir); err != nil {
+		_ = os.Remove(link)
+		return fmt.Errorf("error swapping output directory: %v", err)
+	}
+
+	stale, err := filepath.Glob(generationPrefix(outputDir) + "*")
+	if err != nil {
+		return nil
+	}
+	for _, dir := range stale {
+		if dir != generation {
+			if err := os.RemoveAll(dir); err != nil {
+				log.Printf("  Warning: Failed to remove old output generation %s: %v", dir, err)
+			}
+		}
+	}
+	return nil
+}
+
+// copyDir copies a directory tree of regular files
+func copyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if entry.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		return copyFile(path, target)
+	})
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,189 @@
+package serve
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/checkpoint"
+	"github.com/opendatahub-io/model-metadata-collection/internal/errorreport"
+	"github.com/opendatahub-io/model-metadata-collection/internal/metrics"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+const (
+	unchangedRef = "registry.example.com/org/unchanged:1.0"
+	changedRef   = "registry.example.com/org/changed:1.0"
+)
+
+// fakePipeline writes the catalog a pipeline run would produce, or fails with the given exit code
+func fakePipeline(t *testing.T, exitCode string) string {
+	t.Helper()
+	script := filepath.Join(t.TempDir(), "pipeline.sh")
+	content := `#!/bin/sh
+while [ $# -gt 0 ]; do
+  case "$1" in
+    --catalog-output) shift; echo "models: []" > "$1" ;;
+  esac
+  shift
+done
+exit ` + exitCode + "\n"
+	if err := os.WriteFile(script, []byte(content), 0755); err != nil {
+		t.Fatalf("failed to write pipeline script: %v", err)
+	}
+	return script
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+func testOptions(t *testing.T, dir string, digests map[string]string) Options {
+	t.Helper()
+	index := filepath.Join(dir, "models-index.yaml")
+	writeFile(t, index, "models:\n  - type: oci\n    uri: "+unchangedRef+"\n  - type: oci\n    uri: "+changedRef+"\n")
+	return Options{
+		OutputDir:   filepath.Join(dir, "output"),
+		CatalogName: "models-catalog.yaml",
+		ModelsIndex: index,
+		Executable:  fakePipeline(t, "0"),
+		ResolveDigest: func(ctx context.Context, ref string) (string, error) {
+			return digests[ref], nil
+		},
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	dir := t.TempDir()
+	digests := map[string]string{unchangedRef: "sha256:aaa", changedRef: "sha256:bbb"}
+	opts := testOptions(t, dir, digests)
+
+	// An existing output directory becomes the first generation
+	writeFile(t, filepath.Join(opts.OutputDir, "manifests.yaml"), "models: []\n")
+	if err := Refresh(context.Background(), opts); err != nil {
+		t.Fatalf("first Refresh failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(opts.OutputDir, "models-catalog.yaml")); err != nil {
+		t.Fatalf("expected the catalog in the output directory: %v", err)
+	}
+
+	// Stand in for the model output the pipeline run would have written
+	for _, ref := range []string{unchangedRef, changedRef} {
+		writeFile(t, filepath.Join(opts.OutputDir, utils.SanitizeManifestRef(ref), "models", "metadata.yaml"), "name: "+ref+"\n")
+	}
+
+	// Only the model whose digest moved is left for the pipeline to extract
+	digests[changedRef] = "sha256:ccc"
+	if err := Refresh(context.Background(), opts); err != nil {
+		t.Fatalf("second Refresh failed: %v", err)
+	}
+	cp, err := checkpoint.Load(opts.OutputDir)
+	if err != nil {
+		t.Fatalf("failed to load checkpoint: %v", err)
+	}
+	if !cp.Done(unchangedRef, checkpoint.StageEnrichment) {
+		t.Error("unchanged model should be reused")
+	}
+	if cp.Done(changedRef, checkpoint.StageExtraction) {
+		t.Error("changed model should be extracted again")
+	}
+	if _, err := os.Stat(filepath.Join(opts.OutputDir, utils.SanitizeManifestRef(unchangedRef), "models", "metadata.yaml")); err != nil {
+		t.Errorf("expected the unchanged model's output to be copied: %v", err)
+	}
+
+	// A model that failed in the previous generation is extracted again though its digest is unchanged
+	writeFile(t, filepath.Join(opts.OutputDir, utils.SanitizeManifestRef(changedRef), "models", "metadata.yaml"), "name: skeleton\n")
+	writeFile(t, filepath.Join(opts.OutputDir, errorreport.FileName), "models:\n  - model: "+changedRef+"\n    skeletonCreated: true\n")
+	if err := Refresh(context.Background(), opts); err != nil {
+		t.Fatalf("third Refresh failed: %v", err)
+	}
+	cp, err = checkpoint.Load(opts.OutputDir)
+	if err != nil {
+		t.Fatalf("failed to load checkpoint: %v", err)
+	}
+	if !cp.Done(unchangedRef, checkpoint.StageEnrichment) || cp.Done(changedRef, checkpoint.StageExtraction) {
+		t.Error("a model that failed in the previous generation should be extracted again")
+	}
+	if recorded := readDigests(opts.OutputDir); recorded[changedRef] != "" || recorded[unchangedRef] != "sha256:aaa" {
+		t.Errorf("expected no digest recorded for the failed model, got %v", recorded)
+	}
+
+	generations, _ := filepath.Glob(generationPrefix(opts.OutputDir) + "*")
+	if len(generations) != 1 {
+		t.Errorf("expected old generations to be removed, got %v", generations)
+	}
+}
+
+func TestReuseModelOutput_LegacyDirectory(t *testing.T) {
+	previous, generation := t.TempDir(), t.TempDir()
+	writeFile(t, filepath.Join(previous, utils.LegacySanitizeManifestRef(unchangedRef), "models", "metadata.yaml"), "name: legacy\n")
+	cp, err := checkpoint.New(generation)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reuseModelOutput(unchangedRef, previous, generation, cp) {
+		t.Fatal("Expected the output in the legacy directory to be reused")
+	}
+	data, err := os.ReadFile(filepath.Join(generation, utils.SanitizeManifestRef(unchangedRef), "models", "metadata.yaml"))
+	if err != nil || string(data) != "name: legacy\n" {
+		t.Errorf("Expected the output under the new directory name, got %q (err %v)", data, err)
+	}
+}
+
+func TestRefreshFailureKeepsOutput(t *testing.T) {
+	dir := t.TempDir()
+	opts := testOptions(t, dir, map[string]string{})
+	if err := Refresh(context.Background(), opts); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+	current, err := os.Readlink(opts.OutputDir)
+	if err != nil {
+		t.Fatalf("output directory should be a symlink: %v", err)
+	}
+
+	opts.Executable = fakePipeline(t, "1")
+	if err := Refresh(context.Background(), opts); err == nil {
+		t.Fatal("expected a failed pipeline run to fail the refresh")
+	}
+	if after, _ := os.Readlink(opts.OutputDir); after != current {
+		t.Errorf("failed refresh swapped the output to %s", after)
+	}
+
+	// A degraded run still replaces the output
+	opts.Executable = fakePipeline(t, "3")
+	if err := Refresh(context.Background(), opts); err != nil {
+		t.Fatalf("degraded Refresh failed: %v", err)
+	}
+	if after, _ := os.Readlink(opts.OutputDir); after == current {
+		t.Error("degraded refresh should swap the output")
+	}
+}
+
+func TestHandlerMetrics(t *testing.T) {
+	dir := t.TempDir()
+	opts := testOptions(t, dir, map[string]string{})
+	opts.Metrics = metrics.NewRegistry()
+	if err := Refresh(context.Background(), opts); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+
+	recorder := httptest.NewRecorder()
+	NewHandler(opts.Metrics, NewCatalog()).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("GET /metrics returned %d", recorder.Code)
+	}
+	if body := recorder.Body.String(); !strings.Contains(body, `model_catalog_refreshes_total{result="success"} 1`) {
+		t.Errorf("expected the refresh to be counted, got:\n%s", body)
+	}
+}
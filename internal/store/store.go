@@ -0,0 +1,241 @@
+// Package store records extracted and enriched model metadata in a relational database, next to
+// the per-model YAML files, so metadata can be queried across runs and its history tracked
+// without parsing hundreds of metadata.yaml files.
+package store
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	// Registers the pure-Go "sqlite" database/sql driver, so the store works in CGO_ENABLED=0 builds
+	_ "modernc.org/sqlite"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+)
+
+// SQLiteScheme is the URL scheme of SQLite stores, e.g. sqlite://metadata.db
+const SQLiteScheme = "sqlite://"
+
+// schema creates the store tables. models holds the latest metadata of every model ever
+// recorded; model_history holds a revision per run in which a model's metadata changed.
+const schema = `
+CREATE TABLE IF NOT EXISTS runs (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	started_at TEXT NOT NULL,
+	models INTEGER NOT NULL DEFAULT 0,
+	changed INTEGER NOT NULL DEFAULT 0
+);
+CREATE TABLE IF NOT EXISTS models (
+	ref TEXT PRIMARY KEY,
+	name TEXT,
+	provider TEXT,
+	license TEXT,
+	digest TEXT NOT NULL,
+	metadata TEXT NOT NULL,
+	first_run INTEGER NOT NULL REFERENCES runs(id),
+	last_run INTEGER NOT NULL REFERENCES runs(id),
+	updated_run INTEGER NOT NULL REFERENCES runs(id)
+);
+CREATE TABLE IF NOT EXISTS model_history (
+	run_id INTEGER NOT NULL REFERENCES runs(id),
+	ref TEXT NOT NULL,
+	digest TEXT NOT NULL,
+	metadata TEXT NOT NULL,
+	PRIMARY KEY (run_id, ref)
+);
+CREATE INDEX IF NOT EXISTS model_history_ref ON model_history(ref);
+`
+
+// Store is a metadata store opened from a --store URL
+type Store struct {
+	db *sql.DB
+}
+
+// RunSummary reports what a run recorded in the store
+type RunSummary struct {
+	RunID int64
+	// Models is the number of models whose metadata was recorded
+	Models int
+	// Changed is the number of new models or models whose metadata differs from the previous run
+	Changed int
+}
+
+// Revision is a recorded version of a model's metadata
+type Revision struct {
+	RunID     int64
+	StartedAt time.Time
+	Digest    string
+	// Metadata is the model's metadata.yaml as JSON, queryable with SQLite's json_extract
+	Metadata string
+}
+
+// ValidateURL checks that a --store URL names a supported store
+func ValidateURL(rawURL string) error {
+	_, err := sqlitePath(rawURL)
+	return err
+}
+
+// sqlitePath returns the database file of a sqlite:// store URL
+func sqlitePath(rawURL string) (string, error) {
+	if !strings.HasPrefix(rawURL, SQLiteScheme) {
+		return "", fmt.Errorf("unsupported store %q: expected %spath/to/metadata.db", rawURL, SQLiteScheme)
+	}
+	path := strings.TrimPrefix(rawURL, SQLiteScheme)
+	if path == "" {
+		return "", fmt.Errorf("store %q has no database path", rawURL)
+	}
+	return path, nil
+}
+
+// Open opens the store at rawURL, creating the database and its tables if needed
+func Open(rawURL string) (*Store, error) {
+	path, err := sqlitePath(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, fmt.Errorf("error opening store %s: %v", path, err)
+	}
+	// A single connection keeps writes serialized and in-memory databases shared
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(schema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("error initializing store %s: %v", path, err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the store's database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// RecordRun records the metadata.yaml of the given models as a new run in a single transaction.
+// Models whose metadata is unchanged since they were last recorded only have their last run
+// updated; new and changed models also get a history revision. Models without metadata are
+// skipped with a warning.
+func (s *Store) RecordRun(output outputfs.FS, refs []string) (RunSummary, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return RunSummary{}, fmt.Errorf("error starting store transaction: %v", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.Exec(`INSERT INTO runs (started_at) VALUES (?)`, time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		return RunSummary{}, fmt.Errorf("error recording run: %v", err)
+	}
+	summary := RunSummary{}
+	if summary.RunID, err = result.LastInsertId(); err != nil {
+		return RunSummary{}, fmt.Errorf("error recording run: %v", err)
+	}
+
+	for _, ref := range refs {
+		data, err := output.ReadFile(outputfs.ModelPath(ref, "metadata.yaml"))
+		if err != nil {
+			log.Printf("  Warning: Not storing %s: %v", ref, err)
+			continue
+		}
+		changed, err := recordModel(tx, summary.RunID, ref, data)
+		if err != nil {
+			return RunSummary{}, err
+		}
+		summary.Models++
+		if changed {
+			summary.Changed++
+		}
+	}
+
+	if _, err := tx.Exec(`UPDATE runs SET models = ?, changed = ? WHERE id = ?`, summary.Models, summary.Changed, summary.RunID); err != nil {
+		return RunSummary{}, fmt.Errorf("error recording run: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return RunSummary{}, fmt.Errorf("error committing run: %v", err)
+	}
+	return summary, nil
+}
+
+// recordModel stores the metadata of one model and reports whether it is new or changed
+func recordModel(tx *sql.Tx, runID int64, ref string, data []byte) (bool, error) {
+	var fields map[string]interface{}
+	if err := yaml.Unmarshal(data, &fields); err != nil {
+		return false, fmt.Errorf("error parsing metadata of %s: %v", ref, err)
+	}
+	metadata, err := json.Marshal(fields)
+	if err != nil {
+		return false, fmt.Errorf("error encoding metadata of %s: %v", ref, err)
+	}
+	sum := sha256.Sum256(metadata)
+	digest := hex.EncodeToString(sum[:])
+
+	var previous string
+	err = tx.QueryRow(`SELECT digest FROM models WHERE ref = ?`, ref).Scan(&previous)
+	switch {
+	case err == sql.ErrNoRows:
+		_, err = tx.Exec(`INSERT INTO models (ref, name, provider, license, digest, metadata, first_run, last_run, updated_run)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			ref, stringField(fields, "name"), stringField(fields, "provider"), stringField(fields, "license"),
+			digest, string(metadata), runID, runID, runID)
+	case err != nil:
+		return false, fmt.Errorf("error reading stored metadata of %s: %v", ref, err)
+	case previous == digest:
+		_, err = tx.Exec(`UPDATE models SET last_run = ? WHERE ref = ?`, runID, ref)
+		if err != nil {
+			return false, fmt.Errorf("error storing metadata of %s: %v", ref, err)
+		}
+		return false, nil
+	default:
+		_, err = tx.Exec(`UPDATE models SET name = ?, provider = ?, license = ?, digest = ?, metadata = ?, last_run = ?, updated_run = ?
+			WHERE ref = ?`,
+			stringField(fields, "name"), stringField(fields, "provider"), stringField(fields, "license"),
+			digest, string(metadata), runID, runID, ref)
+	}
+	if err != nil {
+		return false, fmt.Errorf("error storing metadata of %s: %v", ref, err)
+	}
+
+	if _, err := tx.Exec(`INSERT INTO model_history (run_id, ref, digest, metadata) VALUES (?, ?, ?, ?)`,
+		runID, ref, digest, string(metadata)); err != nil {
+		return false, fmt.Errorf("error storing history of %s: %v", ref, err)
+	}
+	return true, nil
+}
+
+// History returns the recorded revisions of a model's metadata, oldest first
+func (s *Store) History(ref string) ([]Revision, error) {
+	rows, err := s.db.Query(`SELECT h.run_id, r.started_at, h.digest, h.metadata
+		FROM model_history h JOIN runs r ON r.id = h.run_id
+		WHERE h.ref = ? ORDER BY h.run_id`, ref)
+	if err != nil {
+		return nil, fmt.Errorf("error reading history of %s: %v", ref, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var revisions []Revision
+	for rows.Next() {
+		var revision Revision
+		var startedAt string
+		if err := rows.Scan(&revision.RunID, &startedAt, &revision.Digest, &revision.Metadata); err != nil {
+			return nil, fmt.Errorf("error reading history of %s: %v", ref, err)
+		}
+		revision.StartedAt, _ = time.Parse(time.RFC3339, startedAt)
+		revisions = append(revisions, revision)
+	}
+	return revisions, rows.Err()
+}
+
+// stringField returns a top-level string field of a model's metadata, or nil when it is unset
+func stringField(fields map[string]interface{}, key string) interface{} {
+	if value, ok := fields[key].(string); ok {
+		return value
+	}
+	return nil
+}
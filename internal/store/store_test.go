@@ -0,0 +1,122 @@
+package store
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+)
+
+func writeMetadata(t *testing.T, output outputfs.FS, ref, content string) {
+	t.Helper()
+	if err := output.MkdirAll(outputfs.ModelPath(ref), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := output.WriteFile(outputfs.ModelPath(ref, "metadata.yaml"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{"sqlite://metadata.db", false},
+		{"sqlite:///var/lib/catalog/metadata.db", false},
+		{"sqlite://", true},
+		{"postgres://localhost/catalog", true},
+		{"metadata.db", true},
+	}
+	for _, tt := range tests {
+		if err := ValidateURL(tt.url); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+		}
+	}
+}
+
+func TestRecordRunTracksChanges(t *testing.T) {
+	dir := t.TempDir()
+	output := outputfs.Dir(filepath.Join(dir, "output"))
+	granite := "registry.redhat.io/rhelai1/granite-3-1-8b-instruct:1.4"
+	llama := "registry.redhat.io/rhelai1/llama-3-1-8b-instruct:1.4"
+	writeMetadata(t, output, granite, "name: granite-3.1-8b-instruct\nprovider: IBM\nlicense: apache-2.0\n")
+	writeMetadata(t, output, llama, "name: llama-3.1-8b-instruct\nprovider: Meta\n")
+
+	s, err := Open("sqlite://" + filepath.Join(dir, "metadata.db"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer func() { _ = s.Close() }()
+
+	refs := []string{granite, llama, "registry.redhat.io/rhelai1/missing:1.0"}
+	first, err := s.RecordRun(output, refs)
+	if err != nil {
+		t.Fatalf("RecordRun() error = %v", err)
+	}
+	if first.Models != 2 || first.Changed != 2 {
+		t.Errorf("first run = %+v, want 2 models, 2 changed", first)
+	}
+
+	second, err := s.RecordRun(output, refs)
+	if err != nil {
+		t.Fatalf("RecordRun() error = %v", err)
+	}
+	if second.Models != 2 || second.Changed != 0 || second.RunID == first.RunID {
+		t.Errorf("second run = %+v, want a new run with 2 models, 0 changed", second)
+	}
+
+	writeMetadata(t, output, granite, "name: granite-3.1-8b-instruct\nprovider: IBM\nlicense: apache-2.0\ntasks:\n  - text-generation\n")
+	third, err := s.RecordRun(output, refs)
+	if err != nil {
+		t.Fatalf("RecordRun() error = %v", err)
+	}
+	if third.Changed != 1 {
+		t.Errorf("third run changed = %d, want 1", third.Changed)
+	}
+
+	history, err := s.History(granite)
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(history) != 2 || history[0].RunID != first.RunID || history[1].RunID != third.RunID {
+		t.Fatalf("History() = %+v, want revisions from runs %d and %d", history, first.RunID, third.RunID)
+	}
+	if !strings.Contains(history[1].Metadata, `"tasks":["text-generation"]`) {
+		t.Errorf("latest revision metadata = %s, want the added tasks", history[1].Metadata)
+	}
+
+	var license string
+	var lastRun, updatedRun int64
+	err = s.db.QueryRow(`SELECT json_extract(metadata, '$.license'), last_run, updated_run FROM models WHERE ref = ?`, granite).
+		Scan(&license, &lastRun, &updatedRun)
+	if err != nil {
+		t.Fatalf("querying models: %v", err)
+	}
+	if license != "apache-2.0" || lastRun != third.RunID || updatedRun != third.RunID {
+		t.Errorf("granite row = (%s, %d, %d), want (apache-2.0, %d, %d)", license, lastRun, updatedRun, third.RunID, third.RunID)
+	}
+	if history, _ := s.History(llama); len(history) != 1 {
+		t.Errorf("unchanged model has %d revisions, want 1", len(history))
+	}
+}
+
+func TestOpenSetsPragmas(t *testing.T) {
+	s, err := Open("sqlite://" + filepath.Join(t.TempDir(), "metadata.db"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer func() { _ = s.Close() }()
+
+	var foreignKeys, busyTimeout int
+	if err := s.db.QueryRow("PRAGMA foreign_keys").Scan(&foreignKeys); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.db.QueryRow("PRAGMA busy_timeout").Scan(&busyTimeout); err != nil {
+		t.Fatal(err)
+	}
+	if foreignKeys != 1 || busyTimeout != 5000 {
+		t.Errorf("foreign_keys = %d, busy_timeout = %d, want 1 and 5000", foreignKeys, busyTimeout)
+	}
+}
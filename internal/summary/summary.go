@@ -0,0 +1,207 @@
+package summary
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/enrichment"
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// FileName is the name of the run summary written to the output directory
+const FileName = "run-summary.yaml"
+
+// RunSummary is the machine-readable summary of one pipeline run, used by CI to spot
+// regressions in metadata coverage between runs
+type RunSummary struct {
+	StartedAt       string                  `yaml:"startedAt"`
+	DurationSeconds float64                 `yaml:"durationSeconds"`
+	Models          ModelCounts             `yaml:"models"`
+	Enrichment      map[string]FieldSummary `yaml:"enrichment,omitempty"`
+	Stages          []StageDuration         `yaml:"stages"`
+	// NewModels and RemovedModels list the catalog models added or removed since the previous
+	// run; both are empty on the first run with a changelog
+	NewModels     []string `yaml:"newModels,omitempty"`
+	RemovedModels []string `yaml:"removedModels,omitempty"`
+	// Degraded lists why the run completed with degraded models; the run exits with a distinct
+	// code when it is not empty
+	Degraded []string `yaml:"degraded,omitempty"`
+}
+
+// ModelCounts counts the outcome of model extraction
+type ModelCounts struct {
+	Processed       int `yaml:"processed"`
+	ModelcardsFound int `yaml:"modelcardsFound"`
+	// SkeletonsCreated counts models without a modelcard layer that got a skeleton metadata.yaml
+	SkeletonsCreated int `yaml:"skeletonsCreated"`
+	// Failed counts models that produced no metadata.yaml at all
+	Failed int `yaml:"failed"`
+}
+
+// Failures counts the models that ended up with skeleton metadata or no metadata at all
+func (c ModelCounts) Failures() int {
+	return c.SkeletonsCreated + c.Failed
+}
+
+// CheckFailureThreshold returns an error when more models failed than allowed, either more than
+// maxFailures models or a larger share of the processed models than maxRate. A negative
+// maxFailures or a maxRate of 1 disables the respective limit.
+func (c ModelCounts) CheckFailureThreshold(maxFailures int, maxRate float64) error {
+	failures := c.Failures()
+	if maxFailures >= 0 && failures > maxFailures {
+		return fmt.Errorf("%d of %d models failed (skeleton or no metadata), more than the allowed %d", failures, c.Processed, maxFailures)
+	}
+	if c.Processed > 0 && maxRate < 1 {
+		if rate := float64(failures) / float64(c.Processed); rate > maxRate {
+			return fmt.Errorf("%d of %d models failed (skeleton or no metadata), a rate of %.2f above the allowed %.2f", failures, c.Processed, rate, maxRate)
+		}
+	}
+	return nil
+}
+
+// FieldSummary counts, for one metadata field, how many models got it from HuggingFace
+// enrichment, how many have it from any source, and how many are still missing it
+type FieldSummary struct {
+	Enriched  int `yaml:"enriched"`
+	Populated int `yaml:"populated"`
+	Missing   int `yaml:"missing"`
+}
+
+// StageDuration is the wall time of one pipeline stage
+type StageDuration struct {
+	Name    string  `yaml:"name"`
+	Seconds float64 `yaml:"seconds"`
+}
+
+// Recorder accumulates a run summary while the pipeline runs
+type Recorder struct {
+	started time.Time
+	summary RunSummary
+}
+
+// NewRecorder starts recording a run that begins now
+func NewRecorder() *Recorder {
+	now := time.Now()
+	return &Recorder{
+		started: now,
+		summary: RunSummary{StartedAt: now.UTC().Format(time.RFC3339)},
+	}
+}
+
+// StartStage begins timing a stage; call the returned function when the stage ends
+func (r *Recorder) StartStage(name string) func() {
+	start := time.Now()
+	return func() {
+		r.summary.Stages = append(r.summary.Stages, StageDuration{Name: name, Seconds: roundSeconds(time.Since(start))})
+	}
+}
+
+// RecordModels counts extracted models. modelcardsFound[i] reports whether modelRefs[i] had a
+// modelcard layer; models without one count as skeletons when they have a metadata.yaml.
+func (r *Recorder) RecordModels(modelRefs []string, modelcardsFound []bool, outputDir string) {
+	counts := ModelCounts{Processed: len(modelRefs)}
+	for i, ref := range modelRefs {
+		switch {
+		case modelcardsFound[i]:
+			counts.ModelcardsFound++
+		case metadataExists(ref, outputDir):
+			counts.SkeletonsCreated++
+		default:
+			counts.Failed++
+		}
+	}
+	r.summary.Models = counts
+
+	if counts.SkeletonsCreated > 0 {
+		r.RecordDegraded(fmt.Sprintf("%d models have skeleton metadata without a modelcard", counts.SkeletonsCreated))
+	}
+	if counts.Failed > 0 {
+		r.RecordDegraded(fmt.Sprintf("%d models produced no metadata", counts.Failed))
+	}
+}
+
+// RecordDegraded notes a soft failure: the run goes on, but its output is incomplete
+func (r *Recorder) RecordDegraded(reason string) {
+	r.summary.Degraded = append(r.summary.Degraded, reason)
+}
+
+// Degraded returns the soft failures recorded so far
+func (r *Recorder) Degraded() []string {
+	return append([]string(nil), r.summary.Degraded...)
+}
+
+// RecordEnrichment counts, per metadata field, where the models' values came from
+func (r *Recorder) RecordEnrichment(modelRefs []string, outputDir string) {
+	fields := make(map[string]FieldSummary)
+	for _, ref := range modelRefs {
+		provenance, err := enrichment.BuildModelProvenance(outputfs.Dir(outputDir), ref)
+		if err != nil {
+			continue
+		}
+		for field, source := range provenance.Fields {
+			counts := fields[field]
+			switch source.Category {
+			case enrichment.ProvenanceNone:
+				counts.Missing++
+			case enrichment.ProvenanceHuggingFaceAPI, enrichment.ProvenanceHuggingFaceFrontmatter, enrichment.ProvenanceHuggingFaceReadme:
+				counts.Enriched++
+				counts.Populated++
+			default:
+				counts.Populated++
+			}
+			fields[field] = counts
+		}
+	}
+	r.summary.Enrichment = fields
+}
+
+// RecordCatalogChanges notes the catalog models added and removed since the previous run
+func (r *Recorder) RecordCatalogChanges(added, removed []string) {
+	r.summary.NewModels = added
+	r.summary.RemovedModels = removed
+}
+
+// Models returns the model counts recorded by RecordModels
+func (r *Recorder) Models() ModelCounts {
+	return r.summary.Models
+}
+
+// Summary returns the summary recorded so far, with the total duration up to now
+func (r *Recorder) Summary() RunSummary {
+	summary := r.summary
+	summary.DurationSeconds = roundSeconds(time.Since(r.started))
+	summary.Stages = append([]StageDuration(nil), r.summary.Stages...)
+	summary.Degraded = r.Degraded()
+	return summary
+}
+
+// Write writes the summary to outputDir/run-summary.yaml
+func (r *Recorder) Write(outputDir string) error {
+	summary := r.Summary()
+	data, err := yaml.Marshal(&summary)
+	if err != nil {
+		return fmt.Errorf("failed to marshal run summary: %v", err)
+	}
+	path := filepath.Join(outputDir, FileName)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write run summary: %v", err)
+	}
+	log.Printf("Wrote run summary to %s", path)
+	return nil
+}
+
+func metadataExists(ref, outputDir string) bool {
+	_, err := os.Stat(filepath.Join(outputDir, utils.SanitizeManifestRef(ref), "models", "metadata.yaml"))
+	return err == nil
+}
+
+// roundSeconds converts a duration to seconds with millisecond precision
+func roundSeconds(d time.Duration) float64 {
+	return float64(d.Round(time.Millisecond).Milliseconds()) / 1000
+}
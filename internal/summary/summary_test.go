@@ -0,0 +1,123 @@
+package summary
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+func writeModel(t *testing.T, outputDir, ref string, files map[string]string) {
+	t.Helper()
+	modelDir := filepath.Join(outputDir, utils.SanitizeManifestRef(ref), "models")
+	if err := os.MkdirAll(modelDir, 0755); err != nil {
+		t.Fatalf("Failed to create model dir: %v", err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(modelDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	outputDir := t.TempDir()
+	withCard := "registry.example.com/test/with-card:1.0"
+	skeleton := "registry.example.com/test/skeleton:1.0"
+	failed := "registry.example.com/test/failed:1.0"
+
+	writeModel(t, outputDir, withCard, map[string]string{
+		"metadata.yaml": "name: With Card\nlicense: apache-2.0\n",
+		"modelcard.md":  "# With Card\n",
+		"enrichment.yaml": `data_sources:
+  license: huggingface.yaml
+`,
+	})
+	writeModel(t, outputDir, skeleton, map[string]string{
+		"metadata.yaml": "name: Skeleton\n",
+	})
+
+	recorder := NewRecorder()
+	endStage := recorder.StartStage("model-extraction")
+	endStage()
+	recorder.RecordModels([]string{withCard, skeleton, failed}, []bool{true, false, false}, outputDir)
+	recorder.RecordEnrichment([]string{withCard, skeleton, failed}, outputDir)
+
+	if err := recorder.Write(outputDir); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(outputDir, FileName))
+	if err != nil {
+		t.Fatalf("Failed to read run summary: %v", err)
+	}
+	var summary RunSummary
+	if err := yaml.Unmarshal(data, &summary); err != nil {
+		t.Fatalf("Failed to parse run summary: %v", err)
+	}
+
+	expectedModels := ModelCounts{Processed: 3, ModelcardsFound: 1, SkeletonsCreated: 1, Failed: 1}
+	if summary.Models != expectedModels {
+		t.Errorf("Models = %+v, want %+v", summary.Models, expectedModels)
+	}
+
+	if license := summary.Enrichment["license"]; license != (FieldSummary{Enriched: 1, Populated: 1, Missing: 1}) {
+		t.Errorf("license counts = %+v", license)
+	}
+	if name := summary.Enrichment["name"]; name != (FieldSummary{Enriched: 0, Populated: 2, Missing: 0}) {
+		t.Errorf("name counts = %+v", name)
+	}
+
+	if len(summary.Stages) != 1 || summary.Stages[0].Name != "model-extraction" {
+		t.Errorf("Stages = %+v", summary.Stages)
+	}
+	if summary.StartedAt == "" {
+		t.Error("Expected startedAt to be set")
+	}
+
+	expectedDegraded := []string{"1 models have skeleton metadata without a modelcard", "1 models produced no metadata"}
+	if !reflect.DeepEqual(summary.Degraded, expectedDegraded) {
+		t.Errorf("Degraded = %v, want %v", summary.Degraded, expectedDegraded)
+	}
+}
+
+func TestRecorderNotDegraded(t *testing.T) {
+	recorder := NewRecorder()
+	recorder.RecordModels([]string{"registry.example.com/model:1"}, []bool{true}, t.TempDir())
+	if degraded := recorder.Degraded(); len(degraded) != 0 {
+		t.Errorf("Expected no degraded reasons, got %v", degraded)
+	}
+
+	recorder.RecordDegraded("enrichment failed")
+	if degraded := recorder.Degraded(); len(degraded) != 1 || degraded[0] != "enrichment failed" {
+		t.Errorf("Degraded = %v", degraded)
+	}
+}
+
+func TestCheckFailureThreshold(t *testing.T) {
+	counts := ModelCounts{Processed: 10, ModelcardsFound: 7, SkeletonsCreated: 2, Failed: 1}
+	tests := []struct {
+		name        string
+		maxFailures int
+		maxRate     float64
+		expectError bool
+	}{
+		{name: "disabled", maxFailures: -1, maxRate: 1},
+		{name: "count within limit", maxFailures: 3, maxRate: 1},
+		{name: "count exceeded", maxFailures: 2, maxRate: 1, expectError: true},
+		{name: "rate within limit", maxFailures: -1, maxRate: 0.3},
+		{name: "rate exceeded", maxFailures: -1, maxRate: 0.1, expectError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := counts.CheckFailureThreshold(tt.maxFailures, tt.maxRate)
+			if (err != nil) != tt.expectError {
+				t.Errorf("CheckFailureThreshold(%d, %.2f) error = %v, expectError %v", tt.maxFailures, tt.maxRate, err, tt.expectError)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+// Package verification checks the supply-chain artifacts published next to model images: cosign
+// signatures, attestations and SBOMs. The result is recorded as one verification status per
+// artifact, so policy engines can decide whether to admit a model from a single field.
+package verification
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/registry"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/errdefs"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// Cosign stores the signatures, attestations and SBOMs of an image in the image's repository,
+// under tags derived from its manifest digest, e.g. sha256-<hex>.sig
+const (
+	signatureTagSuffix   = ".sig"
+	attestationTagSuffix = ".att"
+	sbomTagSuffix        = ".sbom"
+)
+
+// signatureAnnotation holds the base64 signature of the payload of a cosign signature layer
+const signatureAnnotation = "dev.cosignproject.cosign/signature"
+
+// Registry reads the manifests and blobs of image references
+type Registry interface {
+	// Digest resolves an image reference to the digest of its manifest
+	Digest(ctx context.Context, imageRef string) (string, error)
+	// Manifest returns the raw manifest of an image reference, failing with
+	// errdefs.ErrManifestNotFound when it does not exist
+	Manifest(ctx context.Context, imageRef string) ([]byte, error)
+	// Blob returns a blob of the repository of an image reference
+	Blob(ctx context.Context, imageRef, digest string) ([]byte, error)
+}
+
+// containerRegistry reads images from their container registry, authenticating with auth
+type containerRegistry struct {
+	auth registry.Auth
+}
+
+func (r containerRegistry) Digest(ctx context.Context, imageRef string) (string, error) {
+	return registry.FetchManifestDigest(ctx, r.auth, imageRef)
+}
+
+func (r containerRegistry) Manifest(ctx context.Context, imageRef string) ([]byte, error) {
+	return registry.FetchManifest(ctx, r.auth, imageRef)
+}
+
+func (r containerRegistry) Blob(ctx context.Context, imageRef, digest string) ([]byte, error) {
+	return registry.FetchBlob(ctx, r.auth, imageRef, digest)
+}
+
+// Verifier checks the signatures, attestations and SBOMs of images
+type Verifier struct {
+	// PublicKeys verify cosign signatures; without keys no signature is verified
+	PublicKeys []crypto.PublicKey
+	// Registry reads images (default: their container registry)
+	Registry Registry
+}
+
+// NewVerifier returns a verifier of signatures made with any of keys that reads images from their
+// container registry, authenticating with auth
+func NewVerifier(keys []crypto.PublicKey, auth registry.Auth) *Verifier {
+	return &Verifier{PublicKeys: keys, Registry: containerRegistry{auth: auth}}
+}
+
+// LoadPublicKeys reads PEM-encoded ECDSA, RSA or Ed25519 public keys, such as cosign.pub
+func LoadPublicKeys(paths []string) ([]crypto.PublicKey, error) {
+	var keys []crypto.PublicKey
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading public key: %v", err)
+		}
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("public key %s is not PEM-encoded", path)
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing public key %s: %v", path, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// Check returns the verification status of the image with the given manifest digest, scanned at
+// now; an empty digest is resolved from the registry. Missing signatures, attestations and SBOMs
+// leave their field false; other registry failures are returned.
+func (v *Verifier) Check(ctx context.Context, imageRef, manifestDigest string, now time.Time) (*types.VerificationStatus, error) {
+	parsed, err := utils.ParseArtifactURI(imageRef)
+	if err != nil || parsed.Scheme != utils.URISchemeOCI {
+		return nil, fmt.Errorf("%s is not an image reference", imageRef)
+	}
+	repository := parsed.Registry + "/" + parsed.Repository
+
+	if manifestDigest == "" {
+		manifestDigest = parsed.Digest
+	}
+	if manifestDigest == "" {
+		manifestDigest, err = v.Registry.Digest(ctx, parsed.ImageReference())
+		if err != nil {
+			return nil, err
+		}
+	}
+	tagPrefix := repository + ":" + strings.Replace(manifestDigest, ":", "-", 1)
+
+	status := &types.VerificationStatus{ScannedAt: now.UTC().Format(time.RFC3339)}
+	if _, status.SBOMPresent, err = v.manifest(ctx, tagPrefix+sbomTagSuffix); err != nil {
+		return nil, err
+	}
+	if _, status.AttestationPresent, err = v.manifest(ctx, tagPrefix+attestationTagSuffix); err != nil {
+		return nil, err
+	}
+	signatures, found, err := v.manifest(ctx, tagPrefix+signatureTagSuffix)
+	if err != nil {
+		return nil, err
+	}
+	if found && len(v.PublicKeys) > 0 {
+		status.SignatureVerified, err = v.verifySignatures(ctx, repository, manifestDigest, signatures)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return status, nil
+}
+
+// manifest returns the manifest of an image reference, reporting whether it exists
+func (v *Verifier) manifest(ctx context.Context, imageRef string) ([]byte, bool, error) {
+	manifest, err := v.Registry.Manifest(ctx, imageRef)
+	if errors.Is(err, errdefs.ErrManifestNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return manifest, true, nil
+}
+
+// signatureManifest is the part of a cosign signature manifest that is verified
+type signatureManifest struct {
+	Layers []struct {
+		Digest      string            `json:"digest"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"layers"`
+}
+
+// simpleSigningPayload is the part of a cosign signature payload naming the signed image
+type simpleSigningPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// verifySignatures reports whether any signature of a cosign signature manifest signs
+// manifestDigest and verifies against one of the public keys
+func (v *Verifier) verifySignatures(ctx context.Context, repository, manifestDigest string, manifest []byte) (bool, error) {
+	var signatures signatureManifest
+	if err := json.Unmarshal(manifest, &signatures); err != nil {
+		return false, fmt.Errorf("error parsing signature manifest of %s: %v", repository, err)
+	}
+	for _, layer := range signatures.Layers {
+		signature, err := base64.StdEncoding.DecodeString(layer.Annotations[signatureAnnotation])
+		if err != nil || len(signature) == 0 {
+			continue
+		}
+		payload, err := v.Registry.Blob(ctx, repository, layer.Digest)
+		if err != nil {
+			return false, err
+		}
+		var signed simpleSigningPayload
+		if err := json.Unmarshal(payload, &signed); err != nil || signed.Critical.Image.DockerManifestDigest != manifestDigest {
+			continue
+		}
+		for _, key := range v.PublicKeys {
+			if verifySignature(key, payload, signature) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// verifySignature reports whether signature is a signature of payload made with the private key
+// of key
+func verifySignature(key crypto.PublicKey, payload, signature []byte) bool {
+	digest := sha256.Sum256(payload)
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(key, digest[:], signature)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil ||
+			rsa.VerifyPSS(key, crypto.SHA256, digest[:], signature, nil) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, payload, signature)
+	}
+	return false
+}
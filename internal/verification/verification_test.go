@@ -0,0 +1,140 @@
+package verification
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/errdefs"
+)
+
+const testDigest = "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+
+// fakeRegistry serves manifests by reference and blobs by digest
+type fakeRegistry struct {
+	manifests map[string][]byte
+	blobs     map[string][]byte
+}
+
+func (r *fakeRegistry) Digest(ctx context.Context, imageRef string) (string, error) {
+	return testDigest, nil
+}
+
+func (r *fakeRegistry) Manifest(ctx context.Context, imageRef string) ([]byte, error) {
+	if manifest, ok := r.manifests[imageRef]; ok {
+		return manifest, nil
+	}
+	return nil, fmt.Errorf("%w: %s", errdefs.ErrManifestNotFound, imageRef)
+}
+
+func (r *fakeRegistry) Blob(ctx context.Context, imageRef, digest string) ([]byte, error) {
+	if blob, ok := r.blobs[digest]; ok {
+		return blob, nil
+	}
+	return nil, fmt.Errorf("blob %s not found", digest)
+}
+
+// signedRegistry returns a registry holding a cosign signature of testDigest made with key
+func signedRegistry(t *testing.T, key *ecdsa.PrivateKey, signedDigest string) *fakeRegistry {
+	t.Helper()
+	payload := []byte(`{"critical":{"identity":{"docker-reference":"quay.io/org/model"},"image":{"docker-manifest-digest":"` + signedDigest + `"},"type":"cosign container image signature"}}`)
+	hash := sha256.Sum256(payload)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	if err != nil {
+		t.Fatalf("Failed to sign payload: %v", err)
+	}
+	layerDigest := fmt.Sprintf("sha256:%x", hash)
+	manifest, _ := json.Marshal(map[string]interface{}{
+		"layers": []map[string]interface{}{{
+			"digest":      layerDigest,
+			"annotations": map[string]string{signatureAnnotation: base64.StdEncoding.EncodeToString(signature)},
+		}},
+	})
+	tag := "quay.io/org/model:sha256-2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+	return &fakeRegistry{
+		manifests: map[string][]byte{tag + ".sig": manifest, tag + ".sbom": []byte(`{}`)},
+		blobs:     map[string][]byte{layerDigest: payload},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	other, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		keys         []crypto.PublicKey
+		signedDigest string
+		verified     bool
+	}{
+		{name: "matching key", keys: []crypto.PublicKey{&other.PublicKey, &key.PublicKey}, signedDigest: testDigest, verified: true},
+		{name: "other key", keys: []crypto.PublicKey{&other.PublicKey}, signedDigest: testDigest},
+		{name: "no keys", signedDigest: testDigest},
+		{name: "signature of another image", keys: []crypto.PublicKey{&key.PublicKey}, signedDigest: "sha256:" + fmt.Sprintf("%064d", 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := &Verifier{PublicKeys: tt.keys, Registry: signedRegistry(t, key, tt.signedDigest)}
+			status, err := verifier.Check(context.Background(), "quay.io/org/model:1.0", "", now)
+			if err != nil {
+				t.Fatalf("Check failed: %v", err)
+			}
+			if status.SignatureVerified != tt.verified {
+				t.Errorf("Expected signatureVerified %v, got %v", tt.verified, status.SignatureVerified)
+			}
+			if !status.SBOMPresent || status.AttestationPresent {
+				t.Errorf("Expected only an SBOM to be present, got %+v", status)
+			}
+			if status.ScannedAt != "2025-03-01T12:00:00Z" {
+				t.Errorf("Expected scannedAt 2025-03-01T12:00:00Z, got %s", status.ScannedAt)
+			}
+		})
+	}
+
+	if _, err := (&Verifier{Registry: &fakeRegistry{}}).Check(context.Background(), "hf://org/model", "", now); err == nil {
+		t.Error("Expected an error for an artifact that is not an image")
+	}
+}
+
+func TestLoadPublicKeys(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cosign.pub")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := LoadPublicKeys([]string{path})
+	if err != nil {
+		t.Fatalf("LoadPublicKeys failed: %v", err)
+	}
+	if len(keys) != 1 || !key.PublicKey.Equal(keys[0]) {
+		t.Errorf("Expected the written key, got %v", keys)
+	}
+
+	invalid := filepath.Join(dir, "invalid.pub")
+	_ = os.WriteFile(invalid, []byte("not a key"), 0644)
+	if _, err := LoadPublicKeys([]string{invalid}); err == nil {
+		t.Error("Expected an error for a file that is not PEM-encoded")
+	}
+}
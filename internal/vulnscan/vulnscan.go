@@ -0,0 +1,282 @@
+// Package vulnscan counts the known vulnerabilities of modelcar images by severity, either by
+// running Trivy or from Trivy and Clair JSON reports produced by a separate scanning job.
+package vulnscan
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+// Scanners whose results are understood
+const (
+	ScannerTrivy = "trivy"
+	ScannerClair = "clair"
+)
+
+// trivyTimeout bounds a single Trivy scan, which downloads the image's layers
+const trivyTimeout = 15 * time.Minute
+
+// Scanner returns the vulnerability counts of images
+type Scanner interface {
+	// Scan returns the vulnerability counts of the image with the given manifest digest, or nil
+	// when the scanner has no result for it
+	Scan(ctx context.Context, imageRef, manifestDigest string) (*types.VulnerabilitySummary, error)
+}
+
+// ValidateSeverity returns an error unless severity is one of types.Severities
+func ValidateSeverity(severity string) error {
+	for _, known := range types.Severities {
+		if severity == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid severity %q (expected %s)", severity, strings.Join(types.Severities, ", "))
+}
+
+// Report is a parsed Trivy or Clair vulnerability report
+type Report struct {
+	// Images are the image references the report describes
+	Images []string
+	// Digests are the manifest digests the report describes
+	Digests []string
+	// Summary counts the distinct vulnerabilities of the report by severity
+	Summary types.VulnerabilitySummary
+}
+
+// trivyReport is the part of a Trivy JSON report (trivy image --format json) that is counted
+type trivyReport struct {
+	SchemaVersion int    `json:"SchemaVersion"`
+	CreatedAt     string `json:"CreatedAt"`
+	ArtifactName  string `json:"ArtifactName"`
+	Metadata      struct {
+		RepoTags    []string `json:"RepoTags"`
+		RepoDigests []string `json:"RepoDigests"`
+	} `json:"Metadata"`
+	Results []struct {
+		Vulnerabilities []struct {
+			VulnerabilityID string `json:"VulnerabilityID"`
+			Severity        string `json:"Severity"`
+		} `json:"Vulnerabilities"`
+	} `json:"Results"`
+}
+
+// clairReport is the part of a Clair v4 vulnerability report that is counted
+type clairReport struct {
+	ManifestHash    string `json:"manifest_hash"`
+	Vulnerabilities map[string]struct {
+		Name               string `json:"name"`
+		NormalizedSeverity string `json:"normalized_severity"`
+	} `json:"vulnerabilities"`
+}
+
+// ParseReport parses a Trivy JSON report or a Clair v4 vulnerability report. A vulnerability found
+// in several packages is counted once, with its highest severity.
+func ParseReport(data []byte) (*Report, error) {
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("error parsing vulnerability report: %v", err)
+	}
+
+	severities := make(map[string]string)
+	record := func(id, severity string) {
+		severity = normalizeSeverity(severity)
+		if current, seen := severities[id]; !seen || severityRank(severity) < severityRank(current) {
+			severities[id] = severity
+		}
+	}
+
+	report := &Report{}
+	switch {
+	case probe["manifest_hash"] != nil:
+		var clair clairReport
+		if err := json.Unmarshal(data, &clair); err != nil {
+			return nil, fmt.Errorf("error parsing Clair report: %v", err)
+		}
+		report.Summary.Scanner = ScannerClair
+		report.Digests = []string{clair.ManifestHash}
+		for id, vulnerability := range clair.Vulnerabilities {
+			if vulnerability.Name != "" {
+				id = vulnerability.Name
+			}
+			record(id, vulnerability.NormalizedSeverity)
+		}
+	case probe["SchemaVersion"] != nil || probe["Results"] != nil:
+		var trivy trivyReport
+		if err := json.Unmarshal(data, &trivy); err != nil {
+			return nil, fmt.Errorf("error parsing Trivy report: %v", err)
+		}
+		report.Summary.Scanner = ScannerTrivy
+		report.Summary.ScannedAt = trivy.CreatedAt
+		if trivy.ArtifactName != "" {
+			report.Images = append(report.Images, trivy.ArtifactName)
+		}
+		report.Images = append(report.Images, trivy.Metadata.RepoTags...)
+		for _, repoDigest := range trivy.Metadata.RepoDigests {
+			if _, digest, found := strings.Cut(repoDigest, "@"); found {
+				report.Digests = append(report.Digests, digest)
+			}
+		}
+		for _, result := range trivy.Results {
+			for _, vulnerability := range result.Vulnerabilities {
+				record(vulnerability.VulnerabilityID, vulnerability.Severity)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unrecognized vulnerability report: expected a Trivy or Clair JSON report")
+	}
+
+	for _, severity := range severities {
+		report.Summary.Add(severity)
+	}
+	return report, nil
+}
+
+// normalizeSeverity maps a scanner severity to one of types.Severities; Clair's "negligible"
+// counts as low
+func normalizeSeverity(severity string) string {
+	severity = strings.ToLower(strings.TrimSpace(severity))
+	if severity == "negligible" {
+		return types.SeverityLow
+	}
+	if ValidateSeverity(severity) != nil {
+		return types.SeverityUnknown
+	}
+	return severity
+}
+
+// severityRank returns the position of a severity in types.Severities, highest first
+func severityRank(severity string) int {
+	for rank, known := range types.Severities {
+		if severity == known {
+			return rank
+		}
+	}
+	return len(types.Severities)
+}
+
+// Trivy scans images by running the trivy CLI
+type Trivy struct {
+	// Binary is the trivy executable (default "trivy" on the PATH)
+	Binary string
+}
+
+// Scan runs trivy image on an image reference
+func (t *Trivy) Scan(ctx context.Context, imageRef, manifestDigest string) (*types.VulnerabilitySummary, error) {
+	binary := t.Binary
+	if binary == "" {
+		binary = ScannerTrivy
+	}
+	ctx, cancel := context.WithTimeout(ctx, trivyTimeout)
+	defer cancel()
+
+	target := utils.ImageReferenceOf(imageRef)
+	cmd := exec.CommandContext(ctx, binary, "image", "--format", "json", "--quiet", "--scanners", "vuln", target)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("trivy failed on %s: %v: %s", target, err, strings.TrimSpace(stderr.String()))
+	}
+	report, err := ParseReport(stdout.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	if report.Summary.ScannedAt == "" {
+		report.Summary.ScannedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+	return &report.Summary, nil
+}
+
+// Reports serves the results of Trivy and Clair JSON reports read from a directory
+type Reports struct {
+	byImage  map[string]*types.VulnerabilitySummary
+	byDigest map[string]*types.VulnerabilitySummary
+}
+
+// LoadReports reads the *.json reports of a directory. Reports are matched to images by the
+// image references and digests they name; Clair reports, which carry no scan time, take the
+// modification time of their file.
+func LoadReports(dir string) (*Reports, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("error reading vulnerability reports: %v", err)
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("error listing vulnerability reports: %v", err)
+	}
+
+	reports := &Reports{
+		byImage:  make(map[string]*types.VulnerabilitySummary),
+		byDigest: make(map[string]*types.VulnerabilitySummary),
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading vulnerability report: %v", err)
+		}
+		report, err := ParseReport(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		summary := report.Summary
+		if summary.ScannedAt == "" {
+			if info, err := os.Stat(path); err == nil {
+				summary.ScannedAt = info.ModTime().UTC().Format(time.RFC3339)
+			}
+		}
+		for _, image := range report.Images {
+			reports.byImage[utils.NormalizeArtifactURI(image)] = &summary
+		}
+		for _, digest := range report.Digests {
+			reports.byDigest[digest] = &summary
+		}
+	}
+	return reports, nil
+}
+
+// Scan returns the summary of the report naming the image's digest or reference, or nil when no
+// report describes the image
+func (r *Reports) Scan(ctx context.Context, imageRef, manifestDigest string) (*types.VulnerabilitySummary, error) {
+	if summary, ok := r.byDigest[manifestDigest]; ok && manifestDigest != "" {
+		return summary, nil
+	}
+	if summary, ok := r.byImage[utils.NormalizeArtifactURI(imageRef)]; ok {
+		return summary, nil
+	}
+	return nil, nil
+}
+
+// ErrScannerConflict is returned by NewScanner when both a scanner and a reports directory are
+// configured
+var ErrScannerConflict = errors.New("a vulnerability scanner and a reports directory are mutually exclusive")
+
+// NewScanner returns the scanner configured by a scanner name (only "trivy" is run) or a reports
+// directory, or nil when neither is set
+func NewScanner(scanner, reportsDir string) (Scanner, error) {
+	switch {
+	case scanner != "" && reportsDir != "":
+		return nil, ErrScannerConflict
+	case scanner == ScannerTrivy:
+		return &Trivy{}, nil
+	case scanner != "":
+		return nil, fmt.Errorf("unsupported vulnerability scanner %q (expected %s; Clair results are read from a reports directory)", scanner, ScannerTrivy)
+	case reportsDir != "":
+		reports, err := LoadReports(reportsDir)
+		if err != nil {
+			return nil, err
+		}
+		return reports, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,101 @@
+package vulnscan
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testTrivyReport = `{
+  "SchemaVersion": 2,
+  "CreatedAt": "2025-03-01T12:00:00Z",
+  "ArtifactName": "quay.io/org/model:1.0",
+  "Metadata": {"RepoDigests": ["quay.io/org/model@sha256:aaaa"]},
+  "Results": [
+    {"Vulnerabilities": [
+      {"VulnerabilityID": "CVE-2024-0001", "Severity": "HIGH"},
+      {"VulnerabilityID": "CVE-2024-0002", "Severity": "LOW"}
+    ]},
+    {"Vulnerabilities": [
+      {"VulnerabilityID": "CVE-2024-0001", "Severity": "CRITICAL"},
+      {"VulnerabilityID": "CVE-2024-0003", "Severity": "UNKNOWN"}
+    ]}
+  ]
+}`
+
+const testClairReport = `{
+  "manifest_hash": "sha256:bbbb",
+  "vulnerabilities": {
+    "1": {"name": "CVE-2024-0004", "normalized_severity": "Medium"},
+    "2": {"name": "CVE-2024-0005", "normalized_severity": "Negligible"},
+    "3": {"name": "CVE-2024-0004", "normalized_severity": "Medium"}
+  }
+}`
+
+func TestParseReport(t *testing.T) {
+	trivy, err := ParseReport([]byte(testTrivyReport))
+	if err != nil {
+		t.Fatalf("ParseReport failed on a Trivy report: %v", err)
+	}
+	summary := trivy.Summary
+	if summary.Scanner != ScannerTrivy || summary.ScannedAt != "2025-03-01T12:00:00Z" {
+		t.Errorf("Expected a trivy scan at 2025-03-01T12:00:00Z, got %+v", summary)
+	}
+	if summary.Critical != 1 || summary.High != 0 || summary.Low != 1 || summary.Unknown != 1 {
+		t.Errorf("Expected CVE-2024-0001 counted once as critical, got %+v", summary)
+	}
+	if len(trivy.Images) != 1 || len(trivy.Digests) != 1 || trivy.Digests[0] != "sha256:aaaa" {
+		t.Errorf("Expected the image and digest of the report, got %v %v", trivy.Images, trivy.Digests)
+	}
+
+	clair, err := ParseReport([]byte(testClairReport))
+	if err != nil {
+		t.Fatalf("ParseReport failed on a Clair report: %v", err)
+	}
+	if clair.Summary.Scanner != ScannerClair || clair.Summary.Medium != 1 || clair.Summary.Low != 1 {
+		t.Errorf("Expected one medium and one negligible vulnerability, got %+v", clair.Summary)
+	}
+
+	if _, err := ParseReport([]byte(`{"foo": 1}`)); err == nil {
+		t.Error("Expected an error for a report of an unknown scanner")
+	}
+}
+
+func TestLoadReports(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "trivy.json"), []byte(testTrivyReport), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "clair.json"), []byte(testClairReport), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	scanner, err := NewScanner("", dir)
+	if err != nil {
+		t.Fatalf("NewScanner failed: %v", err)
+	}
+	ctx := context.Background()
+
+	byRef, _ := scanner.Scan(ctx, "oci://quay.io/org/model:1.0", "")
+	if byRef == nil || byRef.Scanner != ScannerTrivy {
+		t.Errorf("Expected the Trivy report matched by image reference, got %+v", byRef)
+	}
+	byDigest, _ := scanner.Scan(ctx, "oci://registry.example.com/other:2.0", "sha256:bbbb")
+	if byDigest == nil || byDigest.Scanner != ScannerClair || byDigest.ScannedAt == "" {
+		t.Errorf("Expected the Clair report matched by digest with its file time, got %+v", byDigest)
+	}
+	if missing, _ := scanner.Scan(ctx, "oci://quay.io/org/unscanned:1.0", "sha256:cccc"); missing != nil {
+		t.Errorf("Expected no result for an unscanned image, got %+v", missing)
+	}
+
+	if _, err := NewScanner(ScannerTrivy, dir); err != ErrScannerConflict {
+		t.Errorf("Expected ErrScannerConflict, got %v", err)
+	}
+	if _, err := NewScanner(ScannerClair, ""); err == nil {
+		t.Error("Expected an error for running Clair")
+	}
+	if scanner, err := NewScanner("", ""); scanner != nil || err != nil {
+		t.Errorf("Expected no scanner, got %v %v", scanner, err)
+	}
+}
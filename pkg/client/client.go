@@ -0,0 +1,249 @@
+// Package client is a Go client for the catalog API of the model-extractor serve subcommand. It
+// implements the OpenAPI document served on /openapi.yaml (internal/serve/openapi.yaml), so
+// downstream services can query the catalog without hand-writing HTTP calls.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// APIVersion is the info.version of the OpenAPI document this client implements
+const APIVersion = "1.0.0"
+
+// maxResponseSize caps the response bodies read from the server
+const maxResponseSize = 64 * 1024 * 1024
+
+var (
+	// ErrNotFound reports a model that is not in the catalog, or a model without a readme
+	ErrNotFound = errors.New("not found")
+	// ErrNotLoaded reports a server that has not loaded its first catalog yet
+	ErrNotLoaded = errors.New("catalog not loaded yet")
+)
+
+// Client calls the catalog API of a serve instance
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// New returns a client for the serve instance at baseURL, e.g. http://localhost:8080. A nil
+// httpClient uses a client with a 30 second timeout.
+func New(baseURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &Client{baseURL: strings.TrimSuffix(baseURL, "/"), httpClient: httpClient}
+}
+
+// ListOptions are the filters of ListModels; empty fields match every model
+type ListOptions struct {
+	Provider string
+	License  string
+	Task     string
+	// Labels must all be present on a model
+	Labels []string
+	// Query searches the model name and description
+	Query string
+	// Deprecated selects only deprecated (true) or only current (false) models when set
+	Deprecated *bool
+}
+
+// ModelList is the response of ListModels. Its models have no readme.
+type ModelList struct {
+	Source string                  `yaml:"source"`
+	Count  int                     `yaml:"count"`
+	Models []types.CatalogMetadata `yaml:"models"`
+}
+
+// GraphQLError is an error reported in the errors of a GraphQL response
+type GraphQLError struct {
+	Messages []string
+}
+
+func (e *GraphQLError) Error() string {
+	return "GraphQL error: " + strings.Join(e.Messages, "; ")
+}
+
+// ListModels returns the catalog models matching the filters
+func (c *Client) ListModels(ctx context.Context, opts ListOptions) (*ModelList, error) {
+	query := url.Values{}
+	setQuery(query, "provider", opts.Provider)
+	setQuery(query, "license", opts.License)
+	setQuery(query, "task", opts.Task)
+	setQuery(query, "q", opts.Query)
+	for _, label := range opts.Labels {
+		query.Add("label", label)
+	}
+	if opts.Deprecated != nil {
+		query.Set("deprecated", strconv.FormatBool(*opts.Deprecated))
+	}
+
+	data, err := c.get(ctx, "/models", query)
+	if err != nil {
+		return nil, err
+	}
+	// The catalog types only carry YAML field names; JSON documents are valid YAML
+	var list ModelList
+	if err := yaml.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("error decoding models: %v", err)
+	}
+	return &list, nil
+}
+
+// GetModel returns the catalog model with the given name, including its readme
+func (c *Client) GetModel(ctx context.Context, name string) (*types.CatalogMetadata, error) {
+	data, err := c.get(ctx, modelPath(name), nil)
+	if err != nil {
+		return nil, err
+	}
+	var model types.CatalogMetadata
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, fmt.Errorf("error decoding model %s: %v", name, err)
+	}
+	return &model, nil
+}
+
+// GetModelReadme returns the readme of the catalog model with the given name
+func (c *Client) GetModelReadme(ctx context.Context, name string) (string, error) {
+	data, err := c.get(ctx, modelPath(name)+"/readme", nil)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// GraphQL executes a GraphQL query with optional variables and decodes its data into result.
+// Errors reported by the server are returned as a *GraphQLError.
+func (c *Client) GraphQL(ctx context.Context, query string, variables map[string]interface{}, result interface{}) error {
+	body, err := json.Marshal(struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables,omitempty"`
+	}{query, variables})
+	if err != nil {
+		return fmt.Errorf("error encoding GraphQL request: %v", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/graphql", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	data, status, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK && status != http.StatusBadRequest {
+		return statusError(status, data)
+	}
+
+	var response struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		if status == http.StatusBadRequest {
+			return statusError(status, data)
+		}
+		return fmt.Errorf("error decoding GraphQL response: %v", err)
+	}
+	if len(response.Errors) > 0 {
+		graphQLErr := &GraphQLError{}
+		for _, e := range response.Errors {
+			graphQLErr.Messages = append(graphQLErr.Messages, e.Message)
+		}
+		return graphQLErr
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(response.Data, result); err != nil {
+		return fmt.Errorf("error decoding GraphQL data: %v", err)
+	}
+	return nil
+}
+
+// GraphQLSchema returns the GraphQL schema of the catalog in the schema definition language
+func (c *Client) GraphQLSchema(ctx context.Context) (string, error) {
+	data, err := c.get(ctx, "/graphql/schema", nil)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// get performs a GET request and returns the body of a 200 response
+func (c *Client) get(ctx context.Context, path string, query url.Values) ([]byte, error) {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	data, status, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, statusError(status, data)
+	}
+	return data, nil
+}
+
+func (c *Client) do(req *http.Request) ([]byte, int, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error calling %s %s: %v", req.Method, req.URL.Path, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, 0, fmt.Errorf("error reading response of %s %s: %v", req.Method, req.URL.Path, err)
+	}
+	return data, resp.StatusCode, nil
+}
+
+// statusError returns the error of a non-200 response, wrapping ErrNotFound and ErrNotLoaded
+func statusError(status int, body []byte) error {
+	message := strings.TrimSpace(string(body))
+	switch status {
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %s", ErrNotFound, message)
+	case http.StatusServiceUnavailable:
+		return ErrNotLoaded
+	default:
+		return fmt.Errorf("unexpected status %d: %s", status, message)
+	}
+}
+
+// modelPath returns the API path of a model; the slashes of model names are kept unescaped
+func modelPath(name string) string {
+	segments := strings.Split(name, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return "/models/" + strings.Join(segments, "/")
+}
+
+func setQuery(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
@@ -0,0 +1,234 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/serve"
+)
+
+const testCatalog = `source: Red Hat
+models:
+  - name: RedHatAI/granite-3.1-8b-instruct
+    provider: IBM
+    license: apache-2.0
+    description: Granite instruct model
+    readme: "# Granite"
+    tasks: [text-generation]
+    customProperties:
+      validated: {metadataType: MetadataStringValue, string_value: ""}
+    artifacts:
+      - uri: oci://registry.redhat.io/rhelai1/modelcar-granite-3-1-8b-instruct:1.5
+        createTimeSinceEpoch: "1733000000000"
+  - name: RedHatAI/Llama-3.1-8B-Instruct
+    provider: Meta
+    license: llama3.1
+    description: Llama instruct model
+    tasks: [text-generation]
+    deprecated: true
+    artifacts: []
+`
+
+func testServer(t *testing.T) *Client {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "models-catalog.yaml")
+	if err := os.WriteFile(path, []byte(testCatalog), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cat := serve.NewCatalog()
+	if err := cat.Load(path); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	server := httptest.NewServer(serve.NewHandler(nil, cat))
+	t.Cleanup(server.Close)
+	return New(server.URL+"/", server.Client())
+}
+
+func TestListModels(t *testing.T) {
+	c := testServer(t)
+	current := false
+	list, err := c.ListModels(context.Background(), ListOptions{Task: "text-generation", Labels: []string{"validated"}, Deprecated: &current})
+	if err != nil {
+		t.Fatalf("ListModels() error = %v", err)
+	}
+	if list.Source != "Red Hat" || list.Count != 1 || len(list.Models) != 1 {
+		t.Fatalf("ListModels() = %+v, want the granite model", list)
+	}
+	model := list.Models[0]
+	if *model.Name != "RedHatAI/granite-3.1-8b-instruct" || *model.Provider != "IBM" || model.Readme != nil {
+		t.Errorf("model = %+v, want granite without readme", model)
+	}
+	if len(model.Artifacts) != 1 || *model.Artifacts[0].CreateTimeSinceEpoch != "1733000000000" {
+		t.Errorf("artifacts = %+v, want the decoded granite artifact", model.Artifacts)
+	}
+	if _, ok := model.CustomProperties["validated"]; !ok {
+		t.Errorf("customProperties = %v, want validated", model.CustomProperties)
+	}
+}
+
+func TestGetModelAndReadme(t *testing.T) {
+	c := testServer(t)
+	ctx := context.Background()
+
+	model, err := c.GetModel(ctx, "RedHatAI/Llama-3.1-8B-Instruct")
+	if err != nil {
+		t.Fatalf("GetModel() error = %v", err)
+	}
+	if !model.Deprecated || *model.License != "llama3.1" {
+		t.Errorf("GetModel() = %+v, want the deprecated llama model", model)
+	}
+
+	readme, err := c.GetModelReadme(ctx, "RedHatAI/granite-3.1-8b-instruct")
+	if err != nil || readme != "# Granite" {
+		t.Errorf("GetModelReadme() = %q, %v, want the granite readme", readme, err)
+	}
+
+	if _, err := c.GetModel(ctx, "RedHatAI/missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetModel(missing) error = %v, want ErrNotFound", err)
+	}
+	if _, err := c.GetModelReadme(ctx, "RedHatAI/Llama-3.1-8B-Instruct"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetModelReadme(no readme) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestGraphQL(t *testing.T) {
+	c := testServer(t)
+	ctx := context.Background()
+
+	var result struct {
+		Models []struct {
+			Name     string `json:"name"`
+			Provider string `json:"provider"`
+		} `json:"models"`
+	}
+	err := c.GraphQL(ctx, `query($p: String) { models(provider: $p) { name provider } }`, map[string]interface{}{"p": "meta"}, &result)
+	if err != nil {
+		t.Fatalf("GraphQL() error = %v", err)
+	}
+	if len(result.Models) != 1 || result.Models[0].Name != "RedHatAI/Llama-3.1-8B-Instruct" {
+		t.Errorf("GraphQL() = %+v, want the llama model", result)
+	}
+
+	var graphQLErr *GraphQLError
+	if err := c.GraphQL(ctx, `{ models { unknownField } }`, nil, nil); !errors.As(err, &graphQLErr) {
+		t.Errorf("GraphQL(invalid) error = %v, want a GraphQLError", err)
+	}
+
+	schema, err := c.GraphQLSchema(ctx)
+	if err != nil || !strings.Contains(schema, "type Query") {
+		t.Errorf("GraphQLSchema() = %q, %v, want the schema", schema, err)
+	}
+}
+
+func TestNotLoaded(t *testing.T) {
+	server := httptest.NewServer(serve.NewHandler(nil, serve.NewCatalog()))
+	defer server.Close()
+	if _, err := New(server.URL, nil).ListModels(context.Background(), ListOptions{}); !errors.Is(err, ErrNotLoaded) {
+		t.Errorf("ListModels() error = %v, want ErrNotLoaded", err)
+	}
+}
+
+func TestAPIVersionMatchesSpec(t *testing.T) {
+	var spec struct {
+		Info struct {
+			Version string `yaml:"version"`
+		} `yaml:"info"`
+	}
+	if err := yaml.Unmarshal(serve.OpenAPISpec(), &spec); err != nil {
+		t.Fatalf("parsing OpenAPI document: %v", err)
+	}
+	if spec.Info.Version != APIVersion {
+		t.Errorf("OpenAPI info.version = %s, client APIVersion = %s", spec.Info.Version, APIVersion)
+	}
+}
+
+// TestClientMatchesSpec calls every client method and checks that each request is an operation of
+// the OpenAPI document with parameters it declares, and that every operation of the document is
+// either used by the client or listed as not implemented, so the client cannot drift from the spec
+func TestClientMatchesSpec(t *testing.T) {
+	type parameter struct {
+		Name string `yaml:"name"`
+		In   string `yaml:"in"`
+	}
+	type operation struct {
+		OperationID string      `yaml:"operationId"`
+		Parameters  []parameter `yaml:"parameters"`
+	}
+	var spec struct {
+		Paths map[string]map[string]operation `yaml:"paths"`
+	}
+	if err := yaml.Unmarshal(serve.OpenAPISpec(), &spec); err != nil {
+		t.Fatalf("parsing OpenAPI document: %v", err)
+	}
+	// Operations served for tooling rather than catalog consumers, and the GET form of GraphQL,
+	// which the client replaces with POST
+	notImplemented := map[string]bool{"getMetrics": true, "getOpenAPI": true, "queryGraphQLGet": true}
+
+	path := filepath.Join(t.TempDir(), "models-catalog.yaml")
+	if err := os.WriteFile(path, []byte(testCatalog), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cat := serve.NewCatalog()
+	if err := cat.Load(path); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	const modelName = "RedHatAI/granite-3.1-8b-instruct"
+	used := make(map[string]bool)
+	handler := serve.NewHandler(nil, cat)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		template := strings.Replace(r.URL.Path, "/models/"+modelName, "/models/{name}", 1)
+		op, ok := spec.Paths[template][strings.ToLower(r.Method)]
+		if !ok {
+			t.Errorf("%s %s is not an operation of the OpenAPI document", r.Method, template)
+		}
+		declared := make(map[string]bool)
+		for _, p := range op.Parameters {
+			if p.In == "query" {
+				declared[p.Name] = true
+			}
+		}
+		for name := range r.URL.Query() {
+			if !declared[name] {
+				t.Errorf("%s %s sends query parameter %q, which the OpenAPI document does not declare", r.Method, template, name)
+			}
+		}
+		used[op.OperationID] = true
+		handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	c := New(server.URL, server.Client())
+	ctx := context.Background()
+	deprecated := true
+	if _, err := c.ListModels(ctx, ListOptions{Provider: "IBM", License: "apache-2.0", Task: "text-generation", Labels: []string{"validated"}, Query: "granite", Deprecated: &deprecated}); err != nil {
+		t.Errorf("ListModels() error = %v", err)
+	}
+	if _, err := c.GetModel(ctx, modelName); err != nil {
+		t.Errorf("GetModel() error = %v", err)
+	}
+	if _, err := c.GetModelReadme(ctx, modelName); err != nil {
+		t.Errorf("GetModelReadme() error = %v", err)
+	}
+	if err := c.GraphQL(ctx, "{ modelCount }", nil, nil); err != nil {
+		t.Errorf("GraphQL() error = %v", err)
+	}
+	if _, err := c.GraphQLSchema(ctx); err != nil {
+		t.Errorf("GraphQLSchema() error = %v", err)
+	}
+
+	for template, operations := range spec.Paths {
+		for method, op := range operations {
+			if !used[op.OperationID] && !notImplemented[op.OperationID] {
+				t.Errorf("%s %s (%s) is not implemented by the client", strings.ToUpper(method), template, op.OperationID)
+			}
+		}
+	}
+}
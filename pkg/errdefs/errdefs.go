@@ -0,0 +1,48 @@
+package errdefs
+
+import "errors"
+
+// Failure classes of the pipeline. Errors are wrapped around these with %w, so callers match
+// them with errors.Is instead of comparing messages.
+var (
+	// ErrManifestNotFound reports a model image whose manifest the registry does not have, e.g. a
+	// removed tag
+	ErrManifestNotFound = errors.New("manifest not found")
+	// ErrNoModelcardLayer reports a model image without a layer annotated as the modelcard
+	ErrNoModelcardLayer = errors.New("no modelcard layer found in the image")
+	// ErrHFRateLimited reports a HuggingFace request rejected with 429 Too Many Requests after
+	// all retries
+	ErrHFRateLimited = errors.New("HuggingFace rate limit exceeded")
+	// ErrUnauthorized reports a registry or HuggingFace request rejected for missing or invalid
+	// credentials
+	ErrUnauthorized = errors.New("unauthorized")
+)
+
+// Failure kinds reported by Classify
+const (
+	KindManifestNotFound = "manifest-not-found"
+	KindNoModelcardLayer = "no-modelcard-layer"
+	KindRateLimited      = "rate-limited"
+	KindUnauthorized     = "unauthorized"
+)
+
+// kinds maps each failure class to its kind, in the order Classify checks them
+var kinds = []struct {
+	err  error
+	kind string
+}{
+	{ErrManifestNotFound, KindManifestNotFound},
+	{ErrNoModelcardLayer, KindNoModelcardLayer},
+	{ErrHFRateLimited, KindRateLimited},
+	{ErrUnauthorized, KindUnauthorized},
+}
+
+// Classify returns the kind of the failure class err wraps, or "" for unclassified errors
+func Classify(err error) string {
+	for _, k := range kinds {
+		if errors.Is(err, k.err) {
+			return k.kind
+		}
+	}
+	return ""
+}
@@ -0,0 +1,29 @@
+package errdefs
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"nil", nil, ""},
+		{"unclassified", errors.New("connection reset"), ""},
+		{"wrapped", fmt.Errorf("failed to fetch README: %w", fmt.Errorf("README not found, status 429: %w", ErrHFRateLimited)), KindRateLimited},
+		{"manifest", fmt.Errorf("%w: reading manifest 1.0", ErrManifestNotFound), KindManifestNotFound},
+		{"unauthorized", fmt.Errorf("%w: invalid username/password", ErrUnauthorized), KindUnauthorized},
+		{"modelcard", ErrNoModelcardLayer, KindNoModelcardLayer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Classify(tt.err); got != tt.want {
+				t.Errorf("Classify() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
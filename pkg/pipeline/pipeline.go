@@ -0,0 +1,786 @@
+// Package pipeline runs the model metadata collection pipeline from Go programs, so other
+// components can collect model metadata without running the model-extractor binary; the
+// model-extractor CLI runs its models pipeline through this package as well. A Pipeline is
+// configured once with New. Extract reads modelcards and metadata from model images, Enrich adds
+// HuggingFace data and BuildCatalog writes the models catalog. The steps communicate through the
+// per-model output directory and can be run separately or in sequence.
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/catalog"
+	"github.com/opendatahub-io/model-metadata-collection/internal/checkpoint"
+	"github.com/opendatahub-io/model-metadata-collection/internal/embeddings"
+	"github.com/opendatahub-io/model-metadata-collection/internal/enrichment"
+	"github.com/opendatahub-io/model-metadata-collection/internal/errorreport"
+	"github.com/opendatahub-io/model-metadata-collection/internal/extraction"
+	"github.com/opendatahub-io/model-metadata-collection/internal/huggingface"
+	"github.com/opendatahub-io/model-metadata-collection/internal/metadata"
+	"github.com/opendatahub-io/model-metadata-collection/internal/outputfs"
+	"github.com/opendatahub-io/model-metadata-collection/internal/pyxis"
+	"github.com/opendatahub-io/model-metadata-collection/internal/registry"
+	"github.com/opendatahub-io/model-metadata-collection/internal/verification"
+	"github.com/opendatahub-io/model-metadata-collection/internal/vulnscan"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+)
+
+// Options configures a Pipeline
+type Options struct {
+	// OutputDir receives a directory per model with its modelcard and metadata, manifests.yaml
+	// listing the extracted models, the checkpoint and the errors.yaml report
+	OutputDir string
+	// ModelsIndexPath is the models index whose models are enriched
+	ModelsIndexPath string
+	// MaxConcurrent bounds the number of models extracted or enriched at the same time (default
+	// 1 for extraction, enrichment.DefaultMaxConcurrent for enrichment)
+	MaxConcurrent int
+	// MatchThreshold is the minimum similarity for matching a model to a HuggingFace model
+	// (default enrichment.DefaultMatchThreshold)
+	MatchThreshold float64
+	// Resume skips the models that checkpoint.yaml in OutputDir records as already extracted or
+	// enriched
+	Resume bool
+	// RetryFailed reprocesses only the models listed in errors.yaml of the previous run in
+	// OutputDir, keeping the output of the others
+	RetryFailed bool
+	// HuggingFace configures the HuggingFace requests of every step
+	HuggingFace HuggingFaceOptions
+	// RegistryCredentials maps registry hosts, e.g. RedHatRegistry, to the service account the
+	// registry operations of every step use for their images instead of the container auth files
+	RegistryCredentials map[string]RegistryCredentials
+	// Readme configures how modelcards become the readme field of each model
+	Readme ReadmeOptions
+	// Extract configures Extract
+	Extract ExtractOptions
+	// Enrich configures Enrich
+	Enrich EnrichOptions
+	// Catalog configures BuildCatalog
+	Catalog CatalogOptions
+}
+
+// HuggingFaceOptions configures the HuggingFace requests of the pipeline
+type HuggingFaceOptions struct {
+	// Timeout bounds every request, including reading its body (default 30s)
+	Timeout time.Duration
+	// Retries is how often a request that fails with a network error, 429 Too Many Requests or a
+	// 5xx status is retried (default 3); a negative value disables retries
+	Retries int
+	// SnapshotDir is a directory of pre-downloaded HuggingFace model files read instead of the
+	// network. Collection discovery always needs the network and is skipped.
+	SnapshotDir string
+}
+
+// RedHatRegistry is the registry that Red Hat registry service accounts authenticate to
+const RedHatRegistry = registry.RedHatRegistry
+
+// RegistryCredentials are the username and token of a registry account, such as those shown on
+// the token page of a registry.redhat.io service account
+type RegistryCredentials struct {
+	Username string
+	Token    string
+}
+
+// ReadmeOptions configures the readme field of models
+type ReadmeOptions struct {
+	// Skip leaves the readme field of models empty
+	Skip bool
+	// MaxSize caps readmes in bytes, truncating longer modelcards at a line break (default
+	// 256 KiB); a negative value disables the cap
+	MaxSize int
+}
+
+// ExtractOptions configures Extract
+type ExtractOptions struct {
+	// KeepIntermediate keeps each model's raw manifest, config blob and modelcard layer in a
+	// debug directory next to its metadata
+	KeepIntermediate bool
+	// ModelcardAnnotations are the layer annotations marking modelcard layers, any of which is
+	// accepted (default io.opendatahub.modelcar.layer.type=modelcard)
+	ModelcardAnnotations []LayerAnnotation
+	// VerifyArtifacts records the signature, attestation and SBOM checks of each image on its
+	// artifact
+	VerifyArtifacts bool
+	// VerificationKeyPaths are PEM public keys image signatures are verified against
+	VerificationKeyPaths []string
+	// VulnScanner runs a vulnerability scanner on each image; only "trivy" is supported
+	VulnScanner string
+	// VulnReportsDir holds Trivy or Clair JSON reports the vulnerability counts are read from
+	// instead of running a scanner
+	VulnReportsDir string
+}
+
+// LayerAnnotation identifies modelcard layers by a layer annotation. An empty Value accepts any
+// value of the annotation.
+type LayerAnnotation struct {
+	Key   string
+	Value string
+}
+
+// EnrichOptions configures Enrich
+type EnrichOptions struct {
+	// HFIndexPath is the HuggingFace collection index to match models against; defaults to the
+	// merged collection index, or the latest version index when there is none
+	HFIndexPath string
+	// VLLMConfigDir holds supplemental vLLM configuration files; empty skips them
+	VLLMConfigDir string
+	// RedHatCatalog records the Red Hat container catalog (Pyxis) record of Red Hat registry images
+	RedHatCatalog bool
+	// PyxisURL is the Pyxis API queried with RedHatCatalog (default pyxis.DefaultURL)
+	PyxisURL string
+	// PyxisAPIKey is sent to the Pyxis API when set
+	PyxisAPIKey string
+}
+
+// CatalogOptions configures BuildCatalog
+type CatalogOptions struct {
+	// CatalogPath is the file the catalog is written to
+	CatalogPath string
+	// StaticCatalogPaths are catalog files whose models are added as they are
+	StaticCatalogPaths []string
+	// Format is the output format: yaml (default), json or ndjson
+	Format string
+	// Validation is the schema validation mode: error (default), warn or off
+	Validation string
+	// IncludeLabels, when non-empty, limits the catalog to models carrying at least one of them
+	IncludeLabels []string
+	// ExcludeLabels drops models carrying any of them from the catalog
+	ExcludeLabels []string
+	// ModelFilterPath is a YAML allowlist/denylist of model names or artifact URI patterns applied
+	// as the final filter of the catalog
+	ModelFilterPath string
+	// RequiredFields fails catalog generation when any model lacks one of these fields
+	RequiredFields []string
+	// SkipURIDedup keeps models with different names that share an artifact URI
+	SkipURIDedup bool
+	// SkipTagGrouping keeps models whose images are different tags of the same repository
+	SkipTagGrouping bool
+	// InternalRegistries are registry hosts or domains whose artifacts get the "internal"
+	// registrySource
+	InternalRegistries []string
+	// ExcludeLowConfidence leaves out values the provenance reports mark as guesses
+	ExcludeLowConfidence bool
+	// VulnerabilityThreshold excludes models with vulnerabilities of this severity or higher
+	VulnerabilityThreshold string
+	// DescriptionOverridesPath is a YAML file of descriptions and localized descriptions by model
+	// name
+	DescriptionOverridesPath string
+	// LogoDir holds SVG, PNG or JPEG logos replacing the built-in logos with the same name
+	LogoDir string
+	// LogoMappingPath is a provider logo mapping overriding the built-in provider logos
+	LogoMappingPath string
+	// ModelcardTemplatePath is a Go text/template replacing the built-in template of the readmes
+	// generated for models without one
+	ModelcardTemplatePath string
+	// ChunkSize, when positive, also splits catalogs larger than this many bytes into numbered
+	// chunk files with an index next to the catalog
+	ChunkSize int
+	// MarkdownPath, when set, receives a markdown table of the catalog for review in pull requests
+	MarkdownPath string
+	// ChangelogPath, when set, receives a changelog entry when the catalog changed since the
+	// previous run
+	ChangelogPath string
+	// ChangelogSnapshotPath stores the catalog of the previous run the changelog compares with
+	// (default .<catalog name>-snapshot.yaml next to the catalog)
+	ChangelogSnapshotPath string
+	// OnChanges, when set, receives the models added to and removed from the catalog since the
+	// previous run
+	OnChanges func(added, removed []string)
+	// ConfigMapPath, when set, also receives the catalog as Kubernetes ConfigMap manifests
+	ConfigMapPath string
+	// ConfigMapName is the name of the catalog ConfigMaps; chunks are suffixed -1, -2, ...
+	ConfigMapName string
+	// ConfigMapNamespace is set on the catalog ConfigMaps and the ModelCatalogSource when non-empty
+	ConfigMapNamespace string
+	// CatalogSourcePath, when set, also receives the catalog ConfigMaps and an OpenDataHub
+	// ModelCatalogSource referencing them
+	CatalogSourcePath string
+	// CatalogSourceName is the name of the ModelCatalogSource
+	CatalogSourceName string
+	// CatalogSourceLabels are the source labels of the ModelCatalogSource
+	CatalogSourceLabels []string
+	// KServeOutputDir, when set, also receives a ServingRuntime and InferenceService manifest per
+	// model
+	KServeOutputDir string
+	// KServeRuntimeImage is the vLLM image of the ServingRuntimes
+	KServeRuntimeImage string
+	// KServeNamespace is set on the KServe manifests when non-empty
+	KServeNamespace string
+	// PushReference, when set, is the registry reference the catalog and per-model readmes are
+	// also pushed to as an OCI artifact
+	PushReference string
+	// EmbeddingsPath, when set, also receives embeddings of each model's name, description and
+	// readme, computed by the OpenAI-compatible endpoint EmbeddingsURL with EmbeddingsModel
+	EmbeddingsPath   string
+	EmbeddingsURL    string
+	EmbeddingsModel  string
+	EmbeddingsAPIKey string
+	// SearchIndexPath, when set, also receives a full-text search index of the models
+	SearchIndexPath string
+}
+
+// ModelResult is the extraction result of a single model
+type ModelResult struct {
+	// Ref is the models index reference of the model
+	Ref string
+	// ModelCardFound reports whether a modelcard was found; models without one get skeleton
+	// metadata
+	ModelCardFound bool
+}
+
+// DegradedError reports a step that completed with some of its parts failed, e.g. HuggingFace
+// enrichment failing while the OCI artifacts were still updated
+type DegradedError struct {
+	Errors []error
+}
+
+func (e *DegradedError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns the failed parts of the step
+func (e *DegradedError) Unwrap() []error {
+	return e.Errors
+}
+
+// Pipeline runs the steps of a model metadata collection run. The steps share the run's
+// checkpoint and error report.
+type Pipeline struct {
+	opts        Options
+	output      outputfs.FS
+	huggingFace *huggingface.Client
+	registry    registry.Auth
+	verifier    *verification.Verifier
+	scanner     vulnscan.Scanner
+	pyxis       *pyxis.Client
+	logos       *catalog.Logos
+	modelFilter *catalog.ModelFilter
+	template    *catalog.ModelcardTemplate
+	overrides   []catalog.DescriptionOverride
+	checkpoint  *checkpoint.Checkpoint
+	errors      *errorreport.Report
+}
+
+// New creates a pipeline. Verification keys, vulnerability reports, logos, the model filter, the
+// modelcard template and description overrides are loaded up front, so invalid configuration
+// fails before any model is processed.
+func New(opts Options) (*Pipeline, error) {
+	if opts.OutputDir == "" {
+		return nil, errors.New("output directory is required")
+	}
+	if opts.Resume && opts.RetryFailed {
+		return nil, errors.New("resume and retry-failed cannot be combined")
+	}
+
+	p := &Pipeline{
+		opts:   opts,
+		output: outputfs.Dir(opts.OutputDir),
+		huggingFace: huggingface.NewClient(huggingface.Options{
+			Timeout:     opts.HuggingFace.Timeout,
+			Retries:     opts.HuggingFace.Retries,
+			SnapshotDir: opts.HuggingFace.SnapshotDir,
+		}),
+		errors: errorreport.New(),
+	}
+
+	if opts.HuggingFace.SnapshotDir != "" {
+		if info, err := os.Stat(opts.HuggingFace.SnapshotDir); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("HuggingFace snapshot directory %s is not accessible", opts.HuggingFace.SnapshotDir)
+		}
+	}
+
+	for host, creds := range opts.RegistryCredentials {
+		if creds.Username == "" || creds.Token == "" {
+			return nil, fmt.Errorf("credentials of %s must set both a username and a token", host)
+		}
+		if p.registry == nil {
+			p.registry = make(registry.Auth)
+		}
+		p.registry[host] = registry.Credentials{Username: creds.Username, Token: creds.Token}
+	}
+
+	if opts.Extract.VerifyArtifacts {
+		keys, err := verification.LoadPublicKeys(opts.Extract.VerificationKeyPaths)
+		if err != nil {
+			return nil, fmt.Errorf("invalid verification keys: %v", err)
+		}
+		p.verifier = verification.NewVerifier(keys, p.registry)
+	}
+	scanner, err := vulnscan.NewScanner(opts.Extract.VulnScanner, opts.Extract.VulnReportsDir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vulnerability scan configuration: %v", err)
+	}
+	p.scanner = scanner
+
+	if opts.Enrich.RedHatCatalog {
+		client, err := pyxis.NewClient(opts.Enrich.PyxisURL, opts.Enrich.PyxisAPIKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Pyxis URL: %v", err)
+		}
+		p.pyxis = client
+	}
+
+	if err := p.loadCatalogOptions(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// loadCatalogOptions validates the catalog options and loads the files they refer to
+func (p *Pipeline) loadCatalogOptions() error {
+	opts := p.opts.Catalog
+	if opts.Format != "" {
+		if err := catalog.ValidateCatalogFormat(opts.Format); err != nil {
+			return err
+		}
+	}
+	if opts.Validation != "" {
+		if err := catalog.ValidateCatalogValidationMode(opts.Validation); err != nil {
+			return err
+		}
+	}
+	if err := catalog.ValidateRequiredFields(opts.RequiredFields); err != nil {
+		return err
+	}
+	if opts.VulnerabilityThreshold != "" {
+		if err := vulnscan.ValidateSeverity(opts.VulnerabilityThreshold); err != nil {
+			return err
+		}
+	}
+	if opts.EmbeddingsPath != "" && (opts.EmbeddingsURL == "" || opts.EmbeddingsModel == "") {
+		return errors.New("embeddings output requires an embeddings URL and model")
+	}
+
+	logos, err := catalog.LoadLogos(opts.LogoDir, opts.LogoMappingPath)
+	if err != nil {
+		return err
+	}
+	p.logos = logos
+
+	modelFilter, err := catalog.LoadModelFilter(opts.ModelFilterPath)
+	if err != nil {
+		return err
+	}
+	p.modelFilter = modelFilter
+
+	template, err := catalog.LoadModelcardTemplate(opts.ModelcardTemplatePath)
+	if err != nil {
+		return err
+	}
+	p.template = template
+
+	if opts.DescriptionOverridesPath != "" {
+		overrides, err := catalog.LoadDescriptionOverrides(opts.DescriptionOverridesPath)
+		if err != nil {
+			return fmt.Errorf("failed to load description overrides: %v", err)
+		}
+		p.overrides = overrides
+	}
+	return nil
+}
+
+// LoadModels reads the models of a models index file
+func LoadModels(modelsIndexPath string) ([]types.ModelEntry, error) {
+	return extraction.LoadModels(modelsIndexPath)
+}
+
+// ParseLayerAnnotations parses a comma-separated list of key=value layer annotations, as taken by
+// --modelcard-annotations; an annotation given as a bare key accepts any value
+func ParseLayerAnnotations(spec string) ([]LayerAnnotation, error) {
+	parsed, err := extraction.ParseLayerAnnotations(spec)
+	if err != nil {
+		return nil, err
+	}
+	var annotations []LayerAnnotation
+	for _, annotation := range parsed {
+		annotations = append(annotations, LayerAnnotation{Key: annotation.Key, Value: annotation.Value})
+	}
+	return annotations, nil
+}
+
+// ProcessCollections discovers the Red Hat AI validated model collections on HuggingFace and
+// writes their index files, which Enrich matches models against. It does nothing in offline
+// snapshot mode.
+func (p *Pipeline) ProcessCollections(ctx context.Context) error {
+	if p.opts.HuggingFace.SnapshotDir != "" {
+		log.Println("Skipping HuggingFace collection discovery (offline snapshot mode)")
+		return nil
+	}
+	return p.huggingFace.ProcessCollections(ctx)
+}
+
+// Extract pulls the modelcard and metadata of each model image into the output directory and
+// writes manifests.yaml and errors.yaml. When ctx is canceled, the models extracted so far are
+// returned together with the context's error.
+func (p *Pipeline) Extract(ctx context.Context, models []types.ModelEntry) ([]ModelResult, error) {
+	if err := os.MkdirAll(p.opts.OutputDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %v", err)
+	}
+
+	// Rename model directories written before their names carried a digest of the ref
+	var refs []string
+	for _, model := range models {
+		refs = append(refs, model.URI)
+	}
+	migrated, err := outputfs.MigrateModelDirs(p.output, refs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate model output directories: %v", err)
+	}
+	if migrated > 0 {
+		log.Printf("Migrated %d model output directories to digest-suffixed names", migrated)
+	}
+
+	if err := p.openCheckpoint(models); err != nil {
+		return nil, err
+	}
+	pending, resumed := extraction.SplitResumed(models, p.checkpoint)
+	if len(resumed) > 0 {
+		log.Printf("Resuming: %d models were already extracted", len(resumed))
+	}
+	log.Printf("Processing %d models...", len(pending))
+
+	results := extraction.ProcessModels(ctx, pending, extraction.Options{
+		Output:               p.output,
+		MaxConcurrent:        p.opts.MaxConcurrent,
+		MatchThreshold:       p.opts.MatchThreshold,
+		ModelcardAnnotations: p.modelcardAnnotations(),
+		KeepIntermediate:     p.opts.Extract.KeepIntermediate,
+		Verifier:             p.verifier,
+		VulnerabilityScanner: p.scanner,
+		Checkpoint:           p.checkpoint,
+		Errors:               p.errors,
+		HuggingFace:          p.huggingFace,
+		Readme:               p.readmeOptions(),
+		RegistryAuth:         p.registry,
+	})
+	results = append(extraction.ResumedResults(p.output, resumed), results...)
+	p.writeErrorReport()
+
+	// An interrupted run lists the models extracted so far
+	if err := extraction.WriteManifests(p.output, results); err != nil {
+		return modelResults(results), fmt.Errorf("failed to write manifests.yaml: %v", err)
+	}
+	return modelResults(results), ctx.Err()
+}
+
+// openCheckpoint starts the checkpoint completed models are recorded in: the previous run's when
+// resuming, or one in which only the previous run's failed models are pending when retrying them
+func (p *Pipeline) openCheckpoint(models []types.ModelEntry) error {
+	if !p.opts.RetryFailed {
+		cp, err := checkpoint.Open(p.opts.OutputDir, p.opts.Resume)
+		if err != nil {
+			return fmt.Errorf("failed to open checkpoint: %v", err)
+		}
+		p.checkpoint = cp
+		return nil
+	}
+
+	failed, err := errorreport.ReadFailedModels(p.opts.OutputDir)
+	if err != nil {
+		return fmt.Errorf("failed to read the previous run's error report: %v", err)
+	}
+	cp, err := retryCheckpoint(p.opts.OutputDir, models, failed)
+	if err != nil {
+		return fmt.Errorf("failed to open checkpoint: %v", err)
+	}
+	log.Printf("Retrying %d models that failed in the previous run", len(failed))
+	p.checkpoint = cp
+	return nil
+}
+
+// retryCheckpoint starts a checkpoint in which every model except the failed ones has completed all
+// stages, so the resumed pipeline only processes the models the previous run failed on
+func retryCheckpoint(outputDir string, modelEntries []types.ModelEntry, failed []string) (*checkpoint.Checkpoint, error) {
+	cp, err := checkpoint.New(outputDir)
+	if err != nil {
+		return nil, err
+	}
+	retry := make(map[string]bool, len(failed))
+	for _, ref := range failed {
+		retry[ref] = true
+	}
+	for _, entry := range modelEntries {
+		if retry[entry.URI] {
+			continue
+		}
+		if err := cp.MarkComplete(entry.URI); err != nil {
+			return nil, err
+		}
+	}
+	return cp, nil
+}
+
+func modelResults(results []extraction.ModelResult) []ModelResult {
+	converted := make([]ModelResult, len(results))
+	for i, result := range results {
+		converted[i] = ModelResult{Ref: result.Ref, ModelCardFound: result.ModelCardFound}
+	}
+	return converted
+}
+
+// Enrich adds HuggingFace data, OCI artifact metadata and, when configured, Red Hat container
+// catalog records to the extracted models of the models index, then writes their provenance
+// reports and errors.yaml. Parts of enrichment that fail are returned as a *DegradedError after
+// the remaining parts ran. When ctx is canceled, the context's error is returned.
+func (p *Pipeline) Enrich(ctx context.Context) error {
+	if p.opts.ModelsIndexPath == "" {
+		return errors.New("models index is required")
+	}
+	hfIndexPath := p.opts.Enrich.HFIndexPath
+	if hfIndexPath == "" {
+		// Prefer the merged index file so models from all collections are available for matching
+		resolved, err := huggingface.ResolveIndexFile()
+		if err != nil {
+			return fmt.Errorf("could not find any HuggingFace index file: %v", err)
+		}
+		hfIndexPath = resolved
+	}
+	log.Printf("Using HuggingFace index file: %s", hfIndexPath)
+
+	// Enriching without a prior Extract continues the checkpoint of the run being resumed
+	if p.checkpoint == nil && p.opts.Resume {
+		cp, err := checkpoint.Load(p.opts.OutputDir)
+		if err != nil {
+			return err
+		}
+		p.checkpoint = cp
+	}
+
+	enrichOptions := enrichment.Options{
+		Output:         p.output,
+		VLLMConfigDir:  p.opts.Enrich.VLLMConfigDir,
+		MatchThreshold: p.opts.MatchThreshold,
+		MaxConcurrent:  p.opts.MaxConcurrent,
+		Pyxis:          p.pyxis,
+		Checkpoint:     p.checkpoint,
+		Errors:         p.errors,
+		HuggingFace:    p.huggingFace,
+		Readme:         p.readmeOptions(),
+		RegistryAuth:   p.registry,
+	}
+
+	var errs []error
+	if err := enrichment.EnrichMetadataFromHuggingFace(ctx, hfIndexPath, p.opts.ModelsIndexPath, enrichOptions); err != nil {
+		errs = append(errs, fmt.Errorf("enrichment failed: %v", err))
+	}
+	if err := enrichment.UpdateAllModelsWithOCIArtifacts(ctx, p.opts.ModelsIndexPath, enrichOptions); err != nil {
+		errs = append(errs, fmt.Errorf("OCI artifact update failed: %v", err))
+	}
+	// Certified-image records are read after the OCI artifacts they are recorded on
+	if err := enrichment.EnrichMetadataFromPyxis(ctx, p.opts.ModelsIndexPath, enrichOptions); err != nil {
+		errs = append(errs, fmt.Errorf("container catalog enrichment failed: %v", err))
+	}
+	p.writeErrorReport()
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	models, err := extraction.LoadModels(p.opts.ModelsIndexPath)
+	if err != nil {
+		return err
+	}
+	var refs []string
+	for _, model := range models {
+		refs = append(refs, model.URI)
+	}
+	p.WriteProvenanceReports(refs)
+
+	if len(errs) > 0 {
+		return &DegradedError{Errors: errs}
+	}
+	return nil
+}
+
+// WriteProvenanceReports records which source supplied each metadata field of the models, for
+// auditing. Enrich writes them itself; runs that skip enrichment call it directly. A failure is
+// only logged.
+func (p *Pipeline) WriteProvenanceReports(refs []string) {
+	if err := enrichment.WriteProvenanceReports(p.output, refs); err != nil {
+		log.Printf("Warning: Failed to write provenance reports: %v", err)
+	}
+}
+
+// BuildCatalog writes the models catalog from the metadata of modelRefs in the output directory
+// and the static catalogs, validating it against the catalog schema, to the catalog file and the
+// other configured outputs. A nil modelRefs includes the models listed in manifests.yaml. Static
+// catalogs that fail to load are left out and reported as a *DegradedError once the catalog is
+// written. ctx bounds the registry lookups of the static catalogs' artifacts.
+func (p *Pipeline) BuildCatalog(ctx context.Context, modelRefs []string) error {
+	opts := p.opts.Catalog
+	if opts.CatalogPath == "" {
+		return errors.New("catalog path is required")
+	}
+	if opts.Format == "" {
+		opts.Format = catalog.CatalogFormatYAML
+	}
+	if opts.Validation == "" {
+		opts.Validation = catalog.CatalogValidationError
+	}
+
+	if modelRefs == nil {
+		listed, err := manifestRefs(p.opts.OutputDir)
+		if err != nil {
+			return err
+		}
+		modelRefs = listed
+	}
+
+	var errs []error
+	staticModels := []types.CatalogMetadata{}
+	if len(opts.StaticCatalogPaths) > 0 {
+		log.Printf("Loading static catalogs...")
+		loaded, err := catalog.LoadStaticCatalogs(ctx, p.registry, opts.StaticCatalogPaths)
+		if err != nil {
+			log.Printf("Warning: Failed to load static catalogs: %v", err)
+			errs = append(errs, fmt.Errorf("static catalogs not loaded: %v", err))
+		} else {
+			staticModels = loaded
+		}
+	} else {
+		log.Printf("No static catalog files to process")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(opts.CatalogPath), 0755); err != nil {
+		return fmt.Errorf("failed to create catalog output directory: %v", err)
+	}
+
+	log.Printf("Creating models catalog...")
+	err := catalog.CreateModelsCatalogWithOptions(ctx, p.output, opts.CatalogPath, modelRefs, staticModels, p.catalogOptions(opts))
+	if err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return &DegradedError{Errors: errs}
+	}
+	return nil
+}
+
+// catalogOptions returns the options of the catalog package for the models catalog
+func (p *Pipeline) catalogOptions(opts CatalogOptions) catalog.CatalogOptions {
+	catalogOpts := catalog.CatalogOptions{
+		Format:                 opts.Format,
+		Writers:                p.catalogWriters(opts),
+		Validation:             opts.Validation,
+		IncludeLabels:          opts.IncludeLabels,
+		ExcludeLabels:          opts.ExcludeLabels,
+		SkipURIDedup:           opts.SkipURIDedup,
+		SkipTagGrouping:        opts.SkipTagGrouping,
+		InternalRegistries:     opts.InternalRegistries,
+		Logos:                  p.logos,
+		ModelFilter:            p.modelFilter,
+		Readme:                 p.readmeOptions(),
+		ModelcardTemplate:      p.template,
+		RequiredFields:         opts.RequiredFields,
+		ChunkSize:              opts.ChunkSize,
+		MarkdownPath:           opts.MarkdownPath,
+		DescriptionOverrides:   p.overrides,
+		ExcludeLowConfidence:   opts.ExcludeLowConfidence,
+		VulnerabilityThreshold: opts.VulnerabilityThreshold,
+		Changelog: catalog.ChangelogOptions{
+			Path:         opts.ChangelogPath,
+			SnapshotPath: opts.ChangelogSnapshotPath,
+		},
+	}
+	if onChanges := opts.OnChanges; onChanges != nil {
+		catalogOpts.Changelog.OnChanges = func(changes *catalog.CatalogChanges) {
+			onChanges(changes.Added, changes.Removed)
+		}
+	}
+	return catalogOpts
+}
+
+// catalogWriters returns the outputs the models catalog is written to: the catalog file in the
+// selected format, plus the ConfigMap, ModelCatalogSource and KServe manifests, the registry
+// artifact, the embeddings file and the search index when requested
+func (p *Pipeline) catalogWriters(opts CatalogOptions) []catalog.CatalogWriter {
+	writers := []catalog.CatalogWriter{catalog.NewFileWriter(opts.CatalogPath, opts.Format)}
+	if opts.ConfigMapPath != "" {
+		writers = append(writers, catalog.NewConfigMapWriter(catalog.ConfigMapOptions{
+			Path:      opts.ConfigMapPath,
+			Name:      opts.ConfigMapName,
+			Namespace: opts.ConfigMapNamespace,
+		}))
+	}
+	if opts.CatalogSourcePath != "" {
+		writers = append(writers, catalog.NewCatalogSourceWriter(catalog.CatalogSourceOptions{
+			Path:          opts.CatalogSourcePath,
+			Name:          opts.CatalogSourceName,
+			Namespace:     opts.ConfigMapNamespace,
+			Labels:        opts.CatalogSourceLabels,
+			ConfigMapName: opts.ConfigMapName,
+			Image:         opts.PushReference,
+		}))
+	}
+	if opts.KServeOutputDir != "" {
+		writers = append(writers, catalog.NewKServeWriter(catalog.KServeOptions{
+			Dir:          opts.KServeOutputDir,
+			RuntimeImage: opts.KServeRuntimeImage,
+			Namespace:    opts.KServeNamespace,
+		}))
+	}
+	if opts.PushReference != "" {
+		pushWriter := catalog.NewOCIWriter(opts.PushReference, filepath.Base(opts.CatalogPath), opts.Format)
+		pushWriter.ReadmesDir = p.opts.OutputDir
+		writers = append(writers, pushWriter)
+	}
+	if opts.EmbeddingsPath != "" {
+		client := embeddings.NewClient(opts.EmbeddingsURL, opts.EmbeddingsModel, opts.EmbeddingsAPIKey)
+		writers = append(writers, catalog.NewEmbeddingsWriter(opts.EmbeddingsPath, client))
+	}
+	if opts.SearchIndexPath != "" {
+		writers = append(writers, catalog.NewSearchIndexWriter(opts.SearchIndexPath))
+	}
+	return writers
+}
+
+// modelcardAnnotations converts the modelcard layer annotations for the extraction package
+func (p *Pipeline) modelcardAnnotations() []extraction.LayerAnnotation {
+	var annotations []extraction.LayerAnnotation
+	for _, annotation := range p.opts.Extract.ModelcardAnnotations {
+		annotations = append(annotations, extraction.LayerAnnotation{Key: annotation.Key, Value: annotation.Value})
+	}
+	return annotations
+}
+
+// readmeOptions converts the readme options for the internal packages
+func (p *Pipeline) readmeOptions() metadata.ReadmeOptions {
+	return metadata.ReadmeOptions{Skip: p.opts.Readme.Skip, MaxSize: p.opts.Readme.MaxSize}
+}
+
+// writeErrorReport writes errors.yaml with the failures of the run so far; a failure is only
+// logged
+func (p *Pipeline) writeErrorReport() {
+	if err := p.errors.Write(p.opts.OutputDir); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+}
+
+// manifestRefs returns the models listed in manifests.yaml of the output directory
+func manifestRefs(outputDir string) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(outputDir, "manifests.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("error reading manifests.yaml: %v", err)
+	}
+	var manifests types.ManifestsData
+	if err := yaml.Unmarshal(data, &manifests); err != nil {
+		return nil, fmt.Errorf("error parsing manifests.yaml: %v", err)
+	}
+	var refs []string
+	for _, manifest := range manifests.Models {
+		refs = append(refs, manifest.Ref)
+	}
+	return refs, nil
+}
@@ -0,0 +1,146 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/opendatahub-io/model-metadata-collection/internal/catalog"
+	"github.com/opendatahub-io/model-metadata-collection/internal/checkpoint"
+	"github.com/opendatahub-io/model-metadata-collection/internal/extraction"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/types"
+	"github.com/opendatahub-io/model-metadata-collection/pkg/utils"
+)
+
+func TestExtract_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	outputDir := t.TempDir()
+
+	models := []types.ModelEntry{{URI: "registry.example.com/org/model:1.0"}}
+	p, err := New(Options{OutputDir: outputDir, MaxConcurrent: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err := p.Extract(ctx, models)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the cancellation to be reported, got %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("Expected no models to be extracted, got %d", len(results))
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "manifests.yaml")); err != nil {
+		t.Errorf("Expected manifests.yaml to be written: %v", err)
+	}
+}
+
+func TestBuildCatalog(t *testing.T) {
+	outputDir := t.TempDir()
+	ref := "registry.example.com/org/model:1.0"
+	name, provider := "org/model", "Org"
+	metadata := types.ExtractedMetadata{Name: &name, Provider: &provider, Artifacts: []types.OCIArtifact{{URI: "oci://" + ref}}}
+
+	modelDir := filepath.Join(outputDir, utils.SanitizeManifestRef(ref), "models")
+	if err := os.MkdirAll(modelDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	data, err := yaml.Marshal(&metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(modelDir, "metadata.yaml"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	manifests := "models:\n  - ref: " + ref + "\n    modelcard:\n      present: true\n"
+	if err := os.WriteFile(filepath.Join(outputDir, "manifests.yaml"), []byte(manifests), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The models default to those listed in manifests.yaml
+	catalogPath := filepath.Join(t.TempDir(), "data", "models-catalog.json")
+	p, err := New(Options{OutputDir: outputDir, Catalog: CatalogOptions{CatalogPath: catalogPath, Format: catalog.CatalogFormatJSON}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.BuildCatalog(context.Background(), nil); err != nil {
+		t.Fatalf("BuildCatalog() error = %v", err)
+	}
+	built, err := catalog.ReadCatalog(catalogPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(built.Models) != 1 || built.Models[0].Name == nil || *built.Models[0].Name != name {
+		t.Errorf("Expected the catalog to contain %s, got %+v", name, built.Models)
+	}
+
+	p.opts.Catalog.CatalogPath = ""
+	if err := p.BuildCatalog(context.Background(), nil); err == nil {
+		t.Error("Expected an error without a catalog path")
+	}
+}
+
+func TestNew_InvalidOptions(t *testing.T) {
+	outputDir := t.TempDir()
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{"no output directory", Options{}},
+		{"resume and retry-failed", Options{OutputDir: outputDir, Resume: true, RetryFailed: true}},
+		{"registry credentials without a token", Options{OutputDir: outputDir, RegistryCredentials: map[string]RegistryCredentials{RedHatRegistry: {Username: "12345678|ci"}}}},
+		{"missing snapshot directory", Options{OutputDir: outputDir, HuggingFace: HuggingFaceOptions{SnapshotDir: filepath.Join(outputDir, "missing")}}},
+		{"catalog format", Options{OutputDir: outputDir, Catalog: CatalogOptions{Format: "xml"}}},
+		{"required field", Options{OutputDir: outputDir, Catalog: CatalogOptions{RequiredFields: []string{"color"}}}},
+		{"model filter", Options{OutputDir: outputDir, Catalog: CatalogOptions{ModelFilterPath: filepath.Join(outputDir, "missing.yaml")}}},
+		{"embeddings without endpoint", Options{OutputDir: outputDir, Catalog: CatalogOptions{EmbeddingsPath: filepath.Join(outputDir, "embeddings.json")}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.opts); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestRetryCheckpoint(t *testing.T) {
+	tmpDir := t.TempDir()
+	entries := []types.ModelEntry{{URI: "registry.example.com/ok:1"}, {URI: "registry.example.com/failed:1"}}
+	cp, err := retryCheckpoint(tmpDir, entries, []string{"registry.example.com/failed:1", "registry.example.com/removed:1"})
+	if err != nil {
+		t.Fatalf("retryCheckpoint failed: %v", err)
+	}
+
+	if !cp.Done("registry.example.com/ok:1", checkpoint.StageArtifacts) {
+		t.Error("Expected the model that did not fail to keep its output")
+	}
+	pending, _ := extraction.SplitResumed(entries, cp)
+	if len(pending) != 1 || pending[0].URI != "registry.example.com/failed:1" {
+		t.Errorf("Expected only the failed model to be processed, got %v", pending)
+	}
+}
+
+func TestParseLayerAnnotations(t *testing.T) {
+	annotations, err := ParseLayerAnnotations("io.opendatahub.modelcar.layer.type=modelcard, org.example.readme")
+	if err != nil {
+		t.Fatalf("ParseLayerAnnotations() error = %v", err)
+	}
+	want := []LayerAnnotation{{Key: "io.opendatahub.modelcar.layer.type", Value: "modelcard"}, {Key: "org.example.readme"}}
+	if !reflect.DeepEqual(annotations, want) {
+		t.Errorf("ParseLayerAnnotations() = %v, want %v", annotations, want)
+	}
+	if _, err := ParseLayerAnnotations("=modelcard"); err == nil {
+		t.Error("Expected an error for an annotation without a key")
+	}
+
+	p := &Pipeline{opts: Options{Extract: ExtractOptions{ModelcardAnnotations: annotations}}}
+	converted := p.modelcardAnnotations()
+	if len(converted) != 2 || converted[0] != extraction.DefaultModelcardAnnotation || converted[1].Key != "org.example.readme" {
+		t.Errorf("modelcardAnnotations() = %v", converted)
+	}
+}
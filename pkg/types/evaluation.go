@@ -0,0 +1,76 @@
+package types
+
+import (
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Evaluation is a single benchmark result reported for a model
+type Evaluation struct {
+	Benchmark string  `yaml:"benchmark"`
+	Metric    string  `yaml:"metric"`
+	Score     float64 `yaml:"score"`
+	Task      string  `yaml:"task,omitempty"`
+	Source    string  `yaml:"source,omitempty"`
+}
+
+// HFModelIndex is the `model-index` card metadata. Model cards frequently contain hand-written,
+// malformed model-index blocks, so decoding errors leave it empty instead of failing the
+// surrounding card or API response.
+type HFModelIndex []HFModelIndexEntry
+
+// UnmarshalJSON decodes a model-index, ignoring malformed content
+func (m *HFModelIndex) UnmarshalJSON(data []byte) error {
+	var entries []HFModelIndexEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		*m = nil
+		return nil
+	}
+	*m = entries
+	return nil
+}
+
+// UnmarshalYAML decodes a model-index, ignoring malformed content
+func (m *HFModelIndex) UnmarshalYAML(value *yaml.Node) error {
+	var entries []HFModelIndexEntry
+	if err := value.Decode(&entries); err != nil {
+		*m = nil
+		return nil
+	}
+	*m = entries
+	return nil
+}
+
+// HFModelIndexEntry is an entry of the `model-index` card metadata used by HuggingFace
+// (and the Open LLM Leaderboard) to publish evaluation results
+type HFModelIndexEntry struct {
+	Name    string         `json:"name" yaml:"name"`
+	Results []HFEvalResult `json:"results" yaml:"results"`
+}
+
+// HFEvalResult groups the metrics measured for one task/dataset pair
+type HFEvalResult struct {
+	Task struct {
+		Type string `json:"type" yaml:"type"`
+		Name string `json:"name" yaml:"name"`
+	} `json:"task" yaml:"task"`
+	Dataset struct {
+		Name   string `json:"name" yaml:"name"`
+		Type   string `json:"type" yaml:"type"`
+		Config string `json:"config" yaml:"config"`
+		Split  string `json:"split" yaml:"split"`
+	} `json:"dataset" yaml:"dataset"`
+	Metrics []HFEvalMetric `json:"metrics" yaml:"metrics"`
+	Source  struct {
+		Name string `json:"name" yaml:"name"`
+		URL  string `json:"url" yaml:"url"`
+	} `json:"source" yaml:"source"`
+}
+
+// HFEvalMetric is a single metric value; Value may be a number or a string such as "45.2"
+type HFEvalMetric struct {
+	Type  string      `json:"type" yaml:"type"`
+	Name  string      `json:"name" yaml:"name"`
+	Value interface{} `json:"value" yaml:"value"`
+}
@@ -0,0 +1,16 @@
+package types
+
+// HardwareRequirements describes the hardware a model needs to be served, as stated in its modelcard
+type HardwareRequirements struct {
+	// MinGPUMemoryGB is the minimum total GPU memory, in GB
+	MinGPUMemoryGB float64 `yaml:"minGpuMemoryGB,omitempty"`
+	// RecommendedAcceleratorCount is the number of GPUs or other accelerators to deploy on
+	RecommendedAcceleratorCount int `yaml:"recommendedAcceleratorCount,omitempty"`
+	// Accelerators lists the accelerator models the card names, e.g. H100 or MI300X
+	Accelerators []string `yaml:"accelerators,omitempty"`
+}
+
+// IsEmpty reports whether no requirement is set
+func (h *HardwareRequirements) IsEmpty() bool {
+	return h == nil || (h.MinGPUMemoryGB == 0 && h.RecommendedAcceleratorCount == 0 && len(h.Accelerators) == 0)
+}
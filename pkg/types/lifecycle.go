@@ -0,0 +1,30 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// EndOfLifeDateFormat is the layout of endOfLife dates (YYYY-MM-DD)
+const EndOfLifeDateFormat = "2006-01-02"
+
+// ValidateEndOfLife validates that an endOfLife value is a YYYY-MM-DD date
+func ValidateEndOfLife(endOfLife string) error {
+	if _, err := time.Parse(EndOfLifeDateFormat, endOfLife); err != nil {
+		return fmt.Errorf("invalid endOfLife: %q (expected a YYYY-MM-DD date)", endOfLife)
+	}
+	return nil
+}
+
+// ValidateLifecycle validates the lifecycle fields shared by index entries and catalog models
+func ValidateLifecycle(endOfLife, replacedBy *string) error {
+	if endOfLife != nil {
+		if err := ValidateEndOfLife(*endOfLife); err != nil {
+			return err
+		}
+	}
+	if replacedBy != nil && *replacedBy == "" {
+		return fmt.Errorf("invalid replacedBy: must not be empty when set")
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package types
+
+import "testing"
+
+func TestValidateLifecycle(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	tests := []struct {
+		name       string
+		endOfLife  *string
+		replacedBy *string
+		wantErr    bool
+	}{
+		{"unset", nil, nil, false},
+		{"valid date and replacement", str("2026-03-31"), str("RedHatAI/granite-3.3-8b-instruct"), false},
+		{"invalid date format", str("31/03/2026"), nil, true},
+		{"impossible date", str("2026-02-30"), nil, true},
+		{"empty replacement", nil, str(""), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLifecycle(tt.endOfLife, tt.replacedBy)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateLifecycle() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
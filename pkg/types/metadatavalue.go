@@ -0,0 +1,79 @@
+package types
+
+import (
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Metadata value types of the model-registry metadata type system
+const (
+	MetadataTypeString = "MetadataStringValue"
+	MetadataTypeInt    = "MetadataIntValue"
+	MetadataTypeDouble = "MetadataDoubleValue"
+	MetadataTypeBool   = "MetadataBoolValue"
+)
+
+// MetadataValue represents a metadata value with type information. Only the field matching
+// MetadataType is meaningful; int values are kept as strings like the model registry's int64 values.
+type MetadataValue struct {
+	MetadataType string  `yaml:"metadataType"`
+	StringValue  string  `yaml:"string_value,omitempty"`
+	IntValue     string  `yaml:"int_value,omitempty"`
+	DoubleValue  float64 `yaml:"double_value,omitempty"`
+	BoolValue    bool    `yaml:"bool_value,omitempty"`
+}
+
+// NewStringValue creates a MetadataStringValue
+func NewStringValue(value string) MetadataValue {
+	return MetadataValue{MetadataType: MetadataTypeString, StringValue: value}
+}
+
+// NewIntValue creates a MetadataIntValue
+func NewIntValue(value int64) MetadataValue {
+	return MetadataValue{MetadataType: MetadataTypeInt, IntValue: strconv.FormatInt(value, 10)}
+}
+
+// NewDoubleValue creates a MetadataDoubleValue
+func NewDoubleValue(value float64) MetadataValue {
+	return MetadataValue{MetadataType: MetadataTypeDouble, DoubleValue: value}
+}
+
+// NewBoolValue creates a MetadataBoolValue
+func NewBoolValue(value bool) MetadataValue {
+	return MetadataValue{MetadataType: MetadataTypeBool, BoolValue: value}
+}
+
+// MarshalYAML implements yaml.Marshaler to emit only the value field matching the metadata type,
+// forcing non-empty string and int values to be quoted
+func (mv MetadataValue) MarshalYAML() (interface{}, error) {
+	result := map[string]interface{}{
+		"metadataType": mv.MetadataType,
+	}
+
+	switch mv.MetadataType {
+	case MetadataTypeInt:
+		result["int_value"] = quotedNode(mv.IntValue)
+	case MetadataTypeDouble:
+		result["double_value"] = mv.DoubleValue
+	case MetadataTypeBool:
+		result["bool_value"] = mv.BoolValue
+	default:
+		// Force string_value to be quoted by using a yaml.Node with style set to DoubleQuotedStyle
+		if mv.StringValue != "" {
+			result["string_value"] = quotedNode(mv.StringValue)
+		} else {
+			result["string_value"] = mv.StringValue
+		}
+	}
+
+	return result, nil
+}
+
+func quotedNode(value string) *yaml.Node {
+	return &yaml.Node{
+		Kind:  yaml.ScalarNode,
+		Value: value,
+		Style: yaml.DoubleQuotedStyle,
+	}
+}
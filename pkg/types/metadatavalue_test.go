@@ -0,0 +1,71 @@
+package types
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestMetadataValueMarshalYAML(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    MetadataValue
+		expected string
+	}{
+		{
+			name:     "empty string",
+			value:    NewStringValue(""),
+			expected: "metadataType: MetadataStringValue\nstring_value: \"\"\n",
+		},
+		{
+			name:     "string",
+			value:    NewStringValue("1.5"),
+			expected: "metadataType: MetadataStringValue\nstring_value: \"1.5\"\n",
+		},
+		{
+			name:     "int",
+			value:    NewIntValue(8030261248),
+			expected: "int_value: \"8030261248\"\nmetadataType: MetadataIntValue\n",
+		},
+		{
+			name:     "double",
+			value:    NewDoubleValue(0.75),
+			expected: "double_value: 0.75\nmetadataType: MetadataDoubleValue\n",
+		},
+		{
+			name:     "bool",
+			value:    NewBoolValue(false),
+			expected: "bool_value: false\nmetadataType: MetadataBoolValue\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := yaml.Marshal(tc.value)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			if string(data) != tc.expected {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tc.expected, data)
+			}
+
+			var decoded MetadataValue
+			if err := yaml.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if decoded != tc.value {
+				t.Errorf("Expected round trip to give %+v, got %+v", tc.value, decoded)
+			}
+		})
+	}
+}
+
+func TestMetadataValueUnmarshalUnquotedInt(t *testing.T) {
+	var value MetadataValue
+	if err := yaml.Unmarshal([]byte("metadataType: MetadataIntValue\nint_value: 42\n"), &value); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if value != NewIntValue(42) {
+		t.Errorf("Expected %+v, got %+v", NewIntValue(42), value)
+	}
+}
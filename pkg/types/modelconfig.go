@@ -0,0 +1,38 @@
+package types
+
+// ModelConfig describes a model's architecture as declared in the transformers config.json and
+// generation_config.json shipped with its weights
+type ModelConfig struct {
+	// Architecture is the first entry of "architectures", e.g. LlamaForCausalLM
+	Architecture string `yaml:"architecture,omitempty"`
+	// ModelType is the transformers model family, e.g. llama or qwen2
+	ModelType             string       `yaml:"modelType,omitempty"`
+	VocabSize             int64        `yaml:"vocabSize,omitempty"`
+	HiddenSize            int64        `yaml:"hiddenSize,omitempty"`
+	NumHiddenLayers       int64        `yaml:"numHiddenLayers,omitempty"`
+	MaxPositionEmbeddings int64        `yaml:"maxPositionEmbeddings,omitempty"`
+	TorchDtype            string       `yaml:"torchDtype,omitempty"`
+	RopeTheta             float64      `yaml:"ropeTheta,omitempty"`
+	RopeScaling           *RopeScaling `yaml:"ropeScaling,omitempty"`
+	// GenerationDefaults are the sampling defaults from generation_config.json
+	GenerationDefaults *GenerationDefaults `yaml:"generationDefaults,omitempty"`
+}
+
+// RopeScaling describes how rotary position embeddings are scaled to extend the context window
+type RopeScaling struct {
+	Type                          string  `yaml:"type,omitempty"`
+	Factor                        float64 `yaml:"factor,omitempty"`
+	OriginalMaxPositionEmbeddings int64   `yaml:"originalMaxPositionEmbeddings,omitempty"`
+}
+
+// GenerationDefaults are the default sampling parameters a model is published with
+type GenerationDefaults struct {
+	Temperature float64 `yaml:"temperature,omitempty"`
+	TopP        float64 `yaml:"topP,omitempty"`
+	TopK        int64   `yaml:"topK,omitempty"`
+}
+
+// IsEmpty reports whether no configuration value is set
+func (c *ModelConfig) IsEmpty() bool {
+	return c == nil || *c == (ModelConfig{})
+}
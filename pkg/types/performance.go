@@ -0,0 +1,23 @@
+package types
+
+// PerformanceInfo describes the serving performance a model image was benchmarked at, as declared
+// by the image's performance annotations
+type PerformanceInfo struct {
+	// TokensPerSecond is the output token throughput
+	TokensPerSecond float64 `yaml:"tokensPerSecond,omitempty"`
+	// TimeToFirstTokenMs is the latency until the first output token, in milliseconds
+	TimeToFirstTokenMs float64 `yaml:"timeToFirstTokenMs,omitempty"`
+	// InterTokenLatencyMs is the latency between output tokens, in milliseconds
+	InterTokenLatencyMs float64 `yaml:"interTokenLatencyMs,omitempty"`
+	// RequestLatencyMs is the end-to-end latency of a request, in milliseconds
+	RequestLatencyMs float64 `yaml:"requestLatencyMs,omitempty"`
+	// Hardware is the reference hardware of the benchmark, e.g. "1x NVIDIA H100 80GB"
+	Hardware string `yaml:"hardware,omitempty"`
+	// Concurrency is the number of concurrent requests of the benchmark
+	Concurrency int64 `yaml:"concurrency,omitempty"`
+}
+
+// IsEmpty reports whether no performance value is set
+func (p *PerformanceInfo) IsEmpty() bool {
+	return p == nil || *p == (PerformanceInfo{})
+}
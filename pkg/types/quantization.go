@@ -0,0 +1,12 @@
+package types
+
+// QuantizationFormatGGUF identifies quantization details read from a GGUF file header
+const QuantizationFormatGGUF = "gguf"
+
+// QuantizationInfo describes how a model's weights are quantized
+type QuantizationInfo struct {
+	Format         string `yaml:"format"`
+	Type           string `yaml:"type,omitempty"`
+	ParameterCount int64  `yaml:"parameterCount,omitempty"`
+	ContextLength  int64  `yaml:"contextLength,omitempty"`
+}
@@ -0,0 +1,12 @@
+package types
+
+// RedHatCatalogInfo is the Red Hat container catalog (Pyxis) record of a certified image
+type RedHatCatalogInfo struct {
+	// FreshnessGrade is the current health index of the image, from A (no unapplied security
+	// fixes) to F
+	FreshnessGrade string `yaml:"freshnessGrade,omitempty"`
+	// Advisories link the errata that shipped the image
+	Advisories []string `yaml:"advisories,omitempty"`
+	// CatalogURL is the page of the image's repository on catalog.redhat.com
+	CatalogURL string `yaml:"catalogURL,omitempty"`
+}
@@ -0,0 +1,18 @@
+package types
+
+// ResponsibleUse holds the responsible-AI sections of a modelcard, each as markdown text
+type ResponsibleUse struct {
+	// IntendedUse describes the uses the model was built for ("Intended Use", "Uses", "Use Cases")
+	IntendedUse string `yaml:"intendedUse,omitempty"`
+	// Limitations describes known limitations and out-of-scope uses
+	Limitations string `yaml:"limitations,omitempty"`
+	// Risks describes biases and risks of using the model
+	Risks string `yaml:"risks,omitempty"`
+	// EthicalConsiderations holds the card's ethical considerations
+	EthicalConsiderations string `yaml:"ethicalConsiderations,omitempty"`
+}
+
+// IsEmpty reports whether no section is set
+func (r *ResponsibleUse) IsEmpty() bool {
+	return r == nil || (r.IntendedUse == "" && r.Limitations == "" && r.Risks == "" && r.EthicalConsiderations == "")
+}
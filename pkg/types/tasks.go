@@ -0,0 +1,106 @@
+package types
+
+import "strings"
+
+// Tasks of the canonical task vocabulary. Catalog consumers filter and group models by these
+// strings, so extracted and enriched tasks are normalized onto them.
+const (
+	TaskTextGeneration      = "text-generation"
+	TaskTextToText          = "text-to-text"
+	TaskTextClassification  = "text-classification"
+	TaskQuestionAnswering   = "question-answering"
+	TaskTextEmbedding       = "text-embedding"
+	TaskSentenceSimilarity  = "sentence-similarity"
+	TaskTextRanking         = "text-ranking"
+	TaskToolCalling         = "tool-calling"
+	TaskImageClassification = "image-classification"
+	TaskImageToText         = "image-to-text"
+	TaskImageTextToText     = "image-text-to-text"
+	TaskImageToImage        = "image-to-image"
+	TaskAudioToText         = "audio-to-text"
+	TaskTextToAudio         = "text-to-audio"
+	TaskVideoToText         = "video-to-text"
+	TaskVideoUnderstanding  = "video-understanding"
+	TaskTextToVideo         = "text-to-video"
+	TaskVideoToVideo        = "video-to-video"
+	TaskAnyToAny            = "any-to-any"
+)
+
+// supportedTasks lists the canonical tasks in documentation order
+var supportedTasks = []string{
+	TaskTextGeneration, TaskTextToText, TaskTextClassification, TaskQuestionAnswering,
+	TaskTextEmbedding, TaskSentenceSimilarity, TaskTextRanking, TaskToolCalling,
+	TaskImageClassification, TaskImageToText, TaskImageTextToText, TaskImageToImage,
+	TaskAudioToText, TaskTextToAudio, TaskVideoToText, TaskVideoUnderstanding,
+	TaskTextToVideo, TaskVideoToVideo, TaskAnyToAny,
+}
+
+// taskAliases maps task names used by HuggingFace pipeline tags and modelcards onto the
+// canonical task they describe
+var taskAliases = map[string]string{
+	"text2text-generation":         TaskTextToText,
+	"text-to-text-generation":      TaskTextToText,
+	"conversational":               TaskTextGeneration,
+	"chat":                         TaskTextGeneration,
+	"feature-extraction":           TaskTextEmbedding,
+	"embedding":                    TaskTextEmbedding,
+	"embeddings":                   TaskTextEmbedding,
+	"text-embeddings":              TaskTextEmbedding,
+	"sentence-embedding":           TaskTextEmbedding,
+	"reranking":                    TaskTextRanking,
+	"function-calling":             TaskToolCalling,
+	"automatic-speech-recognition": TaskAudioToText,
+	"speech-recognition":           TaskAudioToText,
+	"speech-to-text":               TaskAudioToText,
+	"text-to-speech":               TaskTextToAudio,
+	"image-captioning":             TaskImageToText,
+	"visual-question-answering":    TaskImageTextToText,
+	"video-text-to-text":           TaskVideoToText,
+}
+
+// SupportedTasks returns the canonical task vocabulary
+func SupportedTasks() []string {
+	return append([]string(nil), supportedTasks...)
+}
+
+// IsSupportedTask reports whether task is part of the canonical task vocabulary
+func IsSupportedTask(task string) bool {
+	for _, supported := range supportedTasks {
+		if task == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeTask maps a task onto the canonical vocabulary, ignoring case, surrounding whitespace
+// and the separator between words. Unknown tasks are returned in lowercase so catalog
+// validation can report them.
+func NormalizeTask(task string) string {
+	normalized := strings.Join(strings.FieldsFunc(strings.ToLower(task), func(r rune) bool {
+		return r == ' ' || r == '_' || r == '-'
+	}), "-")
+	if canonical, ok := taskAliases[normalized]; ok {
+		return canonical
+	}
+	return normalized
+}
+
+// NormalizeTasks normalizes every task, dropping empty values and duplicates while keeping the
+// order in which tasks first appear
+func NormalizeTasks(tasks []string) []string {
+	if tasks == nil {
+		return nil
+	}
+	normalized := make([]string, 0, len(tasks))
+	seen := make(map[string]bool, len(tasks))
+	for _, task := range tasks {
+		task = NormalizeTask(task)
+		if task == "" || seen[task] {
+			continue
+		}
+		seen[task] = true
+		normalized = append(normalized, task)
+	}
+	return normalized
+}
@@ -0,0 +1,45 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeTasks(t *testing.T) {
+	tests := []struct {
+		name     string
+		tasks    []string
+		expected []string
+	}{
+		{"canonical tasks", []string{"text-generation", "image-text-to-text"}, []string{"text-generation", "image-text-to-text"}},
+		{"case and separators", []string{"Text Generation", "audio_to_text"}, []string{"text-generation", "audio-to-text"}},
+		{"pipeline tag aliases", []string{"feature-extraction", "automatic-speech-recognition"}, []string{"text-embedding", "audio-to-text"}},
+		{"duplicates after normalization", []string{"embedding", "text-embedding", " "}, []string{"text-embedding"}},
+		{"unknown task kept", []string{"Protein-Folding"}, []string{"protein-folding"}},
+		{"nil", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeTasks(tt.tasks); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("NormalizeTasks(%v) = %v, expected %v", tt.tasks, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSupportedTasks(t *testing.T) {
+	for _, task := range SupportedTasks() {
+		if !IsSupportedTask(task) || NormalizeTask(task) != task {
+			t.Errorf("Supported task %q does not normalize onto itself", task)
+		}
+	}
+	for alias, canonical := range taskAliases {
+		if !IsSupportedTask(canonical) {
+			t.Errorf("Alias %q maps onto unsupported task %q", alias, canonical)
+		}
+	}
+	if IsSupportedTask("feature-extraction") {
+		t.Error("Expected aliases not to be supported tasks")
+	}
+}
@@ -3,8 +3,6 @@ package types
 import (
 	"fmt"
 	"time"
-
-	"gopkg.in/yaml.v3"
 )
 
 // Model type constants
@@ -14,12 +12,35 @@ const (
 	ModelTypeUnknown    = "unknown"
 )
 
+// Types of models index entries
+const (
+	// ModelEntryTypeOCI is the type of modelcar images in a container registry
+	ModelEntryTypeOCI = "oci"
+	// ModelEntryTypeHTTPS is the type of weights downloadable over HTTPS, such as a .gguf or
+	// .safetensors URL
+	ModelEntryTypeHTTPS = "https"
+	// ModelEntryTypeS3 is the type of weights stored as objects in an S3 bucket
+	ModelEntryTypeS3 = "s3"
+	// ModelEntryTypeHF is the type of HuggingFace model repositories, whose metadata all comes
+	// from enrichment
+	ModelEntryTypeHF = "hf"
+)
+
+// ModelEntryTypes lists the supported models index entry types
+var ModelEntryTypes = []string{ModelEntryTypeOCI, ModelEntryTypeHTTPS, ModelEntryTypeS3, ModelEntryTypeHF}
+
+// KnownModelLabels are the labels models index entries may carry
+var KnownModelLabels = []string{"validated", "featured", "lab-teacher", "lab-base", "experimental"}
+
 // ModelEntry represents a single model entry in the models index
 type ModelEntry struct {
-	Type      string   `yaml:"type"`       // "oci" for registry-based modelcar or "hf" for HuggingFace models
-	URI       string   `yaml:"uri"`        // OCI link or HuggingFace link
-	Labels    []string `yaml:"labels"`     // Labels for the model (e.g., "validated", "featured", "lab-teacher", "lab-base")
-	ModelType string   `yaml:"model_type"` // Model type: "generative", "predictive", or "unknown" (defaults to "generative" if omitted)
+	Type       string   `yaml:"type"`                 // One of ModelEntryTypes, e.g. ModelEntryTypeOCI for registry-based modelcars
+	URI        string   `yaml:"uri"`                  // OCI image reference, https:// URL, s3:// or hf:// URI, depending on the type
+	Labels     []string `yaml:"labels"`               // Labels for the model (e.g., "validated", "featured", "lab-teacher", "lab-base")
+	ModelType  string   `yaml:"model_type"`           // Model type: "generative", "predictive", or "unknown" (defaults to "generative" if omitted)
+	Deprecated bool     `yaml:"deprecated,omitempty"` // Model is being retired from the collection
+	EndOfLife  *string  `yaml:"endOfLife,omitempty"`  // Date (YYYY-MM-DD) after which the model is no longer supported
+	ReplacedBy *string  `yaml:"replacedBy,omitempty"` // Name of the model that supersedes this one
 }
 
 // ModelsConfig represents the configuration of models to process
@@ -44,18 +65,30 @@ type HFModel struct {
 
 // HuggingFace model details
 type HFModelDetails struct {
-	ID           string    `json:"id"`
-	Author       string    `json:"author"`
-	Sha          string    `json:"sha"`
-	Downloads    int       `json:"downloads"`
-	Likes        int       `json:"likes"`
-	Private      bool      `json:"private"`
-	Gated        bool      `json:"gated"`
-	Tags         []string  `json:"tags"`
-	Description  string    `json:"description"`
-	License      string    `json:"license"`
-	CreatedAt    time.Time `json:"createdAt"`
-	LastModified string    `json:"lastModified"`
+	ID           string      `json:"id"`
+	Author       string      `json:"author"`
+	Sha          string      `json:"sha"`
+	Downloads    int         `json:"downloads"`
+	Likes        int         `json:"likes"`
+	Private      bool        `json:"private"`
+	Gated        bool        `json:"gated"`
+	Tags         []string    `json:"tags"`
+	Description  string      `json:"description"`
+	License      string      `json:"license"`
+	CreatedAt    time.Time   `json:"createdAt"`
+	LastModified string      `json:"lastModified"`
+	Siblings     []HFSibling `json:"siblings,omitempty"`
+	CardData     HFCardData  `json:"cardData"`
+}
+
+// HFCardData holds the parsed model card metadata returned by the HuggingFace API
+type HFCardData struct {
+	ModelIndex HFModelIndex `json:"model-index"`
+}
+
+// HFSibling is a file in a HuggingFace model repository
+type HFSibling struct {
+	RFilename string `json:"rfilename"`
 }
 
 // Version-specific index structures
@@ -76,26 +109,49 @@ type OCIArtifact struct {
 	CreateTimeSinceEpoch     *int64                 `yaml:"createTimeSinceEpoch"`
 	LastUpdateTimeSinceEpoch *int64                 `yaml:"lastUpdateTimeSinceEpoch"`
 	CustomProperties         map[string]interface{} `yaml:"customProperties,omitempty"`
+	Verification             *VerificationStatus    `yaml:"verification,omitempty"`
+	Vulnerabilities          *VulnerabilitySummary  `yaml:"vulnerabilities,omitempty"`
+	RedHatCatalog            *RedHatCatalogInfo     `yaml:"redHatCatalog,omitempty"`
 }
 
 // ExtractedMetadata represents the actual extracted values from the modelcard
 type ExtractedMetadata struct {
-	Name                     *string            `yaml:"name"`
-	Provider                 *string            `yaml:"provider"`
-	Description              *string            `yaml:"description"`
-	Readme                   *string            `yaml:"readme"`
-	Language                 []string           `yaml:"language"`
-	License                  *string            `yaml:"license"`
-	LicenseLink              *string            `yaml:"licenseLink"`
-	Tags                     []string           `yaml:"tags"`
-	Tasks                    []string           `yaml:"tasks"`
-	CreateTimeSinceEpoch     *int64             `yaml:"createTimeSinceEpoch"`
-	LastUpdateTimeSinceEpoch *int64             `yaml:"lastUpdateTimeSinceEpoch"`
-	ValidatedOn              []string           `yaml:"validatedOn"`
-	HardwareTag              []string           `yaml:"hardwareTag"`
-	ValidatedTasks           []string           `yaml:"validatedTasks,omitempty"`
-	ToolCallingConfig        *ToolCallingConfig `yaml:"toolCallingConfig,omitempty"`
-	Artifacts                []OCIArtifact      `yaml:"artifacts"`
+	Name                     *string               `yaml:"name"`
+	Provider                 *string               `yaml:"provider"`
+	Description              *string               `yaml:"description"`
+	Readme                   *string               `yaml:"readme"`
+	Language                 []string              `yaml:"language"`
+	License                  *string               `yaml:"license"`
+	LicenseLink              *string               `yaml:"licenseLink"`
+	Tags                     []string              `yaml:"tags"`
+	Tasks                    []string              `yaml:"tasks"`
+	CardLanguage             string                `yaml:"cardLanguage,omitempty"`
+	CreateTimeSinceEpoch     *int64                `yaml:"createTimeSinceEpoch"`
+	LastUpdateTimeSinceEpoch *int64                `yaml:"lastUpdateTimeSinceEpoch"`
+	ValidatedOn              []string              `yaml:"validatedOn"`
+	HardwareTag              []string              `yaml:"hardwareTag"`
+	ValidatedTasks           []string              `yaml:"validatedTasks,omitempty"`
+	TrainingDatasets         []string              `yaml:"trainingDatasets,omitempty"`
+	BaseModel                []string              `yaml:"baseModel,omitempty"`
+	TrainingData             *string               `yaml:"trainingData,omitempty"`
+	ToolCallingConfig        *ToolCallingConfig    `yaml:"toolCallingConfig,omitempty"`
+	Quantization             *QuantizationInfo     `yaml:"quantization,omitempty"`
+	HardwareRequirements     *HardwareRequirements `yaml:"hardwareRequirements,omitempty"`
+	ResponsibleUse           *ResponsibleUse       `yaml:"responsibleUse,omitempty"`
+	MaxContextLength         *int64                `yaml:"maxContextLength,omitempty"`
+	ModelConfig              *ModelConfig          `yaml:"modelConfig,omitempty"`
+	Performance              *PerformanceInfo      `yaml:"performance,omitempty"`
+	Evaluations              []Evaluation          `yaml:"evaluations,omitempty"`
+	Deprecated               bool                  `yaml:"deprecated,omitempty"`
+	EndOfLife                *string               `yaml:"endOfLife,omitempty"`
+	ReplacedBy               *string               `yaml:"replacedBy,omitempty"`
+	Artifacts                []OCIArtifact         `yaml:"artifacts"`
+
+	// VLLMProfilePath is the path of the vLLM launch profile written during catalog generation
+	VLLMProfilePath string `yaml:"-"`
+	// PreviousVersions is the JSON list of the earlier versions in the model's version history,
+	// read during catalog generation
+	PreviousVersions string `yaml:"-"`
 }
 
 // LegacyExtractedMetadata represents the old format with string artifacts
@@ -185,6 +241,11 @@ type EnrichedModelMetadata struct {
 	ValidatedOn          MetadataSource `yaml:"validated_on"`
 	HardwareTag          MetadataSource `yaml:"hardware_tag"`
 	ValidatedTasks       MetadataSource `yaml:"validated_tasks"`
+	TrainingDatasets     MetadataSource `yaml:"training_datasets"`
+	BaseModel            MetadataSource `yaml:"base_model"`
+	Quantization         MetadataSource `yaml:"quantization"`
+	Evaluations          MetadataSource `yaml:"evaluations"`
+	MaxContextLength     MetadataSource `yaml:"max_context_length"`
 }
 
 // EnrichmentInfo tracks data sources for metadata fields
@@ -208,40 +269,15 @@ type EnrichmentInfo struct {
 	} `json:"dataSources"`
 }
 
-// MetadataValue represents a metadata value with type information
-type MetadataValue struct {
-	MetadataType string `yaml:"metadataType"`
-	StringValue  string `yaml:"string_value"`
-}
-
-// MarshalYAML implements yaml.Marshaler to force string values to be quoted
-func (mv MetadataValue) MarshalYAML() (interface{}, error) {
-	// Create a map that will be marshaled with explicit string quoting for string_value
-	result := map[string]interface{}{
-		"metadataType": mv.MetadataType,
-	}
-
-	// Force string_value to be quoted by using a yaml.Node with style set to DoubleQuotedStyle
-	if mv.StringValue != "" {
-		stringNode := &yaml.Node{
-			Kind:  yaml.ScalarNode,
-			Value: mv.StringValue,
-			Style: yaml.DoubleQuotedStyle,
-		}
-		result["string_value"] = stringNode
-	} else {
-		result["string_value"] = mv.StringValue
-	}
-
-	return result, nil
-}
-
 // CatalogOCIArtifact represents an OCI artifact for catalog output with string timestamps
 type CatalogOCIArtifact struct {
 	URI                      string                 `yaml:"uri"`
 	CreateTimeSinceEpoch     *string                `yaml:"createTimeSinceEpoch"`
 	LastUpdateTimeSinceEpoch *string                `yaml:"lastUpdateTimeSinceEpoch"`
 	CustomProperties         map[string]interface{} `yaml:"customProperties,omitempty"`
+	Verification             *VerificationStatus    `yaml:"verification,omitempty"`
+	Vulnerabilities          *VulnerabilitySummary  `yaml:"vulnerabilities,omitempty"`
+	RedHatCatalog            *RedHatCatalogInfo     `yaml:"redHatCatalog,omitempty"`
 }
 
 // CatalogMetadata represents metadata for the catalog output without tags field
@@ -249,18 +285,32 @@ type CatalogMetadata struct {
 	Name                     *string                  `yaml:"name"`
 	Provider                 *string                  `yaml:"provider"`
 	Description              *string                  `yaml:"description"`
+	DescriptionI18n          map[string]string        `yaml:"description_i18n,omitempty"` // Localized descriptions keyed by language tag (e.g. es, ja, pt-BR)
 	Readme                   *string                  `yaml:"readme"`
 	Language                 []string                 `yaml:"language"`
 	License                  *string                  `yaml:"license"`
 	LicenseLink              *string                  `yaml:"licenseLink"`
 	Tasks                    []string                 `yaml:"tasks"`
 	ValidatedTasks           []string                 `yaml:"validatedTasks,omitempty"`
+	TrainingDatasets         []string                 `yaml:"trainingDatasets,omitempty"`
+	BaseModel                []string                 `yaml:"baseModel,omitempty"`
+	TrainingData             *string                  `yaml:"trainingData,omitempty"`
 	ServingConfig            *ServingConfig           `yaml:"servingConfig,omitempty"`
+	Quantization             *QuantizationInfo        `yaml:"quantization,omitempty"`
+	HardwareRequirements     *HardwareRequirements    `yaml:"hardwareRequirements,omitempty"`
+	ResponsibleUse           *ResponsibleUse          `yaml:"responsibleUse,omitempty"`
+	MaxContextLength         *int64                   `yaml:"maxContextLength,omitempty"`
+	ModelConfig              *ModelConfig             `yaml:"modelConfig,omitempty"`
+	Evaluations              []Evaluation             `yaml:"evaluations,omitempty"`
+	Deprecated               bool                     `yaml:"deprecated,omitempty"`
+	EndOfLife                *string                  `yaml:"endOfLife,omitempty"`
+	ReplacedBy               *string                  `yaml:"replacedBy,omitempty"`
 	CreateTimeSinceEpoch     *string                  `yaml:"createTimeSinceEpoch"`
 	LastUpdateTimeSinceEpoch *string                  `yaml:"lastUpdateTimeSinceEpoch"`
 	CustomProperties         map[string]MetadataValue `yaml:"customProperties,omitempty"`
 	Artifacts                []CatalogOCIArtifact     `yaml:"artifacts"`
 	Logo                     *string                  `yaml:"logo,omitempty"`
+	Source                   string                   `yaml:"source,omitempty"`
 }
 
 // ModelsCatalog represents the aggregated catalog of all models
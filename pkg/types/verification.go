@@ -0,0 +1,15 @@
+package types
+
+// VerificationStatus summarizes the supply-chain checks of an artifact, so policy engines can
+// decide whether to admit it from a single field
+type VerificationStatus struct {
+	// SignatureVerified is true when a cosign signature of the artifact's manifest digest verified
+	// against one of the configured public keys
+	SignatureVerified bool `yaml:"signatureVerified"`
+	// SBOMPresent is true when an SBOM is attached to the artifact
+	SBOMPresent bool `yaml:"sbomPresent"`
+	// AttestationPresent is true when an in-toto attestation is attached to the artifact
+	AttestationPresent bool `yaml:"attestationPresent"`
+	// ScannedAt is the RFC 3339 time the checks ran
+	ScannedAt string `yaml:"scannedAt"`
+}
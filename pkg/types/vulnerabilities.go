@@ -0,0 +1,72 @@
+package types
+
+// Severities of vulnerabilities, from highest to lowest
+const (
+	SeverityCritical = "critical"
+	SeverityHigh     = "high"
+	SeverityMedium   = "medium"
+	SeverityLow      = "low"
+	SeverityUnknown  = "unknown"
+)
+
+// Severities lists the vulnerability severities from highest to lowest
+var Severities = []string{SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow, SeverityUnknown}
+
+// VulnerabilitySummary counts the known vulnerabilities (CVEs) of an artifact by severity
+type VulnerabilitySummary struct {
+	// Scanner is the scanner that produced the counts: trivy or clair
+	Scanner string `yaml:"scanner"`
+	// ScannedAt is the RFC 3339 time of the scan
+	ScannedAt string `yaml:"scannedAt"`
+	Critical  int64  `yaml:"critical"`
+	High      int64  `yaml:"high"`
+	Medium    int64  `yaml:"medium"`
+	Low       int64  `yaml:"low"`
+	Unknown   int64  `yaml:"unknown"`
+}
+
+// Count returns the number of vulnerabilities of a severity
+func (s *VulnerabilitySummary) Count(severity string) int64 {
+	switch severity {
+	case SeverityCritical:
+		return s.Critical
+	case SeverityHigh:
+		return s.High
+	case SeverityMedium:
+		return s.Medium
+	case SeverityLow:
+		return s.Low
+	case SeverityUnknown:
+		return s.Unknown
+	}
+	return 0
+}
+
+// Add counts one more vulnerability of a severity; unrecognized severities count as unknown
+func (s *VulnerabilitySummary) Add(severity string) {
+	switch severity {
+	case SeverityCritical:
+		s.Critical++
+	case SeverityHigh:
+		s.High++
+	case SeverityMedium:
+		s.Medium++
+	case SeverityLow:
+		s.Low++
+	default:
+		s.Unknown++
+	}
+}
+
+// CountAtOrAbove returns the number of vulnerabilities of severity or a higher one; unknown
+// severities are only counted for a SeverityUnknown threshold
+func (s *VulnerabilitySummary) CountAtOrAbove(severity string) int64 {
+	var count int64
+	for _, current := range Severities {
+		count += s.Count(current)
+		if current == severity {
+			return count
+		}
+	}
+	return 0
+}
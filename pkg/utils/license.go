@@ -1,41 +1,112 @@
 package utils
 
 import (
+	_ "embed"
+	"encoding/json"
+	"log"
 	"strings"
+	"sync"
 )
 
-// getLicenseURL returns the canonical URL for well-known licenses
+// spdxLicensesJSON is the SPDX license list in the licenses.json format of
+// github.com/spdx/license-list-data; scripts/update-spdx-licenses.sh refreshes it
+//
+//go:embed licenses/spdx-licenses.json
+var spdxLicensesJSON []byte
+
+// License is an entry of the license registry
+type License struct {
+	// ID is the SPDX identifier, or the HuggingFace identifier of licenses not on the SPDX list
+	ID string
+	// Name is the full license name, e.g. "Eclipse Public License 2.0"
+	Name string
+	// URL links to the license text
+	URL string
+}
+
+// customLicenses are the model licenses missing from the SPDX list, and SPDX licenses linked at
+// their canonical home instead of spdx.org. An empty Name keeps the SPDX name.
+var customLicenses = []License{
+	{ID: "apache-2.0", URL: "https://www.apache.org/licenses/LICENSE-2.0"},
+	{ID: "mit", URL: "https://opensource.org/licenses/MIT"},
+	{ID: "bsd-3-clause", URL: "https://opensource.org/licenses/BSD-3-Clause"},
+	{ID: "bsd-2-clause", URL: "https://opensource.org/licenses/BSD-2-Clause"},
+	{ID: "gpl-3.0", URL: "https://www.gnu.org/licenses/gpl-3.0.html"},
+	{ID: "gpl-2.0", URL: "https://www.gnu.org/licenses/old-licenses/gpl-2.0.html"},
+	{ID: "lgpl-3.0", URL: "https://www.gnu.org/licenses/lgpl-3.0.html"},
+	{ID: "lgpl-2.1", URL: "https://www.gnu.org/licenses/old-licenses/lgpl-2.1.html"},
+	{ID: "cc-by-4.0", URL: "https://creativecommons.org/licenses/by/4.0/"},
+	{ID: "cc-by-sa-4.0", URL: "https://creativecommons.org/licenses/by-sa/4.0/"},
+	{ID: "cc-by-nc-4.0", URL: "https://creativecommons.org/licenses/by-nc/4.0/"},
+	{ID: "cc0-1.0", URL: "https://creativecommons.org/publicdomain/zero/1.0/"},
+	{ID: "unlicense", URL: "https://unlicense.org/"},
+	{ID: "llama2", Name: "Llama 2 Community License Agreement", URL: "https://github.com/facebookresearch/llama/blob/main/LICENSE"},
+	{ID: "llama3", Name: "Llama 3 Community License Agreement", URL: "https://github.com/meta-llama/llama-models/blob/main/models/llama3/LICENSE"},
+	{ID: "llama3.1", Name: "Llama 3.1 Community License Agreement", URL: "https://github.com/meta-llama/llama-models/blob/main/models/llama3_1/LICENSE"},
+	{ID: "llama3.2", Name: "Llama 3.2 Community License Agreement", URL: "https://github.com/meta-llama/llama-models/blob/main/models/llama3_2/LICENSE"},
+	{ID: "llama3.3", Name: "Llama 3.3 Community License Agreement", URL: "https://github.com/meta-llama/llama-models/blob/main/models/llama3_3/LICENSE"},
+	{ID: "llama4", Name: "Llama 4 Community License Agreement", URL: "https://github.com/meta-llama/llama-models/blob/main/models/llama4/LICENSE"},
+	{ID: "bigscience-openrail-m", Name: "BigScience OpenRAIL-M License", URL: "https://huggingface.co/spaces/bigscience/license"},
+	{ID: "openrail", Name: "Open RAIL License", URL: "https://www.licenses.ai/ai-licenses"},
+	{ID: "gemma", Name: "Gemma Terms of Use", URL: "https://ai.google.dev/gemma/terms"},
+}
+
+// licenseRegistry holds the SPDX and custom licenses by lowercase identifier
+var (
+	licenseRegistry     map[string]License
+	licenseRegistryOnce sync.Once
+)
+
+func loadLicenseRegistry() map[string]License {
+	licenseRegistryOnce.Do(func() {
+		var list struct {
+			Licenses []struct {
+				LicenseID string `json:"licenseId"`
+				Name      string `json:"name"`
+				Reference string `json:"reference"`
+			} `json:"licenses"`
+		}
+		if err := json.Unmarshal(spdxLicensesJSON, &list); err != nil {
+			log.Printf("ERROR: Failed to parse the embedded SPDX license list: %v", err)
+		}
+
+		licenseRegistry = make(map[string]License, len(list.Licenses)+len(customLicenses))
+		for _, entry := range list.Licenses {
+			licenseRegistry[strings.ToLower(entry.LicenseID)] = License{ID: entry.LicenseID, Name: entry.Name, URL: entry.Reference}
+		}
+		for _, custom := range customLicenses {
+			license, exists := licenseRegistry[custom.ID]
+			if !exists {
+				license.ID = custom.ID
+			}
+			if custom.Name != "" {
+				license.Name = custom.Name
+			}
+			license.URL = custom.URL
+			licenseRegistry[custom.ID] = license
+		}
+	})
+	return licenseRegistry
+}
+
+// LookupLicense returns the registry entry of a license identifier, ignoring case and surrounding
+// whitespace
+func LookupLicense(licenseID string) (License, bool) {
+	license, exists := loadLicenseRegistry()[strings.ToLower(strings.TrimSpace(licenseID))]
+	return license, exists
+}
+
+// GetLicenseURL returns the canonical URL of a known license, or "" for unknown licenses
 func GetLicenseURL(licenseID string) string {
-	licenseID = strings.ToLower(strings.TrimSpace(licenseID))
-
-	licenseURLs := map[string]string{
-		"apache-2.0":            "https://www.apache.org/licenses/LICENSE-2.0",
-		"mit":                   "https://opensource.org/licenses/MIT",
-		"bsd-3-clause":          "https://opensource.org/licenses/BSD-3-Clause",
-		"bsd-2-clause":          "https://opensource.org/licenses/BSD-2-Clause",
-		"gpl-3.0":               "https://www.gnu.org/licenses/gpl-3.0.html",
-		"gpl-2.0":               "https://www.gnu.org/licenses/old-licenses/gpl-2.0.html",
-		"lgpl-3.0":              "https://www.gnu.org/licenses/lgpl-3.0.html",
-		"lgpl-2.1":              "https://www.gnu.org/licenses/old-licenses/lgpl-2.1.html",
-		"cc-by-4.0":             "https://creativecommons.org/licenses/by/4.0/",
-		"cc-by-sa-4.0":          "https://creativecommons.org/licenses/by-sa/4.0/",
-		"cc-by-nc-4.0":          "https://creativecommons.org/licenses/by-nc/4.0/",
-		"cc0-1.0":               "https://creativecommons.org/publicdomain/zero/1.0/",
-		"unlicense":             "https://unlicense.org/",
-		"llama2":                "https://github.com/facebookresearch/llama/blob/main/LICENSE",
-		"llama3":                "https://github.com/meta-llama/llama-models/blob/main/models/llama3/LICENSE",
-		"llama3.1":              "https://github.com/meta-llama/llama-models/blob/main/models/llama3_1/LICENSE",
-		"llama3.2":              "https://github.com/meta-llama/llama-models/blob/main/models/llama3_2/LICENSE",
-		"llama3.3":              "https://github.com/meta-llama/llama-models/blob/main/models/llama3_3/LICENSE",
-		"llama4":                "https://github.com/meta-llama/llama-models/blob/main/models/llama4/LICENSE",
-		"bigscience-openrail-m": "https://huggingface.co/spaces/bigscience/license",
-		"openrail":              "https://www.licenses.ai/ai-licenses",
-		"gemma":                 "https://ai.google.dev/gemma/terms",
-	}
+	license, _ := LookupLicense(licenseID)
+	return license.URL
+}
 
-	if url, exists := licenseURLs[licenseID]; exists {
-		return url
+// GetHumanReadableLicenseName returns the full name of a license, e.g. "Mozilla Public License
+// 2.0" for mpl-2.0, falling back to the identifier itself for unknown licenses
+func GetHumanReadableLicenseName(licenseID string) string {
+	if license, exists := LookupLicense(licenseID); exists && license.Name != "" {
+		return license.Name
 	}
-
-	return ""
+	return strings.TrimSpace(licenseID)
 }
@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"embed"
+	"log"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// licenseTextFS holds reference texts of common licenses, named by license identifier
+//
+//go:embed licenses/texts/*.txt
+var licenseTextFS embed.FS
+
+// LicenseMatchThreshold is the minimum similarity (0-1) of a license file to a reference text for
+// DetectLicense to classify it
+const LicenseMatchThreshold = 0.8
+
+// licenseText is a reference license text prepared for comparison
+type licenseText struct {
+	id       string
+	hash     [sha256.Size]byte
+	shingles map[string]struct{}
+}
+
+var (
+	licenseTexts     []licenseText
+	licenseTextsOnce sync.Once
+)
+
+func loadLicenseTexts() []licenseText {
+	licenseTextsOnce.Do(func() {
+		entries, err := licenseTextFS.ReadDir("licenses/texts")
+		if err != nil {
+			log.Printf("ERROR: Failed to read the embedded license texts: %v", err)
+			return
+		}
+		for _, entry := range entries {
+			content, err := licenseTextFS.ReadFile(path.Join("licenses/texts", entry.Name()))
+			if err != nil {
+				log.Printf("ERROR: Failed to read the embedded license text %s: %v", entry.Name(), err)
+				continue
+			}
+			words := licenseWords(string(content))
+			licenseTexts = append(licenseTexts, licenseText{
+				id:       strings.TrimSuffix(entry.Name(), ".txt"),
+				hash:     sha256.Sum256([]byte(strings.Join(words, " "))),
+				shingles: wordShingles(words),
+			})
+		}
+	})
+	return licenseTexts
+}
+
+// DetectLicense classifies the text of a license file by comparing it with the reference texts
+// of common licenses. Texts identical to a reference after normalization match by hash; others
+// are scored by the Dice coefficient of their word pairs. It returns the license identifier and
+// the similarity, or "" when no reference reaches LicenseMatchThreshold.
+func DetectLicense(text string) (string, float64) {
+	words := licenseWords(text)
+	if len(words) == 0 {
+		return "", 0
+	}
+	hash := sha256.Sum256([]byte(strings.Join(words, " ")))
+	shingles := wordShingles(words)
+
+	bestID, bestScore := "", 0.0
+	for _, reference := range loadLicenseTexts() {
+		if reference.hash == hash {
+			return reference.id, 1
+		}
+		if score := diceCoefficient(shingles, reference.shingles); score > bestScore {
+			bestID, bestScore = reference.id, score
+		}
+	}
+	if bestScore < LicenseMatchThreshold {
+		return "", bestScore
+	}
+	return bestID, bestScore
+}
+
+// licenseWordPattern matches the words compared between license texts
+var licenseWordPattern = regexp.MustCompile(`[a-z0-9]+`)
+
+// maxCopyrightLineLength bounds the lines dropped as copyright notices, so a license reflowed
+// into a single line starting with its notice is still compared
+const maxCopyrightLineLength = 200
+
+// licenseWords returns the lowercase words of a license text without its copyright lines, which
+// differ between every copy of the same license
+func licenseWords(text string) []string {
+	var words []string
+	for _, line := range strings.Split(strings.ToLower(text), "\n") {
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "copyright") && len(trimmed) <= maxCopyrightLineLength {
+			continue
+		}
+		words = append(words, licenseWordPattern.FindAllString(line, -1)...)
+	}
+	return words
+}
+
+// wordShingles returns the set of adjacent word pairs
+func wordShingles(words []string) map[string]struct{} {
+	shingles := make(map[string]struct{}, len(words))
+	for i := 1; i < len(words); i++ {
+		shingles[words[i-1]+" "+words[i]] = struct{}{}
+	}
+	return shingles
+}
+
+// diceCoefficient returns 2|a∩b| / (|a|+|b|)
+func diceCoefficient(a, b map[string]struct{}) float64 {
+	if len(a)+len(b) == 0 {
+		return 0
+	}
+	common := 0
+	for shingle := range a {
+		if _, ok := b[shingle]; ok {
+			common++
+		}
+	}
+	return 2 * float64(common) / float64(len(a)+len(b))
+}
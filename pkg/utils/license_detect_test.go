@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetectLicense(t *testing.T) {
+	apache, err := licenseTextFS.ReadFile("licenses/texts/apache-2.0.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bsd3, err := licenseTextFS.ReadFile("licenses/texts/bsd-3-clause.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{
+			name:     "reference text",
+			text:     string(apache),
+			expected: "apache-2.0",
+		},
+		{
+			name:     "reflowed with another copyright holder",
+			text:     "Copyright (c) 2024, Example Labs\n" + strings.Join(strings.Fields(string(bsd3)), " "),
+			expected: "bsd-3-clause",
+		},
+		{
+			name:     "license text without its appendix",
+			text:     string(apache[:strings.Index(string(apache), "APPENDIX")]),
+			expected: "apache-2.0",
+		},
+		{
+			name:     "custom terms",
+			text:     "You may use this model for research purposes only. Commercial use requires a separate agreement.",
+			expected: "",
+		},
+		{
+			name:     "empty",
+			text:     "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			licenseID, score := DetectLicense(tt.text)
+			if licenseID != tt.expected {
+				t.Errorf("DetectLicense() = %q (similarity %.2f), expected %q", licenseID, score, tt.expected)
+			}
+		})
+	}
+}
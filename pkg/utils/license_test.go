@@ -56,3 +56,34 @@ func TestGetLicenseURL(t *testing.T) {
 		})
 	}
 }
+
+func TestGetLicenseURL_SPDXLicenses(t *testing.T) {
+	for licenseID, expected := range map[string]string{
+		"EPL-2.0":           "https://spdx.org/licenses/EPL-2.0.html",
+		"mpl-2.0":           "https://spdx.org/licenses/MPL-2.0.html",
+		"agpl-3.0-only":     "https://spdx.org/licenses/AGPL-3.0-only.html",
+		"gemma":             "https://ai.google.dev/gemma/terms",
+		"not-a-license-1.0": "",
+	} {
+		if got := GetLicenseURL(licenseID); got != expected {
+			t.Errorf("GetLicenseURL(%q) = %q, expected %q", licenseID, got, expected)
+		}
+	}
+}
+
+func TestGetHumanReadableLicenseName(t *testing.T) {
+	for licenseID, expected := range map[string]string{
+		"apache-2.0":      "Apache License 2.0",
+		"EPL-2.0":         "Eclipse Public License 2.0",
+		"mpl-2.0":         "Mozilla Public License 2.0",
+		"AGPL-3.0-only":   "GNU Affero General Public License v3.0 only",
+		"cc-by-nc-sa-4.0": "Creative Commons Attribution Non Commercial Share Alike 4.0 International",
+		"llama3.1":        "Llama 3.1 Community License Agreement",
+		"gemma":           "Gemma Terms of Use",
+		" other ":         "other",
+	} {
+		if got := GetHumanReadableLicenseName(licenseID); got != expected {
+			t.Errorf("GetHumanReadableLicenseName(%q) = %q, expected %q", licenseID, got, expected)
+		}
+	}
+}
@@ -26,14 +26,13 @@ var DefaultRetryConfig = RetryConfig{
 	OverallTimeout: 2 * time.Minute,
 }
 
-// RetryWithExponentialBackoff retries a function with exponential backoff
+// RetryWithExponentialBackoff retries a function with exponential backoff until ctx is canceled
 // Returns the result of the function or the last error encountered
-func RetryWithExponentialBackoff[T any](config RetryConfig, operation func() (T, error), operationName string) (T, error) {
+func RetryWithExponentialBackoff[T any](ctx context.Context, config RetryConfig, operation func() (T, error), operationName string) (T, error) {
 	var result T
 	var err error
 
-	// Create context with overall timeout if configured
-	ctx := context.Background()
+	// Bound the retries with the overall timeout if configured
 	var cancel context.CancelFunc
 	if config.OverallTimeout > 0 {
 		ctx, cancel = context.WithTimeout(ctx, config.OverallTimeout)
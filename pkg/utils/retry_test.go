@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -15,7 +16,7 @@ func TestRetryWithExponentialBackoff_Success(t *testing.T) {
 		Multiplier:     2.0,
 	}
 
-	result, err := RetryWithExponentialBackoff(config, func() (string, error) {
+	result, err := RetryWithExponentialBackoff(context.Background(), config, func() (string, error) {
 		attempts++
 		if attempts < 2 {
 			return "", errors.New("temporary failure")
@@ -43,7 +44,7 @@ func TestRetryWithExponentialBackoff_AllFailures(t *testing.T) {
 		Multiplier:     2.0,
 	}
 
-	_, err := RetryWithExponentialBackoff(config, func() (string, error) {
+	_, err := RetryWithExponentialBackoff(context.Background(), config, func() (string, error) {
 		attempts++
 		return "", errors.New("persistent failure")
 	}, "test operation")
@@ -61,7 +62,7 @@ func TestRetryWithExponentialBackoff_ImmediateSuccess(t *testing.T) {
 	attempts := 0
 	config := DefaultRetryConfig
 
-	result, err := RetryWithExponentialBackoff(config, func() (int, error) {
+	result, err := RetryWithExponentialBackoff(context.Background(), config, func() (int, error) {
 		attempts++
 		return 42, nil
 	}, "test operation")
@@ -86,7 +87,7 @@ func TestRetryWithExponentialBackoff_BackoffCapping(t *testing.T) {
 	}
 
 	start := time.Now()
-	_, _ = RetryWithExponentialBackoff(config, func() (bool, error) {
+	_, _ = RetryWithExponentialBackoff(context.Background(), config, func() (bool, error) {
 		return false, errors.New("always fail")
 	}, "test operation")
 	elapsed := time.Since(start)
@@ -110,7 +111,7 @@ func TestRetryWithExponentialBackoff_TimeoutProtection(t *testing.T) {
 
 	attempts := 0
 	start := time.Now()
-	_, err := RetryWithExponentialBackoff(config, func() (string, error) {
+	_, err := RetryWithExponentialBackoff(context.Background(), config, func() (string, error) {
 		attempts++
 		time.Sleep(50 * time.Millisecond) // Simulate slow operation
 		return "", errors.New("slow failure")
@@ -143,7 +144,7 @@ func TestRetryWithExponentialBackoff_TimeoutErrorWrapping(t *testing.T) {
 		OverallTimeout: 150 * time.Millisecond, // Short timeout to trigger during retries
 	}
 
-	_, err := RetryWithExponentialBackoff(config, func() (string, error) {
+	_, err := RetryWithExponentialBackoff(context.Background(), config, func() (string, error) {
 		time.Sleep(30 * time.Millisecond)
 		return "", errors.New("operation failed")
 	}, "test operation")
@@ -164,3 +165,27 @@ func TestRetryWithExponentialBackoff_TimeoutErrorWrapping(t *testing.T) {
 		t.Errorf("Expected descriptive timeout error, got: %v", err)
 	}
 }
+
+func TestRetryWithExponentialBackoff_Canceled(t *testing.T) {
+	config := RetryConfig{
+		MaxRetries:     5,
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Second,
+		Multiplier:     1.0,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	_, err := RetryWithExponentialBackoff(ctx, config, func() (string, error) {
+		attempts++
+		cancel()
+		return "", errors.New("operation failed")
+	}, "test operation")
+
+	if err == nil {
+		t.Fatal("Expected an error after cancellation, got nil")
+	}
+	if attempts != 1 {
+		t.Errorf("Expected no retries after cancellation, got %d attempts", attempts)
+	}
+}
@@ -485,3 +485,11 @@ func NormalizeTask(task string) string {
 	// Return original if no normalization possible
 	return task
 }
+
+// StringValue returns the string s points to, or "" for nil
+func StringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
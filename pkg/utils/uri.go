@@ -0,0 +1,234 @@
+package utils
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Schemes of artifact URIs
+const (
+	URISchemeOCI   = "oci"
+	URISchemeHTTPS = "https"
+	URISchemeS3    = "s3"
+	URISchemeHF    = "hf"
+)
+
+// DefaultImageTag is the tag of image references that carry neither a tag nor a digest
+const DefaultImageTag = "latest"
+
+// ArtifactURI is a parsed artifact URI: an OCI image reference written as
+// registry/repository[:tag][@digest] with or without the oci:// scheme, an https:// URL, an
+// s3://bucket/key object URI or an hf://org/model[:revision] HuggingFace repository
+type ArtifactURI struct {
+	// Scheme is URISchemeOCI, URISchemeHTTPS, URISchemeS3 or URISchemeHF
+	Scheme string
+	// Registry is the lowercase registry host, with its port when one is given
+	Registry string
+	// Repository is the repository path within the registry, or the org/model HuggingFace repository
+	Repository string
+	// Tag is the image tag; DefaultImageTag when the reference has neither a tag nor a digest
+	Tag string
+	// Digest is the image digest, e.g. "sha256:..."
+	Digest string
+	// URL is the normalized URL of HTTPS artifacts
+	URL string
+	// Bucket is the bucket of S3 artifacts
+	Bucket string
+	// Key is the object key of S3 artifacts
+	Key string
+	// Revision is the branch, tag or commit of HuggingFace repositories; empty for the default branch
+	Revision string
+}
+
+var (
+	// repositoryComponentPattern matches one path component of an OCI repository name
+	repositoryComponentPattern = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*$`)
+	// imageTagPattern matches an OCI image tag
+	imageTagPattern = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9._-]{0,127}$`)
+	// imageDigestPattern matches an OCI image digest
+	imageDigestPattern = regexp.MustCompile(`^[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-zA-Z0-9=_-]{32,}$`)
+	// s3BucketPattern matches an S3 bucket name
+	s3BucketPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+	// hfRepositoryPattern matches an org/model HuggingFace repository
+	hfRepositoryPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*/[A-Za-z0-9][A-Za-z0-9._-]*$`)
+	// hfRevisionPattern matches a HuggingFace branch, tag or commit
+	hfRevisionPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]*$`)
+)
+
+// ParseArtifactURI parses an artifact URI, returning an error that names the problem when it is
+// neither a valid image reference, an https:// URL, an s3:// object URI nor an hf:// repository
+func ParseArtifactURI(uri string) (*ArtifactURI, error) {
+	trimmed := strings.TrimSpace(uri)
+	if trimmed == "" {
+		return nil, fmt.Errorf("empty artifact URI")
+	}
+
+	if scheme, rest, found := strings.Cut(trimmed, "://"); found {
+		switch strings.ToLower(scheme) {
+		case URISchemeOCI:
+			return parseImageReference(uri, rest)
+		case URISchemeHTTPS:
+			return parseHTTPSURI(uri)
+		case URISchemeS3:
+			return parseS3URI(uri, rest)
+		case URISchemeHF:
+			return parseHFURI(uri, rest)
+		default:
+			return nil, fmt.Errorf("artifact URI %q has unsupported scheme %q (expected oci://, https://, s3:// or hf://)", uri, scheme)
+		}
+	}
+	return parseImageReference(uri, trimmed)
+}
+
+// parseImageReference parses registry/repository[:tag][@digest]
+func parseImageReference(uri, ref string) (*ArtifactURI, error) {
+	parsed := &ArtifactURI{Scheme: URISchemeOCI}
+
+	name := ref
+	if at := strings.Index(ref, "@"); at >= 0 {
+		name, parsed.Digest = ref[:at], ref[at+1:]
+		if !imageDigestPattern.MatchString(parsed.Digest) {
+			return nil, fmt.Errorf("artifact URI %q has invalid digest %q", uri, parsed.Digest)
+		}
+	}
+	if colon := strings.LastIndex(name, ":"); colon > strings.LastIndex(name, "/") {
+		name, parsed.Tag = name[:colon], name[colon+1:]
+		if !imageTagPattern.MatchString(parsed.Tag) {
+			return nil, fmt.Errorf("artifact URI %q has invalid tag %q", uri, parsed.Tag)
+		}
+	}
+
+	registry, repository, found := strings.Cut(name, "/")
+	if !found || !isRegistryHost(registry) {
+		return nil, fmt.Errorf("artifact URI %q does not start with a registry host", uri)
+	}
+	for _, component := range strings.Split(repository, "/") {
+		if !repositoryComponentPattern.MatchString(component) {
+			return nil, fmt.Errorf("artifact URI %q has invalid repository %q", uri, repository)
+		}
+	}
+	parsed.Registry = strings.ToLower(registry)
+	parsed.Repository = repository
+
+	if parsed.Tag == "" && parsed.Digest == "" {
+		parsed.Tag = DefaultImageTag
+	}
+	return parsed, nil
+}
+
+// isRegistryHost reports whether the first component of an image reference names a registry
+// rather than a repository on the default registry, following the Docker convention
+func isRegistryHost(component string) bool {
+	return component == "localhost" || strings.ContainsAny(component, ".:")
+}
+
+// parseHTTPSURI parses and normalizes an https:// URL
+func parseHTTPSURI(uri string) (*ArtifactURI, error) {
+	u, err := url.Parse(strings.TrimSpace(uri))
+	if err != nil {
+		return nil, fmt.Errorf("artifact URI %q is not a valid URL: %v", uri, err)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("artifact URI %q has no host", uri)
+	}
+	u.Scheme = URISchemeHTTPS
+	u.Host = strings.TrimSuffix(strings.ToLower(u.Host), ":443")
+	u.Fragment = ""
+	u.RawFragment = ""
+	return &ArtifactURI{Scheme: URISchemeHTTPS, URL: u.String()}, nil
+}
+
+// parseS3URI parses bucket/key, the part of an s3:// URI after the scheme
+func parseS3URI(uri, rest string) (*ArtifactURI, error) {
+	bucket, key, _ := strings.Cut(rest, "/")
+	if !s3BucketPattern.MatchString(bucket) {
+		return nil, fmt.Errorf("artifact URI %q has invalid bucket %q", uri, bucket)
+	}
+	if key == "" || strings.HasSuffix(key, "/") {
+		return nil, fmt.Errorf("artifact URI %q does not name an object", uri)
+	}
+	return &ArtifactURI{Scheme: URISchemeS3, Bucket: bucket, Key: key}, nil
+}
+
+// parseHFURI parses org/model[:revision], the part of an hf:// URI after the scheme, following the
+// KServe storage URI convention
+func parseHFURI(uri, rest string) (*ArtifactURI, error) {
+	repository, revision, found := strings.Cut(rest, ":")
+	if !hfRepositoryPattern.MatchString(repository) {
+		return nil, fmt.Errorf("artifact URI %q has invalid HuggingFace repository %q (expected org/model)", uri, repository)
+	}
+	if found && !hfRevisionPattern.MatchString(revision) {
+		return nil, fmt.Errorf("artifact URI %q has invalid revision %q", uri, revision)
+	}
+	return &ArtifactURI{Scheme: URISchemeHF, Repository: repository, Revision: revision}, nil
+}
+
+// ImageReference returns the image reference of OCI artifacts without the oci:// scheme, as
+// passed to registry tools, or "" for other artifacts
+func (u *ArtifactURI) ImageReference() string {
+	if u.Scheme != URISchemeOCI {
+		return ""
+	}
+	ref := u.Registry + "/" + u.Repository
+	if u.Tag != "" {
+		ref += ":" + u.Tag
+	}
+	if u.Digest != "" {
+		ref += "@" + u.Digest
+	}
+	return ref
+}
+
+// String returns the normalized URI: oci://registry/repository:tag[@digest] for OCI artifacts,
+// the normalized URL for HTTPS artifacts, s3://bucket/key for S3 artifacts and
+// hf://org/model[:revision] for HuggingFace repositories
+func (u *ArtifactURI) String() string {
+	switch u.Scheme {
+	case URISchemeHTTPS:
+		return u.URL
+	case URISchemeS3:
+		return URISchemeS3 + "://" + u.Bucket + "/" + u.Key
+	case URISchemeHF:
+		if u.Revision != "" {
+			return URISchemeHF + "://" + u.Repository + ":" + u.Revision
+		}
+		return URISchemeHF + "://" + u.Repository
+	}
+	return URISchemeOCI + "://" + u.ImageReference()
+}
+
+// ValidateArtifactURI returns an error describing why uri is not a valid artifact URI
+func ValidateArtifactURI(uri string) error {
+	_, err := ParseArtifactURI(uri)
+	return err
+}
+
+// NormalizeArtifactURI returns the normalized form of an artifact URI, so references to the same
+// artifact written differently compare equal. URIs that do not parse are returned trimmed.
+func NormalizeArtifactURI(uri string) string {
+	parsed, err := ParseArtifactURI(uri)
+	if err != nil {
+		return strings.TrimSpace(uri)
+	}
+	return parsed.String()
+}
+
+// ImageReferenceOf returns the image reference of an artifact URI without the oci:// scheme,
+// falling back to the URI with the scheme removed when it does not parse
+func ImageReferenceOf(uri string) string {
+	if parsed, err := ParseArtifactURI(uri); err == nil && parsed.Scheme == URISchemeOCI {
+		return parsed.ImageReference()
+	}
+	return strings.TrimPrefix(strings.TrimSpace(uri), "oci://")
+}
+
+// HuggingFaceRepositoryOf returns the org/model repository of an hf:// artifact URI, or "" for
+// other URIs
+func HuggingFaceRepositoryOf(uri string) string {
+	if parsed, err := ParseArtifactURI(uri); err == nil && parsed.Scheme == URISchemeHF {
+		return parsed.Repository
+	}
+	return ""
+}
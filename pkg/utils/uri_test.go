@@ -0,0 +1,142 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeArtifactURI(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("ab", 32)
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "oci URI unchanged",
+			input:    "oci://registry.redhat.io/rhelai1/modelcar-granite:1.5",
+			expected: "oci://registry.redhat.io/rhelai1/modelcar-granite:1.5",
+		},
+		{
+			name:     "bare reference gains the scheme",
+			input:    "registry.redhat.io/rhelai1/modelcar-granite:1.5",
+			expected: "oci://registry.redhat.io/rhelai1/modelcar-granite:1.5",
+		},
+		{
+			name:     "missing tag defaults to latest",
+			input:    "quay.io/org/model",
+			expected: "oci://quay.io/org/model:latest",
+		},
+		{
+			name:     "registry and scheme are lowercased",
+			input:    " OCI://Quay.IO/org/model:V1 ",
+			expected: "oci://quay.io/org/model:V1",
+		},
+		{
+			name:     "registry port is kept",
+			input:    "localhost:5000/model:1",
+			expected: "oci://localhost:5000/model:1",
+		},
+		{
+			name:     "digest without tag",
+			input:    "quay.io/org/model@" + digest,
+			expected: "oci://quay.io/org/model@" + digest,
+		},
+		{
+			name:     "tag and digest",
+			input:    "oci://quay.io/org/model:1@" + digest,
+			expected: "oci://quay.io/org/model:1@" + digest,
+		},
+		{
+			name:     "https host lowercased, default port and fragment dropped",
+			input:    "HTTPS://HuggingFace.co:443/org/model#readme",
+			expected: "https://huggingface.co/org/model",
+		},
+		{
+			name:     "s3 scheme lowercased, key kept",
+			input:    "S3://models-bucket/granite/Granite-3.1-8B.gguf",
+			expected: "s3://models-bucket/granite/Granite-3.1-8B.gguf",
+		},
+		{
+			name:     "hf scheme lowercased, repository and revision kept",
+			input:    "HF://ibm-granite/granite-3.1-8b-instruct:v1.0",
+			expected: "hf://ibm-granite/granite-3.1-8b-instruct:v1.0",
+		},
+		{
+			name:     "invalid URI returned trimmed",
+			input:    " model:1 ",
+			expected: "model:1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeArtifactURI(tt.input); got != tt.expected {
+				t.Errorf("NormalizeArtifactURI(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseArtifactURI_Invalid(t *testing.T) {
+	for _, uri := range []string{
+		"",
+		"model:1",
+		"docker.io",
+		"oci://quay.io/Org/model:1",
+		"oci://quay.io/org/model:bad/tag",
+		"oci://quay.io/org/model:1@sha256:short",
+		"oci://quay.io/org//model:1",
+		"s3://bucket",
+		"s3://bucket/models/",
+		"s3://Bucket/model",
+		"gs://bucket/model",
+		"hf://granite-3.1-8b-instruct",
+		"hf://ibm-granite/granite/extra",
+		"hf://ibm-granite/granite-3.1-8b-instruct:",
+		"https:///path",
+	} {
+		if _, err := ParseArtifactURI(uri); err == nil {
+			t.Errorf("ParseArtifactURI(%q): expected an error", uri)
+		}
+	}
+}
+
+func TestParseArtifactURI_Components(t *testing.T) {
+	parsed, err := ParseArtifactURI("registry.redhat.io/rhelai1/modelcar-granite:1.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Scheme != URISchemeOCI || parsed.Registry != "registry.redhat.io" ||
+		parsed.Repository != "rhelai1/modelcar-granite" || parsed.Tag != "1.5" || parsed.Digest != "" {
+		t.Errorf("Unexpected components: %+v", parsed)
+	}
+	if ref := parsed.ImageReference(); ref != "registry.redhat.io/rhelai1/modelcar-granite:1.5" {
+		t.Errorf("ImageReference() = %q", ref)
+	}
+	if ref := ImageReferenceOf("oci://quay.io/org/model:1"); ref != "quay.io/org/model:1" {
+		t.Errorf("ImageReferenceOf() = %q", ref)
+	}
+
+	parsed, err = ParseArtifactURI("s3://models-bucket/granite/model.gguf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Scheme != URISchemeS3 || parsed.Bucket != "models-bucket" || parsed.Key != "granite/model.gguf" || parsed.ImageReference() != "" {
+		t.Errorf("Unexpected S3 components: %+v", parsed)
+	}
+
+	parsed, err = ParseArtifactURI("hf://ibm-granite/granite-3.1-8b-instruct:refs/pr/1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Scheme != URISchemeHF || parsed.Repository != "ibm-granite/granite-3.1-8b-instruct" || parsed.Revision != "refs/pr/1" {
+		t.Errorf("Unexpected HuggingFace components: %+v", parsed)
+	}
+	if repo := HuggingFaceRepositoryOf("hf://ibm-granite/granite-3.1-8b-instruct"); repo != "ibm-granite/granite-3.1-8b-instruct" {
+		t.Errorf("HuggingFaceRepositoryOf() = %q", repo)
+	}
+	if repo := HuggingFaceRepositoryOf("quay.io/org/model:1"); repo != "" {
+		t.Errorf("HuggingFaceRepositoryOf() of an image = %q", repo)
+	}
+}
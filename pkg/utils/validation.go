@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"regexp"
 	"strings"
 	"time"
@@ -54,15 +56,33 @@ func ContainsMetadataField(content string, indicators []string) bool {
 	return false
 }
 
-// sanitizeManifestRef creates a valid directory name from manifestRef
+// invalidPathCharsPattern matches the characters that are invalid in directory names: / \ : * ? " < > |
+var invalidPathCharsPattern = regexp.MustCompile(`[/\\:*?"<>|]`)
+
+// manifestRefDigestLength is the number of hex digits of the ref digest appended to directory names
+const manifestRefDigestLength = 8
+
+// SanitizeManifestRef creates a valid directory name from manifestRef. The name is the legacy
+// sanitized ref followed by a short digest of manifestRef, so references that differ only in
+// replaced characters (e.g. "a/b:1" and "a:b/1") get different directories. Names that are
+// already valid directory names are returned unchanged, so sanitizing a directory name found in
+// the output directory yields the same directory.
 func SanitizeManifestRef(manifestRef string) string {
+	if !invalidPathCharsPattern.MatchString(manifestRef) {
+		return manifestRef
+	}
+	digest := sha256.Sum256([]byte(manifestRef))
+	return LegacySanitizeManifestRef(manifestRef) + "_" + hex.EncodeToString(digest[:])[:manifestRefDigestLength]
+}
+
+// LegacySanitizeManifestRef returns the directory name of manifestRef used before SanitizeManifestRef
+// appended a digest, which output directories written by earlier versions still use
+func LegacySanitizeManifestRef(manifestRef string) string {
 	// Replace invalid filesystem characters with underscores
-	// Invalid characters: / \ : * ? " < > |
-	re := regexp.MustCompile(`[/\\:*?"<>|]`)
-	sanitized := re.ReplaceAllString(manifestRef, "_")
+	sanitized := invalidPathCharsPattern.ReplaceAllString(manifestRef, "_")
 
 	// Replace multiple consecutive underscores with a single one
-	re = regexp.MustCompile(`_+`)
+	re := regexp.MustCompile(`_+`)
 	sanitized = re.ReplaceAllString(sanitized, "_")
 
 	// Remove leading/trailing underscores
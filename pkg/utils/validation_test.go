@@ -110,17 +110,27 @@ func TestSanitizeManifestRef(t *testing.T) {
 		{
 			name:     "basic registry reference",
 			input:    "registry.redhat.io/rhelai1/modelcar-granite:1.0",
-			expected: "registry.redhat.io_rhelai1_modelcar-granite_1.0",
+			expected: "registry.redhat.io_rhelai1_modelcar-granite_1.0_3d75a818",
 		},
 		{
 			name:     "complex reference with multiple special chars",
 			input:    "registry.io/path/with:colon/and\\backslash?question",
-			expected: "registry.io_path_with_colon_and_backslash_question",
+			expected: "registry.io_path_with_colon_and_backslash_question_44e9006f",
 		},
 		{
 			name:     "multiple underscores cleanup",
 			input:    "test///multiple\\\\\\slashes",
-			expected: "test_multiple_slashes",
+			expected: "test_multiple_slashes_b0109f1f",
+		},
+		{
+			name:     "refs differing only in replaced characters",
+			input:    "a:b/1",
+			expected: "a_b_1_bdfda84c",
+		},
+		{
+			name:     "directory name unchanged",
+			input:    "registry.redhat.io_rhelai1_modelcar-granite_1.0_3d75a818",
+			expected: "registry.redhat.io_rhelai1_modelcar-granite_1.0_3d75a818",
 		},
 	}
 
@@ -134,6 +144,15 @@ func TestSanitizeManifestRef(t *testing.T) {
 	}
 }
 
+func TestLegacySanitizeManifestRef(t *testing.T) {
+	if got := LegacySanitizeManifestRef("registry.redhat.io/rhelai1/modelcar-granite:1.0"); got != "registry.redhat.io_rhelai1_modelcar-granite_1.0" {
+		t.Errorf("LegacySanitizeManifestRef() = %q", got)
+	}
+	if SanitizeManifestRef("a/b:1") == SanitizeManifestRef("a:b/1") {
+		t.Error("Expected refs that differ only in replaced characters to get different directories")
+	}
+}
+
 func TestParseDateToEpoch(t *testing.T) {
 	tests := []struct {
 		name     string
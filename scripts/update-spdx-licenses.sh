@@ -0,0 +1,16 @@
+#!/bin/bash
+# Script to update the SPDX license list embedded in pkg/utils
+
+set -e
+
+# Change to project root
+cd "$(dirname "$0")/.."
+
+SPDX_VERSION="${1:-main}"
+TARGET="pkg/utils/licenses/spdx-licenses.json"
+
+echo "Downloading SPDX license list (${SPDX_VERSION})..."
+curl -fsSL -o "${TARGET}" "https://raw.githubusercontent.com/spdx/license-list-data/${SPDX_VERSION}/json/licenses.json"
+
+echo "SPDX license list updated: ${TARGET}"
+grep -m1 '"licenseListVersion"' "${TARGET}" || true